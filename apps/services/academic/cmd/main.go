@@ -14,11 +14,16 @@ import (
 	_ "github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain" // ensure all models are registered
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/handler"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/middleware"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/queue"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository/migrations"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/router"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/notifier"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"go.uber.org/zap"
@@ -101,11 +106,25 @@ func run() error {
 	defer baseService.Close()
 
 	// Initialize audit client
-	auditClient := client.NewAuditClient(cfg.IAMServiceURL, logger)
+	serviceTokens := servicetoken.NewClient(servicetoken.Config{
+		TokenURL:     cfg.IAMServiceURL + servicetoken.TokenPath,
+		ClientID:     cfg.ServiceClient.ClientID,
+		ClientSecret: cfg.ServiceClient.ClientSecret,
+		Scopes:       []string{servicetoken.ScopeAuditWrite, servicetoken.ScopeAssignmentClone, servicetoken.ScopeScoresRead},
+	})
+	auditClient := client.NewAuditClient(cfg.IAMServiceURL, serviceTokens, logger)
 
 	// Initialize IAM client for user profile lookups
 	iamClient := client.NewIAMClient(cfg.IAMServiceURL)
 
+	// Initialize assessment client for semester rollovers and final grades
+	assessmentClient := client.NewAssessmentClient(cfg.AssessmentServiceURL, serviceTokens)
+
+	// Scoped permission checks (course-level role assignments) are answered by IAM
+	permissionChecker := authz.NewIAMChecker(cfg.IAMServiceURL, 30*time.Second)
+	// Personal access tokens are resolved by IAM and cached for the same window
+	tokenVerifier := pat.NewIAMVerifier(cfg.IAMServiceURL, 30*time.Second)
+
 	// Initialize repositories
 	facultyRepo := repository.NewFacultyRepository(db.DB)
 	leadershipRepo := repository.NewFacultyLeadershipRepository(db.DB)
@@ -137,17 +156,73 @@ func run() error {
 	courseService := service.NewCourseService(courseRepo, auditClient, logger)
 	semesterService := service.NewSemesterService(semesterRepo, auditClient, logger)
 
+	// Advance semesters and their course instances along the calendar.
+	calendarCtx, stopCalendar := context.WithCancel(context.Background())
+	defer stopCalendar()
+	go semesterService.Run(calendarCtx, time.Duration(cfg.Calendar.Interval)*time.Minute)
+
 	// Initialize repositories for enrollment management
 	batchMemberRepo := repository.NewBatchMemberRepository(db.DB)
 	courseInstanceRepo := repository.NewCourseInstanceRepository(db.DB)
 	courseInstructorRepo := repository.NewCourseInstructorRepository(db.DB)
 	enrollmentRepo := repository.NewEnrollmentRepository(db.DB)
+	gradingSchemeRepo := repository.NewGradingSchemeRepository(db.DB)
+	degreeRequirementRepo := repository.NewDegreeRequirementRepository(db.DB)
+	userDataRepo := repository.NewUserDataRepository(db.DB)
+	structureRepo := repository.NewStructureRepository(db.DB)
+	sectionRepo := repository.NewSectionRepository(db.DB)
+	attendanceRepo := repository.NewAttendanceRepository(db.DB)
+	approvalRepo := repository.NewApprovalRepository(db.DB)
+	historyRepo := repository.NewHistoryRepository(db.DB)
 
 	// Initialize services for enrollment management
-	enrollmentService := service.NewEnrollmentService(courseInstanceRepo, batchMemberRepo, enrollmentRepo, auditClient, iamClient, logger)
-	batchMemberService := service.NewBatchMemberService(batchRepo, batchMemberRepo, enrollmentService, auditClient, iamClient, logger)
+	enrollmentService := service.NewEnrollmentService(courseInstanceRepo, courseRepo, semesterRepo, batchMemberRepo, enrollmentRepo, gradingSchemeRepo, auditClient, iamClient, logger)
+	batchMemberService := service.NewBatchMemberService(batchRepo, batchMemberRepo, enrollmentService, auditClient, iamClient, cfg.Enrollment.BatchMovePolicy, logger)
 	courseInstanceService := service.NewCourseInstanceService(batchRepo, courseInstanceRepo, enrollmentService, auditClient, logger)
 	courseInstructorService := service.NewCourseInstructorService(courseInstanceRepo, courseInstructorRepo, auditClient, logger)
+	userDataService := service.NewUserDataService(userDataRepo, auditClient, logger)
+	finalGradeService := service.NewFinalGradeService(courseInstanceRepo, enrollmentRepo, gradingSchemeRepo, assessmentClient, auditClient, logger)
+	transcriptService := service.NewTranscriptService(enrollmentRepo, courseRepo, semesterRepo, iamClient, logger)
+	degreeAuditService := service.NewDegreeAuditService(degreeRequirementRepo, degreeRepo, departmentRepo, specializationRepo, courseRepo, batchRepo, batchMemberRepo, enrollmentRepo, auditClient, logger)
+	structureImportService := service.NewStructureImportService(structureRepo, enrollmentService, auditClient, logger)
+	sectionService := service.NewSectionService(sectionRepo, courseInstanceRepo, courseInstructorRepo, enrollmentRepo, batchRepo, batchMemberRepo, auditClient, logger)
+	attendanceService := service.NewAttendanceService(attendanceRepo, courseInstanceRepo, enrollmentRepo, sectionRepo, auditClient, cfg.Attendance.AtRiskThreshold, time.Duration(cfg.Attendance.CodeRotation)*time.Second, time.Duration(cfg.Attendance.CheckInWindow)*time.Minute, logger)
+	historyService := service.NewHistoryService(historyRepo, courseInstanceRepo, batchRepo, logger)
+	leadershipService := service.NewLeadershipService(leadershipRepo, courseInstanceRepo, enrollmentRepo, approvalRepo, logger)
+	approvalService := service.NewApprovalService(approvalRepo, courseInstanceRepo, enrollmentRepo, semesterRepo, leadershipService, courseInstanceService, enrollmentService, finalGradeService, auditClient, logger)
+	rolloverService := service.NewSemesterRolloverService(semesterRepo, batchRepo, courseInstanceRepo, courseInstructorRepo, enrollmentService, assessmentClient, auditClient, logger)
+
+	// Relay domain events from the transactional outbox to RabbitMQ. Without a
+	// broker URL events are still recorded and can be read or replayed later.
+	outboxRepo := repository.NewOutboxRepository(db.DB)
+	// Approval events also notify the users involved through the
+	// Notification Service's exchange.
+	var eventPublisher service.EventPublisher
+	var notificationPublisher service.NotificationPublisher
+	if cfg.Events.RabbitMQURL != "" {
+		rmq, err := queue.NewRabbitMQ(cfg.Events.RabbitMQURL, logger)
+		if err != nil {
+			return fmt.Errorf("connecting to rabbitmq: %w", err)
+		}
+		defer rmq.Close()
+		go rmq.WatchReconnect()
+		eventPublisher = queue.NewEventPublisher(rmq)
+
+		notifications, err := notifier.NewPublisher(cfg.Events.RabbitMQURL, logger)
+		if err != nil {
+			return fmt.Errorf("connecting notification publisher: %w", err)
+		}
+		defer notifications.Close()
+		go notifications.WatchReconnect()
+		notificationPublisher = notifications
+	} else {
+		logger.Warn("RABBITMQ_URL not set; domain events will not be published")
+	}
+	eventService := service.NewEventService(outboxRepo, eventPublisher, notificationPublisher, auditClient, logger)
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go eventService.Run(relayCtx, time.Duration(cfg.Events.RelayInterval)*time.Second, cfg.Events.RelayBatch)
 
 	// Initialize handlers
 	healthHandler := handler.NewHealthHandler()
@@ -162,10 +237,11 @@ func run() error {
 	courseInstanceHandler := handler.NewCourseInstanceHandler(courseInstanceService, logger)
 	courseInstructorHandler := handler.NewCourseInstructorHandler(courseInstructorService, logger)
 	enrollmentHandler := handler.NewEnrollmentHandler(enrollmentService, logger)
+	userDataHandler := handler.NewUserDataHandler(userDataService, logger)
 
 	// Initialize handlers for course catalog & academic calendar
 	courseHandler := handler.NewCourseHandler(courseService, logger)
-	semesterHandler := handler.NewSemesterHandler(semesterService, logger)
+	semesterHandler := handler.NewSemesterHandler(semesterService, rolloverService, logger)
 
 	// Initialize handler for instructor-scoped endpoints
 	instructorHandler := handler.NewInstructorHandler(courseInstructorService, enrollmentService, courseService, batchService, batchMemberService, iamClient, logger)
@@ -173,6 +249,19 @@ func run() error {
 	// Initialize handler for student-scoped endpoints
 	studentHandler := handler.NewStudentHandler(enrollmentService, courseInstructorService, courseService, semesterService, batchService, batchMemberService, iamClient, logger)
 
+	// Initialize handler for final grades and transcripts
+	gradeHandler := handler.NewGradeHandler(finalGradeService, transcriptService, courseInstructorService, logger)
+
+	// Initialize handler for degree requirements and audits
+	degreeAuditHandler := handler.NewDegreeAuditHandler(degreeAuditService, logger)
+	structureHandler := handler.NewStructureHandler(structureImportService, logger)
+	eventHandler := handler.NewEventHandler(eventService, logger)
+	sectionHandler := handler.NewSectionHandler(sectionService, courseInstructorService, logger)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceService, courseInstructorService, logger)
+	leadershipHandler := handler.NewLeadershipHandler(leadershipService, finalGradeService, logger)
+	approvalHandler := handler.NewApprovalHandler(approvalService, courseInstructorService, logger)
+	historyHandler := handler.NewHistoryHandler(historyService, logger)
+
 	app := fiber.New(fiber.Config{
 		AppName:      "academic-service",
 		ErrorHandler: utils.ErrorHandler,
@@ -199,11 +288,23 @@ func run() error {
 		CourseInstanceHandler:   courseInstanceHandler,
 		CourseInstructorHandler: courseInstructorHandler,
 		EnrollmentHandler:       enrollmentHandler,
+		UserDataHandler:         userDataHandler,
+		SectionHandler:          sectionHandler,
+		AttendanceHandler:       attendanceHandler,
+		LeadershipHandler:       leadershipHandler,
+		ApprovalHandler:         approvalHandler,
+		HistoryHandler:          historyHandler,
 		CourseHandler:           courseHandler,
 		SemesterHandler:         semesterHandler,
 		InstructorHandler:       instructorHandler,
 		StudentHandler:          studentHandler,
+		GradeHandler:            gradeHandler,
+		DegreeAuditHandler:      degreeAuditHandler,
+		StructureHandler:        structureHandler,
+		EventHandler:            eventHandler,
 		JWTSecretKey:            []byte(cfg.JWT.SecretKey),
+		TokenVerifier:           tokenVerifier,
+		PermissionChecker:       permissionChecker,
 	})
 
 	sigChan := make(chan os.Signal, 1)
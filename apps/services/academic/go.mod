@@ -3,13 +3,20 @@ module github.com/4yrg/gradeloop-core-v2/apps/services/academic
 go 1.25.0
 
 require (
+	github.com/4yrg/gradeloop-core-v2/packages/go/authz v0.0.0-00010101000000-000000000000
 	github.com/4yrg/gradeloop-core-v2/packages/go/env v1.0.0
+	github.com/4yrg/gradeloop-core-v2/packages/go/notifier v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/openapi v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/pat v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/queryspec v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken v0.0.0-00010101000000-000000000000
 	github.com/gofiber/fiber/v3 v3.0.0-rc.1
 	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/google/uuid v1.6.0
+	github.com/rabbitmq/amqp091-go v1.10.0
 	github.com/stretchr/testify v1.11.1
-	github.com/swaggo/swag v1.16.6
-	go.uber.org/zap v1.26.0
+	github.com/xuri/excelize/v2 v2.10.1
+	go.uber.org/zap v1.27.0
 	gorm.io/driver/postgres v1.5.4
 	gorm.io/driver/sqlite v1.6.0
 	gorm.io/gorm v1.30.0
@@ -27,20 +34,15 @@ replace github.com/4yrg/gradeloop-core-v2/packages/go/grpc => ../../../packages/
 
 replace github.com/4yrg/gradeloop-core-v2/packages/go/env => ../../../packages/go/env
 
+replace github.com/4yrg/gradeloop-core-v2/packages/go/authz => ../../../packages/go/authz
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken => ../../../packages/go/servicetoken
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/pat => ../../../packages/go/pat
+
 require (
-	github.com/KyleBanks/depth v1.2.1 // indirect
 	github.com/andybalholm/brotli v1.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/go-openapi/jsonpointer v0.22.4 // indirect
-	github.com/go-openapi/jsonreference v0.21.4 // indirect
-	github.com/go-openapi/spec v0.22.3 // indirect
-	github.com/go-openapi/swag/conv v0.25.4 // indirect
-	github.com/go-openapi/swag/jsonname v0.25.4 // indirect
-	github.com/go-openapi/swag/jsonutils v0.25.4 // indirect
-	github.com/go-openapi/swag/loading v0.25.4 // indirect
-	github.com/go-openapi/swag/stringutils v0.25.4 // indirect
-	github.com/go-openapi/swag/typeutils v0.25.4 // indirect
-	github.com/go-openapi/swag/yamlutils v0.25.4 // indirect
 	github.com/gofiber/schema v1.6.0 // indirect
 	github.com/gofiber/utils/v2 v2.0.0-rc.1 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
@@ -55,19 +57,26 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	github.com/philhofer/fwd v1.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/richardlehane/mscfb v1.0.6 // indirect
+	github.com/richardlehane/msoleps v1.0.6 // indirect
 	github.com/rogpeppe/go-internal v1.14.1 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
+	github.com/tiendc/go-deepcopy v1.7.2 // indirect
 	github.com/tinylib/msgp v1.3.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasthttp v1.69.0 // indirect
+	github.com/xuri/efp v0.0.1 // indirect
+	github.com/xuri/nfp v0.0.2-0.20250530014748-2ddeb826f9a9 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
-	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/crypto v0.48.0 // indirect
-	golang.org/x/mod v0.33.0 // indirect
 	golang.org/x/net v0.50.0 // indirect
-	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
 	golang.org/x/text v0.34.0 // indirect
-	golang.org/x/tools v0.42.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/openapi => ../../../packages/go/openapi
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/queryspec => ../../../packages/go/queryspec
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/notifier => ../../../packages/go/notifier
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+)
+
+// CourseInstanceMapping pairs a course instance with its copy in the next
+// term. Target is uuid.Nil on a dry run when the copy does not exist yet.
+type CourseInstanceMapping struct {
+	SourceCourseInstanceID uuid.UUID `json:"source_course_instance_id"`
+	TargetCourseInstanceID uuid.UUID `json:"target_course_instance_id"`
+}
+
+// CloneAssignmentsRequest is sent to the assessment service to copy the
+// assignments of each mapped course instance.
+type CloneAssignmentsRequest struct {
+	Mappings    []CourseInstanceMapping `json:"mappings"`
+	OffsetDays  int                     `json:"offset_days"`
+	DryRun      bool                    `json:"dry_run"`
+	RequestedBy string                  `json:"requested_by"`
+}
+
+// ClonedAssignment describes one assignment copied by the assessment service.
+type ClonedAssignment struct {
+	SourceAssignmentID     uuid.UUID  `json:"source_assignment_id"`
+	TargetAssignmentID     *uuid.UUID `json:"target_assignment_id,omitempty"`
+	SourceCourseInstanceID uuid.UUID  `json:"source_course_instance_id"`
+	TargetCourseInstanceID uuid.UUID  `json:"target_course_instance_id"`
+	Title                  string     `json:"title"`
+	ReleaseAt              *time.Time `json:"release_at,omitempty"`
+	DueAt                  *time.Time `json:"due_at,omitempty"`
+	Skipped                bool       `json:"skipped,omitempty"`
+	// SectionsDropped counts section targets not carried over to the copy.
+	SectionsDropped int `json:"sections_dropped,omitempty"`
+}
+
+// CloneAssignmentsResponse summarises a clone request.
+type CloneAssignmentsResponse struct {
+	DryRun      bool               `json:"dry_run"`
+	Cloned      int                `json:"cloned"`
+	Skipped     int                `json:"skipped"`
+	Assignments []ClonedAssignment `json:"assignments"`
+}
+
+// StudentScore is one student's effective score on an assignment.
+type StudentScore struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Score        float64   `json:"score"`
+	SubmissionID uuid.UUID `json:"submission_id"`
+}
+
+// AssignmentScores lists the effective scores recorded for one assignment;
+// students without a graded submission are omitted.
+type AssignmentScores struct {
+	AssignmentID   uuid.UUID      `json:"assignment_id"`
+	Title          string         `json:"title"`
+	AssessmentType string         `json:"assessment_type"`
+	DueAt          *time.Time     `json:"due_at,omitempty"`
+	Scores         []StudentScore `json:"scores"`
+}
+
+// CourseInstanceScores is the effective score of every student on every
+// active assignment of a course instance.
+type CourseInstanceScores struct {
+	CourseInstanceID uuid.UUID          `json:"course_instance_id"`
+	Assignments      []AssignmentScores `json:"assignments"`
+}
+
+// AssessmentClient handles calls to the assessment service's internal
+// endpoints.
+type AssessmentClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewAssessmentClient creates a new assessment client. Requests are
+// authenticated with service tokens from tokens, which must be granted the
+// assessment.assignments:clone and assessment.scores:read scopes.
+func NewAssessmentClient(baseURL string, tokens *servicetoken.Client) *AssessmentClient {
+	return &AssessmentClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout:   60 * time.Second,
+			Transport: tokens.Transport(nil),
+		},
+	}
+}
+
+// CloneAssignments copies the assignments, rubrics, test cases, sample
+// answers and code configs of each source course instance into its target,
+// shifting release and due dates by req.OffsetDays.
+func (c *AssessmentClient) CloneAssignments(ctx context.Context, req *CloneAssignmentsRequest) (*CloneAssignmentsResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/internal/assignments/clone", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	var result CloneAssignmentsResponse
+	if err := c.do(httpReq, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListCourseInstanceScores returns the effective assignment scores of a
+// course instance, used to compute its final grades.
+func (c *AssessmentClient) ListCourseInstanceScores(ctx context.Context, courseInstanceID uuid.UUID) (*CourseInstanceScores, error) {
+	url := fmt.Sprintf("%s/api/v1/internal/course-instances/%s/scores", c.baseURL, courseInstanceID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	var result CourseInstanceScores
+	if err := c.do(httpReq, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// do sends the request and decodes a 200 response into out.
+func (c *AssessmentClient) do(httpReq *http.Request, out interface{}) error {
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Message != "" {
+			return fmt.Errorf("assessment service error: %s", errResp.Message)
+		}
+		return fmt.Errorf("assessment service returned status %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
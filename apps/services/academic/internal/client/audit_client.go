@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -27,6 +28,11 @@ const (
 	AuditActionDegreeUpdated     AuditAction = "DEGREE_UPDATED"
 	AuditActionDegreeDeactivated AuditAction = "DEGREE_DEACTIVATED"
 
+	// Degree requirement actions
+	AuditActionDegreeRequirementCreated AuditAction = "DEGREE_REQUIREMENT_CREATED"
+	AuditActionDegreeRequirementUpdated AuditAction = "DEGREE_REQUIREMENT_UPDATED"
+	AuditActionDegreeRequirementDeleted AuditAction = "DEGREE_REQUIREMENT_DELETED"
+
 	// Specialization actions
 	AuditActionSpecializationCreated     AuditAction = "SPECIALIZATION_CREATED"
 	AuditActionSpecializationUpdated     AuditAction = "SPECIALIZATION_UPDATED"
@@ -45,13 +51,17 @@ const (
 	AuditActionCoursePrerequisiteRemoved AuditAction = "COURSE_PREREQUISITE_REMOVED"
 
 	// Semester actions
-	AuditActionSemesterCreated     AuditAction = "SEMESTER_CREATED"
-	AuditActionSemesterUpdated     AuditAction = "SEMESTER_UPDATED"
-	AuditActionSemesterDeactivated AuditAction = "SEMESTER_DEACTIVATED"
+	AuditActionSemesterCreated       AuditAction = "SEMESTER_CREATED"
+	AuditActionSemesterUpdated       AuditAction = "SEMESTER_UPDATED"
+	AuditActionSemesterDeactivated   AuditAction = "SEMESTER_DEACTIVATED"
+	AuditActionSemesterStatusChanged AuditAction = "SEMESTER_STATUS_CHANGED"
+	AuditActionSemesterRolledOver    AuditAction = "SEMESTER_ROLLED_OVER"
 
 	// Enrollment management actions
 	AuditActionBatchMemberAdded         AuditAction = "BATCH_MEMBER_ADDED"
 	AuditActionBatchMemberRemoved       AuditAction = "BATCH_MEMBER_REMOVED"
+	AuditActionBatchMemberMoved         AuditAction = "BATCH_MEMBER_MOVED"
+	AuditActionEnrollmentTransferred    AuditAction = "ENROLLMENT_TRANSFERRED"
 	AuditActionCourseInstanceCreated    AuditAction = "COURSE_INSTANCE_CREATED"
 	AuditActionCourseInstanceUpdated    AuditAction = "COURSE_INSTANCE_UPDATED"
 	AuditActionCourseInstructorAssigned AuditAction = "COURSE_INSTRUCTOR_ASSIGNED"
@@ -59,6 +69,51 @@ const (
 	AuditActionStudentEnrolled          AuditAction = "STUDENT_ENROLLED"
 	AuditActionEnrollmentUpdated        AuditAction = "ENROLLMENT_UPDATED"
 	AuditActionEnrollmentRemoved        AuditAction = "ENROLLMENT_REMOVED"
+	AuditActionStudentWaitlisted        AuditAction = "STUDENT_WAITLISTED"
+	AuditActionWaitlistPromoted         AuditAction = "WAITLIST_PROMOTED"
+	AuditActionPrerequisiteOverridden   AuditAction = "PREREQUISITE_OVERRIDDEN"
+
+	// Section and timetable actions
+	AuditActionSectionCreated            AuditAction = "SECTION_CREATED"
+	AuditActionSectionUpdated            AuditAction = "SECTION_UPDATED"
+	AuditActionSectionDeleted            AuditAction = "SECTION_DELETED"
+	AuditActionSectionInstructorAssigned AuditAction = "SECTION_INSTRUCTOR_ASSIGNED"
+	AuditActionSectionInstructorRemoved  AuditAction = "SECTION_INSTRUCTOR_REMOVED"
+	AuditActionSectionSlotAdded          AuditAction = "SECTION_SLOT_ADDED"
+	AuditActionSectionSlotRemoved        AuditAction = "SECTION_SLOT_REMOVED"
+	AuditActionSectionStudentsAssigned   AuditAction = "SECTION_STUDENTS_ASSIGNED"
+	AuditActionSectionStudentRemoved     AuditAction = "SECTION_STUDENT_REMOVED"
+
+	// Attendance actions
+	AuditActionAttendanceSessionCreated AuditAction = "ATTENDANCE_SESSION_CREATED"
+	AuditActionAttendanceSessionUpdated AuditAction = "ATTENDANCE_SESSION_UPDATED"
+	AuditActionAttendanceSessionDeleted AuditAction = "ATTENDANCE_SESSION_DELETED"
+	AuditActionAttendanceMarked         AuditAction = "ATTENDANCE_MARKED"
+	AuditActionAttendanceCheckInOpened  AuditAction = "ATTENDANCE_CHECK_IN_OPENED"
+	AuditActionAttendanceCheckInClosed  AuditAction = "ATTENDANCE_CHECK_IN_CLOSED"
+
+	// Approval actions
+	AuditActionApprovalRequested AuditAction = "APPROVAL_REQUESTED"
+	AuditActionApprovalApproved  AuditAction = "APPROVAL_APPROVED"
+	AuditActionApprovalRejected  AuditAction = "APPROVAL_REJECTED"
+	AuditActionApprovalWithdrawn AuditAction = "APPROVAL_WITHDRAWN"
+
+	// Final grade actions
+	AuditActionGradingSchemeSet     AuditAction = "GRADING_SCHEME_SET"
+	AuditActionFinalGradesComputed  AuditAction = "FINAL_GRADES_COMPUTED"
+	AuditActionFinalGradeOverridden AuditAction = "FINAL_GRADE_OVERRIDDEN"
+	AuditActionFinalGradesLocked    AuditAction = "FINAL_GRADES_LOCKED"
+	AuditActionFinalGradeAmended    AuditAction = "FINAL_GRADE_AMENDED"
+
+	// Domain event actions
+	AuditActionEventsReplayed AuditAction = "EVENTS_REPLAYED"
+
+	// Structure import actions
+	AuditActionStructureImported AuditAction = "STRUCTURE_IMPORTED"
+
+	// User data actions
+	AuditActionUserDataErased   AuditAction = "USER_DATA_ERASED"
+	AuditActionUserDataExported AuditAction = "USER_DATA_EXPORTED"
 )
 
 // AuditLogRequest represents the request body for audit logging
@@ -83,12 +138,14 @@ type AuditClient struct {
 	logger        *zap.Logger
 }
 
-// NewAuditClient creates a new audit client
-func NewAuditClient(iamServiceURL string, logger *zap.Logger) *AuditClient {
+// NewAuditClient creates a new audit client. Requests are authenticated with
+// service tokens from tokens, which must be granted the iam.audit:write scope.
+func NewAuditClient(iamServiceURL string, tokens *servicetoken.Client, logger *zap.Logger) *AuditClient {
 	return &AuditClient{
 		iamServiceURL: iamServiceURL,
 		httpClient: &http.Client{
-			Timeout: 5 * time.Second,
+			Timeout:   5 * time.Second,
+			Transport: tokens.Transport(nil),
 		},
 		logger: logger,
 	}
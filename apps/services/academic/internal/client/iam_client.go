@@ -24,8 +24,9 @@ type ValidateTokenRequest struct {
 // ValidateTokenResponse represents the response from token validation
 type ValidateTokenResponse struct {
 	Valid       bool     `json:"valid"`
-	UserID      uint     `json:"user_id"`
+	UserID      string   `json:"user_id"`
 	Email       string   `json:"email"`
+	UserType    string   `json:"user_type"`
 	Roles       []string `json:"roles"`
 	Permissions []string `json:"permissions"`
 }
@@ -5,17 +5,24 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/packages/go/env"
 )
 
 // Config holds all configuration for the Academic service.
 type Config struct {
-	Server          ServerConfig
-	Database        DatabaseConfig
-	JWT             JWTConfig
-	FrontendURL     string
-	EmailServiceURL string
-	IAMServiceURL   string
+	Server               ServerConfig
+	Database             DatabaseConfig
+	JWT                  JWTConfig
+	FrontendURL          string
+	EmailServiceURL      string
+	IAMServiceURL        string
+	AssessmentServiceURL string
+	ServiceClient        ServiceClientConfig
+	Calendar             CalendarConfig
+	Events               EventsConfig
+	Enrollment           EnrollmentConfig
+	Attendance           AttendanceConfig
 }
 
 // ServerConfig holds server-related configuration.
@@ -39,6 +46,43 @@ type JWTConfig struct {
 	SecretKey string
 }
 
+// ServiceClientConfig holds the credentials this service uses to obtain
+// service tokens from IAM for service-to-service calls.
+type ServiceClientConfig struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// CalendarConfig holds the academic calendar scheduler settings.
+type CalendarConfig struct {
+	Interval int64 // in minutes; 0 disables the scheduler
+}
+
+// EventsConfig holds the outbox relay settings. Without a RabbitMQ URL the
+// relay is off: events still accumulate in the outbox and can be read
+// through the replay API, and are published once a broker is configured.
+type EventsConfig struct {
+	RabbitMQURL   string
+	RelayInterval int64 // in seconds
+	RelayBatch    int
+}
+
+// EnrollmentConfig holds enrollment policy settings.
+type EnrollmentConfig struct {
+	// BatchMovePolicy is the default policy applied to a student's
+	// enrollments when they move between batches: transfer, drop, or keep.
+	BatchMovePolicy string
+}
+
+// AttendanceConfig holds attendance tracking settings.
+type AttendanceConfig struct {
+	// AtRiskThreshold is the attendance rate, in percent, below which a
+	// student is flagged as at risk unless the course instance overrides it.
+	AtRiskThreshold float64
+	CodeRotation    int64 // check-in code lifetime, in seconds
+	CheckInWindow   int64 // default check-in window, in minutes
+}
+
 // Load reads configuration from environment variables.
 func Load() (*Config, error) {
 	env.Load()
@@ -46,6 +90,11 @@ func Load() (*Config, error) {
 	dbPort := getEnv("GRA_DB_PORT", "5432")
 	dbSSLMode := getEnv("GRA_DB_SSLMODE", "disable")
 
+	batchMovePolicy := getEnv("BATCH_MOVE_POLICY", domain.BatchMovePolicyTransfer)
+	if !domain.IsValidBatchMovePolicy(batchMovePolicy) {
+		return nil, fmt.Errorf("invalid BATCH_MOVE_POLICY %q: allowed values are transfer, drop, keep", batchMovePolicy)
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port:          getEnv("ACADEMIC_SVC_PORT", "8083"),
@@ -62,9 +111,30 @@ func Load() (*Config, error) {
 		JWT: JWTConfig{
 			SecretKey: getEnv("JWT_SECRET_KEY", ""),
 		},
-		FrontendURL:     getEnv("FRONTEND_URL", "http://localhost:3000"),
-		EmailServiceURL: getEnv("EMAIL_SERVICE_URL", "http://localhost:8082"),
-		IAMServiceURL:   getEnv("IAM_SERVICE_URL", "http://localhost:8081"),
+		FrontendURL:          getEnv("FRONTEND_URL", "http://localhost:3000"),
+		EmailServiceURL:      getEnv("EMAIL_SERVICE_URL", "http://localhost:8082"),
+		IAMServiceURL:        getEnv("IAM_SERVICE_URL", "http://localhost:8081"),
+		AssessmentServiceURL: getEnv("ASSESSMENT_SERVICE_URL", "http://localhost:8084"),
+		ServiceClient: ServiceClientConfig{
+			ClientID:     getEnv("SERVICE_CLIENT_ID", "academic-service"),
+			ClientSecret: getEnv("SERVICE_CLIENT_SECRET", ""),
+		},
+		Calendar: CalendarConfig{
+			Interval: getEnvAsInt64("CALENDAR_INTERVAL", 60),
+		},
+		Events: EventsConfig{
+			RabbitMQURL:   getEnv("RABBITMQ_URL", ""),
+			RelayInterval: getEnvAsInt64("OUTBOX_RELAY_INTERVAL", 2),
+			RelayBatch:    int(getEnvAsInt64("OUTBOX_RELAY_BATCH", 100)),
+		},
+		Enrollment: EnrollmentConfig{
+			BatchMovePolicy: batchMovePolicy,
+		},
+		Attendance: AttendanceConfig{
+			AtRiskThreshold: float64(getEnvAsInt64("ATTENDANCE_AT_RISK_THRESHOLD", 75)),
+			CodeRotation:    getEnvAsInt64("ATTENDANCE_CODE_ROTATION", 30),
+			CheckInWindow:   getEnvAsInt64("ATTENDANCE_CHECK_IN_WINDOW", 15),
+		},
 	}, nil
 }
 
@@ -99,3 +169,15 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return result
 }
+
+func getEnvAsInt64(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApprovalRequest asks the leadership of a faculty to allow a sensitive
+// change to one of its course instances. The change is described by Kind and
+// Payload and is only carried out when the request is approved; the decision
+// can be taken by any active leader of FacultyID or by an admin, but never by
+// the requester.
+type ApprovalRequest struct {
+	ID               uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	FacultyID        uuid.UUID       `gorm:"type:uuid;not null;index"                       json:"faculty_id"`
+	CourseInstanceID uuid.UUID       `gorm:"type:uuid;not null;index"                       json:"course_instance_id"`
+	Kind             string          `gorm:"type:varchar(50);not null"                      json:"kind"`
+	Payload          json.RawMessage `gorm:"type:jsonb;not null"                            json:"payload"`
+	Reason           string          `gorm:"type:text;not null"                             json:"reason"`
+	Status           string          `gorm:"type:varchar(20);not null;index"                json:"status"`
+
+	RequestedByID uuid.UUID `gorm:"type:uuid;not null;index" json:"requested_by_id"`
+	RequestedBy   string    `gorm:"type:varchar(255)"        json:"requested_by"`
+
+	DecidedByID  *uuid.UUID `gorm:"type:uuid"         json:"decided_by_id,omitempty"`
+	DecidedBy    string     `gorm:"type:varchar(255)" json:"decided_by,omitempty"`
+	DecisionNote string     `gorm:"type:text"         json:"decision_note,omitempty"`
+	DecidedAt    *time.Time `json:"decided_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DB FK — requests go with their course instance
+	CourseInstance *CourseInstance `gorm:"foreignKey:CourseInstanceID;constraint:OnDelete:CASCADE" json:"course_instance,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (ApprovalRequest) TableName() string {
+	return "approval_requests"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (a *ApprovalRequest) BeforeCreate(_ *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// Operations that need approval.
+const (
+	// ApprovalKindGradeChange changes a final grade after the grades of the
+	// course instance were locked.
+	ApprovalKindGradeChange = "final_grade_change"
+	// ApprovalKindCancellation cancels a course instance.
+	ApprovalKindCancellation = "course_instance_cancellation"
+	// ApprovalKindEnrollmentOverride adds or drops a student after the
+	// semester's add/drop deadline.
+	ApprovalKindEnrollmentOverride = "enrollment_override"
+)
+
+// IsValidApprovalKind reports whether k is one of the accepted values.
+func IsValidApprovalKind(k string) bool {
+	switch k {
+	case ApprovalKindGradeChange, ApprovalKindCancellation, ApprovalKindEnrollmentOverride:
+		return true
+	}
+	return false
+}
+
+// Approval request statuses. Only pending requests can be decided or
+// withdrawn.
+const (
+	ApprovalStatusPending   = "pending"
+	ApprovalStatusApproved  = "approved"
+	ApprovalStatusRejected  = "rejected"
+	ApprovalStatusWithdrawn = "withdrawn"
+)
+
+// IsValidApprovalStatus reports whether s is one of the accepted values.
+func IsValidApprovalStatus(s string) bool {
+	switch s {
+	case ApprovalStatusPending, ApprovalStatusApproved, ApprovalStatusRejected, ApprovalStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// GradeChangePayload is the payload of a final_grade_change request.
+type GradeChangePayload struct {
+	UserID        uuid.UUID `json:"user_id"`
+	FinalGrade    string    `json:"final_grade"`
+	PreviousGrade string    `json:"previous_grade"`
+}
+
+// EnrollmentOverridePayload is the payload of an enrollment_override
+// request. Status is Enrolled to add the student or Dropped to drop them.
+type EnrollmentOverridePayload struct {
+	UserID          uuid.UUID `json:"user_id"`
+	Status          string    `json:"status"`
+	AllowIndividual bool      `json:"allow_individual,omitempty"`
+}
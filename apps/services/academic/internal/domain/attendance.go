@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttendanceSession is one class meeting of a course instance for which
+// attendance is taken. A session with a SectionID is meant for the members
+// of that section or lab group only.
+//
+// While self check-in is open (CheckInOpenUntil in the future), students can
+// mark themselves present with a short code derived from CheckInSecret that
+// rotates every few seconds. The secret never leaves the service.
+type AttendanceSession struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	CourseInstanceID uuid.UUID  `gorm:"type:uuid;not null;index" json:"course_instance_id"`
+	SectionID        *uuid.UUID `gorm:"type:uuid;index" json:"section_id,omitempty"`
+	Date             time.Time  `gorm:"type:date;not null" json:"date"`
+	Type             string     `gorm:"type:varchar(20);not null" json:"type"`
+	Topic            string     `gorm:"type:varchar(255)" json:"topic"`
+
+	CheckInSecret    string     `gorm:"type:varchar(64)" json:"-"`
+	CheckInOpenUntil *time.Time `json:"check_in_open_until,omitempty"`
+
+	CreatedBy string    `gorm:"type:varchar(255)" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DB FK — sessions go with their course instance
+	CourseInstance *CourseInstance `gorm:"foreignKey:CourseInstanceID;constraint:OnDelete:CASCADE" json:"course_instance,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (AttendanceSession) TableName() string {
+	return "attendance_sessions"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (s *AttendanceSession) BeforeCreate(_ *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// CheckInOpen reports whether students can self-check-in at the given time.
+func (s *AttendanceSession) CheckInOpen(now time.Time) bool {
+	return s.CheckInSecret != "" && s.CheckInOpenUntil != nil && now.Before(*s.CheckInOpenUntil)
+}
+
+// Allowed session types.
+const (
+	AttendanceSessionLecture  = "lecture"
+	AttendanceSessionLab      = "lab"
+	AttendanceSessionTutorial = "tutorial"
+)
+
+// IsValidAttendanceSessionType reports whether t is one of the accepted values.
+func IsValidAttendanceSessionType(t string) bool {
+	switch t {
+	case AttendanceSessionLecture, AttendanceSessionLab, AttendanceSessionTutorial:
+		return true
+	}
+	return false
+}
+
+// AttendanceRecord is one student's attendance at a session. user_id is a
+// logical reference to the IAM service. MarkedBy is the username of the staff
+// member who entered it, or empty for a self check-in.
+type AttendanceRecord struct {
+	SessionID uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"session_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;not null;index" json:"user_id"`
+	Status    string    `gorm:"type:varchar(20);not null" json:"status"`
+	Source    string    `gorm:"type:varchar(20);not null" json:"source"`
+	Note      string    `gorm:"type:text" json:"note,omitempty"`
+	MarkedBy  string    `gorm:"type:varchar(255)" json:"marked_by,omitempty"`
+	MarkedAt  time.Time `json:"marked_at"`
+
+	// DB FK — records go with their session
+	Session *AttendanceSession `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE" json:"session,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (AttendanceRecord) TableName() string {
+	return "attendance_records"
+}
+
+// Allowed attendance statuses. Late counts as attended; excused sessions are
+// left out of a student's attendance rate.
+const (
+	AttendanceStatusPresent = "present"
+	AttendanceStatusAbsent  = "absent"
+	AttendanceStatusLate    = "late"
+	AttendanceStatusExcused = "excused"
+)
+
+// IsValidAttendanceStatus reports whether s is one of the accepted values.
+func IsValidAttendanceStatus(s string) bool {
+	switch s {
+	case AttendanceStatusPresent, AttendanceStatusAbsent, AttendanceStatusLate, AttendanceStatusExcused:
+		return true
+	}
+	return false
+}
+
+// How an attendance record was entered.
+const (
+	AttendanceSourceStaff   = "staff"
+	AttendanceSourceCheckIn = "check_in"
+)
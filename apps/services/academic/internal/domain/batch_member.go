@@ -53,3 +53,24 @@ func IsValidBatchMemberStatus(s string) bool {
 	_, ok := ValidBatchMemberStatuses[s]
 	return ok
 }
+
+// Policies for enrollments in courses of the source batch that the target
+// batch does not share when a student moves between batches.
+//   - transfer: move each enrollment to the target batch's instance of the
+//     same course in the same semester, dropping those without one.
+//   - drop: drop them all.
+//   - keep: leave them as they are.
+const (
+	BatchMovePolicyTransfer = "transfer"
+	BatchMovePolicyDrop     = "drop"
+	BatchMovePolicyKeep     = "keep"
+)
+
+// IsValidBatchMovePolicy reports whether p is one of the accepted policies.
+func IsValidBatchMovePolicy(p string) bool {
+	switch p {
+	case BatchMovePolicyTransfer, BatchMovePolicyDrop, BatchMovePolicyKeep:
+		return true
+	}
+	return false
+}
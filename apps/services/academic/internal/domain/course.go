@@ -36,17 +36,35 @@ func (c *Course) BeforeCreate(_ *gorm.DB) error {
 	return nil
 }
 
-// CoursePrerequisite represents a prerequisite relationship between two courses.
-// A course can have multiple prerequisites; this is a self-referential join table.
+// CoursePrerequisite represents a requirement a student must meet before
+// enrolling in a course. Rules sharing a non-zero Group are alternatives (OR);
+// separate groups, and every rule left in group 0, must all be met (AND).
+// A prerequisite needs a Completed enrollment in the required course, with at
+// least MinGrade when set. A co-requisite is also met by enrolling in the
+// required course in the same semester.
 type CoursePrerequisite struct {
 	CourseID             uuid.UUID `gorm:"type:uuid;primaryKey;not null"                                                    json:"course_id"`
 	PrerequisiteCourseID uuid.UUID `gorm:"type:uuid;primaryKey;not null"                                                    json:"prerequisite_course_id"`
+	Kind                 string    `gorm:"type:varchar(20);not null;default:'prerequisite'"                                 json:"kind"`
+	Group                int       `gorm:"column:group_no;not null;default:0"                                               json:"group"`
+	MinGrade             string    `gorm:"type:varchar(10)"                                                                 json:"min_grade,omitempty"`
 
 	// DB FKs — both courses must exist and not be soft-deleted
 	Course             *Course `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"             json:"course,omitempty"`
 	PrerequisiteCourse *Course `gorm:"foreignKey:PrerequisiteCourseID;constraint:OnDelete:CASCADE" json:"prerequisite_course,omitempty"`
 }
 
+// Allowed values for CoursePrerequisite.Kind.
+const (
+	PrerequisiteKindPrerequisite = "prerequisite"
+	PrerequisiteKindCorequisite  = "corequisite"
+)
+
+// IsValidPrerequisiteKind reports whether k is one of the accepted values.
+func IsValidPrerequisiteKind(k string) bool {
+	return k == PrerequisiteKindPrerequisite || k == PrerequisiteKindCorequisite
+}
+
 // TableName overrides the GORM default table name.
 func (CoursePrerequisite) TableName() string {
 	return "course_prerequisites"
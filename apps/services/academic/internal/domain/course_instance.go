@@ -10,6 +10,11 @@ import (
 // CourseInstance represents a course offered to a specific batch in a specific
 // semester. course_id and semester_id are logical references to the Course
 // Catalog and Academic Calendar services — no DB foreign keys for those.
+// MaxEnrollment caps the seat-taking enrollments; 0 means unlimited.
+// With IncludeSubBatches set, members of every descendant batch are enrolled
+// as well, so one instance can serve a whole intake. AttendanceThreshold
+// overrides the service-wide attendance percentage below which students are
+// flagged as at risk.
 type CourseInstance struct {
 	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 	CourseID      uuid.UUID `gorm:"type:uuid;not null"                             json:"course_id"`
@@ -18,6 +23,10 @@ type CourseInstance struct {
 	Status        string    `gorm:"type:varchar(50);not null;default:'Planned'"    json:"status"`
 	MaxEnrollment int       `gorm:"not null;default:0"                             json:"max_enrollment"`
 
+	IncludeSubBatches bool `gorm:"not null;default:false" json:"include_sub_batches"`
+
+	AttendanceThreshold *float64 `gorm:"type:numeric(5,2)" json:"attendance_threshold,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
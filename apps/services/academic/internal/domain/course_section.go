@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CourseSection is a lecture section or lab group of a course instance.
+// Students belong to at most one section of each kind per course instance.
+// Capacity caps the members; 0 means unlimited. BatchID optionally names the
+// batch (typically a child of the instance's batch) whose members the
+// by-batch assignment strategy places in this section.
+type CourseSection struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	CourseInstanceID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_course_sections_instance_name" json:"course_instance_id"`
+	Kind             string     `gorm:"type:varchar(20);not null" json:"kind"`
+	Name             string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_course_sections_instance_name" json:"name"`
+	Capacity         int        `gorm:"not null;default:0" json:"capacity"`
+	BatchID          *uuid.UUID `gorm:"type:uuid" json:"batch_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DB FK — sections go with their course instance
+	CourseInstance *CourseInstance `gorm:"foreignKey:CourseInstanceID;constraint:OnDelete:CASCADE" json:"course_instance,omitempty"`
+
+	Instructors []SectionInstructor `gorm:"foreignKey:SectionID" json:"instructors,omitempty"`
+	Slots       []SectionSlot       `gorm:"foreignKey:SectionID" json:"slots,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (CourseSection) TableName() string {
+	return "course_sections"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (cs *CourseSection) BeforeCreate(_ *gorm.DB) error {
+	if cs.ID == uuid.Nil {
+		cs.ID = uuid.New()
+	}
+	return nil
+}
+
+// Allowed section kinds.
+const (
+	SectionKindLecture = "lecture"
+	SectionKindLab     = "lab"
+)
+
+// IsValidSectionKind reports whether k is one of the accepted values.
+func IsValidSectionKind(k string) bool {
+	return k == SectionKindLecture || k == SectionKindLab
+}
+
+// SectionMember places a student in a section. CourseInstanceID and Kind are
+// copied from the section so that one section per kind can be enforced by a
+// unique index. user_id is a logical reference to the IAM service.
+type SectionMember struct {
+	SectionID        uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"section_id"`
+	UserID           uuid.UUID `gorm:"type:uuid;primaryKey;not null;uniqueIndex:idx_section_members_instance_kind_user" json:"user_id"`
+	CourseInstanceID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_section_members_instance_kind_user" json:"course_instance_id"`
+	Kind             string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_section_members_instance_kind_user" json:"kind"`
+	AssignedAt       time.Time `gorm:"autoCreateTime" json:"assigned_at"`
+
+	// DB FK — memberships go with their section
+	Section *CourseSection `gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE" json:"section,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (SectionMember) TableName() string {
+	return "section_members"
+}
+
+// SectionInstructor assigns one of the course instance's instructors or TAs
+// to a section. Role takes the CourseInstructor role values.
+type SectionInstructor struct {
+	SectionID uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"section_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"user_id"`
+	Role      string    `gorm:"type:varchar(50);not null" json:"role"`
+
+	// DB FK — assignments go with their section
+	Section *CourseSection `gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the GORM default.
+func (SectionInstructor) TableName() string {
+	return "section_instructors"
+}
+
+// SectionSlot is a weekly timetable slot of a section. DayOfWeek runs from 1
+// (Monday) to 7 (Sunday); StartTime and EndTime are "HH:MM" in the
+// institution's local time.
+type SectionSlot struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SectionID uuid.UUID `gorm:"type:uuid;not null;index" json:"section_id"`
+	DayOfWeek int       `gorm:"not null" json:"day_of_week"`
+	StartTime string    `gorm:"type:varchar(5);not null" json:"start_time"`
+	EndTime   string    `gorm:"type:varchar(5);not null" json:"end_time"`
+	Room      string    `gorm:"type:varchar(100)" json:"room"`
+
+	// DB FK — slots go with their section
+	Section *CourseSection `gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the GORM default.
+func (SectionSlot) TableName() string {
+	return "section_slots"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (ss *SectionSlot) BeforeCreate(_ *gorm.DB) error {
+	if ss.ID == uuid.Nil {
+		ss.ID = uuid.New()
+	}
+	return nil
+}
+
+// Strategies for assigning enrolled students to sections of one kind.
+//   - manual: place the given students in the given section, moving them
+//     out of any other section of the same kind.
+//   - round_robin: spread unassigned students over the sections, filling
+//     the emptiest first.
+//   - batch: place unassigned students in the section whose batch they
+//     belong to, directly or through a descendant batch.
+const (
+	SectionStrategyManual     = "manual"
+	SectionStrategyRoundRobin = "round_robin"
+	SectionStrategyBatch      = "batch"
+)
+
+// IsValidSectionStrategy reports whether s is one of the accepted values.
+func IsValidSectionStrategy(s string) bool {
+	switch s {
+	case SectionStrategyManual, SectionStrategyRoundRobin, SectionStrategyBatch:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DegreeRequirement is one rule a student must satisfy to complete a degree.
+// Rules with a SpecializationID apply only to students of that
+// specialization; the others apply to every student of the degree.
+//
+//   - required_courses: every listed course must be completed.
+//   - elective_pool: at least MinCredits must be earned from the listed
+//     courses. A course counts toward only one pool and never toward a pool
+//     when it is already used by a required_courses rule.
+//   - total_credits: at least MinCredits must be earned across all courses.
+//
+// MinGrade, when set, is the lowest letter grade that counts as completing a
+// course under the rule.
+type DegreeRequirement struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	DegreeID         uuid.UUID  `gorm:"type:uuid;not null;index"                       json:"degree_id"`
+	SpecializationID *uuid.UUID `gorm:"type:uuid;index"                                json:"specialization_id,omitempty"`
+	Kind             string     `gorm:"type:varchar(30);not null"                      json:"kind"`
+	Name             string     `gorm:"type:varchar(255);not null"                     json:"name"`
+	MinCredits       int        `gorm:"not null;default:0"                             json:"min_credits"`
+	MinGrade         string     `gorm:"type:varchar(10)"                               json:"min_grade,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	Courses []DegreeRequirementCourse `gorm:"foreignKey:RequirementID;constraint:OnDelete:CASCADE" json:"courses,omitempty"`
+
+	// DB FKs — degree and specialization must exist
+	Degree         *Degree         `gorm:"foreignKey:DegreeID;constraint:OnDelete:CASCADE"         json:"degree,omitempty"`
+	Specialization *Specialization `gorm:"foreignKey:SpecializationID;constraint:OnDelete:CASCADE" json:"specialization,omitempty"`
+}
+
+// Allowed values for DegreeRequirement.Kind.
+const (
+	RequirementKindRequiredCourses = "required_courses"
+	RequirementKindElectivePool    = "elective_pool"
+	RequirementKindTotalCredits    = "total_credits"
+)
+
+// IsValidRequirementKind reports whether k is one of the accepted values.
+func IsValidRequirementKind(k string) bool {
+	switch k {
+	case RequirementKindRequiredCourses, RequirementKindElectivePool, RequirementKindTotalCredits:
+		return true
+	}
+	return false
+}
+
+// TableName overrides the GORM default table name.
+func (DegreeRequirement) TableName() string {
+	return "degree_requirements"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (r *DegreeRequirement) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// DegreeRequirementCourse lists a course under a required_courses or
+// elective_pool rule.
+type DegreeRequirementCourse struct {
+	RequirementID uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"-"`
+	CourseID      uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"course_id"`
+
+	// DB FK — course must exist
+	Course *Course `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"course,omitempty"`
+}
+
+// TableName overrides the GORM default table name.
+func (DegreeRequirementCourse) TableName() string {
+	return "degree_requirement_courses"
+}
@@ -9,11 +9,20 @@ import (
 
 // Enrollment represents a student's enrollment in a course instance.
 // user_id is a logical reference to the IAM service — no DB foreign key.
+// WaitlistPosition is set only while Status is Waitlisted; position 1 is the
+// next student to be promoted when a seat frees up.
+// FinalScore and GradePoints are filled in when final grades are computed
+// from the course instance's grading scheme; GradeOverridden marks a letter
+// grade set by an instructor during review.
 type Enrollment struct {
 	CourseInstanceID uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"course_instance_id"`
 	UserID           uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"user_id"`
 	Status           string    `gorm:"type:varchar(50)"              json:"status"`
 	FinalGrade       string    `gorm:"type:varchar(10)"              json:"final_grade,omitempty"`
+	FinalScore       *float64  `gorm:"type:numeric(5,2)"             json:"final_score,omitempty"`
+	GradePoints      *float64  `gorm:"type:numeric(4,2)"             json:"grade_points,omitempty"`
+	GradeOverridden  bool      `gorm:"not null;default:false"        json:"grade_overridden"`
+	WaitlistPosition *int      `gorm:"type:integer"                  json:"waitlist_position,omitempty"`
 	EnrolledAt       time.Time `gorm:"autoCreateTime"                json:"enrolled_at"`
 
 	// DB FK — course instance must exist
@@ -39,14 +48,18 @@ const (
 	EnrollmentStatusDropped   = "Dropped"
 	EnrollmentStatusCompleted = "Completed"
 	EnrollmentStatusFailed    = "Failed"
+	// EnrollmentStatusWaitlisted is assigned automatically when a student is
+	// enrolled into a full course instance.
+	EnrollmentStatusWaitlisted = "Waitlisted"
 )
 
 // ValidEnrollmentStatuses is the set of accepted status strings.
 var ValidEnrollmentStatuses = map[string]struct{}{
-	EnrollmentStatusEnrolled:  {},
-	EnrollmentStatusDropped:   {},
-	EnrollmentStatusCompleted: {},
-	EnrollmentStatusFailed:    {},
+	EnrollmentStatusEnrolled:   {},
+	EnrollmentStatusDropped:    {},
+	EnrollmentStatusCompleted:  {},
+	EnrollmentStatusFailed:     {},
+	EnrollmentStatusWaitlisted: {},
 }
 
 // IsValidEnrollmentStatus reports whether s is one of the accepted values.
@@ -54,3 +67,9 @@ func IsValidEnrollmentStatus(s string) bool {
 	_, ok := ValidEnrollmentStatuses[s]
 	return ok
 }
+
+// OccupiesSeat reports whether an enrollment with status s counts against the
+// course instance's MaxEnrollment. Dropped and waitlisted students do not.
+func OccupiesSeat(s string) bool {
+	return s != EnrollmentStatusDropped && s != EnrollmentStatusWaitlisted
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "strings"
+
+// letterGradeRanks orders the letter grades recorded in Enrollment.FinalGrade,
+// from lowest to highest.
+var letterGradeRanks = map[string]int{
+	"F":  0,
+	"E":  1,
+	"D":  2,
+	"D+": 3,
+	"C-": 4,
+	"C":  5,
+	"C+": 6,
+	"B-": 7,
+	"B":  8,
+	"B+": 9,
+	"A-": 10,
+	"A":  11,
+	"A+": 12,
+}
+
+// IsValidLetterGrade reports whether g is a recognised letter grade.
+func IsValidLetterGrade(g string) bool {
+	_, ok := letterGradeRanks[strings.ToUpper(strings.TrimSpace(g))]
+	return ok
+}
+
+// MeetsMinimumGrade reports whether grade is at least min. An empty min is
+// always met; a grade that is missing or not a letter grade never meets a
+// non-empty min.
+func MeetsMinimumGrade(grade, min string) bool {
+	if min == "" {
+		return true
+	}
+	g, ok := letterGradeRanks[strings.ToUpper(strings.TrimSpace(grade))]
+	if !ok {
+		return false
+	}
+	m, ok := letterGradeRanks[strings.ToUpper(strings.TrimSpace(min))]
+	if !ok {
+		return false
+	}
+	return g >= m
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GradingScheme defines how the final grades of a course instance are
+// computed. Each category weights the assignments whose assessment type
+// matches its name; the bands map the weighted percentage to a letter grade
+// and grade points. Once LockedAt is set the final grades are released and
+// neither the scheme nor the grades can change.
+type GradingScheme struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	CourseInstanceID uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null"                 json:"course_instance_id"`
+	LockedAt         *time.Time `json:"locked_at,omitempty"`
+	LockedBy         string     `gorm:"type:varchar(255)"                              json:"locked_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	Categories []GradingCategory `gorm:"foreignKey:SchemeID;constraint:OnDelete:CASCADE" json:"categories,omitempty"`
+	Bands      []GradeBand       `gorm:"foreignKey:SchemeID;constraint:OnDelete:CASCADE" json:"bands,omitempty"`
+
+	// DB FK — course instance must exist
+	CourseInstance *CourseInstance `gorm:"foreignKey:CourseInstanceID;constraint:OnDelete:CASCADE" json:"course_instance,omitempty"`
+}
+
+// TableName overrides the GORM default table name.
+func (GradingScheme) TableName() string {
+	return "grading_schemes"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (g *GradingScheme) BeforeCreate(_ *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsLocked reports whether the final grades have been released.
+func (g *GradingScheme) IsLocked() bool {
+	return g.LockedAt != nil
+}
+
+// GradingCategory weights one assignment category, e.g. "lab" or "exam".
+// Name matches the assessment type of assignments in the Assessment Service.
+type GradingCategory struct {
+	SchemeID uuid.UUID `gorm:"type:uuid;primaryKey;not null"   json:"-"`
+	Name     string    `gorm:"type:varchar(50);primaryKey"     json:"name"`
+	Weight   float64   `gorm:"type:numeric(5,2);not null"      json:"weight"`
+}
+
+// TableName overrides the GORM default table name.
+func (GradingCategory) TableName() string {
+	return "grading_categories"
+}
+
+// GradeBand awards Letter and GradePoints to final percentages of at least
+// MinPercent, up to the next band's cut-off.
+type GradeBand struct {
+	SchemeID    uuid.UUID `gorm:"type:uuid;primaryKey;not null" json:"-"`
+	Letter      string    `gorm:"type:varchar(10);primaryKey"   json:"letter"`
+	MinPercent  float64   `gorm:"type:numeric(5,2);not null"    json:"min_percent"`
+	GradePoints float64   `gorm:"type:numeric(4,2);not null"    json:"grade_points"`
+}
+
+// TableName overrides the GORM default table name.
+func (GradeBand) TableName() string {
+	return "grade_bands"
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EnrollmentHistory is one version of a student's enrollment in a course
+// instance. A version is valid from ValidFrom up to, but not including,
+// ValidTo; the current version has no ValidTo. Enrollments are updated and
+// deleted in place, so these rows are the only record of who was enrolled
+// at a given time. ChangedBy is the user whose change opened the version and
+// EndedBy the one whose change closed it.
+type EnrollmentHistory struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	CourseInstanceID uuid.UUID  `gorm:"type:uuid;not null;index:idx_enrollment_history_key" json:"course_instance_id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index:idx_enrollment_history_key;index" json:"user_id"`
+	Status           string     `gorm:"type:varchar(50);not null"                      json:"status"`
+	ValidFrom        time.Time  `gorm:"not null"                                       json:"valid_from"`
+	ValidTo          *time.Time `json:"valid_to,omitempty"`
+	ChangedBy        string     `gorm:"type:varchar(255)"                              json:"changed_by,omitempty"`
+	EndedBy          string     `gorm:"type:varchar(255)"                              json:"ended_by,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (EnrollmentHistory) TableName() string {
+	return "enrollment_history"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (h *EnrollmentHistory) BeforeCreate(_ *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
+
+// BatchMemberHistory is one version of a student's membership in a batch,
+// with the same validity rules as EnrollmentHistory.
+type BatchMemberHistory struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	BatchID   uuid.UUID  `gorm:"type:uuid;not null;index:idx_batch_member_history_key" json:"batch_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_batch_member_history_key;index" json:"user_id"`
+	Status    string     `gorm:"type:varchar(50);not null"                      json:"status"`
+	ValidFrom time.Time  `gorm:"not null"                                       json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	ChangedBy string     `gorm:"type:varchar(255)"                              json:"changed_by,omitempty"`
+	EndedBy   string     `gorm:"type:varchar(255)"                              json:"ended_by,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (BatchMemberHistory) TableName() string {
+	return "batch_member_history"
+}
+
+// BeforeCreate generates a UUID when none is provided.
+func (h *BatchMemberHistory) BeforeCreate(_ *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
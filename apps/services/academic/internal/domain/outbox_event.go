@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the
+// change it describes and relayed to the message broker afterwards.
+// Sequence orders events globally, and therefore within each aggregate;
+// PublishedAt stays nil until the broker has confirmed the event.
+type OutboxEvent struct {
+	Sequence      int64           `gorm:"primaryKey;autoIncrement"                         json:"sequence"`
+	ID            uuid.UUID       `gorm:"type:uuid;uniqueIndex;not null"                   json:"id"`
+	AggregateType string          `gorm:"type:varchar(50);not null;index:idx_outbox_aggregate" json:"aggregate_type"`
+	AggregateID   uuid.UUID       `gorm:"type:uuid;not null;index:idx_outbox_aggregate"    json:"aggregate_id"`
+	EventType     string          `gorm:"type:varchar(100);not null"                       json:"event_type"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null"                              json:"payload"`
+	OccurredAt    time.Time       `gorm:"not null"                                         json:"occurred_at"`
+	PublishedAt   *time.Time      `gorm:"index"                                            json:"published_at,omitempty"`
+	Attempts      int             `gorm:"not null;default:0"                               json:"attempts"`
+	LastError     string          `gorm:"type:text"                                        json:"last_error,omitempty"`
+}
+
+// TableName overrides the GORM default.
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// BeforeCreate generates the event ID and timestamp when none are provided.
+func (e *OutboxEvent) BeforeCreate(_ *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// Aggregates that events are ordered by. Enrollment, instructor and section
+// membership events belong to their course instance, batch membership events
+// to their batch.
+const (
+	AggregateCourseInstance = "course_instance"
+	AggregateBatch          = "batch"
+)
+
+// Event catalog. The event type doubles as the broker routing key.
+const (
+	EventEnrollmentCreated           = "enrollment.created"
+	EventEnrollmentStatusChanged     = "enrollment.status_changed"
+	EventEnrollmentDropped           = "enrollment.dropped"
+	EventBatchMemberAdded            = "batch_member.added"
+	EventBatchMemberRemoved          = "batch_member.removed"
+	EventCourseInstanceStatusChanged = "course_instance.status_changed"
+	EventInstructorAssigned          = "instructor.assigned"
+	EventInstructorRemoved           = "instructor.removed"
+	EventSectionMemberAdded          = "section_member.added"
+	EventSectionMemberRemoved        = "section_member.removed"
+	EventApprovalRequested           = "approval.requested"
+	EventApprovalApproved            = "approval.approved"
+	EventApprovalRejected            = "approval.rejected"
+	EventApprovalWithdrawn           = "approval.withdrawn"
+)
+
+// EnrollmentEventPayload is the payload of enrollment events. Removed is
+// set on enrollment.dropped when the record was deleted rather than moved
+// to the Dropped status.
+type EnrollmentEventPayload struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Status           string    `json:"status"`
+	PreviousStatus   string    `json:"previous_status,omitempty"`
+	Removed          bool      `json:"removed,omitempty"`
+}
+
+// BatchMemberEventPayload is the payload of batch membership events.
+type BatchMemberEventPayload struct {
+	BatchID uuid.UUID `json:"batch_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Status  string    `json:"status"`
+}
+
+// CourseInstanceStatusPayload is the payload of course_instance.status_changed.
+type CourseInstanceStatusPayload struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	CourseID         uuid.UUID `json:"course_id"`
+	SemesterID       uuid.UUID `json:"semester_id"`
+	BatchID          uuid.UUID `json:"batch_id"`
+	Status           string    `json:"status"`
+	PreviousStatus   string    `json:"previous_status"`
+}
+
+// InstructorEventPayload is the payload of instructor events.
+type InstructorEventPayload struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Role             string    `json:"role"`
+}
+
+// SectionMemberEventPayload is the payload of section membership events.
+type SectionMemberEventPayload struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	SectionID        uuid.UUID `json:"section_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Kind             string    `json:"kind"`
+}
+
+// ApprovalEventPayload is the payload of approval events, which drive the
+// notifications of the approval workflow. NotifyUserIDs are the users to
+// tell: the faculty's active leaders when a request is raised or withdrawn,
+// the requester when it is decided.
+type ApprovalEventPayload struct {
+	ApprovalID       uuid.UUID   `json:"approval_id"`
+	FacultyID        uuid.UUID   `json:"faculty_id"`
+	CourseInstanceID uuid.UUID   `json:"course_instance_id"`
+	Kind             string      `json:"kind"`
+	Status           string      `json:"status"`
+	RequestedByID    uuid.UUID   `json:"requested_by_id"`
+	DecidedByID      *uuid.UUID  `json:"decided_by_id,omitempty"`
+	NotifyUserIDs    []uuid.UUID `json:"notify_user_ids,omitempty"`
+}
+
+func newOutboxEvent(aggregateType string, aggregateID uuid.UUID, eventType string, payload interface{}) OutboxEvent {
+	// The payloads are flat structs of strings and UUIDs, which always marshal.
+	body, _ := json.Marshal(payload)
+	return OutboxEvent{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       body,
+	}
+}
+
+// NewEnrollmentCreatedEvent describes a new enrollment, including one that
+// was placed on the waitlist.
+func NewEnrollmentCreatedEvent(e *Enrollment) OutboxEvent {
+	return newOutboxEvent(AggregateCourseInstance, e.CourseInstanceID, EventEnrollmentCreated, EnrollmentEventPayload{
+		CourseInstanceID: e.CourseInstanceID,
+		UserID:           e.UserID,
+		Status:           e.Status,
+	})
+}
+
+// NewEnrollmentStatusEvent describes a status change of an enrollment:
+// enrollment.dropped when it moved to Dropped, enrollment.status_changed
+// otherwise (e.g. a waitlist promotion or a completed course).
+func NewEnrollmentStatusEvent(e *Enrollment, previousStatus string) OutboxEvent {
+	eventType := EventEnrollmentStatusChanged
+	if e.Status == EnrollmentStatusDropped {
+		eventType = EventEnrollmentDropped
+	}
+	return newOutboxEvent(AggregateCourseInstance, e.CourseInstanceID, eventType, EnrollmentEventPayload{
+		CourseInstanceID: e.CourseInstanceID,
+		UserID:           e.UserID,
+		Status:           e.Status,
+		PreviousStatus:   previousStatus,
+	})
+}
+
+// NewEnrollmentRemovedEvent describes a deleted enrollment.
+func NewEnrollmentRemovedEvent(e *Enrollment) OutboxEvent {
+	return newOutboxEvent(AggregateCourseInstance, e.CourseInstanceID, EventEnrollmentDropped, EnrollmentEventPayload{
+		CourseInstanceID: e.CourseInstanceID,
+		UserID:           e.UserID,
+		Status:           EnrollmentStatusDropped,
+		PreviousStatus:   e.Status,
+		Removed:          true,
+	})
+}
+
+// NewBatchMemberEvent describes a membership added to or removed from a batch.
+func NewBatchMemberEvent(eventType string, m *BatchMember) OutboxEvent {
+	return newOutboxEvent(AggregateBatch, m.BatchID, eventType, BatchMemberEventPayload{
+		BatchID: m.BatchID,
+		UserID:  m.UserID,
+		Status:  m.Status,
+	})
+}
+
+// NewCourseInstanceStatusEvent describes a course instance status change.
+func NewCourseInstanceStatusEvent(ci *CourseInstance, previousStatus string) OutboxEvent {
+	return newOutboxEvent(AggregateCourseInstance, ci.ID, EventCourseInstanceStatusChanged, CourseInstanceStatusPayload{
+		CourseInstanceID: ci.ID,
+		CourseID:         ci.CourseID,
+		SemesterID:       ci.SemesterID,
+		BatchID:          ci.BatchID,
+		Status:           ci.Status,
+		PreviousStatus:   previousStatus,
+	})
+}
+
+// NewInstructorEvent describes an instructor assigned to or removed from a
+// course instance.
+func NewInstructorEvent(eventType string, ci *CourseInstructor) OutboxEvent {
+	return newOutboxEvent(AggregateCourseInstance, ci.CourseInstanceID, eventType, InstructorEventPayload{
+		CourseInstanceID: ci.CourseInstanceID,
+		UserID:           ci.UserID,
+		Role:             ci.Role,
+	})
+}
+
+// NewSectionMemberEvent describes a student added to or removed from a
+// course section or lab group.
+func NewSectionMemberEvent(eventType string, m *SectionMember) OutboxEvent {
+	return newOutboxEvent(AggregateCourseInstance, m.CourseInstanceID, eventType, SectionMemberEventPayload{
+		CourseInstanceID: m.CourseInstanceID,
+		SectionID:        m.SectionID,
+		UserID:           m.UserID,
+		Kind:             m.Kind,
+	})
+}
+
+// NewApprovalEvent describes an approval request being raised, decided or
+// withdrawn. The event type follows the request's status.
+func NewApprovalEvent(a *ApprovalRequest, notify []uuid.UUID) OutboxEvent {
+	eventType := EventApprovalRequested
+	switch a.Status {
+	case ApprovalStatusApproved:
+		eventType = EventApprovalApproved
+	case ApprovalStatusRejected:
+		eventType = EventApprovalRejected
+	case ApprovalStatusWithdrawn:
+		eventType = EventApprovalWithdrawn
+	}
+	return newOutboxEvent(AggregateCourseInstance, a.CourseInstanceID, eventType, ApprovalEventPayload{
+		ApprovalID:       a.ID,
+		FacultyID:        a.FacultyID,
+		CourseInstanceID: a.CourseInstanceID,
+		Kind:             a.Kind,
+		Status:           a.Status,
+		RequestedByID:    a.RequestedByID,
+		DecidedByID:      a.DecidedByID,
+		NotifyUserIDs:    notify,
+	})
+}
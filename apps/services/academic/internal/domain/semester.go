@@ -8,15 +8,24 @@ import (
 )
 
 // Semester represents an academic semester / term in the academic calendar.
+// The key dates are optional; a zero AddDropDeadline leaves enrollment
+// changes open for the whole semester.
 type Semester struct {
-	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	Name      string     `gorm:"type:varchar(255);not null"                     json:"name"`
-	Code      string     `gorm:"type:varchar(50);uniqueIndex;not null"          json:"code"`
-	TermType  string     `gorm:"type:varchar(50);not null"                      json:"term_type"`
-	StartDate string     `gorm:"type:date;not null"                             json:"start_date"`
-	EndDate   string     `gorm:"type:date;not null"                             json:"end_date"`
-	Status    string     `gorm:"type:varchar(50);not null;default:'Planned'"    json:"status"`
-	IsActive  bool       `gorm:"default:true"                                   json:"is_active"`
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name      string    `gorm:"type:varchar(255);not null"                     json:"name"`
+	Code      string    `gorm:"type:varchar(50);uniqueIndex;not null"          json:"code"`
+	TermType  string    `gorm:"type:varchar(50);not null"                      json:"term_type"`
+	StartDate Date      `gorm:"type:date;not null"                             json:"start_date"`
+	EndDate   Date      `gorm:"type:date;not null"                             json:"end_date"`
+	Status    string    `gorm:"type:varchar(50);not null;default:'Planned'"    json:"status"`
+	IsActive  bool      `gorm:"default:true"                                   json:"is_active"`
+
+	// Key dates
+	AddDropDeadline         Date `gorm:"type:date" json:"add_drop_deadline"`
+	ExamStartDate           Date `gorm:"type:date" json:"exam_start_date"`
+	ExamEndDate             Date `gorm:"type:date" json:"exam_end_date"`
+	GradeSubmissionDeadline Date `gorm:"type:date" json:"grade_submission_deadline"`
+
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `gorm:"index"                                          json:"deleted_at,omitempty"`
@@ -78,3 +87,30 @@ func IsValidSemesterStatus(s string) bool {
 	_, ok := ValidSemesterStatuses[s]
 	return ok
 }
+
+// Overlaps reports whether s and o share at least one day.
+func (s *Semester) Overlaps(o *Semester) bool {
+	return !s.StartDate.After(o.EndDate) && !o.StartDate.After(s.EndDate)
+}
+
+// ScheduledStatus returns the status the calendar gives s on the given day:
+// Planned before its start date, Active up to and including its end date
+// and Completed afterwards. Cancelled semesters stay Cancelled.
+func (s *Semester) ScheduledStatus(today Date) string {
+	switch {
+	case s.Status == SemesterStatusCancelled:
+		return SemesterStatusCancelled
+	case today.Before(s.StartDate):
+		return SemesterStatusPlanned
+	case today.After(s.EndDate):
+		return SemesterStatusCompleted
+	default:
+		return SemesterStatusActive
+	}
+}
+
+// AddDropOpen reports whether enrollments in s may still be added or
+// dropped on the given day. The deadline day itself is still open.
+func (s *Semester) AddDropOpen(today Date) bool {
+	return s.AddDropDeadline.IsZero() || !today.After(s.AddDropDeadline)
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Approval DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RequestGradeChangeRequest is the payload for
+// POST /instructor-courses/:id/final-grades/:userID/change-requests and
+// POST /course-instances/:id/final-grades/:userID/change-requests.
+type RequestGradeChangeRequest struct {
+	FinalGrade string `json:"final_grade"`
+	Reason     string `json:"reason"`
+}
+
+// RequestCancellationRequest is the payload for
+// POST /course-instances/:id/cancellation-requests.
+type RequestCancellationRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RequestEnrollmentOverrideRequest is the payload for
+// POST /course-instances/:id/enrollment-overrides. Status is Enrolled to add
+// the student or Dropped to drop them.
+type RequestEnrollmentOverrideRequest struct {
+	UserID          uuid.UUID `json:"user_id"`
+	Status          string    `json:"status"`
+	AllowIndividual bool      `json:"allow_individual"`
+	Reason          string    `json:"reason"`
+}
+
+// DecideApprovalRequest is the payload for POST /approvals/:id/approve and
+// POST /approvals/:id/reject. A note is required to reject.
+type DecideApprovalRequest struct {
+	Note string `json:"note"`
+}
+
+// ListApprovalsQuery filters approval request lists. Empty fields match
+// everything.
+type ListApprovalsQuery struct {
+	Status string `query:"status"`
+	Kind   string `query:"kind"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Faculty leadership DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// FacultyCourseInstanceSummary is one row of a faculty leader's dashboard.
+type FacultyCourseInstanceSummary struct {
+	ID               uuid.UUID `json:"id"`
+	CourseID         uuid.UUID `json:"course_id"`
+	SemesterID       uuid.UUID `json:"semester_id"`
+	BatchID          uuid.UUID `json:"batch_id"`
+	Status           string    `json:"status"`
+	MaxEnrollment    int       `json:"max_enrollment"`
+	Enrolled         int       `json:"enrolled"`
+	Waitlisted       int       `json:"waitlisted"`
+	Dropped          int       `json:"dropped"`
+	Completed        int       `json:"completed"`
+	Failed           int       `json:"failed"`
+	PendingApprovals int       `json:"pending_approvals"`
+}
@@ -0,0 +1,163 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Attendance DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CreateAttendanceSessionRequest is the payload for
+// POST /instructor-courses/:id/attendance/sessions. Date is "YYYY-MM-DD";
+// Type is "lecture", "lab" or "tutorial". A session with a SectionID is for
+// that section's members only.
+type CreateAttendanceSessionRequest struct {
+	SectionID *uuid.UUID `json:"section_id"`
+	Date      string     `json:"date"`
+	Type      string     `json:"type"`
+	Topic     string     `json:"topic"`
+}
+
+// UpdateAttendanceSessionRequest is the payload for
+// PUT /instructor-courses/:id/attendance/sessions/:sessionID. Omitted fields
+// are left unchanged.
+type UpdateAttendanceSessionRequest struct {
+	Date  *string `json:"date"`
+	Type  *string `json:"type"`
+	Topic *string `json:"topic"`
+}
+
+// MarkAttendanceRequest is the payload for
+// PUT /instructor-courses/:id/attendance/sessions/:sessionID/records/:userID.
+type MarkAttendanceRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
+
+// AttendanceMark is one student's entry in a bulk marking request.
+type AttendanceMark struct {
+	UserID uuid.UUID `json:"user_id"`
+	Status string    `json:"status"`
+	Note   string    `json:"note"`
+}
+
+// BulkMarkAttendanceRequest is the payload for
+// POST /instructor-courses/:id/attendance/sessions/:sessionID/records.
+// RemainingStatus, when set, is applied to every student on the session's
+// roster who is neither listed nor already marked, e.g. "absent" after
+// ticking off those present.
+type BulkMarkAttendanceRequest struct {
+	Records         []AttendanceMark `json:"records"`
+	RemainingStatus string           `json:"remaining_status"`
+}
+
+// BulkMarkAttendanceResponse summarises a bulk marking run.
+type BulkMarkAttendanceResponse struct {
+	SessionID uuid.UUID `json:"session_id"`
+	Marked    int       `json:"marked"`
+	Remaining int       `json:"remaining"`
+}
+
+// OpenCheckInRequest is the payload for
+// POST /instructor-courses/:id/attendance/sessions/:sessionID/check-in.
+// Minutes defaults to the configured check-in window.
+type OpenCheckInRequest struct {
+	Minutes int `json:"minutes"`
+}
+
+// CheckInCodeResponse is the code an instructor shows to the class. It
+// rotates at ExpiresAt; check-in closes at OpenUntil.
+type CheckInCodeResponse struct {
+	SessionID uuid.UUID `json:"session_id"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	OpenUntil time.Time `json:"open_until"`
+}
+
+// CheckInRequest is the payload for
+// POST /student-courses/:id/attendance/check-in.
+type CheckInRequest struct {
+	Code string `json:"code"`
+}
+
+// AttendanceRecordResponse is one student's attendance at a session.
+type AttendanceRecordResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Status   string    `json:"status"`
+	Source   string    `json:"source"`
+	Note     string    `json:"note,omitempty"`
+	MarkedBy string    `json:"marked_by,omitempty"`
+	MarkedAt time.Time `json:"marked_at"`
+}
+
+// AttendanceSessionResponse is returned for session endpoints. Records is
+// only filled in when a single session is fetched.
+type AttendanceSessionResponse struct {
+	ID               uuid.UUID                  `json:"id"`
+	CourseInstanceID uuid.UUID                  `json:"course_instance_id"`
+	SectionID        *uuid.UUID                 `json:"section_id,omitempty"`
+	Date             string                     `json:"date"`
+	Type             string                     `json:"type"`
+	Topic            string                     `json:"topic"`
+	CheckInOpen      bool                       `json:"check_in_open"`
+	CheckInOpenUntil *time.Time                 `json:"check_in_open_until,omitempty"`
+	CreatedBy        string                     `json:"created_by"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+	Records          []AttendanceRecordResponse `json:"records,omitempty"`
+}
+
+// AttendanceSummary counts a student's attendance. Rate is the percentage of
+// counted sessions attended (present or late); excused and unmarked sessions
+// are not counted, and Rate is omitted until one is.
+type AttendanceSummary struct {
+	Sessions int      `json:"sessions"`
+	Present  int      `json:"present"`
+	Late     int      `json:"late"`
+	Absent   int      `json:"absent"`
+	Excused  int      `json:"excused"`
+	Unmarked int      `json:"unmarked"`
+	Rate     *float64 `json:"rate,omitempty"`
+	AtRisk   bool     `json:"at_risk"`
+}
+
+// StudentAttendanceEntry is one session in a student's attendance report.
+// Status is empty while the session is unmarked.
+type StudentAttendanceEntry struct {
+	SessionID uuid.UUID  `json:"session_id"`
+	SectionID *uuid.UUID `json:"section_id,omitempty"`
+	Date      string     `json:"date"`
+	Type      string     `json:"type"`
+	Topic     string     `json:"topic"`
+	Status    string     `json:"status,omitempty"`
+	Source    string     `json:"source,omitempty"`
+	MarkedAt  *time.Time `json:"marked_at,omitempty"`
+}
+
+// StudentAttendanceReport is one student's attendance in a course instance.
+type StudentAttendanceReport struct {
+	CourseInstanceID uuid.UUID                `json:"course_instance_id"`
+	UserID           uuid.UUID                `json:"user_id"`
+	Threshold        float64                  `json:"threshold"`
+	Summary          AttendanceSummary        `json:"summary"`
+	Sessions         []StudentAttendanceEntry `json:"sessions"`
+}
+
+// StudentAttendanceSummary is one row of a course attendance report.
+type StudentAttendanceSummary struct {
+	UserID uuid.UUID `json:"user_id"`
+	AttendanceSummary
+}
+
+// CourseAttendanceReport summarises attendance for every student on a course
+// instance's roster.
+type CourseAttendanceReport struct {
+	CourseInstanceID uuid.UUID                  `json:"course_instance_id"`
+	Threshold        float64                    `json:"threshold"`
+	Sessions         int                        `json:"sessions"`
+	AtRiskCount      int                        `json:"at_risk_count"`
+	Students         []StudentAttendanceSummary `json:"students"`
+}
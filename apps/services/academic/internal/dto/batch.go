@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 )
 
@@ -63,6 +64,20 @@ type ListBatchesQuery struct {
 	IncludeInactive bool `query:"include_inactive"`
 }
 
+// ListBatchesSchema declares the filters, sorts and paging of GET /batches.
+var ListBatchesSchema = queryspec.Schema{
+	Fields: map[string]queryspec.Field{
+		"name":       {Kind: queryspec.String, Filter: true, Sort: true},
+		"code":       {Kind: queryspec.String, Filter: true, Sort: true},
+		"degree_id":  {Kind: queryspec.UUID, Filter: true},
+		"parent_id":  {Kind: queryspec.UUID, Filter: true},
+		"start_year": {Kind: queryspec.Int, Filter: true, Sort: true},
+		"end_year":   {Kind: queryspec.Int, Filter: true, Sort: true},
+		"created_at": {Kind: queryspec.Time, Filter: true, Sort: true},
+	},
+	Sort: "created_at",
+}
+
 // BatchEnrollmentStats combines batch metadata with computed enrollment counts
 // for a specific course instance. Used by instructor-scoped endpoints.
 type BatchEnrollmentStats struct {
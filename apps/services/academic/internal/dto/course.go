@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 )
 
@@ -43,18 +44,37 @@ type ListCoursesQuery struct {
 	IncludeInactive bool `query:"include_inactive"`
 }
 
+// ListCoursesSchema declares the filters, sorts and paging of GET /courses.
+var ListCoursesSchema = queryspec.Schema{
+	Fields: map[string]queryspec.Field{
+		"code":       {Kind: queryspec.String, Filter: true, Sort: true},
+		"title":      {Kind: queryspec.String, Filter: true, Sort: true},
+		"credits":    {Kind: queryspec.Int, Filter: true, Sort: true},
+		"created_at": {Kind: queryspec.Time, Filter: true, Sort: true},
+	},
+	Sort: "created_at",
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // CoursePrerequisite DTOs
 // ─────────────────────────────────────────────────────────────────────────────
 
-// AddPrerequisiteRequest is the payload for POST /courses/:id/prerequisites
+// AddPrerequisiteRequest is the payload for POST /courses/:id/prerequisites.
+// Kind defaults to "prerequisite"; rules with the same non-zero Group are
+// alternatives.
 type AddPrerequisiteRequest struct {
 	PrerequisiteCourseID uuid.UUID `json:"prerequisite_course_id"`
+	Kind                 string    `json:"kind"`
+	Group                int       `json:"group"`
+	MinGrade             string    `json:"min_grade"`
 }
 
 // CoursePrerequisiteResponse is returned for prerequisite endpoints
 type CoursePrerequisiteResponse struct {
 	CourseID             uuid.UUID       `json:"course_id"`
 	PrerequisiteCourseID uuid.UUID       `json:"prerequisite_course_id"`
+	Kind                 string          `json:"kind"`
+	Group                int             `json:"group"`
+	MinGrade             string          `json:"min_grade,omitempty"`
 	PrerequisiteCourse   *CourseResponse `json:"prerequisite_course,omitempty"`
 }
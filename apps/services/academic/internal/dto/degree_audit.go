@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Degree requirement DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// DegreeRequirementRequest is the payload for creating or replacing a degree
+// requirement rule. Kind is one of required_courses, elective_pool or
+// total_credits. CourseIDs is required for the first two kinds and must be
+// empty for total_credits; MinCredits is required for the last two.
+// SpecializationID limits the rule to students of that specialization.
+type DegreeRequirementRequest struct {
+	SpecializationID *uuid.UUID  `json:"specialization_id"`
+	Kind             string      `json:"kind"`
+	Name             string      `json:"name"`
+	MinCredits       int         `json:"min_credits"`
+	MinGrade         string      `json:"min_grade"`
+	CourseIDs        []uuid.UUID `json:"course_ids"`
+}
+
+// RequirementCourseResponse is a course listed under a requirement rule.
+type RequirementCourseResponse struct {
+	CourseID uuid.UUID `json:"course_id"`
+	Code     string    `json:"code"`
+	Title    string    `json:"title"`
+	Credits  int       `json:"credits"`
+}
+
+// DegreeRequirementResponse is returned for degree requirement endpoints.
+type DegreeRequirementResponse struct {
+	ID               uuid.UUID                   `json:"id"`
+	DegreeID         uuid.UUID                   `json:"degree_id"`
+	SpecializationID *uuid.UUID                  `json:"specialization_id,omitempty"`
+	Kind             string                      `json:"kind"`
+	Name             string                      `json:"name"`
+	MinCredits       int                         `json:"min_credits"`
+	MinGrade         string                      `json:"min_grade,omitempty"`
+	Courses          []RequirementCourseResponse `json:"courses"`
+	CreatedAt        time.Time                   `json:"created_at"`
+	UpdatedAt        time.Time                   `json:"updated_at"`
+}
+
+// ListDegreeRequirementsResponse lists the rules of a degree.
+type ListDegreeRequirementsResponse struct {
+	Requirements []DegreeRequirementResponse `json:"requirements"`
+	Count        int                         `json:"count"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Degree audit DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AuditCourseResponse is a course as it stands in a degree audit. Status is
+// completed, in_progress or missing; FinalGrade is set for completed
+// courses and for completed attempts below a rule's minimum grade.
+type AuditCourseResponse struct {
+	CourseID   uuid.UUID `json:"course_id"`
+	CourseCode string    `json:"course_code"`
+	Credits    int       `json:"credits"`
+	Status     string    `json:"status"`
+	FinalGrade string    `json:"final_grade,omitempty"`
+}
+
+// RequirementAuditResponse is the outcome of one requirement rule. Status is
+// satisfied, pending (met once the in-progress courses are completed) or
+// missing.
+type RequirementAuditResponse struct {
+	RequirementID     uuid.UUID             `json:"requirement_id"`
+	Kind              string                `json:"kind"`
+	Name              string                `json:"name"`
+	Status            string                `json:"status"`
+	MinCredits        int                   `json:"min_credits,omitempty"`
+	CreditsCompleted  int                   `json:"credits_completed"`
+	CreditsInProgress int                   `json:"credits_in_progress"`
+	Courses           []AuditCourseResponse `json:"courses,omitempty"`
+}
+
+// DegreeAuditResponse is a student's progress against the requirements of
+// their degree and specialization. Status is the worst of the requirement
+// statuses.
+type DegreeAuditResponse struct {
+	UserID             uuid.UUID                  `json:"user_id"`
+	DegreeID           uuid.UUID                  `json:"degree_id"`
+	DegreeCode         string                     `json:"degree_code"`
+	DegreeName         string                     `json:"degree_name"`
+	SpecializationID   *uuid.UUID                 `json:"specialization_id,omitempty"`
+	SpecializationName string                     `json:"specialization_name,omitempty"`
+	Status             string                     `json:"status"`
+	CreditsCompleted   int                        `json:"credits_completed"`
+	CreditsInProgress  int                        `json:"credits_in_progress"`
+	Requirements       []RequirementAuditResponse `json:"requirements"`
+	GeneratedAt        time.Time                  `json:"generated_at"`
+}
@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 )
 
@@ -42,6 +43,39 @@ type BulkAddBatchMembersRequest struct {
 	UserIDs []uuid.UUID `json:"user_ids"`
 }
 
+// MoveBatchMemberRequest is the payload for POST /batch-members/move.
+// Policy decides what happens to enrollments in courses of the source batch
+// that the target batch does not share; empty means the configured default.
+type MoveBatchMemberRequest struct {
+	UserID      uuid.UUID `json:"user_id"`
+	FromBatchID uuid.UUID `json:"from_batch_id"`
+	ToBatchID   uuid.UUID `json:"to_batch_id"`
+	Policy      string    `json:"policy"`
+}
+
+// BatchMoveCourseOutcome reports what a batch move did to one course
+// instance. TargetCourseInstanceID is set for transfers.
+type BatchMoveCourseOutcome struct {
+	CourseInstanceID       uuid.UUID  `json:"course_instance_id"`
+	CourseID               uuid.UUID  `json:"course_id"`
+	SemesterID             uuid.UUID  `json:"semester_id"`
+	Outcome                string     `json:"outcome"`
+	TargetCourseInstanceID *uuid.UUID `json:"target_course_instance_id,omitempty"`
+	Reason                 string     `json:"reason,omitempty"`
+}
+
+// MoveBatchMemberResponse summarises a batch move. EnrollmentError is set
+// when the student moved but their enrollments could not be processed at
+// all; Courses is then empty and the enrollments need attention by hand.
+type MoveBatchMemberResponse struct {
+	UserID          uuid.UUID                `json:"user_id"`
+	FromBatchID     uuid.UUID                `json:"from_batch_id"`
+	ToBatchID       uuid.UUID                `json:"to_batch_id"`
+	Policy          string                   `json:"policy"`
+	Courses         []BatchMoveCourseOutcome `json:"courses"`
+	EnrollmentError string                   `json:"enrollment_error,omitempty"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Course Instance DTOs
 // ─────────────────────────────────────────────────────────────────────────────
@@ -53,12 +87,21 @@ type CreateCourseInstanceRequest struct {
 	BatchID       uuid.UUID `json:"batch_id"`
 	Status        string    `json:"status"`
 	MaxEnrollment int       `json:"max_enrollment"`
+	// IncludeSubBatches also enrolls the members of every descendant batch.
+	IncludeSubBatches bool `json:"include_sub_batches"`
 }
 
 // UpdateCourseInstanceRequest is the payload for PUT /course-instances/:id
 type UpdateCourseInstanceRequest struct {
-	Status        string `json:"status"`
-	MaxEnrollment *int   `json:"max_enrollment"`
+	Status            string `json:"status"`
+	MaxEnrollment     *int   `json:"max_enrollment"`
+	IncludeSubBatches *bool  `json:"include_sub_batches"`
+	// AttendanceThreshold sets the at-risk attendance percentage for this
+	// instance; 0 falls back to the service-wide default.
+	AttendanceThreshold *float64 `json:"attendance_threshold"`
+	// CancellationApproved allows the Cancelled status. It is only set when
+	// an approved cancellation request is carried out, never from the body.
+	CancellationApproved bool `json:"-"`
 }
 
 // CourseInstanceResponse is returned for course-instance endpoints
@@ -69,8 +112,13 @@ type CourseInstanceResponse struct {
 	BatchID       uuid.UUID `json:"batch_id"`
 	Status        string    `json:"status"`
 	MaxEnrollment int       `json:"max_enrollment"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+
+	IncludeSubBatches bool `json:"include_sub_batches"`
+
+	AttendanceThreshold *float64 `json:"attendance_threshold,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -106,25 +154,58 @@ type EnrollmentRequest struct {
 	UserID           uuid.UUID `json:"user_id"`
 	Status           string    `json:"status"`
 	AllowIndividual  bool      `json:"allow_individual"` // Skip batch membership check for individual enrollments
+	// OverridePrerequisites enrolls the student even when they have not met
+	// the course's prerequisites. OverrideReason is required with it.
+	OverridePrerequisites bool   `json:"override_prerequisites"`
+	OverrideReason        string `json:"override_reason"`
+	// DeadlineApproved skips the add/drop deadline. It is only set when an
+	// approved enrollment override is carried out, never from the body.
+	DeadlineApproved bool `json:"-"`
 }
 
 // UpdateEnrollmentRequest is the payload for PUT /enrollments/:instanceID/:userID
 type UpdateEnrollmentRequest struct {
 	Status     string `json:"status"`
 	FinalGrade string `json:"final_grade"`
+	// DeadlineApproved skips the add/drop deadline; see EnrollmentRequest.
+	DeadlineApproved bool `json:"-"`
 }
 
 // EnrollBatchRequest is the payload for POST /instructor-courses/:id/enroll-batch
 type EnrollBatchRequest struct {
 	BatchID uuid.UUID `json:"batch_id"`
+	// IncludeDescendants also enrolls the members of every descendant batch.
+	IncludeDescendants    bool   `json:"include_descendants"`
+	OverridePrerequisites bool   `json:"override_prerequisites"`
+	OverrideReason        string `json:"override_reason"`
 }
 
-// EnrollBatchResponse summarises the result of a bulk batch enrollment
+// EnrollBatchResponse summarises the result of a bulk batch enrollment.
+// Students who did not fit within the instance's capacity are waitlisted;
+// students who have not met the course's prerequisites are left out.
 type EnrollBatchResponse struct {
-	Enrolled     int         `json:"enrolled"`
-	Skipped      int         `json:"skipped"`
-	Total        int         `json:"total"`
-	SkippedUsers []uuid.UUID `json:"skipped_users,omitempty"`
+	Enrolled        int         `json:"enrolled"`
+	Waitlisted      int         `json:"waitlisted"`
+	Skipped         int         `json:"skipped"`
+	Ineligible      int         `json:"ineligible"`
+	Total           int         `json:"total"`
+	SkippedUsers    []uuid.UUID `json:"skipped_users"`
+	WaitlistedUsers []uuid.UUID `json:"waitlisted_users"`
+	IneligibleUsers []uuid.UUID `json:"ineligible_users"`
+}
+
+// ListEnrollmentsSchema declares the filters, sorts and paging of
+// GET /course-instances/:id/enrollments. Enrollments are keyed by user within
+// an instance.
+var ListEnrollmentsSchema = queryspec.Schema{
+	Fields: map[string]queryspec.Field{
+		"user_id":     {Kind: queryspec.UUID, Filter: true},
+		"status":      {Kind: queryspec.String, Filter: true},
+		"final_grade": {Kind: queryspec.String, Filter: true},
+		"enrolled_at": {Kind: queryspec.Time, Filter: true, Sort: true},
+	},
+	Sort: "enrolled_at",
+	Key:  "user_id",
 }
 
 // EnrollmentResponse is returned for enrollment endpoints
@@ -136,9 +217,18 @@ type EnrollmentResponse struct {
 	Email            string    `json:"email"`
 	Status           string    `json:"status"`
 	FinalGrade       string    `json:"final_grade,omitempty"`
+	WaitlistPosition *int      `json:"waitlist_position,omitempty"`
 	EnrolledAt       time.Time `json:"enrolled_at"`
 }
 
+// CourseCapacityResponse summarises seat usage of a course instance.
+// MaxEnrollment 0 means unlimited.
+type CourseCapacityResponse struct {
+	MaxEnrollment int `json:"max_enrollment"`
+	SeatsTaken    int `json:"seats_taken"`
+	Waitlisted    int `json:"waitlisted"`
+}
+
 // StudentCourseEnrollmentResponse is returned for student-scoped course endpoints.
 // It enriches the raw enrollment with course, semester, and batch details so the
 // frontend can render a fully populated course card without additional lookups.
@@ -159,7 +249,8 @@ type StudentCourseEnrollmentResponse struct {
 	BatchID   uuid.UUID `json:"batch_id,omitempty"`
 	BatchName string    `json:"batch_name,omitempty"`
 
-	Status     string    `json:"status"`
-	FinalGrade string    `json:"final_grade,omitempty"`
-	EnrolledAt time.Time `json:"enrolled_at"`
+	Status           string    `json:"status"`
+	FinalGrade       string    `json:"final_grade,omitempty"`
+	WaitlistPosition *int      `json:"waitlist_position,omitempty"`
+	EnrolledAt       time.Time `json:"enrolled_at"`
 }
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Domain event DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// EventResponse is one stored domain event.
+type EventResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	EventType     string          `json:"event_type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty"`
+}
+
+// ListEventsQuery filters GET /internal/events. Events are returned in
+// sequence order after After; pass the previous response's NextAfter to
+// page through them.
+type ListEventsQuery struct {
+	After         int64
+	AggregateType string
+	AggregateID   *uuid.UUID
+	EventType     string
+	Limit         int
+}
+
+// ListEventsResponse is returned by GET /internal/events. NextAfter is the
+// last sequence returned, or the requested After when there are no events.
+type ListEventsResponse struct {
+	Events    []EventResponse `json:"events"`
+	Count     int             `json:"count"`
+	NextAfter int64           `json:"next_after"`
+}
+
+// ReplayEventsRequest is the payload for POST /events/replay. Published
+// events with FromSequence < sequence <= ToSequence (0 = no upper bound)
+// matching the optional filters are re-sent to the broker, at most 1000
+// per call.
+type ReplayEventsRequest struct {
+	FromSequence  int64      `json:"from_sequence"`
+	ToSequence    int64      `json:"to_sequence"`
+	AggregateType string     `json:"aggregate_type"`
+	AggregateID   *uuid.UUID `json:"aggregate_id"`
+	EventType     string     `json:"event_type"`
+}
+
+// ReplayEventsResponse reports a replay. When HasMore is set, call again
+// with FromSequence set to LastSequence.
+type ReplayEventsResponse struct {
+	Replayed     int   `json:"replayed"`
+	LastSequence int64 `json:"last_sequence"`
+	HasMore      bool  `json:"has_more"`
+}
+
+// CourseAccessEnrollment is one enrollment in a course access snapshot.
+type CourseAccessEnrollment struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Status           string    `json:"status"`
+}
+
+// CourseAccessInstructor is one instructor assignment in a course access
+// snapshot.
+type CourseAccessInstructor struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Role             string    `json:"role"`
+}
+
+// CourseAccessSectionMember is one section membership in a course access
+// snapshot. Memberships of students who dropped the course are included.
+type CourseAccessSectionMember struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	SectionID        uuid.UUID `json:"section_id"`
+	UserID           uuid.UUID `json:"user_id"`
+}
+
+// CourseAccessSnapshotResponse is returned by GET /internal/course-access.
+// Sequence is the highest event sequence committed when it was taken, but a
+// lower one may still commit later. The rows of a course instance reflect
+// exactly its events up to CourseInstanceSequences (0 when it has none);
+// consumers rebuilding a read-model should ignore only those events.
+type CourseAccessSnapshotResponse struct {
+	Sequence                int64                       `json:"sequence"`
+	CourseInstanceSequences map[uuid.UUID]int64         `json:"course_instance_sequences"`
+	Enrollments             []CourseAccessEnrollment    `json:"enrollments"`
+	Instructors             []CourseAccessInstructor    `json:"instructors"`
+	SectionMembers          []CourseAccessSectionMember `json:"section_members"`
+}
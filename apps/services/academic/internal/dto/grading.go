@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Grading scheme DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GradingCategoryRequest weights one assignment category. Name matches the
+// assessment type of assignments, e.g. "lab" or "exam".
+type GradingCategoryRequest struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+// GradeBandRequest is a letter-grade cut-off.
+type GradeBandRequest struct {
+	Letter      string  `json:"letter"`
+	MinPercent  float64 `json:"min_percent"`
+	GradePoints float64 `json:"grade_points"`
+}
+
+// SetGradingSchemeRequest is the payload for
+// PUT /instructor-courses/:id/grading-scheme. Category weights must add up
+// to 100 and one band must start at 0%.
+type SetGradingSchemeRequest struct {
+	Categories []GradingCategoryRequest `json:"categories"`
+	Bands      []GradeBandRequest       `json:"bands"`
+}
+
+// GradingSchemeResponse is returned for grading scheme endpoints.
+type GradingSchemeResponse struct {
+	CourseInstanceID uuid.UUID                `json:"course_instance_id"`
+	Categories       []GradingCategoryRequest `json:"categories"`
+	Bands            []GradeBandRequest       `json:"bands"`
+	LockedAt         *time.Time               `json:"locked_at,omitempty"`
+	LockedBy         string                   `json:"locked_by,omitempty"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Final grade DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// OverrideFinalGradeRequest is the payload for
+// PUT /instructor-courses/:id/final-grades/:userID. The letter must be one of
+// the grading scheme's bands.
+type OverrideFinalGradeRequest struct {
+	FinalGrade string `json:"final_grade"`
+	Reason     string `json:"reason"`
+}
+
+// CategoryScoreResponse is a student's average in one grading category.
+type CategoryScoreResponse struct {
+	Name        string  `json:"name"`
+	Weight      float64 `json:"weight"`
+	Average     float64 `json:"average"`
+	Assignments int     `json:"assignments"`
+	Missing     int     `json:"missing"`
+}
+
+// FinalGradeResponse is one student's final grade in a course instance.
+// Categories is only filled in by a compute run.
+type FinalGradeResponse struct {
+	UserID          uuid.UUID               `json:"user_id"`
+	Status          string                  `json:"status"`
+	FinalScore      *float64                `json:"final_score,omitempty"`
+	FinalGrade      string                  `json:"final_grade,omitempty"`
+	GradePoints     *float64                `json:"grade_points,omitempty"`
+	GradeOverridden bool                    `json:"grade_overridden"`
+	Categories      []CategoryScoreResponse `json:"categories,omitempty"`
+}
+
+// FinalGradesResponse lists the final grades of a course instance.
+type FinalGradesResponse struct {
+	CourseInstanceID uuid.UUID            `json:"course_instance_id"`
+	Locked           bool                 `json:"locked"`
+	LockedAt         *time.Time           `json:"locked_at,omitempty"`
+	Grades           []FinalGradeResponse `json:"grades"`
+	Count            int                  `json:"count"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Transcript DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// TranscriptCourseResponse is one graded course on a transcript.
+type TranscriptCourseResponse struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	CourseCode       string    `json:"course_code"`
+	CourseTitle      string    `json:"course_title"`
+	Credits          int       `json:"credits"`
+	Status           string    `json:"status"`
+	FinalGrade       string    `json:"final_grade"`
+	GradePoints      *float64  `json:"grade_points,omitempty"`
+}
+
+// TranscriptTermResponse groups a transcript's courses by semester. GPA is
+// weighted by credits over courses with grade points.
+type TranscriptTermResponse struct {
+	SemesterID       uuid.UUID                  `json:"semester_id"`
+	SemesterCode     string                     `json:"semester_code"`
+	SemesterName     string                     `json:"semester_name"`
+	StartDate        string                     `json:"start_date"`
+	Courses          []TranscriptCourseResponse `json:"courses"`
+	CreditsAttempted int                        `json:"credits_attempted"`
+	CreditsEarned    int                        `json:"credits_earned"`
+	GPA              float64                    `json:"gpa"`
+}
+
+// TranscriptResponse is a student's academic record.
+type TranscriptResponse struct {
+	UserID           uuid.UUID                `json:"user_id"`
+	StudentID        string                   `json:"student_id,omitempty"`
+	FullName         string                   `json:"full_name,omitempty"`
+	Terms            []TranscriptTermResponse `json:"terms"`
+	CreditsAttempted int                      `json:"credits_attempted"`
+	CreditsEarned    int                      `json:"credits_earned"`
+	CumulativeGPA    float64                  `json:"cumulative_gpa"`
+	GeneratedAt      time.Time                `json:"generated_at"`
+}
@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Section DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CreateSectionRequest is the payload for POST /course-instances/:id/sections.
+// Kind is "lecture" or "lab"; Capacity 0 means unlimited.
+type CreateSectionRequest struct {
+	Kind     string     `json:"kind"`
+	Name     string     `json:"name"`
+	Capacity int        `json:"capacity"`
+	BatchID  *uuid.UUID `json:"batch_id"`
+}
+
+// UpdateSectionRequest is the payload for
+// PUT /course-instances/:id/sections/:sectionID. Omitted fields are left
+// unchanged; ClearBatch unlinks the section from its batch.
+type UpdateSectionRequest struct {
+	Name       *string    `json:"name"`
+	Capacity   *int       `json:"capacity"`
+	BatchID    *uuid.UUID `json:"batch_id"`
+	ClearBatch bool       `json:"clear_batch"`
+}
+
+// SectionInstructorRequest is the payload for
+// POST /course-instances/:id/sections/:sectionID/instructors. Role defaults
+// to the user's role on the course instance.
+type SectionInstructorRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+// SectionSlotRequest is the payload for
+// POST /course-instances/:id/sections/:sectionID/slots. DayOfWeek runs from
+// 1 (Monday) to 7 (Sunday); times are "HH:MM".
+type SectionSlotRequest struct {
+	DayOfWeek int    `json:"day_of_week"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Room      string `json:"room"`
+}
+
+// AssignSectionStudentsRequest is the payload for
+// POST /course-instances/:id/sections/assign. SectionID and UserIDs are used
+// by the manual strategy only; the others assign every enrolled student who
+// has no section of the kind yet.
+type AssignSectionStudentsRequest struct {
+	Kind      string      `json:"kind"`
+	Strategy  string      `json:"strategy"`
+	SectionID uuid.UUID   `json:"section_id"`
+	UserIDs   []uuid.UUID `json:"user_ids"`
+}
+
+// SectionAssignmentCount is the number of students placed in one section.
+type SectionAssignmentCount struct {
+	SectionID uuid.UUID `json:"section_id"`
+	Assigned  int       `json:"assigned"`
+}
+
+// AssignSectionStudentsResponse summarises a section assignment run.
+// Unassigned lists students who could not be placed, e.g. because every
+// matching section was full.
+type AssignSectionStudentsResponse struct {
+	Kind       string                   `json:"kind"`
+	Strategy   string                   `json:"strategy"`
+	Assigned   int                      `json:"assigned"`
+	Sections   []SectionAssignmentCount `json:"sections"`
+	Unassigned []uuid.UUID              `json:"unassigned"`
+}
+
+// SectionInstructorResponse is a section's instructor or TA.
+type SectionInstructorResponse struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+// SectionSlotResponse is a weekly timetable slot.
+type SectionSlotResponse struct {
+	ID        uuid.UUID `json:"id"`
+	DayOfWeek int       `json:"day_of_week"`
+	StartTime string    `json:"start_time"`
+	EndTime   string    `json:"end_time"`
+	Room      string    `json:"room"`
+}
+
+// SectionResponse is returned for section endpoints.
+type SectionResponse struct {
+	ID               uuid.UUID                   `json:"id"`
+	CourseInstanceID uuid.UUID                   `json:"course_instance_id"`
+	Kind             string                      `json:"kind"`
+	Name             string                      `json:"name"`
+	Capacity         int                         `json:"capacity"`
+	BatchID          *uuid.UUID                  `json:"batch_id,omitempty"`
+	MemberCount      int                         `json:"member_count"`
+	Instructors      []SectionInstructorResponse `json:"instructors"`
+	Slots            []SectionSlotResponse       `json:"slots"`
+	CreatedAt        time.Time                   `json:"created_at"`
+	UpdatedAt        time.Time                   `json:"updated_at"`
+}
+
+// SectionMemberResponse is a student placed in a section.
+type SectionMemberResponse struct {
+	UserID     uuid.UUID `json:"user_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
@@ -10,24 +10,33 @@ import (
 // Semester DTOs
 // ─────────────────────────────────────────────────────────────────────────────
 
-// CreateSemesterRequest is the payload for POST /semesters
+// CreateSemesterRequest is the payload for POST /semesters.
+// All dates are YYYY-MM-DD; the key dates are optional.
 type CreateSemesterRequest struct {
-	Name      string `json:"name"`
-	Code      string `json:"code"`
-	TermType  string `json:"term_type"`
-	StartDate string `json:"start_date"`
-	EndDate   string `json:"end_date"`
-	Status    string `json:"status"`
+	Name                    string `json:"name"`
+	Code                    string `json:"code"`
+	TermType                string `json:"term_type"`
+	StartDate               string `json:"start_date"`
+	EndDate                 string `json:"end_date"`
+	Status                  string `json:"status"`
+	AddDropDeadline         string `json:"add_drop_deadline"`
+	ExamStartDate           string `json:"exam_start_date"`
+	ExamEndDate             string `json:"exam_end_date"`
+	GradeSubmissionDeadline string `json:"grade_submission_deadline"`
 }
 
 // UpdateSemesterRequest is the payload for PUT /semesters/:id
 type UpdateSemesterRequest struct {
-	Name      string `json:"name"`
-	TermType  string `json:"term_type"`
-	StartDate string `json:"start_date"`
-	EndDate   string `json:"end_date"`
-	Status    string `json:"status"`
-	IsActive  *bool  `json:"is_active"`
+	Name                    string `json:"name"`
+	TermType                string `json:"term_type"`
+	StartDate               string `json:"start_date"`
+	EndDate                 string `json:"end_date"`
+	Status                  string `json:"status"`
+	IsActive                *bool  `json:"is_active"`
+	AddDropDeadline         string `json:"add_drop_deadline"`
+	ExamStartDate           string `json:"exam_start_date"`
+	ExamEndDate             string `json:"exam_end_date"`
+	GradeSubmissionDeadline string `json:"grade_submission_deadline"`
 }
 
 // SemesterResponse is returned for semester endpoints
@@ -40,6 +49,12 @@ type SemesterResponse struct {
 	EndDate   string    `json:"end_date"`
 	Status    string    `json:"status"`
 	IsActive  bool      `json:"is_active"`
+
+	AddDropDeadline         string `json:"add_drop_deadline,omitempty"`
+	ExamStartDate           string `json:"exam_start_date,omitempty"`
+	ExamEndDate             string `json:"exam_end_date,omitempty"`
+	GradeSubmissionDeadline string `json:"grade_submission_deadline,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -49,3 +64,83 @@ type ListSemestersQuery struct {
 	IncludeInactive bool   `query:"include_inactive"`
 	TermType        string `query:"term_type"`
 }
+
+// SemesterTransitionResponse describes one status change made by the
+// academic calendar scheduler.
+type SemesterTransitionResponse struct {
+	SemesterID             uuid.UUID `json:"semester_id"`
+	Code                   string    `json:"code"`
+	From                   string    `json:"from"`
+	To                     string    `json:"to"`
+	CourseInstancesUpdated int64     `json:"course_instances_updated"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Semester rollover DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RolloverBatchMapping moves the course instances of one batch to another
+// batch in the target semester, e.g. when a cohort advances a year.
+type RolloverBatchMapping struct {
+	FromBatchID uuid.UUID `json:"from_batch_id"`
+	ToBatchID   uuid.UUID `json:"to_batch_id"`
+}
+
+// RolloverRequest is the payload for POST /semesters/rollover.
+// CourseIDs limits the rollover to those courses; empty copies every course
+// instance of the source semester. Batches without a mapping keep their batch.
+type RolloverRequest struct {
+	SourceSemesterID uuid.UUID              `json:"source_semester_id"`
+	TargetSemesterID uuid.UUID              `json:"target_semester_id"`
+	CourseIDs        []uuid.UUID            `json:"course_ids"`
+	BatchMappings    []RolloverBatchMapping `json:"batch_mappings"`
+	CopyInstructors  bool                   `json:"copy_instructors"`
+	CopyAssignments  bool                   `json:"copy_assignments"`
+	DryRun           bool                   `json:"dry_run"`
+}
+
+// RolloverInstanceResponse describes one course instance of the rollover.
+// Action is "create" for a new instance or "existing" when the target
+// semester already has an instance for the course and batch.
+// TargetCourseInstanceID is empty for instances a dry run would create.
+type RolloverInstanceResponse struct {
+	SourceCourseInstanceID uuid.UUID  `json:"source_course_instance_id"`
+	TargetCourseInstanceID *uuid.UUID `json:"target_course_instance_id,omitempty"`
+	CourseID               uuid.UUID  `json:"course_id"`
+	SourceBatchID          uuid.UUID  `json:"source_batch_id"`
+	TargetBatchID          uuid.UUID  `json:"target_batch_id"`
+	Action                 string     `json:"action"`
+	InstructorsCopied      int        `json:"instructors_copied"`
+}
+
+// RolloverAssignmentResponse describes one assignment copied by the rollover.
+// SectionsDropped counts the source's section targets, which the copy does
+// not keep because sections are not rolled over.
+type RolloverAssignmentResponse struct {
+	SourceAssignmentID     uuid.UUID  `json:"source_assignment_id"`
+	TargetAssignmentID     *uuid.UUID `json:"target_assignment_id,omitempty"`
+	SourceCourseInstanceID uuid.UUID  `json:"source_course_instance_id"`
+	Title                  string     `json:"title"`
+	ReleaseAt              *time.Time `json:"release_at,omitempty"`
+	DueAt                  *time.Time `json:"due_at,omitempty"`
+	Skipped                bool       `json:"skipped,omitempty"`
+	SectionsDropped        int        `json:"sections_dropped,omitempty"`
+}
+
+// RolloverResponse summarises a semester rollover or its preview.
+// AssignmentError is set when the course instances were copied but the
+// assessment service could not clone the assignments; re-running the
+// rollover retries them without duplicating anything.
+type RolloverResponse struct {
+	DryRun             bool                         `json:"dry_run"`
+	SourceSemesterID   uuid.UUID                    `json:"source_semester_id"`
+	TargetSemesterID   uuid.UUID                    `json:"target_semester_id"`
+	OffsetDays         int                          `json:"offset_days"`
+	InstancesCreated   int                          `json:"instances_created"`
+	InstructorsCopied  int                          `json:"instructors_copied"`
+	AssignmentsCloned  int                          `json:"assignments_cloned"`
+	AssignmentsSkipped int                          `json:"assignments_skipped"`
+	Instances          []RolloverInstanceResponse   `json:"instances"`
+	Assignments        []RolloverAssignmentResponse `json:"assignments,omitempty"`
+	AssignmentError    string                       `json:"assignment_error,omitempty"`
+}
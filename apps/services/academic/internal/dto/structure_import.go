@@ -0,0 +1,52 @@
+package dto
+
+// StructureRow is one line of an academic structure import or export. Type
+// selects the record and which columns apply:
+//
+//   - faculty: Code, Name, Description
+//   - faculty_leader: Parent Code (faculty), User ID, Role
+//   - department: Code, Name, Parent Code (faculty), Description
+//   - degree: Code, Name, Parent Code (department), Level
+//   - specialization: Code, Name, Parent Code (degree)
+//   - course: Code, Name (title), Description, Credits
+//   - batch: Code, Name, Degree Code, Parent Code (parent batch),
+//     Specialization Code, Start Year, End Year
+//   - batch_member: Parent Code (batch), Degree Code, User ID, Status
+type StructureRow struct {
+	Type               string `json:"type"`
+	Code               string `json:"code,omitempty"`
+	Name               string `json:"name,omitempty"`
+	ParentCode         string `json:"parent_code,omitempty"`
+	DegreeCode         string `json:"degree_code,omitempty"`
+	SpecializationCode string `json:"specialization_code,omitempty"`
+	Description        string `json:"description,omitempty"`
+	Level              string `json:"level,omitempty"`
+	Credits            string `json:"credits,omitempty"`
+	StartYear          string `json:"start_year,omitempty"`
+	EndYear            string `json:"end_year,omitempty"`
+	UserID             string `json:"user_id,omitempty"`
+	Role               string `json:"role,omitempty"`
+	Status             string `json:"status,omitempty"`
+}
+
+// StructureRowResult reports what an import does with one row. Action is
+// create, exists (already present and left unchanged) or invalid.
+type StructureRowResult struct {
+	RowIndex int          `json:"row_index"`
+	Data     StructureRow `json:"data"`
+	Action   string       `json:"action"`
+	Errors   []string     `json:"errors,omitempty"`
+}
+
+// StructureImportResponse is returned by the preview and execute endpoints.
+// Applied is true only when execute wrote the rows; an import with any
+// invalid row writes nothing.
+type StructureImportResponse struct {
+	Applied      bool                 `json:"applied"`
+	TotalRows    int                  `json:"total_rows"`
+	CreateRows   int                  `json:"create_rows"`
+	ExistingRows int                  `json:"existing_rows"`
+	InvalidRows  int                  `json:"invalid_rows"`
+	Created      map[string]int       `json:"created"`
+	Rows         []StructureRowResult `json:"rows"`
+}
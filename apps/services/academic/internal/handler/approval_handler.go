@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ApprovalHandler handles approval requests. Requests are raised under the
+// course instance they concern; they are listed, decided and withdrawn under
+// /approvals, where the service checks the caller may do so.
+type ApprovalHandler struct {
+	approvalService         service.ApprovalService
+	courseInstructorService service.CourseInstructorService
+	logger                  *zap.Logger
+}
+
+// NewApprovalHandler creates a new ApprovalHandler.
+func NewApprovalHandler(
+	approvalService service.ApprovalService,
+	courseInstructorService service.CourseInstructorService,
+	logger *zap.Logger,
+) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService:         approvalService,
+		courseInstructorService: courseInstructorService,
+		logger:                  logger,
+	}
+}
+
+// RequireGradingInstructor returns middleware that checks the caller teaches
+// the course instance in the route and may change its grades, so TAs are
+// rejected.
+func (h *ApprovalHandler) RequireGradingInstructor() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if _, err := authorizeCourseStaff(c, h.courseInstructorService, "teaching assistants cannot change final grades"); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Raising requests
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RequestGradeChange handles
+// POST /instructor-courses/:id/final-grades/:userID/change-requests and
+// POST /course-instances/:id/final-grades/:userID/change-requests
+func (h *ApprovalHandler) RequestGradeChange(c fiber.Ctx) error {
+	callerID, err := instructorUserID(c)
+	if err != nil {
+		return err
+	}
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+
+	var req dto.RequestGradeChangeRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	request, err := h.approvalService.RequestGradeChange(instanceID, userID, &req, callerID, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(request)
+}
+
+// RequestCancellation handles POST /course-instances/:id/cancellation-requests
+func (h *ApprovalHandler) RequestCancellation(c fiber.Ctx) error {
+	callerID, err := instructorUserID(c)
+	if err != nil {
+		return err
+	}
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.RequestCancellationRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	request, err := h.approvalService.RequestCancellation(instanceID, &req, callerID, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(request)
+}
+
+// RequestEnrollmentOverride handles
+// POST /course-instances/:id/enrollment-overrides
+func (h *ApprovalHandler) RequestEnrollmentOverride(c fiber.Ctx) error {
+	callerID, err := instructorUserID(c)
+	if err != nil {
+		return err
+	}
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.RequestEnrollmentOverrideRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	request, err := h.approvalService.RequestEnrollmentOverride(instanceID, &req, callerID, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(request)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Listing
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListApprovals handles GET /approvals (admin)
+func (h *ApprovalHandler) ListApprovals(c fiber.Ctx) error {
+	facultyID, err := optionalUUIDQuery(c, "faculty_id")
+	if err != nil {
+		return err
+	}
+	return h.list(c, facultyID, nil)
+}
+
+// ListFacultyApprovals handles GET /faculty-leadership/:facultyID/approvals
+func (h *ApprovalHandler) ListFacultyApprovals(c fiber.Ctx) error {
+	facultyID, err := parseUUID(c, "facultyID")
+	if err != nil {
+		return err
+	}
+	return h.list(c, &facultyID, nil)
+}
+
+// ListMyApprovals handles GET /approvals/mine, the caller's own requests.
+func (h *ApprovalHandler) ListMyApprovals(c fiber.Ctx) error {
+	callerID, err := instructorUserID(c)
+	if err != nil {
+		return err
+	}
+	return h.list(c, nil, &callerID)
+}
+
+func (h *ApprovalHandler) list(c fiber.Ctx, facultyID, requestedByID *uuid.UUID) error {
+	var query dto.ListApprovalsQuery
+	if err := c.Bind().Query(&query); err != nil {
+		return utils.ErrBadRequest("invalid query parameters")
+	}
+
+	approvals, err := h.approvalService.ListApprovals(facultyID, requestedByID, &query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"approvals": approvals,
+		"count":     len(approvals),
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /approvals/:id
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetApproval handles GET /approvals/:id
+func (h *ApprovalHandler) GetApproval(c fiber.Ctx) error {
+	callerID, err := instructorUserID(c)
+	if err != nil {
+		return err
+	}
+	id, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	request, err := h.approvalService.GetApproval(id, callerID, isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(request)
+}
+
+// Approve handles POST /approvals/:id/approve
+func (h *ApprovalHandler) Approve(c fiber.Ctx) error {
+	callerID, id, req, err := decisionParams(c)
+	if err != nil {
+		return err
+	}
+
+	request, err := h.approvalService.Approve(id, req, callerID, isAdmin(c), requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(request)
+}
+
+// Reject handles POST /approvals/:id/reject
+func (h *ApprovalHandler) Reject(c fiber.Ctx) error {
+	callerID, id, req, err := decisionParams(c)
+	if err != nil {
+		return err
+	}
+
+	request, err := h.approvalService.Reject(id, req, callerID, isAdmin(c), requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(request)
+}
+
+// Withdraw handles POST /approvals/:id/withdraw
+func (h *ApprovalHandler) Withdraw(c fiber.Ctx) error {
+	callerID, err := instructorUserID(c)
+	if err != nil {
+		return err
+	}
+	id, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	request, err := h.approvalService.Withdraw(id, callerID, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(request)
+}
+
+// decisionParams reads the caller, the request ID and the optional decision
+// body.
+func decisionParams(c fiber.Ctx) (uuid.UUID, uuid.UUID, *dto.DecideApprovalRequest, error) {
+	callerID, err := instructorUserID(c)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, nil, err
+	}
+	id, err := parseUUID(c, "id")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, nil, err
+	}
+
+	var req dto.DecideApprovalRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(&req); err != nil {
+			return uuid.Nil, uuid.Nil, nil, utils.ErrBadRequest("invalid request body")
+		}
+	}
+	return callerID, id, &req, nil
+}
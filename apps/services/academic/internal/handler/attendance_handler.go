@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AttendanceHandler handles attendance sessions, marking, self check-in and
+// reports. Staff routes live under /instructor-courses/:id/attendance, where
+// RequireCourseStaff guards access; admins read reports under
+// /course-instances/:id/attendance and students check in under
+// /student-courses/:id/attendance.
+type AttendanceHandler struct {
+	attendanceService       service.AttendanceService
+	courseInstructorService service.CourseInstructorService
+	logger                  *zap.Logger
+}
+
+// NewAttendanceHandler creates a new AttendanceHandler.
+func NewAttendanceHandler(
+	attendanceService service.AttendanceService,
+	courseInstructorService service.CourseInstructorService,
+	logger *zap.Logger,
+) *AttendanceHandler {
+	return &AttendanceHandler{
+		attendanceService:       attendanceService,
+		courseInstructorService: courseInstructorService,
+		logger:                  logger,
+	}
+}
+
+// RequireCourseStaff returns middleware that checks the caller teaches the
+// course instance in the route. TAs take attendance too, so any role passes.
+func (h *AttendanceHandler) RequireCourseStaff() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if _, err := authorizeCourseStaff(c, h.courseInstructorService, ""); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /instructor-courses/:id/attendance/sessions
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CreateSession handles POST /instructor-courses/:id/attendance/sessions
+func (h *AttendanceHandler) CreateSession(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateAttendanceSessionRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	session, err := h.attendanceService.CreateSession(instanceID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+// ListSessions handles GET /instructor-courses/:id/attendance/sessions
+func (h *AttendanceHandler) ListSessions(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	sessions, err := h.attendanceService.ListSessions(instanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"sessions": sessions,
+		"count":    len(sessions),
+	})
+}
+
+// GetSession handles GET /instructor-courses/:id/attendance/sessions/:sessionID
+func (h *AttendanceHandler) GetSession(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := h.attendanceService.GetSession(instanceID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// UpdateSession handles PUT /instructor-courses/:id/attendance/sessions/:sessionID
+func (h *AttendanceHandler) UpdateSession(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateAttendanceSessionRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	session, err := h.attendanceService.UpdateSession(instanceID, sessionID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// DeleteSession handles DELETE /instructor-courses/:id/attendance/sessions/:sessionID
+func (h *AttendanceHandler) DeleteSession(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	if err := h.attendanceService.DeleteSession(instanceID, sessionID, username, c.IP(), c.Get("User-Agent")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "attendance session deleted successfully",
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /instructor-courses/:id/attendance/sessions/:sessionID/records
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MarkAttendance handles
+// PUT /instructor-courses/:id/attendance/sessions/:sessionID/records/:userID
+func (h *AttendanceHandler) MarkAttendance(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+
+	var req dto.MarkAttendanceRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	record, err := h.attendanceService.MarkAttendance(instanceID, sessionID, userID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(record)
+}
+
+// BulkMarkAttendance handles
+// POST /instructor-courses/:id/attendance/sessions/:sessionID/records
+func (h *AttendanceHandler) BulkMarkAttendance(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.BulkMarkAttendanceRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	result, err := h.attendanceService.BulkMarkAttendance(instanceID, sessionID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /instructor-courses/:id/attendance/sessions/:sessionID/check-in
+// ─────────────────────────────────────────────────────────────────────────────
+
+// OpenCheckIn handles
+// POST /instructor-courses/:id/attendance/sessions/:sessionID/check-in
+func (h *AttendanceHandler) OpenCheckIn(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.OpenCheckInRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(&req); err != nil {
+			return utils.ErrBadRequest("invalid request body")
+		}
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	code, err := h.attendanceService.OpenCheckIn(instanceID, sessionID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(code)
+}
+
+// GetCheckInCode handles
+// GET /instructor-courses/:id/attendance/sessions/:sessionID/check-in.
+// The projected code rotates, so clients poll this until ExpiresAt.
+func (h *AttendanceHandler) GetCheckInCode(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+
+	code, err := h.attendanceService.GetCheckInCode(instanceID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(code)
+}
+
+// CloseCheckIn handles
+// DELETE /instructor-courses/:id/attendance/sessions/:sessionID/check-in
+func (h *AttendanceHandler) CloseCheckIn(c fiber.Ctx) error {
+	instanceID, sessionID, err := attendanceSessionParams(c)
+	if err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	if err := h.attendanceService.CloseCheckIn(instanceID, sessionID, username, c.IP(), c.Get("User-Agent")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "check-in closed successfully",
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Reports
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetCourseReport handles GET /course-instances/:id/attendance/report and
+// GET /instructor-courses/:id/attendance/report
+func (h *AttendanceHandler) GetCourseReport(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	report, err := h.attendanceService.CourseReport(instanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// GetStudentReport handles GET /course-instances/:id/attendance/report/:userID
+// and GET /instructor-courses/:id/attendance/report/:userID
+func (h *AttendanceHandler) GetStudentReport(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+
+	report, err := h.attendanceService.StudentReport(instanceID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /student-courses/:id/attendance
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CheckIn handles POST /student-courses/:id/attendance/check-in
+func (h *AttendanceHandler) CheckIn(c fiber.Ctx) error {
+	userID, err := studentUserID(c)
+	if err != nil {
+		return err
+	}
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CheckInRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	record, err := h.attendanceService.CheckIn(instanceID, userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(record)
+}
+
+// GetMyAttendance handles GET /student-courses/:id/attendance
+func (h *AttendanceHandler) GetMyAttendance(c fiber.Ctx) error {
+	userID, err := studentUserID(c)
+	if err != nil {
+		return err
+	}
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	report, err := h.attendanceService.StudentReport(instanceID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+func attendanceSessionParams(c fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	sessionID, err := parseUUID(c, "sessionID")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return instanceID, sessionID, nil
+}
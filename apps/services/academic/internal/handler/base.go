@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
 )
 
 type Handler interface {
@@ -28,3 +32,75 @@ func requireUsername(c fiber.Ctx) string {
 	username, _ := c.Locals("username").(string)
 	return username
 }
+
+// isAdmin reports whether the caller has the admin user type.
+func isAdmin(c fiber.Ctx) bool {
+	userType, _ := c.Locals("user_type").(string)
+	return userType == "admin"
+}
+
+// courseRole returns the user's teaching role on the course instance, or a
+// 403 when they are not assigned to it.
+func courseRole(instructors service.CourseInstructorService, instanceID, userID uuid.UUID) (string, error) {
+	staff, err := instructors.GetInstructors(instanceID)
+	if err != nil {
+		return "", err
+	}
+	for _, inst := range staff {
+		if inst.UserID == userID {
+			return inst.Role, nil
+		}
+	}
+	return "", utils.ErrForbidden("you are not assigned to this course instance")
+}
+
+// verifyCourseStaff checks the user teaches the course instance. A non-empty
+// taDenied rejects teaching assistants with that message.
+func verifyCourseStaff(instructors service.CourseInstructorService, instanceID, userID uuid.UUID, taDenied string) error {
+	role, err := courseRole(instructors, instanceID, userID)
+	if err != nil {
+		return err
+	}
+	if taDenied != "" && role == domain.InstructorRoleTA {
+		return utils.ErrForbidden(taDenied)
+	}
+	return nil
+}
+
+// authorizeCourseStaff runs verifyCourseStaff for the caller and the course
+// instance in the :id route parameter, and returns that instance.
+func authorizeCourseStaff(c fiber.Ctx, instructors service.CourseInstructorService, taDenied string) (uuid.UUID, error) {
+	userID, err := instructorUserID(c)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := verifyCourseStaff(instructors, instanceID, userID, taDenied); err != nil {
+		return uuid.Nil, err
+	}
+	return instanceID, nil
+}
+
+// parseListQuery parses the paging, filter and sort parameters of a list
+// request against the list's schema.
+func parseListQuery(c fiber.Ctx, schema queryspec.Schema) (queryspec.Spec, error) {
+	spec, err := schema.Parse(c.Queries())
+	if err != nil {
+		return queryspec.Spec{}, utils.ErrBadRequest(err.Error())
+	}
+	return spec, nil
+}
+
+// pageResponse is the envelope of a paginated list: the items under key, with
+// the page's count, the total across pages and the next page's cursor.
+func pageResponse(key string, items any, meta queryspec.Meta) fiber.Map {
+	return fiber.Map{
+		key:           items,
+		"count":       meta.Count,
+		"total":       meta.Total,
+		"next_cursor": meta.NextCursor,
+	}
+}
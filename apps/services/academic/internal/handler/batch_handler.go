@@ -143,20 +143,22 @@ func (h *BatchHandler) ListBatches(c fiber.Ctx) error {
 		return utils.ErrBadRequest("invalid query parameters")
 	}
 
-	batches, err := h.batchService.ListBatches(query.IncludeInactive)
+	spec, err := parseListQuery(c, dto.ListBatchesSchema)
 	if err != nil {
 		return err
 	}
 
-	responses := make([]dto.BatchResponse, len(batches))
-	for i, b := range batches {
+	page, err := h.batchService.ListBatchesPage(query.IncludeInactive, spec)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.BatchResponse, len(page.Items))
+	for i, b := range page.Items {
 		responses[i] = *h.toBatchResponse(&b)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"batches": responses,
-		"count":   len(responses),
-	})
+	return c.Status(fiber.StatusOK).JSON(pageResponse("batches", responses, page.Meta()))
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
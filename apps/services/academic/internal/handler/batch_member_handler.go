@@ -2,12 +2,15 @@ package handler
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -70,6 +73,91 @@ func (h *BatchMemberHandler) AddMembersToBatch(c fiber.Ctx) error {
 	})
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /batch-members/move
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MoveBatchMember handles POST /batch-members/move
+func (h *BatchMemberHandler) MoveBatchMember(c fiber.Ctx) error {
+	var req dto.MoveBatchMemberRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	resp, err := h.batchMemberService.MoveBatchMember(&req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /internal/batch-members
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AddBatchMemberInternal handles POST /internal/batch-members for other
+// services, such as the IAM bulk user import. An existing membership is not an
+// error, so a retried import does not fail on rows it already placed.
+func (h *BatchMemberHandler) AddBatchMemberInternal(c fiber.Ctx) error {
+	var req dto.AddBatchMemberRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	if req.BatchID != uuid.Nil && req.UserID != uuid.Nil {
+		isMember, err := h.batchMemberService.IsMember(req.BatchID, req.UserID)
+		if err != nil {
+			return err
+		}
+		if isMember {
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"batch_id":       req.BatchID,
+				"user_id":        req.UserID,
+				"already_member": true,
+			})
+		}
+	}
+
+	clientID, _ := c.Locals(servicetoken.LocalsClientID).(string)
+
+	member, err := h.batchMemberService.AddBatchMember(&req, clientID, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toBatchMemberResponse(member))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /internal/graduated-members?max_end_year=
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListGraduatedMembers handles GET /internal/graduated-members for the IAM
+// lifecycle job. It returns users whose batches all ended in or before
+// max_end_year.
+func (h *BatchMemberHandler) ListGraduatedMembers(c fiber.Ctx) error {
+	maxEndYear, err := strconv.Atoi(c.Query("max_end_year"))
+	if err != nil {
+		return utils.ErrBadRequest("max_end_year must be a year")
+	}
+
+	users, err := h.batchMemberService.ListGraduatedUsers(maxEndYear)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"members": users,
+		"count":   len(users),
+	})
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // GET /batches/:id/members
 // ─────────────────────────────────────────────────────────────────────────────
@@ -58,20 +58,22 @@ func (h *CourseHandler) ListCourses(c fiber.Ctx) error {
 		return utils.ErrBadRequest("invalid query parameters")
 	}
 
-	courses, err := h.courseService.ListCourses(query.IncludeInactive)
+	spec, err := parseListQuery(c, dto.ListCoursesSchema)
 	if err != nil {
 		return err
 	}
 
-	responses := make([]dto.CourseResponse, len(courses))
-	for i, course := range courses {
+	page, err := h.courseService.ListCourses(query.IncludeInactive, spec)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.CourseResponse, len(page.Items))
+	for i, course := range page.Items {
 		responses[i] = *toCourseResponse(&course)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"courses": responses,
-		"count":   len(responses),
-	})
+	return c.Status(fiber.StatusOK).JSON(pageResponse("courses", responses, page.Meta()))
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -254,6 +256,9 @@ func toCoursePrerequisiteResponse(p *domain.CoursePrerequisite) *dto.CoursePrere
 	resp := &dto.CoursePrerequisiteResponse{
 		CourseID:             p.CourseID,
 		PrerequisiteCourseID: p.PrerequisiteCourseID,
+		Kind:                 p.Kind,
+		Group:                p.Group,
+		MinGrade:             p.MinGrade,
 	}
 	if p.PrerequisiteCourse != nil {
 		cr := toCourseResponse(p.PrerequisiteCourse)
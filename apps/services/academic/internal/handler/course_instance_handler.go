@@ -167,7 +167,12 @@ func toCourseInstanceResponse(ci *domain.CourseInstance) *dto.CourseInstanceResp
 		BatchID:       ci.BatchID,
 		Status:        ci.Status,
 		MaxEnrollment: ci.MaxEnrollment,
-		CreatedAt:     ci.CreatedAt,
-		UpdatedAt:     ci.UpdatedAt,
+
+		IncludeSubBatches: ci.IncludeSubBatches,
+
+		AttendanceThreshold: ci.AttendanceThreshold,
+
+		CreatedAt: ci.CreatedAt,
+		UpdatedAt: ci.UpdatedAt,
 	}
 }
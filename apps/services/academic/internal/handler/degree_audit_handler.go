@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DegreeAuditHandler handles degree requirement and degree audit requests.
+type DegreeAuditHandler struct {
+	degreeAuditService service.DegreeAuditService
+	logger             *zap.Logger
+}
+
+// NewDegreeAuditHandler creates a new DegreeAuditHandler.
+func NewDegreeAuditHandler(degreeAuditService service.DegreeAuditService, logger *zap.Logger) *DegreeAuditHandler {
+	return &DegreeAuditHandler{
+		degreeAuditService: degreeAuditService,
+		logger:             logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/degrees/:id/requirements
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListRequirements returns every requirement rule of the degree, including
+// the specialization-specific ones.
+func (h *DegreeAuditHandler) ListRequirements(c fiber.Ctx) error {
+	degreeID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	rules, err := h.degreeAuditService.ListRequirements(degreeID)
+	if err != nil {
+		return err
+	}
+
+	resp := dto.ListDegreeRequirementsResponse{
+		Requirements: make([]dto.DegreeRequirementResponse, len(rules)),
+		Count:        len(rules),
+	}
+	for i := range rules {
+		resp.Requirements[i] = toDegreeRequirementResponse(&rules[i])
+	}
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /api/v1/degrees/:id/requirements
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CreateRequirement adds a requirement rule to the degree.
+func (h *DegreeAuditHandler) CreateRequirement(c fiber.Ctx) error {
+	degreeID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.DegreeRequirementRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	rule, err := h.degreeAuditService.CreateRequirement(degreeID, &req, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toDegreeRequirementResponse(rule))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PUT /api/v1/degrees/:id/requirements/:requirementID
+// ─────────────────────────────────────────────────────────────────────────────
+
+// UpdateRequirement replaces a requirement rule, including its course list.
+func (h *DegreeAuditHandler) UpdateRequirement(c fiber.Ctx) error {
+	degreeID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+	requirementID, err := parseUUID(c, "requirementID")
+	if err != nil {
+		return err
+	}
+
+	var req dto.DegreeRequirementRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	rule, err := h.degreeAuditService.UpdateRequirement(degreeID, requirementID, &req, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toDegreeRequirementResponse(rule))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// DELETE /api/v1/degrees/:id/requirements/:requirementID
+// ─────────────────────────────────────────────────────────────────────────────
+
+// DeleteRequirement removes a requirement rule.
+func (h *DegreeAuditHandler) DeleteRequirement(c fiber.Ctx) error {
+	degreeID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+	requirementID, err := parseUUID(c, "requirementID")
+	if err != nil {
+		return err
+	}
+
+	if err := h.degreeAuditService.DeleteRequirement(degreeID, requirementID, requireUsername(c), c.IP(), c.Get("User-Agent")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "degree requirement deleted successfully",
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Degree audits
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetDegreeAudit handles GET /api/v1/degree-audits/:userID for advisors.
+// Pass ?degree_id= and ?specialization_id= to audit against a programme
+// other than the one the student's batches belong to.
+func (h *DegreeAuditHandler) GetDegreeAudit(c fiber.Ctx) error {
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+	return h.sendAudit(c, userID)
+}
+
+// StudentScopes scopes GET /api/v1/degree-audits/:userID to the departments
+// and faculties of the student's degrees, so an advisor role assigned on one
+// of them grants degree_audit:read for that student only.
+func (h *DegreeAuditHandler) StudentScopes(c fiber.Ctx) ([]authz.Scope, error) {
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return nil, err
+	}
+	departmentIDs, facultyIDs, err := h.degreeAuditService.ProgrammeUnits(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]authz.Scope, 0, len(departmentIDs)+len(facultyIDs))
+	for _, id := range departmentIDs {
+		scopes = append(scopes, authz.Scope{Type: authz.ScopeDepartment, ID: id.String()})
+	}
+	for _, id := range facultyIDs {
+		scopes = append(scopes, authz.Scope{Type: authz.ScopeFaculty, ID: id.String()})
+	}
+	return scopes, nil
+}
+
+// GetMyDegreeAudit handles GET /api/v1/student-courses/me/degree-audit.
+func (h *DegreeAuditHandler) GetMyDegreeAudit(c fiber.Ctx) error {
+	userID, err := studentUserID(c)
+	if err != nil {
+		return err
+	}
+	return h.sendAudit(c, userID)
+}
+
+func (h *DegreeAuditHandler) sendAudit(c fiber.Ctx, userID uuid.UUID) error {
+	degreeID, err := optionalUUIDQuery(c, "degree_id")
+	if err != nil {
+		return err
+	}
+	specializationID, err := optionalUUIDQuery(c, "specialization_id")
+	if err != nil {
+		return err
+	}
+
+	audit, err := h.degreeAuditService.AuditStudent(userID, degreeID, specializationID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(audit)
+}
+
+// optionalUUIDQuery parses a UUID query parameter, returning nil when it is
+// absent.
+func optionalUUIDQuery(c fiber.Ctx, name string) (*uuid.UUID, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, utils.ErrBadRequest("invalid " + name + " (must be a valid UUID)")
+	}
+	return &id, nil
+}
+
+func toDegreeRequirementResponse(r *domain.DegreeRequirement) dto.DegreeRequirementResponse {
+	resp := dto.DegreeRequirementResponse{
+		ID:               r.ID,
+		DegreeID:         r.DegreeID,
+		SpecializationID: r.SpecializationID,
+		Kind:             r.Kind,
+		Name:             r.Name,
+		MinCredits:       r.MinCredits,
+		MinGrade:         r.MinGrade,
+		Courses:          make([]dto.RequirementCourseResponse, len(r.Courses)),
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+	for i, c := range r.Courses {
+		resp.Courses[i] = dto.RequirementCourseResponse{CourseID: c.CourseID}
+		if c.Course != nil {
+			resp.Courses[i].Code = c.Course.Code
+			resp.Courses[i].Title = c.Course.Title
+			resp.Courses[i].Credits = c.Course.Credits
+		}
+	}
+	return resp
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
 	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -63,12 +64,51 @@ func (h *EnrollmentHandler) GetEnrollments(c fiber.Ctx) error {
 	// Extract authorization token for IAM user lookup
 	token := c.Get("Authorization")
 
+	spec, err := parseListQuery(c, dto.ListEnrollmentsSchema)
+	if err != nil {
+		return err
+	}
+
 	// Use GetEnrollmentsDetailed to fetch user info from IAM
-	responses, err := h.enrollmentService.GetEnrollmentsDetailed(context.Background(), instanceID, token)
+	page, err := h.enrollmentService.GetEnrollmentsDetailed(context.Background(), instanceID, token, spec)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(pageResponse("enrollments", page.Items, page.Meta()))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /internal/enrollments?user_id=&course_instance_id=
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CheckEnrollment handles GET /internal/enrollments for other services. It
+// returns the user's active enrollments in the course instance, including
+// those implied by batch membership. Callers need a service token with the
+// academic.enrollments:read scope.
+func (h *EnrollmentHandler) CheckEnrollment(c fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Query("user_id"))
+	if err != nil {
+		return utils.ErrBadRequest("user_id must be a valid UUID")
+	}
+	instanceID, err := uuid.Parse(c.Query("course_instance_id"))
+	if err != nil {
+		return utils.ErrBadRequest("course_instance_id must be a valid UUID")
+	}
+
+	enrollments, err := h.enrollmentService.GetMyEnrollments(userID)
 	if err != nil {
 		return err
 	}
 
+	responses := make([]*dto.EnrollmentResponse, 0, 1)
+	for i := range enrollments {
+		e := &enrollments[i]
+		if e.CourseInstanceID == instanceID && e.Status == domain.EnrollmentStatusEnrolled {
+			responses = append(responses, toEnrollmentResponse(e))
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"enrollments": responses,
 		"count":       len(responses),
@@ -120,6 +160,7 @@ func toEnrollmentResponse(e *domain.Enrollment) *dto.EnrollmentResponse {
 		UserID:           e.UserID,
 		Status:           e.Status,
 		FinalGrade:       e.FinalGrade,
+		WaitlistPosition: e.WaitlistPosition,
 		EnrolledAt:       e.EnrolledAt,
 	}
 }
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// EventHandler serves stored domain events and replays.
+type EventHandler struct {
+	eventService service.EventService
+	logger       *zap.Logger
+}
+
+// NewEventHandler creates a new EventHandler.
+func NewEventHandler(eventService service.EventService, logger *zap.Logger) *EventHandler {
+	return &EventHandler{
+		eventService: eventService,
+		logger:       logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /internal/events?after=&aggregate_type=&aggregate_id=&event_type=&limit=
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListEvents handles GET /internal/events for consumers catching up on
+// events they missed. Pass the previous response's next_after as after.
+func (h *EventHandler) ListEvents(c fiber.Ctx) error {
+	var query dto.ListEventsQuery
+	var err error
+	if raw := c.Query("after"); raw != "" {
+		if query.After, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return utils.ErrBadRequest("after must be a sequence number")
+		}
+	}
+	if raw := c.Query("limit"); raw != "" {
+		if query.Limit, err = strconv.Atoi(raw); err != nil {
+			return utils.ErrBadRequest("limit must be a number")
+		}
+	}
+	if query.AggregateID, err = optionalUUIDQuery(c, "aggregate_id"); err != nil {
+		return err
+	}
+	query.AggregateType = c.Query("aggregate_type")
+	query.EventType = c.Query("event_type")
+
+	resp, err := h.eventService.ListEvents(query)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /internal/course-access
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CourseAccessSnapshot handles GET /internal/course-access for services that
+// keep a local read-model of enrollments and instructor assignments.
+func (h *EventHandler) CourseAccessSnapshot(c fiber.Ctx) error {
+	resp, err := h.eventService.CourseAccessSnapshot()
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /events/replay
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ReplayEvents handles POST /events/replay
+func (h *EventHandler) ReplayEvents(c fiber.Ctx) error {
+	var req dto.ReplayEventsRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	resp, err := h.eventService.Replay(&req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
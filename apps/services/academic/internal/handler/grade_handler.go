@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GradeHandler handles grading scheme, final grade and transcript requests.
+// Course-instance routes are authorized from the caller's CourseInstructor
+// assignment; TAs may read but not change grades.
+type GradeHandler struct {
+	finalGradeService       service.FinalGradeService
+	transcriptService       service.TranscriptService
+	courseInstructorService service.CourseInstructorService
+	logger                  *zap.Logger
+}
+
+// NewGradeHandler creates a new GradeHandler.
+func NewGradeHandler(
+	finalGradeService service.FinalGradeService,
+	transcriptService service.TranscriptService,
+	courseInstructorService service.CourseInstructorService,
+	logger *zap.Logger,
+) *GradeHandler {
+	return &GradeHandler{
+		finalGradeService:       finalGradeService,
+		transcriptService:       transcriptService,
+		courseInstructorService: courseInstructorService,
+		logger:                  logger,
+	}
+}
+
+// authorize resolves the course instance from the route and checks the
+// caller teaches it. When write is true, TAs are rejected.
+func (h *GradeHandler) authorize(c fiber.Ctx, write bool) (uuid.UUID, error) {
+	taDenied := ""
+	if write {
+		taDenied = "teaching assistants cannot change final grades"
+	}
+	return authorizeCourseStaff(c, h.courseInstructorService, taDenied)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/instructor-courses/:id/grading-scheme
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetGradingScheme returns the course instance's grading scheme.
+func (h *GradeHandler) GetGradingScheme(c fiber.Ctx) error {
+	instanceID, err := h.authorize(c, false)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := h.finalGradeService.GetGradingScheme(instanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toGradingSchemeResponse(scheme))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PUT /api/v1/instructor-courses/:id/grading-scheme
+// ─────────────────────────────────────────────────────────────────────────────
+
+// SetGradingScheme creates or replaces the course instance's grading scheme
+// until its final grades are locked.
+func (h *GradeHandler) SetGradingScheme(c fiber.Ctx) error {
+	instanceID, err := h.authorize(c, true)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SetGradingSchemeRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	scheme, err := h.finalGradeService.SetGradingScheme(instanceID, &req, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toGradingSchemeResponse(scheme))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/instructor-courses/:id/final-grades
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListFinalGrades returns the current final grades for review.
+func (h *GradeHandler) ListFinalGrades(c fiber.Ctx) error {
+	instanceID, err := h.authorize(c, false)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.finalGradeService.ListFinalGrades(instanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /api/v1/instructor-courses/:id/final-grades/compute
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ComputeFinalGrades recomputes final grades from the Assessment Service's
+// effective scores, returning each student's category breakdown.
+func (h *GradeHandler) ComputeFinalGrades(c fiber.Ctx) error {
+	instanceID, err := h.authorize(c, true)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.finalGradeService.ComputeFinalGrades(c.RequestCtx(), instanceID, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PUT /api/v1/instructor-courses/:id/final-grades/:userID
+// ─────────────────────────────────────────────────────────────────────────────
+
+// OverrideFinalGrade sets a student's letter grade during review.
+func (h *GradeHandler) OverrideFinalGrade(c fiber.Ctx) error {
+	instanceID, err := h.authorize(c, true)
+	if err != nil {
+		return err
+	}
+	studentID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+
+	var req dto.OverrideFinalGradeRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	resp, err := h.finalGradeService.OverrideFinalGrade(instanceID, studentID, &req, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /api/v1/instructor-courses/:id/final-grades/lock
+// ─────────────────────────────────────────────────────────────────────────────
+
+// LockFinalGrades releases the reviewed final grades. This cannot be undone.
+func (h *GradeHandler) LockFinalGrades(c fiber.Ctx) error {
+	instanceID, err := h.authorize(c, true)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.finalGradeService.LockFinalGrades(instanceID, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Transcripts
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetMyTranscript handles GET /api/v1/student-courses/me/transcript.
+// Pass ?format=pdf to download the transcript as a PDF.
+func (h *GradeHandler) GetMyTranscript(c fiber.Ctx) error {
+	userID, err := studentUserID(c)
+	if err != nil {
+		return err
+	}
+	return h.sendTranscript(c, userID)
+}
+
+// GetTranscript handles GET /api/v1/transcripts/:userID for admins.
+// Pass ?format=pdf to download the transcript as a PDF.
+func (h *GradeHandler) GetTranscript(c fiber.Ctx) error {
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+	return h.sendTranscript(c, userID)
+}
+
+func (h *GradeHandler) sendTranscript(c fiber.Ctx, userID uuid.UUID) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "pdf" {
+		return utils.ErrBadRequest("format must be json or pdf")
+	}
+
+	transcript, err := h.transcriptService.GetTranscript(c.RequestCtx(), userID, c.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+
+	if format == "pdf" {
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="transcript-%s.pdf"`, userID))
+		return c.Status(fiber.StatusOK).Send(h.transcriptService.RenderTranscriptPDF(transcript))
+	}
+	return c.Status(fiber.StatusOK).JSON(transcript)
+}
+
+func toGradingSchemeResponse(s *domain.GradingScheme) dto.GradingSchemeResponse {
+	resp := dto.GradingSchemeResponse{
+		CourseInstanceID: s.CourseInstanceID,
+		Categories:       make([]dto.GradingCategoryRequest, len(s.Categories)),
+		Bands:            make([]dto.GradeBandRequest, len(s.Bands)),
+		LockedAt:         s.LockedAt,
+		LockedBy:         s.LockedBy,
+		UpdatedAt:        s.UpdatedAt,
+	}
+	for i, cat := range s.Categories {
+		resp.Categories[i] = dto.GradingCategoryRequest{Name: cat.Name, Weight: cat.Weight}
+	}
+	for i, b := range s.Bands {
+		resp.Bands[i] = dto.GradeBandRequest{Letter: b.Letter, MinPercent: b.MinPercent, GradePoints: b.GradePoints}
+	}
+	return resp
+}
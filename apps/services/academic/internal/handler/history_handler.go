@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// HistoryHandler serves the change history of enrollments and batch
+// memberships. Both endpoints take optional user_id and as_of query
+// parameters; as_of is an RFC 3339 timestamp and turns the history into the
+// roster or membership at that time.
+type HistoryHandler struct {
+	historyService service.HistoryService
+	logger         *zap.Logger
+}
+
+// NewHistoryHandler creates a new HistoryHandler.
+func NewHistoryHandler(historyService service.HistoryService, logger *zap.Logger) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: historyService,
+		logger:         logger,
+	}
+}
+
+// GetEnrollmentHistory handles
+// GET /course-instances/:id/enrollments/history[?user_id=&as_of=]
+func (h *HistoryHandler) GetEnrollmentHistory(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+	userID, asOf, err := historyParams(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := h.historyService.EnrollmentHistory(instanceID, userID, asOf)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"enrollments": versions,
+		"count":       len(versions),
+	})
+}
+
+// GetMemberHistory handles
+// GET /batches/:id/members/history[?user_id=&as_of=]
+func (h *HistoryHandler) GetMemberHistory(c fiber.Ctx) error {
+	batchID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+	userID, asOf, err := historyParams(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := h.historyService.MemberHistory(batchID, userID, asOf)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"members": versions,
+		"count":   len(versions),
+	})
+}
+
+// historyParams reads the optional user_id and as_of query parameters.
+func historyParams(c fiber.Ctx) (*uuid.UUID, *time.Time, error) {
+	userID, err := optionalUUIDQuery(c, "user_id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw := c.Query("as_of")
+	if raw == "" {
+		return userID, nil, nil
+	}
+	asOf, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, nil, utils.ErrBadRequest("invalid as_of (must be an RFC 3339 timestamp)")
+	}
+	asOf = asOf.UTC()
+	return userID, &asOf, nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
@@ -15,7 +16,8 @@ import (
 )
 
 // InstructorHandler handles instructor-scoped HTTP requests.
-// These endpoints are accessible to instructors, admins, and super admins.
+// Access is decided per course instance from the caller's CourseInstructor
+// assignment; TAs get the read-only endpoints for their courses.
 type InstructorHandler struct {
 	courseInstructorService service.CourseInstructorService
 	enrollmentService       service.EnrollmentService
@@ -60,18 +62,16 @@ func instructorUserID(c fiber.Ctx) (uuid.UUID, error) {
 	return id, nil
 }
 
-// verifyInstructorAssignment checks the instructor is assigned to the course instance.
+// verifyCourseStaff checks the caller teaches the course instance in any role,
+// including TA. Used for read-only endpoints.
+func (h *InstructorHandler) verifyCourseStaff(instanceID, userID uuid.UUID) error {
+	return verifyCourseStaff(h.courseInstructorService, instanceID, userID, "")
+}
+
+// verifyInstructorAssignment checks the instructor is assigned to the course
+// instance in a role that may modify it. TAs are rejected.
 func (h *InstructorHandler) verifyInstructorAssignment(instanceID, userID uuid.UUID) error {
-	instructors, err := h.courseInstructorService.GetInstructors(instanceID)
-	if err != nil {
-		return err
-	}
-	for _, inst := range instructors {
-		if inst.UserID == userID {
-			return nil
-		}
-	}
-	return utils.ErrForbidden("you are not assigned to this course instance")
+	return verifyCourseStaff(h.courseInstructorService, instanceID, userID, "teaching assistants cannot modify enrollments")
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -193,6 +193,7 @@ func (h *InstructorHandler) GetMyStudents(c fiber.Ctx) error {
 				UserID:           e.UserID,
 				Status:           e.Status,
 				FinalGrade:       e.FinalGrade,
+				WaitlistPosition: e.WaitlistPosition,
 				EnrolledAt:       e.EnrolledAt,
 			}
 			continue
@@ -206,13 +207,28 @@ func (h *InstructorHandler) GetMyStudents(c fiber.Ctx) error {
 			Email:            userInfo.Email,
 			Status:           e.Status,
 			FinalGrade:       e.FinalGrade,
+			WaitlistPosition: e.WaitlistPosition,
 			EnrolledAt:       e.EnrolledAt,
 		}
 	}
 
+	capacity := dto.CourseCapacityResponse{}
+	if instance, err := h.courseInstructorService.GetCourseInstance(instanceID); err == nil && instance != nil {
+		capacity.MaxEnrollment = instance.MaxEnrollment
+	}
+	for _, e := range enrollments {
+		switch {
+		case e.Status == domain.EnrollmentStatusWaitlisted:
+			capacity.Waitlisted++
+		case domain.OccupiesSeat(e.Status):
+			capacity.SeatsTaken++
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"enrollments": responses,
 		"count":       len(responses),
+		"capacity":    capacity,
 	})
 }
 
@@ -330,8 +346,10 @@ func (h *InstructorHandler) EnrollStudent(c fiber.Ctx) error {
 	}
 
 	var body struct {
-		UserID string `json:"user_id"`
-		Status string `json:"status"`
+		UserID                string `json:"user_id"`
+		Status                string `json:"status"`
+		OverridePrerequisites bool   `json:"override_prerequisites"`
+		OverrideReason        string `json:"override_reason"`
 	}
 	if err := c.Bind().JSON(&body); err != nil {
 		return utils.ErrBadRequest("invalid request body")
@@ -350,10 +368,12 @@ func (h *InstructorHandler) EnrollStudent(c fiber.Ctx) error {
 	username := requireUsername(c)
 
 	enrollment, err := h.enrollmentService.EnrollStudent(&dto.EnrollmentRequest{
-		CourseInstanceID: instanceID,
-		UserID:           enrollUserID,
-		Status:           status,
-		AllowIndividual:  true,
+		CourseInstanceID:      instanceID,
+		UserID:                enrollUserID,
+		Status:                status,
+		AllowIndividual:       true,
+		OverridePrerequisites: body.OverridePrerequisites,
+		OverrideReason:        body.OverrideReason,
 	}, username, c.IP(), c.Get("User-Agent"))
 	if err != nil {
 		return err
@@ -364,6 +384,7 @@ func (h *InstructorHandler) EnrollStudent(c fiber.Ctx) error {
 		UserID:           enrollment.UserID,
 		Status:           enrollment.Status,
 		FinalGrade:       enrollment.FinalGrade,
+		WaitlistPosition: enrollment.WaitlistPosition,
 		EnrolledAt:       enrollment.EnrolledAt,
 	}
 
@@ -416,8 +437,12 @@ func (h *InstructorHandler) UnenrollStudent(c fiber.Ctx) error {
 // POST /api/v1/instructor-courses/:id/enroll-batch
 // ─────────────────────────────────────────────────────────────────────────────
 
-// EnrollBatch enrolls all members of a batch. Already-enrolled students are
-// skipped; partial success details are returned in the response.
+// EnrollBatch enrolls all members of a batch, and of its descendant batches
+// when include_descendants is set. Already-enrolled students are
+// skipped, students who have not met the course's prerequisites are reported
+// as ineligible unless overridden, and students beyond the instance's
+// capacity are waitlisted; partial success details are returned in the
+// response.
 func (h *InstructorHandler) EnrollBatch(c fiber.Ctx) error {
 	userID, err := instructorUserID(c)
 	if err != nil {
@@ -441,48 +466,67 @@ func (h *InstructorHandler) EnrollBatch(c fiber.Ctx) error {
 		return utils.ErrBadRequest("batch_id is required")
 	}
 
-	members, err := h.batchMemberService.GetBatchMembers(req.BatchID)
-	if err != nil {
-		return err
+	var userIDs []uuid.UUID
+	if req.IncludeDescendants {
+		userIDs, err = h.batchMemberService.GetSubtreeMemberIDs(req.BatchID)
+		if err != nil {
+			return err
+		}
+	} else {
+		members, err := h.batchMemberService.GetBatchMembers(req.BatchID)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			userIDs = append(userIDs, member.UserID)
+		}
 	}
 
-	if len(members) == 0 {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"enrolled": 0, "skipped": 0, "total": 0, "skipped_users": []interface{}{},
+	if len(userIDs) == 0 {
+		return c.Status(fiber.StatusOK).JSON(dto.EnrollBatchResponse{
+			SkippedUsers:    []uuid.UUID{},
+			WaitlistedUsers: []uuid.UUID{},
+			IneligibleUsers: []uuid.UUID{},
 		})
 	}
 
 	username := requireUsername(c)
-	enrolled := 0
-	skipped := 0
-	skippedUsers := make([]uuid.UUID, 0)
-
-	for _, member := range members {
-		_, enrollErr := h.enrollmentService.EnrollStudent(&dto.EnrollmentRequest{
-			CourseInstanceID: instanceID,
-			UserID:           member.UserID,
-			Status:           "Enrolled",
-			AllowIndividual:  true,
+	resp := dto.EnrollBatchResponse{
+		Total:           len(userIDs),
+		SkippedUsers:    make([]uuid.UUID, 0),
+		WaitlistedUsers: make([]uuid.UUID, 0),
+		IneligibleUsers: make([]uuid.UUID, 0),
+	}
+
+	for _, memberID := range userIDs {
+		enrollment, enrollErr := h.enrollmentService.EnrollStudent(&dto.EnrollmentRequest{
+			CourseInstanceID:      instanceID,
+			UserID:                memberID,
+			Status:                "Enrolled",
+			AllowIndividual:       true,
+			OverridePrerequisites: req.OverridePrerequisites,
+			OverrideReason:        req.OverrideReason,
 		}, username, c.IP(), c.Get("User-Agent"))
 		if enrollErr != nil {
 			var appErr *utils.AppError
 			if errors.As(enrollErr, &appErr) && appErr.Code == http.StatusConflict {
-				skipped++
-				skippedUsers = append(skippedUsers, member.UserID)
+				resp.Skipped++
+				resp.SkippedUsers = append(resp.SkippedUsers, memberID)
+			} else if errors.As(enrollErr, &appErr) && appErr.Code == http.StatusUnprocessableEntity {
+				resp.Ineligible++
+				resp.IneligibleUsers = append(resp.IneligibleUsers, memberID)
 			} else {
 				return enrollErr
 			}
+		} else if enrollment.Status == domain.EnrollmentStatusWaitlisted {
+			resp.Waitlisted++
+			resp.WaitlistedUsers = append(resp.WaitlistedUsers, memberID)
 		} else {
-			enrolled++
+			resp.Enrolled++
 		}
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"enrolled":      enrolled,
-		"skipped":       skipped,
-		"total":         len(members),
-		"skipped_users": skippedUsers,
-	})
+	return c.Status(fiber.StatusOK).JSON(resp)
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -542,7 +586,7 @@ func (h *InstructorHandler) GetEnrolledBatches(c fiber.Ctx) error {
 		return err
 	}
 
-	if err := h.verifyInstructorAssignment(instanceID, userID); err != nil {
+	if err := h.verifyCourseStaff(instanceID, userID); err != nil {
 		return err
 	}
 
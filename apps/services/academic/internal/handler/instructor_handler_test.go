@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockCourseInstructorService mocks the staff lookups; other methods are not
+// reached by these tests.
+type MockCourseInstructorService struct {
+	service.CourseInstructorService
+	mock.Mock
+}
+
+func (m *MockCourseInstructorService) GetInstructors(instanceID uuid.UUID) ([]domain.CourseInstructor, error) {
+	args := m.Called(instanceID)
+	return args.Get(0).([]domain.CourseInstructor), args.Error(1)
+}
+
+type MockEnrollmentService struct {
+	service.EnrollmentService
+	mock.Mock
+}
+
+func (m *MockEnrollmentService) GetEnrollments(instanceID uuid.UUID) ([]domain.Enrollment, error) {
+	args := m.Called(instanceID)
+	return args.Get(0).([]domain.Enrollment), args.Error(1)
+}
+
+func (m *MockEnrollmentService) RemoveEnrollment(instanceID, userID uuid.UUID, username, ipAddress, userAgent string) error {
+	args := m.Called(instanceID, userID)
+	return args.Error(0)
+}
+
+type MockBatchService struct {
+	service.BatchService
+	mock.Mock
+}
+
+func (m *MockBatchService) ListBatches(includeInactive bool) ([]domain.Batch, error) {
+	args := m.Called(includeInactive)
+	return args.Get(0).([]domain.Batch), args.Error(1)
+}
+
+func setupInstructorTestApp(handler *InstructorHandler, userID uuid.UUID) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: utils.ErrorHandler})
+	app.Use(func(c fiber.Ctx) error {
+		c.Locals("user_id", userID.String())
+		c.Locals("username", "staff@example.com")
+		return c.Next()
+	})
+	app.Get("/instructor-courses/:id/enrolled-batches", handler.GetEnrolledBatches)
+	app.Delete("/instructor-courses/:id/students/:userID", handler.UnenrollStudent)
+	return app
+}
+
+func TestInstructorHandler_TeachingAssistantAccess(t *testing.T) {
+	instanceID := uuid.New()
+	ta, lead, outsider := uuid.New(), uuid.New(), uuid.New()
+	staff := []domain.CourseInstructor{
+		{CourseInstanceID: instanceID, UserID: lead, Role: domain.InstructorRoleLead},
+		{CourseInstanceID: instanceID, UserID: ta, Role: domain.InstructorRoleTA},
+	}
+
+	tests := []struct {
+		name   string
+		caller uuid.UUID
+		method string
+		path   string
+		want   int
+	}{
+		{"TA reads enrolled batches", ta, "GET", "/enrolled-batches", fiber.StatusOK},
+		{"TA cannot unenroll", ta, "DELETE", "/students/" + uuid.NewString(), fiber.StatusForbidden},
+		{"lead instructor unenrolls", lead, "DELETE", "/students/" + uuid.NewString(), fiber.StatusNoContent},
+		{"unassigned user cannot read", outsider, "GET", "/enrolled-batches", fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructors := new(MockCourseInstructorService)
+			instructors.On("GetInstructors", instanceID).Return(staff, nil)
+			enrollments := new(MockEnrollmentService)
+			enrollments.On("GetEnrollments", instanceID).Return([]domain.Enrollment{}, nil).Maybe()
+			enrollments.On("RemoveEnrollment", instanceID, mock.Anything).Return(nil).Maybe()
+			batches := new(MockBatchService)
+			batches.On("ListBatches", false).Return([]domain.Batch{}, nil).Maybe()
+
+			handler := NewInstructorHandler(instructors, enrollments, nil, batches, nil, nil, zap.NewNop())
+			app := setupInstructorTestApp(handler, tt.caller)
+
+			req := httptest.NewRequest(tt.method, "/instructor-courses/"+instanceID.String()+tt.path, nil)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.StatusCode)
+
+			if tt.want == fiber.StatusForbidden {
+				enrollments.AssertNotCalled(t, "RemoveEnrollment", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// LeadershipHandler serves the read-only faculty dashboards of deans, heads
+// of department and other faculty leaders under
+// /faculty-leadership/:facultyID. RequireFacultyLeader guards the faculty,
+// RequireFacultyCourseInstance keeps drill-downs within it; admins pass both.
+type LeadershipHandler struct {
+	leadershipService service.LeadershipService
+	finalGradeService service.FinalGradeService
+	logger            *zap.Logger
+}
+
+// NewLeadershipHandler creates a new LeadershipHandler.
+func NewLeadershipHandler(
+	leadershipService service.LeadershipService,
+	finalGradeService service.FinalGradeService,
+	logger *zap.Logger,
+) *LeadershipHandler {
+	return &LeadershipHandler{
+		leadershipService: leadershipService,
+		finalGradeService: finalGradeService,
+		logger:            logger,
+	}
+}
+
+// RequireFacultyLeader returns middleware that checks the caller is an active
+// leader of the faculty in the route.
+func (h *LeadershipHandler) RequireFacultyLeader() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		facultyID, err := parseUUID(c, "facultyID")
+		if err != nil {
+			return err
+		}
+		if isAdmin(c) {
+			return c.Next()
+		}
+		userID, err := instructorUserID(c)
+		if err != nil {
+			return err
+		}
+
+		leader, err := h.leadershipService.IsFacultyLeader(facultyID, userID)
+		if err != nil {
+			return err
+		}
+		if !leader {
+			return utils.ErrForbidden("you are not a leader of this faculty")
+		}
+		return c.Next()
+	}
+}
+
+// RequireFacultyCourseInstance returns middleware that checks the course
+// instance in the route belongs to the faculty in the route.
+func (h *LeadershipHandler) RequireFacultyCourseInstance() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		facultyID, err := parseUUID(c, "facultyID")
+		if err != nil {
+			return err
+		}
+		instanceID, err := parseUUID(c, "id")
+		if err != nil {
+			return err
+		}
+		if err := h.leadershipService.CheckCourseInstance(facultyID, instanceID); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /faculty-leadership/me
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetMyLeadership returns the caller's active leadership roles.
+func (h *LeadershipHandler) GetMyLeadership(c fiber.Ctx) error {
+	userID, err := instructorUserID(c)
+	if err != nil {
+		return err
+	}
+
+	roles, err := h.leadershipService.MyLeadership(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"leadership": roles,
+		"count":      len(roles),
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /faculty-leadership/:facultyID/course-instances
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListCourseInstances handles
+// GET /faculty-leadership/:facultyID/course-instances[?semester_id=]
+func (h *LeadershipHandler) ListCourseInstances(c fiber.Ctx) error {
+	facultyID, err := parseUUID(c, "facultyID")
+	if err != nil {
+		return err
+	}
+	semesterID, err := optionalUUIDQuery(c, "semester_id")
+	if err != nil {
+		return err
+	}
+
+	instances, err := h.leadershipService.ListCourseInstances(facultyID, semesterID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"course_instances": instances,
+		"count":            len(instances),
+	})
+}
+
+// ListFinalGrades handles
+// GET /faculty-leadership/:facultyID/course-instances/:id/final-grades
+func (h *LeadershipHandler) ListFinalGrades(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	grades, err := h.finalGradeService.ListFinalGrades(instanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(grades)
+}
@@ -0,0 +1,412 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SectionHandler handles course section, lab group and timetable requests.
+// The same handlers serve the admin routes under /course-instances/:id and
+// the instructor routes under /instructor-courses/:id, where RequireInstructor
+// guards access.
+type SectionHandler struct {
+	sectionService          service.SectionService
+	courseInstructorService service.CourseInstructorService
+	logger                  *zap.Logger
+}
+
+// NewSectionHandler creates a new SectionHandler.
+func NewSectionHandler(
+	sectionService service.SectionService,
+	courseInstructorService service.CourseInstructorService,
+	logger *zap.Logger,
+) *SectionHandler {
+	return &SectionHandler{
+		sectionService:          sectionService,
+		courseInstructorService: courseInstructorService,
+		logger:                  logger,
+	}
+}
+
+// RequireInstructor returns middleware that checks the caller teaches the
+// course instance in the route. When write is true, TAs are rejected.
+func (h *SectionHandler) RequireInstructor(write bool) fiber.Handler {
+	taDenied := ""
+	if write {
+		taDenied = "teaching assistants cannot change sections"
+	}
+	return func(c fiber.Ctx) error {
+		if _, err := authorizeCourseStaff(c, h.courseInstructorService, taDenied); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /course-instances/:id/sections
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CreateSection handles POST /course-instances/:id/sections
+func (h *SectionHandler) CreateSection(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateSectionRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	section, err := h.sectionService.CreateSection(instanceID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(section)
+}
+
+// ListSections handles GET /course-instances/:id/sections
+func (h *SectionHandler) ListSections(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	sections, err := h.sectionService.ListSections(instanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"sections": sections,
+		"count":    len(sections),
+	})
+}
+
+// GetSection handles GET /course-instances/:id/sections/:sectionID
+func (h *SectionHandler) GetSection(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	section, err := h.sectionService.GetSection(instanceID, sectionID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(section)
+}
+
+// UpdateSection handles PUT /course-instances/:id/sections/:sectionID
+func (h *SectionHandler) UpdateSection(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateSectionRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	section, err := h.sectionService.UpdateSection(instanceID, sectionID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(section)
+}
+
+// DeleteSection handles DELETE /course-instances/:id/sections/:sectionID
+func (h *SectionHandler) DeleteSection(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	if err := h.sectionService.DeleteSection(instanceID, sectionID, username, c.IP(), c.Get("User-Agent")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "section deleted successfully",
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /course-instances/:id/sections/:sectionID/instructors
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AssignInstructor handles POST /course-instances/:id/sections/:sectionID/instructors
+func (h *SectionHandler) AssignInstructor(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SectionInstructorRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	section, err := h.sectionService.AssignInstructor(instanceID, sectionID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(section)
+}
+
+// RemoveInstructor handles DELETE /course-instances/:id/sections/:sectionID/instructors/:userID
+func (h *SectionHandler) RemoveInstructor(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	if err := h.sectionService.RemoveInstructor(instanceID, sectionID, userID, username, c.IP(), c.Get("User-Agent")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "section instructor removed successfully",
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /course-instances/:id/sections/:sectionID/slots
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AddSlot handles POST /course-instances/:id/sections/:sectionID/slots
+func (h *SectionHandler) AddSlot(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SectionSlotRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	section, err := h.sectionService.AddSlot(instanceID, sectionID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(section)
+}
+
+// RemoveSlot handles DELETE /course-instances/:id/sections/:sectionID/slots/:slotID
+func (h *SectionHandler) RemoveSlot(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+	slotID, err := parseUUID(c, "slotID")
+	if err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	if err := h.sectionService.RemoveSlot(instanceID, sectionID, slotID, username, c.IP(), c.Get("User-Agent")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "slot removed successfully",
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// /course-instances/:id/sections/assign and members
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AssignStudents handles POST /course-instances/:id/sections/assign
+func (h *SectionHandler) AssignStudents(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.AssignSectionStudentsRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	resp, err := h.sectionService.AssignStudents(instanceID, &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ListMembers handles GET /course-instances/:id/sections/:sectionID/members
+func (h *SectionHandler) ListMembers(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	members, err := h.sectionService.ListMembers(instanceID, sectionID)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.SectionMemberResponse, len(members))
+	for i, m := range members {
+		responses[i] = dto.SectionMemberResponse{UserID: m.UserID, AssignedAt: m.AssignedAt}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"members": responses,
+		"count":   len(responses),
+	})
+}
+
+// RemoveMember handles DELETE /course-instances/:id/sections/:sectionID/members/:userID
+func (h *SectionHandler) RemoveMember(c fiber.Ctx) error {
+	instanceID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+	userID, err := parseUUID(c, "userID")
+	if err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	if err := h.sectionService.RemoveMember(instanceID, sectionID, userID, username, c.IP(), c.Get("User-Agent")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "section member removed successfully",
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /student-courses/me/timetable
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetMyTimetable returns the caller's sections across all their courses,
+// with instructors and weekly slots.
+func (h *SectionHandler) GetMyTimetable(c fiber.Ctx) error {
+	userID, err := studentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	sections, err := h.sectionService.ListUserSections(userID, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"sections": sections,
+		"count":    len(sections),
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /internal/course-instances/:id/sections?user_id=
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListSectionsInternal serves other services, such as assessment targeting
+// assignments at sections. With user_id, only that student's sections are
+// returned.
+func (h *SectionHandler) ListSectionsInternal(c fiber.Ctx) error {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var (
+		sections []dto.SectionResponse
+		raw      = c.Query("user_id")
+	)
+	if raw == "" {
+		sections, err = h.sectionService.ListSections(instanceID)
+	} else {
+		userID, parseErr := uuid.Parse(raw)
+		if parseErr != nil {
+			return utils.ErrBadRequest("invalid user_id")
+		}
+		sections, err = h.sectionService.ListUserSections(userID, &instanceID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"sections": sections,
+		"count":    len(sections),
+	})
+}
+
+func sectionParams(c fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	instanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	sectionID, err := parseUUID(c, "sectionID")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return instanceID, sectionID, nil
+}
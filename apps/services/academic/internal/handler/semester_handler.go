@@ -12,13 +12,15 @@ import (
 // SemesterHandler handles semester-related HTTP requests.
 type SemesterHandler struct {
 	semesterService service.SemesterService
+	rolloverService service.SemesterRolloverService
 	logger          *zap.Logger
 }
 
 // NewSemesterHandler creates a new SemesterHandler.
-func NewSemesterHandler(semesterService service.SemesterService, logger *zap.Logger) *SemesterHandler {
+func NewSemesterHandler(semesterService service.SemesterService, rolloverService service.SemesterRolloverService, logger *zap.Logger) *SemesterHandler {
 	return &SemesterHandler{
 		semesterService: semesterService,
+		rolloverService: rolloverService,
 		logger:          logger,
 	}
 }
@@ -147,6 +149,54 @@ func (h *SemesterHandler) DeactivateSemester(c fiber.Ctx) error {
 	})
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /semesters/advance
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AdvanceCalendar handles POST /semesters/advance. It runs the calendar
+// scheduler immediately instead of waiting for its next tick.
+func (h *SemesterHandler) AdvanceCalendar(c fiber.Ctx) error {
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	transitions, err := h.semesterService.AdvanceCalendar(domain.Today(), username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"transitions": transitions,
+		"count":       len(transitions),
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /semesters/rollover
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RolloverSemester handles POST /semesters/rollover. With dry_run set it
+// only previews what would be copied.
+func (h *SemesterHandler) RolloverSemester(c fiber.Ctx) error {
+	var req dto.RolloverRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	resp, err := h.rolloverService.Rollover(c.RequestCtx(), &req, username, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Private helpers
 // ─────────────────────────────────────────────────────────────────────────────
@@ -157,10 +207,16 @@ func toSemesterResponse(s *domain.Semester) *dto.SemesterResponse {
 		Name:      s.Name,
 		Code:      s.Code,
 		TermType:  s.TermType,
-		StartDate: s.StartDate,
-		EndDate:   s.EndDate,
+		StartDate: s.StartDate.String(),
+		EndDate:   s.EndDate.String(),
 		Status:    s.Status,
 		IsActive:  s.IsActive,
+
+		AddDropDeadline:         s.AddDropDeadline.String(),
+		ExamStartDate:           s.ExamStartDate.String(),
+		ExamEndDate:             s.ExamEndDate.String(),
+		GradeSubmissionDeadline: s.GradeSubmissionDeadline.String(),
+
 		CreatedAt: s.CreatedAt,
 		UpdatedAt: s.UpdatedAt,
 	}
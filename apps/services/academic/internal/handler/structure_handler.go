@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"fmt"
+	"mime/multipart"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// StructureHandler handles academic structure import and export requests.
+type StructureHandler struct {
+	structureImportService service.StructureImportService
+	logger                 *zap.Logger
+}
+
+// NewStructureHandler creates a new StructureHandler.
+func NewStructureHandler(structureImportService service.StructureImportService, logger *zap.Logger) *StructureHandler {
+	return &StructureHandler{
+		structureImportService: structureImportService,
+		logger:                 logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/structure/import/template
+// ─────────────────────────────────────────────────────────────────────────────
+
+// DownloadTemplate returns an empty import sheet. Pass ?format=csv for CSV;
+// the default is XLSX.
+func (h *StructureHandler) DownloadTemplate(c fiber.Ctx) error {
+	format, err := sheetFormat(c)
+	if err != nil {
+		return err
+	}
+
+	content, contentType, err := h.structureImportService.GenerateTemplate(format)
+	if err != nil {
+		return utils.ErrInternal("failed to generate template", err)
+	}
+	return sendSheet(c, "structure_import_template."+format, contentType, content)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /api/v1/structure/import/preview
+// ─────────────────────────────────────────────────────────────────────────────
+
+// PreviewImport checks an uploaded file and reports, row by row, what an
+// import would create. Nothing is written.
+func (h *StructureHandler) PreviewImport(c fiber.Ctx) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return utils.ErrBadRequest("no file provided")
+	}
+	f, err := openUpload(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	resp, err := h.structureImportService.Preview(f, file.Filename)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /api/v1/structure/import/execute
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ExecuteImport applies an uploaded file. When any row is invalid nothing is
+// written and the row report is returned with 422.
+func (h *StructureHandler) ExecuteImport(c fiber.Ctx) error {
+	userIDRaw, ok := c.Locals("user_id").(string)
+	if !ok || userIDRaw == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+	actorID, err := uuid.Parse(userIDRaw)
+	if err != nil {
+		h.logger.Error("failed to parse user_id from context", zap.Error(err), zap.String("user_id", userIDRaw))
+		return utils.ErrUnauthorized("invalid user session")
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return utils.ErrBadRequest("no file provided")
+	}
+	f, err := openUpload(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	resp, err := h.structureImportService.Execute(f, file.Filename, actorID, requireUsername(c), c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+	if !resp.Applied {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(resp)
+	}
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/structure/export
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Export downloads the current structure in the import format. Pass
+// ?format=csv for CSV; the default is XLSX.
+func (h *StructureHandler) Export(c fiber.Ctx) error {
+	format, err := sheetFormat(c)
+	if err != nil {
+		return err
+	}
+
+	content, contentType, err := h.structureImportService.Export(format)
+	if err != nil {
+		return err
+	}
+	return sendSheet(c, "academic_structure."+format, contentType, content)
+}
+
+func sheetFormat(c fiber.Ctx) (string, error) {
+	format := c.Query("format", "xlsx")
+	if format != "csv" && format != "xlsx" {
+		return "", utils.ErrBadRequest("format must be csv or xlsx")
+	}
+	return format, nil
+}
+
+func openUpload(file *multipart.FileHeader) (multipart.File, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, utils.ErrInternal("failed to open file", err)
+	}
+	return f, nil
+}
+
+func sendSheet(c fiber.Ctx, filename, contentType string, content []byte) error {
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Status(fiber.StatusOK).Send(content)
+}
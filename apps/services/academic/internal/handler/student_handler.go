@@ -102,8 +102,8 @@ func (h *StudentHandler) buildEnrollmentResponse(instanceID uuid.UUID, status, f
 	} else {
 		resp.SemesterName = semester.Name
 		resp.SemesterTerm = semester.TermType
-		resp.SemesterStartDate = semester.StartDate
-		resp.SemesterEndDate = semester.EndDate
+		resp.SemesterStartDate = semester.StartDate.String()
+		resp.SemesterEndDate = semester.EndDate.String()
 	}
 
 	// Batch details
@@ -137,6 +137,7 @@ func (h *StudentHandler) GetMyCourses(c fiber.Ctx) error {
 	responses := make([]dto.StudentCourseEnrollmentResponse, len(enrollments))
 	for i, e := range enrollments {
 		resp := h.buildEnrollmentResponse(e.CourseInstanceID, e.Status, e.FinalGrade)
+		resp.WaitlistPosition = e.WaitlistPosition
 		resp.EnrolledAt = e.EnrolledAt
 		responses[i] = resp
 	}
@@ -174,6 +175,7 @@ func (h *StudentHandler) GetCourseInstance(c fiber.Ctx) error {
 	for _, e := range enrollments {
 		if e.UserID == userID {
 			resp := h.buildEnrollmentResponse(instanceID, e.Status, e.FinalGrade)
+			resp.WaitlistPosition = e.WaitlistPosition
 			resp.EnrolledAt = e.EnrolledAt
 			return c.Status(fiber.StatusOK).JSON(resp)
 		}
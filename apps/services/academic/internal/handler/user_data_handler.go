@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// UserDataHandler handles internal requests about a user's academic data.
+type UserDataHandler struct {
+	userDataService service.UserDataService
+	logger          *zap.Logger
+}
+
+// NewUserDataHandler creates a new UserDataHandler.
+func NewUserDataHandler(userDataService service.UserDataService, logger *zap.Logger) *UserDataHandler {
+	return &UserDataHandler{
+		userDataService: userDataService,
+		logger:          logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /internal/users/:id/data
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ExportUserData handles GET /internal/users/:id/data. IAM calls it to build
+// a personal data export; callers need the academic.user_data:read scope.
+func (h *UserDataHandler) ExportUserData(c fiber.Ctx) error {
+	userID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	clientID, _ := c.Locals(servicetoken.LocalsClientID).(string)
+
+	export, err := h.userDataService.ExportUserData(userID, clientID, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user_id": userID,
+		"data":    export,
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// DELETE /internal/users/:id
+// ─────────────────────────────────────────────────────────────────────────────
+
+// EraseUserData handles DELETE /internal/users/:id. IAM calls it before
+// purging an account and for erasure requests, passing retain_grades=true
+// when grade records must be kept. Callers need the academic.user_data:erase
+// scope.
+func (h *UserDataHandler) EraseUserData(c fiber.Ctx) error {
+	userID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	retainGrades := fiber.Query[bool](c, "retain_grades")
+	clientID, _ := c.Locals(servicetoken.LocalsClientID).(string)
+
+	counts, err := h.userDataService.EraseUserData(userID, retainGrades, clientID, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user_id":       userID,
+		"retain_grades": retainGrades,
+		"erased":        counts,
+	})
+}
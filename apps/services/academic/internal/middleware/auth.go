@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
 	"github.com/gofiber/fiber/v3"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -14,10 +17,13 @@ type Claims struct {
 	UserID   string `json:"user_id"`   // UUID string from IAM
 	Email    string `json:"email"`     // Email from IAM (used as identifier)
 	UserType string `json:"user_type"` // User type: student, instructor, admin
+	// Global roles and permissions granted by IAM
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func AuthMiddleware(secretKey []byte) fiber.Handler {
+func AuthMiddleware(secretKey []byte, tokens pat.Verifier) fiber.Handler {
 	return func(c fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -31,6 +37,27 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 
 		tokenString := parts[1]
 
+		// Personal access tokens are opaque; IAM resolves them to a user.
+		if pat.IsToken(tokenString) {
+			identity, err := tokens.Verify(c.RequestCtx(), tokenString)
+			if err != nil {
+				if errors.Is(err, pat.ErrInvalidToken) {
+					return utils.ErrUnauthorized("Invalid token")
+				}
+				return utils.NewAppError(fiber.StatusServiceUnavailable, "Token verification unavailable", err)
+			}
+
+			c.Locals("user_id", identity.UserID)
+			c.Locals("username", identity.Email)
+			c.Locals("user_type", identity.UserType)
+			c.Locals(authz.LocalsRoles, identity.Roles)
+			c.Locals(authz.LocalsPermissions, identity.Permissions)
+			c.Locals(pat.LocalsTokenID, identity.TokenID)
+			c.Locals(pat.LocalsTokenScopes, identity.Scopes)
+
+			return c.Next()
+		}
+
 		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, utils.ErrUnauthorized("Invalid signing method")
@@ -47,10 +74,17 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 			return utils.ErrUnauthorized("Invalid token claims")
 		}
 
+		// Service tokens share the signing key but carry no user.
+		if claims.UserID == "" {
+			return utils.ErrUnauthorized("Invalid token claims")
+		}
+
 		// Store claims in context for handlers to access
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Email)
 		c.Locals("user_type", claims.UserType)
+		c.Locals(authz.LocalsRoles, claims.Roles)
+		c.Locals(authz.LocalsPermissions, claims.Permissions)
 
 		fmt.Printf("[DEBUG AuthMiddleware] path=%s user_id='%s' username='%s' user_type='%s'\n",
 			c.Path(), claims.UserID, claims.Email, claims.UserType)
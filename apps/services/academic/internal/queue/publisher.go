@@ -0,0 +1,100 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// EventMessage is the body of every message on the events exchange.
+// Consumers should de-duplicate on ID, since delivery is at-least-once, and
+// may track Sequence to ask the replay API for anything they missed.
+// Replayed marks a message re-sent through the replay API.
+type EventMessage struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	EventType     string          `json:"event_type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+	Replayed      bool            `json:"replayed,omitempty"`
+}
+
+// EventPublisher publishes outbox events to the events exchange.
+type EventPublisher struct {
+	rmq *RabbitMQ
+}
+
+// NewEventPublisher creates an EventPublisher backed by rmq.
+func NewEventPublisher(rmq *RabbitMQ) *EventPublisher {
+	return &EventPublisher{rmq: rmq}
+}
+
+// Publish sends the event with its type as routing key and blocks until the
+// broker confirms it or ctx is done. Messages are persistent, so they
+// survive a broker restart once confirmed.
+func (p *EventPublisher) Publish(ctx context.Context, event *domain.OutboxEvent, replayed bool) error {
+	body, err := json.Marshal(EventMessage{
+		ID:            event.ID,
+		Sequence:      event.Sequence,
+		EventType:     event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		OccurredAt:    event.OccurredAt,
+		Payload:       event.Payload,
+		Replayed:      replayed,
+	})
+	if err != nil {
+		return fmt.Errorf("event publisher: marshalling event %d: %w", event.Sequence, err)
+	}
+
+	ch, err := p.rmq.Channel()
+	if err != nil {
+		return fmt.Errorf("event publisher: acquiring channel: %w", err)
+	}
+	defer ch.Close()
+
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+
+	if err := ch.PublishWithContext(
+		ctx,
+		EventsExchange,  // exchange
+		event.EventType, // routing key
+		false,           // mandatory — events without subscribers are fine
+		false,           // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    event.ID.String(),
+			Type:         event.EventType,
+			Timestamp:    event.OccurredAt,
+			Headers: amqp.Table{
+				"sequence":     strconv.FormatInt(event.Sequence, 10),
+				"aggregate_id": event.AggregateID.String(),
+			},
+			Body: body,
+		},
+	); err != nil {
+		return fmt.Errorf("event publisher: publishing event %d: %w", event.Sequence, err)
+	}
+
+	select {
+	case confirm, ok := <-confirms:
+		if !ok {
+			return fmt.Errorf("event publisher: confirms channel closed before ack for event %d", event.Sequence)
+		}
+		if !confirm.Ack {
+			return fmt.Errorf("event publisher: broker nacked event %d", event.Sequence)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("event publisher: waiting for broker confirm: %w", ctx.Err())
+	}
+}
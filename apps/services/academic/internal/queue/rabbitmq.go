@@ -0,0 +1,189 @@
+package queue
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+const (
+	// EventsExchange is the durable topic exchange academic domain events are
+	// published to. The routing key is the event type, e.g.
+	// "enrollment.created"; consumers declare and bind their own queues.
+	EventsExchange = "academic.events"
+
+	// reconnectDelay is the base back-off between reconnection attempts.
+	reconnectDelay = 5 * time.Second
+
+	// maxReconnectAttempts is the maximum number of times the manager will try
+	// to re-establish a lost connection before giving up.
+	maxReconnectAttempts = 10
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RabbitMQ – connection manager
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RabbitMQ manages a single AMQP connection and opens channels on demand.
+// On connection loss it re-dials up to maxReconnectAttempts times with a
+// fixed back-off delay before giving up.
+//
+// All exported methods are safe for concurrent use.
+type RabbitMQ struct {
+	url    string
+	conn   *amqp.Connection
+	mu     sync.RWMutex
+	logger *zap.Logger
+}
+
+// NewRabbitMQ dials the broker at url, declares the events exchange, and
+// returns a ready-to-use RabbitMQ manager.
+func NewRabbitMQ(url string, logger *zap.Logger) (*RabbitMQ, error) {
+	r := &RabbitMQ{url: url, logger: logger}
+
+	if err := r.connect(); err != nil {
+		return nil, err
+	}
+	if err := r.declareTopology(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Channel opens a fresh AMQP channel with publisher confirms enabled.
+func (r *RabbitMQ) Channel() (*amqp.Channel, error) {
+	r.mu.RLock()
+	conn := r.conn
+	r.mu.RUnlock()
+
+	if conn == nil || conn.IsClosed() {
+		return nil, fmt.Errorf("rabbitmq: connection is not available")
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("rabbitmq: opening channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("rabbitmq: enabling confirms: %w", err)
+	}
+
+	return ch, nil
+}
+
+// Close shuts down the underlying AMQP connection.
+func (r *RabbitMQ) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn != nil && !r.conn.IsClosed() {
+		return r.conn.Close()
+	}
+
+	return nil
+}
+
+// WatchReconnect listens for connection-close notifications and re-dials the
+// broker. Launch it once as a goroutine after construction:
+//
+//	go rmq.WatchReconnect()
+func (r *RabbitMQ) WatchReconnect() {
+	for {
+		r.mu.RLock()
+		conn := r.conn
+		r.mu.RUnlock()
+
+		if conn == nil {
+			return
+		}
+
+		reason, ok := <-conn.NotifyClose(make(chan *amqp.Error, 1))
+		if !ok {
+			r.logger.Info("rabbitmq: connection closed intentionally; stopping reconnect watcher")
+			return
+		}
+
+		r.logger.Warn("rabbitmq: connection lost; attempting to reconnect",
+			zap.String("reason", reason.Reason),
+			zap.Int("code", reason.Code),
+		)
+
+		var lastErr error
+		for attempt := 1; attempt <= maxReconnectAttempts; attempt++ {
+			time.Sleep(reconnectDelay)
+
+			if err := r.connect(); err != nil {
+				lastErr = err
+				r.logger.Warn("rabbitmq: reconnect attempt failed", zap.Int("attempt", attempt), zap.Error(err))
+				continue
+			}
+			if err := r.declareTopology(); err != nil {
+				lastErr = err
+				r.logger.Warn("rabbitmq: topology re-declaration failed after reconnect", zap.Int("attempt", attempt), zap.Error(err))
+				continue
+			}
+
+			r.logger.Info("rabbitmq: reconnected successfully", zap.Int("attempt", attempt))
+			lastErr = nil
+			break
+		}
+
+		if lastErr != nil {
+			r.logger.Error("rabbitmq: all reconnect attempts exhausted; giving up", zap.Error(lastErr))
+			return
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Internal helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// connect dials the broker and stores the connection under the write lock.
+func (r *RabbitMQ) connect() error {
+	conn, err := amqp.Dial(r.url)
+	if err != nil {
+		return fmt.Errorf("rabbitmq: dialing broker: %w", err)
+	}
+
+	r.mu.Lock()
+	r.conn = conn
+	r.mu.Unlock()
+
+	r.logger.Info("rabbitmq: connected")
+	return nil
+}
+
+// declareTopology declares the durable events exchange on a short-lived
+// channel.
+func (r *RabbitMQ) declareTopology() error {
+	r.mu.RLock()
+	conn := r.conn
+	r.mu.RUnlock()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("rabbitmq: opening channel for topology declaration: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.ExchangeDeclare(
+		EventsExchange, // name
+		"topic",        // kind
+		true,           // durable
+		false,          // auto-delete
+		false,          // internal
+		false,          // no-wait
+		nil,            // args
+	); err != nil {
+		return fmt.Errorf("rabbitmq: declaring exchange %q: %w", EventsExchange, err)
+	}
+
+	r.logger.Info("rabbitmq: topology declared", zap.String("exchange", EventsExchange))
+	return nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrApprovalNotPending is returned when a request that has already been
+// decided or withdrawn is decided or withdrawn again.
+var ErrApprovalNotPending = errors.New("approval request is no longer pending")
+
+// ApprovalFilter narrows List. Zero fields are not filtered on;
+// an empty non-nil FacultyIDs matches nothing.
+type ApprovalFilter struct {
+	FacultyIDs       []uuid.UUID
+	CourseInstanceID *uuid.UUID
+	RequestedByID    *uuid.UUID
+	Status           string
+	Kind             string
+}
+
+// ApprovalRepository defines all data operations for approval requests.
+// Every write records the matching approval event in the outbox.
+type ApprovalRepository interface {
+	// Create inserts a pending request; notify lists the users to tell.
+	Create(request *domain.ApprovalRequest, notify []uuid.UUID) error
+	GetByID(id uuid.UUID) (*domain.ApprovalRequest, error)
+	List(filter ApprovalFilter) ([]domain.ApprovalRequest, error)
+	// Close moves a pending request to its new status along with the
+	// decision fields. It fails with ErrApprovalNotPending when the request
+	// was closed concurrently.
+	Close(request *domain.ApprovalRequest, notify []uuid.UUID) error
+	// Decide loads the request with SELECT ... FOR UPDATE and holds the lock
+	// while decide runs, so concurrent decisions on one request run one after
+	// the other and each sees the previous one's outcome. When decide returns
+	// no error, the decision it made on the request is stored as by Close.
+	// Returns nil, nil when no record is found.
+	Decide(id uuid.UUID, decide func(request *domain.ApprovalRequest) (notify []uuid.UUID, err error)) (*domain.ApprovalRequest, error)
+}
+
+// approvalRepository is the concrete GORM-backed implementation.
+type approvalRepository struct {
+	db *gorm.DB
+}
+
+// NewApprovalRepository creates a new approvalRepository.
+func NewApprovalRepository(db *gorm.DB) ApprovalRepository {
+	return &approvalRepository{db: db}
+}
+
+// Create inserts the request and its approval.requested event.
+func (r *approvalRepository) Create(request *domain.ApprovalRequest, notify []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("CourseInstance").Create(request).Error; err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.NewApprovalEvent(request, notify))
+	})
+}
+
+// GetByID loads a single request. Returns nil, nil when no record is found.
+func (r *approvalRepository) GetByID(id uuid.UUID) (*domain.ApprovalRequest, error) {
+	var request domain.ApprovalRequest
+	err := r.db.Where("id = ?", id).First(&request).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// List returns the matching requests, newest first.
+func (r *approvalRepository) List(filter ApprovalFilter) ([]domain.ApprovalRequest, error) {
+	var requests []domain.ApprovalRequest
+	if filter.FacultyIDs != nil && len(filter.FacultyIDs) == 0 {
+		return requests, nil
+	}
+
+	query := r.db.Model(&domain.ApprovalRequest{})
+	if filter.FacultyIDs != nil {
+		query = query.Where("faculty_id IN ?", filter.FacultyIDs)
+	}
+	if filter.CourseInstanceID != nil {
+		query = query.Where("course_instance_id = ?", *filter.CourseInstanceID)
+	}
+	if filter.RequestedByID != nil {
+		query = query.Where("requested_by_id = ?", *filter.RequestedByID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+
+	err := query.Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+// Close updates the request only while it is still pending, and records the
+// matching approval event.
+func (r *approvalRepository) Close(request *domain.ApprovalRequest, notify []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return closeRequest(tx, request, notify)
+	})
+}
+
+// Decide runs decide inside the transaction that holds the row lock.
+func (r *approvalRepository) Decide(
+	id uuid.UUID,
+	decide func(request *domain.ApprovalRequest) ([]uuid.UUID, error),
+) (*domain.ApprovalRequest, error) {
+	var request *domain.ApprovalRequest
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked domain.ApprovalRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&locked).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		notify, err := decide(&locked)
+		if err != nil {
+			return err
+		}
+		if err := closeRequest(tx, &locked, notify); err != nil {
+			return err
+		}
+		request = &locked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// closeRequest updates the request only while it is still pending, and
+// records the matching approval event.
+func closeRequest(tx *gorm.DB, request *domain.ApprovalRequest, notify []uuid.UUID) error {
+	res := tx.Model(&domain.ApprovalRequest{}).
+		Where("id = ? AND status = ?", request.ID, domain.ApprovalStatusPending).
+		Updates(map[string]interface{}{
+			"status":        request.Status,
+			"decided_by_id": request.DecidedByID,
+			"decided_by":    request.DecidedBy,
+			"decision_note": request.DecisionNote,
+			"decided_at":    request.DecidedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrApprovalNotPending
+	}
+	return appendEvents(tx, domain.NewApprovalEvent(request, notify))
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupApprovalTestDB extends the enrollment test schema with approval
+// requests and the batch → degree → department chain that places a course
+// instance in a faculty, created by hand for the same reason as
+// course_instances.
+func setupApprovalTestDB(t *testing.T) *gorm.DB {
+	db := setupEnrollmentTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE approval_requests (
+		id TEXT PRIMARY KEY,
+		faculty_id TEXT NOT NULL,
+		course_instance_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		status TEXT NOT NULL,
+		requested_by_id TEXT NOT NULL,
+		requested_by TEXT,
+		decided_by_id TEXT,
+		decided_by TEXT,
+		decision_note TEXT,
+		decided_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE batches (id TEXT PRIMARY KEY, degree_id TEXT NOT NULL)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE degrees (id TEXT PRIMARY KEY, department_id TEXT NOT NULL)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE departments (id TEXT PRIMARY KEY, faculty_id TEXT NOT NULL)`).Error)
+
+	return db
+}
+
+// createFacultyInstance creates a course instance whose batch belongs to the
+// faculty.
+func createFacultyInstance(t *testing.T, db *gorm.DB, facultyID uuid.UUID) uuid.UUID {
+	batchID, degreeID, departmentID, id := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO departments (id, faculty_id) VALUES (?, ?)`, departmentID, facultyID).Error)
+	require.NoError(t, db.Exec(`INSERT INTO degrees (id, department_id) VALUES (?, ?)`, degreeID, departmentID).Error)
+	require.NoError(t, db.Exec(`INSERT INTO batches (id, degree_id) VALUES (?, ?)`, batchID, degreeID).Error)
+	require.NoError(t, db.Exec(
+		`INSERT INTO course_instances (id, course_id, semester_id, batch_id) VALUES (?, ?, ?, ?)`,
+		id, uuid.New(), uuid.New(), batchID,
+	).Error)
+	return id
+}
+
+func TestCloseApprovalRequest(t *testing.T) {
+	db := setupApprovalTestDB(t)
+	repo := NewApprovalRepository(db)
+
+	facultyID := uuid.New()
+	request := &domain.ApprovalRequest{
+		FacultyID:        facultyID,
+		CourseInstanceID: createFacultyInstance(t, db, facultyID),
+		Kind:             domain.ApprovalKindCancellation,
+		Payload:          json.RawMessage(`{}`),
+		Reason:           "low enrollment",
+		Status:           domain.ApprovalStatusPending,
+		RequestedByID:    uuid.New(),
+	}
+	require.NoError(t, repo.Create(request, []uuid.UUID{uuid.New()}))
+
+	approver, now := uuid.New(), time.Now().UTC()
+	request.Status = domain.ApprovalStatusApproved
+	request.DecidedByID = &approver
+	request.DecidedAt = &now
+	require.NoError(t, repo.Close(request, []uuid.UUID{request.RequestedByID}))
+
+	stored, err := repo.GetByID(request.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusApproved, stored.Status)
+	require.NotNil(t, stored.DecidedByID)
+	assert.Equal(t, approver, *stored.DecidedByID)
+
+	// A decided request cannot be closed again.
+	request.Status = domain.ApprovalStatusRejected
+	assert.ErrorIs(t, repo.Close(request, nil), ErrApprovalNotPending)
+
+	assert.Equal(t, []string{domain.EventApprovalRequested, domain.EventApprovalApproved}, outboxEvents(t, db))
+
+	listed, err := repo.List(ApprovalFilter{FacultyIDs: []uuid.UUID{facultyID}, Status: domain.ApprovalStatusApproved})
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+	listed, err = repo.List(ApprovalFilter{FacultyIDs: []uuid.UUID{}})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+}
+
+func TestDecideApprovalRequest(t *testing.T) {
+	db := setupApprovalTestDB(t)
+	repo := NewApprovalRepository(db)
+
+	facultyID := uuid.New()
+	request := &domain.ApprovalRequest{
+		FacultyID:        facultyID,
+		CourseInstanceID: createFacultyInstance(t, db, facultyID),
+		Kind:             domain.ApprovalKindCancellation,
+		Payload:          json.RawMessage(`{}`),
+		Reason:           "low enrollment",
+		Status:           domain.ApprovalStatusPending,
+		RequestedByID:    uuid.New(),
+	}
+	require.NoError(t, repo.Create(request, nil))
+
+	// A failed decision leaves the request pending and records no event.
+	failed := errors.New("apply failed")
+	_, err := repo.Decide(request.ID, func(*domain.ApprovalRequest) ([]uuid.UUID, error) {
+		return nil, failed
+	})
+	assert.ErrorIs(t, err, failed)
+
+	approver := uuid.New()
+	decided, err := repo.Decide(request.ID, func(locked *domain.ApprovalRequest) ([]uuid.UUID, error) {
+		assert.Equal(t, domain.ApprovalStatusPending, locked.Status)
+		locked.Status = domain.ApprovalStatusApproved
+		locked.DecidedByID = &approver
+		return []uuid.UUID{locked.RequestedByID}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusApproved, decided.Status)
+
+	stored, err := repo.GetByID(request.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusApproved, stored.Status)
+	assert.Equal(t, []string{domain.EventApprovalRequested, domain.EventApprovalApproved}, outboxEvents(t, db))
+
+	// The decision sees the committed status, so it is not applied twice.
+	_, err = repo.Decide(request.ID, func(locked *domain.ApprovalRequest) ([]uuid.UUID, error) {
+		assert.Equal(t, domain.ApprovalStatusApproved, locked.Status)
+		return nil, failed
+	})
+	assert.ErrorIs(t, err, failed)
+
+	missing, err := repo.Decide(uuid.New(), func(*domain.ApprovalRequest) ([]uuid.UUID, error) {
+		t.Error("decide called for a missing request")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestCourseInstanceFaculty(t *testing.T) {
+	db := setupApprovalTestDB(t)
+	repo := NewCourseInstanceRepository(db)
+
+	facultyID := uuid.New()
+	instanceID := createFacultyInstance(t, db, facultyID)
+	createFacultyInstance(t, db, uuid.New())
+
+	got, err := repo.GetFacultyID(instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, facultyID, got)
+
+	got, err = repo.GetFacultyID(uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, got)
+
+	instances, err := repo.ListByFaculty(facultyID, nil)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, instanceID, instances[0].ID)
+}
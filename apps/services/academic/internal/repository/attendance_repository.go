@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AttendanceRepository defines all data operations for attendance sessions
+// and records.
+type AttendanceRepository interface {
+	CreateSession(session *domain.AttendanceSession) error
+	UpdateSession(session *domain.AttendanceSession) error
+	DeleteSession(id uuid.UUID) error
+	GetSession(id uuid.UUID) (*domain.AttendanceSession, error)
+	ListSessions(instanceID uuid.UUID) ([]domain.AttendanceSession, error)
+	// ListOpenSessions returns the sessions of the course instance accepting
+	// self check-in at the given time.
+	ListOpenSessions(instanceID uuid.UUID, now time.Time) ([]domain.AttendanceSession, error)
+
+	// UpsertRecords inserts the records, overwriting any existing record of
+	// the same student for the same session, in one transaction.
+	UpsertRecords(records []domain.AttendanceRecord) error
+	// CreateRecord inserts the record unless the student already has one for
+	// the session, and reports whether it was inserted.
+	CreateRecord(record *domain.AttendanceRecord) (bool, error)
+	GetRecord(sessionID, userID uuid.UUID) (*domain.AttendanceRecord, error)
+	ListRecords(sessionID uuid.UUID) ([]domain.AttendanceRecord, error)
+	ListRecordsByInstance(instanceID uuid.UUID) ([]domain.AttendanceRecord, error)
+	ListRecordsByUser(instanceID, userID uuid.UUID) ([]domain.AttendanceRecord, error)
+}
+
+// attendanceRepository is the concrete GORM-backed implementation.
+type attendanceRepository struct {
+	db *gorm.DB
+}
+
+// NewAttendanceRepository creates a new attendanceRepository.
+func NewAttendanceRepository(db *gorm.DB) AttendanceRepository {
+	return &attendanceRepository{db: db}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Sessions
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CreateSession inserts a new attendance session.
+func (r *attendanceRepository) CreateSession(session *domain.AttendanceSession) error {
+	return r.db.Omit(clause.Associations).Create(session).Error
+}
+
+// UpdateSession saves the session's own columns.
+func (r *attendanceRepository) UpdateSession(session *domain.AttendanceSession) error {
+	return r.db.Omit(clause.Associations).Save(session).Error
+}
+
+// DeleteSession hard-deletes a session together with its records.
+func (r *attendanceRepository) DeleteSession(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", id).Delete(&domain.AttendanceRecord{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.AttendanceSession{}).Error
+	})
+}
+
+// GetSession loads a single session. Returns nil, nil when no record is
+// found.
+func (r *attendanceRepository) GetSession(id uuid.UUID) (*domain.AttendanceSession, error) {
+	var session domain.AttendanceSession
+	err := r.db.Where("id = ?", id).First(&session).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// ListSessions returns the sessions of a course instance in date order.
+func (r *attendanceRepository) ListSessions(instanceID uuid.UUID) ([]domain.AttendanceSession, error) {
+	var sessions []domain.AttendanceSession
+	err := r.db.
+		Where("course_instance_id = ?", instanceID).
+		Order("date ASC, created_at ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// ListOpenSessions returns the sessions with an open check-in window.
+func (r *attendanceRepository) ListOpenSessions(instanceID uuid.UUID, now time.Time) ([]domain.AttendanceSession, error) {
+	var sessions []domain.AttendanceSession
+	err := r.db.
+		Where("course_instance_id = ? AND check_in_secret <> '' AND check_in_open_until > ?", instanceID, now).
+		Order("date ASC, created_at ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Records
+// ─────────────────────────────────────────────────────────────────────────────
+
+// UpsertRecords writes the records in one transaction.
+func (r *attendanceRepository) UpsertRecords(records []domain.AttendanceRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "source", "note", "marked_by", "marked_at"}),
+		}).Omit(clause.Associations).Create(&records).Error
+	})
+}
+
+// CreateRecord inserts the record, leaving an existing one untouched.
+func (r *attendanceRepository) CreateRecord(record *domain.AttendanceRecord) (bool, error) {
+	res := r.db.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(record)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// GetRecord loads one student's record for a session. Returns nil, nil when
+// no record is found.
+func (r *attendanceRepository) GetRecord(sessionID, userID uuid.UUID) (*domain.AttendanceRecord, error) {
+	var record domain.AttendanceRecord
+	err := r.db.
+		Where("session_id = ? AND user_id = ?", sessionID, userID).
+		First(&record).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// ListRecords returns the records of a session.
+func (r *attendanceRepository) ListRecords(sessionID uuid.UUID) ([]domain.AttendanceRecord, error) {
+	var records []domain.AttendanceRecord
+	err := r.db.
+		Where("session_id = ?", sessionID).
+		Order("marked_at ASC").
+		Find(&records).Error
+	return records, err
+}
+
+// ListRecordsByInstance returns the records of every session of a course
+// instance.
+func (r *attendanceRepository) ListRecordsByInstance(instanceID uuid.UUID) ([]domain.AttendanceRecord, error) {
+	var records []domain.AttendanceRecord
+	err := r.db.
+		Where("session_id IN (?)", r.db.Model(&domain.AttendanceSession{}).Select("id").Where("course_instance_id = ?", instanceID)).
+		Find(&records).Error
+	return records, err
+}
+
+// ListRecordsByUser returns one student's records across the sessions of a
+// course instance.
+func (r *attendanceRepository) ListRecordsByUser(instanceID, userID uuid.UUID) ([]domain.AttendanceRecord, error) {
+	var records []domain.AttendanceRecord
+	err := r.db.
+		Where("user_id = ?", userID).
+		Where("session_id IN (?)", r.db.Model(&domain.AttendanceSession{}).Select("id").Where("course_instance_id = ?", instanceID)).
+		Find(&records).Error
+	return records, err
+}
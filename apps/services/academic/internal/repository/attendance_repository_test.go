@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupAttendanceTestDB extends the enrollment test schema with the
+// attendance tables, created by hand for the same reason as course_instances.
+func setupAttendanceTestDB(t *testing.T) *gorm.DB {
+	db := setupEnrollmentTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE attendance_sessions (
+		id TEXT PRIMARY KEY,
+		course_instance_id TEXT NOT NULL,
+		section_id TEXT,
+		date DATE NOT NULL,
+		type TEXT NOT NULL,
+		topic TEXT,
+		check_in_secret TEXT,
+		check_in_open_until DATETIME,
+		created_by TEXT,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE attendance_records (
+		session_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		source TEXT NOT NULL,
+		note TEXT,
+		marked_by TEXT,
+		marked_at DATETIME,
+		PRIMARY KEY (session_id, user_id)
+	)`).Error)
+
+	return db
+}
+
+func createTestSession(t *testing.T, repo AttendanceRepository, instanceID uuid.UUID) *domain.AttendanceSession {
+	session := &domain.AttendanceSession{
+		CourseInstanceID: instanceID,
+		Date:             time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Type:             domain.AttendanceSessionLecture,
+	}
+	require.NoError(t, repo.CreateSession(session))
+	return session
+}
+
+func TestUpsertAttendanceRecords(t *testing.T) {
+	db := setupAttendanceTestDB(t)
+	repo := NewAttendanceRepository(db)
+
+	instanceID := createTestInstance(t, db, 0)
+	session := createTestSession(t, repo, instanceID)
+	other := createTestSession(t, repo, createTestInstance(t, db, 0))
+	student := uuid.New()
+
+	mark := func(s *domain.AttendanceSession, status string) domain.AttendanceRecord {
+		return domain.AttendanceRecord{
+			SessionID: s.ID,
+			UserID:    student,
+			Status:    status,
+			Source:    domain.AttendanceSourceStaff,
+			MarkedAt:  time.Now(),
+		}
+	}
+
+	require.NoError(t, repo.UpsertRecords([]domain.AttendanceRecord{
+		mark(session, domain.AttendanceStatusAbsent),
+		mark(other, domain.AttendanceStatusPresent),
+	}))
+
+	// Marking again overwrites the earlier status.
+	require.NoError(t, repo.UpsertRecords([]domain.AttendanceRecord{mark(session, domain.AttendanceStatusExcused)}))
+
+	record, err := repo.GetRecord(session.ID, student)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, domain.AttendanceStatusExcused, record.Status)
+
+	records, err := repo.ListRecordsByUser(instanceID, student)
+	require.NoError(t, err)
+	require.Len(t, records, 1, "records of other course instances are excluded")
+	assert.Equal(t, session.ID, records[0].SessionID)
+
+	require.NoError(t, repo.DeleteSession(session.ID))
+	records, err = repo.ListRecordsByInstance(instanceID)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestListOpenSessions(t *testing.T) {
+	db := setupAttendanceTestDB(t)
+	repo := NewAttendanceRepository(db)
+
+	instanceID := createTestInstance(t, db, 0)
+	open := createTestSession(t, repo, instanceID)
+	expired := createTestSession(t, repo, instanceID)
+	createTestSession(t, repo, instanceID)
+
+	now := time.Now()
+	until := now.Add(10 * time.Minute)
+	open.CheckInSecret = "secret"
+	open.CheckInOpenUntil = &until
+	require.NoError(t, repo.UpdateSession(open))
+
+	past := now.Add(-time.Minute)
+	expired.CheckInSecret = "secret"
+	expired.CheckInOpenUntil = &past
+	require.NoError(t, repo.UpdateSession(expired))
+
+	sessions, err := repo.ListOpenSessions(instanceID, now)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, open.ID, sessions[0].ID)
+}
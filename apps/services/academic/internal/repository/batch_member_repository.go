@@ -6,17 +6,31 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BatchMemberRepository defines all data operations for batch members.
+// The methods that change memberships take the username of the actor, which
+// is recorded in the membership history.
 type BatchMemberRepository interface {
-	AddMember(member *domain.BatchMember) error
-	AddMembers(members []domain.BatchMember) error
+	AddMember(member *domain.BatchMember, actor string) error
+	AddMembers(members []domain.BatchMember, actor string) error
 	GetMembers(batchID uuid.UUID) ([]domain.BatchMember, error)
 	GetMember(batchID, userID uuid.UUID) (*domain.BatchMember, error)
 	GetBatchesByUserID(userID uuid.UUID) ([]uuid.UUID, error)
 	GetMembersByBatchID(batchID uuid.UUID) ([]uuid.UUID, error)
-	RemoveMember(batchID, userID uuid.UUID) error
+	GetMembersBySubtree(batchID uuid.UUID) ([]uuid.UUID, error)
+	GetMemberInSubtree(batchID, userID uuid.UUID) (*domain.BatchMember, error)
+	MoveMember(fromBatchID, toBatchID, userID uuid.UUID, actor string) (*domain.BatchMember, error)
+	RemoveMember(batchID, userID uuid.UUID, actor string) error
+	ListGraduatedUsers(maxEndYear int) ([]GraduatedUser, error)
+}
+
+// GraduatedUser is a user whose batches have all ended. EndYear is the
+// latest end year among them.
+type GraduatedUser struct {
+	UserID  uuid.UUID `json:"user_id"`
+	EndYear int       `json:"end_year"`
 }
 
 // batchMemberRepository is the concrete GORM-backed implementation.
@@ -30,16 +44,32 @@ func NewBatchMemberRepository(db *gorm.DB) BatchMemberRepository {
 }
 
 // AddMember inserts a new batch membership record.
-func (r *batchMemberRepository) AddMember(member *domain.BatchMember) error {
-	return r.db.Create(member).Error
+func (r *batchMemberRepository) AddMember(member *domain.BatchMember, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		if err := recordMembers(tx, []domain.BatchMember{*member}, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.NewBatchMemberEvent(domain.EventBatchMemberAdded, member))
+	})
 }
 
 // AddMembers inserts multiple batch membership records in one batch operation.
-func (r *batchMemberRepository) AddMembers(members []domain.BatchMember) error {
+func (r *batchMemberRepository) AddMembers(members []domain.BatchMember, actor string) error {
 	if len(members) == 0 {
 		return nil
 	}
-	return r.db.Create(&members).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&members).Error; err != nil {
+			return err
+		}
+		if err := recordMembers(tx, members, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, batchMemberEvents(domain.EventBatchMemberAdded, members)...)
+	})
 }
 
 // GetMembers returns all members belonging to the given batch.
@@ -90,9 +120,111 @@ func (r *batchMemberRepository) GetMembersByBatchID(batchID uuid.UUID) ([]uuid.U
 	return userIDs, err
 }
 
+// GetMembersBySubtree returns the distinct user IDs belonging to the given
+// batch or any of its descendants.
+func (r *batchMemberRepository) GetMembersBySubtree(batchID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Raw(batchSubtreeCTE+`
+		SELECT DISTINCT user_id FROM batch_members
+		WHERE batch_id IN (SELECT id FROM subtree)
+	`, batchID).Scan(&userIDs).Error
+	return userIDs, err
+}
+
+// GetMemberInSubtree loads the user's earliest membership in the given batch
+// or any of its descendants. Returns nil, nil when there is none.
+func (r *batchMemberRepository) GetMemberInSubtree(batchID, userID uuid.UUID) (*domain.BatchMember, error) {
+	var members []domain.BatchMember
+	err := r.db.Raw(batchSubtreeCTE+`
+		SELECT * FROM batch_members
+		WHERE user_id = ? AND batch_id IN (SELECT id FROM subtree)
+		ORDER BY enrolled_at ASC
+		LIMIT 1
+	`, batchID, userID).Scan(&members).Error
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+	return &members[0], nil
+}
+
+// MoveMember replaces the user's membership of fromBatchID with one of
+// toBatchID, keeping its status, in one transaction. Returns nil, nil when
+// the user is not a member of fromBatchID.
+func (r *batchMemberRepository) MoveMember(fromBatchID, toBatchID, userID uuid.UUID, actor string) (*domain.BatchMember, error) {
+	var moved *domain.BatchMember
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var removed []domain.BatchMember
+		if err := tx.Clauses(clause.Returning{}).
+			Where("batch_id = ? AND user_id = ?", fromBatchID, userID).
+			Delete(&removed).Error; err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+
+		member := &domain.BatchMember{
+			BatchID: toBatchID,
+			UserID:  userID,
+			Status:  removed[0].Status,
+		}
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		moved = member
+
+		if err := closeMembers(tx, removed, actor); err != nil {
+			return err
+		}
+		if err := recordMembers(tx, []domain.BatchMember{*member}, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx,
+			domain.NewBatchMemberEvent(domain.EventBatchMemberRemoved, &removed[0]),
+			domain.NewBatchMemberEvent(domain.EventBatchMemberAdded, member),
+		)
+	})
+	return moved, err
+}
+
 // RemoveMember hard-deletes the membership row identified by the composite key.
-func (r *batchMemberRepository) RemoveMember(batchID, userID uuid.UUID) error {
-	return r.db.
-		Where("batch_id = ? AND user_id = ?", batchID, userID).
-		Delete(&domain.BatchMember{}).Error
+func (r *batchMemberRepository) RemoveMember(batchID, userID uuid.UUID, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var removed []domain.BatchMember
+		if err := tx.Clauses(clause.Returning{}).
+			Where("batch_id = ? AND user_id = ?", batchID, userID).
+			Delete(&removed).Error; err != nil {
+			return err
+		}
+		if err := closeMembers(tx, removed, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, batchMemberEvents(domain.EventBatchMemberRemoved, removed)...)
+	})
+}
+
+// batchMemberEvents builds one event of the given type per membership.
+func batchMemberEvents(eventType string, members []domain.BatchMember) []domain.OutboxEvent {
+	events := make([]domain.OutboxEvent, len(members))
+	for i := range members {
+		events[i] = domain.NewBatchMemberEvent(eventType, &members[i])
+	}
+	return events
+}
+
+// ListGraduatedUsers returns users whose non-withdrawn memberships are all in
+// batches that ended in or before maxEndYear. A batch without an end year
+// counts as ongoing, so its members are never returned.
+func (r *batchMemberRepository) ListGraduatedUsers(maxEndYear int) ([]GraduatedUser, error) {
+	var users []GraduatedUser
+	err := r.db.
+		Table("batch_members AS bm").
+		Select("bm.user_id, MAX(b.end_year) AS end_year").
+		Joins("JOIN batches b ON b.id = bm.batch_id AND b.deleted_at IS NULL").
+		Where("bm.status <> ?", domain.BatchMemberStatusWithdrawn).
+		Group("bm.user_id").
+		Having("MAX(CASE WHEN COALESCE(b.end_year, 0) = 0 THEN 9999 ELSE b.end_year END) <= ?", maxEndYear).
+		Order("bm.user_id").
+		Scan(&users).Error
+	return users, err
 }
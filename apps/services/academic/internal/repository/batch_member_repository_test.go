@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupBatchTreeTestDB extends the enrollment test schema with the batch
+// tables. batches is created by hand for the same reason as course_instances.
+func setupBatchTreeTestDB(t *testing.T) *gorm.DB {
+	db := setupEnrollmentTestDB(t)
+
+	require.NoError(t, db.Exec(`ALTER TABLE course_instances ADD COLUMN include_sub_batches BOOLEAN NOT NULL DEFAULT false`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE batches (
+		id TEXT PRIMARY KEY,
+		parent_id TEXT,
+		deleted_at DATETIME
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE batch_members (
+		batch_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		enrolled_at DATETIME,
+		status TEXT NOT NULL,
+		PRIMARY KEY (batch_id, user_id)
+	)`).Error)
+
+	return db
+}
+
+func createTestBatch(t *testing.T, db *gorm.DB, parentID *uuid.UUID) uuid.UUID {
+	id := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO batches (id, parent_id) VALUES (?, ?)`, id, parentID).Error)
+	return id
+}
+
+func addTestMember(t *testing.T, repo BatchMemberRepository, batchID uuid.UUID) uuid.UUID {
+	userID := uuid.New()
+	require.NoError(t, repo.AddMember(&domain.BatchMember{
+		BatchID: batchID,
+		UserID:  userID,
+		Status:  domain.BatchMemberStatusActive,
+	}, "registrar"))
+	return userID
+}
+
+func TestGetMembersBySubtree(t *testing.T) {
+	db := setupBatchTreeTestDB(t)
+	repo := NewBatchMemberRepository(db)
+
+	intake := createTestBatch(t, db, nil)
+	track := createTestBatch(t, db, &intake)
+	team := createTestBatch(t, db, &track)
+	other := createTestBatch(t, db, nil)
+
+	a := addTestMember(t, repo, intake)
+	b := addTestMember(t, repo, track)
+	c := addTestMember(t, repo, team)
+	addTestMember(t, repo, other)
+
+	ids, err := repo.GetMembersBySubtree(intake)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{a, b, c}, ids)
+
+	ids, err = repo.GetMembersBySubtree(track)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{b, c}, ids)
+
+	member, err := repo.GetMemberInSubtree(intake, c)
+	require.NoError(t, err)
+	require.NotNil(t, member)
+	assert.Equal(t, team, member.BatchID)
+
+	member, err = repo.GetMemberInSubtree(track, a)
+	require.NoError(t, err)
+	assert.Nil(t, member)
+}
+
+func TestListInheritedByBatch(t *testing.T) {
+	db := setupBatchTreeTestDB(t)
+	repo := NewCourseInstanceRepository(db)
+
+	intake := createTestBatch(t, db, nil)
+	track := createTestBatch(t, db, &intake)
+	team := createTestBatch(t, db, &track)
+
+	inherited := uuid.New()
+	require.NoError(t, db.Exec(
+		`INSERT INTO course_instances (id, course_id, semester_id, batch_id, include_sub_batches) VALUES (?, ?, ?, ?, ?)`,
+		inherited, uuid.New(), uuid.New(), intake, true,
+	).Error)
+	// Only the instance that includes sub-batches is inherited.
+	require.NoError(t, db.Exec(
+		`INSERT INTO course_instances (id, course_id, semester_id, batch_id) VALUES (?, ?, ?, ?)`,
+		uuid.New(), uuid.New(), uuid.New(), track,
+	).Error)
+
+	instances, err := repo.ListInheritedByBatch(team)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, inherited, instances[0].ID)
+
+	instances, err = repo.ListInheritedByBatch(intake)
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+}
+
+func TestMoveMember(t *testing.T) {
+	db := setupBatchTreeTestDB(t)
+	repo := NewBatchMemberRepository(db)
+
+	from := createTestBatch(t, db, nil)
+	to := createTestBatch(t, db, nil)
+	userID := uuid.New()
+	require.NoError(t, repo.AddMember(&domain.BatchMember{
+		BatchID: from,
+		UserID:  userID,
+		Status:  domain.BatchMemberStatusSuspended,
+	}, "registrar"))
+
+	moved, err := repo.MoveMember(from, to, userID, "registrar")
+	require.NoError(t, err)
+	require.NotNil(t, moved)
+	assert.Equal(t, to, moved.BatchID)
+	assert.Equal(t, domain.BatchMemberStatusSuspended, moved.Status)
+
+	old, err := repo.GetMember(from, userID)
+	require.NoError(t, err)
+	assert.Nil(t, old)
+
+	assert.Equal(t, []string{
+		domain.EventBatchMemberAdded,
+		domain.EventBatchMemberRemoved,
+		domain.EventBatchMemberAdded,
+	}, outboxEvents(t, db))
+
+	// A user who is not a member of the source batch is left alone.
+	moved, err = repo.MoveMember(from, to, uuid.New(), "registrar")
+	require.NoError(t, err)
+	assert.Nil(t, moved)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -14,6 +15,7 @@ type BatchRepository interface {
 	UpdateBatch(batch *domain.Batch) error
 	GetBatchByID(id uuid.UUID) (*domain.Batch, error)
 	ListBatches(includeInactive bool) ([]domain.Batch, error)
+	ListBatchesPage(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Batch], error)
 	ListRootBatches(includeInactive bool) ([]domain.Batch, error)
 	ListChildren(parentID uuid.UUID) ([]domain.Batch, error)
 	SoftDeleteBatch(id uuid.UUID) error
@@ -22,6 +24,29 @@ type BatchRepository interface {
 	GetAllBatchesTree(includeInactive bool) ([]domain.Batch, error)
 }
 
+// batchSubtreeCTE selects, as "subtree", the id of the batch bound to its
+// placeholder and of every non-deleted descendant.
+const batchSubtreeCTE = `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM batches WHERE id = ? AND deleted_at IS NULL
+		UNION
+		SELECT b.id FROM batches b
+		INNER JOIN subtree s ON b.parent_id = s.id
+		WHERE b.deleted_at IS NULL
+	)`
+
+// batchAncestorsCTE selects, as "ancestors", the ids of every non-deleted
+// ancestor of the batch bound to its placeholder, excluding the batch itself.
+const batchAncestorsCTE = `
+	WITH RECURSIVE ancestors AS (
+		SELECT b.parent_id AS id FROM batches b
+		WHERE b.id = ? AND b.parent_id IS NOT NULL
+		UNION
+		SELECT b.parent_id FROM batches b
+		INNER JOIN ancestors a ON b.id = a.id
+		WHERE b.parent_id IS NOT NULL AND b.deleted_at IS NULL
+	)`
+
 // batchRepository is the concrete GORM-backed implementation.
 type batchRepository struct {
 	db *gorm.DB
@@ -72,6 +97,15 @@ func (r *batchRepository) ListBatches(includeInactive bool) ([]domain.Batch, err
 	return batches, err
 }
 
+// ListBatchesPage returns a page of the batches ListBatches returns.
+func (r *batchRepository) ListBatchesPage(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Batch], error) {
+	query := r.db.Where("deleted_at IS NULL")
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+	return queryspec.Find[domain.Batch](query, spec)
+}
+
 // ListRootBatches returns only top-level batches (parent_id IS NULL).
 func (r *batchRepository) ListRootBatches(includeInactive bool) ([]domain.Batch, error) {
 	var batches []domain.Batch
@@ -14,8 +14,13 @@ type CourseInstanceRepository interface {
 	Update(instance *domain.CourseInstance) error
 	GetByID(id uuid.UUID) (*domain.CourseInstance, error)
 	ListByBatch(batchID uuid.UUID) ([]domain.CourseInstance, error)
+	ListInheritedByBatch(batchID uuid.UUID) ([]domain.CourseInstance, error)
 	ListByCourse(courseID uuid.UUID) ([]domain.CourseInstance, error)
 	GetByUnique(courseID, semesterID, batchID uuid.UUID) (*domain.CourseInstance, error)
+	ListBySemester(semesterID uuid.UUID) ([]domain.CourseInstance, error)
+	CreateWithInstructors(instance *domain.CourseInstance, instructors []domain.CourseInstructor) error
+	ListByFaculty(facultyID uuid.UUID, semesterID *uuid.UUID) ([]domain.CourseInstance, error)
+	GetFacultyID(instanceID uuid.UUID) (uuid.UUID, error)
 }
 
 // courseInstanceRepository is the concrete GORM-backed implementation.
@@ -33,9 +38,24 @@ func (r *courseInstanceRepository) Create(instance *domain.CourseInstance) error
 	return r.db.Create(instance).Error
 }
 
-// Update saves changes to an existing course instance record.
+// Update saves changes to an existing course instance record, recording a
+// status change in the outbox.
 func (r *courseInstanceRepository) Update(instance *domain.CourseInstance) error {
-	return r.db.Save(instance).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous []string
+		if err := tx.Model(&domain.CourseInstance{}).
+			Where("id = ?", instance.ID).
+			Pluck("status", &previous).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(instance).Error; err != nil {
+			return err
+		}
+		if len(previous) == 0 || previous[0] == instance.Status {
+			return nil
+		}
+		return appendEvents(tx, domain.NewCourseInstanceStatusEvent(instance, previous[0]))
+	})
 }
 
 // GetByID loads a single course instance by primary key.
@@ -67,6 +87,19 @@ func (r *courseInstanceRepository) ListByBatch(batchID uuid.UUID) ([]domain.Cour
 	return instances, err
 }
 
+// ListInheritedByBatch returns the course instances of the batch's ancestors
+// that include sub-batches, i.e. those the batch's members inherit, ordered
+// by creation time ascending.
+func (r *courseInstanceRepository) ListInheritedByBatch(batchID uuid.UUID) ([]domain.CourseInstance, error) {
+	var instances []domain.CourseInstance
+	err := r.db.Raw(batchAncestorsCTE+`
+		SELECT * FROM course_instances
+		WHERE include_sub_batches = ? AND batch_id IN (SELECT id FROM ancestors)
+		ORDER BY created_at ASC
+	`, batchID, true).Scan(&instances).Error
+	return instances, err
+}
+
 // ListByCourse returns all course instances associated with the given course,
 // ordered by creation time ascending.
 func (r *courseInstanceRepository) ListByCourse(courseID uuid.UUID) ([]domain.CourseInstance, error) {
@@ -96,3 +129,71 @@ func (r *courseInstanceRepository) GetByUnique(courseID, semesterID, batchID uui
 
 	return &instance, nil
 }
+
+// ListBySemester returns all course instances of the given semester, ordered
+// by creation time ascending.
+func (r *courseInstanceRepository) ListBySemester(semesterID uuid.UUID) ([]domain.CourseInstance, error) {
+	var instances []domain.CourseInstance
+	err := r.db.
+		Where("semester_id = ?", semesterID).
+		Order("created_at ASC").
+		Find(&instances).Error
+	return instances, err
+}
+
+// facultyJoins joins a course_instances query through its batch, degree and
+// department up to the owning faculty.
+const facultyJoins = `
+	INNER JOIN batches ON batches.id = course_instances.batch_id
+	INNER JOIN degrees ON degrees.id = batches.degree_id
+	INNER JOIN departments ON departments.id = degrees.department_id`
+
+// ListByFaculty returns the course instances whose batch belongs to a degree
+// of the faculty, optionally limited to one semester, ordered by creation
+// time ascending.
+func (r *courseInstanceRepository) ListByFaculty(facultyID uuid.UUID, semesterID *uuid.UUID) ([]domain.CourseInstance, error) {
+	var instances []domain.CourseInstance
+	query := r.db.
+		Select("course_instances.*").
+		Joins(facultyJoins).
+		Where("departments.faculty_id = ?", facultyID)
+	if semesterID != nil {
+		query = query.Where("course_instances.semester_id = ?", *semesterID)
+	}
+	err := query.Order("course_instances.created_at ASC").Find(&instances).Error
+	return instances, err
+}
+
+// GetFacultyID resolves the faculty that owns a course instance.
+// Returns uuid.Nil, nil when the instance does not exist.
+func (r *courseInstanceRepository) GetFacultyID(instanceID uuid.UUID) (uuid.UUID, error) {
+	var facultyIDs []uuid.UUID
+	err := r.db.Model(&domain.CourseInstance{}).
+		Joins(facultyJoins).
+		Where("course_instances.id = ?", instanceID).
+		Pluck("departments.faculty_id", &facultyIDs).Error
+	if err != nil || len(facultyIDs) == 0 {
+		return uuid.Nil, err
+	}
+	return facultyIDs[0], nil
+}
+
+// CreateWithInstructors inserts a course instance and its instructor
+// assignments in one transaction.
+func (r *courseInstanceRepository) CreateWithInstructors(instance *domain.CourseInstance, instructors []domain.CourseInstructor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(instance).Error; err != nil {
+			return err
+		}
+		if len(instructors) == 0 {
+			return nil
+		}
+		for i := range instructors {
+			instructors[i].CourseInstanceID = instance.ID
+		}
+		if err := tx.Create(&instructors).Error; err != nil {
+			return err
+		}
+		return appendEvents(tx, instructorEvents(domain.EventInstructorAssigned, instructors)...)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CourseInstructorRepository defines all data operations for course instructors.
@@ -29,7 +30,12 @@ func NewCourseInstructorRepository(db *gorm.DB) CourseInstructorRepository {
 
 // AssignInstructor inserts a new course instructor assignment record.
 func (r *courseInstructorRepository) AssignInstructor(instructor *domain.CourseInstructor) error {
-	return r.db.Create(instructor).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(instructor).Error; err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.NewInstructorEvent(domain.EventInstructorAssigned, instructor))
+	})
 }
 
 // GetByCourseInstance returns all instructors assigned to the given course
@@ -62,11 +68,33 @@ func (r *courseInstructorRepository) GetInstructor(instanceID, userID uuid.UUID)
 }
 
 // RemoveInstructor hard-deletes the instructor assignment identified by the
-// composite primary key.
+// composite primary key, together with the user's assignments to the
+// instance's sections.
 func (r *courseInstructorRepository) RemoveInstructor(instanceID, userID uuid.UUID) error {
-	return r.db.
-		Where("course_instance_id = ? AND user_id = ?", instanceID, userID).
-		Delete(&domain.CourseInstructor{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var removed []domain.CourseInstructor
+		if err := tx.Clauses(clause.Returning{}).
+			Where("course_instance_id = ? AND user_id = ?", instanceID, userID).
+			Delete(&removed).Error; err != nil {
+			return err
+		}
+		if err := tx.
+			Where("user_id = ? AND section_id IN (?)", userID,
+				tx.Model(&domain.CourseSection{}).Select("id").Where("course_instance_id = ?", instanceID)).
+			Delete(&domain.SectionInstructor{}).Error; err != nil {
+			return err
+		}
+		return appendEvents(tx, instructorEvents(domain.EventInstructorRemoved, removed)...)
+	})
+}
+
+// instructorEvents builds one event of the given type per assignment.
+func instructorEvents(eventType string, instructors []domain.CourseInstructor) []domain.OutboxEvent {
+	events := make([]domain.OutboxEvent, len(instructors))
+	for i := range instructors {
+		events[i] = domain.NewInstructorEvent(eventType, &instructors[i])
+	}
+	return events
 }
 
 // GetByUserID returns all course instructor assignments for the given user,
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -14,7 +15,7 @@ type CourseRepository interface {
 	Update(course *domain.Course) error
 	GetByID(id uuid.UUID) (*domain.Course, error)
 	GetByCode(code string) (*domain.Course, error)
-	List(includeInactive bool) ([]domain.Course, error)
+	List(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Course], error)
 	Exists(id uuid.UUID) (bool, error)
 
 	// Prerequisite operations
@@ -22,6 +23,9 @@ type CourseRepository interface {
 	RemovePrerequisite(courseID, prerequisiteCourseID uuid.UUID) error
 	ListPrerequisites(courseID uuid.UUID) ([]domain.CoursePrerequisite, error)
 	PrerequisiteExists(courseID, prerequisiteCourseID uuid.UUID) (bool, error)
+	// ListAllPrerequisites returns every prerequisite rule, for walking the
+	// prerequisite graph.
+	ListAllPrerequisites() ([]domain.CoursePrerequisite, error)
 }
 
 // courseRepository is the concrete GORM-backed implementation.
@@ -80,17 +84,15 @@ func (r *courseRepository) GetByCode(code string) (*domain.Course, error) {
 	return &course, nil
 }
 
-// List returns all courses, optionally including inactive ones.
-func (r *courseRepository) List(includeInactive bool) ([]domain.Course, error) {
-	var courses []domain.Course
+// List returns a page of courses, optionally including inactive ones.
+func (r *courseRepository) List(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Course], error) {
 	query := r.db.Where("deleted_at IS NULL")
 
 	if !includeInactive {
 		query = query.Where("is_active = ?", true)
 	}
 
-	err := query.Order("created_at ASC").Find(&courses).Error
-	return courses, err
+	return queryspec.Find[domain.Course](query, spec)
 }
 
 // Exists reports whether a non-deleted course with the given id exists.
@@ -138,3 +140,11 @@ func (r *courseRepository) PrerequisiteExists(courseID, prerequisiteCourseID uui
 		Count(&count).Error
 	return count > 0, err
 }
+
+// ListAllPrerequisites returns every prerequisite rule without preloading
+// course details.
+func (r *courseRepository) ListAllPrerequisites() ([]domain.CoursePrerequisite, error) {
+	var prereqs []domain.CoursePrerequisite
+	err := r.db.Find(&prereqs).Error
+	return prereqs, err
+}
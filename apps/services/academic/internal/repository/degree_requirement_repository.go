@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DegreeRequirementRepository defines data operations for degree requirement
+// rules and the courses they list.
+type DegreeRequirementRepository interface {
+	// Create inserts the rule together with its courses.
+	Create(req *domain.DegreeRequirement) error
+	// Update saves the rule and replaces its courses.
+	Update(req *domain.DegreeRequirement) error
+	// GetByID returns the rule with its courses. Returns nil, nil when it
+	// does not exist.
+	GetByID(id uuid.UUID) (*domain.DegreeRequirement, error)
+	// ListByDegree returns every rule of the degree, including the
+	// specialization-specific ones, with their courses preloaded.
+	ListByDegree(degreeID uuid.UUID) ([]domain.DegreeRequirement, error)
+	// Delete removes the rule and its courses.
+	Delete(id uuid.UUID) error
+}
+
+// degreeRequirementRepository is the concrete GORM-backed implementation.
+type degreeRequirementRepository struct {
+	db *gorm.DB
+}
+
+// NewDegreeRequirementRepository creates a new degreeRequirementRepository.
+func NewDegreeRequirementRepository(db *gorm.DB) DegreeRequirementRepository {
+	return &degreeRequirementRepository{db: db}
+}
+
+// Create inserts a new rule and its courses in one transaction.
+func (r *degreeRequirementRepository) Create(req *domain.DegreeRequirement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		courses := req.Courses
+		req.Courses = nil
+		defer func() { req.Courses = courses }()
+
+		if err := tx.Create(req).Error; err != nil {
+			return err
+		}
+		return createRequirementCourses(tx, req.ID, courses)
+	})
+}
+
+// Update saves the rule's columns and rewrites its course list.
+func (r *degreeRequirementRepository) Update(req *domain.DegreeRequirement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		courses := req.Courses
+		req.Courses = nil
+		defer func() { req.Courses = courses }()
+
+		if err := tx.Omit("Degree", "Specialization").Save(req).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("requirement_id = ?", req.ID).Delete(&domain.DegreeRequirementCourse{}).Error; err != nil {
+			return err
+		}
+		return createRequirementCourses(tx, req.ID, courses)
+	})
+}
+
+// GetByID retrieves a rule by ID.
+func (r *degreeRequirementRepository) GetByID(id uuid.UUID) (*domain.DegreeRequirement, error) {
+	var req domain.DegreeRequirement
+	err := r.db.
+		Preload("Courses.Course").
+		Where("id = ?", id).
+		First(&req).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ListByDegree retrieves the degree's rules, oldest first so audits list
+// them in the order they were defined.
+func (r *degreeRequirementRepository) ListByDegree(degreeID uuid.UUID) ([]domain.DegreeRequirement, error) {
+	var reqs []domain.DegreeRequirement
+	err := r.db.
+		Preload("Courses.Course").
+		Where("degree_id = ?", degreeID).
+		Order("created_at ASC").
+		Find(&reqs).Error
+	return reqs, err
+}
+
+// Delete removes the rule; its courses are removed with it.
+func (r *degreeRequirementRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("requirement_id = ?", id).Delete(&domain.DegreeRequirementCourse{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.DegreeRequirement{}).Error
+	})
+}
+
+func createRequirementCourses(tx *gorm.DB, requirementID uuid.UUID, courses []domain.DegreeRequirementCourse) error {
+	if len(courses) == 0 {
+		return nil
+	}
+	rows := make([]domain.DegreeRequirementCourse, len(courses))
+	for i, c := range courses {
+		rows[i] = domain.DegreeRequirementCourse{RequirementID: requirementID, CourseID: c.CourseID}
+	}
+	return tx.Create(&rows).Error
+}
@@ -2,20 +2,65 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrAlreadyEnrolled is returned when the student already has an
+	// enrollment record for the course instance.
+	ErrAlreadyEnrolled = errors.New("student already enrolled")
+	// ErrCourseInstanceFull is returned when a change would take a seat in a
+	// course instance that has none left.
+	ErrCourseInstanceFull = errors.New("course instance is full")
+	// ErrCourseInstanceMissing is returned when the enrollment's course
+	// instance does not exist.
+	ErrCourseInstanceMissing = errors.New("course instance not found")
 )
 
 // EnrollmentRepository defines all data operations for student enrollments.
 type EnrollmentRepository interface {
-	EnrollStudent(enrollment *domain.Enrollment) error
-	UpdateEnrollment(enrollment *domain.Enrollment) error
+	// The methods that change enrollments take the username of the actor,
+	// which is recorded in the enrollment history.
+
+	EnrollStudent(enrollment *domain.Enrollment, actor string) error
+	UpdateEnrollment(enrollment *domain.Enrollment, actor string) error
 	GetEnrollments(instanceID uuid.UUID) ([]domain.Enrollment, error)
+	GetEnrollmentsPage(instanceID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Enrollment], error)
 	GetEnrollment(instanceID, userID uuid.UUID) (*domain.Enrollment, error)
 	GetByUserID(userID uuid.UUID) ([]domain.Enrollment, error)
-	RemoveEnrollment(instanceID, userID uuid.UUID) error
+	// GetHistoryByUserID returns the user's enrollments with their course
+	// instances loaded, for checking prerequisites.
+	GetHistoryByUserID(userID uuid.UUID) ([]domain.Enrollment, error)
+	RemoveEnrollment(instanceID, userID uuid.UUID, actor string) error
+	// CountByStatus returns, per course instance, how many enrollments are
+	// in each status. Instances without enrollments are absent from the map.
+	CountByStatus(instanceIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error)
+
+	// The capacity-aware methods below lock the course instance row for the
+	// duration of their transaction, so concurrent enrollments into the same
+	// instance are serialised and MaxEnrollment cannot be exceeded.
+
+	// EnrollWithCapacity inserts the enrollment. A seat-taking enrollment
+	// into a full instance is placed at the end of the waitlist instead;
+	// enrollment.Status and WaitlistPosition reflect the outcome.
+	EnrollWithCapacity(enrollment *domain.Enrollment, actor string) error
+	// UpdateWithCapacity saves the enrollment's status and final grade.
+	// Moving a student into a seat of a full instance fails with
+	// ErrCourseInstanceFull; freeing a seat promotes waitlisted students,
+	// which are returned.
+	UpdateWithCapacity(enrollment *domain.Enrollment, actor string) ([]domain.Enrollment, error)
+	// RemoveWithPromotion deletes the enrollment and promotes waitlisted
+	// students into any seat it frees.
+	RemoveWithPromotion(instanceID, userID uuid.UUID, actor string) ([]domain.Enrollment, error)
+	// PromoteWaitlisted fills free seats from the waitlist, e.g. after
+	// MaxEnrollment was raised.
+	PromoteWaitlisted(instanceID uuid.UUID, actor string) ([]domain.Enrollment, error)
 }
 
 // enrollmentRepository is the concrete GORM-backed implementation.
@@ -29,20 +74,43 @@ func NewEnrollmentRepository(db *gorm.DB) EnrollmentRepository {
 }
 
 // EnrollStudent inserts a new enrollment record.
-func (r *enrollmentRepository) EnrollStudent(enrollment *domain.Enrollment) error {
-	return r.db.Create(enrollment).Error
+func (r *enrollmentRepository) EnrollStudent(enrollment *domain.Enrollment, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(enrollment).Error; err != nil {
+			return err
+		}
+		if err := recordEnrollment(tx, enrollment, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.NewEnrollmentCreatedEvent(enrollment))
+	})
 }
 
 // UpdateEnrollment saves changes to an existing enrollment record (status,
 // final_grade, etc.).
-func (r *enrollmentRepository) UpdateEnrollment(enrollment *domain.Enrollment) error {
-	return r.db.
-		Model(&domain.Enrollment{}).
-		Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
-		Updates(map[string]interface{}{
-			"status":      enrollment.Status,
-			"final_grade": enrollment.FinalGrade,
-		}).Error
+func (r *enrollmentRepository) UpdateEnrollment(enrollment *domain.Enrollment, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current domain.Enrollment
+		if err := tx.Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
+			First(&current).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Enrollment{}).
+			Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
+			Updates(map[string]interface{}{
+				"status":      enrollment.Status,
+				"final_grade": enrollment.FinalGrade,
+			}).Error; err != nil {
+			return err
+		}
+		if current.Status == enrollment.Status {
+			return nil
+		}
+		if err := recordEnrollment(tx, enrollment, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.NewEnrollmentStatusEvent(enrollment, current.Status))
+	})
 }
 
 // GetEnrollments returns all enrollments for the given course instance, ordered
@@ -56,6 +124,12 @@ func (r *enrollmentRepository) GetEnrollments(instanceID uuid.UUID) ([]domain.En
 	return enrollments, err
 }
 
+// GetEnrollmentsPage returns a page of the enrollments for the given course
+// instance.
+func (r *enrollmentRepository) GetEnrollmentsPage(instanceID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Enrollment], error) {
+	return queryspec.Find[domain.Enrollment](r.db.Where("course_instance_id = ?", instanceID), spec)
+}
+
 // GetEnrollment loads a single enrollment by its composite primary key.
 // Returns nil, nil when no record is found.
 func (r *enrollmentRepository) GetEnrollment(instanceID, userID uuid.UUID) (*domain.Enrollment, error) {
@@ -76,10 +150,10 @@ func (r *enrollmentRepository) GetEnrollment(instanceID, userID uuid.UUID) (*dom
 
 // RemoveEnrollment hard-deletes the enrollment record identified by the
 // composite primary key.
-func (r *enrollmentRepository) RemoveEnrollment(instanceID, userID uuid.UUID) error {
-	return r.db.
-		Where("course_instance_id = ? AND user_id = ?", instanceID, userID).
-		Delete(&domain.Enrollment{}).Error
+func (r *enrollmentRepository) RemoveEnrollment(instanceID, userID uuid.UUID, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return removeEnrollment(tx, instanceID, userID, actor)
+	})
 }
 
 // GetByUserID returns all enrollments for the given user, ordered by enrolled_at
@@ -92,3 +166,327 @@ func (r *enrollmentRepository) GetByUserID(userID uuid.UUID) ([]domain.Enrollmen
 		Find(&enrollments).Error
 	return enrollments, err
 }
+
+// GetHistoryByUserID returns all enrollments for the given user with their
+// course instances preloaded.
+func (r *enrollmentRepository) GetHistoryByUserID(userID uuid.UUID) ([]domain.Enrollment, error) {
+	var enrollments []domain.Enrollment
+	err := r.db.
+		Where("user_id = ?", userID).
+		Preload("CourseInstance").
+		Find(&enrollments).Error
+	return enrollments, err
+}
+
+// CountByStatus returns the number of enrollments per status for each of
+// the course instances.
+func (r *enrollmentRepository) CountByStatus(instanceIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error) {
+	counts := make(map[uuid.UUID]map[string]int)
+	if len(instanceIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		CourseInstanceID uuid.UUID
+		Status           string
+		Count            int
+	}
+	err := r.db.
+		Model(&domain.Enrollment{}).
+		Select("course_instance_id, status, COUNT(*) AS count").
+		Where("course_instance_id IN ?", instanceIDs).
+		Group("course_instance_id, status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if counts[row.CourseInstanceID] == nil {
+			counts[row.CourseInstanceID] = make(map[string]int)
+		}
+		counts[row.CourseInstanceID][row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// EnrollWithCapacity inserts the enrollment, waitlisting it when the course
+// instance is full.
+func (r *enrollmentRepository) EnrollWithCapacity(enrollment *domain.Enrollment, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		instance, err := lockCourseInstance(tx, enrollment.CourseInstanceID)
+		if err != nil {
+			return err
+		}
+
+		var existing int64
+		if err := tx.Model(&domain.Enrollment{}).
+			Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrAlreadyEnrolled
+		}
+
+		enrollment.WaitlistPosition = nil
+		if domain.OccupiesSeat(enrollment.Status) {
+			full, err := isFull(tx, instance)
+			if err != nil {
+				return err
+			}
+			if full {
+				position, err := nextWaitlistPosition(tx, instance.ID)
+				if err != nil {
+					return err
+				}
+				enrollment.Status = domain.EnrollmentStatusWaitlisted
+				enrollment.WaitlistPosition = &position
+			}
+		}
+
+		if err := tx.Create(enrollment).Error; err != nil {
+			return err
+		}
+		if err := recordEnrollment(tx, enrollment, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.NewEnrollmentCreatedEvent(enrollment))
+	})
+}
+
+// UpdateWithCapacity saves the enrollment's status and grade, enforcing
+// capacity and promoting from the waitlist when a seat is freed.
+func (r *enrollmentRepository) UpdateWithCapacity(enrollment *domain.Enrollment, actor string) ([]domain.Enrollment, error) {
+	var promoted []domain.Enrollment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		instance, err := lockCourseInstance(tx, enrollment.CourseInstanceID)
+		if err != nil {
+			return err
+		}
+
+		var current domain.Enrollment
+		if err := tx.Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
+			First(&current).Error; err != nil {
+			return err
+		}
+
+		takesSeat := !domain.OccupiesSeat(current.Status) && domain.OccupiesSeat(enrollment.Status)
+		if takesSeat {
+			full, err := isFull(tx, instance)
+			if err != nil {
+				return err
+			}
+			if full {
+				return ErrCourseInstanceFull
+			}
+		}
+
+		updates := map[string]interface{}{
+			"status":      enrollment.Status,
+			"final_grade": enrollment.FinalGrade,
+		}
+		if enrollment.Status != domain.EnrollmentStatusWaitlisted {
+			updates["waitlist_position"] = nil
+			enrollment.WaitlistPosition = nil
+		}
+		if current.Status == domain.EnrollmentStatusWaitlisted && takesSeat {
+			now := time.Now().UTC()
+			updates["enrolled_at"] = now
+			enrollment.EnrolledAt = now
+		}
+		if err := tx.Model(&domain.Enrollment{}).
+			Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		if current.Status != enrollment.Status {
+			if err := recordEnrollment(tx, enrollment, actor); err != nil {
+				return err
+			}
+			if err := appendEvents(tx, domain.NewEnrollmentStatusEvent(enrollment, current.Status)); err != nil {
+				return err
+			}
+		}
+
+		promoted, err = promoteWaitlisted(tx, instance, actor)
+		return err
+	})
+	return promoted, err
+}
+
+// RemoveWithPromotion deletes the enrollment and fills the seat it frees.
+func (r *enrollmentRepository) RemoveWithPromotion(instanceID, userID uuid.UUID, actor string) ([]domain.Enrollment, error) {
+	var promoted []domain.Enrollment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		instance, err := lockCourseInstance(tx, instanceID)
+		if err != nil {
+			return err
+		}
+
+		if err := removeEnrollment(tx, instanceID, userID, actor); err != nil {
+			return err
+		}
+
+		promoted, err = promoteWaitlisted(tx, instance, actor)
+		return err
+	})
+	return promoted, err
+}
+
+// PromoteWaitlisted fills free seats of the course instance from its waitlist.
+func (r *enrollmentRepository) PromoteWaitlisted(instanceID uuid.UUID, actor string) ([]domain.Enrollment, error) {
+	var promoted []domain.Enrollment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		instance, err := lockCourseInstance(tx, instanceID)
+		if err != nil {
+			return err
+		}
+
+		promoted, err = promoteWaitlisted(tx, instance, actor)
+		return err
+	})
+	return promoted, err
+}
+
+// removeEnrollment deletes the enrollment and records its removal. Deleting
+// a missing enrollment is a no-op.
+func removeEnrollment(tx *gorm.DB, instanceID, userID uuid.UUID, actor string) error {
+	var removed []domain.Enrollment
+	if err := tx.Clauses(clause.Returning{}).
+		Where("course_instance_id = ? AND user_id = ?", instanceID, userID).
+		Delete(&removed).Error; err != nil {
+		return err
+	}
+	for i := range removed {
+		if err := closeEnrollment(tx, instanceID, userID, actor); err != nil {
+			return err
+		}
+		if err := appendEvents(tx, domain.NewEnrollmentRemovedEvent(&removed[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Capacity helpers — all expect the course instance row to be locked by tx
+// ─────────────────────────────────────────────────────────────────────────────
+
+// lockCourseInstance loads the course instance with SELECT ... FOR UPDATE.
+func lockCourseInstance(tx *gorm.DB, instanceID uuid.UUID) (*domain.CourseInstance, error) {
+	var instance domain.CourseInstance
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", instanceID).
+		First(&instance).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseInstanceMissing
+		}
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// seatCount returns how many enrollments currently occupy a seat.
+func seatCount(tx *gorm.DB, instanceID uuid.UUID) (int64, error) {
+	var count int64
+	err := tx.Model(&domain.Enrollment{}).
+		Where("course_instance_id = ? AND status NOT IN ?", instanceID,
+			[]string{domain.EnrollmentStatusDropped, domain.EnrollmentStatusWaitlisted}).
+		Count(&count).Error
+	return count, err
+}
+
+func isFull(tx *gorm.DB, instance *domain.CourseInstance) (bool, error) {
+	if instance.MaxEnrollment <= 0 {
+		return false, nil
+	}
+	seats, err := seatCount(tx, instance.ID)
+	if err != nil {
+		return false, err
+	}
+	return seats >= int64(instance.MaxEnrollment), nil
+}
+
+func nextWaitlistPosition(tx *gorm.DB, instanceID uuid.UUID) (int, error) {
+	var last *int
+	err := tx.Model(&domain.Enrollment{}).
+		Where("course_instance_id = ? AND status = ?", instanceID, domain.EnrollmentStatusWaitlisted).
+		Select("MAX(waitlist_position)").
+		Scan(&last).Error
+	if err != nil {
+		return 0, err
+	}
+	if last == nil {
+		return 1, nil
+	}
+	return *last + 1, nil
+}
+
+// promoteWaitlisted enrolls waitlisted students in queue order while seats
+// are free, then renumbers the remaining queue from 1. The promotions are
+// recorded as changes by the actor whose change freed the seats.
+func promoteWaitlisted(tx *gorm.DB, instance *domain.CourseInstance, actor string) ([]domain.Enrollment, error) {
+	var waitlist []domain.Enrollment
+	if err := tx.Where("course_instance_id = ? AND status = ?", instance.ID, domain.EnrollmentStatusWaitlisted).
+		Order("waitlist_position ASC, enrolled_at ASC").
+		Find(&waitlist).Error; err != nil {
+		return nil, err
+	}
+	if len(waitlist) == 0 {
+		return nil, nil
+	}
+
+	free := len(waitlist)
+	if instance.MaxEnrollment > 0 {
+		seats, err := seatCount(tx, instance.ID)
+		if err != nil {
+			return nil, err
+		}
+		free = instance.MaxEnrollment - int(seats)
+		if free < 0 {
+			free = 0
+		}
+		if free > len(waitlist) {
+			free = len(waitlist)
+		}
+	}
+
+	now := time.Now().UTC()
+	promoted := waitlist[:free]
+	for i := range promoted {
+		if err := tx.Model(&domain.Enrollment{}).
+			Where("course_instance_id = ? AND user_id = ?", instance.ID, promoted[i].UserID).
+			Updates(map[string]interface{}{
+				"status":            domain.EnrollmentStatusEnrolled,
+				"waitlist_position": nil,
+				"enrolled_at":       now,
+			}).Error; err != nil {
+			return nil, err
+		}
+		promoted[i].Status = domain.EnrollmentStatusEnrolled
+		promoted[i].WaitlistPosition = nil
+		promoted[i].EnrolledAt = now
+		if err := recordEnrollment(tx, &promoted[i], actor); err != nil {
+			return nil, err
+		}
+		if err := appendEvents(tx, domain.NewEnrollmentStatusEvent(&promoted[i], domain.EnrollmentStatusWaitlisted)); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, e := range waitlist[free:] {
+		position := i + 1
+		if e.WaitlistPosition != nil && *e.WaitlistPosition == position {
+			continue
+		}
+		if err := tx.Model(&domain.Enrollment{}).
+			Where("course_instance_id = ? AND user_id = ?", instance.ID, e.UserID).
+			Update("waitlist_position", position).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return promoted, nil
+}
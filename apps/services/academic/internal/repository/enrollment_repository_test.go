@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupEnrollmentTestDB creates the tables the capacity logic touches.
+// course_instances and the history tables are created by hand because their
+// gen_random_uuid() defaults are PostgreSQL-only.
+func setupEnrollmentTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Exec(`CREATE TABLE course_instances (
+		id TEXT PRIMARY KEY,
+		course_id TEXT NOT NULL,
+		semester_id TEXT NOT NULL,
+		batch_id TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'Planned',
+		max_enrollment INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME,
+		updated_at DATETIME
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE enrollments (
+		course_instance_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		status TEXT,
+		final_grade TEXT,
+		final_score REAL,
+		grade_points REAL,
+		grade_overridden BOOLEAN NOT NULL DEFAULT false,
+		waitlist_position INTEGER,
+		enrolled_at DATETIME,
+		PRIMARY KEY (course_instance_id, user_id)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE enrollment_history (
+		id TEXT PRIMARY KEY,
+		course_instance_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		valid_from DATETIME NOT NULL,
+		valid_to DATETIME,
+		changed_by TEXT,
+		ended_by TEXT
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE batch_member_history (
+		id TEXT PRIMARY KEY,
+		batch_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		valid_from DATETIME NOT NULL,
+		valid_to DATETIME,
+		changed_by TEXT,
+		ended_by TEXT
+	)`).Error)
+	require.NoError(t, db.AutoMigrate(&domain.OutboxEvent{}))
+
+	return db
+}
+
+// outboxEvents returns the event types recorded in the outbox, in order.
+func outboxEvents(t *testing.T, db *gorm.DB) []string {
+	var types []string
+	require.NoError(t, db.Model(&domain.OutboxEvent{}).Order("sequence ASC").Pluck("event_type", &types).Error)
+	return types
+}
+
+func createTestInstance(t *testing.T, db *gorm.DB, maxEnrollment int) uuid.UUID {
+	id := uuid.New()
+	require.NoError(t, db.Exec(
+		`INSERT INTO course_instances (id, course_id, semester_id, batch_id, max_enrollment) VALUES (?, ?, ?, ?, ?)`,
+		id, uuid.New(), uuid.New(), uuid.New(), maxEnrollment,
+	).Error)
+	return id
+}
+
+func enroll(t *testing.T, repo EnrollmentRepository, instanceID uuid.UUID) *domain.Enrollment {
+	e := &domain.Enrollment{
+		CourseInstanceID: instanceID,
+		UserID:           uuid.New(),
+		Status:           domain.EnrollmentStatusEnrolled,
+	}
+	require.NoError(t, repo.EnrollWithCapacity(e, "registrar"))
+	return e
+}
+
+func TestEnrollWithCapacity_WaitlistsWhenFull(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	instanceID := createTestInstance(t, db, 2)
+
+	first := enroll(t, repo, instanceID)
+	second := enroll(t, repo, instanceID)
+	third := enroll(t, repo, instanceID)
+	fourth := enroll(t, repo, instanceID)
+
+	assert.Equal(t, domain.EnrollmentStatusEnrolled, first.Status)
+	assert.Equal(t, domain.EnrollmentStatusEnrolled, second.Status)
+	assert.Equal(t, domain.EnrollmentStatusWaitlisted, third.Status)
+	require.NotNil(t, third.WaitlistPosition)
+	assert.Equal(t, 1, *third.WaitlistPosition)
+	require.NotNil(t, fourth.WaitlistPosition)
+	assert.Equal(t, 2, *fourth.WaitlistPosition)
+}
+
+func TestEnrollWithCapacity_Unlimited(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	instanceID := createTestInstance(t, db, 0)
+
+	for i := 0; i < 5; i++ {
+		e := enroll(t, repo, instanceID)
+		assert.Equal(t, domain.EnrollmentStatusEnrolled, e.Status)
+	}
+}
+
+func TestEnrollWithCapacity_Duplicate(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	instanceID := createTestInstance(t, db, 1)
+
+	e := enroll(t, repo, instanceID)
+	err := repo.EnrollWithCapacity(&domain.Enrollment{
+		CourseInstanceID: instanceID,
+		UserID:           e.UserID,
+		Status:           domain.EnrollmentStatusEnrolled,
+	}, "registrar")
+	assert.ErrorIs(t, err, ErrAlreadyEnrolled)
+}
+
+func TestUpdateWithCapacity_DropPromotesHeadOfWaitlist(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	instanceID := createTestInstance(t, db, 1)
+
+	seated := enroll(t, repo, instanceID)
+	next := enroll(t, repo, instanceID)
+	last := enroll(t, repo, instanceID)
+
+	seated.Status = domain.EnrollmentStatusDropped
+	promoted, err := repo.UpdateWithCapacity(seated, "registrar")
+	require.NoError(t, err)
+	require.Len(t, promoted, 1)
+	assert.Equal(t, next.UserID, promoted[0].UserID)
+
+	got, err := repo.GetEnrollment(instanceID, next.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EnrollmentStatusEnrolled, got.Status)
+	assert.Nil(t, got.WaitlistPosition)
+
+	got, err = repo.GetEnrollment(instanceID, last.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EnrollmentStatusWaitlisted, got.Status)
+	require.NotNil(t, got.WaitlistPosition)
+	assert.Equal(t, 1, *got.WaitlistPosition)
+}
+
+func TestUpdateWithCapacity_RejectsSeatWhenFull(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	instanceID := createTestInstance(t, db, 1)
+
+	enroll(t, repo, instanceID)
+	waiting := enroll(t, repo, instanceID)
+
+	waiting.Status = domain.EnrollmentStatusEnrolled
+	_, err := repo.UpdateWithCapacity(waiting, "registrar")
+	assert.ErrorIs(t, err, ErrCourseInstanceFull)
+}
+
+func TestRemoveWithPromotion(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	instanceID := createTestInstance(t, db, 1)
+
+	seated := enroll(t, repo, instanceID)
+	waiting := enroll(t, repo, instanceID)
+
+	promoted, err := repo.RemoveWithPromotion(instanceID, seated.UserID, "registrar")
+	require.NoError(t, err)
+	require.Len(t, promoted, 1)
+	assert.Equal(t, waiting.UserID, promoted[0].UserID)
+
+	assert.Equal(t, []string{
+		domain.EventEnrollmentCreated,
+		domain.EventEnrollmentCreated,
+		domain.EventEnrollmentDropped,
+		domain.EventEnrollmentStatusChanged,
+	}, outboxEvents(t, db))
+
+	var dropped domain.OutboxEvent
+	require.NoError(t, db.Where("event_type = ?", domain.EventEnrollmentDropped).First(&dropped).Error)
+	assert.Equal(t, domain.AggregateCourseInstance, dropped.AggregateType)
+	assert.Equal(t, instanceID, dropped.AggregateID)
+	assert.JSONEq(t, `{"course_instance_id":"`+instanceID.String()+`","user_id":"`+seated.UserID.String()+
+		`","status":"Dropped","previous_status":"Enrolled","removed":true}`, string(dropped.Payload))
+}
+
+func TestOutboxRelay_StopsAtFirstFailure(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	outbox := NewOutboxRepository(db)
+	instanceID := createTestInstance(t, db, 0)
+
+	enroll(t, repo, instanceID)
+	enroll(t, repo, instanceID)
+	enroll(t, repo, instanceID)
+
+	var seen []int64
+	published, err := outbox.Relay(10, func(e *domain.OutboxEvent) error {
+		if len(seen) == 1 {
+			return assert.AnError
+		}
+		seen = append(seen, e.Sequence)
+		return nil
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, published)
+
+	pending, err := outbox.List(OutboxFilter{AfterSequence: seen[0], Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Nil(t, pending[0].PublishedAt)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, assert.AnError.Error(), pending[0].LastError)
+	assert.Zero(t, pending[1].Attempts, "events after the failure are left alone")
+
+	published, err = outbox.Relay(10, func(e *domain.OutboxEvent) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+}
+
+func TestPromoteWaitlisted_AfterCapacityRaised(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	instanceID := createTestInstance(t, db, 1)
+
+	enroll(t, repo, instanceID)
+	enroll(t, repo, instanceID)
+	enroll(t, repo, instanceID)
+
+	require.NoError(t, db.Exec(`UPDATE course_instances SET max_enrollment = 2 WHERE id = ?`, instanceID).Error)
+
+	promoted, err := repo.PromoteWaitlisted(instanceID, "registrar")
+	require.NoError(t, err)
+	assert.Len(t, promoted, 1)
+}
@@ -25,6 +25,8 @@ type FacultyLeadershipRepository interface {
 	DeleteLeadersByFacultyID(facultyID uuid.UUID) error
 	GetLeadersByFacultyID(facultyID uuid.UUID) ([]domain.FacultyLeadership, error)
 	DeactivateLeadersByFacultyID(facultyID uuid.UUID) error
+	GetActiveLeadersByFacultyID(facultyID uuid.UUID) ([]domain.FacultyLeadership, error)
+	GetActiveLeadershipsByUserID(userID uuid.UUID) ([]domain.FacultyLeadership, error)
 }
 
 // facultyRepository is the concrete implementation
@@ -164,3 +166,30 @@ func (r *facultyLeadershipRepository) DeactivateLeadersByFacultyID(facultyID uui
 		Where("faculty_id = ?", facultyID).
 		Update("deleted_at", gorm.Expr("NOW()")).Error
 }
+
+// GetActiveLeadersByFacultyID retrieves the active leaders of a faculty
+func (r *facultyLeadershipRepository) GetActiveLeadersByFacultyID(facultyID uuid.UUID) ([]domain.FacultyLeadership, error) {
+	var leaders []domain.FacultyLeadership
+	err := r.db.Where("faculty_id = ? AND is_active = ? AND deleted_at IS NULL", facultyID, true).
+		Find(&leaders).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return leaders, nil
+}
+
+// GetActiveLeadershipsByUserID retrieves the active leadership roles a user
+// holds, one per faculty
+func (r *facultyLeadershipRepository) GetActiveLeadershipsByUserID(userID uuid.UUID) ([]domain.FacultyLeadership, error) {
+	var leaders []domain.FacultyLeadership
+	err := r.db.Where("user_id = ? AND is_active = ? AND deleted_at IS NULL", userID, true).
+		Find(&leaders).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return leaders, nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrGradesLocked is returned when final grades are changed after release.
+var ErrGradesLocked = errors.New("final grades are locked")
+
+// GradingSchemeRepository defines data operations for grading schemes and the
+// final grades computed from them.
+type GradingSchemeRepository interface {
+	// GetByCourseInstance returns the scheme with its categories and bands,
+	// bands ordered from the highest cut-off down. Returns nil, nil when the
+	// course instance has no scheme.
+	GetByCourseInstance(instanceID uuid.UUID) (*domain.GradingScheme, error)
+	// Save creates the scheme or replaces its categories and bands.
+	Save(scheme *domain.GradingScheme) error
+	// SaveFinalGrades stores the computed or reviewed grades of the given
+	// enrollments. It fails with ErrGradesLocked once the scheme is locked.
+	SaveFinalGrades(schemeID uuid.UUID, enrollments []domain.Enrollment) error
+	// Lock releases the final grades: the scheme is locked and each
+	// enrollment's status is saved along with its grade. Status changes are
+	// recorded in the enrollment history as made by lockedBy.
+	Lock(scheme *domain.GradingScheme, lockedBy string, enrollments []domain.Enrollment) error
+	// AmendFinalGrade changes a released grade and the status that follows
+	// from it. It is only used to carry out an approved grade change, so it
+	// ignores the lock.
+	AmendFinalGrade(enrollment *domain.Enrollment, actor string) error
+}
+
+// gradingSchemeRepository is the concrete GORM-backed implementation.
+type gradingSchemeRepository struct {
+	db *gorm.DB
+}
+
+// NewGradingSchemeRepository creates a new gradingSchemeRepository.
+func NewGradingSchemeRepository(db *gorm.DB) GradingSchemeRepository {
+	return &gradingSchemeRepository{db: db}
+}
+
+// GetByCourseInstance loads a course instance's grading scheme.
+func (r *gradingSchemeRepository) GetByCourseInstance(instanceID uuid.UUID) (*domain.GradingScheme, error) {
+	var scheme domain.GradingScheme
+	err := r.db.
+		Preload("Categories", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		Preload("Bands", func(db *gorm.DB) *gorm.DB { return db.Order("min_percent DESC") }).
+		Where("course_instance_id = ?", instanceID).
+		First(&scheme).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &scheme, nil
+}
+
+// Save upserts the scheme row and rewrites its categories and bands in one
+// transaction.
+func (r *gradingSchemeRepository) Save(scheme *domain.GradingScheme) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		categories, bands := scheme.Categories, scheme.Bands
+		scheme.Categories, scheme.Bands = nil, nil
+		defer func() { scheme.Categories, scheme.Bands = categories, bands }()
+
+		if scheme.ID == uuid.Nil {
+			if err := tx.Create(scheme).Error; err != nil {
+				return err
+			}
+		} else {
+			res := tx.Model(&domain.GradingScheme{}).
+				Where("id = ? AND locked_at IS NULL", scheme.ID).
+				Update("updated_at", time.Now().UTC())
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return ErrGradesLocked
+			}
+			if err := tx.Where("scheme_id = ?", scheme.ID).Delete(&domain.GradingCategory{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("scheme_id = ?", scheme.ID).Delete(&domain.GradeBand{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range categories {
+			categories[i].SchemeID = scheme.ID
+		}
+		for i := range bands {
+			bands[i].SchemeID = scheme.ID
+		}
+		if len(categories) > 0 {
+			if err := tx.Create(&categories).Error; err != nil {
+				return err
+			}
+		}
+		if len(bands) > 0 {
+			if err := tx.Create(&bands).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveFinalGrades updates the grade columns of each enrollment while the
+// scheme row is locked against a concurrent release.
+func (r *gradingSchemeRepository) SaveFinalGrades(schemeID uuid.UUID, enrollments []domain.Enrollment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOpenScheme(tx, schemeID); err != nil {
+			return err
+		}
+		for _, e := range enrollments {
+			if err := tx.Model(&domain.Enrollment{}).
+				Where("course_instance_id = ? AND user_id = ?", e.CourseInstanceID, e.UserID).
+				Updates(map[string]interface{}{
+					"final_grade":      e.FinalGrade,
+					"final_score":      e.FinalScore,
+					"grade_points":     e.GradePoints,
+					"grade_overridden": e.GradeOverridden,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Lock marks the scheme locked and saves each enrollment's status and grade.
+func (r *gradingSchemeRepository) Lock(scheme *domain.GradingScheme, lockedBy string, enrollments []domain.Enrollment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOpenScheme(tx, scheme.ID); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		if err := tx.Model(&domain.GradingScheme{}).
+			Where("id = ?", scheme.ID).
+			Updates(map[string]interface{}{
+				"locked_at": now,
+				"locked_by": lockedBy,
+			}).Error; err != nil {
+			return err
+		}
+		var current []domain.Enrollment
+		if err := tx.Where("course_instance_id = ?", scheme.CourseInstanceID).Find(&current).Error; err != nil {
+			return err
+		}
+		previous := make(map[uuid.UUID]string, len(current))
+		for _, e := range current {
+			previous[e.UserID] = e.Status
+		}
+		for i, e := range enrollments {
+			if err := tx.Model(&domain.Enrollment{}).
+				Where("course_instance_id = ? AND user_id = ?", e.CourseInstanceID, e.UserID).
+				Updates(map[string]interface{}{
+					"status":       e.Status,
+					"final_grade":  e.FinalGrade,
+					"grade_points": e.GradePoints,
+				}).Error; err != nil {
+				return err
+			}
+			if was, ok := previous[e.UserID]; ok && was != e.Status {
+				if err := recordEnrollment(tx, &enrollments[i], lockedBy); err != nil {
+					return err
+				}
+				if err := appendEvents(tx, domain.NewEnrollmentStatusEvent(&enrollments[i], was)); err != nil {
+					return err
+				}
+			}
+		}
+		scheme.LockedAt = &now
+		scheme.LockedBy = lockedBy
+		return nil
+	})
+}
+
+// AmendFinalGrade saves the enrollment's grade and status, recording a
+// status change in the outbox.
+func (r *gradingSchemeRepository) AmendFinalGrade(enrollment *domain.Enrollment, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current domain.Enrollment
+		if err := tx.Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
+			First(&current).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Enrollment{}).
+			Where("course_instance_id = ? AND user_id = ?", enrollment.CourseInstanceID, enrollment.UserID).
+			Updates(map[string]interface{}{
+				"status":           enrollment.Status,
+				"final_grade":      enrollment.FinalGrade,
+				"grade_points":     enrollment.GradePoints,
+				"grade_overridden": enrollment.GradeOverridden,
+			}).Error; err != nil {
+			return err
+		}
+		if current.Status == enrollment.Status {
+			return nil
+		}
+		if err := recordEnrollment(tx, enrollment, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.NewEnrollmentStatusEvent(enrollment, current.Status))
+	})
+}
+
+// lockOpenScheme takes a row lock on an unlocked scheme, failing with
+// ErrGradesLocked when it has already been locked.
+func lockOpenScheme(tx *gorm.DB, schemeID uuid.UUID) error {
+	var scheme domain.GradingScheme
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", schemeID).
+		First(&scheme).Error
+	if err != nil {
+		return err
+	}
+	if scheme.IsLocked() {
+		return ErrGradesLocked
+	}
+	return nil
+}
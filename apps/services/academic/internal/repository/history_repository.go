@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// HistoryFilter narrows a history query. With AsOf, only the versions valid
+// at that time are returned: those that began at or before it and had not
+// yet ended. Zero-valued fields do not filter.
+type HistoryFilter struct {
+	UserID *uuid.UUID
+	AsOf   *time.Time
+}
+
+// HistoryRepository reads the version history of enrollments and batch
+// memberships. Versions are written by the repositories that make the
+// changes, inside their own transactions.
+type HistoryRepository interface {
+	// EnrollmentHistory returns the course instance's enrollment versions,
+	// oldest first.
+	EnrollmentHistory(instanceID uuid.UUID, filter HistoryFilter) ([]domain.EnrollmentHistory, error)
+	// MemberHistory returns the batch's membership versions, oldest first.
+	MemberHistory(batchID uuid.UUID, filter HistoryFilter) ([]domain.BatchMemberHistory, error)
+}
+
+// historyRepository is the concrete GORM-backed implementation.
+type historyRepository struct {
+	db *gorm.DB
+}
+
+// NewHistoryRepository creates a new historyRepository.
+func NewHistoryRepository(db *gorm.DB) HistoryRepository {
+	return &historyRepository{db: db}
+}
+
+// EnrollmentHistory returns the versions ordered by when they began.
+func (r *historyRepository) EnrollmentHistory(instanceID uuid.UUID, filter HistoryFilter) ([]domain.EnrollmentHistory, error) {
+	var versions []domain.EnrollmentHistory
+	err := r.db.Scopes(filter.apply).
+		Where("course_instance_id = ?", instanceID).
+		Order("valid_from ASC").
+		Find(&versions).Error
+	return versions, err
+}
+
+// MemberHistory returns the versions ordered by when they began.
+func (r *historyRepository) MemberHistory(batchID uuid.UUID, filter HistoryFilter) ([]domain.BatchMemberHistory, error) {
+	var versions []domain.BatchMemberHistory
+	err := r.db.Scopes(filter.apply).
+		Where("batch_id = ?", batchID).
+		Order("valid_from ASC").
+		Find(&versions).Error
+	return versions, err
+}
+
+func (f HistoryFilter) apply(db *gorm.DB) *gorm.DB {
+	if f.UserID != nil {
+		db = db.Where("user_id = ?", *f.UserID)
+	}
+	if f.AsOf != nil {
+		db = db.Where("valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", *f.AsOf, *f.AsOf)
+	}
+	return db
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Writers — called inside the transaction that makes the change
+// ─────────────────────────────────────────────────────────────────────────────
+
+// recordEnrollment closes the enrollment's current version and opens one
+// with its new status.
+func recordEnrollment(tx *gorm.DB, e *domain.Enrollment, actor string) error {
+	now := time.Now().UTC()
+	if err := closeEnrollmentAt(tx, e.CourseInstanceID, e.UserID, actor, now); err != nil {
+		return err
+	}
+	return tx.Create(&domain.EnrollmentHistory{
+		CourseInstanceID: e.CourseInstanceID,
+		UserID:           e.UserID,
+		Status:           e.Status,
+		ValidFrom:        now,
+		ChangedBy:        actor,
+	}).Error
+}
+
+// closeEnrollment ends the current version of a removed enrollment.
+func closeEnrollment(tx *gorm.DB, instanceID, userID uuid.UUID, actor string) error {
+	return closeEnrollmentAt(tx, instanceID, userID, actor, time.Now().UTC())
+}
+
+func closeEnrollmentAt(tx *gorm.DB, instanceID, userID uuid.UUID, actor string, at time.Time) error {
+	return tx.Model(&domain.EnrollmentHistory{}).
+		Where("course_instance_id = ? AND user_id = ? AND valid_to IS NULL", instanceID, userID).
+		Updates(map[string]interface{}{
+			"valid_to": at,
+			"ended_by": actor,
+		}).Error
+}
+
+// recordMembers closes the current version of each membership and opens one
+// with its new status.
+func recordMembers(tx *gorm.DB, members []domain.BatchMember, actor string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	versions := make([]domain.BatchMemberHistory, len(members))
+	for i, m := range members {
+		if err := closeMemberAt(tx, m.BatchID, m.UserID, actor, now); err != nil {
+			return err
+		}
+		versions[i] = domain.BatchMemberHistory{
+			BatchID:   m.BatchID,
+			UserID:    m.UserID,
+			Status:    m.Status,
+			ValidFrom: now,
+			ChangedBy: actor,
+		}
+	}
+	return tx.CreateInBatches(&versions, 100).Error
+}
+
+// closeMembers ends the current version of each removed membership.
+func closeMembers(tx *gorm.DB, members []domain.BatchMember, actor string) error {
+	now := time.Now().UTC()
+	for _, m := range members {
+		if err := closeMemberAt(tx, m.BatchID, m.UserID, actor, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func closeMemberAt(tx *gorm.DB, batchID, userID uuid.UUID, actor string, at time.Time) error {
+	return tx.Model(&domain.BatchMemberHistory{}).
+		Where("batch_id = ? AND user_id = ? AND valid_to IS NULL", batchID, userID).
+		Updates(map[string]interface{}{
+			"valid_to": at,
+			"ended_by": actor,
+		}).Error
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tick returns a moment strictly between the changes made before and after
+// it.
+func tick() time.Time {
+	time.Sleep(5 * time.Millisecond)
+	at := time.Now().UTC()
+	time.Sleep(5 * time.Millisecond)
+	return at
+}
+
+func rosterAsOf(t *testing.T, repo HistoryRepository, instanceID uuid.UUID, at time.Time) map[uuid.UUID]string {
+	versions, err := repo.EnrollmentHistory(instanceID, HistoryFilter{AsOf: &at})
+	require.NoError(t, err)
+	roster := make(map[uuid.UUID]string, len(versions))
+	for _, v := range versions {
+		roster[v.UserID] = v.Status
+	}
+	return roster
+}
+
+func TestEnrollmentHistoryAsOf(t *testing.T) {
+	db := setupEnrollmentTestDB(t)
+	repo := NewEnrollmentRepository(db)
+	history := NewHistoryRepository(db)
+	instanceID := createTestInstance(t, db, 1)
+
+	before := tick()
+	seated := enroll(t, repo, instanceID)
+	waiting := enroll(t, repo, instanceID)
+	enrolled := tick()
+
+	seated.Status = domain.EnrollmentStatusDropped
+	_, err := repo.UpdateWithCapacity(seated, "registrar")
+	require.NoError(t, err)
+	dropped := tick()
+
+	_, err = repo.RemoveWithPromotion(instanceID, seated.UserID, "admin")
+	require.NoError(t, err)
+	removed := tick()
+
+	assert.Empty(t, rosterAsOf(t, history, instanceID, before))
+	assert.Equal(t, map[uuid.UUID]string{
+		seated.UserID:  domain.EnrollmentStatusEnrolled,
+		waiting.UserID: domain.EnrollmentStatusWaitlisted,
+	}, rosterAsOf(t, history, instanceID, enrolled))
+	assert.Equal(t, map[uuid.UUID]string{
+		seated.UserID:  domain.EnrollmentStatusDropped,
+		waiting.UserID: domain.EnrollmentStatusEnrolled,
+	}, rosterAsOf(t, history, instanceID, dropped))
+	assert.Equal(t, map[uuid.UUID]string{
+		waiting.UserID: domain.EnrollmentStatusEnrolled,
+	}, rosterAsOf(t, history, instanceID, removed))
+
+	versions, err := history.EnrollmentHistory(instanceID, HistoryFilter{UserID: &seated.UserID})
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, domain.EnrollmentStatusEnrolled, versions[0].Status)
+	assert.Equal(t, "registrar", versions[0].EndedBy)
+	assert.Equal(t, domain.EnrollmentStatusDropped, versions[1].Status)
+	assert.Equal(t, "registrar", versions[1].ChangedBy)
+	require.NotNil(t, versions[1].ValidTo)
+	assert.Equal(t, "admin", versions[1].EndedBy)
+
+	// The promotion is attributed to the change that freed the seat.
+	versions, err = history.EnrollmentHistory(instanceID, HistoryFilter{UserID: &waiting.UserID})
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "registrar", versions[1].ChangedBy)
+	assert.Nil(t, versions[1].ValidTo)
+}
+
+func TestMemberHistoryAsOf(t *testing.T) {
+	db := setupBatchTreeTestDB(t)
+	repo := NewBatchMemberRepository(db)
+	history := NewHistoryRepository(db)
+
+	from := createTestBatch(t, db, nil)
+	to := createTestBatch(t, db, nil)
+	userID := addTestMember(t, repo, from)
+	joined := tick()
+
+	_, err := repo.MoveMember(from, to, userID, "admin")
+	require.NoError(t, err)
+	moved := tick()
+
+	require.NoError(t, repo.RemoveMember(to, userID, "admin"))
+	left := tick()
+
+	members := func(batchID uuid.UUID, at time.Time) int {
+		versions, err := history.MemberHistory(batchID, HistoryFilter{AsOf: &at})
+		require.NoError(t, err)
+		return len(versions)
+	}
+	assert.Equal(t, 1, members(from, joined))
+	assert.Equal(t, 0, members(to, joined))
+	assert.Equal(t, 0, members(from, moved))
+	assert.Equal(t, 1, members(to, moved))
+	assert.Equal(t, 0, members(to, left))
+
+	versions, err := history.MemberHistory(from, HistoryFilter{UserID: &userID})
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "registrar", versions[0].ChangedBy)
+	assert.Equal(t, "admin", versions[0].EndedBy)
+}
@@ -44,6 +44,28 @@ func (m *Migrator) Run() error {
 		&domain.CourseInstance{},
 		&domain.CourseInstructor{},
 		&domain.Enrollment{},
+		// Enrollment and batch membership history
+		&domain.EnrollmentHistory{},
+		&domain.BatchMemberHistory{},
+		// Sections, lab groups and timetable
+		&domain.CourseSection{},
+		&domain.SectionMember{},
+		&domain.SectionInstructor{},
+		&domain.SectionSlot{},
+		// Attendance
+		&domain.AttendanceSession{},
+		&domain.AttendanceRecord{},
+		// Approvals
+		&domain.ApprovalRequest{},
+		// Final grades
+		&domain.GradingScheme{},
+		&domain.GradingCategory{},
+		&domain.GradeBand{},
+		// Degree audit
+		&domain.DegreeRequirement{},
+		&domain.DegreeRequirementCourse{},
+		// Transactional outbox
+		&domain.OutboxEvent{},
 	); err != nil {
 		return fmt.Errorf("auto migrate: %w", err)
 	}
@@ -178,6 +200,31 @@ func (m *Migrator) Run() error {
 		m.logger.Warn("failed to create index on semesters(term_type)", zap.Error(err))
 	}
 
+	// Open a first history version for enrollments and memberships that
+	// predate the history tables, starting when the student joined.
+	if err := m.db.Exec(`
+		INSERT INTO enrollment_history (id, course_instance_id, user_id, status, valid_from)
+		SELECT gen_random_uuid(), e.course_instance_id, e.user_id, e.status, e.enrolled_at
+		FROM enrollments e
+		WHERE NOT EXISTS (
+			SELECT 1 FROM enrollment_history h
+			WHERE h.course_instance_id = e.course_instance_id AND h.user_id = e.user_id
+		)
+	`).Error; err != nil {
+		m.logger.Warn("failed to backfill enrollment_history", zap.Error(err))
+	}
+	if err := m.db.Exec(`
+		INSERT INTO batch_member_history (id, batch_id, user_id, status, valid_from)
+		SELECT gen_random_uuid(), bm.batch_id, bm.user_id, bm.status, bm.enrolled_at
+		FROM batch_members bm
+		WHERE NOT EXISTS (
+			SELECT 1 FROM batch_member_history h
+			WHERE h.batch_id = bm.batch_id AND h.user_id = bm.user_id
+		)
+	`).Error; err != nil {
+		m.logger.Warn("failed to backfill batch_member_history", zap.Error(err))
+	}
+
 	m.logger.Info("migrations completed successfully")
 	return nil
 }
@@ -1,6 +1,8 @@
 package migrations
 
 import (
+	"time"
+
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -55,8 +57,8 @@ func (s *Seeder) seedSemesters() error {
 			Name:      "Fall 2024",
 			Code:      "FALL-2024",
 			TermType:  domain.TermTypeFall,
-			StartDate: "2024-09-01",
-			EndDate:   "2024-12-15",
+			StartDate: domain.NewDate(2024, time.September, 1),
+			EndDate:   domain.NewDate(2024, time.December, 15),
 			Status:    domain.SemesterStatusCompleted,
 			IsActive:  false,
 		},
@@ -64,8 +66,8 @@ func (s *Seeder) seedSemesters() error {
 			Name:      "Spring 2025",
 			Code:      "SPRING-2025",
 			TermType:  domain.TermTypeSpring,
-			StartDate: "2025-01-15",
-			EndDate:   "2025-05-15",
+			StartDate: domain.NewDate(2025, time.January, 15),
+			EndDate:   domain.NewDate(2025, time.May, 15),
 			Status:    domain.SemesterStatusActive,
 			IsActive:  true,
 		},
@@ -73,8 +75,8 @@ func (s *Seeder) seedSemesters() error {
 			Name:      "Summer 2025",
 			Code:      "SUMMER-2025",
 			TermType:  domain.TermTypeSummer,
-			StartDate: "2025-06-01",
-			EndDate:   "2025-08-15",
+			StartDate: domain.NewDate(2025, time.June, 1),
+			EndDate:   domain.NewDate(2025, time.August, 15),
 			Status:    domain.SemesterStatusPlanned,
 			IsActive:  true,
 		},
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxFilter selects stored events for replay. Events are returned in
+// sequence order starting after AfterSequence; zero-valued fields do not
+// filter.
+type OutboxFilter struct {
+	AfterSequence int64
+	UpToSequence  int64
+	AggregateType string
+	AggregateID   *uuid.UUID
+	EventType     string
+	PublishedOnly bool
+	Limit         int
+}
+
+// CourseAccessSnapshot is the current set of enrollments, instructor
+// assignments and section memberships together with the event sequences it
+// reflects. Sequence is the
+// highest committed sequence overall; CourseInstanceSequences holds the
+// highest per course instance, for instances that have events.
+type CourseAccessSnapshot struct {
+	Sequence                int64
+	CourseInstanceSequences map[uuid.UUID]int64
+	Enrollments             []domain.Enrollment
+	Instructors             []domain.CourseInstructor
+	SectionMembers          []domain.SectionMember
+}
+
+// OutboxRepository reads and relays the transactional outbox. Events are
+// appended by the repositories that make the changes, inside their own
+// transactions.
+type OutboxRepository interface {
+	// Relay hands up to limit unpublished events to publish in sequence
+	// order and marks each one published once publish returns nil. It stops
+	// at the first failure, recording it on the event, so later events of
+	// the same aggregate are never published ahead of it. The rows stay
+	// locked while publishing, so concurrent relays do not interleave.
+	Relay(limit int, publish func(*domain.OutboxEvent) error) (int, error)
+	// List returns stored events, published or not.
+	List(filter OutboxFilter) ([]domain.OutboxEvent, error)
+	// CourseAccessSnapshot reads every enrollment, instructor assignment and
+	// section membership and the highest event sequence from one consistent snapshot, so
+	// consumers can rebuild a read-model and resume from the event stream.
+	CourseAccessSnapshot() (*CourseAccessSnapshot, error)
+}
+
+// outboxRepository is the concrete GORM-backed implementation.
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outboxRepository.
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Relay publishes the oldest unpublished events.
+func (r *outboxRepository) Relay(limit int, publish func(*domain.OutboxEvent) error) (int, error) {
+	published := 0
+	var publishErr error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var events []domain.OutboxEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("published_at IS NULL").
+			Order("sequence ASC").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+
+		for i := range events {
+			event := &events[i]
+			if publishErr = publish(event); publishErr != nil {
+				return tx.Model(&domain.OutboxEvent{}).
+					Where("sequence = ?", event.Sequence).
+					Updates(map[string]interface{}{
+						"attempts":   gorm.Expr("attempts + 1"),
+						"last_error": publishErr.Error(),
+					}).Error
+			}
+			if err := tx.Model(&domain.OutboxEvent{}).
+				Where("sequence = ?", event.Sequence).
+				Updates(map[string]interface{}{
+					"attempts":     gorm.Expr("attempts + 1"),
+					"published_at": time.Now().UTC(),
+					"last_error":   "",
+				}).Error; err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return published, publishErr
+}
+
+// List returns events matching the filter in sequence order.
+func (r *outboxRepository) List(filter OutboxFilter) ([]domain.OutboxEvent, error) {
+	query := r.db.Where("sequence > ?", filter.AfterSequence)
+	if filter.UpToSequence > 0 {
+		query = query.Where("sequence <= ?", filter.UpToSequence)
+	}
+	if filter.AggregateType != "" {
+		query = query.Where("aggregate_type = ?", filter.AggregateType)
+	}
+	if filter.AggregateID != nil {
+		query = query.Where("aggregate_id = ?", *filter.AggregateID)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.PublishedOnly {
+		query = query.Where("published_at IS NOT NULL")
+	}
+
+	var events []domain.OutboxEvent
+	err := query.Order("sequence ASC").Limit(filter.Limit).Find(&events).Error
+	return events, err
+}
+
+// CourseAccessSnapshot loads the snapshot in a read-only repeatable-read
+// transaction.
+//
+// Sequences are allocated before commit, so an event below the overall
+// maximum can still commit after the snapshot. Events of one course instance
+// do commit in sequence order (see appendEvents), which makes the
+// per-instance maximum a safe watermark for that instance's rows.
+func (r *outboxRepository) CourseAccessSnapshot() (*CourseAccessSnapshot, error) {
+	snap := &CourseAccessSnapshot{CourseInstanceSequences: make(map[uuid.UUID]int64)}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.OutboxEvent{}).
+			Select("COALESCE(MAX(sequence), 0)").
+			Scan(&snap.Sequence).Error; err != nil {
+			return err
+		}
+		var sequences []struct {
+			AggregateID uuid.UUID
+			Sequence    int64
+		}
+		if err := tx.Model(&domain.OutboxEvent{}).
+			Select("aggregate_id, MAX(sequence) AS sequence").
+			Where("aggregate_type = ?", domain.AggregateCourseInstance).
+			Group("aggregate_id").
+			Scan(&sequences).Error; err != nil {
+			return err
+		}
+		for _, s := range sequences {
+			snap.CourseInstanceSequences[s.AggregateID] = s.Sequence
+		}
+		if err := tx.Select("course_instance_id", "user_id", "status").
+			Order("course_instance_id, user_id").
+			Find(&snap.Enrollments).Error; err != nil {
+			return err
+		}
+		if err := tx.Order("course_instance_id, user_id").Find(&snap.Instructors).Error; err != nil {
+			return err
+		}
+		return tx.Select("course_instance_id", "section_id", "user_id").
+			Order("course_instance_id, user_id, section_id").
+			Find(&snap.SectionMembers).Error
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Appending — called by other repositories inside their transactions
+// ─────────────────────────────────────────────────────────────────────────────
+
+// appendEvents writes events to the outbox as part of tx. On PostgreSQL it
+// first takes a transaction-scoped advisory lock per aggregate, so two
+// transactions touching the same aggregate commit their events in sequence
+// order and the relay can never publish them out of order.
+func appendEvents(tx *gorm.DB, events ...domain.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if tx.Dialector.Name() == "postgres" {
+		// Lock in a fixed order so transactions spanning several aggregates
+		// cannot deadlock each other.
+		seen := make(map[string]struct{}, len(events))
+		var aggregates []string
+		for _, e := range events {
+			id := e.AggregateID.String()
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				aggregates = append(aggregates, id)
+			}
+		}
+		sort.Strings(aggregates)
+		for _, id := range aggregates {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", id).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Create(&events).Error
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SectionRepository defines all data operations for course sections, their
+// members, instructors and timetable slots.
+type SectionRepository interface {
+	Create(section *domain.CourseSection) error
+	Update(section *domain.CourseSection) error
+	Delete(id uuid.UUID) error
+	GetByID(id uuid.UUID) (*domain.CourseSection, error)
+	ListByInstance(instanceID uuid.UUID) ([]domain.CourseSection, error)
+	CountMembers(instanceID uuid.UUID) (map[uuid.UUID]int, error)
+
+	// AssignMembers inserts the memberships. With replace, each student's
+	// membership in another section of the same kind is removed first;
+	// otherwise students who already have one are skipped. It returns the
+	// memberships actually inserted.
+	AssignMembers(members []domain.SectionMember, replace bool) ([]domain.SectionMember, error)
+	RemoveMember(sectionID, userID uuid.UUID) (bool, error)
+	ListMembers(sectionID uuid.UUID) ([]domain.SectionMember, error)
+	ListAssignedUsers(instanceID uuid.UUID, kind string) ([]uuid.UUID, error)
+	// ListUserSections returns the sections the user belongs to with their
+	// slots and instructors, limited to one course instance when instanceID
+	// is set. Memberships in instances the user has dropped are left out.
+	ListUserSections(userID uuid.UUID, instanceID *uuid.UUID) ([]domain.CourseSection, error)
+
+	AddInstructor(instructor *domain.SectionInstructor) error
+	GetInstructor(sectionID, userID uuid.UUID) (*domain.SectionInstructor, error)
+	RemoveInstructor(sectionID, userID uuid.UUID) (bool, error)
+
+	AddSlot(slot *domain.SectionSlot) error
+	RemoveSlot(sectionID, slotID uuid.UUID) (bool, error)
+	// FindRoomClashes returns the slots in the same room of any section in
+	// the semester that overlap the given slot.
+	FindRoomClashes(semesterID uuid.UUID, slot *domain.SectionSlot) ([]domain.SectionSlot, error)
+}
+
+// activeSectionMembers restricts section_members (as sm) to students whose
+// enrollment in the course instance has not been dropped. Memberships are
+// not removed on a drop, so re-enrolling restores the student's sections.
+const activeSectionMembers = `JOIN enrollments e
+	ON e.course_instance_id = sm.course_instance_id
+	AND e.user_id = sm.user_id
+	AND e.status <> '` + domain.EnrollmentStatusDropped + `'`
+
+// sectionRepository is the concrete GORM-backed implementation.
+type sectionRepository struct {
+	db *gorm.DB
+}
+
+// NewSectionRepository creates a new sectionRepository.
+func NewSectionRepository(db *gorm.DB) SectionRepository {
+	return &sectionRepository{db: db}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Sections
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Create inserts a new section record.
+func (r *sectionRepository) Create(section *domain.CourseSection) error {
+	return r.db.Omit(clause.Associations).Create(section).Error
+}
+
+// Update saves the section's own columns.
+func (r *sectionRepository) Update(section *domain.CourseSection) error {
+	return r.db.Omit(clause.Associations).Save(section).Error
+}
+
+// Delete hard-deletes a section; its members, instructors and slots go with
+// it.
+func (r *sectionRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var removed []domain.SectionMember
+		if err := tx.Clauses(clause.Returning{}).
+			Where("section_id = ?", id).
+			Delete(&removed).Error; err != nil {
+			return err
+		}
+		for _, model := range []interface{}{&domain.SectionInstructor{}, &domain.SectionSlot{}} {
+			if err := tx.Where("section_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("id = ?", id).Delete(&domain.CourseSection{}).Error; err != nil {
+			return err
+		}
+		return appendEvents(tx, sectionMemberEvents(domain.EventSectionMemberRemoved, removed)...)
+	})
+}
+
+// GetByID loads a single section with its instructors and slots.
+// Returns nil, nil when no record is found.
+func (r *sectionRepository) GetByID(id uuid.UUID) (*domain.CourseSection, error) {
+	var section domain.CourseSection
+	err := r.withDetails(r.db).
+		Where("id = ?", id).
+		First(&section).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &section, nil
+}
+
+// ListByInstance returns the sections of a course instance with their
+// instructors and slots, ordered by kind and name.
+func (r *sectionRepository) ListByInstance(instanceID uuid.UUID) ([]domain.CourseSection, error) {
+	var sections []domain.CourseSection
+	err := r.withDetails(r.db).
+		Where("course_instance_id = ?", instanceID).
+		Order("kind ASC, name ASC").
+		Find(&sections).Error
+	return sections, err
+}
+
+// CountMembers returns the number of active members of each section of a
+// course instance. Sections without members are absent from the map.
+func (r *sectionRepository) CountMembers(instanceID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		SectionID uuid.UUID
+		Count     int
+	}
+	err := r.db.
+		Table("section_members AS sm").
+		Select("sm.section_id, COUNT(*) AS count").
+		Joins(activeSectionMembers).
+		Where("sm.course_instance_id = ?", instanceID).
+		Group("sm.section_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[row.SectionID] = row.Count
+	}
+	return counts, nil
+}
+
+// withDetails preloads a section's instructors and slots in display order.
+func (r *sectionRepository) withDetails(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Instructors", func(db *gorm.DB) *gorm.DB { return db.Order("role ASC, user_id ASC") }).
+		Preload("Slots", func(db *gorm.DB) *gorm.DB { return db.Order("day_of_week ASC, start_time ASC") })
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Members
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AssignMembers inserts the memberships in one transaction.
+func (r *sectionRepository) AssignMembers(members []domain.SectionMember, replace bool) ([]domain.SectionMember, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	var inserted []domain.SectionMember
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var events []domain.OutboxEvent
+		for i := range members {
+			m := members[i]
+			if replace {
+				var removed []domain.SectionMember
+				if err := tx.Clauses(clause.Returning{}).
+					Where("course_instance_id = ? AND kind = ? AND user_id = ?", m.CourseInstanceID, m.Kind, m.UserID).
+					Delete(&removed).Error; err != nil {
+					return err
+				}
+				events = append(events, sectionMemberEvents(domain.EventSectionMemberRemoved, removed)...)
+			}
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&m)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected > 0 {
+				inserted = append(inserted, m)
+				events = append(events, domain.NewSectionMemberEvent(domain.EventSectionMemberAdded, &m))
+			}
+		}
+		return appendEvents(tx, events...)
+	})
+	return inserted, err
+}
+
+// RemoveMember hard-deletes a membership and reports whether one existed.
+func (r *sectionRepository) RemoveMember(sectionID, userID uuid.UUID) (bool, error) {
+	var removed []domain.SectionMember
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Returning{}).
+			Where("section_id = ? AND user_id = ?", sectionID, userID).
+			Delete(&removed).Error; err != nil {
+			return err
+		}
+		return appendEvents(tx, sectionMemberEvents(domain.EventSectionMemberRemoved, removed)...)
+	})
+	return len(removed) > 0, err
+}
+
+// sectionMemberEvents builds one event of the given type per membership.
+func sectionMemberEvents(eventType string, members []domain.SectionMember) []domain.OutboxEvent {
+	events := make([]domain.OutboxEvent, len(members))
+	for i := range members {
+		events[i] = domain.NewSectionMemberEvent(eventType, &members[i])
+	}
+	return events
+}
+
+// ListMembers returns the active members of a section, ordered by
+// assignment time ascending.
+func (r *sectionRepository) ListMembers(sectionID uuid.UUID) ([]domain.SectionMember, error) {
+	var members []domain.SectionMember
+	err := r.db.
+		Table("section_members AS sm").
+		Select("sm.*").
+		Joins(activeSectionMembers).
+		Where("sm.section_id = ?", sectionID).
+		Order("sm.assigned_at ASC").
+		Scan(&members).Error
+	return members, err
+}
+
+// ListAssignedUsers returns the users with a section of the given kind in
+// the course instance.
+func (r *sectionRepository) ListAssignedUsers(instanceID uuid.UUID, kind string) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.
+		Model(&domain.SectionMember{}).
+		Where("course_instance_id = ? AND kind = ?", instanceID, kind).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// ListUserSections returns the sections the user actively belongs to.
+func (r *sectionRepository) ListUserSections(userID uuid.UUID, instanceID *uuid.UUID) ([]domain.CourseSection, error) {
+	sub := r.db.
+		Table("section_members AS sm").
+		Select("sm.section_id").
+		Joins(activeSectionMembers).
+		Where("sm.user_id = ?", userID)
+	if instanceID != nil {
+		sub = sub.Where("sm.course_instance_id = ?", *instanceID)
+	}
+
+	var sections []domain.CourseSection
+	err := r.withDetails(r.db).
+		Where("id IN (?)", sub).
+		Order("course_instance_id ASC, kind ASC, name ASC").
+		Find(&sections).Error
+	return sections, err
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Instructors
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AddInstructor inserts a section instructor assignment.
+func (r *sectionRepository) AddInstructor(instructor *domain.SectionInstructor) error {
+	return r.db.Create(instructor).Error
+}
+
+// GetInstructor loads a single section instructor assignment.
+// Returns nil, nil when no record is found.
+func (r *sectionRepository) GetInstructor(sectionID, userID uuid.UUID) (*domain.SectionInstructor, error) {
+	var instructor domain.SectionInstructor
+	err := r.db.
+		Where("section_id = ? AND user_id = ?", sectionID, userID).
+		First(&instructor).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &instructor, nil
+}
+
+// RemoveInstructor hard-deletes an assignment and reports whether one
+// existed.
+func (r *sectionRepository) RemoveInstructor(sectionID, userID uuid.UUID) (bool, error) {
+	res := r.db.
+		Where("section_id = ? AND user_id = ?", sectionID, userID).
+		Delete(&domain.SectionInstructor{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Slots
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AddSlot inserts a timetable slot.
+func (r *sectionRepository) AddSlot(slot *domain.SectionSlot) error {
+	return r.db.Create(slot).Error
+}
+
+// RemoveSlot hard-deletes a slot of the section and reports whether one
+// existed.
+func (r *sectionRepository) RemoveSlot(sectionID, slotID uuid.UUID) (bool, error) {
+	res := r.db.
+		Where("id = ? AND section_id = ?", slotID, sectionID).
+		Delete(&domain.SectionSlot{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// FindRoomClashes matches rooms case-insensitively. Slots that merely touch,
+// one ending when the other starts, do not clash.
+func (r *sectionRepository) FindRoomClashes(semesterID uuid.UUID, slot *domain.SectionSlot) ([]domain.SectionSlot, error) {
+	var clashes []domain.SectionSlot
+	err := r.db.
+		Table("section_slots AS ss").
+		Select("ss.*").
+		Joins("JOIN course_sections cs ON cs.id = ss.section_id").
+		Joins("JOIN course_instances ci ON ci.id = cs.course_instance_id").
+		Where("ci.semester_id = ?", semesterID).
+		Where("LOWER(ss.room) = ?", strings.ToLower(slot.Room)).
+		Where("ss.day_of_week = ? AND ss.start_time < ? AND ss.end_time > ?", slot.DayOfWeek, slot.EndTime, slot.StartTime).
+		Scan(&clashes).Error
+	return clashes, err
+}
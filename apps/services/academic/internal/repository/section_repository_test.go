@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// setupSectionTestDB extends the enrollment test schema with the section
+// tables, created by hand for the same reason as course_instances.
+func setupSectionTestDB(t *testing.T) *gorm.DB {
+	db := setupEnrollmentTestDB(t)
+
+	require.NoError(t, db.Exec(`CREATE TABLE course_sections (
+		id TEXT PRIMARY KEY,
+		course_instance_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		capacity INTEGER NOT NULL DEFAULT 0,
+		batch_id TEXT,
+		created_at DATETIME,
+		updated_at DATETIME,
+		UNIQUE (course_instance_id, name)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE section_members (
+		section_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		course_instance_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		assigned_at DATETIME,
+		PRIMARY KEY (section_id, user_id),
+		UNIQUE (course_instance_id, kind, user_id)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE section_instructors (
+		section_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		role TEXT NOT NULL,
+		PRIMARY KEY (section_id, user_id)
+	)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE section_slots (
+		id TEXT PRIMARY KEY,
+		section_id TEXT NOT NULL,
+		day_of_week INTEGER NOT NULL,
+		start_time TEXT NOT NULL,
+		end_time TEXT NOT NULL,
+		room TEXT
+	)`).Error)
+
+	return db
+}
+
+func createTestSection(t *testing.T, repo SectionRepository, instanceID uuid.UUID, kind, name string) *domain.CourseSection {
+	section := &domain.CourseSection{CourseInstanceID: instanceID, Kind: kind, Name: name}
+	require.NoError(t, repo.Create(section))
+	return section
+}
+
+func TestAssignMembers(t *testing.T) {
+	db := setupSectionTestDB(t)
+	repo := NewSectionRepository(db)
+	enrollments := NewEnrollmentRepository(db)
+
+	instanceID := createTestInstance(t, db, 0)
+	labA := createTestSection(t, repo, instanceID, domain.SectionKindLab, "Lab A")
+	labB := createTestSection(t, repo, instanceID, domain.SectionKindLab, "Lab B")
+	lecture := createTestSection(t, repo, instanceID, domain.SectionKindLecture, "L1")
+
+	student := enroll(t, enrollments, instanceID)
+	member := func(section *domain.CourseSection) domain.SectionMember {
+		return domain.SectionMember{
+			SectionID:        section.ID,
+			UserID:           student.UserID,
+			CourseInstanceID: instanceID,
+			Kind:             section.Kind,
+		}
+	}
+
+	inserted, err := repo.AssignMembers([]domain.SectionMember{member(labA), member(lecture)}, false)
+	require.NoError(t, err)
+	assert.Len(t, inserted, 2)
+
+	// Without replace a student keeps their lab.
+	inserted, err = repo.AssignMembers([]domain.SectionMember{member(labB)}, false)
+	require.NoError(t, err)
+	assert.Empty(t, inserted)
+
+	// With replace they move, and their lecture is untouched.
+	inserted, err = repo.AssignMembers([]domain.SectionMember{member(labB)}, true)
+	require.NoError(t, err)
+	assert.Len(t, inserted, 1)
+
+	counts, err := repo.CountMembers(instanceID)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{labB.ID: 1, lecture.ID: 1}, counts)
+
+	assert.Equal(t, []string{
+		domain.EventEnrollmentCreated,
+		domain.EventSectionMemberAdded,
+		domain.EventSectionMemberAdded,
+		domain.EventSectionMemberRemoved,
+		domain.EventSectionMemberAdded,
+	}, outboxEvents(t, db))
+
+	// Dropping the course hides the memberships without deleting them.
+	student.Status = domain.EnrollmentStatusDropped
+	require.NoError(t, enrollments.UpdateEnrollment(student, "registrar"))
+
+	counts, err = repo.CountMembers(instanceID)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+
+	sections, err := repo.ListUserSections(student.UserID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, sections)
+
+	assigned, err := repo.ListAssignedUsers(instanceID, domain.SectionKindLab)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{student.UserID}, assigned)
+}
+
+func TestRemoveSectionMembers(t *testing.T) {
+	db := setupSectionTestDB(t)
+	repo := NewSectionRepository(db)
+
+	instanceID := createTestInstance(t, db, 0)
+	lab := createTestSection(t, repo, instanceID, domain.SectionKindLab, "Lab A")
+	members := []domain.SectionMember{
+		{SectionID: lab.ID, UserID: uuid.New(), CourseInstanceID: instanceID, Kind: lab.Kind},
+		{SectionID: lab.ID, UserID: uuid.New(), CourseInstanceID: instanceID, Kind: lab.Kind},
+	}
+	_, err := repo.AssignMembers(members, false)
+	require.NoError(t, err)
+
+	removed, err := repo.RemoveMember(lab.ID, members[0].UserID)
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = repo.RemoveMember(lab.ID, members[0].UserID)
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	// Deleting the section removes its remaining member.
+	require.NoError(t, repo.Delete(lab.ID))
+
+	var payloads []domain.SectionMemberEventPayload
+	var events []domain.OutboxEvent
+	require.NoError(t, db.Where("event_type = ?", domain.EventSectionMemberRemoved).Order("sequence ASC").Find(&events).Error)
+	for _, e := range events {
+		var p domain.SectionMemberEventPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, instanceID, e.AggregateID)
+		payloads = append(payloads, p)
+	}
+	require.Len(t, payloads, 2)
+	assert.Equal(t, members[0].UserID, payloads[0].UserID)
+	assert.Equal(t, members[1].UserID, payloads[1].UserID)
+	assert.Equal(t, lab.ID, payloads[1].SectionID)
+}
+
+func TestFindRoomClashes(t *testing.T) {
+	db := setupSectionTestDB(t)
+	repo := NewSectionRepository(db)
+
+	instanceID := createTestInstance(t, db, 0)
+	var instance domain.CourseInstance
+	require.NoError(t, db.Select("semester_id").Where("id = ?", instanceID).First(&instance).Error)
+	semesterID := instance.SemesterID
+
+	section := createTestSection(t, repo, instanceID, domain.SectionKindLecture, "L1")
+	require.NoError(t, repo.AddSlot(&domain.SectionSlot{
+		SectionID: section.ID,
+		DayOfWeek: 1,
+		StartTime: "09:00",
+		EndTime:   "11:00",
+		Room:      "A101",
+	}))
+
+	cases := []struct {
+		name    string
+		slot    domain.SectionSlot
+		clashes int
+	}{
+		{"overlapping, room case differs", domain.SectionSlot{DayOfWeek: 1, StartTime: "10:00", EndTime: "12:00", Room: "a101"}, 1},
+		{"inside", domain.SectionSlot{DayOfWeek: 1, StartTime: "09:30", EndTime: "10:30", Room: "A101"}, 1},
+		{"touching", domain.SectionSlot{DayOfWeek: 1, StartTime: "11:00", EndTime: "12:00", Room: "A101"}, 0},
+		{"other day", domain.SectionSlot{DayOfWeek: 2, StartTime: "09:00", EndTime: "11:00", Room: "A101"}, 0},
+		{"other room", domain.SectionSlot{DayOfWeek: 1, StartTime: "09:00", EndTime: "11:00", Room: "B202"}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clashes, err := repo.FindRoomClashes(semesterID, &tc.slot)
+			require.NoError(t, err)
+			assert.Len(t, clashes, tc.clashes)
+		})
+	}
+
+	// Other semesters do not clash.
+	clashes, err := repo.FindRoomClashes(uuid.New(), &domain.SectionSlot{DayOfWeek: 1, StartTime: "09:00", EndTime: "11:00", Room: "A101"})
+	require.NoError(t, err)
+	assert.Empty(t, clashes)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // SemesterRepository defines all data operations for semesters.
@@ -16,6 +17,9 @@ type SemesterRepository interface {
 	GetByCode(code string) (*domain.Semester, error)
 	List(includeInactive bool, termType string) ([]domain.Semester, error)
 	Exists(id uuid.UUID) (bool, error)
+	ListOverlapping(termType string, start, end domain.Date, excludeID uuid.UUID) ([]domain.Semester, error)
+	ListDueForTransition(today domain.Date) ([]domain.Semester, error)
+	Transition(semester *domain.Semester, to string, instancesFrom []string, instancesTo string) (int64, error)
 }
 
 // semesterRepository is the concrete GORM-backed implementation.
@@ -100,3 +104,82 @@ func (r *semesterRepository) Exists(id uuid.UUID) (bool, error) {
 		Count(&count).Error
 	return count > 0, err
 }
+
+// ListOverlapping returns the active, non-cancelled semesters of termType
+// that share at least one day with [start, end], excluding excludeID.
+func (r *semesterRepository) ListOverlapping(termType string, start, end domain.Date, excludeID uuid.UUID) ([]domain.Semester, error) {
+	var semesters []domain.Semester
+	err := r.db.
+		Where("deleted_at IS NULL AND is_active = ? AND status <> ?", true, domain.SemesterStatusCancelled).
+		Where("term_type = ? AND id <> ?", termType, excludeID).
+		Where("start_date <= ? AND end_date >= ?", end, start).
+		Order("start_date ASC").
+		Find(&semesters).Error
+	return semesters, err
+}
+
+// ListDueForTransition returns the active semesters whose status lags the
+// calendar on the given day: Planned semesters that have started and
+// Active semesters that have ended.
+func (r *semesterRepository) ListDueForTransition(today domain.Date) ([]domain.Semester, error) {
+	var semesters []domain.Semester
+	err := r.db.
+		Where("deleted_at IS NULL AND is_active = ?", true).
+		Where("(status = ? AND start_date <= ?) OR (status = ? AND end_date < ?)",
+			domain.SemesterStatusPlanned, today,
+			domain.SemesterStatusActive, today,
+		).
+		Order("start_date ASC").
+		Find(&semesters).Error
+	return semesters, err
+}
+
+// Transition sets the semester's status and moves its course instances
+// that are in one of instancesFrom to instancesTo, in one transaction.
+// It returns the number of course instances updated.
+func (r *semesterRepository) Transition(semester *domain.Semester, to string, instancesFrom []string, instancesTo string) (int64, error) {
+	var updated int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Guard on the old status so a concurrent manual change wins.
+		res := tx.Model(&domain.Semester{}).
+			Where("id = ? AND status = ?", semester.ID, semester.Status).
+			Update("status", to)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		semester.Status = to
+
+		if len(instancesFrom) == 0 {
+			return nil
+		}
+		var moved []domain.CourseInstance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("semester_id = ? AND status IN ?", semester.ID, instancesFrom).
+			Find(&moved).Error; err != nil {
+			return err
+		}
+		if len(moved) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, len(moved))
+		events := make([]domain.OutboxEvent, len(moved))
+		for i := range moved {
+			previous := moved[i].Status
+			moved[i].Status = instancesTo
+			ids[i] = moved[i].ID
+			events[i] = domain.NewCourseInstanceStatusEvent(&moved[i], previous)
+		}
+		res = tx.Model(&domain.CourseInstance{}).
+			Where("id IN ?", ids).
+			Update("status", instancesTo)
+		if res.Error != nil {
+			return res.Error
+		}
+		updated = res.RowsAffected
+		return appendEvents(tx, events...)
+	})
+	return updated, err
+}
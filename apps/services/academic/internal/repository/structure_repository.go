@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"gorm.io/gorm"
+)
+
+// StructureSnapshot is the whole academic structure: every non-deleted
+// faculty (with its active leaders), department, degree, specialization,
+// course and batch, and every batch membership.
+type StructureSnapshot struct {
+	Faculties       []domain.Faculty
+	Departments     []domain.Department
+	Degrees         []domain.Degree
+	Specializations []domain.Specialization
+	Courses         []domain.Course
+	Batches         []domain.Batch
+	Members         []domain.BatchMember
+}
+
+// StructureChanges are new records to insert. IDs are assigned by the
+// caller so records can reference each other; Batches must list parents
+// before their children.
+type StructureChanges struct {
+	Faculties       []domain.Faculty
+	Leaders         []domain.FacultyLeadership
+	Departments     []domain.Department
+	Degrees         []domain.Degree
+	Specializations []domain.Specialization
+	Courses         []domain.Course
+	Batches         []domain.Batch
+	Members         []domain.BatchMember
+}
+
+// StructureRepository reads and bulk-writes the academic structure.
+type StructureRepository interface {
+	// LoadSnapshot reads the current structure.
+	LoadSnapshot() (*StructureSnapshot, error)
+	// Apply inserts every change in one transaction, recording the new
+	// batch memberships in their history as added by actor.
+	Apply(changes *StructureChanges, actor string) error
+}
+
+// structureRepository is the concrete GORM-backed implementation.
+type structureRepository struct {
+	db *gorm.DB
+}
+
+// NewStructureRepository creates a new structureRepository.
+func NewStructureRepository(db *gorm.DB) StructureRepository {
+	return &structureRepository{db: db}
+}
+
+// LoadSnapshot reads each table ordered by code so exports are stable.
+func (r *structureRepository) LoadSnapshot() (*StructureSnapshot, error) {
+	var snap StructureSnapshot
+	active := func(db *gorm.DB) *gorm.DB {
+		return db.Where("deleted_at IS NULL AND is_active = ?", true).Order("created_at ASC")
+	}
+
+	if err := r.db.Preload("Leaders", active).
+		Where("deleted_at IS NULL").Order("code ASC").Find(&snap.Faculties).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("deleted_at IS NULL").Order("code ASC").Find(&snap.Departments).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("deleted_at IS NULL").Order("code ASC").Find(&snap.Degrees).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("deleted_at IS NULL").Order("code ASC").Find(&snap.Specializations).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("deleted_at IS NULL").Order("code ASC").Find(&snap.Courses).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("deleted_at IS NULL").Order("code ASC").Find(&snap.Batches).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Order("batch_id ASC, user_id ASC").Find(&snap.Members).Error; err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// Apply inserts the changes parents first, so foreign keys always resolve,
+// and records the new batch memberships in the outbox.
+func (r *structureRepository) Apply(changes *StructureChanges, actor string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := createAll(tx, &changes.Faculties, len(changes.Faculties)); err != nil {
+			return err
+		}
+		if err := createAll(tx, &changes.Leaders, len(changes.Leaders)); err != nil {
+			return err
+		}
+		if err := createAll(tx, &changes.Departments, len(changes.Departments)); err != nil {
+			return err
+		}
+		if err := createAll(tx, &changes.Degrees, len(changes.Degrees)); err != nil {
+			return err
+		}
+		if err := createAll(tx, &changes.Specializations, len(changes.Specializations)); err != nil {
+			return err
+		}
+		if err := createAll(tx, &changes.Courses, len(changes.Courses)); err != nil {
+			return err
+		}
+		// Batches go one at a time so each parent exists before its children.
+		for i := range changes.Batches {
+			if err := tx.Create(&changes.Batches[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := createAll(tx, &changes.Members, len(changes.Members)); err != nil {
+			return err
+		}
+		if err := recordMembers(tx, changes.Members, actor); err != nil {
+			return err
+		}
+		return appendEvents(tx, batchMemberEvents(domain.EventBatchMemberAdded, changes.Members)...)
+	})
+}
+
+// createAll inserts a slice of records in chunks; GORM rejects empty slices.
+func createAll(tx *gorm.DB, records interface{}, n int) error {
+	if n == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(records, 100).Error
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserDataCounts reports how many rows referencing a user were affected.
+type UserDataCounts struct {
+	BatchMemberships   int64 `json:"batch_memberships"`
+	BatchMemberHistory int64 `json:"batch_member_history"`
+	Enrollments        int64 `json:"enrollments"`
+	EnrollmentHistory  int64 `json:"enrollment_history"`
+	CourseInstructors  int64 `json:"course_instructors"`
+	FacultyLeaderships int64 `json:"faculty_leaderships"`
+	SectionMemberships int64 `json:"section_memberships"`
+	SectionInstructors int64 `json:"section_instructors"`
+	AttendanceRecords  int64 `json:"attendance_records"`
+}
+
+// UserDataExport holds every academic record tied to one user.
+type UserDataExport struct {
+	BatchMemberships   []domain.BatchMember        `json:"batch_memberships"`
+	BatchMemberHistory []domain.BatchMemberHistory `json:"batch_member_history"`
+	Enrollments        []domain.Enrollment         `json:"enrollments"`
+	EnrollmentHistory  []domain.EnrollmentHistory  `json:"enrollment_history"`
+	CourseInstructors  []domain.CourseInstructor   `json:"course_instructors"`
+	FacultyLeaderships []domain.FacultyLeadership  `json:"faculty_leaderships"`
+	SectionMemberships []domain.SectionMember      `json:"section_memberships"`
+	SectionInstructors []domain.SectionInstructor  `json:"section_instructors"`
+	AttendanceRecords  []domain.AttendanceRecord   `json:"attendance_records"`
+}
+
+// UserDataRepository operates on every academic record tied to one user.
+type UserDataRepository interface {
+	ExportUser(userID uuid.UUID) (*UserDataExport, error)
+	// EraseUser deletes the user's academic records. With retainGrades,
+	// enrollments (which carry final grades), batch memberships, their
+	// history and attendance are kept as the academic record; staff,
+	// leadership and section assignments go.
+	EraseUser(userID uuid.UUID, retainGrades bool) (*UserDataCounts, error)
+}
+
+// userDataRepository is the concrete GORM-backed implementation.
+type userDataRepository struct {
+	db *gorm.DB
+}
+
+// NewUserDataRepository creates a new userDataRepository.
+func NewUserDataRepository(db *gorm.DB) UserDataRepository {
+	return &userDataRepository{db: db}
+}
+
+// ExportUser loads the user's batch memberships, enrollments and their
+// history, course staff assignments, faculty leadership roles, section assignments and attendance
+// with the records they refer to.
+func (r *userDataRepository) ExportUser(userID uuid.UUID) (*UserDataExport, error) {
+	export := &UserDataExport{}
+	if err := r.db.Preload("Batch").
+		Where("user_id = ?", userID).
+		Order("enrolled_at").
+		Find(&export.BatchMemberships).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("valid_from").
+		Find(&export.BatchMemberHistory).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("CourseInstance").
+		Where("user_id = ?", userID).
+		Order("enrolled_at").
+		Find(&export.Enrollments).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("valid_from").
+		Find(&export.EnrollmentHistory).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("CourseInstance").
+		Where("user_id = ?", userID).
+		Find(&export.CourseInstructors).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Find(&export.FacultyLeaderships).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Section").
+		Where("user_id = ?", userID).
+		Order("assigned_at").
+		Find(&export.SectionMemberships).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Find(&export.SectionInstructors).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Session").
+		Where("user_id = ?", userID).
+		Order("marked_at").
+		Find(&export.AttendanceRecords).Error; err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// EraseUser hard-deletes the user's records in one transaction: batch
+// memberships, enrollments, their history and attendance unless
+// retainGrades is set, then
+// course staff
+// assignments, faculty leadership roles and section assignments. Section
+// memberships only place a student in a timetable, so they go either way.
+func (r *userDataRepository) EraseUser(userID uuid.UUID, retainGrades bool) (*UserDataCounts, error) {
+	counts := &UserDataCounts{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if !retainGrades {
+			var members []domain.BatchMember
+			res := tx.Clauses(clause.Returning{}).Where("user_id = ?", userID).Delete(&members)
+			if res.Error != nil {
+				return res.Error
+			}
+			counts.BatchMemberships = res.RowsAffected
+			if err := appendEvents(tx, batchMemberEvents(domain.EventBatchMemberRemoved, members)...); err != nil {
+				return err
+			}
+
+			res = tx.Where("user_id = ?", userID).Delete(&domain.BatchMemberHistory{})
+			if res.Error != nil {
+				return res.Error
+			}
+			counts.BatchMemberHistory = res.RowsAffected
+
+			var enrollments []domain.Enrollment
+			res = tx.Clauses(clause.Returning{}).Where("user_id = ?", userID).Delete(&enrollments)
+			if res.Error != nil {
+				return res.Error
+			}
+			counts.Enrollments = res.RowsAffected
+			for i := range enrollments {
+				if err := appendEvents(tx, domain.NewEnrollmentRemovedEvent(&enrollments[i])); err != nil {
+					return err
+				}
+			}
+
+			res = tx.Where("user_id = ?", userID).Delete(&domain.EnrollmentHistory{})
+			if res.Error != nil {
+				return res.Error
+			}
+			counts.EnrollmentHistory = res.RowsAffected
+
+			res = tx.Where("user_id = ?", userID).Delete(&domain.AttendanceRecord{})
+			if res.Error != nil {
+				return res.Error
+			}
+			counts.AttendanceRecords = res.RowsAffected
+		}
+
+		var instructors []domain.CourseInstructor
+		res := tx.Clauses(clause.Returning{}).Where("user_id = ?", userID).Delete(&instructors)
+		if res.Error != nil {
+			return res.Error
+		}
+		counts.CourseInstructors = res.RowsAffected
+		if err := appendEvents(tx, instructorEvents(domain.EventInstructorRemoved, instructors)...); err != nil {
+			return err
+		}
+
+		res = tx.Where("user_id = ?", userID).Delete(&domain.FacultyLeadership{})
+		if res.Error != nil {
+			return res.Error
+		}
+		counts.FacultyLeaderships = res.RowsAffected
+
+		var memberships []domain.SectionMember
+		res = tx.Clauses(clause.Returning{}).Where("user_id = ?", userID).Delete(&memberships)
+		if res.Error != nil {
+			return res.Error
+		}
+		counts.SectionMemberships = res.RowsAffected
+		if err := appendEvents(tx, sectionMemberEvents(domain.EventSectionMemberRemoved, memberships)...); err != nil {
+			return err
+		}
+
+		res = tx.Where("user_id = ?", userID).Delete(&domain.SectionInstructor{})
+		if res.Error != nil {
+			return res.Error
+		}
+		counts.SectionInstructors = res.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
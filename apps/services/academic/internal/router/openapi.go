@@ -0,0 +1,1466 @@
+package router
+
+import (
+	"reflect"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OpenAPI returns the academic service's OpenAPI document, served at
+// /openapi.json and enforced on requests by SetupRoutes. Every route
+// SetupRoutes registers must be listed in routes: TestOpenAPIMatchesRoutes
+// fails the build when the two disagree.
+func OpenAPI() *openapi.Document {
+	return openapi.Spec{
+		Info: openapi.Info{
+			Title:       "GradeLoop Academic Service",
+			Description: "Faculties, degrees, batches, courses, enrollments, grading and attendance.",
+			Version:     "1.0.0",
+		},
+		Types: map[reflect.Type]*openapi.Schema{
+			reflect.TypeOf(domain.Date{}):    {Type: []string{"string", "null"}, Format: "date"},
+			reflect.TypeOf(gorm.DeletedAt{}): {Type: []string{"string", "null"}, Format: "date-time"},
+		},
+		Error:  utils.AppError{},
+		Routes: routes,
+	}.MustBuild()
+}
+
+var (
+	// sheetFormat is the format of structure import sheets.
+	sheetFormat = &openapi.Schema{Type: "string", Enum: []any{"csv", "xlsx"}}
+	// sheet is a structure sheet in either format.
+	sheet = openapi.Binary{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}
+	// transcriptFormat is the format of a transcript download.
+	transcriptFormat = &openapi.Schema{Type: "string", Enum: []any{"json", "pdf"}}
+	// transcript is a transcript as JSON or, with format=pdf, a PDF.
+	transcript = openapi.Content{"application/json": dto.TranscriptResponse{}, "application/pdf": nil}
+)
+
+var routes = []openapi.Route{
+
+	// service
+	{
+		Method: fiber.MethodGet, Path: "/", Name: "getServiceInfo",
+		Summary: "Service information", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"service": "", "version": "", "status": ""},
+		},
+	},
+
+	// approvals
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/approvals/", Name: "listApprovals",
+		Summary: "List approvals", Tag: "approvals",
+		Query: openapi.Params{"faculty_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("approvals", []domain.ApprovalRequest{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/approvals/:id", Name: "getApproval",
+		Summary: "Get approval", Tag: "approvals",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/approvals/:id/approve", Name: "approveRequest",
+		Summary: "Approve request", Tag: "approvals",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.DecideApprovalRequest{},
+		Responses: openapi.Responses{
+			200: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/approvals/:id/reject", Name: "rejectRequest",
+		Summary: "Reject request", Tag: "approvals",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.DecideApprovalRequest{},
+		Responses: openapi.Responses{
+			200: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/approvals/:id/withdraw", Name: "withdrawRequest",
+		Summary: "Withdraw request", Tag: "approvals",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/approvals/mine", Name: "listMyApprovals",
+		Summary: "List my approvals", Tag: "approvals",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("approvals", []domain.ApprovalRequest{}),
+		},
+	},
+
+	// batch-members
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/batch-members/", Name: "addBatchMember",
+		Summary: "Add batch member", Tag: "batch-members",
+		Body: dto.AddBatchMemberRequest{},
+		Responses: openapi.Responses{
+			201: dto.BatchMemberResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/batch-members/:batchID/:userID", Name: "removeBatchMember",
+		Summary: "Remove batch member", Tag: "batch-members",
+		Params: openapi.Params{"batchID": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/batch-members/bulk", Name: "addMembersToBatch",
+		Summary: "Add members to batch", Tag: "batch-members",
+		Body: dto.BulkAddBatchMembersRequest{},
+		Responses: openapi.Responses{
+			201: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/batch-members/move", Name: "moveBatchMember",
+		Summary: "Move batch member", Tag: "batch-members",
+		Body: dto.MoveBatchMemberRequest{},
+		Responses: openapi.Responses{
+			200: dto.MoveBatchMemberResponse{},
+		},
+	},
+
+	// batches
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/", Name: "listBatches",
+		Summary: "List batches", Tag: "batches",
+		Query: dto.ListBatchesSchema.Params(),
+		Responses: openapi.Responses{
+			200: openapi.PageOf("batches", []dto.BatchResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/batches/", Name: "createBatch",
+		Summary: "Create batch", Tag: "batches",
+		Body: dto.CreateBatchRequest{},
+		Responses: openapi.Responses{
+			201: dto.BatchResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/:id", Name: "getBatch",
+		Summary: "Get batch", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.BatchResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/batches/:id", Name: "updateBatch",
+		Summary: "Update batch", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateBatchRequest{},
+		Responses: openapi.Responses{
+			200: dto.BatchResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/:id/course-instances", Name: "listCourseInstancesByBatch",
+		Summary: "List course instances by batch", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("course_instances", []dto.CourseInstanceResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/batches/:id/deactivate", Name: "deactivateBatch",
+		Summary: "Deactivate batch", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/:id/members", Name: "getBatchMembers",
+		Summary: "Get batch members", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("members", []dto.BatchMemberResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/:id/members/detailed", Name: "getBatchMembersDetailed",
+		Summary: "Get batch members detailed", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("members", []dto.BatchMemberDetailResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/:id/members/history", Name: "getMemberHistory",
+		Summary: "Get member history", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"as_of": openapi.String, "user_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("members", []domain.BatchMemberHistory{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/:id/tree", Name: "getBatchSubtree",
+		Summary: "Get batch subtree", Tag: "batches",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.BatchTreeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/batches/tree", Name: "getBatchTree",
+		Summary: "Get batch tree", Tag: "batches",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("tree", []dto.BatchTreeResponse{}),
+		},
+	},
+
+	// course-instances
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/", Name: "createCourseInstance",
+		Summary: "Create course instance", Tag: "course-instances",
+		Body: dto.CreateCourseInstanceRequest{},
+		Responses: openapi.Responses{
+			201: dto.CourseInstanceResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id", Name: "getCourseInstanceByID",
+		Summary: "Get course instance by ID", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseInstanceResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/course-instances/:id", Name: "updateCourseInstance",
+		Summary: "Update course instance", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateCourseInstanceRequest{},
+		Responses: openapi.Responses{
+			200: dto.CourseInstanceResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/attendance/report", Name: "getCourseReport",
+		Summary: "Get course report", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseAttendanceReport{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/attendance/report/:userID", Name: "getStudentReport",
+		Summary: "Get student report", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.StudentAttendanceReport{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/attendance/sessions", Name: "listSessions",
+		Summary: "List sessions", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("sessions", []dto.AttendanceSessionResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/:id/cancellation-requests", Name: "requestCancellation",
+		Summary: "Request cancellation", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.RequestCancellationRequest{},
+		Responses: openapi.Responses{
+			201: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/:id/enrollment-overrides", Name: "requestEnrollmentOverride",
+		Summary: "Request enrollment override", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.RequestEnrollmentOverrideRequest{},
+		Responses: openapi.Responses{
+			201: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/enrollments", Name: "getEnrollments",
+		Summary: "Get enrollments", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  dto.ListEnrollmentsSchema.Params(),
+		Responses: openapi.Responses{
+			200: openapi.PageOf("enrollments", []dto.EnrollmentResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/enrollments/history", Name: "getEnrollmentHistory",
+		Summary: "Get enrollment history", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"as_of": openapi.String, "user_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("enrollments", []domain.EnrollmentHistory{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/:id/final-grades/:userID/change-requests", Name: "requestGradeChange",
+		Summary: "Request grade change", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "userID": openapi.UUID},
+		Body:   dto.RequestGradeChangeRequest{},
+		Responses: openapi.Responses{
+			201: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/instructors", Name: "getCourseInstanceInstructors",
+		Summary: "Get course instance instructors", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("instructors", []dto.CourseInstructorResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/sections", Name: "listSections",
+		Summary: "List sections", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("sections", []dto.SectionResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/:id/sections", Name: "createSection",
+		Summary: "Create section", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.CreateSectionRequest{},
+		Responses: openapi.Responses{
+			201: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/sections/:sectionID", Name: "getSection",
+		Summary: "Get section", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/course-instances/:id/sections/:sectionID", Name: "updateSection",
+		Summary: "Update section", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Body:   dto.UpdateSectionRequest{},
+		Responses: openapi.Responses{
+			200: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/course-instances/:id/sections/:sectionID", Name: "deleteSection",
+		Summary: "Delete section", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/:id/sections/:sectionID/instructors", Name: "assignSectionInstructor",
+		Summary: "Assign section instructor", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Body:   dto.SectionInstructorRequest{},
+		Responses: openapi.Responses{
+			201: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/course-instances/:id/sections/:sectionID/instructors/:userID", Name: "removeSectionInstructor",
+		Summary: "Remove section instructor", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-instances/:id/sections/:sectionID/members", Name: "listMembers",
+		Summary: "List members", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("members", []dto.SectionMemberResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/course-instances/:id/sections/:sectionID/members/:userID", Name: "removeMember",
+		Summary: "Remove member", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/:id/sections/:sectionID/slots", Name: "addSlot",
+		Summary: "Add slot", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Body:   dto.SectionSlotRequest{},
+		Responses: openapi.Responses{
+			201: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/course-instances/:id/sections/:sectionID/slots/:slotID", Name: "removeSlot",
+		Summary: "Remove slot", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID, "slotID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instances/:id/sections/assign", Name: "assignStudents",
+		Summary: "Assign students", Tag: "course-instances",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.AssignSectionStudentsRequest{},
+		Responses: openapi.Responses{
+			200: dto.AssignSectionStudentsResponse{},
+		},
+	},
+
+	// course-instructors
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/course-instructors/", Name: "assignCourseInstructor",
+		Summary: "Assign course instructor", Tag: "course-instructors",
+		Body: dto.AssignInstructorRequest{},
+		Responses: openapi.Responses{
+			201: dto.CourseInstructorResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/course-instructors/:instanceID/:userID", Name: "removeCourseInstructor",
+		Summary: "Remove course instructor", Tag: "course-instructors",
+		Params: openapi.Params{"instanceID": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+
+	// courses
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/courses/", Name: "listCourses",
+		Summary: "List courses", Tag: "courses",
+		Query: dto.ListCoursesSchema.Params(),
+		Responses: openapi.Responses{
+			200: openapi.PageOf("courses", []dto.CourseResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/courses/", Name: "createCourse",
+		Summary: "Create course", Tag: "courses",
+		Body: dto.CreateCourseRequest{},
+		Responses: openapi.Responses{
+			201: dto.CourseResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/courses/:id", Name: "getCourse",
+		Summary: "Get course", Tag: "courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/courses/:id", Name: "updateCourse",
+		Summary: "Update course", Tag: "courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateCourseRequest{},
+		Responses: openapi.Responses{
+			200: dto.CourseResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/courses/:id/course-instances", Name: "listCourseInstancesByCourse",
+		Summary: "List course instances by course", Tag: "courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("course_instances", []dto.CourseInstanceResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/courses/:id/deactivate", Name: "deactivateCourse",
+		Summary: "Deactivate course", Tag: "courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/courses/:id/prerequisites", Name: "listPrerequisites",
+		Summary: "List prerequisites", Tag: "courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("prerequisites", []dto.CoursePrerequisiteResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/courses/:id/prerequisites", Name: "addPrerequisite",
+		Summary: "Add prerequisite", Tag: "courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.AddPrerequisiteRequest{},
+		Responses: openapi.Responses{
+			201: dto.CoursePrerequisiteResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/courses/:id/prerequisites/:prereqID", Name: "removePrerequisite",
+		Summary: "Remove prerequisite", Tag: "courses",
+		Params: openapi.Params{"id": openapi.UUID, "prereqID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+
+	// debug
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/debug/auth", Name: "debugAuth",
+		Summary: "Show the caller's identity", Tag: "debug",
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": "", "username": "", "user_type": "", "authenticated": false},
+		},
+	},
+
+	// degree-audits
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/degree-audits/:userID", Name: "getDegreeAudit",
+		Summary: "Get degree audit", Tag: "degree-audits",
+		Params: openapi.Params{"userID": openapi.UUID},
+		Query:  openapi.Params{"degree_id": openapi.UUID, "specialization_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.DegreeAuditResponse{},
+		},
+	},
+
+	// degrees
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/degrees/", Name: "listDegrees",
+		Summary: "List degrees", Tag: "degrees",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("degrees", []dto.DegreeResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/degrees/", Name: "createDegree",
+		Summary: "Create degree", Tag: "degrees",
+		Body: dto.CreateDegreeRequest{},
+		Responses: openapi.Responses{
+			201: dto.DegreeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/degrees/:id", Name: "getDegree",
+		Summary: "Get degree", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.DegreeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/degrees/:id", Name: "updateDegree",
+		Summary: "Update degree", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateDegreeRequest{},
+		Responses: openapi.Responses{
+			200: dto.DegreeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/degrees/:id/deactivate", Name: "deactivateDegree",
+		Summary: "Deactivate degree", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.DeactivateDegreeRequest{},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/degrees/:id/requirements", Name: "listRequirements",
+		Summary: "List requirements", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.ListDegreeRequirementsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/degrees/:id/requirements", Name: "createRequirement",
+		Summary: "Create requirement", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.DegreeRequirementRequest{},
+		Responses: openapi.Responses{
+			201: dto.DegreeRequirementResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/degrees/:id/requirements/:requirementID", Name: "updateRequirement",
+		Summary: "Update requirement", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID, "requirementID": openapi.UUID},
+		Body:   dto.DegreeRequirementRequest{},
+		Responses: openapi.Responses{
+			200: dto.DegreeRequirementResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/degrees/:id/requirements/:requirementID", Name: "deleteRequirement",
+		Summary: "Delete requirement", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID, "requirementID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/degrees/:id/specializations", Name: "listSpecializationsByDegree",
+		Summary: "List specializations by degree", Tag: "degrees",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("specializations", []dto.SpecializationResponse{}),
+		},
+	},
+
+	// departments
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/departments/", Name: "listDepartments",
+		Summary: "List departments", Tag: "departments",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("departments", []dto.DepartmentResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/departments/", Name: "createDepartment",
+		Summary: "Create department", Tag: "departments",
+		Body: dto.CreateDepartmentRequest{},
+		Responses: openapi.Responses{
+			201: dto.DepartmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/departments/:id", Name: "getDepartment",
+		Summary: "Get department", Tag: "departments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.DepartmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/departments/:id", Name: "updateDepartment",
+		Summary: "Update department", Tag: "departments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateDepartmentRequest{},
+		Responses: openapi.Responses{
+			200: dto.DepartmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/departments/:id/deactivate", Name: "deactivateDepartment",
+		Summary: "Deactivate department", Tag: "departments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/departments/:id/degrees", Name: "listDegreesByDepartment",
+		Summary: "List degrees by department", Tag: "departments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("degrees", []dto.DegreeResponse{}),
+		},
+	},
+
+	// enrollments
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/enrollments/", Name: "enrollStudent",
+		Summary: "Enroll student", Tag: "enrollments",
+		Body: dto.EnrollmentRequest{},
+		Responses: openapi.Responses{
+			201: dto.EnrollmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/enrollments/:instanceID/:userID", Name: "updateEnrollment",
+		Summary: "Update enrollment", Tag: "enrollments",
+		Params: openapi.Params{"instanceID": openapi.UUID, "userID": openapi.UUID},
+		Body:   dto.UpdateEnrollmentRequest{},
+		Responses: openapi.Responses{
+			200: dto.EnrollmentResponse{},
+		},
+	},
+
+	// events
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/events/replay", Name: "replayEvents",
+		Summary: "Replay events", Tag: "events",
+		Body: dto.ReplayEventsRequest{},
+		Responses: openapi.Responses{
+			200: dto.ReplayEventsResponse{},
+		},
+	},
+
+	// faculties
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculties/", Name: "listFaculties",
+		Summary: "List faculties", Tag: "faculties",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("faculties", []dto.FacultyResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/faculties/", Name: "createFaculty",
+		Summary: "Create faculty", Tag: "faculties",
+		Body: dto.CreateFacultyRequest{},
+		Responses: openapi.Responses{
+			201: dto.FacultyResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculties/:id", Name: "getFaculty",
+		Summary: "Get faculty", Tag: "faculties",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.FacultyResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/faculties/:id", Name: "updateFaculty",
+		Summary: "Update faculty", Tag: "faculties",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateFacultyRequest{},
+		Responses: openapi.Responses{
+			200: dto.FacultyResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/faculties/:id/deactivate", Name: "deactivateFaculty",
+		Summary: "Deactivate faculty", Tag: "faculties",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculties/:id/departments", Name: "listDepartmentsByFaculty",
+		Summary: "List departments by faculty", Tag: "faculties",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("departments", []dto.DepartmentResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculties/:id/leaders", Name: "getFacultyLeaders",
+		Summary: "Get faculty leaders", Tag: "faculties",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("leaders", []dto.FacultyLeadershipResponse{}),
+		},
+	},
+
+	// faculty-leadership
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/:facultyID/approvals", Name: "listFacultyApprovals",
+		Summary: "List faculty approvals", Tag: "faculty-leadership",
+		Params: openapi.Params{"facultyID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("approvals", []domain.ApprovalRequest{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/:facultyID/course-instances", Name: "listCourseInstances",
+		Summary: "List course instances", Tag: "faculty-leadership",
+		Params: openapi.Params{"facultyID": openapi.UUID},
+		Query:  openapi.Params{"semester_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("course_instances", []dto.FacultyCourseInstanceSummary{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/:facultyID/course-instances/:id", Name: "getCourseInstanceByIDAsFacultyLeader",
+		Summary: "Get course instance by ID", Tag: "faculty-leadership",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseInstanceResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/:facultyID/course-instances/:id/attendance/report", Name: "getCourseReportAsFacultyLeader",
+		Summary: "Get course report", Tag: "faculty-leadership",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseAttendanceReport{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/:facultyID/course-instances/:id/enrollments", Name: "getEnrollmentsAsFacultyLeader",
+		Summary: "Get enrollments", Tag: "faculty-leadership",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  dto.ListEnrollmentsSchema.Params(),
+		Responses: openapi.Responses{
+			200: openapi.PageOf("enrollments", []dto.EnrollmentResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/:facultyID/course-instances/:id/enrollments/history", Name: "getEnrollmentHistoryAsFacultyLeader",
+		Summary: "Get enrollment history", Tag: "faculty-leadership",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"as_of": openapi.String, "user_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("enrollments", []domain.EnrollmentHistory{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/:facultyID/course-instances/:id/final-grades", Name: "listFinalGradesAsFacultyLeader",
+		Summary: "List final grades", Tag: "faculty-leadership",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.FinalGradesResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/faculty-leadership/me", Name: "getMyLeadership",
+		Summary: "Get my leadership", Tag: "faculty-leadership",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("leadership", []domain.FacultyLeadership{}),
+		},
+	},
+
+	// instructor-courses
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/attendance/report", Name: "getCourseReportAsInstructor",
+		Summary: "Get course report", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseAttendanceReport{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/attendance/report/:userID", Name: "getStudentReportAsInstructor",
+		Summary: "Get student report", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.StudentAttendanceReport{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/attendance/sessions", Name: "listSessionsAsInstructor",
+		Summary: "List sessions", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("sessions", []dto.AttendanceSessionResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/attendance/sessions", Name: "createSession",
+		Summary: "Create session", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.CreateAttendanceSessionRequest{},
+		Responses: openapi.Responses{
+			201: dto.AttendanceSessionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID", Name: "getSession",
+		Summary: "Get session", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.AttendanceSessionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID", Name: "updateSession",
+		Summary: "Update session", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID},
+		Body:   dto.UpdateAttendanceSessionRequest{},
+		Responses: openapi.Responses{
+			200: dto.AttendanceSessionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID", Name: "deleteSession",
+		Summary: "Delete session", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID/check-in", Name: "getCheckInCode",
+		Summary: "Get check in code", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CheckInCodeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID/check-in", Name: "openCheckIn",
+		Summary: "Open check in", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID},
+		Body:   dto.OpenCheckInRequest{},
+		Responses: openapi.Responses{
+			200: dto.CheckInCodeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID/check-in", Name: "closeCheckIn",
+		Summary: "Close check in", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID/records", Name: "bulkMarkAttendance",
+		Summary: "Bulk mark attendance", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID},
+		Body:   dto.BulkMarkAttendanceRequest{},
+		Responses: openapi.Responses{
+			200: dto.BulkMarkAttendanceResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/instructor-courses/:id/attendance/sessions/:sessionID/records/:userID", Name: "markAttendance",
+		Summary: "Mark attendance", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sessionID": openapi.UUID, "userID": openapi.UUID},
+		Body:   dto.MarkAttendanceRequest{},
+		Responses: openapi.Responses{
+			200: dto.AttendanceRecordResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/enroll-batch", Name: "enrollBatch",
+		Summary: "Enroll batch", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.EnrollBatchRequest{},
+		Responses: openapi.Responses{
+			200: dto.EnrollBatchResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/enrolled-batches", Name: "getEnrolledBatches",
+		Summary: "Get enrolled batches", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("batches", []dto.BatchEnrollmentStats{}),
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/enrolled-batches/:batchID", Name: "unenrollBatch",
+		Summary: "Unenroll batch", Tag: "instructor-courses",
+		Params: openapi.Params{"batchID": openapi.UUID, "id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"removed": 0, "total": 0},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/enrollments", Name: "enrollStudentAsInstructor",
+		Summary: "Enroll student", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   openapi.Fields{"user_id": "", "status": "", "override_prerequisites": false, "override_reason": ""},
+		Responses: openapi.Responses{
+			201: dto.EnrollmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/final-grades", Name: "listFinalGrades",
+		Summary: "List final grades", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.FinalGradesResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/instructor-courses/:id/final-grades/:userID", Name: "overrideFinalGrade",
+		Summary: "Override final grade", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "userID": openapi.UUID},
+		Body:   dto.OverrideFinalGradeRequest{},
+		Responses: openapi.Responses{
+			200: dto.FinalGradeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/final-grades/:userID/change-requests", Name: "requestGradeChangeAsInstructor",
+		Summary: "Request grade change", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "userID": openapi.UUID},
+		Body:   dto.RequestGradeChangeRequest{},
+		Responses: openapi.Responses{
+			201: domain.ApprovalRequest{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/final-grades/compute", Name: "computeFinalGrades",
+		Summary: "Compute final grades", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.FinalGradesResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/final-grades/lock", Name: "lockFinalGrades",
+		Summary: "Lock final grades", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.FinalGradesResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/grading-scheme", Name: "getGradingScheme",
+		Summary: "Get grading scheme", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.GradingSchemeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/instructor-courses/:id/grading-scheme", Name: "setGradingScheme",
+		Summary: "Set grading scheme", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.SetGradingSchemeRequest{},
+		Responses: openapi.Responses{
+			200: dto.GradingSchemeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/instructors", Name: "getMyInstructors",
+		Summary: "Get my instructors", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("instructors", []dto.CourseInstructorResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/sections", Name: "listSectionsAsInstructor",
+		Summary: "List sections", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("sections", []dto.SectionResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/sections", Name: "createSectionAsInstructor",
+		Summary: "Create section", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.CreateSectionRequest{},
+		Responses: openapi.Responses{
+			201: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/sections/:sectionID", Name: "getSectionAsInstructor",
+		Summary: "Get section", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/instructor-courses/:id/sections/:sectionID", Name: "updateSectionAsInstructor",
+		Summary: "Update section", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Body:   dto.UpdateSectionRequest{},
+		Responses: openapi.Responses{
+			200: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/sections/:sectionID", Name: "deleteSectionAsInstructor",
+		Summary: "Delete section", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/sections/:sectionID/instructors", Name: "assignSectionInstructorAsInstructor",
+		Summary: "Assign instructor", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Body:   dto.SectionInstructorRequest{},
+		Responses: openapi.Responses{
+			201: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/sections/:sectionID/instructors/:userID", Name: "removeSectionInstructorAsInstructor",
+		Summary: "Remove instructor", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/sections/:sectionID/members", Name: "listMembersAsInstructor",
+		Summary: "List members", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("members", []dto.SectionMemberResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/sections/:sectionID/members/:userID", Name: "removeMemberAsInstructor",
+		Summary: "Remove member", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/sections/:sectionID/slots", Name: "addSlotAsInstructor",
+		Summary: "Add slot", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID},
+		Body:   dto.SectionSlotRequest{},
+		Responses: openapi.Responses{
+			201: dto.SectionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/sections/:sectionID/slots/:slotID", Name: "removeSlotAsInstructor",
+		Summary: "Remove slot", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "sectionID": openapi.UUID, "slotID": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-courses/:id/sections/assign", Name: "assignStudentsAsInstructor",
+		Summary: "Assign students", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.AssignSectionStudentsRequest{},
+		Responses: openapi.Responses{
+			200: dto.AssignSectionStudentsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/:id/students", Name: "getMyStudents",
+		Summary: "Get my students", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"enrollments": []dto.EnrollmentResponse{}, "count": 0, "capacity": dto.CourseCapacityResponse{}},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/instructor-courses/:id/students/:userID", Name: "unenrollStudent",
+		Summary: "Unenroll student", Tag: "instructor-courses",
+		Params: openapi.Params{"id": openapi.UUID, "userID": openapi.UUID},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/batches", Name: "listAvailableBatches",
+		Summary: "List available batches", Tag: "instructor-courses",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("batches", []dto.BatchEnrollmentStats{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-courses/me", Name: "getMyCourses",
+		Summary: "Get my courses", Tag: "instructor-courses",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("courses", []dto.CourseInstructorResponse{}),
+		},
+	},
+
+	// internal
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/internal/batch-members", Name: "addBatchMemberInternal",
+		Summary: "Add batch member", Tag: "internal", Auth: openapi.AuthService,
+		Body: dto.AddBatchMemberRequest{},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"batch_id": uuid.UUID{}, "user_id": uuid.UUID{}, "already_member": false},
+			201: dto.BatchMemberResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/course-access", Name: "courseAccessSnapshot",
+		Summary: "Course access snapshot", Tag: "internal", Auth: openapi.AuthService,
+		Responses: openapi.Responses{
+			200: dto.CourseAccessSnapshotResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/course-instances/:id/sections", Name: "listSectionsInternal",
+		Summary: "List sections", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"user_id": openapi.String},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("sections", []dto.SectionResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/enrollments", Name: "checkEnrollment",
+		Summary: "Check enrollment", Tag: "internal", Auth: openapi.AuthService,
+		Query: openapi.Params{"course_instance_id": openapi.UUID, "user_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("enrollments", []*dto.EnrollmentResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/events", Name: "listEvents",
+		Summary: "List events", Tag: "internal", Auth: openapi.AuthService,
+		Query: openapi.Params{"after": openapi.Integer, "aggregate_id": openapi.UUID, "aggregate_type": openapi.String, "event_type": openapi.String, "limit": openapi.Integer},
+		Responses: openapi.Responses{
+			200: dto.ListEventsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/graduated-members", Name: "listGraduatedMembers",
+		Summary: "List graduated members", Tag: "internal", Auth: openapi.AuthService,
+		Query: openapi.Params{"max_end_year": openapi.Integer},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("members", []repository.GraduatedUser{}),
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/internal/users/:id", Name: "eraseUserData",
+		Summary: "Erase user data", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"retain_grades": openapi.Boolean},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": uuid.UUID{}, "retain_grades": false, "erased": repository.UserDataCounts{}},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/users/:id/data", Name: "exportUserData",
+		Summary: "Export user data", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": uuid.UUID{}, "data": repository.UserDataExport{}},
+		},
+	},
+
+	// semesters
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/semesters/", Name: "listSemesters",
+		Summary: "List semesters", Tag: "semesters",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("semesters", []dto.SemesterResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/semesters/", Name: "createSemester",
+		Summary: "Create semester", Tag: "semesters",
+		Body: dto.CreateSemesterRequest{},
+		Responses: openapi.Responses{
+			201: dto.SemesterResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/semesters/:id", Name: "getSemester",
+		Summary: "Get semester", Tag: "semesters",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SemesterResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/semesters/:id", Name: "updateSemester",
+		Summary: "Update semester", Tag: "semesters",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateSemesterRequest{},
+		Responses: openapi.Responses{
+			200: dto.SemesterResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/semesters/:id/deactivate", Name: "deactivateSemester",
+		Summary: "Deactivate semester", Tag: "semesters",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/semesters/advance", Name: "advanceCalendar",
+		Summary: "Advance calendar", Tag: "semesters",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("transitions", []dto.SemesterTransitionResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/semesters/rollover", Name: "rolloverSemester",
+		Summary: "Rollover semester", Tag: "semesters",
+		Body: dto.RolloverRequest{},
+		Responses: openapi.Responses{
+			200: dto.RolloverResponse{},
+		},
+	},
+
+	// specializations
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/specializations/", Name: "createSpecialization",
+		Summary: "Create specialization", Tag: "specializations",
+		Body: dto.CreateSpecializationRequest{},
+		Responses: openapi.Responses{
+			201: dto.SpecializationResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/specializations/:id", Name: "getSpecialization",
+		Summary: "Get specialization", Tag: "specializations",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SpecializationResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/specializations/:id", Name: "updateSpecialization",
+		Summary: "Update specialization", Tag: "specializations",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateSpecializationRequest{},
+		Responses: openapi.Responses{
+			200: dto.SpecializationResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/specializations/:id/deactivate", Name: "deactivateSpecialization",
+		Summary: "Deactivate specialization", Tag: "specializations",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.DeactivateSpecializationRequest{},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+
+	// structure
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/structure/export", Name: "exportStructure",
+		Summary: "Export structure", Tag: "structure",
+		Query: openapi.Params{"format": sheetFormat},
+		Responses: openapi.Responses{
+			200: sheet,
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/structure/import/execute", Name: "executeImport",
+		Summary: "Execute import", Tag: "structure",
+		Body: openapi.Upload{"file"},
+		Responses: openapi.Responses{
+			200: dto.StructureImportResponse{},
+			422: dto.StructureImportResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/structure/import/preview", Name: "previewImport",
+		Summary: "Preview import", Tag: "structure",
+		Body: openapi.Upload{"file"},
+		Responses: openapi.Responses{
+			200: dto.StructureImportResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/structure/import/template", Name: "downloadImportTemplate",
+		Summary: "Download import template", Tag: "structure",
+		Query: openapi.Params{"format": sheetFormat},
+		Responses: openapi.Responses{
+			200: sheet,
+		},
+	},
+
+	// student-courses
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-courses/:id", Name: "getCourseInstance",
+		Summary: "Get course instance", Tag: "student-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.StudentCourseEnrollmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-courses/:id/attendance", Name: "getMyAttendance",
+		Summary: "Get my attendance", Tag: "student-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.StudentAttendanceReport{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/student-courses/:id/attendance/check-in", Name: "checkIn",
+		Summary: "Check in to a session", Tag: "student-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.CheckInRequest{},
+		Responses: openapi.Responses{
+			200: dto.AttendanceRecordResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-courses/:id/instructors", Name: "getCourseInstructors",
+		Summary: "Get course instructors", Tag: "student-courses",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.ListOf("instructors", []dto.CourseInstructorResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-courses/me", Name: "getMyCoursesAsStudent",
+		Summary: "Get my courses", Tag: "student-courses",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("enrollments", []dto.StudentCourseEnrollmentResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-courses/me/degree-audit", Name: "getMyDegreeAudit",
+		Summary: "Get my degree audit", Tag: "student-courses",
+		Query: openapi.Params{"degree_id": openapi.UUID, "specialization_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.DegreeAuditResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-courses/me/timetable", Name: "getMyTimetable",
+		Summary: "Get my timetable", Tag: "student-courses",
+		Responses: openapi.Responses{
+			200: openapi.ListOf("sections", []dto.SectionResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-courses/me/transcript", Name: "getMyTranscript",
+		Summary: "Get my transcript", Tag: "student-courses",
+		Query: openapi.Params{"format": transcriptFormat},
+		Responses: openapi.Responses{
+			200: transcript,
+		},
+	},
+
+	// transcripts
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/transcripts/:userID", Name: "getTranscript",
+		Summary: "Get transcript", Tag: "transcripts",
+		Params: openapi.Params{"userID": openapi.UUID},
+		Query:  openapi.Params{"format": transcriptFormat},
+		Responses: openapi.Responses{
+			200: transcript,
+		},
+	},
+
+	// health
+	{
+		Method: fiber.MethodGet, Path: "/health", Name: "healthCheck",
+		Summary: "Health check", Tag: "health", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"status": ""},
+		},
+	},
+
+	{
+		Method: fiber.MethodGet, Path: "/openapi.json", Name: "getOpenAPI",
+		Summary: "OpenAPI document", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Any,
+		},
+	},
+}
@@ -4,6 +4,10 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/handler"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/middleware"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/gofiber/fiber/v3"
 )
 
@@ -22,7 +26,21 @@ type Config struct {
 	SemesterHandler         *handler.SemesterHandler
 	InstructorHandler       *handler.InstructorHandler
 	StudentHandler          *handler.StudentHandler
+	GradeHandler            *handler.GradeHandler
+	DegreeAuditHandler      *handler.DegreeAuditHandler
+	StructureHandler        *handler.StructureHandler
+	EventHandler            *handler.EventHandler
+	UserDataHandler         *handler.UserDataHandler
+	SectionHandler          *handler.SectionHandler
+	AttendanceHandler       *handler.AttendanceHandler
+	LeadershipHandler       *handler.LeadershipHandler
+	ApprovalHandler         *handler.ApprovalHandler
+	HistoryHandler          *handler.HistoryHandler
 	JWTSecretKey            []byte
+	// PermissionChecker resolves course-scoped role assignments with IAM.
+	PermissionChecker authz.Checker
+	// TokenVerifier resolves personal access tokens with IAM.
+	TokenVerifier pat.Verifier
 }
 
 // requireAdminRole is a custom middleware that checks for admin user types
@@ -43,13 +61,57 @@ func requireAdminRole() fiber.Handler {
 }
 
 func SetupRoutes(app *fiber.App, cfg Config) {
+	// Requests are checked against the OpenAPI document once they are
+	// authenticated, so that callers without credentials learn nothing of
+	// the request schemas; see openapi.go.
+	doc := OpenAPI()
+	validate := openapi.Validate(doc)
+	app.Get("/openapi.json", openapi.Serve(doc))
+
 	cfg.HealthHandler.RegisterRoutes(app)
 
 	// API v1 group
 	api := app.Group("/api/v1")
 
+	// Internal routes for other services, authenticated by service token alone.
+	// NOTE: Must be registered BEFORE the protected group below, whose user
+	// AuthMiddleware is mounted on the whole /api/v1 prefix.
+	internal := api.Group("/internal")
+	internal.Get("/enrollments",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeEnrollmentRead),
+		validate,
+		cfg.EnrollmentHandler.CheckEnrollment)
+	internal.Post("/batch-members",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeBatchMemberWrite),
+		validate,
+		cfg.BatchMemberHandler.AddBatchMemberInternal)
+	internal.Get("/graduated-members",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeBatchMemberRead),
+		validate,
+		cfg.BatchMemberHandler.ListGraduatedMembers)
+	internal.Get("/users/:id/data",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAcademicUserRead),
+		validate,
+		cfg.UserDataHandler.ExportUserData)
+	internal.Delete("/users/:id",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAcademicUserErase),
+		validate,
+		cfg.UserDataHandler.EraseUserData)
+	internal.Get("/events",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAcademicEventsRead),
+		validate,
+		cfg.EventHandler.ListEvents)
+	internal.Get("/course-access",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAcademicEventsRead),
+		validate,
+		cfg.EventHandler.CourseAccessSnapshot)
+	internal.Get("/course-instances/:id/sections",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeEnrollmentRead),
+		validate,
+		cfg.SectionHandler.ListSectionsInternal)
+
 	// Protected routes (require authentication)
-	protected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecretKey))
+	protected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecretKey, cfg.TokenVerifier), validate)
 
 	// Faculty routes - Admin only
 	faculties := protected.Group("/faculties", middleware.RequireUserType("admin"))
@@ -83,6 +145,11 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	degrees.Patch("/:id/deactivate", cfg.DegreeHandler.DeactivateDegree)
 	// List specializations for a degree
 	degrees.Get("/:id/specializations", cfg.SpecializationHandler.ListSpecializationsByDegree)
+	// Degree requirement rules
+	degrees.Get("/:id/requirements", cfg.DegreeAuditHandler.ListRequirements)
+	degrees.Post("/:id/requirements", cfg.DegreeAuditHandler.CreateRequirement)
+	degrees.Put("/:id/requirements/:requirementID", cfg.DegreeAuditHandler.UpdateRequirement)
+	degrees.Delete("/:id/requirements/:requirementID", cfg.DegreeAuditHandler.DeleteRequirement)
 
 	// Specialization routes - Admin only
 	specializations := protected.Group("/specializations", requireAdminRole())
@@ -113,11 +180,13 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	batchMembers := protected.Group("/batch-members", requireAdminRole())
 	batchMembers.Post("/", cfg.BatchMemberHandler.AddBatchMember)
 	batchMembers.Post("/bulk", cfg.BatchMemberHandler.AddMembersToBatch)
+	batchMembers.Post("/move", cfg.BatchMemberHandler.MoveBatchMember)
 	batchMembers.Delete("/:batchID/:userID", cfg.BatchMemberHandler.RemoveBatchMember)
 
 	// Nested under /batches/:id  (shares the already-protected batches group)
 	batches.Get("/:id/members", cfg.BatchMemberHandler.GetBatchMembers)
 	batches.Get("/:id/members/detailed", cfg.BatchMemberHandler.GetBatchMembersDetailed)
+	batches.Get("/:id/members/history", cfg.HistoryHandler.GetMemberHistory)
 	batches.Get("/:id/course-instances", cfg.CourseInstanceHandler.ListCourseInstancesByBatch)
 
 	// ─────────────────────────────────────────────────────────────────────────
@@ -130,6 +199,30 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	// Nested reads under course-instances (instructors & enrollments)
 	courseInstances.Get("/:id/instructors", cfg.CourseInstructorHandler.GetInstructors)
 	courseInstances.Get("/:id/enrollments", cfg.EnrollmentHandler.GetEnrollments)
+	courseInstances.Get("/:id/enrollments/history", cfg.HistoryHandler.GetEnrollmentHistory)
+	// Sections, lab groups and their timetable
+	courseInstances.Post("/:id/sections", cfg.SectionHandler.CreateSection)
+	courseInstances.Get("/:id/sections", cfg.SectionHandler.ListSections)
+	courseInstances.Post("/:id/sections/assign", cfg.SectionHandler.AssignStudents)
+	courseInstances.Get("/:id/sections/:sectionID", cfg.SectionHandler.GetSection)
+	courseInstances.Put("/:id/sections/:sectionID", cfg.SectionHandler.UpdateSection)
+	courseInstances.Delete("/:id/sections/:sectionID", cfg.SectionHandler.DeleteSection)
+	courseInstances.Post("/:id/sections/:sectionID/instructors", cfg.SectionHandler.AssignInstructor)
+	courseInstances.Delete("/:id/sections/:sectionID/instructors/:userID", cfg.SectionHandler.RemoveInstructor)
+	courseInstances.Post("/:id/sections/:sectionID/slots", cfg.SectionHandler.AddSlot)
+	courseInstances.Delete("/:id/sections/:sectionID/slots/:slotID", cfg.SectionHandler.RemoveSlot)
+	courseInstances.Get("/:id/sections/:sectionID/members", cfg.SectionHandler.ListMembers)
+	courseInstances.Delete("/:id/sections/:sectionID/members/:userID", cfg.SectionHandler.RemoveMember)
+
+	// Attendance reports
+	courseInstances.Get("/:id/attendance/sessions", cfg.AttendanceHandler.ListSessions)
+	courseInstances.Get("/:id/attendance/report", cfg.AttendanceHandler.GetCourseReport)
+	courseInstances.Get("/:id/attendance/report/:userID", cfg.AttendanceHandler.GetStudentReport)
+
+	// Operations that need the approval of the faculty's leadership
+	courseInstances.Post("/:id/cancellation-requests", cfg.ApprovalHandler.RequestCancellation)
+	courseInstances.Post("/:id/enrollment-overrides", cfg.ApprovalHandler.RequestEnrollmentOverride)
+	courseInstances.Post("/:id/final-grades/:userID/change-requests", cfg.ApprovalHandler.RequestGradeChange)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Course instructor routes
@@ -141,9 +234,17 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	// ─────────────────────────────────────────────────────────────────────────
 	// Enrollment routes
 	// ─────────────────────────────────────────────────────────────────────────
-	enrollments := protected.Group("/enrollments", requireAdminRole())
-	enrollments.Post("/", cfg.EnrollmentHandler.EnrollStudent)
-	enrollments.Put("/:instanceID/:userID", cfg.EnrollmentHandler.UpdateEnrollment)
+	// Enrollment writes require enrollment:write, granted globally (admin) or
+	// through a role assigned on the course instance.
+	enrollments := protected.Group("/enrollments")
+	enrollments.Post("/", authz.RequirePermission(authz.PermEnrollmentWrite,
+		authz.ScopesFrom(authz.CourseInstanceFromBody),
+		authz.CheckWith(cfg.PermissionChecker),
+	), cfg.EnrollmentHandler.EnrollStudent)
+	enrollments.Put("/:instanceID/:userID", authz.RequirePermission(authz.PermEnrollmentWrite,
+		authz.InScope(authz.ScopeCourseInstance, "instanceID"),
+		authz.CheckWith(cfg.PermissionChecker),
+	), cfg.EnrollmentHandler.UpdateEnrollment)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Course routes
@@ -166,21 +267,56 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	semesters := protected.Group("/semesters", requireAdminRole())
 	semesters.Post("/", cfg.SemesterHandler.CreateSemester)
 	semesters.Get("/", cfg.SemesterHandler.ListSemesters)
+	semesters.Post("/advance", cfg.SemesterHandler.AdvanceCalendar)
+	semesters.Post("/rollover", cfg.SemesterHandler.RolloverSemester)
 	semesters.Get("/:id", cfg.SemesterHandler.GetSemester)
 	semesters.Put("/:id", cfg.SemesterHandler.UpdateSemester)
 	semesters.Patch("/:id/deactivate", cfg.SemesterHandler.DeactivateSemester)
 
 	// ─────────────────────────────────────────────────────────────────────────
-	// Instructor-scoped routes (instructor + admin)
+	// Transcript routes - Admin only (?format=pdf for a PDF download)
+	// ─────────────────────────────────────────────────────────────────────────
+	transcripts := protected.Group("/transcripts", requireAdminRole())
+	transcripts.Get("/:userID", cfg.GradeHandler.GetTranscript)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Degree audit routes - advisors need degree_audit:read, granted globally
+	// or through a role assigned on the student's department or faculty.
+	// ─────────────────────────────────────────────────────────────────────────
+	degreeAudits := protected.Group("/degree-audits")
+	degreeAudits.Get("/:userID", authz.RequirePermission(authz.PermDegreeAuditRead,
+		authz.ScopesFrom(cfg.DegreeAuditHandler.StudentScopes),
+		authz.CheckWith(cfg.PermissionChecker),
+	), cfg.DegreeAuditHandler.GetDegreeAudit)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Structure import/export routes - Admin only (?format=csv|xlsx)
+	// ─────────────────────────────────────────────────────────────────────────
+	structure := protected.Group("/structure", requireAdminRole())
+	structure.Get("/import/template", cfg.StructureHandler.DownloadTemplate)
+	structure.Post("/import/preview", cfg.StructureHandler.PreviewImport)
+	structure.Post("/import/execute", cfg.StructureHandler.ExecuteImport)
+	structure.Get("/export", cfg.StructureHandler.Export)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Domain event routes - Admin only
+	// ─────────────────────────────────────────────────────────────────────────
+	events := protected.Group("/events", requireAdminRole())
+	events.Post("/replay", cfg.EventHandler.ReplayEvents)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Instructor-scoped routes (course staff: instructors and TAs)
 	// PathPrefix: /api/v1/instructor-courses — routed by Traefik to academic-service
+	// TAs may hold any user type, so instance-scoped routes are authorized by
+	// the handler from the caller's CourseInstructor assignment; TAs are
+	// limited to the reads.
 	// NOTE: static sub-paths (/me, /batches) must be registered BEFORE /:id
 	// so that Fiber doesn't interpret them as UUID parameters.
 	// ─────────────────────────────────────────────────────────────────────────────
-	instructorCourses := protected.Group("/instructor-courses",
-		middleware.RequireAnyUserType("instructor", "admin"))
+	instructorCourses := protected.Group("/instructor-courses")
 	instructorCourses.Get("/me", cfg.InstructorHandler.GetMyCourses)
 	// Static paths before /:id
-	instructorCourses.Get("/batches", cfg.InstructorHandler.ListAvailableBatches)
+	instructorCourses.Get("/batches", middleware.RequireAnyUserType("instructor", "admin"), cfg.InstructorHandler.ListAvailableBatches)
 	// Instance-scoped reads
 	instructorCourses.Get("/:id/students", cfg.InstructorHandler.GetMyStudents)
 	instructorCourses.Get("/:id/instructors", cfg.InstructorHandler.GetMyInstructors)
@@ -190,6 +326,78 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	instructorCourses.Delete("/:id/students/:userID", cfg.InstructorHandler.UnenrollStudent)
 	instructorCourses.Post("/:id/enroll-batch", cfg.InstructorHandler.EnrollBatch)
 	instructorCourses.Delete("/:id/enrolled-batches/:batchID", cfg.InstructorHandler.UnenrollBatch)
+	// Grading scheme and final grades: computed, reviewed, then locked
+	instructorCourses.Get("/:id/grading-scheme", cfg.GradeHandler.GetGradingScheme)
+	instructorCourses.Put("/:id/grading-scheme", cfg.GradeHandler.SetGradingScheme)
+	instructorCourses.Get("/:id/final-grades", cfg.GradeHandler.ListFinalGrades)
+	instructorCourses.Post("/:id/final-grades/compute", cfg.GradeHandler.ComputeFinalGrades)
+	instructorCourses.Post("/:id/final-grades/lock", cfg.GradeHandler.LockFinalGrades)
+	instructorCourses.Put("/:id/final-grades/:userID", cfg.GradeHandler.OverrideFinalGrade)
+	// Once locked, a grade changes only through an approved change request
+	instructorCourses.Post("/:id/final-grades/:userID/change-requests",
+		cfg.ApprovalHandler.RequireGradingInstructor(), cfg.ApprovalHandler.RequestGradeChange)
+	// Sections: TAs may read, instructors may also reorganise
+	sectionRead := cfg.SectionHandler.RequireInstructor(false)
+	sectionWrite := cfg.SectionHandler.RequireInstructor(true)
+	instructorCourses.Get("/:id/sections", sectionRead, cfg.SectionHandler.ListSections)
+	instructorCourses.Post("/:id/sections", sectionWrite, cfg.SectionHandler.CreateSection)
+	instructorCourses.Post("/:id/sections/assign", sectionWrite, cfg.SectionHandler.AssignStudents)
+	instructorCourses.Get("/:id/sections/:sectionID", sectionRead, cfg.SectionHandler.GetSection)
+	instructorCourses.Put("/:id/sections/:sectionID", sectionWrite, cfg.SectionHandler.UpdateSection)
+	instructorCourses.Delete("/:id/sections/:sectionID", sectionWrite, cfg.SectionHandler.DeleteSection)
+	instructorCourses.Post("/:id/sections/:sectionID/instructors", sectionWrite, cfg.SectionHandler.AssignInstructor)
+	instructorCourses.Delete("/:id/sections/:sectionID/instructors/:userID", sectionWrite, cfg.SectionHandler.RemoveInstructor)
+	instructorCourses.Post("/:id/sections/:sectionID/slots", sectionWrite, cfg.SectionHandler.AddSlot)
+	instructorCourses.Delete("/:id/sections/:sectionID/slots/:slotID", sectionWrite, cfg.SectionHandler.RemoveSlot)
+	instructorCourses.Get("/:id/sections/:sectionID/members", sectionRead, cfg.SectionHandler.ListMembers)
+	instructorCourses.Delete("/:id/sections/:sectionID/members/:userID", sectionWrite, cfg.SectionHandler.RemoveMember)
+
+	// Attendance: all course staff, TAs included
+	attendanceStaff := cfg.AttendanceHandler.RequireCourseStaff()
+	instructorCourses.Get("/:id/attendance/sessions", attendanceStaff, cfg.AttendanceHandler.ListSessions)
+	instructorCourses.Post("/:id/attendance/sessions", attendanceStaff, cfg.AttendanceHandler.CreateSession)
+	instructorCourses.Get("/:id/attendance/sessions/:sessionID", attendanceStaff, cfg.AttendanceHandler.GetSession)
+	instructorCourses.Put("/:id/attendance/sessions/:sessionID", attendanceStaff, cfg.AttendanceHandler.UpdateSession)
+	instructorCourses.Delete("/:id/attendance/sessions/:sessionID", attendanceStaff, cfg.AttendanceHandler.DeleteSession)
+	instructorCourses.Post("/:id/attendance/sessions/:sessionID/records", attendanceStaff, cfg.AttendanceHandler.BulkMarkAttendance)
+	instructorCourses.Put("/:id/attendance/sessions/:sessionID/records/:userID", attendanceStaff, cfg.AttendanceHandler.MarkAttendance)
+	instructorCourses.Post("/:id/attendance/sessions/:sessionID/check-in", attendanceStaff, cfg.AttendanceHandler.OpenCheckIn)
+	instructorCourses.Get("/:id/attendance/sessions/:sessionID/check-in", attendanceStaff, cfg.AttendanceHandler.GetCheckInCode)
+	instructorCourses.Delete("/:id/attendance/sessions/:sessionID/check-in", attendanceStaff, cfg.AttendanceHandler.CloseCheckIn)
+	instructorCourses.Get("/:id/attendance/report", attendanceStaff, cfg.AttendanceHandler.GetCourseReport)
+	instructorCourses.Get("/:id/attendance/report/:userID", attendanceStaff, cfg.AttendanceHandler.GetStudentReport)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Faculty leadership routes (active faculty leaders + admin)
+	// Read-only dashboards across the faculty's course instances, and the
+	// approval requests the faculty's leadership decides.
+	// NOTE: /me must be registered BEFORE /:facultyID.
+	// ─────────────────────────────────────────────────────────────────────────
+	leadership := protected.Group("/faculty-leadership")
+	leadership.Get("/me", cfg.LeadershipHandler.GetMyLeadership)
+	faculty := leadership.Group("/:facultyID", cfg.LeadershipHandler.RequireFacultyLeader())
+	faculty.Get("/course-instances", cfg.LeadershipHandler.ListCourseInstances)
+	faculty.Get("/approvals", cfg.ApprovalHandler.ListFacultyApprovals)
+	facultyInstance := cfg.LeadershipHandler.RequireFacultyCourseInstance()
+	faculty.Get("/course-instances/:id", facultyInstance, cfg.CourseInstanceHandler.GetCourseInstanceByID)
+	faculty.Get("/course-instances/:id/enrollments", facultyInstance, cfg.EnrollmentHandler.GetEnrollments)
+	faculty.Get("/course-instances/:id/enrollments/history", facultyInstance, cfg.HistoryHandler.GetEnrollmentHistory)
+	faculty.Get("/course-instances/:id/final-grades", facultyInstance, cfg.LeadershipHandler.ListFinalGrades)
+	faculty.Get("/course-instances/:id/attendance/report", facultyInstance, cfg.AttendanceHandler.GetCourseReport)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// Approval routes
+	// Deciding is limited by the service to the leaders of the request's
+	// faculty and admins; only the requester may withdraw.
+	// NOTE: /mine must be registered BEFORE /:id.
+	// ─────────────────────────────────────────────────────────────────────────
+	approvals := protected.Group("/approvals")
+	approvals.Get("/", requireAdminRole(), cfg.ApprovalHandler.ListApprovals)
+	approvals.Get("/mine", cfg.ApprovalHandler.ListMyApprovals)
+	approvals.Get("/:id", cfg.ApprovalHandler.GetApproval)
+	approvals.Post("/:id/approve", cfg.ApprovalHandler.Approve)
+	approvals.Post("/:id/reject", cfg.ApprovalHandler.Reject)
+	approvals.Post("/:id/withdraw", cfg.ApprovalHandler.Withdraw)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Student-scoped routes (student + admin)
@@ -198,8 +406,13 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	studentCourses := protected.Group("/student-courses",
 		middleware.RequireAnyUserType("student", "admin"))
 	studentCourses.Get("/me", cfg.StudentHandler.GetMyCourses)
+	studentCourses.Get("/me/transcript", cfg.GradeHandler.GetMyTranscript)
+	studentCourses.Get("/me/degree-audit", cfg.DegreeAuditHandler.GetMyDegreeAudit)
+	studentCourses.Get("/me/timetable", cfg.SectionHandler.GetMyTimetable)
 	studentCourses.Get("/:id", cfg.StudentHandler.GetCourseInstance)
 	studentCourses.Get("/:id/instructors", cfg.StudentHandler.GetCourseInstructors)
+	studentCourses.Get("/:id/attendance", cfg.AttendanceHandler.GetMyAttendance)
+	studentCourses.Post("/:id/attendance/check-in", cfg.AttendanceHandler.CheckIn)
 
 	app.Get("/", func(c fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -218,16 +431,4 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 			"authenticated": true,
 		})
 	})
-
-	// Swagger UI - serve static files manually
-	app.Get("/docs/*", func(c fiber.Ctx) error {
-		path := c.Params("*")
-		if path == "" || path == "index.html" {
-			return c.SendFile("docs/index.html")
-		}
-		return c.SendFile("docs/" + path)
-	})
-	app.Get("/openapi.json", func(c fiber.Ctx) error {
-		return c.SendFile("docs/swagger.json")
-	})
 }
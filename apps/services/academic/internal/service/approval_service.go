@@ -0,0 +1,552 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ApprovalService runs the approval workflow for operations that need the
+// sign-off of a faculty's leadership: changing a final grade after the grades
+// were locked, cancelling a course instance, and adding or dropping a student
+// after the add/drop deadline. A request is raised, then approved or rejected
+// by an active leader of the course instance's faculty or an admin, never by
+// its requester; approving carries out the change. Every step emits an
+// approval event that drives notifications.
+type ApprovalService interface {
+	RequestGradeChange(instanceID, userID uuid.UUID, req *dto.RequestGradeChangeRequest, requesterID uuid.UUID, username, ipAddress, userAgent string) (*domain.ApprovalRequest, error)
+	RequestCancellation(instanceID uuid.UUID, req *dto.RequestCancellationRequest, requesterID uuid.UUID, username, ipAddress, userAgent string) (*domain.ApprovalRequest, error)
+	RequestEnrollmentOverride(instanceID uuid.UUID, req *dto.RequestEnrollmentOverrideRequest, requesterID uuid.UUID, username, ipAddress, userAgent string) (*domain.ApprovalRequest, error)
+
+	// ListApprovals lists requests, limited to one faculty and/or one
+	// requester when those are set.
+	ListApprovals(facultyID, requestedByID *uuid.UUID, query *dto.ListApprovalsQuery) ([]domain.ApprovalRequest, error)
+	// GetApproval returns a request to its requester, the leaders of its
+	// faculty and admins.
+	GetApproval(id, callerID uuid.UUID, isAdmin bool) (*domain.ApprovalRequest, error)
+
+	Approve(id uuid.UUID, req *dto.DecideApprovalRequest, approverID uuid.UUID, isAdmin bool, username, ipAddress, userAgent string) (*domain.ApprovalRequest, error)
+	Reject(id uuid.UUID, req *dto.DecideApprovalRequest, approverID uuid.UUID, isAdmin bool, username, ipAddress, userAgent string) (*domain.ApprovalRequest, error)
+	Withdraw(id, requesterID uuid.UUID, username, ipAddress, userAgent string) (*domain.ApprovalRequest, error)
+}
+
+// approvalService is the concrete implementation.
+type approvalService struct {
+	approvalRepo          repository.ApprovalRepository
+	courseInstanceRepo    repository.CourseInstanceRepository
+	enrollmentRepo        repository.EnrollmentRepository
+	semesterRepo          repository.SemesterRepository
+	leadershipService     LeadershipService
+	courseInstanceService CourseInstanceService
+	enrollmentService     EnrollmentService
+	finalGradeService     FinalGradeService
+	auditClient           *client.AuditClient
+	logger                *zap.Logger
+}
+
+// NewApprovalService wires all dependencies together.
+func NewApprovalService(
+	approvalRepo repository.ApprovalRepository,
+	courseInstanceRepo repository.CourseInstanceRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	semesterRepo repository.SemesterRepository,
+	leadershipService LeadershipService,
+	courseInstanceService CourseInstanceService,
+	enrollmentService EnrollmentService,
+	finalGradeService FinalGradeService,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) ApprovalService {
+	return &approvalService{
+		approvalRepo:          approvalRepo,
+		courseInstanceRepo:    courseInstanceRepo,
+		enrollmentRepo:        enrollmentRepo,
+		semesterRepo:          semesterRepo,
+		leadershipService:     leadershipService,
+		courseInstanceService: courseInstanceService,
+		enrollmentService:     enrollmentService,
+		finalGradeService:     finalGradeService,
+		auditClient:           auditClient,
+		logger:                logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Requests
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RequestGradeChange asks to change a final grade after the grades were
+// locked. Before the lock instructors override grades directly.
+func (s *approvalService) RequestGradeChange(
+	instanceID, userID uuid.UUID,
+	req *dto.RequestGradeChangeRequest,
+	requesterID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*domain.ApprovalRequest, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, utils.ErrBadRequest("reason is required")
+	}
+
+	change, err := s.finalGradeService.PrepareGradeChange(instanceID, userID, req.FinalGrade)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.raise(instanceID, domain.ApprovalKindGradeChange, change, change.UserID, reason, requesterID, username, ipAddress, userAgent)
+}
+
+// RequestCancellation asks to cancel a course instance.
+func (s *approvalService) RequestCancellation(
+	instanceID uuid.UUID,
+	req *dto.RequestCancellationRequest,
+	requesterID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*domain.ApprovalRequest, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, utils.ErrBadRequest("reason is required")
+	}
+
+	instance, err := s.loadInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance.Status == domain.CourseInstanceStatusCancelled {
+		return nil, utils.ErrConflict("course instance is already cancelled")
+	}
+
+	return s.raise(instanceID, domain.ApprovalKindCancellation, struct{}{}, uuid.Nil, reason, requesterID, username, ipAddress, userAgent)
+}
+
+// RequestEnrollmentOverride asks to add or drop a student after the add/drop
+// deadline. While add/drop is open the enrollment is changed directly.
+func (s *approvalService) RequestEnrollmentOverride(
+	instanceID uuid.UUID,
+	req *dto.RequestEnrollmentOverrideRequest,
+	requesterID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*domain.ApprovalRequest, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, utils.ErrBadRequest("reason is required")
+	}
+	if req.UserID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+	if req.Status != domain.EnrollmentStatusEnrolled && req.Status != domain.EnrollmentStatusDropped {
+		return nil, utils.ErrBadRequest("invalid status: allowed values are Enrolled, Dropped")
+	}
+
+	instance, err := s.loadInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	semester, err := s.semesterRepo.GetByID(instance.SemesterID)
+	if err != nil {
+		s.logger.Error("failed to load semester", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load semester", err)
+	}
+	if semester == nil || semester.AddDropOpen(domain.Today()) {
+		return nil, utils.ErrUnprocessable("the add/drop period is open; change the enrollment directly")
+	}
+
+	existing, err := s.enrollmentRepo.GetEnrollment(instanceID, req.UserID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment", err)
+	}
+	switch {
+	case existing == nil && req.Status == domain.EnrollmentStatusDropped:
+		return nil, utils.ErrNotFound("enrollment not found")
+	case existing != nil && !isAddDropChange(existing.Status, req.Status):
+		return nil, utils.ErrConflict("enrollment is " + existing.Status + "; it cannot be changed to " + req.Status)
+	}
+
+	override := domain.EnrollmentOverridePayload{
+		UserID:          req.UserID,
+		Status:          req.Status,
+		AllowIndividual: req.AllowIndividual,
+	}
+	return s.raise(instanceID, domain.ApprovalKindEnrollmentOverride, override, req.UserID, reason, requesterID, username, ipAddress, userAgent)
+}
+
+// raise stores a pending request and notifies the faculty's leaders.
+func (s *approvalService) raise(
+	instanceID uuid.UUID,
+	kind string,
+	payload interface{},
+	subject uuid.UUID,
+	reason string,
+	requesterID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*domain.ApprovalRequest, error) {
+	facultyID, err := s.leadershipService.FacultyOf(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.approvalRepo.List(repository.ApprovalFilter{
+		CourseInstanceID: &instanceID,
+		Status:           domain.ApprovalStatusPending,
+		Kind:             kind,
+	})
+	if err != nil {
+		s.logger.Error("failed to list approval requests", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list approval requests", err)
+	}
+	if hasPendingRequest(pending, kind, subject) {
+		return nil, utils.ErrConflict("an identical request is already pending")
+	}
+
+	leaders, err := s.leadershipService.ActiveLeaderIDs(facultyID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to encode approval request", err)
+	}
+	request := &domain.ApprovalRequest{
+		FacultyID:        facultyID,
+		CourseInstanceID: instanceID,
+		Kind:             kind,
+		Payload:          body,
+		Reason:           reason,
+		Status:           domain.ApprovalStatusPending,
+		RequestedByID:    requesterID,
+		RequestedBy:      username,
+	}
+	if err := s.approvalRepo.Create(request, withoutUser(leaders, requesterID)); err != nil {
+		s.logger.Error("failed to create approval request", zap.Error(err))
+		return nil, utils.ErrInternal("failed to create approval request", err)
+	}
+
+	s.audit(client.AuditActionApprovalRequested, request, username, map[string]interface{}{
+		"course_instance_id": instanceID.String(),
+		"kind":               kind,
+		"payload":            payload,
+		"reason":             reason,
+	}, ipAddress, userAgent)
+
+	s.logger.Info("approval requested",
+		zap.String("approval_id", request.ID.String()),
+		zap.String("course_instance_id", instanceID.String()),
+		zap.String("kind", kind),
+	)
+	return request, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Reads
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *approvalService) ListApprovals(facultyID, requestedByID *uuid.UUID, query *dto.ListApprovalsQuery) ([]domain.ApprovalRequest, error) {
+	if query.Status != "" && !domain.IsValidApprovalStatus(query.Status) {
+		return nil, utils.ErrBadRequest("invalid status: allowed values are pending, approved, rejected, withdrawn")
+	}
+	if query.Kind != "" && !domain.IsValidApprovalKind(query.Kind) {
+		return nil, utils.ErrBadRequest("invalid kind: allowed values are final_grade_change, course_instance_cancellation, enrollment_override")
+	}
+
+	filter := repository.ApprovalFilter{
+		RequestedByID: requestedByID,
+		Status:        query.Status,
+		Kind:          query.Kind,
+	}
+	if facultyID != nil {
+		filter.FacultyIDs = []uuid.UUID{*facultyID}
+	}
+	requests, err := s.approvalRepo.List(filter)
+	if err != nil {
+		s.logger.Error("failed to list approval requests", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list approval requests", err)
+	}
+	return requests, nil
+}
+
+func (s *approvalService) GetApproval(id, callerID uuid.UUID, isAdmin bool) (*domain.ApprovalRequest, error) {
+	request, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin || request.RequestedByID == callerID {
+		return request, nil
+	}
+	leader, err := s.leadershipService.IsFacultyLeader(request.FacultyID, callerID)
+	if err != nil {
+		return nil, err
+	}
+	if !leader {
+		return nil, utils.ErrForbidden("you cannot view this approval request")
+	}
+	return request, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Decisions
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Approve carries out the requested change and then marks the request
+// approved, holding a lock on the request throughout so that a concurrent
+// approval cannot apply the change a second time. When the change fails the
+// request stays pending, so it can be approved again once the cause is
+// fixed, or rejected.
+func (s *approvalService) Approve(
+	id uuid.UUID,
+	req *dto.DecideApprovalRequest,
+	approverID uuid.UUID,
+	isAdmin bool,
+	username, ipAddress, userAgent string,
+) (*domain.ApprovalRequest, error) {
+	request, err := s.approvalRepo.Decide(id, func(request *domain.ApprovalRequest) ([]uuid.UUID, error) {
+		if err := s.checkDecidable(request, approverID, isAdmin); err != nil {
+			return nil, err
+		}
+		if err := s.apply(request, username, ipAddress, userAgent); err != nil {
+			return nil, err
+		}
+		recordDecision(request, domain.ApprovalStatusApproved, strings.TrimSpace(req.Note), approverID, username)
+		return []uuid.UUID{request.RequestedByID}, nil
+	})
+	if err != nil {
+		var appErr *utils.AppError
+		if errors.As(err, &appErr) {
+			return nil, err
+		}
+		return nil, s.closeError(err)
+	}
+	if request == nil {
+		return nil, utils.ErrNotFound("approval request not found")
+	}
+
+	s.audit(client.AuditActionApprovalApproved, request, username, map[string]interface{}{
+		"kind": request.Kind,
+		"note": request.DecisionNote,
+	}, ipAddress, userAgent)
+	return request, nil
+}
+
+func (s *approvalService) Reject(
+	id uuid.UUID,
+	req *dto.DecideApprovalRequest,
+	approverID uuid.UUID,
+	isAdmin bool,
+	username, ipAddress, userAgent string,
+) (*domain.ApprovalRequest, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, utils.ErrBadRequest("note is required to reject a request")
+	}
+
+	request, err := s.decidable(id, approverID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.close(request, domain.ApprovalStatusRejected, note, approverID, username); err != nil {
+		return nil, err
+	}
+
+	s.audit(client.AuditActionApprovalRejected, request, username, map[string]interface{}{
+		"kind": request.Kind,
+		"note": note,
+	}, ipAddress, userAgent)
+	return request, nil
+}
+
+// Withdraw lets the requester cancel a request that is still pending.
+func (s *approvalService) Withdraw(id, requesterID uuid.UUID, username, ipAddress, userAgent string) (*domain.ApprovalRequest, error) {
+	request, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequestedByID != requesterID {
+		return nil, utils.ErrForbidden("only the requester can withdraw a request")
+	}
+	if request.Status != domain.ApprovalStatusPending {
+		return nil, utils.ErrConflict("approval request is already " + request.Status)
+	}
+
+	leaders, err := s.leadershipService.ActiveLeaderIDs(request.FacultyID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	request.Status = domain.ApprovalStatusWithdrawn
+	request.DecidedAt = &now
+	if err := s.approvalRepo.Close(request, withoutUser(leaders, requesterID)); err != nil {
+		return nil, s.closeError(err)
+	}
+
+	s.audit(client.AuditActionApprovalWithdrawn, request, username, map[string]interface{}{
+		"kind": request.Kind,
+	}, ipAddress, userAgent)
+	return request, nil
+}
+
+// decidable loads a pending request the caller may decide.
+func (s *approvalService) decidable(id, approverID uuid.UUID, isAdmin bool) (*domain.ApprovalRequest, error) {
+	request, err := s.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDecidable(request, approverID, isAdmin); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// checkDecidable ensures the request is pending and the caller may decide it.
+func (s *approvalService) checkDecidable(request *domain.ApprovalRequest, approverID uuid.UUID, isAdmin bool) error {
+	if request.Status != domain.ApprovalStatusPending {
+		return utils.ErrConflict("approval request is already " + request.Status)
+	}
+	if request.RequestedByID == approverID {
+		return utils.ErrForbidden("you cannot decide your own request")
+	}
+	if isAdmin {
+		return nil
+	}
+	leader, err := s.leadershipService.IsFacultyLeader(request.FacultyID, approverID)
+	if err != nil {
+		return err
+	}
+	if !leader {
+		return utils.ErrForbidden("only the faculty's leadership can decide this request")
+	}
+	return nil
+}
+
+// apply carries out an approved request as the approver.
+func (s *approvalService) apply(request *domain.ApprovalRequest, username, ipAddress, userAgent string) error {
+	switch request.Kind {
+	case domain.ApprovalKindGradeChange:
+		var change domain.GradeChangePayload
+		if err := json.Unmarshal(request.Payload, &change); err != nil {
+			return utils.ErrInternal("failed to decode approval request", err)
+		}
+		_, err := s.finalGradeService.AmendFinalGrade(request.CourseInstanceID, &change, username, ipAddress, userAgent)
+		return err
+
+	case domain.ApprovalKindCancellation:
+		_, err := s.courseInstanceService.UpdateCourseInstance(request.CourseInstanceID, &dto.UpdateCourseInstanceRequest{
+			Status:               domain.CourseInstanceStatusCancelled,
+			CancellationApproved: true,
+		}, username, ipAddress, userAgent)
+		return err
+
+	case domain.ApprovalKindEnrollmentOverride:
+		var override domain.EnrollmentOverridePayload
+		if err := json.Unmarshal(request.Payload, &override); err != nil {
+			return utils.ErrInternal("failed to decode approval request", err)
+		}
+		existing, err := s.enrollmentRepo.GetEnrollment(request.CourseInstanceID, override.UserID)
+		if err != nil {
+			s.logger.Error("failed to load enrollment", zap.Error(err))
+			return utils.ErrInternal("failed to load enrollment", err)
+		}
+		if existing == nil {
+			_, err = s.enrollmentService.EnrollStudent(&dto.EnrollmentRequest{
+				CourseInstanceID: request.CourseInstanceID,
+				UserID:           override.UserID,
+				Status:           override.Status,
+				AllowIndividual:  override.AllowIndividual,
+				DeadlineApproved: true,
+			}, username, ipAddress, userAgent)
+			return err
+		}
+		_, err = s.enrollmentService.UpdateEnrollment(request.CourseInstanceID, override.UserID, &dto.UpdateEnrollmentRequest{
+			Status:           override.Status,
+			DeadlineApproved: true,
+		}, username, ipAddress, userAgent)
+		return err
+	}
+	return utils.ErrUnprocessable("unknown approval request kind " + request.Kind)
+}
+
+// close records the decision and notifies the requester.
+func (s *approvalService) close(request *domain.ApprovalRequest, status, note string, approverID uuid.UUID, username string) error {
+	recordDecision(request, status, note, approverID, username)
+	if err := s.approvalRepo.Close(request, []uuid.UUID{request.RequestedByID}); err != nil {
+		return s.closeError(err)
+	}
+	return nil
+}
+
+// recordDecision sets the decision fields of the request.
+func recordDecision(request *domain.ApprovalRequest, status, note string, approverID uuid.UUID, username string) {
+	now := time.Now().UTC()
+	request.Status = status
+	request.DecidedByID = &approverID
+	request.DecidedBy = username
+	request.DecisionNote = note
+	request.DecidedAt = &now
+}
+
+func (s *approvalService) closeError(err error) error {
+	if errors.Is(err, repository.ErrApprovalNotPending) {
+		return utils.ErrConflict("approval request is no longer pending")
+	}
+	s.logger.Error("failed to update approval request", zap.Error(err))
+	return utils.ErrInternal("failed to update approval request", err)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *approvalService) load(id uuid.UUID) (*domain.ApprovalRequest, error) {
+	request, err := s.approvalRepo.GetByID(id)
+	if err != nil {
+		s.logger.Error("failed to load approval request", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load approval request", err)
+	}
+	if request == nil {
+		return nil, utils.ErrNotFound("approval request not found")
+	}
+	return request, nil
+}
+
+func (s *approvalService) loadInstance(instanceID uuid.UUID) (*domain.CourseInstance, error) {
+	instance, err := s.courseInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load course instance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load course instance", err)
+	}
+	if instance == nil {
+		return nil, utils.ErrNotFound("course instance not found")
+	}
+	return instance, nil
+}
+
+func (s *approvalService) audit(
+	action client.AuditAction,
+	request *domain.ApprovalRequest,
+	username string,
+	changes map[string]interface{},
+	ipAddress, userAgent string,
+) {
+	if auditErr := s.auditClient.LogAction(
+		string(action),
+		"approval_request",
+		request.ID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+)
+
+// approvalSubject returns the student an approval request is about, or
+// uuid.Nil when it concerns the whole course instance.
+func approvalSubject(payload json.RawMessage) uuid.UUID {
+	var subject struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := json.Unmarshal(payload, &subject); err != nil {
+		return uuid.Nil
+	}
+	return subject.UserID
+}
+
+// hasPendingRequest reports whether one of the requests is still pending and
+// concerns the same subject, so a new request would duplicate it.
+func hasPendingRequest(requests []domain.ApprovalRequest, kind string, subject uuid.UUID) bool {
+	for _, r := range requests {
+		if r.Status == domain.ApprovalStatusPending && r.Kind == kind && approvalSubject(r.Payload) == subject {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutUser drops userID from ids, e.g. so a leader who raises a request is
+// not notified of it.
+func withoutUser(ids []uuid.UUID, userID uuid.UUID) []uuid.UUID {
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id != userID {
+			out = append(out, id)
+		}
+	}
+	return out
+}
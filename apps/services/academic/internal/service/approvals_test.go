@@ -0,0 +1,70 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasPendingRequest(t *testing.T) {
+	student := uuid.New()
+	payload, _ := json.Marshal(domain.GradeChangePayload{UserID: student, FinalGrade: "B"})
+
+	requests := []domain.ApprovalRequest{
+		{Kind: domain.ApprovalKindGradeChange, Status: domain.ApprovalStatusPending, Payload: payload},
+		{Kind: domain.ApprovalKindCancellation, Status: domain.ApprovalStatusRejected, Payload: json.RawMessage(`{}`)},
+	}
+
+	assert.Equal(t, student, approvalSubject(payload))
+	assert.True(t, hasPendingRequest(requests, domain.ApprovalKindGradeChange, student))
+	assert.False(t, hasPendingRequest(requests, domain.ApprovalKindGradeChange, uuid.New()), "another student")
+	assert.False(t, hasPendingRequest(requests, domain.ApprovalKindCancellation, uuid.Nil), "no longer pending")
+
+	requests[1].Status = domain.ApprovalStatusPending
+	assert.True(t, hasPendingRequest(requests, domain.ApprovalKindCancellation, uuid.Nil))
+}
+
+func TestWithoutUser(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	assert.Equal(t, []uuid.UUID{b}, withoutUser([]uuid.UUID{a, b}, a))
+	assert.Empty(t, withoutUser(nil, a))
+}
+
+func TestApprovalNotification(t *testing.T) {
+	requester := uuid.New()
+	request := &domain.ApprovalRequest{
+		ID:               uuid.New(),
+		FacultyID:        uuid.New(),
+		CourseInstanceID: uuid.New(),
+		Kind:             domain.ApprovalKindEnrollmentOverride,
+		Status:           domain.ApprovalStatusRejected,
+		RequestedByID:    requester,
+	}
+	event := domain.NewApprovalEvent(request, []uuid.UUID{requester})
+	event.ID = uuid.New()
+
+	notification, ok, err := approvalNotification(&event)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, event.ID.String(), notification.ID)
+	assert.Equal(t, []string{requester.String()}, notification.UserIDs)
+	assert.Equal(t, domain.EventApprovalRejected, notification.Type)
+	assert.Equal(t, "Your request for an enrollment override was rejected.", notification.Message)
+	assert.Equal(t, request.ID, notification.Data["approval_id"])
+
+	// Nobody to tell.
+	silent := domain.NewApprovalEvent(request, nil)
+	_, ok, err = approvalNotification(&silent)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Other events are not notifications.
+	other := domain.OutboxEvent{EventType: domain.EventEnrollmentCreated, Payload: json.RawMessage(`{}`)}
+	_, ok, err = approvalNotification(&other)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/google/uuid"
+)
+
+// newCheckInSecret returns a random hex secret for a session's check-in
+// codes.
+func newCheckInSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// checkInWindow returns the index of the rotation window containing now and
+// the time that window ends.
+func checkInWindow(now time.Time, rotation time.Duration) (int64, time.Time) {
+	step := int64(rotation / time.Second)
+	if step < 1 {
+		step = 1
+	}
+	counter := now.Unix() / step
+	return counter, time.Unix((counter+1)*step, 0).UTC()
+}
+
+// checkInCode derives the six-digit code for one rotation window, the same
+// way a TOTP authenticator does: HMAC-SHA256 over the window index, then
+// dynamic truncation.
+func checkInCode(secret string, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1_000_000)
+}
+
+// matchCheckInCode reports whether code is valid at now. The previous
+// window's code is still accepted so that a student who read the code just
+// before it rotated is not turned away.
+func matchCheckInCode(secret, code string, now time.Time, rotation time.Duration) bool {
+	counter, _ := checkInWindow(now, rotation)
+	for _, c := range []int64{counter, counter - 1} {
+		if hmac.Equal([]byte(checkInCode(secret, c)), []byte(code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Self check-in attempt limits. A student who enters maxCheckInFailures
+// wrong codes for a course instance is locked out of checking in there for
+// checkInLockout, which keeps guessing a six-digit code impractical. The
+// count is kept per replica (see checkInLimiter); even across several
+// replicas the odds of guessing one of the two valid codes stay negligible.
+const (
+	maxCheckInFailures = 5
+	checkInLockout     = 10 * time.Minute
+)
+
+type checkInAttempts struct {
+	failures int
+	resetAt  time.Time
+}
+
+// checkInLimiter counts failed check-in codes per key within a rolling
+// lockout window. It lives in process memory and is not shared between
+// replicas. It is safe for concurrent use.
+type checkInLimiter struct {
+	mu       sync.Mutex
+	attempts map[string]checkInAttempts
+}
+
+func newCheckInLimiter() *checkInLimiter {
+	return &checkInLimiter{attempts: make(map[string]checkInAttempts)}
+}
+
+// blocked reports whether key has used up its attempts at now.
+func (l *checkInLimiter) blocked(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	a, ok := l.attempts[key]
+	return ok && now.Before(a.resetAt) && a.failures >= maxCheckInFailures
+}
+
+// fail records a wrong code for key. The window restarts with the first
+// failure after the previous one expired.
+func (l *checkInLimiter) fail(key string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	a := l.attempts[key]
+	if !now.Before(a.resetAt) {
+		// Drop expired keys while we are here so the map stays small.
+		for k, other := range l.attempts {
+			if !now.Before(other.resetAt) {
+				delete(l.attempts, k)
+			}
+		}
+		a = checkInAttempts{resetAt: now.Add(checkInLockout)}
+	}
+	a.failures++
+	l.attempts[key] = a
+}
+
+// reset forgets key's failures after a successful check-in.
+func (l *checkInLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.attempts, key)
+}
+
+// attendanceThreshold returns the at-risk threshold for a course instance:
+// its own override when set, otherwise the service default.
+func attendanceThreshold(instance *domain.CourseInstance, fallback float64) float64 {
+	if instance.AttendanceThreshold != nil {
+		return *instance.AttendanceThreshold
+	}
+	return fallback
+}
+
+// summarizeAttendance counts one student's attendance over the given
+// sessions. statuses maps a session ID to the student's recorded status;
+// sessions without one count as unmarked. The rate is attended (present or
+// late) over attended plus absent, so excused and unmarked sessions neither
+// help nor hurt. It is nil until at least one session counts, and a student
+// is only at risk once there is a rate below the threshold.
+func summarizeAttendance(
+	sessions []domain.AttendanceSession,
+	statuses map[uuid.UUID]string,
+	threshold float64,
+) dto.AttendanceSummary {
+	summary := dto.AttendanceSummary{Sessions: len(sessions)}
+	for _, session := range sessions {
+		switch statuses[session.ID] {
+		case domain.AttendanceStatusPresent:
+			summary.Present++
+		case domain.AttendanceStatusLate:
+			summary.Late++
+		case domain.AttendanceStatusAbsent:
+			summary.Absent++
+		case domain.AttendanceStatusExcused:
+			summary.Excused++
+		default:
+			summary.Unmarked++
+		}
+	}
+
+	counted := summary.Present + summary.Late + summary.Absent
+	if counted > 0 {
+		rate := math.Round(float64(summary.Present+summary.Late)/float64(counted)*10000) / 100
+		summary.Rate = &rate
+		summary.AtRisk = rate < threshold
+	}
+	return summary
+}
+
+// sessionsFor filters the sessions that concern a student: those for the
+// whole course and those of the sections they belong to.
+func sessionsFor(sessions []domain.AttendanceSession, sectionIDs map[uuid.UUID]bool) []domain.AttendanceSession {
+	var out []domain.AttendanceSession
+	for _, session := range sessions {
+		if session.SectionID == nil || sectionIDs[*session.SectionID] {
+			out = append(out, session)
+		}
+	}
+	return out
+}
@@ -0,0 +1,859 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxCheckInMinutes caps how long self check-in can stay open.
+const maxCheckInMinutes = 240
+
+// AttendanceService defines the business-logic contract for attendance
+// sessions, marking, self check-in and reports.
+type AttendanceService interface {
+	CreateSession(instanceID uuid.UUID, req *dto.CreateAttendanceSessionRequest, username, ipAddress, userAgent string) (*dto.AttendanceSessionResponse, error)
+	UpdateSession(instanceID, sessionID uuid.UUID, req *dto.UpdateAttendanceSessionRequest, username, ipAddress, userAgent string) (*dto.AttendanceSessionResponse, error)
+	DeleteSession(instanceID, sessionID uuid.UUID, username, ipAddress, userAgent string) error
+	GetSession(instanceID, sessionID uuid.UUID) (*dto.AttendanceSessionResponse, error)
+	ListSessions(instanceID uuid.UUID) ([]dto.AttendanceSessionResponse, error)
+
+	MarkAttendance(instanceID, sessionID, userID uuid.UUID, req *dto.MarkAttendanceRequest, username, ipAddress, userAgent string) (*dto.AttendanceRecordResponse, error)
+	BulkMarkAttendance(instanceID, sessionID uuid.UUID, req *dto.BulkMarkAttendanceRequest, username, ipAddress, userAgent string) (*dto.BulkMarkAttendanceResponse, error)
+
+	OpenCheckIn(instanceID, sessionID uuid.UUID, req *dto.OpenCheckInRequest, username, ipAddress, userAgent string) (*dto.CheckInCodeResponse, error)
+	GetCheckInCode(instanceID, sessionID uuid.UUID) (*dto.CheckInCodeResponse, error)
+	CloseCheckIn(instanceID, sessionID uuid.UUID, username, ipAddress, userAgent string) error
+	// CheckIn marks the student present at the open session of the course
+	// instance whose current code matches. A record already entered for the
+	// student is never overwritten, and repeated wrong codes lock the student
+	// out for a while. Wrong codes are counted in memory, so the lockout
+	// applies per replica: behind N replicas a student gets up to N times
+	// maxCheckInFailures guesses per checkInLockout.
+	CheckIn(instanceID, userID uuid.UUID, req *dto.CheckInRequest) (*dto.AttendanceRecordResponse, error)
+
+	StudentReport(instanceID, userID uuid.UUID) (*dto.StudentAttendanceReport, error)
+	CourseReport(instanceID uuid.UUID) (*dto.CourseAttendanceReport, error)
+}
+
+// attendanceService is the concrete implementation.
+type attendanceService struct {
+	attendanceRepo     repository.AttendanceRepository
+	courseInstanceRepo repository.CourseInstanceRepository
+	enrollmentRepo     repository.EnrollmentRepository
+	sectionRepo        repository.SectionRepository
+	auditClient        *client.AuditClient
+	atRiskThreshold    float64
+	codeRotation       time.Duration
+	checkInWindow      time.Duration
+	checkInLimiter     *checkInLimiter
+	logger             *zap.Logger
+}
+
+// NewAttendanceService wires all dependencies together. atRiskThreshold is
+// the default percentage below which students are flagged; course instances
+// can override it.
+func NewAttendanceService(
+	attendanceRepo repository.AttendanceRepository,
+	courseInstanceRepo repository.CourseInstanceRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	sectionRepo repository.SectionRepository,
+	auditClient *client.AuditClient,
+	atRiskThreshold float64,
+	codeRotation time.Duration,
+	checkInWindow time.Duration,
+	logger *zap.Logger,
+) AttendanceService {
+	return &attendanceService{
+		attendanceRepo:     attendanceRepo,
+		courseInstanceRepo: courseInstanceRepo,
+		enrollmentRepo:     enrollmentRepo,
+		sectionRepo:        sectionRepo,
+		auditClient:        auditClient,
+		atRiskThreshold:    atRiskThreshold,
+		codeRotation:       codeRotation,
+		checkInWindow:      checkInWindow,
+		checkInLimiter:     newCheckInLimiter(),
+		logger:             logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Sessions
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *attendanceService) CreateSession(
+	instanceID uuid.UUID,
+	req *dto.CreateAttendanceSessionRequest,
+	username, ipAddress, userAgent string,
+) (*dto.AttendanceSessionResponse, error) {
+	if _, err := s.getInstance(instanceID); err != nil {
+		return nil, err
+	}
+
+	date, err := parseSessionDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+	req.Type = strings.TrimSpace(req.Type)
+	if !domain.IsValidAttendanceSessionType(req.Type) {
+		return nil, utils.ErrBadRequest("invalid type: allowed values are lecture, lab, tutorial")
+	}
+	if req.SectionID != nil {
+		section, err := s.sectionRepo.GetByID(*req.SectionID)
+		if err != nil {
+			s.logger.Error("failed to load section", zap.Error(err))
+			return nil, utils.ErrInternal("failed to load section", err)
+		}
+		if section == nil || section.CourseInstanceID != instanceID {
+			return nil, utils.ErrNotFound("section not found")
+		}
+	}
+
+	session := &domain.AttendanceSession{
+		CourseInstanceID: instanceID,
+		SectionID:        req.SectionID,
+		Date:             date,
+		Type:             req.Type,
+		Topic:            strings.TrimSpace(req.Topic),
+		CreatedBy:        username,
+	}
+	if err := s.attendanceRepo.CreateSession(session); err != nil {
+		s.logger.Error("failed to create attendance session", zap.Error(err))
+		return nil, utils.ErrInternal("failed to create attendance session", err)
+	}
+
+	s.audit(client.AuditActionAttendanceSessionCreated, session.ID, map[string]interface{}{
+		"course_instance_id": instanceID.String(),
+		"date":               req.Date,
+		"type":               session.Type,
+	}, username, ipAddress, userAgent)
+
+	resp := toAttendanceSessionResponse(session, time.Now())
+	return &resp, nil
+}
+
+func (s *attendanceService) UpdateSession(
+	instanceID, sessionID uuid.UUID,
+	req *dto.UpdateAttendanceSessionRequest,
+	username, ipAddress, userAgent string,
+) (*dto.AttendanceSessionResponse, error) {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]interface{}{}
+	if req.Date != nil {
+		date, err := parseSessionDate(*req.Date)
+		if err != nil {
+			return nil, err
+		}
+		session.Date = date
+		changes["date"] = *req.Date
+	}
+	if req.Type != nil {
+		t := strings.TrimSpace(*req.Type)
+		if !domain.IsValidAttendanceSessionType(t) {
+			return nil, utils.ErrBadRequest("invalid type: allowed values are lecture, lab, tutorial")
+		}
+		session.Type = t
+		changes["type"] = t
+	}
+	if req.Topic != nil {
+		session.Topic = strings.TrimSpace(*req.Topic)
+		changes["topic"] = session.Topic
+	}
+
+	if err := s.attendanceRepo.UpdateSession(session); err != nil {
+		s.logger.Error("failed to update attendance session", zap.Error(err))
+		return nil, utils.ErrInternal("failed to update attendance session", err)
+	}
+
+	s.audit(client.AuditActionAttendanceSessionUpdated, session.ID, changes, username, ipAddress, userAgent)
+
+	resp := toAttendanceSessionResponse(session, time.Now())
+	return &resp, nil
+}
+
+func (s *attendanceService) DeleteSession(instanceID, sessionID uuid.UUID, username, ipAddress, userAgent string) error {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attendanceRepo.DeleteSession(session.ID); err != nil {
+		s.logger.Error("failed to delete attendance session", zap.Error(err))
+		return utils.ErrInternal("failed to delete attendance session", err)
+	}
+
+	s.audit(client.AuditActionAttendanceSessionDeleted, session.ID, map[string]interface{}{
+		"course_instance_id": instanceID.String(),
+		"date":               session.Date.Format(time.DateOnly),
+	}, username, ipAddress, userAgent)
+	return nil
+}
+
+func (s *attendanceService) GetSession(instanceID, sessionID uuid.UUID) (*dto.AttendanceSessionResponse, error) {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.attendanceRepo.ListRecords(session.ID)
+	if err != nil {
+		s.logger.Error("failed to list attendance records", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list attendance records", err)
+	}
+
+	resp := toAttendanceSessionResponse(session, time.Now())
+	resp.Records = make([]dto.AttendanceRecordResponse, len(records))
+	for i := range records {
+		resp.Records[i] = toAttendanceRecordResponse(&records[i])
+	}
+	return &resp, nil
+}
+
+func (s *attendanceService) ListSessions(instanceID uuid.UUID) ([]dto.AttendanceSessionResponse, error) {
+	if _, err := s.getInstance(instanceID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.attendanceRepo.ListSessions(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list attendance sessions", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list attendance sessions", err)
+	}
+
+	now := time.Now()
+	resp := make([]dto.AttendanceSessionResponse, len(sessions))
+	for i := range sessions {
+		resp[i] = toAttendanceSessionResponse(&sessions[i], now)
+	}
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Marking
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *attendanceService) MarkAttendance(
+	instanceID, sessionID, userID uuid.UUID,
+	req *dto.MarkAttendanceRequest,
+	username, ipAddress, userAgent string,
+) (*dto.AttendanceRecordResponse, error) {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	req.Status = strings.TrimSpace(req.Status)
+	if !domain.IsValidAttendanceStatus(req.Status) {
+		return nil, utils.ErrBadRequest("invalid status: allowed values are present, absent, late, excused")
+	}
+
+	roster, err := s.roster(session)
+	if err != nil {
+		return nil, err
+	}
+	if !roster[userID] {
+		return nil, utils.ErrUnprocessable("student is not on this session's roster")
+	}
+
+	record := domain.AttendanceRecord{
+		SessionID: session.ID,
+		UserID:    userID,
+		Status:    req.Status,
+		Source:    domain.AttendanceSourceStaff,
+		Note:      strings.TrimSpace(req.Note),
+		MarkedBy:  username,
+		MarkedAt:  time.Now(),
+	}
+	if err := s.attendanceRepo.UpsertRecords([]domain.AttendanceRecord{record}); err != nil {
+		s.logger.Error("failed to mark attendance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to mark attendance", err)
+	}
+
+	s.audit(client.AuditActionAttendanceMarked, session.ID, map[string]interface{}{
+		"user_id": userID.String(),
+		"status":  record.Status,
+	}, username, ipAddress, userAgent)
+
+	resp := toAttendanceRecordResponse(&record)
+	return &resp, nil
+}
+
+func (s *attendanceService) BulkMarkAttendance(
+	instanceID, sessionID uuid.UUID,
+	req *dto.BulkMarkAttendanceRequest,
+	username, ipAddress, userAgent string,
+) (*dto.BulkMarkAttendanceResponse, error) {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	req.RemainingStatus = strings.TrimSpace(req.RemainingStatus)
+	if len(req.Records) == 0 && req.RemainingStatus == "" {
+		return nil, utils.ErrBadRequest("records or remaining_status is required")
+	}
+	if req.RemainingStatus != "" && !domain.IsValidAttendanceStatus(req.RemainingStatus) {
+		return nil, utils.ErrBadRequest("invalid remaining_status: allowed values are present, absent, late, excused")
+	}
+
+	roster, err := s.roster(session)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	listed := make(map[uuid.UUID]bool, len(req.Records))
+	records := make([]domain.AttendanceRecord, 0, len(roster))
+	for _, mark := range req.Records {
+		status := strings.TrimSpace(mark.Status)
+		if !domain.IsValidAttendanceStatus(status) {
+			return nil, utils.ErrBadRequest("invalid status for student " + mark.UserID.String() + ": allowed values are present, absent, late, excused")
+		}
+		if listed[mark.UserID] {
+			return nil, utils.ErrBadRequest("student " + mark.UserID.String() + " is listed more than once")
+		}
+		if !roster[mark.UserID] {
+			return nil, utils.ErrUnprocessable("student " + mark.UserID.String() + " is not on this session's roster")
+		}
+		listed[mark.UserID] = true
+		records = append(records, domain.AttendanceRecord{
+			SessionID: session.ID,
+			UserID:    mark.UserID,
+			Status:    status,
+			Source:    domain.AttendanceSourceStaff,
+			Note:      strings.TrimSpace(mark.Note),
+			MarkedBy:  username,
+			MarkedAt:  now,
+		})
+	}
+
+	remaining := 0
+	if req.RemainingStatus != "" {
+		existing, err := s.attendanceRepo.ListRecords(session.ID)
+		if err != nil {
+			s.logger.Error("failed to list attendance records", zap.Error(err))
+			return nil, utils.ErrInternal("failed to list attendance records", err)
+		}
+		for _, r := range existing {
+			listed[r.UserID] = true
+		}
+		for _, userID := range sortedIDs(roster) {
+			if listed[userID] {
+				continue
+			}
+			records = append(records, domain.AttendanceRecord{
+				SessionID: session.ID,
+				UserID:    userID,
+				Status:    req.RemainingStatus,
+				Source:    domain.AttendanceSourceStaff,
+				MarkedBy:  username,
+				MarkedAt:  now,
+			})
+			remaining++
+		}
+	}
+
+	if err := s.attendanceRepo.UpsertRecords(records); err != nil {
+		s.logger.Error("failed to mark attendance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to mark attendance", err)
+	}
+
+	s.audit(client.AuditActionAttendanceMarked, session.ID, map[string]interface{}{
+		"marked":           len(records),
+		"remaining_status": req.RemainingStatus,
+	}, username, ipAddress, userAgent)
+
+	return &dto.BulkMarkAttendanceResponse{
+		SessionID: session.ID,
+		Marked:    len(records),
+		Remaining: remaining,
+	}, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Self check-in
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *attendanceService) OpenCheckIn(
+	instanceID, sessionID uuid.UUID,
+	req *dto.OpenCheckInRequest,
+	username, ipAddress, userAgent string,
+) (*dto.CheckInCodeResponse, error) {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := s.checkInWindow
+	if req.Minutes < 0 || req.Minutes > maxCheckInMinutes {
+		return nil, utils.ErrBadRequest("minutes must be between 1 and 240")
+	}
+	if req.Minutes > 0 {
+		window = time.Duration(req.Minutes) * time.Minute
+	}
+
+	secret, err := newCheckInSecret()
+	if err != nil {
+		s.logger.Error("failed to generate check-in secret", zap.Error(err))
+		return nil, utils.ErrInternal("failed to open check-in", err)
+	}
+	openUntil := time.Now().Add(window).UTC()
+	session.CheckInSecret = secret
+	session.CheckInOpenUntil = &openUntil
+	if err := s.attendanceRepo.UpdateSession(session); err != nil {
+		s.logger.Error("failed to open check-in", zap.Error(err))
+		return nil, utils.ErrInternal("failed to open check-in", err)
+	}
+
+	s.audit(client.AuditActionAttendanceCheckInOpened, session.ID, map[string]interface{}{
+		"open_until": openUntil,
+	}, username, ipAddress, userAgent)
+
+	return s.currentCode(session, time.Now()), nil
+}
+
+func (s *attendanceService) GetCheckInCode(instanceID, sessionID uuid.UUID) (*dto.CheckInCodeResponse, error) {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !session.CheckInOpen(now) {
+		return nil, utils.ErrConflict("check-in is not open for this session")
+	}
+	return s.currentCode(session, now), nil
+}
+
+func (s *attendanceService) CloseCheckIn(instanceID, sessionID uuid.UUID, username, ipAddress, userAgent string) error {
+	session, err := s.getSession(instanceID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.CheckInSecret = ""
+	session.CheckInOpenUntil = nil
+	if err := s.attendanceRepo.UpdateSession(session); err != nil {
+		s.logger.Error("failed to close check-in", zap.Error(err))
+		return utils.ErrInternal("failed to close check-in", err)
+	}
+
+	s.audit(client.AuditActionAttendanceCheckInClosed, session.ID, nil, username, ipAddress, userAgent)
+	return nil
+}
+
+func (s *attendanceService) CheckIn(instanceID, userID uuid.UUID, req *dto.CheckInRequest) (*dto.AttendanceRecordResponse, error) {
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		return nil, utils.ErrBadRequest("code is required")
+	}
+
+	enrollment, err := s.enrollmentRepo.GetEnrollment(instanceID, userID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment", err)
+	}
+	if enrollment == nil || enrollment.Status != domain.EnrollmentStatusEnrolled {
+		return nil, utils.ErrForbidden("you are not enrolled in this course instance")
+	}
+
+	now := time.Now()
+	limitKey := instanceID.String() + ":" + userID.String()
+	if s.checkInLimiter.blocked(limitKey, now) {
+		return nil, utils.ErrTooManyRequests("too many invalid check-in codes; try again later")
+	}
+
+	sessions, err := s.attendanceRepo.ListOpenSessions(instanceID, now)
+	if err != nil {
+		s.logger.Error("failed to list open attendance sessions", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list open attendance sessions", err)
+	}
+
+	for i := range sessions {
+		session := &sessions[i]
+		if !matchCheckInCode(session.CheckInSecret, code, now, s.codeRotation) {
+			continue
+		}
+		if session.SectionID != nil {
+			roster, err := s.roster(session)
+			if err != nil {
+				return nil, err
+			}
+			if !roster[userID] {
+				return nil, utils.ErrForbidden("this session is for another section")
+			}
+		}
+
+		existing, err := s.attendanceRepo.GetRecord(session.ID, userID)
+		if err != nil {
+			s.logger.Error("failed to load attendance record", zap.Error(err))
+			return nil, utils.ErrInternal("failed to load attendance record", err)
+		}
+		if existing != nil {
+			return nil, checkInRecorded(existing)
+		}
+
+		record := domain.AttendanceRecord{
+			SessionID: session.ID,
+			UserID:    userID,
+			Status:    domain.AttendanceStatusPresent,
+			Source:    domain.AttendanceSourceCheckIn,
+			MarkedAt:  now,
+		}
+		// Staff may mark the student while the check-in is in flight; their
+		// record wins.
+		created, err := s.attendanceRepo.CreateRecord(&record)
+		if err != nil {
+			s.logger.Error("failed to record check-in", zap.Error(err))
+			return nil, utils.ErrInternal("failed to record check-in", err)
+		}
+		if !created {
+			return nil, utils.ErrConflict("your attendance for this session has already been recorded")
+		}
+		s.checkInLimiter.reset(limitKey)
+
+		s.logger.Info("student checked in",
+			zap.String("session_id", session.ID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		resp := toAttendanceRecordResponse(&record)
+		return &resp, nil
+	}
+
+	s.checkInLimiter.fail(limitKey, now)
+	return nil, utils.ErrBadRequest("invalid or expired check-in code")
+}
+
+// checkInRecorded is the error for a check-in to a session the student
+// already has a record for.
+func checkInRecorded(existing *domain.AttendanceRecord) error {
+	switch existing.Status {
+	case domain.AttendanceStatusPresent, domain.AttendanceStatusLate:
+		return utils.ErrConflict("you are already checked in to this session")
+	default:
+		return utils.ErrConflict("your attendance for this session has already been recorded as " + existing.Status)
+	}
+}
+
+// currentCode returns the code for the rotation window containing now.
+func (s *attendanceService) currentCode(session *domain.AttendanceSession, now time.Time) *dto.CheckInCodeResponse {
+	counter, expiresAt := checkInWindow(now, s.codeRotation)
+	if expiresAt.After(*session.CheckInOpenUntil) {
+		expiresAt = *session.CheckInOpenUntil
+	}
+	return &dto.CheckInCodeResponse{
+		SessionID: session.ID,
+		Code:      checkInCode(session.CheckInSecret, counter),
+		ExpiresAt: expiresAt,
+		OpenUntil: *session.CheckInOpenUntil,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Reports
+// ─────────────────────────────────────────────────────────────────────────────
+
+// StudentReport covers the sessions held so far that concern the student:
+// course-wide sessions and those of their sections.
+func (s *attendanceService) StudentReport(instanceID, userID uuid.UUID) (*dto.StudentAttendanceReport, error) {
+	instance, err := s.getInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.enrollmentRepo.GetEnrollment(instanceID, userID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment", err)
+	}
+	if enrollment == nil {
+		return nil, utils.ErrNotFound("student is not enrolled in this course instance")
+	}
+
+	held, err := s.heldSessions(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.sectionRepo.ListUserSections(userID, &instanceID)
+	if err != nil {
+		s.logger.Error("failed to list user sections", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list user sections", err)
+	}
+	sectionIDs := make(map[uuid.UUID]bool, len(sections))
+	for _, section := range sections {
+		sectionIDs[section.ID] = true
+	}
+	sessions := sessionsFor(held, sectionIDs)
+
+	records, err := s.attendanceRepo.ListRecordsByUser(instanceID, userID)
+	if err != nil {
+		s.logger.Error("failed to list attendance records", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list attendance records", err)
+	}
+	bySession := make(map[uuid.UUID]*domain.AttendanceRecord, len(records))
+	statuses := make(map[uuid.UUID]string, len(records))
+	for i := range records {
+		bySession[records[i].SessionID] = &records[i]
+		statuses[records[i].SessionID] = records[i].Status
+	}
+
+	threshold := attendanceThreshold(instance, s.atRiskThreshold)
+	report := &dto.StudentAttendanceReport{
+		CourseInstanceID: instanceID,
+		UserID:           userID,
+		Threshold:        threshold,
+		Summary:          summarizeAttendance(sessions, statuses, threshold),
+		Sessions:         make([]dto.StudentAttendanceEntry, len(sessions)),
+	}
+	for i, session := range sessions {
+		entry := dto.StudentAttendanceEntry{
+			SessionID: session.ID,
+			SectionID: session.SectionID,
+			Date:      session.Date.Format(time.DateOnly),
+			Type:      session.Type,
+			Topic:     session.Topic,
+		}
+		if r := bySession[session.ID]; r != nil {
+			markedAt := r.MarkedAt
+			entry.Status = r.Status
+			entry.Source = r.Source
+			entry.MarkedAt = &markedAt
+		}
+		report.Sessions[i] = entry
+	}
+	return report, nil
+}
+
+// CourseReport summarises every student who holds a seat on the course
+// instance, flagging those below the attendance threshold.
+func (s *attendanceService) CourseReport(instanceID uuid.UUID) (*dto.CourseAttendanceReport, error) {
+	instance, err := s.getInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	held, err := s.heldSessions(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := s.enrollmentRepo.GetEnrollments(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list enrollments", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list enrollments", err)
+	}
+	records, err := s.attendanceRepo.ListRecordsByInstance(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list attendance records", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list attendance records", err)
+	}
+
+	// Section membership per student, only for the sections that had
+	// sessions.
+	memberSections := make(map[uuid.UUID]map[uuid.UUID]bool)
+	loaded := make(map[uuid.UUID]bool)
+	for _, session := range held {
+		if session.SectionID == nil || loaded[*session.SectionID] {
+			continue
+		}
+		sectionID := *session.SectionID
+		loaded[sectionID] = true
+		members, err := s.sectionRepo.ListMembers(sectionID)
+		if err != nil {
+			s.logger.Error("failed to list section members", zap.Error(err))
+			return nil, utils.ErrInternal("failed to list section members", err)
+		}
+		for _, m := range members {
+			if memberSections[m.UserID] == nil {
+				memberSections[m.UserID] = make(map[uuid.UUID]bool)
+			}
+			memberSections[m.UserID][sectionID] = true
+		}
+	}
+
+	statuses := make(map[uuid.UUID]map[uuid.UUID]string)
+	for _, r := range records {
+		if statuses[r.UserID] == nil {
+			statuses[r.UserID] = make(map[uuid.UUID]string)
+		}
+		statuses[r.UserID][r.SessionID] = r.Status
+	}
+
+	threshold := attendanceThreshold(instance, s.atRiskThreshold)
+	report := &dto.CourseAttendanceReport{
+		CourseInstanceID: instanceID,
+		Threshold:        threshold,
+		Sessions:         len(held),
+		Students:         []dto.StudentAttendanceSummary{},
+	}
+	for _, e := range enrollments {
+		if !domain.OccupiesSeat(e.Status) {
+			continue
+		}
+		summary := summarizeAttendance(sessionsFor(held, memberSections[e.UserID]), statuses[e.UserID], threshold)
+		if summary.AtRisk {
+			report.AtRiskCount++
+		}
+		report.Students = append(report.Students, dto.StudentAttendanceSummary{
+			UserID:            e.UserID,
+			AttendanceSummary: summary,
+		})
+	}
+	return report, nil
+}
+
+// heldSessions returns the sessions dated today or earlier; upcoming
+// sessions would otherwise show up as unmarked.
+func (s *attendanceService) heldSessions(instanceID uuid.UUID) ([]domain.AttendanceSession, error) {
+	sessions, err := s.attendanceRepo.ListSessions(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list attendance sessions", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list attendance sessions", err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	held := sessions[:0]
+	for _, session := range sessions {
+		if !session.Date.After(today) {
+			held = append(held, session)
+		}
+	}
+	return held, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *attendanceService) getInstance(instanceID uuid.UUID) (*domain.CourseInstance, error) {
+	instance, err := s.courseInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load course instance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load course instance", err)
+	}
+	if instance == nil {
+		return nil, utils.ErrNotFound("course instance not found")
+	}
+	return instance, nil
+}
+
+// getSession loads a session and checks it belongs to the course instance.
+func (s *attendanceService) getSession(instanceID, sessionID uuid.UUID) (*domain.AttendanceSession, error) {
+	session, err := s.attendanceRepo.GetSession(sessionID)
+	if err != nil {
+		s.logger.Error("failed to load attendance session", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load attendance session", err)
+	}
+	if session == nil || session.CourseInstanceID != instanceID {
+		return nil, utils.ErrNotFound("attendance session not found")
+	}
+	return session, nil
+}
+
+// roster returns the students whose attendance can be taken at a session:
+// the section's members for a section session, otherwise everyone holding a
+// seat on the course instance.
+func (s *attendanceService) roster(session *domain.AttendanceSession) (map[uuid.UUID]bool, error) {
+	roster := make(map[uuid.UUID]bool)
+	if session.SectionID != nil {
+		members, err := s.sectionRepo.ListMembers(*session.SectionID)
+		if err != nil {
+			s.logger.Error("failed to list section members", zap.Error(err))
+			return nil, utils.ErrInternal("failed to list section members", err)
+		}
+		for _, m := range members {
+			roster[m.UserID] = true
+		}
+		return roster, nil
+	}
+
+	enrollments, err := s.enrollmentRepo.GetEnrollments(session.CourseInstanceID)
+	if err != nil {
+		s.logger.Error("failed to list enrollments", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list enrollments", err)
+	}
+	for _, e := range enrollments {
+		if domain.OccupiesSeat(e.Status) {
+			roster[e.UserID] = true
+		}
+	}
+	return roster, nil
+}
+
+func (s *attendanceService) audit(
+	action client.AuditAction,
+	entityID uuid.UUID,
+	changes map[string]interface{},
+	username, ipAddress, userAgent string,
+) {
+	if auditErr := s.auditClient.LogAction(
+		string(action),
+		"attendance_session",
+		entityID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+}
+
+func parseSessionDate(raw string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, strings.TrimSpace(raw))
+	if err != nil {
+		return time.Time{}, utils.ErrBadRequest("date must be in YYYY-MM-DD format")
+	}
+	return date, nil
+}
+
+// sortedIDs returns the keys of set in a stable order.
+func sortedIDs(set map[uuid.UUID]bool) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
+
+func toAttendanceSessionResponse(session *domain.AttendanceSession, now time.Time) dto.AttendanceSessionResponse {
+	resp := dto.AttendanceSessionResponse{
+		ID:               session.ID,
+		CourseInstanceID: session.CourseInstanceID,
+		SectionID:        session.SectionID,
+		Date:             session.Date.Format(time.DateOnly),
+		Type:             session.Type,
+		Topic:            session.Topic,
+		CheckInOpen:      session.CheckInOpen(now),
+		CreatedBy:        session.CreatedBy,
+		CreatedAt:        session.CreatedAt,
+		UpdatedAt:        session.UpdatedAt,
+	}
+	if resp.CheckInOpen {
+		resp.CheckInOpenUntil = session.CheckInOpenUntil
+	}
+	return resp
+}
+
+func toAttendanceRecordResponse(record *domain.AttendanceRecord) dto.AttendanceRecordResponse {
+	return dto.AttendanceRecordResponse{
+		UserID:   record.UserID,
+		Status:   record.Status,
+		Source:   record.Source,
+		Note:     record.Note,
+		MarkedBy: record.MarkedBy,
+		MarkedAt: record.MarkedAt,
+	}
+}
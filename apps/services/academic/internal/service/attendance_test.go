@@ -0,0 +1,122 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInCode(t *testing.T) {
+	secret, err := newCheckInSecret()
+	require.NoError(t, err)
+
+	rotation := 30 * time.Second
+	now := time.Unix(1_700_000_000, 0)
+	counter, expiresAt := checkInWindow(now, rotation)
+	assert.Equal(t, time.Unix(1_700_000_010, 0).UTC(), expiresAt)
+
+	code := checkInCode(secret, counter)
+	assert.Len(t, code, 6)
+	assert.Equal(t, code, checkInCode(secret, counter), "codes are deterministic")
+
+	assert.True(t, matchCheckInCode(secret, code, now, rotation))
+	assert.True(t, matchCheckInCode(secret, code, now.Add(rotation), rotation), "previous window still accepted")
+	assert.False(t, matchCheckInCode(secret, code, now.Add(2*rotation), rotation), "older windows rejected")
+	assert.False(t, matchCheckInCode("other-secret", code, now, rotation))
+}
+
+func TestSummarizeAttendance(t *testing.T) {
+	sessions := make([]domain.AttendanceSession, 6)
+	for i := range sessions {
+		sessions[i].ID = uuid.New()
+	}
+	statuses := map[uuid.UUID]string{
+		sessions[0].ID: domain.AttendanceStatusPresent,
+		sessions[1].ID: domain.AttendanceStatusLate,
+		sessions[2].ID: domain.AttendanceStatusAbsent,
+		sessions[3].ID: domain.AttendanceStatusExcused,
+		sessions[4].ID: domain.AttendanceStatusPresent,
+		// sessions[5] unmarked
+	}
+
+	summary := summarizeAttendance(sessions, statuses, 80)
+	assert.Equal(t, 6, summary.Sessions)
+	assert.Equal(t, 2, summary.Present)
+	assert.Equal(t, 1, summary.Late)
+	assert.Equal(t, 1, summary.Absent)
+	assert.Equal(t, 1, summary.Excused)
+	assert.Equal(t, 1, summary.Unmarked)
+	require.NotNil(t, summary.Rate)
+	assert.Equal(t, 75.0, *summary.Rate)
+	assert.True(t, summary.AtRisk)
+
+	assert.False(t, summarizeAttendance(sessions, statuses, 75).AtRisk, "at the threshold is not at risk")
+
+	// Nothing counted yet: no rate and no flag.
+	summary = summarizeAttendance(sessions[3:4], statuses, 80)
+	assert.Nil(t, summary.Rate)
+	assert.False(t, summary.AtRisk)
+}
+
+func TestSessionsFor(t *testing.T) {
+	labA, labB := uuid.New(), uuid.New()
+	sessions := []domain.AttendanceSession{
+		{ID: uuid.New()},
+		{ID: uuid.New(), SectionID: &labA},
+		{ID: uuid.New(), SectionID: &labB},
+	}
+
+	got := sessionsFor(sessions, map[uuid.UUID]bool{labA: true})
+	require.Len(t, got, 2)
+	assert.Equal(t, sessions[0].ID, got[0].ID)
+	assert.Equal(t, sessions[1].ID, got[1].ID)
+}
+
+func TestAttendanceThreshold(t *testing.T) {
+	override := 60.0
+	assert.Equal(t, 75.0, attendanceThreshold(&domain.CourseInstance{}, 75))
+	assert.Equal(t, 60.0, attendanceThreshold(&domain.CourseInstance{AttendanceThreshold: &override}, 75))
+}
+
+func TestCheckInLimiter(t *testing.T) {
+	l := newCheckInLimiter()
+	now := time.Unix(1_700_000_000, 0)
+
+	for i := 0; i < maxCheckInFailures; i++ {
+		assert.False(t, l.blocked("a", now), "attempt %d", i+1)
+		l.fail("a", now)
+	}
+	assert.True(t, l.blocked("a", now))
+	assert.False(t, l.blocked("b", now), "keys are limited independently")
+	assert.False(t, l.blocked("a", now.Add(checkInLockout)), "lockout expires")
+
+	l.fail("a", now.Add(checkInLockout))
+	assert.False(t, l.blocked("a", now.Add(checkInLockout)), "a new window starts after the lockout")
+
+	for i := 0; i < maxCheckInFailures; i++ {
+		l.fail("c", now)
+	}
+	l.reset("c")
+	assert.False(t, l.blocked("c", now), "a successful check-in clears the failures")
+}
+
+func TestCheckInRecorded(t *testing.T) {
+	for status, want := range map[string]string{
+		domain.AttendanceStatusPresent: "you are already checked in to this session",
+		domain.AttendanceStatusLate:    "you are already checked in to this session",
+		domain.AttendanceStatusAbsent:  "your attendance for this session has already been recorded as absent",
+		domain.AttendanceStatusExcused: "your attendance for this session has already been recorded as excused",
+	} {
+		err := checkInRecorded(&domain.AttendanceRecord{Status: status, Source: domain.AttendanceSourceStaff})
+		var appErr *utils.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+		assert.Equal(t, want, appErr.Message, status)
+	}
+}
@@ -17,9 +17,12 @@ type BatchMemberService interface {
 	AddBatchMember(req *dto.AddBatchMemberRequest, username, ipAddress, userAgent string) (*domain.BatchMember, error)
 	AddMembersToBatch(req *dto.BulkAddBatchMembersRequest, username, ipAddress, userAgent string) error
 	GetBatchMembers(batchID uuid.UUID) ([]domain.BatchMember, error)
+	GetSubtreeMemberIDs(batchID uuid.UUID) ([]uuid.UUID, error)
 	GetBatchMembersDetailed(ctx context.Context, batchID uuid.UUID, token string) ([]dto.BatchMemberDetailResponse, error)
 	IsMember(batchID, userID uuid.UUID) (bool, error)
 	RemoveBatchMember(batchID, userID uuid.UUID, username, ipAddress, userAgent string) error
+	MoveBatchMember(req *dto.MoveBatchMemberRequest, username, ipAddress, userAgent string) (*dto.MoveBatchMemberResponse, error)
+	ListGraduatedUsers(maxEndYear int) ([]repository.GraduatedUser, error)
 }
 
 // batchMemberService is the concrete implementation.
@@ -29,6 +32,7 @@ type batchMemberService struct {
 	enrollmentService EnrollmentService
 	auditClient       *client.AuditClient
 	iamClient         *client.IAMClient
+	movePolicy        string
 	logger            *zap.Logger
 }
 
@@ -39,6 +43,7 @@ func NewBatchMemberService(
 	enrollmentService EnrollmentService,
 	auditClient *client.AuditClient,
 	iamClient *client.IAMClient,
+	movePolicy string,
 	logger *zap.Logger,
 ) BatchMemberService {
 	return &batchMemberService{
@@ -47,6 +52,7 @@ func NewBatchMemberService(
 		enrollmentService: enrollmentService,
 		auditClient:       auditClient,
 		iamClient:         iamClient,
+		movePolicy:        movePolicy,
 		logger:            logger,
 	}
 }
@@ -105,7 +111,7 @@ func (s *batchMemberService) AddBatchMember(
 		Status:  req.Status,
 	}
 
-	if err := s.batchMemberRepo.AddMember(member); err != nil {
+	if err := s.batchMemberRepo.AddMember(member, username); err != nil {
 		s.logger.Error("failed to add batch member", zap.Error(err))
 		return nil, utils.ErrInternal("failed to add batch member", err)
 	}
@@ -182,7 +188,7 @@ func (s *batchMemberService) AddMembersToBatch(
 	}
 
 	// 3. Persist
-	if err := s.batchMemberRepo.AddMembers(members); err != nil {
+	if err := s.batchMemberRepo.AddMembers(members, username); err != nil {
 		s.logger.Error("failed to add members in bulk", zap.Error(err))
 		return utils.ErrInternal("failed to add batch members", err)
 	}
@@ -241,6 +247,26 @@ func (s *batchMemberService) GetBatchMembers(batchID uuid.UUID) ([]domain.BatchM
 	return members, nil
 }
 
+// GetSubtreeMemberIDs returns the distinct user IDs of the members of a batch
+// and all its descendant batches.
+func (s *batchMemberService) GetSubtreeMemberIDs(batchID uuid.UUID) ([]uuid.UUID, error) {
+	batch, err := s.batchRepo.GetBatchByID(batchID)
+	if err != nil {
+		s.logger.Error("failed to load batch", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load batch", err)
+	}
+	if batch == nil {
+		return nil, utils.ErrNotFound("batch not found")
+	}
+
+	userIDs, err := s.batchMemberRepo.GetMembersBySubtree(batchID)
+	if err != nil {
+		s.logger.Error("failed to list batch subtree members", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list batch subtree members", err)
+	}
+	return userIDs, nil
+}
+
 // GetBatchMembersDetailed fetches members with their details from IAM
 func (s *batchMemberService) GetBatchMembersDetailed(ctx context.Context, batchID uuid.UUID, token string) ([]dto.BatchMemberDetailResponse, error) {
 	members, err := s.GetBatchMembers(batchID)
@@ -321,7 +347,7 @@ func (s *batchMemberService) RemoveBatchMember(
 		return utils.ErrNotFound("batch member not found")
 	}
 
-	if err := s.batchMemberRepo.RemoveMember(batchID, userID); err != nil {
+	if err := s.batchMemberRepo.RemoveMember(batchID, userID, username); err != nil {
 		s.logger.Error("failed to remove batch member", zap.Error(err))
 		return utils.ErrInternal("failed to remove batch member", err)
 	}
@@ -351,3 +377,138 @@ func (s *batchMemberService) RemoveBatchMember(
 	)
 	return nil
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// MoveBatchMember
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MoveBatchMember moves a student from one batch to another and applies the
+// move policy to their course enrollments.
+func (s *batchMemberService) MoveBatchMember(
+	req *dto.MoveBatchMemberRequest,
+	username, ipAddress, userAgent string,
+) (*dto.MoveBatchMemberResponse, error) {
+	// 1. Validate request
+	if req.UserID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+	if req.FromBatchID == uuid.Nil || req.ToBatchID == uuid.Nil {
+		return nil, utils.ErrBadRequest("from_batch_id and to_batch_id are required")
+	}
+	if req.FromBatchID == req.ToBatchID {
+		return nil, utils.ErrBadRequest("from_batch_id and to_batch_id must differ")
+	}
+	policy := req.Policy
+	if policy == "" {
+		policy = s.movePolicy
+	}
+	if !domain.IsValidBatchMovePolicy(policy) {
+		return nil, utils.ErrBadRequest("invalid policy: allowed values are transfer, drop, keep")
+	}
+
+	// 2. Validate the target batch and both memberships
+	target, err := s.batchRepo.GetBatchByID(req.ToBatchID)
+	if err != nil {
+		s.logger.Error("failed to load batch", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load batch", err)
+	}
+	if target == nil {
+		return nil, utils.ErrNotFound("target batch not found")
+	}
+	if !target.IsActive {
+		return nil, utils.ErrBadRequest("target batch is not active")
+	}
+
+	existing, err := s.batchMemberRepo.GetMember(req.ToBatchID, req.UserID)
+	if err != nil {
+		s.logger.Error("failed to check existing membership", zap.Error(err))
+		return nil, utils.ErrInternal("failed to check existing membership", err)
+	}
+	if existing != nil {
+		return nil, utils.ErrConflict("user is already a member of the target batch")
+	}
+
+	// 3. Move the membership
+	member, err := s.batchMemberRepo.MoveMember(req.FromBatchID, req.ToBatchID, req.UserID, username)
+	if err != nil {
+		s.logger.Error("failed to move batch member", zap.Error(err))
+		return nil, utils.ErrInternal("failed to move batch member", err)
+	}
+	if member == nil {
+		return nil, utils.ErrNotFound("batch member not found")
+	}
+
+	// 4. Apply the policy to the student's enrollments. The move has
+	// committed, so a failure here is reported alongside it rather than as a
+	// failed request.
+	resp := &dto.MoveBatchMemberResponse{
+		UserID:      req.UserID,
+		FromBatchID: req.FromBatchID,
+		ToBatchID:   req.ToBatchID,
+		Policy:      policy,
+		Courses:     []dto.BatchMoveCourseOutcome{},
+	}
+	outcomes, err := s.enrollmentService.TransferBatchEnrollments(
+		req.UserID, req.FromBatchID, req.ToBatchID, policy, username, ipAddress, userAgent,
+	)
+	if err != nil {
+		s.logger.Error("batch member moved but enrollments were not transferred",
+			zap.String("user_id", req.UserID.String()),
+			zap.Error(err),
+		)
+		resp.EnrollmentError = err.Error()
+	} else {
+		resp.Courses = outcomes
+	}
+
+	// 5. Audit log
+	changes := map[string]interface{}{
+		"user_id":       req.UserID.String(),
+		"from_batch_id": req.FromBatchID.String(),
+		"to_batch_id":   req.ToBatchID.String(),
+		"policy":        policy,
+		"courses":       resp.Courses,
+	}
+	if resp.EnrollmentError != "" {
+		changes["enrollment_error"] = resp.EnrollmentError
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionBatchMemberMoved),
+		"batch_member",
+		req.ToBatchID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	s.logger.Info("batch member moved",
+		zap.String("user_id", req.UserID.String()),
+		zap.String("from_batch_id", req.FromBatchID.String()),
+		zap.String("to_batch_id", req.ToBatchID.String()),
+		zap.String("policy", policy),
+	)
+
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ListGraduatedUsers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *batchMemberService) ListGraduatedUsers(maxEndYear int) ([]repository.GraduatedUser, error) {
+	if maxEndYear <= 0 {
+		return nil, utils.ErrBadRequest("max_end_year must be a positive year")
+	}
+
+	users, err := s.batchMemberRepo.ListGraduatedUsers(maxEndYear)
+	if err != nil {
+		s.logger.Error("failed to list graduated users", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list graduated users", err)
+	}
+	return users, nil
+}
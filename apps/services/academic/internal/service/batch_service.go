@@ -6,6 +6,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -18,6 +19,7 @@ type BatchService interface {
 	DeactivateBatch(id uuid.UUID, username, ipAddress, userAgent string) error
 	GetBatchByID(id uuid.UUID) (*domain.Batch, error)
 	ListBatches(includeInactive bool) ([]domain.Batch, error)
+	ListBatchesPage(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Batch], error)
 	GetBatchTree(includeInactive bool) ([]dto.BatchTreeResponse, error)
 	GetBatchSubtree(id uuid.UUID, includeInactive bool) (*dto.BatchTreeResponse, error)
 }
@@ -386,6 +388,15 @@ func (s *batchService) ListBatches(includeInactive bool) ([]domain.Batch, error)
 	return batches, nil
 }
 
+func (s *batchService) ListBatchesPage(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Batch], error) {
+	page, err := s.batchRepo.ListBatchesPage(includeInactive, spec)
+	if err != nil {
+		s.logger.Error("failed to list batches", zap.Error(err))
+		return queryspec.Page[domain.Batch]{}, utils.ErrInternal("failed to list batches", err)
+	}
+	return page, nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // GetBatchTree  — full hierarchy (all root nodes + descendants)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -107,6 +107,8 @@ func (s *courseInstanceService) CreateCourseInstance(
 		BatchID:       req.BatchID,
 		Status:        req.Status,
 		MaxEnrollment: req.MaxEnrollment,
+
+		IncludeSubBatches: req.IncludeSubBatches,
 	}
 
 	if err := s.courseInstanceRepo.Create(instance); err != nil {
@@ -116,11 +118,12 @@ func (s *courseInstanceService) CreateCourseInstance(
 
 	// 6. Write audit log
 	changes := map[string]interface{}{
-		"course_id":      req.CourseID.String(),
-		"semester_id":    req.SemesterID.String(),
-		"batch_id":       req.BatchID.String(),
-		"status":         req.Status,
-		"max_enrollment": req.MaxEnrollment,
+		"course_id":           req.CourseID.String(),
+		"semester_id":         req.SemesterID.String(),
+		"batch_id":            req.BatchID.String(),
+		"status":              req.Status,
+		"max_enrollment":      req.MaxEnrollment,
+		"include_sub_batches": req.IncludeSubBatches,
 	}
 	if auditErr := s.auditClient.LogAction(
 		string(client.AuditActionCourseInstanceCreated),
@@ -142,7 +145,7 @@ func (s *courseInstanceService) CreateCourseInstance(
 	)
 
 	// 7. Auto-enroll batch members
-	if err := s.enrollmentService.AutoEnrollBatchMembers(instance.ID, req.BatchID, username, ipAddress, userAgent); err != nil {
+	if err := s.enrollmentService.AutoEnrollBatchMembers(instance, username, ipAddress, userAgent); err != nil {
 		s.logger.Warn("failed to auto-enroll batch members", zap.Error(err), zap.String("instance_id", instance.ID.String()))
 	}
 
@@ -168,6 +171,9 @@ func (s *courseInstanceService) UpdateCourseInstance(
 	if req.MaxEnrollment != nil && *req.MaxEnrollment < 0 {
 		return nil, utils.ErrBadRequest("max_enrollment must be a non-negative integer")
 	}
+	if req.AttendanceThreshold != nil && (*req.AttendanceThreshold < 0 || *req.AttendanceThreshold > 100) {
+		return nil, utils.ErrBadRequest("attendance_threshold must be between 0 and 100")
+	}
 
 	// 2. Load existing instance
 	instance, err := s.courseInstanceRepo.GetByID(id)
@@ -179,6 +185,11 @@ func (s *courseInstanceService) UpdateCourseInstance(
 		return nil, utils.ErrNotFound("course instance not found")
 	}
 
+	// Cancelling needs the approval of the faculty's leadership.
+	if req.Status == domain.CourseInstanceStatusCancelled && instance.Status != req.Status && !req.CancellationApproved {
+		return nil, utils.ErrUnprocessable("cancelling a course instance needs approval; submit a cancellation request")
+	}
+
 	// 3. Apply changes
 	changes := make(map[string]interface{})
 
@@ -186,16 +197,49 @@ func (s *courseInstanceService) UpdateCourseInstance(
 	instance.Status = req.Status
 	changes["status"] = map[string]string{"from": oldStatus, "to": req.Status}
 
+	capacityRaised := false
 	if req.MaxEnrollment != nil && *req.MaxEnrollment != instance.MaxEnrollment {
 		changes["max_enrollment"] = map[string]int{"from": instance.MaxEnrollment, "to": *req.MaxEnrollment}
+		capacityRaised = *req.MaxEnrollment == 0 || *req.MaxEnrollment > instance.MaxEnrollment
 		instance.MaxEnrollment = *req.MaxEnrollment
 	}
 
+	subBatchesAdded := false
+	if req.IncludeSubBatches != nil && *req.IncludeSubBatches != instance.IncludeSubBatches {
+		changes["include_sub_batches"] = map[string]bool{"from": instance.IncludeSubBatches, "to": *req.IncludeSubBatches}
+		subBatchesAdded = *req.IncludeSubBatches
+		instance.IncludeSubBatches = *req.IncludeSubBatches
+	}
+
+	if req.AttendanceThreshold != nil {
+		changes["attendance_threshold"] = *req.AttendanceThreshold
+		instance.AttendanceThreshold = nil
+		if *req.AttendanceThreshold > 0 {
+			instance.AttendanceThreshold = req.AttendanceThreshold
+		}
+	}
+
 	if err := s.courseInstanceRepo.Update(instance); err != nil {
 		s.logger.Error("failed to update course instance", zap.Error(err))
 		return nil, utils.ErrInternal("failed to update course instance", err)
 	}
 
+	// New seats go to waitlisted students first. The update itself has
+	// succeeded, so a failed promotion is only logged.
+	if capacityRaised {
+		if err := s.enrollmentService.PromoteWaitlisted(instance.ID, username, ipAddress, userAgent); err != nil {
+			s.logger.Warn("failed to promote waitlisted students", zap.Error(err))
+		}
+	}
+
+	// Members of descendant batches are enrolled once the instance starts
+	// including them. Turning the flag off leaves existing enrollments alone.
+	if subBatchesAdded {
+		if err := s.enrollmentService.AutoEnrollBatchMembers(instance, username, ipAddress, userAgent); err != nil {
+			s.logger.Warn("failed to auto-enroll sub-batch members", zap.Error(err), zap.String("instance_id", instance.ID.String()))
+		}
+	}
+
 	// 4. Write audit log
 	if auditErr := s.auditClient.LogAction(
 		string(client.AuditActionCourseInstanceUpdated),
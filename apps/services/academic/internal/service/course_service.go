@@ -1,11 +1,14 @@
 package service
 
 import (
+	"strings"
+
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -16,7 +19,7 @@ type CourseService interface {
 	UpdateCourse(id uuid.UUID, req *dto.UpdateCourseRequest, username, ipAddress, userAgent string) (*domain.Course, error)
 	DeactivateCourse(id uuid.UUID, username, ipAddress, userAgent string) error
 	GetCourse(id uuid.UUID) (*domain.Course, error)
-	ListCourses(includeInactive bool) ([]domain.Course, error)
+	ListCourses(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Course], error)
 
 	// Prerequisite management
 	AddPrerequisite(courseID uuid.UUID, req *dto.AddPrerequisiteRequest, username, ipAddress, userAgent string) (*domain.CoursePrerequisite, error)
@@ -239,13 +242,13 @@ func (s *courseService) GetCourse(id uuid.UUID) (*domain.Course, error) {
 // ListCourses
 // ─────────────────────────────────────────────────────────────────────────────
 
-func (s *courseService) ListCourses(includeInactive bool) ([]domain.Course, error) {
-	courses, err := s.courseRepo.List(includeInactive)
+func (s *courseService) ListCourses(includeInactive bool, spec queryspec.Spec) (queryspec.Page[domain.Course], error) {
+	page, err := s.courseRepo.List(includeInactive, spec)
 	if err != nil {
 		s.logger.Error("failed to list courses", zap.Error(err))
-		return nil, utils.ErrInternal("failed to list courses", err)
+		return queryspec.Page[domain.Course]{}, utils.ErrInternal("failed to list courses", err)
 	}
-	return courses, nil
+	return page, nil
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -263,6 +266,19 @@ func (s *courseService) AddPrerequisite(
 	if courseID == req.PrerequisiteCourseID {
 		return nil, utils.ErrBadRequest("a course cannot be a prerequisite of itself")
 	}
+	if req.Kind == "" {
+		req.Kind = domain.PrerequisiteKindPrerequisite
+	}
+	if !domain.IsValidPrerequisiteKind(req.Kind) {
+		return nil, utils.ErrBadRequest("invalid kind: allowed values are prerequisite, corequisite")
+	}
+	if req.Group < 0 {
+		return nil, utils.ErrBadRequest("group must not be negative")
+	}
+	req.MinGrade = strings.ToUpper(strings.TrimSpace(req.MinGrade))
+	if req.MinGrade != "" && !domain.IsValidLetterGrade(req.MinGrade) {
+		return nil, utils.ErrBadRequest("invalid min_grade: must be a letter grade such as C or B+")
+	}
 
 	// Verify the parent course exists
 	course, err := s.courseRepo.GetByID(courseID)
@@ -297,6 +313,14 @@ func (s *courseService) AddPrerequisite(
 	prereq := &domain.CoursePrerequisite{
 		CourseID:             courseID,
 		PrerequisiteCourseID: req.PrerequisiteCourseID,
+		Kind:                 req.Kind,
+		Group:                req.Group,
+		MinGrade:             req.MinGrade,
+	}
+
+	// Reject rules that would make a course (indirectly) require itself
+	if err := s.checkPrerequisiteCycle(prereq); err != nil {
+		return nil, err
 	}
 
 	if err := s.courseRepo.AddPrerequisite(prereq); err != nil {
@@ -307,6 +331,9 @@ func (s *courseService) AddPrerequisite(
 	changes := map[string]interface{}{
 		"course_id":              courseID.String(),
 		"prerequisite_course_id": req.PrerequisiteCourseID.String(),
+		"kind":                   prereq.Kind,
+		"group":                  prereq.Group,
+		"min_grade":              prereq.MinGrade,
 	}
 	if auditErr := s.auditClient.LogAction(
 		string(client.AuditActionCoursePrerequisiteAdded),
@@ -330,6 +357,30 @@ func (s *courseService) AddPrerequisite(
 	return prereq, nil
 }
 
+// checkPrerequisiteCycle returns a conflict naming the courses involved when
+// the rule would close a cycle in the prerequisite graph.
+func (s *courseService) checkPrerequisiteCycle(rule *domain.CoursePrerequisite) error {
+	rules, err := s.courseRepo.ListAllPrerequisites()
+	if err != nil {
+		s.logger.Error("failed to load prerequisite graph", zap.Error(err))
+		return utils.ErrInternal("failed to load prerequisite graph", err)
+	}
+
+	cycle := findPrerequisiteCycle(rules, *rule)
+	if cycle == nil {
+		return nil
+	}
+
+	codes := make([]string, len(cycle))
+	for i, id := range cycle {
+		codes[i] = id.String()
+		if course, err := s.courseRepo.GetByID(id); err == nil && course != nil {
+			codes[i] = course.Code
+		}
+	}
+	return utils.ErrConflict("prerequisite would create a cycle: " + strings.Join(codes, " → "))
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // RemovePrerequisite
 // ─────────────────────────────────────────────────────────────────────────────
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/google/uuid"
+)
+
+// Course standings within a degree audit.
+const (
+	auditCourseCompleted  = "completed"
+	auditCourseInProgress = "in_progress"
+	auditCourseMissing    = "missing"
+)
+
+// Requirement outcomes within a degree audit, from best to worst.
+const (
+	auditSatisfied = "satisfied"
+	auditPending   = "pending"
+	auditMissing   = "missing"
+)
+
+// validateDegreeRequirement checks a rule before it is saved. Course
+// existence is checked by the caller.
+func validateDegreeRequirement(req *dto.DegreeRequirementRequest) error {
+	req.Kind = strings.TrimSpace(req.Kind)
+	req.Name = strings.TrimSpace(req.Name)
+	req.MinGrade = strings.ToUpper(strings.TrimSpace(req.MinGrade))
+
+	if !domain.IsValidRequirementKind(req.Kind) {
+		return fmt.Errorf("kind must be one of %s, %s or %s",
+			domain.RequirementKindRequiredCourses, domain.RequirementKindElectivePool, domain.RequirementKindTotalCredits)
+	}
+	if req.Name == "" || len(req.Name) > 255 {
+		return fmt.Errorf("name must be between 1 and 255 characters")
+	}
+	if req.MinGrade != "" && !domain.IsValidLetterGrade(req.MinGrade) {
+		return fmt.Errorf("%q is not a valid letter grade", req.MinGrade)
+	}
+	if req.MinCredits < 0 {
+		return fmt.Errorf("min_credits must not be negative")
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(req.CourseIDs))
+	for _, id := range req.CourseIDs {
+		if _, dup := seen[id]; dup {
+			return fmt.Errorf("course %s is listed more than once", id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	switch req.Kind {
+	case domain.RequirementKindRequiredCourses:
+		if len(req.CourseIDs) == 0 {
+			return fmt.Errorf("a required_courses rule must list at least one course")
+		}
+	case domain.RequirementKindElectivePool:
+		if len(req.CourseIDs) == 0 {
+			return fmt.Errorf("an elective_pool rule must list at least one course")
+		}
+		if req.MinCredits == 0 {
+			return fmt.Errorf("an elective_pool rule needs min_credits")
+		}
+	case domain.RequirementKindTotalCredits:
+		if len(req.CourseIDs) > 0 {
+			return fmt.Errorf("a total_credits rule cannot list courses")
+		}
+		if req.MinCredits == 0 {
+			return fmt.Errorf("a total_credits rule needs min_credits")
+		}
+	}
+	return nil
+}
+
+// requirementsFor keeps the rules that apply to a student of the given
+// specialization: the degree-wide rules and those of the specialization.
+func requirementsFor(rules []domain.DegreeRequirement, specializationID *uuid.UUID) []domain.DegreeRequirement {
+	var out []domain.DegreeRequirement
+	for _, r := range rules {
+		if r.SpecializationID == nil || (specializationID != nil && *r.SpecializationID == *specializationID) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// courseProgress collects a student's attempts at one course.
+type courseProgress struct {
+	grades     []string // final grades of Completed attempts
+	inProgress bool     // an Enrolled attempt exists
+}
+
+// indexProgress groups the enrollment history by course. Dropped, failed
+// and waitlisted attempts do not count towards a degree.
+func indexProgress(history []domain.Enrollment) map[uuid.UUID]*courseProgress {
+	progress := make(map[uuid.UUID]*courseProgress)
+	for _, e := range history {
+		if e.CourseInstance == nil {
+			continue
+		}
+		if e.Status != domain.EnrollmentStatusCompleted && e.Status != domain.EnrollmentStatusEnrolled {
+			continue
+		}
+		p, ok := progress[e.CourseInstance.CourseID]
+		if !ok {
+			p = &courseProgress{}
+			progress[e.CourseInstance.CourseID] = p
+		}
+		if e.Status == domain.EnrollmentStatusCompleted {
+			p.grades = append(p.grades, e.FinalGrade)
+		} else {
+			p.inProgress = true
+		}
+	}
+	return progress
+}
+
+// standing returns where the course stands against minGrade, along with the
+// best completed grade. A completed attempt below minGrade leaves the course
+// in progress when it is being retaken, and missing otherwise.
+func (p *courseProgress) standing(minGrade string) (string, string) {
+	if p == nil {
+		return auditCourseMissing, ""
+	}
+	best, met := "", false
+	for _, g := range p.grades {
+		if best == "" || domain.MeetsMinimumGrade(g, best) {
+			best = g
+		}
+		if domain.MeetsMinimumGrade(g, minGrade) {
+			met = true
+		}
+	}
+	switch {
+	case met:
+		return auditCourseCompleted, best
+	case p.inProgress:
+		return auditCourseInProgress, best
+	default:
+		return auditCourseMissing, best
+	}
+}
+
+// auditDegree evaluates the rules against the enrollment history. courses
+// supplies codes and credits for every course in the rules and the history.
+// It returns each rule's outcome along with the credits completed and in
+// progress across all courses.
+func auditDegree(
+	rules []domain.DegreeRequirement,
+	history []domain.Enrollment,
+	courses map[uuid.UUID]*domain.Course,
+) ([]dto.RequirementAuditResponse, int, int) {
+	progress := indexProgress(history)
+
+	auditCourse := func(courseID uuid.UUID, minGrade string) dto.AuditCourseResponse {
+		status, grade := progress[courseID].standing(minGrade)
+		ac := dto.AuditCourseResponse{CourseID: courseID, CourseCode: courseID.String(), Status: status, FinalGrade: grade}
+		if c := courses[courseID]; c != nil {
+			ac.CourseCode = c.Code
+			ac.Credits = c.Credits
+		}
+		return ac
+	}
+
+	// Courses named by a required_courses rule never count towards a pool.
+	reserved := make(map[uuid.UUID]struct{})
+	for _, r := range rules {
+		if r.Kind == domain.RequirementKindRequiredCourses {
+			for _, c := range r.Courses {
+				reserved[c.CourseID] = struct{}{}
+			}
+		}
+	}
+	pools := allocateElectives(rules, reserved, auditCourse)
+
+	audits := make([]dto.RequirementAuditResponse, 0, len(rules))
+	for _, r := range rules {
+		ra := dto.RequirementAuditResponse{
+			RequirementID: r.ID,
+			Kind:          r.Kind,
+			Name:          r.Name,
+			MinCredits:    r.MinCredits,
+		}
+
+		switch r.Kind {
+		case domain.RequirementKindRequiredCourses:
+			ra.Status = auditSatisfied
+			for _, c := range r.Courses {
+				ac := auditCourse(c.CourseID, r.MinGrade)
+				ra.Courses = append(ra.Courses, ac)
+				switch ac.Status {
+				case auditCourseCompleted:
+					ra.CreditsCompleted += ac.Credits
+				case auditCourseInProgress:
+					ra.CreditsInProgress += ac.Credits
+					ra.Status = worseStatus(ra.Status, auditPending)
+				default:
+					ra.Status = auditMissing
+				}
+			}
+
+		case domain.RequirementKindElectivePool:
+			ra.Courses = pools[r.ID]
+			for _, ac := range ra.Courses {
+				if ac.Status == auditCourseCompleted {
+					ra.CreditsCompleted += ac.Credits
+				} else {
+					ra.CreditsInProgress += ac.Credits
+				}
+			}
+			ra.Status = creditStatus(ra.CreditsCompleted, ra.CreditsInProgress, r.MinCredits)
+
+		case domain.RequirementKindTotalCredits:
+			ra.CreditsCompleted, ra.CreditsInProgress = totalCredits(progress, r.MinGrade, auditCourse)
+			ra.Status = creditStatus(ra.CreditsCompleted, ra.CreditsInProgress, r.MinCredits)
+		}
+
+		audits = append(audits, ra)
+	}
+
+	completed, inProgress := totalCredits(progress, "", auditCourse)
+	return audits, completed, inProgress
+}
+
+// allocateElectives assigns courses to elective pools so that each course
+// counts towards at most one pool. Completed courses are placed first, each
+// in the first pool listing it that still needs credits; in-progress courses
+// then fill whatever remains.
+func allocateElectives(
+	rules []domain.DegreeRequirement,
+	reserved map[uuid.UUID]struct{},
+	auditCourse func(uuid.UUID, string) dto.AuditCourseResponse,
+) map[uuid.UUID][]dto.AuditCourseResponse {
+	pools := make(map[uuid.UUID][]dto.AuditCourseResponse)
+	credits := make(map[uuid.UUID]int)
+	used := make(map[uuid.UUID]struct{})
+
+	for _, pass := range []string{auditCourseCompleted, auditCourseInProgress} {
+		for _, r := range rules {
+			if r.Kind != domain.RequirementKindElectivePool {
+				continue
+			}
+			for _, c := range r.Courses {
+				if credits[r.ID] >= r.MinCredits {
+					break
+				}
+				if _, ok := reserved[c.CourseID]; ok {
+					continue
+				}
+				if _, ok := used[c.CourseID]; ok {
+					continue
+				}
+				ac := auditCourse(c.CourseID, r.MinGrade)
+				if ac.Status != pass {
+					continue
+				}
+				used[c.CourseID] = struct{}{}
+				pools[r.ID] = append(pools[r.ID], ac)
+				credits[r.ID] += ac.Credits
+			}
+		}
+	}
+	return pools
+}
+
+// totalCredits sums the credits of every course completed with at least
+// minGrade, and of the courses still in progress.
+func totalCredits(
+	progress map[uuid.UUID]*courseProgress,
+	minGrade string,
+	auditCourse func(uuid.UUID, string) dto.AuditCourseResponse,
+) (int, int) {
+	completed, inProgress := 0, 0
+	for courseID := range progress {
+		ac := auditCourse(courseID, minGrade)
+		switch ac.Status {
+		case auditCourseCompleted:
+			completed += ac.Credits
+		case auditCourseInProgress:
+			inProgress += ac.Credits
+		}
+	}
+	return completed, inProgress
+}
+
+// creditStatus grades a credit minimum: satisfied by completed credits,
+// pending when the in-progress credits would make up the rest.
+func creditStatus(completed, inProgress, min int) string {
+	switch {
+	case completed >= min:
+		return auditSatisfied
+	case completed+inProgress >= min:
+		return auditPending
+	default:
+		return auditMissing
+	}
+}
+
+// worseStatus returns the worse of two requirement outcomes.
+func worseStatus(a, b string) string {
+	rank := map[string]int{auditSatisfied: 0, auditPending: 1, auditMissing: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// overallStatus is the worst outcome across the audited requirements.
+func overallStatus(audits []dto.RequirementAuditResponse) string {
+	status := auditSatisfied
+	for _, a := range audits {
+		status = worseStatus(status, a.Status)
+	}
+	return status
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DegreeAuditService manages degree requirement rules and audits students'
+// progress against them.
+type DegreeAuditService interface {
+	ListRequirements(degreeID uuid.UUID) ([]domain.DegreeRequirement, error)
+	CreateRequirement(degreeID uuid.UUID, req *dto.DegreeRequirementRequest, username, ipAddress, userAgent string) (*domain.DegreeRequirement, error)
+	UpdateRequirement(degreeID, requirementID uuid.UUID, req *dto.DegreeRequirementRequest, username, ipAddress, userAgent string) (*domain.DegreeRequirement, error)
+	DeleteRequirement(degreeID, requirementID uuid.UUID, username, ipAddress, userAgent string) error
+	// AuditStudent evaluates the student's completed and in-progress
+	// enrollments against their degree's requirements. The degree and
+	// specialization come from the student's batches unless given; degreeID
+	// is needed when the student's batches belong to several degrees.
+	AuditStudent(userID uuid.UUID, degreeID, specializationID *uuid.UUID) (*dto.DegreeAuditResponse, error)
+	// ProgrammeUnits returns the departments and faculties that own the
+	// degrees of the student's batches. Advisors are assigned on these.
+	ProgrammeUnits(userID uuid.UUID) (departmentIDs, facultyIDs []uuid.UUID, err error)
+}
+
+// degreeAuditService is the concrete implementation.
+type degreeAuditService struct {
+	requirementRepo    repository.DegreeRequirementRepository
+	degreeRepo         repository.DegreeRepository
+	departmentRepo     repository.DepartmentRepository
+	specializationRepo repository.SpecializationRepository
+	courseRepo         repository.CourseRepository
+	batchRepo          repository.BatchRepository
+	batchMemberRepo    repository.BatchMemberRepository
+	enrollmentRepo     repository.EnrollmentRepository
+	auditClient        *client.AuditClient
+	logger             *zap.Logger
+}
+
+// NewDegreeAuditService wires all dependencies together.
+func NewDegreeAuditService(
+	requirementRepo repository.DegreeRequirementRepository,
+	degreeRepo repository.DegreeRepository,
+	departmentRepo repository.DepartmentRepository,
+	specializationRepo repository.SpecializationRepository,
+	courseRepo repository.CourseRepository,
+	batchRepo repository.BatchRepository,
+	batchMemberRepo repository.BatchMemberRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) DegreeAuditService {
+	return &degreeAuditService{
+		requirementRepo:    requirementRepo,
+		degreeRepo:         degreeRepo,
+		departmentRepo:     departmentRepo,
+		specializationRepo: specializationRepo,
+		courseRepo:         courseRepo,
+		batchRepo:          batchRepo,
+		batchMemberRepo:    batchMemberRepo,
+		enrollmentRepo:     enrollmentRepo,
+		auditClient:        auditClient,
+		logger:             logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Requirement rules
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *degreeAuditService) ListRequirements(degreeID uuid.UUID) ([]domain.DegreeRequirement, error) {
+	if _, err := s.loadDegree(degreeID); err != nil {
+		return nil, err
+	}
+	rules, err := s.requirementRepo.ListByDegree(degreeID)
+	if err != nil {
+		s.logger.Error("failed to list degree requirements", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list degree requirements", err)
+	}
+	return rules, nil
+}
+
+func (s *degreeAuditService) CreateRequirement(
+	degreeID uuid.UUID,
+	req *dto.DegreeRequirementRequest,
+	username, ipAddress, userAgent string,
+) (*domain.DegreeRequirement, error) {
+	if err := s.checkRequirement(degreeID, req); err != nil {
+		return nil, err
+	}
+
+	rule := &domain.DegreeRequirement{DegreeID: degreeID}
+	applyRequirement(rule, req)
+	if err := s.requirementRepo.Create(rule); err != nil {
+		s.logger.Error("failed to create degree requirement", zap.Error(err))
+		return nil, utils.ErrInternal("failed to create degree requirement", err)
+	}
+
+	s.audit(client.AuditActionDegreeRequirementCreated, rule, username, ipAddress, userAgent)
+	return s.loadRequirement(degreeID, rule.ID)
+}
+
+func (s *degreeAuditService) UpdateRequirement(
+	degreeID, requirementID uuid.UUID,
+	req *dto.DegreeRequirementRequest,
+	username, ipAddress, userAgent string,
+) (*domain.DegreeRequirement, error) {
+	rule, err := s.loadRequirement(degreeID, requirementID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRequirement(degreeID, req); err != nil {
+		return nil, err
+	}
+
+	applyRequirement(rule, req)
+	if err := s.requirementRepo.Update(rule); err != nil {
+		s.logger.Error("failed to update degree requirement", zap.Error(err))
+		return nil, utils.ErrInternal("failed to update degree requirement", err)
+	}
+
+	s.audit(client.AuditActionDegreeRequirementUpdated, rule, username, ipAddress, userAgent)
+	return s.loadRequirement(degreeID, rule.ID)
+}
+
+func (s *degreeAuditService) DeleteRequirement(degreeID, requirementID uuid.UUID, username, ipAddress, userAgent string) error {
+	rule, err := s.loadRequirement(degreeID, requirementID)
+	if err != nil {
+		return err
+	}
+	if err := s.requirementRepo.Delete(rule.ID); err != nil {
+		s.logger.Error("failed to delete degree requirement", zap.Error(err))
+		return utils.ErrInternal("failed to delete degree requirement", err)
+	}
+
+	s.audit(client.AuditActionDegreeRequirementDeleted, rule, username, ipAddress, userAgent)
+	return nil
+}
+
+// checkRequirement validates the payload and checks that the degree, the
+// specialization and every listed course exist.
+func (s *degreeAuditService) checkRequirement(degreeID uuid.UUID, req *dto.DegreeRequirementRequest) error {
+	if err := validateDegreeRequirement(req); err != nil {
+		return utils.ErrBadRequest(err.Error())
+	}
+	if _, err := s.loadDegree(degreeID); err != nil {
+		return err
+	}
+	if req.SpecializationID != nil {
+		if _, err := s.loadSpecialization(degreeID, *req.SpecializationID); err != nil {
+			return err
+		}
+	}
+	for _, id := range req.CourseIDs {
+		exists, err := s.courseRepo.Exists(id)
+		if err != nil {
+			s.logger.Error("failed to check course", zap.Error(err))
+			return utils.ErrInternal("failed to check course", err)
+		}
+		if !exists {
+			return utils.ErrNotFound("course " + id.String() + " not found")
+		}
+	}
+	return nil
+}
+
+func applyRequirement(rule *domain.DegreeRequirement, req *dto.DegreeRequirementRequest) {
+	rule.SpecializationID = req.SpecializationID
+	rule.Kind = req.Kind
+	rule.Name = req.Name
+	rule.MinCredits = req.MinCredits
+	rule.MinGrade = req.MinGrade
+	rule.Courses = make([]domain.DegreeRequirementCourse, len(req.CourseIDs))
+	for i, id := range req.CourseIDs {
+		rule.Courses[i] = domain.DegreeRequirementCourse{CourseID: id}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Audit
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *degreeAuditService) AuditStudent(userID uuid.UUID, degreeID, specializationID *uuid.UUID) (*dto.DegreeAuditResponse, error) {
+	degree, spec, err := s.resolveProgramme(userID, degreeID, specializationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.requirementRepo.ListByDegree(degree.ID)
+	if err != nil {
+		s.logger.Error("failed to list degree requirements", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list degree requirements", err)
+	}
+	var specID *uuid.UUID
+	if spec != nil {
+		specID = &spec.ID
+	}
+	rules = requirementsFor(rules, specID)
+
+	history, err := s.enrollmentRepo.GetHistoryByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment history", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment history", err)
+	}
+
+	courses := make(map[uuid.UUID]*domain.Course)
+	for _, r := range rules {
+		for _, c := range r.Courses {
+			courses[c.CourseID] = c.Course
+		}
+	}
+	for _, e := range history {
+		if e.CourseInstance == nil {
+			continue
+		}
+		if _, ok := courses[e.CourseInstance.CourseID]; ok {
+			continue
+		}
+		course, err := s.courseRepo.GetByID(e.CourseInstance.CourseID)
+		if err != nil {
+			s.logger.Error("failed to load course", zap.Error(err))
+			return nil, utils.ErrInternal("failed to load course", err)
+		}
+		courses[e.CourseInstance.CourseID] = course
+	}
+
+	audits, completed, inProgress := auditDegree(rules, history, courses)
+	resp := &dto.DegreeAuditResponse{
+		UserID:            userID,
+		DegreeID:          degree.ID,
+		DegreeCode:        degree.Code,
+		DegreeName:        degree.Name,
+		SpecializationID:  specID,
+		Status:            overallStatus(audits),
+		CreditsCompleted:  completed,
+		CreditsInProgress: inProgress,
+		Requirements:      audits,
+		GeneratedAt:       time.Now().UTC(),
+	}
+	if spec != nil {
+		resp.SpecializationName = spec.Name
+	}
+	return resp, nil
+}
+
+func (s *degreeAuditService) ProgrammeUnits(userID uuid.UUID) ([]uuid.UUID, []uuid.UUID, error) {
+	batchIDs, err := s.batchMemberRepo.GetBatchesByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to load student batches", zap.Error(err))
+		return nil, nil, utils.ErrInternal("failed to load student batches", err)
+	}
+
+	seen := make(map[uuid.UUID]struct{})
+	var departmentIDs, facultyIDs []uuid.UUID
+	for _, id := range batchIDs {
+		batch, err := s.batchRepo.GetBatchByID(id)
+		if err != nil {
+			s.logger.Error("failed to load batch", zap.Error(err))
+			return nil, nil, utils.ErrInternal("failed to load batch", err)
+		}
+		if batch == nil {
+			continue
+		}
+		degree, err := s.degreeRepo.GetDegreeByID(batch.DegreeID)
+		if err != nil {
+			s.logger.Error("failed to load degree", zap.Error(err))
+			return nil, nil, utils.ErrInternal("failed to load degree", err)
+		}
+		if degree == nil {
+			continue
+		}
+		if _, ok := seen[degree.DepartmentID]; ok {
+			continue
+		}
+		seen[degree.DepartmentID] = struct{}{}
+		departmentIDs = append(departmentIDs, degree.DepartmentID)
+
+		department, err := s.departmentRepo.GetDepartmentByID(degree.DepartmentID)
+		if err != nil {
+			s.logger.Error("failed to load department", zap.Error(err))
+			return nil, nil, utils.ErrInternal("failed to load department", err)
+		}
+		if department == nil {
+			continue
+		}
+		if _, ok := seen[department.FacultyID]; !ok {
+			seen[department.FacultyID] = struct{}{}
+			facultyIDs = append(facultyIDs, department.FacultyID)
+		}
+	}
+	return departmentIDs, facultyIDs, nil
+}
+
+// resolveProgramme picks the degree and specialization to audit against.
+// Explicit IDs win; otherwise they are read from the batches the student
+// belongs to.
+func (s *degreeAuditService) resolveProgramme(
+	userID uuid.UUID,
+	degreeID, specializationID *uuid.UUID,
+) (*domain.Degree, *domain.Specialization, error) {
+	batchIDs, err := s.batchMemberRepo.GetBatchesByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to load student batches", zap.Error(err))
+		return nil, nil, utils.ErrInternal("failed to load student batches", err)
+	}
+
+	degreeIDs := make(map[uuid.UUID]struct{})
+	specIDs := make(map[uuid.UUID]map[uuid.UUID]struct{})
+	for _, id := range batchIDs {
+		batch, err := s.batchRepo.GetBatchByID(id)
+		if err != nil {
+			s.logger.Error("failed to load batch", zap.Error(err))
+			return nil, nil, utils.ErrInternal("failed to load batch", err)
+		}
+		if batch == nil {
+			continue
+		}
+		degreeIDs[batch.DegreeID] = struct{}{}
+		if batch.SpecializationID != nil {
+			if specIDs[batch.DegreeID] == nil {
+				specIDs[batch.DegreeID] = make(map[uuid.UUID]struct{})
+			}
+			specIDs[batch.DegreeID][*batch.SpecializationID] = struct{}{}
+		}
+	}
+
+	if degreeID == nil {
+		switch len(degreeIDs) {
+		case 0:
+			return nil, nil, utils.ErrUnprocessable("student is not in a batch of any degree; pass degree_id")
+		case 1:
+			for id := range degreeIDs {
+				degreeID = &id
+			}
+		default:
+			return nil, nil, utils.ErrUnprocessable("student is in batches of several degrees; pass degree_id")
+		}
+	}
+	degree, err := s.loadDegree(*degreeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if specializationID == nil {
+		switch len(specIDs[degree.ID]) {
+		case 0:
+			return degree, nil, nil
+		case 1:
+			for id := range specIDs[degree.ID] {
+				specializationID = &id
+			}
+		default:
+			return nil, nil, utils.ErrUnprocessable("student is in batches of several specializations; pass specialization_id")
+		}
+	}
+	spec, err := s.loadSpecialization(degree.ID, *specializationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return degree, spec, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *degreeAuditService) loadDegree(id uuid.UUID) (*domain.Degree, error) {
+	degree, err := s.degreeRepo.GetDegreeByID(id)
+	if err != nil {
+		s.logger.Error("failed to load degree", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load degree", err)
+	}
+	if degree == nil {
+		return nil, utils.ErrNotFound("degree not found")
+	}
+	return degree, nil
+}
+
+func (s *degreeAuditService) loadSpecialization(degreeID, id uuid.UUID) (*domain.Specialization, error) {
+	spec, err := s.specializationRepo.GetSpecializationByID(id)
+	if err != nil {
+		s.logger.Error("failed to load specialization", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load specialization", err)
+	}
+	if spec == nil || spec.DegreeID != degreeID {
+		return nil, utils.ErrNotFound("specialization not found for this degree")
+	}
+	return spec, nil
+}
+
+func (s *degreeAuditService) loadRequirement(degreeID, id uuid.UUID) (*domain.DegreeRequirement, error) {
+	rule, err := s.requirementRepo.GetByID(id)
+	if err != nil {
+		s.logger.Error("failed to load degree requirement", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load degree requirement", err)
+	}
+	if rule == nil || rule.DegreeID != degreeID {
+		return nil, utils.ErrNotFound("degree requirement not found")
+	}
+	return rule, nil
+}
+
+func (s *degreeAuditService) audit(action client.AuditAction, rule *domain.DegreeRequirement, username, ipAddress, userAgent string) {
+	changes := map[string]interface{}{
+		"degree_id":   rule.DegreeID,
+		"kind":        rule.Kind,
+		"name":        rule.Name,
+		"min_credits": rule.MinCredits,
+		"courses":     len(rule.Courses),
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(action),
+		"degree_requirement",
+		rule.ID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditFixture struct {
+	courses map[uuid.UUID]*domain.Course
+	history []domain.Enrollment
+}
+
+func newAuditFixture() *auditFixture {
+	return &auditFixture{courses: make(map[uuid.UUID]*domain.Course)}
+}
+
+func (f *auditFixture) course(code string, credits int) uuid.UUID {
+	id := uuid.New()
+	f.courses[id] = &domain.Course{ID: id, Code: code, Credits: credits}
+	return id
+}
+
+func (f *auditFixture) take(courseID uuid.UUID, status, grade string) {
+	f.history = append(f.history, domain.Enrollment{
+		CourseInstanceID: uuid.New(),
+		Status:           status,
+		FinalGrade:       grade,
+		CourseInstance:   &domain.CourseInstance{CourseID: courseID},
+	})
+}
+
+func rule(kind string, minCredits int, courses ...uuid.UUID) domain.DegreeRequirement {
+	r := domain.DegreeRequirement{ID: uuid.New(), Kind: kind, Name: kind, MinCredits: minCredits}
+	for _, c := range courses {
+		r.Courses = append(r.Courses, domain.DegreeRequirementCourse{RequirementID: r.ID, CourseID: c})
+	}
+	return r
+}
+
+func TestAuditDegree_RequiredCourses(t *testing.T) {
+	f := newAuditFixture()
+	cs101, cs102, cs201 := f.course("CS101", 3), f.course("CS102", 3), f.course("CS201", 4)
+	required := rule(domain.RequirementKindRequiredCourses, 0, cs101, cs102, cs201)
+	required.MinGrade = "C"
+
+	f.take(cs101, domain.EnrollmentStatusCompleted, "B")
+	f.take(cs102, domain.EnrollmentStatusCompleted, "D")
+	f.take(cs201, domain.EnrollmentStatusEnrolled, "")
+
+	audits, completed, inProgress := auditDegree([]domain.DegreeRequirement{required}, f.history, f.courses)
+	require.Len(t, audits, 1)
+	assert.Equal(t, auditMissing, audits[0].Status, "CS102 was passed below the minimum grade")
+	assert.Equal(t, []string{auditCourseCompleted, auditCourseMissing, auditCourseInProgress},
+		[]string{audits[0].Courses[0].Status, audits[0].Courses[1].Status, audits[0].Courses[2].Status})
+	assert.Equal(t, "D", audits[0].Courses[1].FinalGrade)
+	assert.Equal(t, 3, audits[0].CreditsCompleted)
+	assert.Equal(t, 4, audits[0].CreditsInProgress)
+	assert.Equal(t, 6, completed)
+	assert.Equal(t, 4, inProgress)
+
+	// Retaking CS102 turns the rule pending.
+	f.take(cs102, domain.EnrollmentStatusEnrolled, "")
+	audits, _, _ = auditDegree([]domain.DegreeRequirement{required}, f.history, f.courses)
+	assert.Equal(t, auditPending, audits[0].Status)
+}
+
+func TestAuditDegree_ElectivePools(t *testing.T) {
+	f := newAuditFixture()
+	core, ai, ml, db, web := f.course("CS101", 3), f.course("CS310", 3), f.course("CS320", 3), f.course("CS330", 3), f.course("CS340", 3)
+	required := rule(domain.RequirementKindRequiredCourses, 0, core)
+	// The first pool lists the core course and a course shared with the second.
+	first := rule(domain.RequirementKindElectivePool, 3, core, ai, ml)
+	second := rule(domain.RequirementKindElectivePool, 6, ml, db, web)
+
+	f.take(core, domain.EnrollmentStatusCompleted, "A")
+	f.take(ai, domain.EnrollmentStatusCompleted, "B")
+	f.take(ml, domain.EnrollmentStatusCompleted, "B")
+	f.take(db, domain.EnrollmentStatusEnrolled, "")
+	f.take(web, domain.EnrollmentStatusDropped, "")
+
+	audits, completed, _ := auditDegree([]domain.DegreeRequirement{required, first, second}, f.history, f.courses)
+	require.Len(t, audits, 3)
+
+	assert.Equal(t, auditSatisfied, audits[1].Status)
+	require.Len(t, audits[1].Courses, 1)
+	assert.Equal(t, "CS310", audits[1].Courses[0].CourseCode, "the required course is never used by a pool")
+
+	assert.Equal(t, auditPending, audits[2].Status, "CS320 spills over to the second pool")
+	assert.Equal(t, 3, audits[2].CreditsCompleted)
+	assert.Equal(t, 3, audits[2].CreditsInProgress)
+	assert.Equal(t, 9, completed)
+	assert.Equal(t, auditPending, overallStatus(audits))
+}
+
+func TestAuditDegree_TotalCredits(t *testing.T) {
+	f := newAuditFixture()
+	a, b, c := f.course("MA101", 4), f.course("MA102", 4), f.course("MA201", 4)
+	total := rule(domain.RequirementKindTotalCredits, 12)
+
+	f.take(a, domain.EnrollmentStatusCompleted, "A")
+	f.take(a, domain.EnrollmentStatusCompleted, "B")
+	f.take(b, domain.EnrollmentStatusFailed, "F")
+	f.take(c, domain.EnrollmentStatusEnrolled, "")
+
+	audits, _, _ := auditDegree([]domain.DegreeRequirement{total}, f.history, f.courses)
+	assert.Equal(t, 4, audits[0].CreditsCompleted, "a course only counts once")
+	assert.Equal(t, 4, audits[0].CreditsInProgress)
+	assert.Equal(t, auditMissing, audits[0].Status)
+
+	f.take(b, domain.EnrollmentStatusEnrolled, "")
+	audits, _, _ = auditDegree([]domain.DegreeRequirement{total}, f.history, f.courses)
+	assert.Equal(t, auditPending, audits[0].Status)
+}
+
+func TestRequirementsFor(t *testing.T) {
+	spec, other := uuid.New(), uuid.New()
+	general := domain.DegreeRequirement{ID: uuid.New()}
+	specific := domain.DegreeRequirement{ID: uuid.New(), SpecializationID: &spec}
+	elsewhere := domain.DegreeRequirement{ID: uuid.New(), SpecializationID: &other}
+	rules := []domain.DegreeRequirement{general, specific, elsewhere}
+
+	assert.Len(t, requirementsFor(rules, nil), 1)
+	got := requirementsFor(rules, &spec)
+	require.Len(t, got, 2)
+	assert.Equal(t, specific.ID, got[1].ID)
+}
+
+func TestValidateDegreeRequirement(t *testing.T) {
+	course := uuid.New()
+	valid := func() *dto.DegreeRequirementRequest {
+		return &dto.DegreeRequirementRequest{
+			Kind:       domain.RequirementKindElectivePool,
+			Name:       " Electives ",
+			MinCredits: 6,
+			MinGrade:   "c+",
+			CourseIDs:  []uuid.UUID{course},
+		}
+	}
+
+	req := valid()
+	require.NoError(t, validateDegreeRequirement(req))
+	assert.Equal(t, "Electives", req.Name)
+	assert.Equal(t, "C+", req.MinGrade)
+
+	req = valid()
+	req.Kind = "anything"
+	assert.Error(t, validateDegreeRequirement(req))
+
+	req = valid()
+	req.MinCredits = 0
+	assert.EqualError(t, validateDegreeRequirement(req), "an elective_pool rule needs min_credits")
+
+	req = valid()
+	req.CourseIDs = append(req.CourseIDs, course)
+	assert.EqualError(t, validateDegreeRequirement(req), "course "+course.String()+" is listed more than once")
+
+	req = valid()
+	req.Kind = domain.RequirementKindTotalCredits
+	assert.EqualError(t, validateDegreeRequirement(req), "a total_credits rule cannot list courses")
+
+	req = valid()
+	req.MinGrade = "Z"
+	assert.EqualError(t, validateDegreeRequirement(req), `"Z" is not a valid letter grade`)
+}
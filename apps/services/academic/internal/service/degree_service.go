@@ -50,14 +50,6 @@ func NewDegreeService(
 	auditClient *client.AuditClient,
 	logger *zap.Logger,
 ) DegreeService {
-	allowed := map[string]struct{}{
-		"Undergraduate": {},
-		"Postgraduate":  {},
-		"Doctoral":      {},
-		"Diploma":       {},
-		"Certificate":   {},
-	}
-
 	return &degreeService{
 		db:                     db,
 		degreeRepo:             degreeRepo,
@@ -65,7 +57,7 @@ func NewDegreeService(
 		departmentRepo:         departmentRepo,
 		auditClient:            auditClient,
 		logger:                 logger,
-		allowedDegreeLevelVals: allowed,
+		allowedDegreeLevelVals: degreeLevels,
 	}
 }
 
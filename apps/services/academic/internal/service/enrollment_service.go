@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -18,18 +21,30 @@ type EnrollmentService interface {
 	EnrollStudent(req *dto.EnrollmentRequest, username, ipAddress, userAgent string) (*domain.Enrollment, error)
 	UpdateEnrollment(instanceID, userID uuid.UUID, req *dto.UpdateEnrollmentRequest, username, ipAddress, userAgent string) (*domain.Enrollment, error)
 	GetEnrollments(instanceID uuid.UUID) ([]domain.Enrollment, error)
-	GetEnrollmentsDetailed(ctx context.Context, instanceID uuid.UUID, token string) ([]dto.EnrollmentResponse, error)
+	GetEnrollmentsDetailed(ctx context.Context, instanceID uuid.UUID, token string, spec queryspec.Spec) (queryspec.Page[dto.EnrollmentResponse], error)
 	GetMyEnrollments(userID uuid.UUID) ([]domain.Enrollment, error)
-	AutoEnrollBatchMembers(courseInstanceID, batchID uuid.UUID, username, ipAddress, userAgent string) error
+	AutoEnrollBatchMembers(instance *domain.CourseInstance, username, ipAddress, userAgent string) error
 	AutoEnrollStudentInBatchCourses(userID, batchID uuid.UUID, username, ipAddress, userAgent string) error
+	// TransferBatchEnrollments applies a batch move policy to the student's
+	// enrollments after they moved from one batch to another, and enrolls
+	// them in the open courses of the target batch. It fails only when the
+	// batches' courses cannot be loaded; everything else is reported per
+	// course.
+	TransferBatchEnrollments(userID, fromBatchID, toBatchID uuid.UUID, policy, username, ipAddress, userAgent string) ([]dto.BatchMoveCourseOutcome, error)
 	RemoveEnrollment(instanceID, userID uuid.UUID, username, ipAddress, userAgent string) error
+	// PromoteWaitlisted fills free seats of a course instance from its
+	// waitlist, e.g. after its MaxEnrollment was raised.
+	PromoteWaitlisted(instanceID uuid.UUID, username, ipAddress, userAgent string) error
 }
 
 // enrollmentService is the concrete implementation.
 type enrollmentService struct {
 	courseInstanceRepo repository.CourseInstanceRepository
+	courseRepo         repository.CourseRepository
+	semesterRepo       repository.SemesterRepository
 	batchMemberRepo    repository.BatchMemberRepository
 	enrollmentRepo     repository.EnrollmentRepository
+	gradingSchemeRepo  repository.GradingSchemeRepository
 	auditClient        *client.AuditClient
 	iamClient          *client.IAMClient
 	logger             *zap.Logger
@@ -38,16 +53,22 @@ type enrollmentService struct {
 // NewEnrollmentService wires all dependencies together.
 func NewEnrollmentService(
 	courseInstanceRepo repository.CourseInstanceRepository,
+	courseRepo repository.CourseRepository,
+	semesterRepo repository.SemesterRepository,
 	batchMemberRepo repository.BatchMemberRepository,
 	enrollmentRepo repository.EnrollmentRepository,
+	gradingSchemeRepo repository.GradingSchemeRepository,
 	auditClient *client.AuditClient,
 	iamClient *client.IAMClient,
 	logger *zap.Logger,
 ) EnrollmentService {
 	return &enrollmentService{
 		courseInstanceRepo: courseInstanceRepo,
+		courseRepo:         courseRepo,
+		semesterRepo:       semesterRepo,
 		batchMemberRepo:    batchMemberRepo,
 		enrollmentRepo:     enrollmentRepo,
+		gradingSchemeRepo:  gradingSchemeRepo,
 		auditClient:        auditClient,
 		iamClient:          iamClient,
 		logger:             logger,
@@ -77,6 +98,13 @@ func (s *enrollmentService) EnrollStudent(
 	if !domain.IsValidEnrollmentStatus(req.Status) {
 		return nil, utils.ErrBadRequest("invalid status: allowed values are Enrolled, Dropped, Completed, Failed")
 	}
+	if req.Status == domain.EnrollmentStatusWaitlisted {
+		return nil, utils.ErrBadRequest("waitlist places are assigned automatically when a course instance is full")
+	}
+	req.OverrideReason = strings.TrimSpace(req.OverrideReason)
+	if req.OverridePrerequisites && req.OverrideReason == "" {
+		return nil, utils.ErrBadRequest("override_reason is required to override prerequisites")
+	}
 
 	// 2. Validate course instance exists
 	instance, err := s.courseInstanceRepo.GetByID(req.CourseInstanceID)
@@ -87,12 +115,22 @@ func (s *enrollmentService) EnrollStudent(
 	if instance == nil {
 		return nil, utils.ErrNotFound("course instance not found")
 	}
+	if req.Status == domain.EnrollmentStatusEnrolled && !req.DeadlineApproved {
+		if err := s.checkAddDropDeadline(instance); err != nil {
+			return nil, err
+		}
+	}
 
 	// 3. Validate the student belongs to the batch that owns this course instance,
+	//    or one of its descendants when the instance includes sub-batches,
 	//    unless allow_individual is explicitly set (for individual enrollments outside batch).
-	//    batch_members.batch_id = instance.batch_id AND user_id = req.UserID
 	if !req.AllowIndividual {
-		membership, err := s.batchMemberRepo.GetMember(instance.BatchID, req.UserID)
+		var membership *domain.BatchMember
+		if instance.IncludeSubBatches {
+			membership, err = s.batchMemberRepo.GetMemberInSubtree(instance.BatchID, req.UserID)
+		} else {
+			membership, err = s.batchMemberRepo.GetMember(instance.BatchID, req.UserID)
+		}
 		if err != nil {
 			s.logger.Error("failed to check batch membership", zap.Error(err))
 			return nil, utils.ErrInternal("failed to check batch membership", err)
@@ -112,26 +150,51 @@ func (s *enrollmentService) EnrollStudent(
 		return nil, utils.ErrConflict("student is already enrolled in this course instance")
 	}
 
-	// 5. Persist enrollment
+	// 5. Check prerequisites. Only new active enrollments are checked, so
+	//    past completions can still be recorded. Instructors and admins may
+	//    override with a reason.
+	var unmet []string
+	if req.Status == domain.EnrollmentStatusEnrolled {
+		unmet, err = s.checkPrerequisites(instance, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if len(unmet) > 0 && !req.OverridePrerequisites {
+			return nil, utils.ErrUnprocessable("prerequisites not met: " + strings.Join(unmet, "; "))
+		}
+	}
+
+	// 6. Persist enrollment — a full instance puts the student on its waitlist
 	enrollment := &domain.Enrollment{
 		CourseInstanceID: req.CourseInstanceID,
 		UserID:           req.UserID,
 		Status:           req.Status,
 	}
 
-	if err := s.enrollmentRepo.EnrollStudent(enrollment); err != nil {
+	if err := s.enrollmentRepo.EnrollWithCapacity(enrollment, username); err != nil {
+		if errors.Is(err, repository.ErrAlreadyEnrolled) {
+			return nil, utils.ErrConflict("student is already enrolled in this course instance")
+		}
 		s.logger.Error("failed to enroll student", zap.Error(err))
 		return nil, utils.ErrInternal("failed to enroll student", err)
 	}
 
-	// 6. Write audit log (non-blocking — failure is warned but never propagated)
+	// 7. Write audit log (non-blocking — failure is warned but never propagated)
+	if len(unmet) > 0 {
+		s.logPrerequisiteOverride(enrollment, unmet, req.OverrideReason, username, ipAddress, userAgent)
+	}
+	action := client.AuditActionStudentEnrolled
 	changes := map[string]interface{}{
 		"course_instance_id": req.CourseInstanceID.String(),
 		"user_id":            req.UserID.String(),
-		"status":             req.Status,
+		"status":             enrollment.Status,
+	}
+	if enrollment.WaitlistPosition != nil {
+		action = client.AuditActionStudentWaitlisted
+		changes["waitlist_position"] = *enrollment.WaitlistPosition
 	}
 	if auditErr := s.auditClient.LogAction(
-		string(client.AuditActionStudentEnrolled),
+		string(action),
 		"enrollment",
 		req.CourseInstanceID.String(),
 		0,
@@ -147,10 +210,93 @@ func (s *enrollmentService) EnrollStudent(
 	s.logger.Info("student enrolled",
 		zap.String("course_instance_id", req.CourseInstanceID.String()),
 		zap.String("user_id", req.UserID.String()),
+		zap.String("status", enrollment.Status),
 	)
 	return enrollment, nil
 }
 
+// checkPrerequisites returns the course's requirements that the student has
+// not met for the given course instance.
+func (s *enrollmentService) checkPrerequisites(instance *domain.CourseInstance, userID uuid.UUID) ([]string, error) {
+	rules, err := s.courseRepo.ListPrerequisites(instance.CourseID)
+	if err != nil {
+		s.logger.Error("failed to load course prerequisites", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load course prerequisites", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	history, err := s.enrollmentRepo.GetHistoryByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment history", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment history", err)
+	}
+
+	return unmetPrerequisites(rules, history, instance.SemesterID), nil
+}
+
+// logPrerequisiteOverride records that a student was enrolled without
+// meeting the course's prerequisites.
+func (s *enrollmentService) logPrerequisiteOverride(enrollment *domain.Enrollment, unmet []string, reason, username, ipAddress, userAgent string) {
+	changes := map[string]interface{}{
+		"course_instance_id": enrollment.CourseInstanceID.String(),
+		"user_id":            enrollment.UserID.String(),
+		"unmet":              unmet,
+		"reason":             reason,
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionPrerequisiteOverridden),
+		"enrollment",
+		enrollment.CourseInstanceID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	s.logger.Info("prerequisites overridden",
+		zap.String("course_instance_id", enrollment.CourseInstanceID.String()),
+		zap.String("user_id", enrollment.UserID.String()),
+		zap.String("reason", reason),
+	)
+}
+
+// checkAddDropDeadline rejects enrollment changes in a course instance whose
+// semester is past its add/drop deadline. Such changes need an approved
+// enrollment override.
+func (s *enrollmentService) checkAddDropDeadline(instance *domain.CourseInstance) error {
+	semester, err := s.semesterRepo.GetByID(instance.SemesterID)
+	if err != nil {
+		s.logger.Error("failed to load semester", zap.Error(err))
+		return utils.ErrInternal("failed to load semester", err)
+	}
+	if semester != nil && !semester.AddDropOpen(domain.Today()) {
+		return utils.ErrUnprocessable("the add/drop deadline for this semester passed on " + semester.AddDropDeadline.String() + "; request an enrollment override")
+	}
+	return nil
+}
+
+// isAddDropChange reports whether moving an enrollment from one status to
+// another adds or drops the student, as opposed to recording an outcome.
+func isAddDropChange(from, to string) bool {
+	if to == "" || to == from {
+		return false
+	}
+	switch to {
+	case domain.EnrollmentStatusDropped:
+		return true
+	case domain.EnrollmentStatusEnrolled:
+		return from == domain.EnrollmentStatusDropped || from == domain.EnrollmentStatusWaitlisted
+	default:
+		return false
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // UpdateEnrollment
 // ─────────────────────────────────────────────────────────────────────────────
@@ -179,6 +325,31 @@ func (s *enrollmentService) UpdateEnrollment(
 	oldStatus := enrollment.Status
 	oldGrade := enrollment.FinalGrade
 
+	if req.Status == domain.EnrollmentStatusWaitlisted && oldStatus != domain.EnrollmentStatusWaitlisted {
+		return nil, utils.ErrBadRequest("waitlist places are assigned automatically when a course instance is full")
+	}
+	if isAddDropChange(oldStatus, req.Status) && !req.DeadlineApproved {
+		instance, err := s.courseInstanceRepo.GetByID(instanceID)
+		if err != nil {
+			s.logger.Error("failed to load course instance", zap.Error(err))
+			return nil, utils.ErrInternal("failed to load course instance", err)
+		}
+		if instance != nil {
+			if err := s.checkAddDropDeadline(instance); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if req.FinalGrade != "" || req.Status != "" {
+		scheme, err := s.gradingSchemeRepo.GetByCourseInstance(instanceID)
+		if err != nil {
+			s.logger.Error("failed to load grading scheme", zap.Error(err))
+			return nil, utils.ErrInternal("failed to load grading scheme", err)
+		}
+		if scheme != nil && scheme.IsLocked() {
+			return nil, utils.ErrConflict("final grades for this course instance are locked")
+		}
+	}
 	if req.Status != "" {
 		enrollment.Status = req.Status
 	}
@@ -186,7 +357,13 @@ func (s *enrollmentService) UpdateEnrollment(
 		enrollment.FinalGrade = req.FinalGrade
 	}
 
-	if err := s.enrollmentRepo.UpdateEnrollment(enrollment); err != nil {
+	// Dropping a student frees their seat for the head of the waitlist;
+	// moving a waitlisted student into a seat needs one to be free.
+	promoted, err := s.enrollmentRepo.UpdateWithCapacity(enrollment, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrCourseInstanceFull) {
+			return nil, utils.ErrConflict("course instance is full")
+		}
 		s.logger.Error("failed to update enrollment", zap.Error(err))
 		return nil, utils.ErrInternal("failed to update enrollment", err)
 	}
@@ -220,6 +397,7 @@ func (s *enrollmentService) UpdateEnrollment(
 		zap.String("course_instance_id", instanceID.String()),
 		zap.String("user_id", userID.String()),
 	)
+	s.logPromotions(instanceID, promoted, username, ipAddress, userAgent)
 	return enrollment, nil
 }
 
@@ -228,15 +406,8 @@ func (s *enrollmentService) UpdateEnrollment(
 // ─────────────────────────────────────────────────────────────────────────────
 
 func (s *enrollmentService) GetEnrollments(instanceID uuid.UUID) ([]domain.Enrollment, error) {
-	// Verify the course instance exists so callers receive a meaningful 404
-	// instead of an empty list for a non-existent instance.
-	instance, err := s.courseInstanceRepo.GetByID(instanceID)
-	if err != nil {
-		s.logger.Error("failed to load course instance", zap.Error(err))
-		return nil, utils.ErrInternal("failed to load course instance", err)
-	}
-	if instance == nil {
-		return nil, utils.ErrNotFound("course instance not found")
+	if err := s.requireInstance(instanceID); err != nil {
+		return nil, err
 	}
 
 	enrollments, err := s.enrollmentRepo.GetEnrollments(instanceID)
@@ -248,24 +419,49 @@ func (s *enrollmentService) GetEnrollments(instanceID uuid.UUID) ([]domain.Enrol
 	return enrollments, nil
 }
 
+// requireInstance verifies the course instance exists so callers receive a
+// meaningful 404 instead of an empty list for a non-existent instance.
+func (s *enrollmentService) requireInstance(instanceID uuid.UUID) error {
+	instance, err := s.courseInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load course instance", zap.Error(err))
+		return utils.ErrInternal("failed to load course instance", err)
+	}
+	if instance == nil {
+		return utils.ErrNotFound("course instance not found")
+	}
+	return nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // GetEnrollmentsDetailed
 // ─────────────────────────────────────────────────────────────────────────────
 
-// GetEnrollmentsDetailed fetches enrollments with their user details from IAM
-func (s *enrollmentService) GetEnrollmentsDetailed(ctx context.Context, instanceID uuid.UUID, token string) ([]dto.EnrollmentResponse, error) {
-	enrollments, err := s.GetEnrollments(instanceID)
+// GetEnrollmentsDetailed fetches a page of enrollments with their user details
+// from IAM
+func (s *enrollmentService) GetEnrollmentsDetailed(ctx context.Context, instanceID uuid.UUID, token string, spec queryspec.Spec) (queryspec.Page[dto.EnrollmentResponse], error) {
+	if err := s.requireInstance(instanceID); err != nil {
+		return queryspec.Page[dto.EnrollmentResponse]{}, err
+	}
+
+	page, err := s.enrollmentRepo.GetEnrollmentsPage(instanceID, spec)
 	if err != nil {
-		return nil, err
+		s.logger.Error("failed to list enrollments", zap.Error(err))
+		return queryspec.Page[dto.EnrollmentResponse]{}, utils.ErrInternal("failed to list enrollments", err)
 	}
 
-	if len(enrollments) == 0 {
-		return []dto.EnrollmentResponse{}, nil
+	detailed := queryspec.Page[dto.EnrollmentResponse]{
+		Items:      []dto.EnrollmentResponse{},
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	if len(page.Items) == 0 {
+		return detailed, nil
 	}
 
 	// Extract User IDs
 	var userIDs []string
-	for _, e := range enrollments {
+	for _, e := range page.Items {
 		userIDs = append(userIDs, e.UserID.String())
 	}
 
@@ -274,7 +470,7 @@ func (s *enrollmentService) GetEnrollmentsDetailed(ctx context.Context, instance
 	if err != nil {
 		s.logger.Error("failed to fetch user info from IAM", zap.Error(err))
 		// Return error as we need user details for display
-		return nil, utils.ErrInternal("failed to fetch user details", err)
+		return queryspec.Page[dto.EnrollmentResponse]{}, utils.ErrInternal("failed to fetch user details", err)
 	}
 
 	// Map user info by ID
@@ -284,13 +480,13 @@ func (s *enrollmentService) GetEnrollmentsDetailed(ctx context.Context, instance
 	}
 
 	// Build detailed responses
-	var results []dto.EnrollmentResponse
-	for _, e := range enrollments {
+	for _, e := range page.Items {
 		detail := dto.EnrollmentResponse{
 			CourseInstanceID: e.CourseInstanceID,
 			UserID:           e.UserID,
 			Status:           e.Status,
 			FinalGrade:       e.FinalGrade,
+			WaitlistPosition: e.WaitlistPosition,
 			EnrolledAt:       e.EnrolledAt,
 		}
 
@@ -300,10 +496,10 @@ func (s *enrollmentService) GetEnrollmentsDetailed(ctx context.Context, instance
 			detail.StudentID = info.StudentID
 		}
 
-		results = append(results, detail)
+		detailed.Items = append(detailed.Items, detail)
 	}
 
-	return results, nil
+	return detailed, nil
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -324,7 +520,19 @@ func (s *enrollmentService) RemoveEnrollment(
 		return utils.ErrNotFound("enrollment not found")
 	}
 
-	if err := s.enrollmentRepo.RemoveEnrollment(instanceID, userID); err != nil {
+	instance, err := s.courseInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load course instance", zap.Error(err))
+		return utils.ErrInternal("failed to load course instance", err)
+	}
+	if instance != nil {
+		if err := s.checkAddDropDeadline(instance); err != nil {
+			return err
+		}
+	}
+
+	promoted, err := s.enrollmentRepo.RemoveWithPromotion(instanceID, userID, username)
+	if err != nil {
 		s.logger.Error("failed to remove enrollment", zap.Error(err))
 		return utils.ErrInternal("failed to remove enrollment", err)
 	}
@@ -352,9 +560,56 @@ func (s *enrollmentService) RemoveEnrollment(
 		zap.String("course_instance_id", instanceID.String()),
 		zap.String("user_id", userID.String()),
 	)
+	s.logPromotions(instanceID, promoted, username, ipAddress, userAgent)
 	return nil
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Waitlist
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *enrollmentService) PromoteWaitlisted(instanceID uuid.UUID, username, ipAddress, userAgent string) error {
+	promoted, err := s.enrollmentRepo.PromoteWaitlisted(instanceID, username)
+	if err != nil {
+		s.logger.Error("failed to promote waitlisted students", zap.Error(err))
+		return utils.ErrInternal("failed to promote waitlisted students", err)
+	}
+	s.logPromotions(instanceID, promoted, username, ipAddress, userAgent)
+	return nil
+}
+
+// logPromotions audits each student moved off the waitlist into a seat.
+func (s *enrollmentService) logPromotions(instanceID uuid.UUID, promoted []domain.Enrollment, username, ipAddress, userAgent string) {
+	for _, e := range promoted {
+		changes := map[string]interface{}{
+			"course_instance_id": instanceID.String(),
+			"user_id":            e.UserID.String(),
+			"status": map[string]string{
+				"from": domain.EnrollmentStatusWaitlisted,
+				"to":   e.Status,
+			},
+		}
+		if auditErr := s.auditClient.LogAction(
+			string(client.AuditActionWaitlistPromoted),
+			"enrollment",
+			instanceID.String(),
+			0,
+			username,
+			changes,
+			nil,
+			ipAddress,
+			userAgent,
+		); auditErr != nil {
+			s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+		}
+
+		s.logger.Info("waitlisted student promoted",
+			zap.String("course_instance_id", instanceID.String()),
+			zap.String("user_id", e.UserID.String()),
+		)
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // GetMyEnrollments
 // ─────────────────────────────────────────────────────────────────────────────
@@ -389,7 +644,7 @@ func (s *enrollmentService) GetMyEnrollments(userID uuid.UUID) ([]domain.Enrollm
 	}
 
 	for _, bID := range batchIDs {
-		instances, err := s.courseInstanceRepo.ListByBatch(bID)
+		instances, err := s.batchCourseInstances(bID)
 		if err != nil {
 			s.logger.Warn("failed to fetch batch course instances", zap.Error(err), zap.String("batch_id", bID.String()))
 			continue
@@ -411,9 +666,32 @@ func (s *enrollmentService) GetMyEnrollments(userID uuid.UUID) ([]domain.Enrollm
 	return enrollments, nil
 }
 
-// AutoEnrollBatchMembers enrolls all students of a batch into a specific course instance.
-func (s *enrollmentService) AutoEnrollBatchMembers(courseInstanceID, batchID uuid.UUID, username, ipAddress, userAgent string) error {
-	userIDs, err := s.batchMemberRepo.GetMembersByBatchID(batchID)
+// batchCourseInstances returns the course instances a batch's members
+// belong in: the batch's own and those inherited from its ancestors.
+func (s *enrollmentService) batchCourseInstances(batchID uuid.UUID) ([]domain.CourseInstance, error) {
+	instances, err := s.courseInstanceRepo.ListByBatch(batchID)
+	if err != nil {
+		return nil, err
+	}
+	inherited, err := s.courseInstanceRepo.ListInheritedByBatch(batchID)
+	if err != nil {
+		return nil, err
+	}
+	return append(instances, inherited...), nil
+}
+
+// AutoEnrollBatchMembers enrolls all students of the instance's batch into
+// it, including those of descendant batches when it includes sub-batches.
+func (s *enrollmentService) AutoEnrollBatchMembers(instance *domain.CourseInstance, username, ipAddress, userAgent string) error {
+	courseInstanceID := instance.ID
+
+	var userIDs []uuid.UUID
+	var err error
+	if instance.IncludeSubBatches {
+		userIDs, err = s.batchMemberRepo.GetMembersBySubtree(instance.BatchID)
+	} else {
+		userIDs, err = s.batchMemberRepo.GetMembersByBatchID(instance.BatchID)
+	}
 	if err != nil {
 		return err
 	}
@@ -430,6 +708,13 @@ func (s *enrollmentService) AutoEnrollBatchMembers(courseInstanceID, batchID uui
 			if utils.IsConflict(err) {
 				continue
 			}
+			if utils.IsUnprocessable(err) {
+				s.logger.Info("student not auto-enrolled: prerequisites not met",
+					zap.String("user_id", userID.String()),
+					zap.String("course_instance_id", courseInstanceID.String()),
+				)
+				continue
+			}
 			s.logger.Warn("failed to auto-enroll student", zap.Error(err), zap.String("user_id", userID.String()))
 		}
 	}
@@ -437,9 +722,10 @@ func (s *enrollmentService) AutoEnrollBatchMembers(courseInstanceID, batchID uui
 	return nil
 }
 
-// AutoEnrollStudentInBatchCourses enrolls a student into all course instances assigned to a specific batch.
+// AutoEnrollStudentInBatchCourses enrolls a student into all course instances
+// assigned to a specific batch or inherited from its ancestors.
 func (s *enrollmentService) AutoEnrollStudentInBatchCourses(userID, batchID uuid.UUID, username, ipAddress, userAgent string) error {
-	instances, err := s.courseInstanceRepo.ListByBatch(batchID)
+	instances, err := s.batchCourseInstances(batchID)
 	if err != nil {
 		return err
 	}
@@ -455,9 +741,265 @@ func (s *enrollmentService) AutoEnrollStudentInBatchCourses(userID, batchID uuid
 			if utils.IsConflict(err) {
 				continue
 			}
+			if utils.IsUnprocessable(err) {
+				s.logger.Info("student not auto-enrolled: prerequisites not met",
+					zap.String("user_id", userID.String()),
+					zap.String("course_instance_id", inst.ID.String()),
+				)
+				continue
+			}
 			s.logger.Warn("failed to auto-enroll student in batch course", zap.Error(err), zap.String("instance_id", inst.ID.String()))
 		}
 	}
 
 	return nil
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// TransferBatchEnrollments
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Outcomes reported per course instance by TransferBatchEnrollments.
+const (
+	moveOutcomeTransferred = "transferred"
+	moveOutcomeDropped     = "dropped"
+	moveOutcomeKept        = "kept"
+	moveOutcomeEnrolled    = "enrolled"
+	moveOutcomeWaitlisted  = "waitlisted"
+	moveOutcomeIneligible  = "ineligible"
+	moveOutcomeFailed      = "failed"
+)
+
+// TransferBatchEnrollments handles the student's active enrollments in
+// courses only the source batch has according to policy, then enrolls them
+// in the courses only the target batch has that are still open (see
+// openInstances). Courses both batches share, e.g. those of a common
+// ancestor that includes sub-batches, are left alone. Drops go through the
+// Dropped status so the history is kept, and each step is subject to the
+// usual add/drop and grade-lock rules; a step that fails is reported in its
+// outcome instead of aborting the others.
+func (s *enrollmentService) TransferBatchEnrollments(
+	userID, fromBatchID, toBatchID uuid.UUID,
+	policy, username, ipAddress, userAgent string,
+) ([]dto.BatchMoveCourseOutcome, error) {
+	source, err := s.batchCourseInstances(fromBatchID)
+	if err != nil {
+		s.logger.Error("failed to list source batch course instances", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list source batch course instances", err)
+	}
+	target, err := s.batchCourseInstances(toBatchID)
+	if err != nil {
+		s.logger.Error("failed to list target batch course instances", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list target batch course instances", err)
+	}
+
+	inSource := make(map[uuid.UUID]bool, len(source))
+	for _, inst := range source {
+		inSource[inst.ID] = true
+	}
+	inTarget := make(map[uuid.UUID]bool, len(target))
+	for _, inst := range target {
+		inTarget[inst.ID] = true
+	}
+	// Only open courses of the target batch take new enrollments; its past
+	// semesters' courses must not be added to the student's record.
+	open, err := s.openInstances(target)
+	if err != nil {
+		s.logger.Error("failed to load target batch semesters", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load target batch semesters", err)
+	}
+
+	outcomes := []dto.BatchMoveCourseOutcome{}
+	handled := make(map[uuid.UUID]bool)
+
+	// 1. Enrollments in courses the target batch does not share
+	for i := range source {
+		inst := &source[i]
+		if inTarget[inst.ID] {
+			continue
+		}
+		outcome := dto.BatchMoveCourseOutcome{
+			CourseInstanceID: inst.ID,
+			CourseID:         inst.CourseID,
+			SemesterID:       inst.SemesterID,
+		}
+		enrollment, err := s.enrollmentRepo.GetEnrollment(inst.ID, userID)
+		if err != nil {
+			s.logger.Error("failed to load enrollment", zap.Error(err))
+			outcome.Outcome = moveOutcomeFailed
+			outcome.Reason = "failed to load enrollment"
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+		// Completed, failed, and dropped enrollments are history.
+		if enrollment == nil ||
+			enrollment.Status != domain.EnrollmentStatusEnrolled && enrollment.Status != domain.EnrollmentStatusWaitlisted {
+			continue
+		}
+
+		switch policy {
+		case domain.BatchMovePolicyKeep:
+			outcome.Outcome = moveOutcomeKept
+		case domain.BatchMovePolicyTransfer:
+			if match := matchingInstance(open, inSource, inst); match != nil {
+				handled[match.ID] = true
+				s.transferEnrollment(inst, match, userID, &outcome, username, ipAddress, userAgent)
+			} else {
+				s.dropForMove(inst.ID, userID, &outcome, username, ipAddress, userAgent)
+			}
+		default:
+			s.dropForMove(inst.ID, userID, &outcome, username, ipAddress, userAgent)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	// 2. Open courses of the target batch the student is not yet enrolled in
+	for i := range open {
+		inst := &open[i]
+		if inSource[inst.ID] || handled[inst.ID] {
+			continue
+		}
+		outcome := dto.BatchMoveCourseOutcome{
+			CourseInstanceID: inst.ID,
+			CourseID:         inst.CourseID,
+			SemesterID:       inst.SemesterID,
+		}
+		enrollment, err := s.EnrollStudent(&dto.EnrollmentRequest{
+			CourseInstanceID: inst.ID,
+			UserID:           userID,
+			Status:           domain.EnrollmentStatusEnrolled,
+		}, username, ipAddress, userAgent)
+		switch {
+		case err == nil && enrollment.WaitlistPosition != nil:
+			outcome.Outcome = moveOutcomeWaitlisted
+		case err == nil:
+			outcome.Outcome = moveOutcomeEnrolled
+		case utils.IsConflict(err):
+			continue
+		case utils.IsUnprocessable(err):
+			outcome.Outcome = moveOutcomeIneligible
+			outcome.Reason = err.Error()
+		default:
+			outcome.Outcome = moveOutcomeFailed
+			outcome.Reason = err.Error()
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, nil
+}
+
+// openInstances returns the instances that can still take enrollments:
+// Planned or Active ones whose semester has not completed or been cancelled.
+// The instance status alone is not enough, as instances of a finished
+// semester are not always closed, and semesters created before add/drop
+// deadlines existed do not stop late enrollments either.
+func (s *enrollmentService) openInstances(instances []domain.CourseInstance) ([]domain.CourseInstance, error) {
+	semesters := make(map[uuid.UUID]*domain.Semester)
+	var open []domain.CourseInstance
+	for _, inst := range instances {
+		semester, ok := semesters[inst.SemesterID]
+		if !ok {
+			var err error
+			if semester, err = s.semesterRepo.GetByID(inst.SemesterID); err != nil {
+				return nil, err
+			}
+			semesters[inst.SemesterID] = semester
+		}
+		if acceptsEnrollments(&inst, semester) {
+			open = append(open, inst)
+		}
+	}
+	return open, nil
+}
+
+// acceptsEnrollments reports whether inst, in semester (nil when unknown),
+// is open for new enrollments.
+func acceptsEnrollments(inst *domain.CourseInstance, semester *domain.Semester) bool {
+	if inst.Status != domain.CourseInstanceStatusPlanned && inst.Status != domain.CourseInstanceStatusActive {
+		return false
+	}
+	return semester == nil ||
+		semester.Status != domain.SemesterStatusCompleted && semester.Status != domain.SemesterStatusCancelled
+}
+
+// matchingInstance returns the target batch's own instance of the same
+// course in the same semester as inst, or nil.
+func matchingInstance(target []domain.CourseInstance, inSource map[uuid.UUID]bool, inst *domain.CourseInstance) *domain.CourseInstance {
+	for i := range target {
+		t := &target[i]
+		if !inSource[t.ID] && t.CourseID == inst.CourseID && t.SemesterID == inst.SemesterID {
+			return t
+		}
+	}
+	return nil
+}
+
+// transferEnrollment enrolls the student in to and then drops them from
+// from. When the new enrollment cannot be made the old one is left intact.
+func (s *enrollmentService) transferEnrollment(
+	from, to *domain.CourseInstance,
+	userID uuid.UUID,
+	outcome *dto.BatchMoveCourseOutcome,
+	username, ipAddress, userAgent string,
+) {
+	outcome.TargetCourseInstanceID = &to.ID
+
+	// The student was already admitted to the course, so its prerequisites
+	// are not checked again.
+	_, err := s.EnrollStudent(&dto.EnrollmentRequest{
+		CourseInstanceID:      to.ID,
+		UserID:                userID,
+		Status:                domain.EnrollmentStatusEnrolled,
+		OverridePrerequisites: true,
+		OverrideReason:        "enrollment transferred from course instance " + from.ID.String(),
+	}, username, ipAddress, userAgent)
+	if err != nil && !utils.IsConflict(err) {
+		outcome.Outcome = moveOutcomeFailed
+		outcome.Reason = err.Error()
+		return
+	}
+
+	s.dropForMove(from.ID, userID, outcome, username, ipAddress, userAgent)
+	if outcome.Outcome != moveOutcomeDropped {
+		return
+	}
+	outcome.Outcome = moveOutcomeTransferred
+
+	changes := map[string]interface{}{
+		"user_id":                 userID.String(),
+		"from_course_instance_id": from.ID.String(),
+		"to_course_instance_id":   to.ID.String(),
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionEnrollmentTransferred),
+		"enrollment",
+		to.ID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+}
+
+// dropForMove sets the enrollment to Dropped and records the result on
+// outcome.
+func (s *enrollmentService) dropForMove(
+	instanceID, userID uuid.UUID,
+	outcome *dto.BatchMoveCourseOutcome,
+	username, ipAddress, userAgent string,
+) {
+	_, err := s.UpdateEnrollment(instanceID, userID, &dto.UpdateEnrollmentRequest{
+		Status: domain.EnrollmentStatusDropped,
+	}, username, ipAddress, userAgent)
+	if err != nil {
+		outcome.Outcome = moveOutcomeFailed
+		outcome.Reason = err.Error()
+		return
+	}
+	outcome.Outcome = moveOutcomeDropped
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptsEnrollments(t *testing.T) {
+	semester := func(status string) *domain.Semester { return &domain.Semester{Status: status} }
+
+	tests := []struct {
+		name     string
+		instance string
+		semester *domain.Semester
+		want     bool
+	}{
+		{"active instance", domain.CourseInstanceStatusActive, semester(domain.SemesterStatusActive), true},
+		{"planned instance", domain.CourseInstanceStatusPlanned, semester(domain.SemesterStatusPlanned), true},
+		{"unknown semester", domain.CourseInstanceStatusActive, nil, true},
+		{"completed instance", domain.CourseInstanceStatusCompleted, semester(domain.SemesterStatusActive), false},
+		{"cancelled instance", domain.CourseInstanceStatusCancelled, semester(domain.SemesterStatusActive), false},
+		// Instances of a past semester are not always closed.
+		{"completed semester", domain.CourseInstanceStatusActive, semester(domain.SemesterStatusCompleted), false},
+		{"cancelled semester", domain.CourseInstanceStatusPlanned, semester(domain.SemesterStatusCancelled), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := &domain.CourseInstance{Status: tt.instance}
+			assert.Equal(t, tt.want, acceptsEnrollments(inst, tt.semester))
+		})
+	}
+}
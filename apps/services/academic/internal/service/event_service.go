@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/notifier"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EventPublisher sends a stored event to the message broker and returns
+// once the broker has accepted it.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *domain.OutboxEvent, replayed bool) error
+}
+
+// NotificationPublisher sends a notification to the Notification Service
+// and returns once the broker has accepted it.
+type NotificationPublisher interface {
+	Publish(ctx context.Context, notification notifier.Notification) error
+}
+
+// EventService relays the transactional outbox to the broker and serves
+// stored events to consumers that need to catch up.
+type EventService interface {
+	// ListEvents returns stored events in sequence order.
+	ListEvents(query dto.ListEventsQuery) (*dto.ListEventsResponse, error)
+	// CourseAccessSnapshot returns all enrollments and instructor assignments
+	// with the event sequence they are consistent with.
+	CourseAccessSnapshot() (*dto.CourseAccessSnapshotResponse, error)
+	// Replay re-publishes already published events, flagged as replayed.
+	Replay(req *dto.ReplayEventsRequest, username, ipAddress, userAgent string) (*dto.ReplayEventsResponse, error)
+	// Run relays unpublished events on the given interval until ctx is done.
+	Run(ctx context.Context, interval time.Duration, batch int)
+}
+
+const (
+	defaultEventListLimit = 100
+	maxEventListLimit     = 1000
+	maxEventReplay        = 1000
+	eventPublishTimeout   = 10 * time.Second
+)
+
+// eventService is the concrete implementation.
+type eventService struct {
+	outboxRepo    repository.OutboxRepository
+	publisher     EventPublisher
+	notifications NotificationPublisher
+	auditClient   *client.AuditClient
+	logger        *zap.Logger
+}
+
+// NewEventService wires all dependencies together. publisher may be nil, in
+// which case events are stored but never relayed. notifications may be nil,
+// in which case approval events are relayed without notifying anyone.
+func NewEventService(
+	outboxRepo repository.OutboxRepository,
+	publisher EventPublisher,
+	notifications NotificationPublisher,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) EventService {
+	return &eventService{
+		outboxRepo:    outboxRepo,
+		publisher:     publisher,
+		notifications: notifications,
+		auditClient:   auditClient,
+		logger:        logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ListEvents
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *eventService) ListEvents(query dto.ListEventsQuery) (*dto.ListEventsResponse, error) {
+	if query.After < 0 {
+		return nil, utils.ErrBadRequest("after must not be negative")
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultEventListLimit
+	}
+	if limit > maxEventListLimit {
+		limit = maxEventListLimit
+	}
+
+	events, err := s.outboxRepo.List(repository.OutboxFilter{
+		AfterSequence: query.After,
+		AggregateType: query.AggregateType,
+		AggregateID:   query.AggregateID,
+		EventType:     query.EventType,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, utils.ErrInternal("failed to list events", err)
+	}
+
+	resp := &dto.ListEventsResponse{
+		Events:    make([]dto.EventResponse, len(events)),
+		Count:     len(events),
+		NextAfter: query.After,
+	}
+	for i, e := range events {
+		resp.Events[i] = dto.EventResponse{
+			ID:            e.ID,
+			Sequence:      e.Sequence,
+			EventType:     e.EventType,
+			AggregateType: e.AggregateType,
+			AggregateID:   e.AggregateID,
+			OccurredAt:    e.OccurredAt,
+			Payload:       e.Payload,
+			PublishedAt:   e.PublishedAt,
+		}
+		resp.NextAfter = e.Sequence
+	}
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// CourseAccessSnapshot
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *eventService) CourseAccessSnapshot() (*dto.CourseAccessSnapshotResponse, error) {
+	snap, err := s.outboxRepo.CourseAccessSnapshot()
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load course access snapshot", err)
+	}
+
+	resp := &dto.CourseAccessSnapshotResponse{
+		Sequence:                snap.Sequence,
+		CourseInstanceSequences: snap.CourseInstanceSequences,
+		Enrollments:             make([]dto.CourseAccessEnrollment, len(snap.Enrollments)),
+		Instructors:             make([]dto.CourseAccessInstructor, len(snap.Instructors)),
+		SectionMembers:          make([]dto.CourseAccessSectionMember, len(snap.SectionMembers)),
+	}
+	for i, e := range snap.Enrollments {
+		resp.Enrollments[i] = dto.CourseAccessEnrollment{
+			CourseInstanceID: e.CourseInstanceID,
+			UserID:           e.UserID,
+			Status:           e.Status,
+		}
+	}
+	for i, ci := range snap.Instructors {
+		resp.Instructors[i] = dto.CourseAccessInstructor{
+			CourseInstanceID: ci.CourseInstanceID,
+			UserID:           ci.UserID,
+			Role:             ci.Role,
+		}
+	}
+	for i, m := range snap.SectionMembers {
+		resp.SectionMembers[i] = dto.CourseAccessSectionMember{
+			CourseInstanceID: m.CourseInstanceID,
+			SectionID:        m.SectionID,
+			UserID:           m.UserID,
+		}
+	}
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Replay
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *eventService) Replay(
+	req *dto.ReplayEventsRequest,
+	username, ipAddress, userAgent string,
+) (*dto.ReplayEventsResponse, error) {
+	if s.publisher == nil {
+		return nil, utils.ErrUnprocessable("event publishing is not configured")
+	}
+	if req.FromSequence < 0 || req.ToSequence < 0 {
+		return nil, utils.ErrBadRequest("sequences must not be negative")
+	}
+	if req.ToSequence > 0 && req.ToSequence <= req.FromSequence {
+		return nil, utils.ErrBadRequest("to_sequence must be greater than from_sequence")
+	}
+
+	// Only published events are replayed; unpublished ones are still owed
+	// to the relay, which keeps them in order.
+	events, err := s.outboxRepo.List(repository.OutboxFilter{
+		AfterSequence: req.FromSequence,
+		UpToSequence:  req.ToSequence,
+		AggregateType: req.AggregateType,
+		AggregateID:   req.AggregateID,
+		EventType:     req.EventType,
+		PublishedOnly: true,
+		Limit:         maxEventReplay + 1,
+	})
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load events", err)
+	}
+
+	resp := &dto.ReplayEventsResponse{LastSequence: req.FromSequence}
+	if len(events) > maxEventReplay {
+		events = events[:maxEventReplay]
+		resp.HasMore = true
+	}
+	var publishErr error
+	for i := range events {
+		if publishErr = s.publish(&events[i], true); publishErr != nil {
+			s.logger.Warn("event replay interrupted",
+				zap.Int64("sequence", events[i].Sequence),
+				zap.Error(publishErr),
+			)
+			resp.HasMore = true
+			break
+		}
+		resp.Replayed++
+		resp.LastSequence = events[i].Sequence
+	}
+
+	changes := map[string]interface{}{
+		"from_sequence": req.FromSequence,
+		"to_sequence":   req.ToSequence,
+		"replayed":      resp.Replayed,
+		"last_sequence": resp.LastSequence,
+	}
+	if req.AggregateType != "" {
+		changes["aggregate_type"] = req.AggregateType
+	}
+	if req.AggregateID != nil {
+		changes["aggregate_id"] = req.AggregateID.String()
+	}
+	if req.EventType != "" {
+		changes["event_type"] = req.EventType
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionEventsReplayed),
+		"academic_events",
+		"",
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	// A partial replay is reported so the caller can resume from
+	// LastSequence; one that sent nothing is an error.
+	if publishErr != nil && resp.Replayed == 0 {
+		return nil, utils.ErrInternal("failed to publish events", publishErr)
+	}
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Run
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *eventService) Run(ctx context.Context, interval time.Duration, batch int) {
+	if s.publisher == nil || interval <= 0 || batch <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the backlog before waiting for the next tick.
+		for {
+			n, err := s.outboxRepo.Relay(batch, func(e *domain.OutboxEvent) error {
+				return s.publish(e, false)
+			})
+			if err != nil {
+				s.logger.Warn("outbox relay failed", zap.Int("published", n), zap.Error(err))
+				break
+			}
+			if n < batch || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *eventService) publish(event *domain.OutboxEvent, replayed bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), eventPublishTimeout)
+	defer cancel()
+
+	// Replays are for consumers catching up; the users were told already.
+	if s.notifications != nil && !replayed {
+		notification, ok, err := approvalNotification(event)
+		if err != nil {
+			// A payload that cannot be read will not improve on retry.
+			s.logger.Error("skipping notification for unreadable approval event",
+				zap.Int64("sequence", event.Sequence),
+				zap.Error(err),
+			)
+		} else if ok {
+			if err := s.notifications.Publish(ctx, notification); err != nil {
+				return err
+			}
+		}
+	}
+	return s.publisher.Publish(ctx, event, replayed)
+}
+
+// approvalNotification builds the notification for an approval event. ok is
+// false for other events and for approval events with nobody to tell. The
+// notification takes the event's ID, so a notification resent because the
+// event could not be published keeps its identity.
+func approvalNotification(event *domain.OutboxEvent) (notification notifier.Notification, ok bool, err error) {
+	if !strings.HasPrefix(event.EventType, "approval.") {
+		return notifier.Notification{}, false, nil
+	}
+	var payload domain.ApprovalEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return notifier.Notification{}, false, fmt.Errorf("decoding approval event %d: %w", event.Sequence, err)
+	}
+	if len(payload.NotifyUserIDs) == 0 {
+		return notifier.Notification{}, false, nil
+	}
+
+	kind := approvalKindLabel(payload.Kind)
+	var title, message string
+	switch event.EventType {
+	case domain.EventApprovalRequested:
+		title, message = "Approval requested", "A request for "+kind+" is waiting for your decision."
+	case domain.EventApprovalApproved:
+		title, message = "Request approved", "Your request for "+kind+" was approved and carried out."
+	case domain.EventApprovalRejected:
+		title, message = "Request rejected", "Your request for "+kind+" was rejected."
+	case domain.EventApprovalWithdrawn:
+		title, message = "Request withdrawn", "A request for "+kind+" awaiting your decision was withdrawn."
+	default:
+		return notifier.Notification{}, false, nil
+	}
+
+	userIDs := make([]string, len(payload.NotifyUserIDs))
+	for i, id := range payload.NotifyUserIDs {
+		userIDs[i] = id.String()
+	}
+	notification = notifier.NewNotification(userIDs, event.EventType, title, message, map[string]any{
+		"approval_id":        payload.ApprovalID,
+		"course_instance_id": payload.CourseInstanceID,
+		"kind":               payload.Kind,
+		"status":             payload.Status,
+	})
+	if event.ID != uuid.Nil {
+		notification.ID = event.ID.String()
+	}
+	return notification, true, nil
+}
+
+// approvalKindLabel names an approval kind in a notification.
+func approvalKindLabel(kind string) string {
+	switch kind {
+	case domain.ApprovalKindGradeChange:
+		return "a final grade change"
+	case domain.ApprovalKindCancellation:
+		return "a course cancellation"
+	case domain.ApprovalKindEnrollmentOverride:
+		return "an enrollment override"
+	default:
+		return "an approval"
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -87,6 +88,16 @@ func (m *MockFacultyLeadershipRepository) DeactivateLeadersByFacultyID(facultyID
 	return args.Error(0)
 }
 
+func (m *MockFacultyLeadershipRepository) GetActiveLeadersByFacultyID(facultyID uuid.UUID) ([]domain.FacultyLeadership, error) {
+	args := m.Called(facultyID)
+	return args.Get(0).([]domain.FacultyLeadership), args.Error(1)
+}
+
+func (m *MockFacultyLeadershipRepository) GetActiveLeadershipsByUserID(userID uuid.UUID) ([]domain.FacultyLeadership, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.FacultyLeadership), args.Error(1)
+}
+
 // MockDepartmentRepository is a mock implementation of DepartmentRepository
 type MockDepartmentRepository struct {
 	mock.Mock
@@ -161,7 +172,7 @@ func TestCreateFaculty_Success(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -195,7 +206,7 @@ func TestCreateFaculty_DuplicateCode(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -232,7 +243,7 @@ func TestCreateFaculty_NoLeaders(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -255,7 +266,7 @@ func TestCreateFaculty_InvalidName(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -283,7 +294,7 @@ func TestUpdateFaculty_Success(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -317,7 +328,7 @@ func TestUpdateFaculty_NotFound(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -342,7 +353,7 @@ func TestUpdateFaculty_WithLeaders(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -383,7 +394,7 @@ func TestUpdateFaculty_EmptyLeaders(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -415,7 +426,7 @@ func TestDeactivateFaculty_Success(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -443,7 +454,7 @@ func TestDeactivateFaculty_NotFound(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -463,7 +474,7 @@ func TestGetFacultyByID_Success(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -492,7 +503,7 @@ func TestGetFacultyByID_NotFound(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -513,7 +524,7 @@ func TestListFaculties_Empty(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -547,7 +558,7 @@ func TestGetFacultyLeaders_Success(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -584,7 +595,7 @@ func TestGetFacultyLeaders_FacultyNotFound(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -605,7 +616,7 @@ func TestValidateUpdateRequest_EmptyLeaders(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
@@ -633,7 +644,7 @@ func TestValidateUpdateRequest_InvalidName(t *testing.T) {
 	mockDepartmentRepo := new(MockDepartmentRepository)
 	db := setupTestDB(t)
 	logger := zap.NewNop()
-	auditClient := client.NewAuditClient("http://localhost:8081", logger)
+	auditClient := client.NewAuditClient("http://localhost:8081", servicetoken.NewClient(servicetoken.Config{}), logger)
 
 	service := NewFacultyService(db, mockFacultyRepo, mockLeadershipRepo, mockDepartmentRepo, auditClient, logger)
 
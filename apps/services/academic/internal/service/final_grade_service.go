@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FinalGradeService manages course instance grading schemes and the final
+// grades computed from them. Grades are computed from the Assessment
+// Service's effective scores, reviewed by instructors and then locked, which
+// releases them to students and their transcripts.
+type FinalGradeService interface {
+	GetGradingScheme(instanceID uuid.UUID) (*domain.GradingScheme, error)
+	SetGradingScheme(instanceID uuid.UUID, req *dto.SetGradingSchemeRequest, username, ipAddress, userAgent string) (*domain.GradingScheme, error)
+	ListFinalGrades(instanceID uuid.UUID) (*dto.FinalGradesResponse, error)
+	ComputeFinalGrades(ctx context.Context, instanceID uuid.UUID, username, ipAddress, userAgent string) (*dto.FinalGradesResponse, error)
+	OverrideFinalGrade(instanceID, userID uuid.UUID, req *dto.OverrideFinalGradeRequest, username, ipAddress, userAgent string) (*dto.FinalGradeResponse, error)
+	LockFinalGrades(instanceID uuid.UUID, username, ipAddress, userAgent string) (*dto.FinalGradesResponse, error)
+	// PrepareGradeChange validates a change to a locked final grade, which
+	// needs approval, and describes it for the approval request.
+	PrepareGradeChange(instanceID, userID uuid.UUID, finalGrade string) (*domain.GradeChangePayload, error)
+	// AmendFinalGrade carries out an approved change to a locked final grade.
+	AmendFinalGrade(instanceID uuid.UUID, change *domain.GradeChangePayload, username, ipAddress, userAgent string) (*dto.FinalGradeResponse, error)
+}
+
+// finalGradeService is the concrete implementation.
+type finalGradeService struct {
+	courseInstanceRepo repository.CourseInstanceRepository
+	enrollmentRepo     repository.EnrollmentRepository
+	gradingSchemeRepo  repository.GradingSchemeRepository
+	assessmentClient   *client.AssessmentClient
+	auditClient        *client.AuditClient
+	logger             *zap.Logger
+}
+
+// NewFinalGradeService wires all dependencies together.
+func NewFinalGradeService(
+	courseInstanceRepo repository.CourseInstanceRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	gradingSchemeRepo repository.GradingSchemeRepository,
+	assessmentClient *client.AssessmentClient,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) FinalGradeService {
+	return &finalGradeService{
+		courseInstanceRepo: courseInstanceRepo,
+		enrollmentRepo:     enrollmentRepo,
+		gradingSchemeRepo:  gradingSchemeRepo,
+		assessmentClient:   assessmentClient,
+		auditClient:        auditClient,
+		logger:             logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Grading scheme
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *finalGradeService) GetGradingScheme(instanceID uuid.UUID) (*domain.GradingScheme, error) {
+	scheme, err := s.loadScheme(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == nil {
+		return nil, utils.ErrNotFound("course instance has no grading scheme")
+	}
+	return scheme, nil
+}
+
+func (s *finalGradeService) SetGradingScheme(
+	instanceID uuid.UUID,
+	req *dto.SetGradingSchemeRequest,
+	username, ipAddress, userAgent string,
+) (*domain.GradingScheme, error) {
+	categories, bands, err := buildGradingScheme(req)
+	if err != nil {
+		return nil, utils.ErrBadRequest(err.Error())
+	}
+
+	if _, err := s.loadInstance(instanceID); err != nil {
+		return nil, err
+	}
+	scheme, err := s.loadScheme(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == nil {
+		scheme = &domain.GradingScheme{CourseInstanceID: instanceID}
+	}
+	if scheme.IsLocked() {
+		return nil, utils.ErrConflict("final grades for this course instance are locked")
+	}
+	scheme.Categories = categories
+	scheme.Bands = bands
+
+	if err := s.gradingSchemeRepo.Save(scheme); err != nil {
+		if errors.Is(err, repository.ErrGradesLocked) {
+			return nil, utils.ErrConflict("final grades for this course instance are locked")
+		}
+		s.logger.Error("failed to save grading scheme", zap.Error(err))
+		return nil, utils.ErrInternal("failed to save grading scheme", err)
+	}
+
+	weights := make(map[string]float64, len(categories))
+	for _, c := range categories {
+		weights[c.Name] = c.Weight
+	}
+	s.audit(client.AuditActionGradingSchemeSet, instanceID, username, map[string]interface{}{
+		"categories": weights,
+		"bands":      len(bands),
+	}, ipAddress, userAgent)
+
+	return s.loadScheme(instanceID)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Final grades
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *finalGradeService) ListFinalGrades(instanceID uuid.UUID) (*dto.FinalGradesResponse, error) {
+	if _, err := s.loadInstance(instanceID); err != nil {
+		return nil, err
+	}
+	scheme, err := s.loadScheme(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := s.gradedEnrollments(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	grades := make([]dto.FinalGradeResponse, len(enrollments))
+	for i := range enrollments {
+		grades[i] = toFinalGradeResponse(&enrollments[i])
+	}
+	return finalGradesResponse(instanceID, scheme, grades), nil
+}
+
+// ComputeFinalGrades recomputes the final score of every graded student.
+// Letter grades set by an instructor during review are kept.
+func (s *finalGradeService) ComputeFinalGrades(
+	ctx context.Context,
+	instanceID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*dto.FinalGradesResponse, error) {
+	if _, err := s.loadInstance(instanceID); err != nil {
+		return nil, err
+	}
+	scheme, err := s.openScheme(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := s.gradedEnrollments(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.assessmentClient.ListCourseInstanceScores(ctx, instanceID)
+	if err != nil {
+		s.logger.Error("failed to fetch assignment scores", zap.String("course_instance_id", instanceID.String()), zap.Error(err))
+		return nil, utils.ErrInternal("failed to fetch assignment scores", err)
+	}
+	idx := indexScores(scores.Assignments)
+
+	grades := make([]dto.FinalGradeResponse, len(enrollments))
+	for i := range enrollments {
+		e := &enrollments[i]
+		final, breakdown, ok := computeFinalScore(scheme.Categories, scores.Assignments, idx, e.UserID)
+		if !ok {
+			return nil, utils.ErrUnprocessable("no assignments match the grading scheme's categories")
+		}
+		e.FinalScore = &final
+		if !e.GradeOverridden {
+			band, _ := bandFor(scheme.Bands, final)
+			points := band.GradePoints
+			e.FinalGrade = band.Letter
+			e.GradePoints = &points
+		}
+		grades[i] = toFinalGradeResponse(e)
+		grades[i].Categories = breakdown
+	}
+
+	if err := s.gradingSchemeRepo.SaveFinalGrades(scheme.ID, enrollments); err != nil {
+		if errors.Is(err, repository.ErrGradesLocked) {
+			return nil, utils.ErrConflict("final grades for this course instance are locked")
+		}
+		s.logger.Error("failed to save final grades", zap.Error(err))
+		return nil, utils.ErrInternal("failed to save final grades", err)
+	}
+
+	s.audit(client.AuditActionFinalGradesComputed, instanceID, username, map[string]interface{}{
+		"students":    len(enrollments),
+		"assignments": len(scores.Assignments),
+	}, ipAddress, userAgent)
+
+	return finalGradesResponse(instanceID, scheme, grades), nil
+}
+
+// OverrideFinalGrade sets a student's letter grade during review. The grade
+// is kept by later compute runs until the grades are locked.
+func (s *finalGradeService) OverrideFinalGrade(
+	instanceID, userID uuid.UUID,
+	req *dto.OverrideFinalGradeRequest,
+	username, ipAddress, userAgent string,
+) (*dto.FinalGradeResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, utils.ErrBadRequest("reason is required")
+	}
+
+	scheme, err := s.openScheme(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	band, ok := bandForLetter(scheme.Bands, req.FinalGrade)
+	if !ok {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("final_grade %q is not in the grading scheme", req.FinalGrade))
+	}
+
+	enrollment, err := s.enrollmentRepo.GetEnrollment(instanceID, userID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment", err)
+	}
+	if enrollment == nil {
+		return nil, utils.ErrNotFound("enrollment not found")
+	}
+	if !isGradedEnrollment(enrollment.Status) {
+		return nil, utils.ErrUnprocessable("only enrolled students receive a final grade")
+	}
+
+	oldGrade := enrollment.FinalGrade
+	points := band.GradePoints
+	enrollment.FinalGrade = band.Letter
+	enrollment.GradePoints = &points
+	enrollment.GradeOverridden = true
+
+	if err := s.gradingSchemeRepo.SaveFinalGrades(scheme.ID, []domain.Enrollment{*enrollment}); err != nil {
+		if errors.Is(err, repository.ErrGradesLocked) {
+			return nil, utils.ErrConflict("final grades for this course instance are locked")
+		}
+		s.logger.Error("failed to save final grade", zap.Error(err))
+		return nil, utils.ErrInternal("failed to save final grade", err)
+	}
+
+	s.audit(client.AuditActionFinalGradeOverridden, instanceID, username, map[string]interface{}{
+		"user_id": userID.String(),
+		"final_grade": map[string]string{
+			"from": oldGrade,
+			"to":   enrollment.FinalGrade,
+		},
+		"reason": reason,
+	}, ipAddress, userAgent)
+
+	resp := toFinalGradeResponse(enrollment)
+	return &resp, nil
+}
+
+// LockFinalGrades releases the final grades. Every graded student needs a
+// grade; each becomes Completed, or Failed when the grade is worth no grade
+// points.
+func (s *finalGradeService) LockFinalGrades(instanceID uuid.UUID, username, ipAddress, userAgent string) (*dto.FinalGradesResponse, error) {
+	if _, err := s.loadInstance(instanceID); err != nil {
+		return nil, err
+	}
+	scheme, err := s.openScheme(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := s.gradedEnrollments(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ungraded := 0
+	completed, failed := 0, 0
+	grades := make([]dto.FinalGradeResponse, len(enrollments))
+	for i := range enrollments {
+		e := &enrollments[i]
+		if e.FinalGrade == "" {
+			ungraded++
+			continue
+		}
+		e.Status = releasedStatus(e.GradePoints)
+		if e.Status == domain.EnrollmentStatusCompleted {
+			completed++
+		} else {
+			failed++
+		}
+		grades[i] = toFinalGradeResponse(e)
+	}
+	if ungraded > 0 {
+		return nil, utils.ErrUnprocessable(fmt.Sprintf("%d students have no final grade; compute final grades first", ungraded))
+	}
+
+	if err := s.gradingSchemeRepo.Lock(scheme, username, enrollments); err != nil {
+		if errors.Is(err, repository.ErrGradesLocked) {
+			return nil, utils.ErrConflict("final grades for this course instance are already locked")
+		}
+		s.logger.Error("failed to lock final grades", zap.Error(err))
+		return nil, utils.ErrInternal("failed to lock final grades", err)
+	}
+
+	s.audit(client.AuditActionFinalGradesLocked, instanceID, username, map[string]interface{}{
+		"completed": completed,
+		"failed":    failed,
+	}, ipAddress, userAgent)
+
+	s.logger.Info("final grades locked",
+		zap.String("course_instance_id", instanceID.String()),
+		zap.Int("completed", completed),
+		zap.Int("failed", failed),
+	)
+	return finalGradesResponse(instanceID, scheme, grades), nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Changes after lock
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *finalGradeService) PrepareGradeChange(instanceID, userID uuid.UUID, finalGrade string) (*domain.GradeChangePayload, error) {
+	current, amended, err := s.lockedGradeChange(instanceID, userID, finalGrade)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.GradeChangePayload{
+		UserID:        userID,
+		FinalGrade:    amended.FinalGrade,
+		PreviousGrade: current.FinalGrade,
+	}, nil
+}
+
+// AmendFinalGrade applies the change only while the grade is still the one
+// the request was made against.
+func (s *finalGradeService) AmendFinalGrade(
+	instanceID uuid.UUID,
+	change *domain.GradeChangePayload,
+	username, ipAddress, userAgent string,
+) (*dto.FinalGradeResponse, error) {
+	current, amended, err := s.lockedGradeChange(instanceID, change.UserID, change.FinalGrade)
+	if err != nil {
+		return nil, err
+	}
+	if current.FinalGrade != change.PreviousGrade {
+		return nil, utils.ErrConflict("final grade has changed since the change was requested")
+	}
+
+	if err := s.gradingSchemeRepo.AmendFinalGrade(amended, username); err != nil {
+		s.logger.Error("failed to amend final grade", zap.Error(err))
+		return nil, utils.ErrInternal("failed to amend final grade", err)
+	}
+
+	s.audit(client.AuditActionFinalGradeAmended, instanceID, username, map[string]interface{}{
+		"user_id": change.UserID.String(),
+		"final_grade": map[string]string{
+			"from": current.FinalGrade,
+			"to":   amended.FinalGrade,
+		},
+	}, ipAddress, userAgent)
+
+	resp := toFinalGradeResponse(amended)
+	return &resp, nil
+}
+
+// lockedGradeChange loads the enrollment behind a change to a locked grade
+// and returns it along with the amended copy.
+func (s *finalGradeService) lockedGradeChange(instanceID, userID uuid.UUID, finalGrade string) (*domain.Enrollment, *domain.Enrollment, error) {
+	scheme, err := s.loadScheme(instanceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if scheme == nil || !scheme.IsLocked() {
+		return nil, nil, utils.ErrConflict("final grades for this course instance are not locked; override the grade instead")
+	}
+
+	enrollment, err := s.enrollmentRepo.GetEnrollment(instanceID, userID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment", zap.Error(err))
+		return nil, nil, utils.ErrInternal("failed to load enrollment", err)
+	}
+	if enrollment == nil {
+		return nil, nil, utils.ErrNotFound("enrollment not found")
+	}
+
+	amended, err := amendGrade(scheme.Bands, *enrollment, finalGrade)
+	if err != nil {
+		return nil, nil, utils.ErrUnprocessable(err.Error())
+	}
+	return enrollment, &amended, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *finalGradeService) loadInstance(instanceID uuid.UUID) (*domain.CourseInstance, error) {
+	instance, err := s.courseInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load course instance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load course instance", err)
+	}
+	if instance == nil {
+		return nil, utils.ErrNotFound("course instance not found")
+	}
+	return instance, nil
+}
+
+func (s *finalGradeService) loadScheme(instanceID uuid.UUID) (*domain.GradingScheme, error) {
+	scheme, err := s.gradingSchemeRepo.GetByCourseInstance(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load grading scheme", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load grading scheme", err)
+	}
+	return scheme, nil
+}
+
+// openScheme loads a scheme whose grades can still change.
+func (s *finalGradeService) openScheme(instanceID uuid.UUID) (*domain.GradingScheme, error) {
+	scheme, err := s.loadScheme(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == nil {
+		return nil, utils.ErrUnprocessable("set a grading scheme for this course instance first")
+	}
+	if scheme.IsLocked() {
+		return nil, utils.ErrConflict("final grades for this course instance are locked")
+	}
+	return scheme, nil
+}
+
+func (s *finalGradeService) gradedEnrollments(instanceID uuid.UUID) ([]domain.Enrollment, error) {
+	all, err := s.enrollmentRepo.GetEnrollments(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load enrollments", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollments", err)
+	}
+	graded := make([]domain.Enrollment, 0, len(all))
+	for _, e := range all {
+		if isGradedEnrollment(e.Status) {
+			graded = append(graded, e)
+		}
+	}
+	return graded, nil
+}
+
+func (s *finalGradeService) audit(action client.AuditAction, instanceID uuid.UUID, username string, changes map[string]interface{}, ipAddress, userAgent string) {
+	if auditErr := s.auditClient.LogAction(
+		string(action),
+		"course_instance",
+		instanceID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+}
+
+func toFinalGradeResponse(e *domain.Enrollment) dto.FinalGradeResponse {
+	return dto.FinalGradeResponse{
+		UserID:          e.UserID,
+		Status:          e.Status,
+		FinalScore:      e.FinalScore,
+		FinalGrade:      e.FinalGrade,
+		GradePoints:     e.GradePoints,
+		GradeOverridden: e.GradeOverridden,
+	}
+}
+
+func finalGradesResponse(instanceID uuid.UUID, scheme *domain.GradingScheme, grades []dto.FinalGradeResponse) *dto.FinalGradesResponse {
+	resp := &dto.FinalGradesResponse{
+		CourseInstanceID: instanceID,
+		Grades:           grades,
+		Count:            len(grades),
+	}
+	if scheme != nil && scheme.IsLocked() {
+		resp.Locked = true
+		resp.LockedAt = scheme.LockedAt
+	}
+	return resp
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/google/uuid"
+)
+
+// buildGradingScheme validates a grading scheme request and converts it to
+// categories and bands, bands ordered from the highest cut-off down.
+// Category weights must add up to 100, every band needs a distinct letter
+// grade and cut-off, a band must start at 0% so every score gets a grade,
+// and higher cut-offs must award higher letters and no fewer grade points.
+func buildGradingScheme(req *dto.SetGradingSchemeRequest) ([]domain.GradingCategory, []domain.GradeBand, error) {
+	if len(req.Categories) == 0 {
+		return nil, nil, errors.New("at least one category is required")
+	}
+	if len(req.Bands) == 0 {
+		return nil, nil, errors.New("at least one grade band is required")
+	}
+
+	categories := make([]domain.GradingCategory, 0, len(req.Categories))
+	seenCategories := make(map[string]bool)
+	total := 0.0
+	for _, c := range req.Categories {
+		name := strings.ToLower(strings.TrimSpace(c.Name))
+		if name == "" {
+			return nil, nil, errors.New("category name is required")
+		}
+		if seenCategories[name] {
+			return nil, nil, fmt.Errorf("category %q is listed more than once", name)
+		}
+		if c.Weight <= 0 {
+			return nil, nil, fmt.Errorf("category %q must have a positive weight", name)
+		}
+		seenCategories[name] = true
+		total += c.Weight
+		categories = append(categories, domain.GradingCategory{Name: name, Weight: c.Weight})
+	}
+	if math.Abs(total-100) > 0.01 {
+		return nil, nil, fmt.Errorf("category weights must add up to 100, got %g", total)
+	}
+
+	bands := make([]domain.GradeBand, 0, len(req.Bands))
+	seenLetters := make(map[string]bool)
+	for _, b := range req.Bands {
+		letter := strings.ToUpper(strings.TrimSpace(b.Letter))
+		if !domain.IsValidLetterGrade(letter) {
+			return nil, nil, fmt.Errorf("%q is not a valid letter grade", b.Letter)
+		}
+		if seenLetters[letter] {
+			return nil, nil, fmt.Errorf("letter grade %s is listed more than once", letter)
+		}
+		if b.MinPercent < 0 || b.MinPercent > 100 {
+			return nil, nil, fmt.Errorf("min_percent for %s must be between 0 and 100", letter)
+		}
+		if b.GradePoints < 0 {
+			return nil, nil, fmt.Errorf("grade_points for %s must not be negative", letter)
+		}
+		seenLetters[letter] = true
+		bands = append(bands, domain.GradeBand{Letter: letter, MinPercent: b.MinPercent, GradePoints: b.GradePoints})
+	}
+	sort.Slice(bands, func(i, j int) bool { return bands[i].MinPercent > bands[j].MinPercent })
+
+	if bands[len(bands)-1].MinPercent != 0 {
+		return nil, nil, errors.New("the lowest grade band must start at 0")
+	}
+	for i := 1; i < len(bands); i++ {
+		hi, lo := bands[i-1], bands[i]
+		if hi.MinPercent == lo.MinPercent {
+			return nil, nil, fmt.Errorf("%s and %s have the same cut-off", hi.Letter, lo.Letter)
+		}
+		if domain.MeetsMinimumGrade(lo.Letter, hi.Letter) {
+			return nil, nil, fmt.Errorf("%s must have a higher cut-off than %s", lo.Letter, hi.Letter)
+		}
+		if lo.GradePoints > hi.GradePoints {
+			return nil, nil, fmt.Errorf("%s must not award more grade points than %s", lo.Letter, hi.Letter)
+		}
+	}
+	return categories, bands, nil
+}
+
+// scoreIndex maps assignment ID to user ID to effective score.
+type scoreIndex map[uuid.UUID]map[uuid.UUID]float64
+
+func indexScores(assignments []client.AssignmentScores) scoreIndex {
+	idx := make(scoreIndex, len(assignments))
+	for _, a := range assignments {
+		byUser := make(map[uuid.UUID]float64, len(a.Scores))
+		for _, s := range a.Scores {
+			byUser[s.UserID] = s.Score
+		}
+		idx[a.AssignmentID] = byUser
+	}
+	return idx
+}
+
+// computeFinalScore returns a student's weighted percentage. Each category
+// averages the student's scores on the assignments of its type, counting a
+// missing score as 0. Categories without any assignments are left out and the
+// remaining weights scaled up, so ok is false only when no category has one.
+func computeFinalScore(
+	categories []domain.GradingCategory,
+	assignments []client.AssignmentScores,
+	scores scoreIndex,
+	userID uuid.UUID,
+) (final float64, breakdown []dto.CategoryScoreResponse, ok bool) {
+	weighted, weights := 0.0, 0.0
+	for _, c := range categories {
+		item := dto.CategoryScoreResponse{Name: c.Name, Weight: c.Weight}
+		sum := 0.0
+		for _, a := range assignments {
+			if strings.ToLower(strings.TrimSpace(a.AssessmentType)) != c.Name {
+				continue
+			}
+			item.Assignments++
+			score, found := scores[a.AssignmentID][userID]
+			if !found {
+				item.Missing++
+				continue
+			}
+			sum += score
+		}
+		if item.Assignments > 0 {
+			item.Average = roundTo2(sum / float64(item.Assignments))
+			weighted += c.Weight * sum / float64(item.Assignments)
+			weights += c.Weight
+		}
+		breakdown = append(breakdown, item)
+	}
+	if weights == 0 {
+		return 0, breakdown, false
+	}
+	return roundTo2(weighted / weights), breakdown, true
+}
+
+// bandFor returns the band awarded to score. Bands must be ordered from the
+// highest cut-off down and end with a band starting at 0.
+func bandFor(bands []domain.GradeBand, score float64) (domain.GradeBand, bool) {
+	for _, b := range bands {
+		if score >= b.MinPercent {
+			return b, true
+		}
+	}
+	return domain.GradeBand{}, false
+}
+
+// bandForLetter returns the band with the given letter grade.
+func bandForLetter(bands []domain.GradeBand, letter string) (domain.GradeBand, bool) {
+	letter = strings.ToUpper(strings.TrimSpace(letter))
+	for _, b := range bands {
+		if b.Letter == letter {
+			return b, true
+		}
+	}
+	return domain.GradeBand{}, false
+}
+
+// isGradedEnrollment reports whether an enrollment with status s receives a
+// final grade. Dropped and waitlisted students do not.
+func isGradedEnrollment(s string) bool {
+	return s == domain.EnrollmentStatusEnrolled ||
+		s == domain.EnrollmentStatusCompleted ||
+		s == domain.EnrollmentStatusFailed
+}
+
+// releasedStatus is the enrollment status set when final grades are locked:
+// a grade worth no grade points is a fail.
+func releasedStatus(gradePoints *float64) string {
+	if gradePoints == nil || *gradePoints <= 0 {
+		return domain.EnrollmentStatusFailed
+	}
+	return domain.EnrollmentStatusCompleted
+}
+
+// amendGrade validates a change to a released final grade and applies it to
+// a copy of the enrollment. The letter must be in the scheme's bands and
+// differ from the current grade; the status follows the new grade the same
+// way it did when the grades were locked.
+func amendGrade(bands []domain.GradeBand, e domain.Enrollment, letter string) (domain.Enrollment, error) {
+	band, ok := bandForLetter(bands, letter)
+	if !ok {
+		return e, fmt.Errorf("final_grade %q is not in the grading scheme", letter)
+	}
+	if e.Status != domain.EnrollmentStatusCompleted && e.Status != domain.EnrollmentStatusFailed {
+		return e, errors.New("only released final grades can be changed")
+	}
+	if band.Letter == e.FinalGrade {
+		return e, fmt.Errorf("final grade is already %s", band.Letter)
+	}
+
+	points := band.GradePoints
+	e.FinalGrade = band.Letter
+	e.GradePoints = &points
+	e.GradeOverridden = true
+	e.Status = releasedStatus(&points)
+	return e, nil
+}
+
+// summariseCredits totals the credits of transcript courses and computes
+// their credit-weighted GPA. Failed courses count as attempted but not
+// earned; courses without grade points are left out of the GPA.
+func summariseCredits(courses []dto.TranscriptCourseResponse) (attempted, earned int, gpa float64) {
+	points, gpaCredits := 0.0, 0
+	for _, c := range courses {
+		attempted += c.Credits
+		if c.Status == domain.EnrollmentStatusCompleted {
+			earned += c.Credits
+		}
+		if c.GradePoints != nil && c.Credits > 0 {
+			points += *c.GradePoints * float64(c.Credits)
+			gpaCredits += c.Credits
+		}
+	}
+	if gpaCredits == 0 {
+		return attempted, earned, 0
+	}
+	return attempted, earned, roundTo2(points / float64(gpaCredits))
+}
+
+func roundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
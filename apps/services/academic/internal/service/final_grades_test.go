@@ -0,0 +1,182 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func schemeRequest() *dto.SetGradingSchemeRequest {
+	return &dto.SetGradingSchemeRequest{
+		Categories: []dto.GradingCategoryRequest{
+			{Name: "Lab", Weight: 40},
+			{Name: "exam", Weight: 60},
+		},
+		Bands: []dto.GradeBandRequest{
+			{Letter: "F", MinPercent: 0, GradePoints: 0},
+			{Letter: "a", MinPercent: 85, GradePoints: 4},
+			{Letter: "B", MinPercent: 70, GradePoints: 3},
+			{Letter: "C", MinPercent: 55, GradePoints: 2},
+		},
+	}
+}
+
+func TestBuildGradingScheme(t *testing.T) {
+	categories, bands, err := buildGradingScheme(schemeRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "lab", categories[0].Name)
+	assert.Equal(t, []string{"A", "B", "C", "F"}, []string{bands[0].Letter, bands[1].Letter, bands[2].Letter, bands[3].Letter})
+
+	req := schemeRequest()
+	req.Categories[1].Weight = 50
+	_, _, err = buildGradingScheme(req)
+	assert.EqualError(t, err, "category weights must add up to 100, got 90")
+
+	req = schemeRequest()
+	req.Bands[0].MinPercent = 10
+	_, _, err = buildGradingScheme(req)
+	assert.EqualError(t, err, "the lowest grade band must start at 0")
+
+	req = schemeRequest()
+	req.Bands[2].MinPercent = 90
+	_, _, err = buildGradingScheme(req)
+	assert.EqualError(t, err, "A must have a higher cut-off than B")
+
+	req = schemeRequest()
+	req.Bands[3].GradePoints = 3.5
+	_, _, err = buildGradingScheme(req)
+	assert.EqualError(t, err, "C must not award more grade points than B")
+
+	req = schemeRequest()
+	req.Bands[3].Letter = "Z"
+	_, _, err = buildGradingScheme(req)
+	assert.EqualError(t, err, `"Z" is not a valid letter grade`)
+}
+
+func TestComputeFinalScore(t *testing.T) {
+	categories, bands, err := buildGradingScheme(schemeRequest())
+	require.NoError(t, err)
+
+	student := uuid.New()
+	lab1, lab2, exam := uuid.New(), uuid.New(), uuid.New()
+	assignments := []client.AssignmentScores{
+		{AssignmentID: lab1, AssessmentType: "lab", Scores: []client.StudentScore{{UserID: student, Score: 90}}},
+		{AssignmentID: lab2, AssessmentType: "LAB"},
+		{AssignmentID: exam, AssessmentType: "exam", Scores: []client.StudentScore{{UserID: student, Score: 80}}},
+	}
+
+	final, breakdown, ok := computeFinalScore(categories, assignments, indexScores(assignments), student)
+	require.True(t, ok)
+	// Labs average 45 with the missing lab counted as 0: 0.4*45 + 0.6*80.
+	assert.Equal(t, 66.0, final)
+	assert.Equal(t, 1, breakdown[0].Missing)
+
+	band, _ := bandFor(bands, final)
+	assert.Equal(t, "C", band.Letter)
+
+	// Without any exams the lab weight stands alone.
+	final, _, ok = computeFinalScore(categories, assignments[:1], indexScores(assignments[:1]), student)
+	require.True(t, ok)
+	assert.Equal(t, 90.0, final)
+
+	_, _, ok = computeFinalScore(categories, nil, nil, student)
+	assert.False(t, ok)
+}
+
+func TestBandFor(t *testing.T) {
+	_, bands, err := buildGradingScheme(schemeRequest())
+	require.NoError(t, err)
+
+	band, ok := bandFor(bands, 85)
+	assert.True(t, ok)
+	assert.Equal(t, "A", band.Letter, "a cut-off is inclusive")
+
+	band, _ = bandFor(bands, 84.99)
+	assert.Equal(t, "B", band.Letter)
+
+	band, _ = bandFor(bands, 0)
+	assert.Equal(t, "F", band.Letter)
+
+	_, ok = bandForLetter(bands, "b")
+	assert.True(t, ok)
+	_, ok = bandForLetter(bands, "D")
+	assert.False(t, ok)
+}
+
+func TestSummariseCredits(t *testing.T) {
+	four, two, zero := 4.0, 2.0, 0.0
+	courses := []dto.TranscriptCourseResponse{
+		{Credits: 3, Status: domain.EnrollmentStatusCompleted, GradePoints: &four},
+		{Credits: 2, Status: domain.EnrollmentStatusCompleted, GradePoints: &two},
+		{Credits: 1, Status: domain.EnrollmentStatusFailed, GradePoints: &zero},
+		{Credits: 3, Status: domain.EnrollmentStatusCompleted},
+	}
+
+	attempted, earned, gpa := summariseCredits(courses)
+	assert.Equal(t, 9, attempted)
+	assert.Equal(t, 8, earned)
+	// (3*4 + 2*2 + 1*0) / 6; the ungraded course is left out.
+	assert.Equal(t, 2.67, gpa)
+
+	_, _, gpa = summariseCredits(nil)
+	assert.Equal(t, 0.0, gpa)
+}
+
+func TestReleasedStatus(t *testing.T) {
+	one, zero := 1.0, 0.0
+	assert.Equal(t, domain.EnrollmentStatusCompleted, releasedStatus(&one))
+	assert.Equal(t, domain.EnrollmentStatusFailed, releasedStatus(&zero))
+	assert.Equal(t, domain.EnrollmentStatusFailed, releasedStatus(nil))
+}
+
+func TestAmendGrade(t *testing.T) {
+	_, bands, err := buildGradingScheme(schemeRequest())
+	require.NoError(t, err)
+
+	four := 4.0
+	released := domain.Enrollment{Status: domain.EnrollmentStatusCompleted, FinalGrade: "A", GradePoints: &four}
+
+	amended, err := amendGrade(bands, released, "f")
+	require.NoError(t, err)
+	assert.Equal(t, "F", amended.FinalGrade)
+	assert.Equal(t, 0.0, *amended.GradePoints)
+	assert.True(t, amended.GradeOverridden)
+	assert.Equal(t, domain.EnrollmentStatusFailed, amended.Status)
+	assert.Equal(t, "A", released.FinalGrade, "the original is left untouched")
+
+	_, err = amendGrade(bands, released, "a")
+	assert.Error(t, err, "same grade")
+	_, err = amendGrade(bands, released, "D")
+	assert.Error(t, err, "not in the scheme")
+	_, err = amendGrade(bands, domain.Enrollment{Status: domain.EnrollmentStatusEnrolled, FinalGrade: "B"}, "A")
+	assert.Error(t, err, "not released")
+}
+
+func TestRenderTranscriptPDF(t *testing.T) {
+	four := 4.0
+	transcript := &dto.TranscriptResponse{
+		UserID:   uuid.New(),
+		FullName: "Ada (Lovelace)",
+		Terms: []dto.TranscriptTermResponse{{
+			SemesterName: "Fall 2026",
+			SemesterCode: "FALL26",
+			Courses: []dto.TranscriptCourseResponse{
+				{CourseCode: "CS101", CourseTitle: "Programming", Credits: 3, FinalGrade: "A", GradePoints: &four},
+			},
+		}},
+		GeneratedAt: time.Date(2026, time.December, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	pdf := renderTranscriptPDF(transcript)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), `(Student: Ada \(Lovelace\)) Tj`)
+	assert.Contains(t, string(pdf), "(CS101) Tj")
+}
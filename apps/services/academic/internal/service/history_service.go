@@ -0,0 +1,84 @@
+package service
+
+import (
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// HistoryService answers questions about past enrollments and batch
+// memberships, such as who was enrolled in a course instance on its exam
+// date.
+type HistoryService interface {
+	// EnrollmentHistory returns the course instance's enrollment versions,
+	// or one student's when userID is set. With asOf it is the roster at
+	// that time.
+	EnrollmentHistory(instanceID uuid.UUID, userID *uuid.UUID, asOf *time.Time) ([]domain.EnrollmentHistory, error)
+	// MemberHistory returns the batch's membership versions, or one
+	// student's when userID is set. With asOf it is the membership at that
+	// time.
+	MemberHistory(batchID uuid.UUID, userID *uuid.UUID, asOf *time.Time) ([]domain.BatchMemberHistory, error)
+}
+
+// historyService is the concrete implementation.
+type historyService struct {
+	historyRepo        repository.HistoryRepository
+	courseInstanceRepo repository.CourseInstanceRepository
+	batchRepo          repository.BatchRepository
+	logger             *zap.Logger
+}
+
+// NewHistoryService wires all dependencies together.
+func NewHistoryService(
+	historyRepo repository.HistoryRepository,
+	courseInstanceRepo repository.CourseInstanceRepository,
+	batchRepo repository.BatchRepository,
+	logger *zap.Logger,
+) HistoryService {
+	return &historyService{
+		historyRepo:        historyRepo,
+		courseInstanceRepo: courseInstanceRepo,
+		batchRepo:          batchRepo,
+		logger:             logger,
+	}
+}
+
+func (s *historyService) EnrollmentHistory(instanceID uuid.UUID, userID *uuid.UUID, asOf *time.Time) ([]domain.EnrollmentHistory, error) {
+	instance, err := s.courseInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load course instance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load course instance", err)
+	}
+	if instance == nil {
+		return nil, utils.ErrNotFound("course instance not found")
+	}
+
+	versions, err := s.historyRepo.EnrollmentHistory(instanceID, repository.HistoryFilter{UserID: userID, AsOf: asOf})
+	if err != nil {
+		s.logger.Error("failed to load enrollment history", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment history", err)
+	}
+	return versions, nil
+}
+
+func (s *historyService) MemberHistory(batchID uuid.UUID, userID *uuid.UUID, asOf *time.Time) ([]domain.BatchMemberHistory, error) {
+	batch, err := s.batchRepo.GetBatchByID(batchID)
+	if err != nil {
+		s.logger.Error("failed to load batch", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load batch", err)
+	}
+	if batch == nil {
+		return nil, utils.ErrNotFound("batch not found")
+	}
+
+	versions, err := s.historyRepo.MemberHistory(batchID, repository.HistoryFilter{UserID: userID, AsOf: asOf})
+	if err != nil {
+		s.logger.Error("failed to load batch membership history", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load batch membership history", err)
+	}
+	return versions, nil
+}
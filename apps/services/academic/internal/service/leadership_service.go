@@ -0,0 +1,171 @@
+package service
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LeadershipService resolves the capabilities faculty leadership grants:
+// read-only access to every course instance of the faculty, and authority
+// over its approval requests.
+type LeadershipService interface {
+	// MyLeadership returns the active leadership roles the user holds.
+	MyLeadership(userID uuid.UUID) ([]domain.FacultyLeadership, error)
+	IsFacultyLeader(facultyID, userID uuid.UUID) (bool, error)
+	// ActiveLeaderIDs returns the user IDs of the faculty's active leaders.
+	ActiveLeaderIDs(facultyID uuid.UUID) ([]uuid.UUID, error)
+	// FacultyOf returns the faculty owning a course instance.
+	FacultyOf(instanceID uuid.UUID) (uuid.UUID, error)
+	// CheckCourseInstance returns 404 unless the course instance belongs to
+	// the faculty.
+	CheckCourseInstance(facultyID, instanceID uuid.UUID) error
+	ListCourseInstances(facultyID uuid.UUID, semesterID *uuid.UUID) ([]dto.FacultyCourseInstanceSummary, error)
+}
+
+// leadershipService is the concrete implementation.
+type leadershipService struct {
+	leadershipRepo     repository.FacultyLeadershipRepository
+	courseInstanceRepo repository.CourseInstanceRepository
+	enrollmentRepo     repository.EnrollmentRepository
+	approvalRepo       repository.ApprovalRepository
+	logger             *zap.Logger
+}
+
+// NewLeadershipService wires all dependencies together.
+func NewLeadershipService(
+	leadershipRepo repository.FacultyLeadershipRepository,
+	courseInstanceRepo repository.CourseInstanceRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	approvalRepo repository.ApprovalRepository,
+	logger *zap.Logger,
+) LeadershipService {
+	return &leadershipService{
+		leadershipRepo:     leadershipRepo,
+		courseInstanceRepo: courseInstanceRepo,
+		enrollmentRepo:     enrollmentRepo,
+		approvalRepo:       approvalRepo,
+		logger:             logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Roles
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *leadershipService) MyLeadership(userID uuid.UUID) ([]domain.FacultyLeadership, error) {
+	roles, err := s.leadershipRepo.GetActiveLeadershipsByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to load faculty leadership", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load faculty leadership", err)
+	}
+	return roles, nil
+}
+
+func (s *leadershipService) IsFacultyLeader(facultyID, userID uuid.UUID) (bool, error) {
+	roles, err := s.MyLeadership(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range roles {
+		if r.FacultyID == facultyID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *leadershipService) ActiveLeaderIDs(facultyID uuid.UUID) ([]uuid.UUID, error) {
+	leaders, err := s.leadershipRepo.GetActiveLeadersByFacultyID(facultyID)
+	if err != nil {
+		s.logger.Error("failed to load faculty leaders", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load faculty leaders", err)
+	}
+	ids := make([]uuid.UUID, len(leaders))
+	for i, l := range leaders {
+		ids[i] = l.UserID
+	}
+	return ids, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Course instances
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *leadershipService) FacultyOf(instanceID uuid.UUID) (uuid.UUID, error) {
+	facultyID, err := s.courseInstanceRepo.GetFacultyID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to resolve course instance faculty", zap.Error(err))
+		return uuid.Nil, utils.ErrInternal("failed to resolve course instance faculty", err)
+	}
+	if facultyID == uuid.Nil {
+		return uuid.Nil, utils.ErrNotFound("course instance not found")
+	}
+	return facultyID, nil
+}
+
+func (s *leadershipService) CheckCourseInstance(facultyID, instanceID uuid.UUID) error {
+	owner, err := s.FacultyOf(instanceID)
+	if err != nil {
+		return err
+	}
+	if owner != facultyID {
+		return utils.ErrNotFound("course instance not found")
+	}
+	return nil
+}
+
+// ListCourseInstances summarises the faculty's course instances with their
+// enrollment counts and pending approval requests.
+func (s *leadershipService) ListCourseInstances(facultyID uuid.UUID, semesterID *uuid.UUID) ([]dto.FacultyCourseInstanceSummary, error) {
+	instances, err := s.courseInstanceRepo.ListByFaculty(facultyID, semesterID)
+	if err != nil {
+		s.logger.Error("failed to list faculty course instances", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list faculty course instances", err)
+	}
+
+	ids := make([]uuid.UUID, len(instances))
+	for i, inst := range instances {
+		ids[i] = inst.ID
+	}
+	counts, err := s.enrollmentRepo.CountByStatus(ids)
+	if err != nil {
+		s.logger.Error("failed to count enrollments", zap.Error(err))
+		return nil, utils.ErrInternal("failed to count enrollments", err)
+	}
+	pending, err := s.approvalRepo.List(repository.ApprovalFilter{
+		FacultyIDs: []uuid.UUID{facultyID},
+		Status:     domain.ApprovalStatusPending,
+	})
+	if err != nil {
+		s.logger.Error("failed to list approval requests", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list approval requests", err)
+	}
+	pendingByInstance := make(map[uuid.UUID]int)
+	for _, a := range pending {
+		pendingByInstance[a.CourseInstanceID]++
+	}
+
+	summaries := make([]dto.FacultyCourseInstanceSummary, len(instances))
+	for i, inst := range instances {
+		byStatus := counts[inst.ID]
+		summaries[i] = dto.FacultyCourseInstanceSummary{
+			ID:               inst.ID,
+			CourseID:         inst.CourseID,
+			SemesterID:       inst.SemesterID,
+			BatchID:          inst.BatchID,
+			Status:           inst.Status,
+			MaxEnrollment:    inst.MaxEnrollment,
+			Enrolled:         byStatus[domain.EnrollmentStatusEnrolled],
+			Waitlisted:       byStatus[domain.EnrollmentStatusWaitlisted],
+			Dropped:          byStatus[domain.EnrollmentStatusDropped],
+			Completed:        byStatus[domain.EnrollmentStatusCompleted],
+			Failed:           byStatus[domain.EnrollmentStatusFailed],
+			PendingApprovals: pendingByInstance[inst.ID],
+		}
+	}
+	return summaries, nil
+}
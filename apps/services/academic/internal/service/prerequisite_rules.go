@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+)
+
+// unmetPrerequisites evaluates a course's prerequisite rules against a
+// student's enrollment history and describes every requirement that is not
+// met. semesterID is the semester of the course instance being enrolled
+// into; co-requisites taken in that semester count as met.
+func unmetPrerequisites(rules []domain.CoursePrerequisite, history []domain.Enrollment, semesterID uuid.UUID) []string {
+	// Rules sharing a non-zero group are alternatives; each group-0 rule
+	// stands alone.
+	var order []string
+	groups := make(map[string][]domain.CoursePrerequisite)
+	for _, r := range rules {
+		key := r.PrerequisiteCourseID.String()
+		if r.Group != 0 {
+			key = fmt.Sprintf("group:%d", r.Group)
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], r)
+	}
+
+	var unmet []string
+	for _, key := range order {
+		alternatives := groups[key]
+		met := false
+		for _, r := range alternatives {
+			if prerequisiteMet(r, history, semesterID) {
+				met = true
+				break
+			}
+		}
+		if met {
+			continue
+		}
+
+		labels := make([]string, len(alternatives))
+		for i, r := range alternatives {
+			labels[i] = describePrerequisite(r)
+		}
+		unmet = append(unmet, strings.Join(labels, " or "))
+	}
+	return unmet
+}
+
+// prerequisiteMet reports whether the history satisfies a single rule.
+func prerequisiteMet(r domain.CoursePrerequisite, history []domain.Enrollment, semesterID uuid.UUID) bool {
+	for _, e := range history {
+		if e.CourseInstance == nil || e.CourseInstance.CourseID != r.PrerequisiteCourseID {
+			continue
+		}
+		if e.Status == domain.EnrollmentStatusCompleted && domain.MeetsMinimumGrade(e.FinalGrade, r.MinGrade) {
+			return true
+		}
+		if r.Kind == domain.PrerequisiteKindCorequisite &&
+			e.Status == domain.EnrollmentStatusEnrolled &&
+			e.CourseInstance.SemesterID == semesterID {
+			return true
+		}
+	}
+	return false
+}
+
+func describePrerequisite(r domain.CoursePrerequisite) string {
+	label := r.PrerequisiteCourseID.String()
+	if r.PrerequisiteCourse != nil {
+		label = r.PrerequisiteCourse.Code
+	}
+	if r.MinGrade != "" {
+		label += " (minimum grade " + r.MinGrade + ")"
+	}
+	if r.Kind == domain.PrerequisiteKindCorequisite {
+		label += " (or taken concurrently)"
+	}
+	return label
+}
+
+// findPrerequisiteCycle reports whether adding rule to the existing rules
+// would make a course depend on itself, returning the cycle as a list of
+// course IDs starting and ending with rule.CourseID. A cycle made only of
+// co-requisites is allowed, since both courses can be taken together; one
+// that passes through any prerequisite can never be satisfied.
+func findPrerequisiteCycle(rules []domain.CoursePrerequisite, rule domain.CoursePrerequisite) []uuid.UUID {
+	type edge struct {
+		to     uuid.UUID
+		strict bool
+	}
+	graph := make(map[uuid.UUID][]edge)
+	for _, r := range rules {
+		if r.CourseID == rule.CourseID && r.PrerequisiteCourseID == rule.PrerequisiteCourseID {
+			continue
+		}
+		graph[r.CourseID] = append(graph[r.CourseID], edge{
+			to:     r.PrerequisiteCourseID,
+			strict: r.Kind != domain.PrerequisiteKindCorequisite,
+		})
+	}
+
+	// Breadth-first search over (course, passed-a-prerequisite) states,
+	// starting from the new rule's required course.
+	type state struct {
+		course uuid.UUID
+		strict bool
+	}
+	start := state{course: rule.PrerequisiteCourseID, strict: rule.Kind != domain.PrerequisiteKindCorequisite}
+	parent := map[state]state{start: start}
+	queue := []state{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if cur.course == rule.CourseID && cur.strict {
+			path := []uuid.UUID{cur.course}
+			for cur != start {
+				cur = parent[cur]
+				path = append(path, cur.course)
+			}
+			path = append(path, rule.CourseID)
+			// Reverse so the path reads course → requirement → … → course.
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+
+		for _, e := range graph[cur.course] {
+			next := state{course: e.to, strict: cur.strict || e.strict}
+			if _, seen := parent[next]; seen {
+				continue
+			}
+			parent[next] = cur
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func historyEntry(courseID, semesterID uuid.UUID, status, grade string) domain.Enrollment {
+	return domain.Enrollment{
+		Status:     status,
+		FinalGrade: grade,
+		CourseInstance: &domain.CourseInstance{
+			CourseID:   courseID,
+			SemesterID: semesterID,
+		},
+	}
+}
+
+func TestUnmetPrerequisites_AndOrGroups(t *testing.T) {
+	course, a, b, c := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	semester := uuid.New()
+	rules := []domain.CoursePrerequisite{
+		{CourseID: course, PrerequisiteCourseID: a, Kind: domain.PrerequisiteKindPrerequisite},
+		{CourseID: course, PrerequisiteCourseID: b, Kind: domain.PrerequisiteKindPrerequisite, Group: 1},
+		{CourseID: course, PrerequisiteCourseID: c, Kind: domain.PrerequisiteKindPrerequisite, Group: 1},
+	}
+
+	// A and one of B/C completed
+	history := []domain.Enrollment{
+		historyEntry(a, uuid.New(), domain.EnrollmentStatusCompleted, "B"),
+		historyEntry(c, uuid.New(), domain.EnrollmentStatusCompleted, "C"),
+	}
+	assert.Empty(t, unmetPrerequisites(rules, history, semester))
+
+	// Only A completed: the B/C group is unmet
+	unmet := unmetPrerequisites(rules, history[:1], semester)
+	assert.Equal(t, []string{b.String() + " or " + c.String()}, unmet)
+
+	// A still in progress does not count
+	history[0].Status = domain.EnrollmentStatusEnrolled
+	assert.Len(t, unmetPrerequisites(rules, history, semester), 1)
+}
+
+func TestUnmetPrerequisites_MinGrade(t *testing.T) {
+	course, a := uuid.New(), uuid.New()
+	rules := []domain.CoursePrerequisite{
+		{CourseID: course, PrerequisiteCourseID: a, Kind: domain.PrerequisiteKindPrerequisite, MinGrade: "B"},
+	}
+
+	low := []domain.Enrollment{historyEntry(a, uuid.New(), domain.EnrollmentStatusCompleted, "C+")}
+	assert.Len(t, unmetPrerequisites(rules, low, uuid.New()), 1)
+
+	high := []domain.Enrollment{historyEntry(a, uuid.New(), domain.EnrollmentStatusCompleted, "b+")}
+	assert.Empty(t, unmetPrerequisites(rules, high, uuid.New()))
+}
+
+func TestUnmetPrerequisites_Corequisite(t *testing.T) {
+	course, lab := uuid.New(), uuid.New()
+	semester := uuid.New()
+	rules := []domain.CoursePrerequisite{
+		{CourseID: course, PrerequisiteCourseID: lab, Kind: domain.PrerequisiteKindCorequisite},
+	}
+
+	same := []domain.Enrollment{historyEntry(lab, semester, domain.EnrollmentStatusEnrolled, "")}
+	assert.Empty(t, unmetPrerequisites(rules, same, semester))
+
+	other := []domain.Enrollment{historyEntry(lab, uuid.New(), domain.EnrollmentStatusEnrolled, "")}
+	assert.Len(t, unmetPrerequisites(rules, other, semester), 1)
+
+	waitlisted := []domain.Enrollment{historyEntry(lab, semester, domain.EnrollmentStatusWaitlisted, "")}
+	assert.Len(t, unmetPrerequisites(rules, waitlisted, semester), 1)
+}
+
+func TestFindPrerequisiteCycle(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	rules := []domain.CoursePrerequisite{
+		{CourseID: a, PrerequisiteCourseID: b, Kind: domain.PrerequisiteKindPrerequisite},
+		{CourseID: b, PrerequisiteCourseID: c, Kind: domain.PrerequisiteKindPrerequisite},
+	}
+
+	// c requires a closes a → b → c → a
+	cycle := findPrerequisiteCycle(rules, domain.CoursePrerequisite{
+		CourseID: c, PrerequisiteCourseID: a, Kind: domain.PrerequisiteKindPrerequisite,
+	})
+	assert.Equal(t, []uuid.UUID{c, a, b, c}, cycle)
+
+	// a new branch is fine
+	assert.Nil(t, findPrerequisiteCycle(rules, domain.CoursePrerequisite{
+		CourseID: a, PrerequisiteCourseID: c, Kind: domain.PrerequisiteKindPrerequisite,
+	}))
+}
+
+func TestFindPrerequisiteCycle_Corequisites(t *testing.T) {
+	lecture, lab, intro := uuid.New(), uuid.New(), uuid.New()
+	rules := []domain.CoursePrerequisite{
+		{CourseID: lecture, PrerequisiteCourseID: lab, Kind: domain.PrerequisiteKindCorequisite},
+	}
+
+	// Mutual co-requisites can be taken together
+	assert.Nil(t, findPrerequisiteCycle(rules, domain.CoursePrerequisite{
+		CourseID: lab, PrerequisiteCourseID: lecture, Kind: domain.PrerequisiteKindCorequisite,
+	}))
+
+	// lab requiring lecture to be completed first can never be met
+	assert.NotNil(t, findPrerequisiteCycle(rules, domain.CoursePrerequisite{
+		CourseID: lab, PrerequisiteCourseID: lecture, Kind: domain.PrerequisiteKindPrerequisite,
+	}))
+
+	// A strict edge anywhere on the loop is a cycle
+	rules = append(rules, domain.CoursePrerequisite{CourseID: lab, PrerequisiteCourseID: intro, Kind: domain.PrerequisiteKindPrerequisite})
+	assert.NotNil(t, findPrerequisiteCycle(rules, domain.CoursePrerequisite{
+		CourseID: intro, PrerequisiteCourseID: lecture, Kind: domain.PrerequisiteKindCorequisite,
+	}))
+}
@@ -0,0 +1,923 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SectionService defines the business-logic contract for course sections,
+// lab groups and their timetable.
+type SectionService interface {
+	CreateSection(instanceID uuid.UUID, req *dto.CreateSectionRequest, username, ipAddress, userAgent string) (*dto.SectionResponse, error)
+	UpdateSection(instanceID, sectionID uuid.UUID, req *dto.UpdateSectionRequest, username, ipAddress, userAgent string) (*dto.SectionResponse, error)
+	DeleteSection(instanceID, sectionID uuid.UUID, username, ipAddress, userAgent string) error
+	GetSection(instanceID, sectionID uuid.UUID) (*dto.SectionResponse, error)
+	ListSections(instanceID uuid.UUID) ([]dto.SectionResponse, error)
+
+	AssignInstructor(instanceID, sectionID uuid.UUID, req *dto.SectionInstructorRequest, username, ipAddress, userAgent string) (*dto.SectionResponse, error)
+	RemoveInstructor(instanceID, sectionID, userID uuid.UUID, username, ipAddress, userAgent string) error
+
+	AddSlot(instanceID, sectionID uuid.UUID, req *dto.SectionSlotRequest, username, ipAddress, userAgent string) (*dto.SectionResponse, error)
+	RemoveSlot(instanceID, sectionID, slotID uuid.UUID, username, ipAddress, userAgent string) error
+
+	AssignStudents(instanceID uuid.UUID, req *dto.AssignSectionStudentsRequest, username, ipAddress, userAgent string) (*dto.AssignSectionStudentsResponse, error)
+	ListMembers(instanceID, sectionID uuid.UUID) ([]domain.SectionMember, error)
+	RemoveMember(instanceID, sectionID, userID uuid.UUID, username, ipAddress, userAgent string) error
+
+	// ListUserSections returns the sections a student belongs to, across all
+	// their courses or within one course instance when instanceID is set.
+	ListUserSections(userID uuid.UUID, instanceID *uuid.UUID) ([]dto.SectionResponse, error)
+}
+
+// sectionService is the concrete implementation.
+type sectionService struct {
+	sectionRepo          repository.SectionRepository
+	courseInstanceRepo   repository.CourseInstanceRepository
+	courseInstructorRepo repository.CourseInstructorRepository
+	enrollmentRepo       repository.EnrollmentRepository
+	batchRepo            repository.BatchRepository
+	batchMemberRepo      repository.BatchMemberRepository
+	auditClient          *client.AuditClient
+	logger               *zap.Logger
+}
+
+// NewSectionService wires all dependencies together.
+func NewSectionService(
+	sectionRepo repository.SectionRepository,
+	courseInstanceRepo repository.CourseInstanceRepository,
+	courseInstructorRepo repository.CourseInstructorRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	batchRepo repository.BatchRepository,
+	batchMemberRepo repository.BatchMemberRepository,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) SectionService {
+	return &sectionService{
+		sectionRepo:          sectionRepo,
+		courseInstanceRepo:   courseInstanceRepo,
+		courseInstructorRepo: courseInstructorRepo,
+		enrollmentRepo:       enrollmentRepo,
+		batchRepo:            batchRepo,
+		batchMemberRepo:      batchMemberRepo,
+		auditClient:          auditClient,
+		logger:               logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Sections
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *sectionService) CreateSection(
+	instanceID uuid.UUID,
+	req *dto.CreateSectionRequest,
+	username, ipAddress, userAgent string,
+) (*dto.SectionResponse, error) {
+	if _, err := s.getInstance(instanceID); err != nil {
+		return nil, err
+	}
+
+	req.Kind = strings.TrimSpace(req.Kind)
+	req.Name = strings.TrimSpace(req.Name)
+	if !domain.IsValidSectionKind(req.Kind) {
+		return nil, utils.ErrBadRequest("invalid kind: allowed values are lecture, lab")
+	}
+	if req.Name == "" {
+		return nil, utils.ErrBadRequest("name is required")
+	}
+	if req.Capacity < 0 {
+		return nil, utils.ErrBadRequest("capacity cannot be negative")
+	}
+	if err := s.checkBatch(req.BatchID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameFree(instanceID, uuid.Nil, req.Name); err != nil {
+		return nil, err
+	}
+
+	section := &domain.CourseSection{
+		CourseInstanceID: instanceID,
+		Kind:             req.Kind,
+		Name:             req.Name,
+		Capacity:         req.Capacity,
+		BatchID:          req.BatchID,
+	}
+	if err := s.sectionRepo.Create(section); err != nil {
+		s.logger.Error("failed to create section", zap.Error(err))
+		return nil, utils.ErrInternal("failed to create section", err)
+	}
+
+	s.audit(client.AuditActionSectionCreated, section.ID, map[string]interface{}{
+		"course_instance_id": instanceID.String(),
+		"kind":               section.Kind,
+		"name":               section.Name,
+		"capacity":           section.Capacity,
+	}, username, ipAddress, userAgent)
+
+	s.logger.Info("section created",
+		zap.String("course_instance_id", instanceID.String()),
+		zap.String("section_id", section.ID.String()),
+	)
+	return s.GetSection(instanceID, section.ID)
+}
+
+func (s *sectionService) UpdateSection(
+	instanceID, sectionID uuid.UUID,
+	req *dto.UpdateSectionRequest,
+	username, ipAddress, userAgent string,
+) (*dto.SectionResponse, error) {
+	section, err := s.getSection(instanceID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, utils.ErrBadRequest("name cannot be empty")
+		}
+		if err := s.checkNameFree(instanceID, sectionID, name); err != nil {
+			return nil, err
+		}
+		section.Name = name
+		changes["name"] = name
+	}
+	if req.Capacity != nil {
+		if *req.Capacity < 0 {
+			return nil, utils.ErrBadRequest("capacity cannot be negative")
+		}
+		section.Capacity = *req.Capacity
+		changes["capacity"] = *req.Capacity
+	}
+	switch {
+	case req.ClearBatch:
+		section.BatchID = nil
+		changes["batch_id"] = nil
+	case req.BatchID != nil:
+		if err := s.checkBatch(req.BatchID); err != nil {
+			return nil, err
+		}
+		section.BatchID = req.BatchID
+		changes["batch_id"] = req.BatchID.String()
+	}
+
+	if err := s.sectionRepo.Update(section); err != nil {
+		s.logger.Error("failed to update section", zap.Error(err))
+		return nil, utils.ErrInternal("failed to update section", err)
+	}
+
+	s.audit(client.AuditActionSectionUpdated, sectionID, changes, username, ipAddress, userAgent)
+	return s.GetSection(instanceID, sectionID)
+}
+
+func (s *sectionService) DeleteSection(
+	instanceID, sectionID uuid.UUID,
+	username, ipAddress, userAgent string,
+) error {
+	section, err := s.getSection(instanceID, sectionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sectionRepo.Delete(sectionID); err != nil {
+		s.logger.Error("failed to delete section", zap.Error(err))
+		return utils.ErrInternal("failed to delete section", err)
+	}
+
+	s.audit(client.AuditActionSectionDeleted, sectionID, map[string]interface{}{
+		"course_instance_id": instanceID.String(),
+		"name":               section.Name,
+	}, username, ipAddress, userAgent)
+
+	s.logger.Info("section deleted", zap.String("section_id", sectionID.String()))
+	return nil
+}
+
+func (s *sectionService) GetSection(instanceID, sectionID uuid.UUID) (*dto.SectionResponse, error) {
+	section, err := s.getSection(instanceID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.sectionRepo.CountMembers(instanceID)
+	if err != nil {
+		s.logger.Error("failed to count section members", zap.Error(err))
+		return nil, utils.ErrInternal("failed to count section members", err)
+	}
+
+	resp := toSectionResponse(section, counts[section.ID])
+	return &resp, nil
+}
+
+func (s *sectionService) ListSections(instanceID uuid.UUID) ([]dto.SectionResponse, error) {
+	if _, err := s.getInstance(instanceID); err != nil {
+		return nil, err
+	}
+
+	sections, err := s.sectionRepo.ListByInstance(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list sections", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list sections", err)
+	}
+	counts, err := s.sectionRepo.CountMembers(instanceID)
+	if err != nil {
+		s.logger.Error("failed to count section members", zap.Error(err))
+		return nil, utils.ErrInternal("failed to count section members", err)
+	}
+
+	resp := make([]dto.SectionResponse, len(sections))
+	for i := range sections {
+		resp[i] = toSectionResponse(&sections[i], counts[sections[i].ID])
+	}
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Instructors
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *sectionService) AssignInstructor(
+	instanceID, sectionID uuid.UUID,
+	req *dto.SectionInstructorRequest,
+	username, ipAddress, userAgent string,
+) (*dto.SectionResponse, error) {
+	if req.UserID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+	if req.Role != "" && !domain.IsValidInstructorRole(req.Role) {
+		return nil, utils.ErrBadRequest("invalid role: allowed values are Lead Instructor, Instructor, TA")
+	}
+	if _, err := s.getSection(instanceID, sectionID); err != nil {
+		return nil, err
+	}
+
+	// Section staff are drawn from the course instance's own instructors.
+	courseRole, err := s.courseInstructorRepo.GetInstructor(instanceID, req.UserID)
+	if err != nil {
+		s.logger.Error("failed to check instructor assignment", zap.Error(err))
+		return nil, utils.ErrInternal("failed to check instructor assignment", err)
+	}
+	if courseRole == nil {
+		return nil, utils.ErrUnprocessable("user is not an instructor of this course instance")
+	}
+
+	existing, err := s.sectionRepo.GetInstructor(sectionID, req.UserID)
+	if err != nil {
+		s.logger.Error("failed to check section instructor", zap.Error(err))
+		return nil, utils.ErrInternal("failed to check section instructor", err)
+	}
+	if existing != nil {
+		return nil, utils.ErrConflict("instructor is already assigned to this section")
+	}
+
+	role := req.Role
+	if role == "" {
+		role = courseRole.Role
+	}
+	instructor := &domain.SectionInstructor{
+		SectionID: sectionID,
+		UserID:    req.UserID,
+		Role:      role,
+	}
+	if err := s.sectionRepo.AddInstructor(instructor); err != nil {
+		s.logger.Error("failed to assign section instructor", zap.Error(err))
+		return nil, utils.ErrInternal("failed to assign section instructor", err)
+	}
+
+	s.audit(client.AuditActionSectionInstructorAssigned, sectionID, map[string]interface{}{
+		"user_id": req.UserID.String(),
+		"role":    role,
+	}, username, ipAddress, userAgent)
+	return s.GetSection(instanceID, sectionID)
+}
+
+func (s *sectionService) RemoveInstructor(
+	instanceID, sectionID, userID uuid.UUID,
+	username, ipAddress, userAgent string,
+) error {
+	if _, err := s.getSection(instanceID, sectionID); err != nil {
+		return err
+	}
+
+	removed, err := s.sectionRepo.RemoveInstructor(sectionID, userID)
+	if err != nil {
+		s.logger.Error("failed to remove section instructor", zap.Error(err))
+		return utils.ErrInternal("failed to remove section instructor", err)
+	}
+	if !removed {
+		return utils.ErrNotFound("section instructor not found")
+	}
+
+	s.audit(client.AuditActionSectionInstructorRemoved, sectionID, map[string]interface{}{
+		"user_id": userID.String(),
+	}, username, ipAddress, userAgent)
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Timetable slots
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *sectionService) AddSlot(
+	instanceID, sectionID uuid.UUID,
+	req *dto.SectionSlotRequest,
+	username, ipAddress, userAgent string,
+) (*dto.SectionResponse, error) {
+	if req.DayOfWeek < 1 || req.DayOfWeek > 7 {
+		return nil, utils.ErrBadRequest("day_of_week must be between 1 (Monday) and 7 (Sunday)")
+	}
+	start, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		return nil, utils.ErrBadRequest("start_time must be HH:MM")
+	}
+	end, err := time.Parse("15:04", req.EndTime)
+	if err != nil {
+		return nil, utils.ErrBadRequest("end_time must be HH:MM")
+	}
+	if !end.After(start) {
+		return nil, utils.ErrBadRequest("end_time must be after start_time")
+	}
+
+	instance, err := s.getInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getSection(instanceID, sectionID); err != nil {
+		return nil, err
+	}
+
+	slot := &domain.SectionSlot{
+		SectionID: sectionID,
+		DayOfWeek: req.DayOfWeek,
+		// Normalise so that string comparison orders times correctly.
+		StartTime: start.Format("15:04"),
+		EndTime:   end.Format("15:04"),
+		Room:      strings.TrimSpace(req.Room),
+	}
+
+	if slot.Room != "" {
+		clashes, err := s.sectionRepo.FindRoomClashes(instance.SemesterID, slot)
+		if err != nil {
+			s.logger.Error("failed to check room clashes", zap.Error(err))
+			return nil, utils.ErrInternal("failed to check room clashes", err)
+		}
+		if len(clashes) > 0 {
+			c := clashes[0]
+			return nil, utils.ErrConflict(fmt.Sprintf(
+				"room %s is already booked on day %d from %s to %s", c.Room, c.DayOfWeek, c.StartTime, c.EndTime,
+			))
+		}
+	}
+
+	if err := s.sectionRepo.AddSlot(slot); err != nil {
+		s.logger.Error("failed to add slot", zap.Error(err))
+		return nil, utils.ErrInternal("failed to add slot", err)
+	}
+
+	s.audit(client.AuditActionSectionSlotAdded, sectionID, map[string]interface{}{
+		"slot_id":     slot.ID.String(),
+		"day_of_week": slot.DayOfWeek,
+		"start_time":  slot.StartTime,
+		"end_time":    slot.EndTime,
+		"room":        slot.Room,
+	}, username, ipAddress, userAgent)
+	return s.GetSection(instanceID, sectionID)
+}
+
+func (s *sectionService) RemoveSlot(
+	instanceID, sectionID, slotID uuid.UUID,
+	username, ipAddress, userAgent string,
+) error {
+	if _, err := s.getSection(instanceID, sectionID); err != nil {
+		return err
+	}
+
+	removed, err := s.sectionRepo.RemoveSlot(sectionID, slotID)
+	if err != nil {
+		s.logger.Error("failed to remove slot", zap.Error(err))
+		return utils.ErrInternal("failed to remove slot", err)
+	}
+	if !removed {
+		return utils.ErrNotFound("slot not found")
+	}
+
+	s.audit(client.AuditActionSectionSlotRemoved, sectionID, map[string]interface{}{
+		"slot_id": slotID.String(),
+	}, username, ipAddress, userAgent)
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Student assignment
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *sectionService) AssignStudents(
+	instanceID uuid.UUID,
+	req *dto.AssignSectionStudentsRequest,
+	username, ipAddress, userAgent string,
+) (*dto.AssignSectionStudentsResponse, error) {
+	if !domain.IsValidSectionKind(req.Kind) {
+		return nil, utils.ErrBadRequest("invalid kind: allowed values are lecture, lab")
+	}
+	if !domain.IsValidSectionStrategy(req.Strategy) {
+		return nil, utils.ErrBadRequest("invalid strategy: allowed values are manual, round_robin, batch")
+	}
+	if req.Strategy == domain.SectionStrategyManual {
+		if req.SectionID == uuid.Nil {
+			return nil, utils.ErrBadRequest("section_id is required for the manual strategy")
+		}
+		if len(req.UserIDs) == 0 {
+			return nil, utils.ErrBadRequest("user_ids is required for the manual strategy")
+		}
+	}
+	if _, err := s.getInstance(instanceID); err != nil {
+		return nil, err
+	}
+
+	all, err := s.sectionRepo.ListByInstance(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list sections", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list sections", err)
+	}
+	var sections []domain.CourseSection
+	for _, section := range all {
+		if section.Kind == req.Kind {
+			sections = append(sections, section)
+		}
+	}
+	if len(sections) == 0 {
+		return nil, utils.ErrUnprocessable(fmt.Sprintf("course instance has no %s sections", req.Kind))
+	}
+
+	counts, err := s.sectionRepo.CountMembers(instanceID)
+	if err != nil {
+		s.logger.Error("failed to count section members", zap.Error(err))
+		return nil, utils.ErrInternal("failed to count section members", err)
+	}
+	enrolled, err := s.enrolledStudents(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		members    []domain.SectionMember
+		unassigned []uuid.UUID
+		replace    bool
+	)
+	switch req.Strategy {
+	case domain.SectionStrategyManual:
+		replace = true
+		members, unassigned, err = s.placeManually(instanceID, req, sections, counts, enrolled)
+	case domain.SectionStrategyRoundRobin:
+		members, unassigned, err = s.placeRoundRobin(instanceID, req.Kind, sections, counts, enrolled)
+	case domain.SectionStrategyBatch:
+		members, unassigned, err = s.placeByBatch(instanceID, req.Kind, sections, counts, enrolled)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inserted, err := s.sectionRepo.AssignMembers(members, replace)
+	if err != nil {
+		s.logger.Error("failed to assign section members", zap.Error(err))
+		return nil, utils.ErrInternal("failed to assign section members", err)
+	}
+
+	perSection := make(map[uuid.UUID]int)
+	for _, m := range inserted {
+		perSection[m.SectionID]++
+	}
+	resp := &dto.AssignSectionStudentsResponse{
+		Kind:       req.Kind,
+		Strategy:   req.Strategy,
+		Assigned:   len(inserted),
+		Sections:   []dto.SectionAssignmentCount{},
+		Unassigned: unassigned,
+	}
+	if resp.Unassigned == nil {
+		resp.Unassigned = []uuid.UUID{}
+	}
+	for _, section := range sections {
+		if n := perSection[section.ID]; n > 0 {
+			resp.Sections = append(resp.Sections, dto.SectionAssignmentCount{SectionID: section.ID, Assigned: n})
+		}
+	}
+
+	s.audit(client.AuditActionSectionStudentsAssigned, instanceID, map[string]interface{}{
+		"kind":       req.Kind,
+		"strategy":   req.Strategy,
+		"assigned":   resp.Assigned,
+		"unassigned": len(resp.Unassigned),
+	}, username, ipAddress, userAgent)
+
+	s.logger.Info("section students assigned",
+		zap.String("course_instance_id", instanceID.String()),
+		zap.String("kind", req.Kind),
+		zap.String("strategy", req.Strategy),
+		zap.Int("assigned", resp.Assigned),
+	)
+	return resp, nil
+}
+
+// placeManually places the requested students in one section, moving them
+// out of their current section of the same kind. Students who are not
+// enrolled are reported as unassigned; exceeding capacity is rejected.
+func (s *sectionService) placeManually(
+	instanceID uuid.UUID,
+	req *dto.AssignSectionStudentsRequest,
+	sections []domain.CourseSection,
+	counts map[uuid.UUID]int,
+	enrolled []uuid.UUID,
+) ([]domain.SectionMember, []uuid.UUID, error) {
+	var target *domain.CourseSection
+	for i := range sections {
+		if sections[i].ID == req.SectionID {
+			target = &sections[i]
+		}
+	}
+	if target == nil {
+		return nil, nil, utils.ErrNotFound(fmt.Sprintf("%s section not found in this course instance", req.Kind))
+	}
+
+	current, err := s.sectionRepo.ListMembers(target.ID)
+	if err != nil {
+		s.logger.Error("failed to list section members", zap.Error(err))
+		return nil, nil, utils.ErrInternal("failed to list section members", err)
+	}
+	inSection := make(map[uuid.UUID]bool, len(current))
+	for _, m := range current {
+		inSection[m.UserID] = true
+	}
+	isEnrolled := make(map[uuid.UUID]bool, len(enrolled))
+	for _, id := range enrolled {
+		isEnrolled[id] = true
+	}
+
+	var (
+		members    []domain.SectionMember
+		unassigned []uuid.UUID
+		seen       = make(map[uuid.UUID]bool)
+	)
+	for _, userID := range req.UserIDs {
+		if seen[userID] || inSection[userID] {
+			continue
+		}
+		seen[userID] = true
+		if !isEnrolled[userID] {
+			unassigned = append(unassigned, userID)
+			continue
+		}
+		members = append(members, newSectionMember(instanceID, target, userID))
+	}
+
+	if target.Capacity > 0 && counts[target.ID]+len(members) > target.Capacity {
+		return nil, nil, utils.ErrConflict(fmt.Sprintf(
+			"section %s has %d of %d places left", target.Name, max(target.Capacity-counts[target.ID], 0), target.Capacity,
+		))
+	}
+	return members, unassigned, nil
+}
+
+// placeRoundRobin spreads the enrolled students without a section of the
+// kind over the sections, each going to the emptiest one with room left.
+func (s *sectionService) placeRoundRobin(
+	instanceID uuid.UUID,
+	kind string,
+	sections []domain.CourseSection,
+	counts map[uuid.UUID]int,
+	enrolled []uuid.UUID,
+) ([]domain.SectionMember, []uuid.UUID, error) {
+	pending, err := s.unplaced(instanceID, kind, enrolled)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		members    []domain.SectionMember
+		unassigned []uuid.UUID
+	)
+	for _, userID := range pending {
+		target := emptiestSection(sections, counts, nil)
+		if target == nil {
+			unassigned = append(unassigned, userID)
+			continue
+		}
+		counts[target.ID]++
+		members = append(members, newSectionMember(instanceID, target, userID))
+	}
+	return members, unassigned, nil
+}
+
+// placeByBatch places each enrolled student without a section of the kind in
+// the section linked to their batch or an ancestor of it. A student matching
+// several sections goes to the emptiest.
+func (s *sectionService) placeByBatch(
+	instanceID uuid.UUID,
+	kind string,
+	sections []domain.CourseSection,
+	counts map[uuid.UUID]int,
+	enrolled []uuid.UUID,
+) ([]domain.SectionMember, []uuid.UUID, error) {
+	pending, err := s.unplaced(instanceID, kind, enrolled)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batchMembers := make(map[uuid.UUID]map[uuid.UUID]bool)
+	for _, section := range sections {
+		if section.BatchID == nil {
+			continue
+		}
+		if _, ok := batchMembers[*section.BatchID]; ok {
+			continue
+		}
+		ids, err := s.batchMemberRepo.GetMembersBySubtree(*section.BatchID)
+		if err != nil {
+			s.logger.Error("failed to list batch members", zap.Error(err))
+			return nil, nil, utils.ErrInternal("failed to list batch members", err)
+		}
+		set := make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			set[id] = true
+		}
+		batchMembers[*section.BatchID] = set
+	}
+	if len(batchMembers) == 0 {
+		return nil, nil, utils.ErrUnprocessable(fmt.Sprintf("no %s section is linked to a batch", kind))
+	}
+
+	var (
+		members    []domain.SectionMember
+		unassigned []uuid.UUID
+	)
+	for _, userID := range pending {
+		target := emptiestSection(sections, counts, func(section *domain.CourseSection) bool {
+			return section.BatchID != nil && batchMembers[*section.BatchID][userID]
+		})
+		if target == nil {
+			unassigned = append(unassigned, userID)
+			continue
+		}
+		counts[target.ID]++
+		members = append(members, newSectionMember(instanceID, target, userID))
+	}
+	return members, unassigned, nil
+}
+
+// emptiestSection returns the section with the fewest members that has room
+// left and passes the filter, or nil when there is none. Ties go to the
+// first section in list order.
+func emptiestSection(
+	sections []domain.CourseSection,
+	counts map[uuid.UUID]int,
+	filter func(*domain.CourseSection) bool,
+) *domain.CourseSection {
+	var best *domain.CourseSection
+	for i := range sections {
+		section := &sections[i]
+		if section.Capacity > 0 && counts[section.ID] >= section.Capacity {
+			continue
+		}
+		if filter != nil && !filter(section) {
+			continue
+		}
+		if best == nil || counts[section.ID] < counts[best.ID] {
+			best = section
+		}
+	}
+	return best
+}
+
+// enrolledStudents returns the students currently enrolled in the course
+// instance, in enrollment order. Waitlisted and finished enrollments do not
+// take a section place.
+func (s *sectionService) enrolledStudents(instanceID uuid.UUID) ([]uuid.UUID, error) {
+	enrollments, err := s.enrollmentRepo.GetEnrollments(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list enrollments", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list enrollments", err)
+	}
+
+	var ids []uuid.UUID
+	for _, e := range enrollments {
+		if e.Status == domain.EnrollmentStatusEnrolled {
+			ids = append(ids, e.UserID)
+		}
+	}
+	return ids, nil
+}
+
+// unplaced filters out the students who already have a section of the kind.
+func (s *sectionService) unplaced(instanceID uuid.UUID, kind string, enrolled []uuid.UUID) ([]uuid.UUID, error) {
+	assigned, err := s.sectionRepo.ListAssignedUsers(instanceID, kind)
+	if err != nil {
+		s.logger.Error("failed to list section members", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list section members", err)
+	}
+
+	placed := make(map[uuid.UUID]bool, len(assigned))
+	for _, id := range assigned {
+		placed[id] = true
+	}
+	var pending []uuid.UUID
+	for _, id := range enrolled {
+		if !placed[id] {
+			pending = append(pending, id)
+		}
+	}
+	return pending, nil
+}
+
+func newSectionMember(instanceID uuid.UUID, section *domain.CourseSection, userID uuid.UUID) domain.SectionMember {
+	return domain.SectionMember{
+		SectionID:        section.ID,
+		UserID:           userID,
+		CourseInstanceID: instanceID,
+		Kind:             section.Kind,
+	}
+}
+
+func (s *sectionService) ListMembers(instanceID, sectionID uuid.UUID) ([]domain.SectionMember, error) {
+	if _, err := s.getSection(instanceID, sectionID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.sectionRepo.ListMembers(sectionID)
+	if err != nil {
+		s.logger.Error("failed to list section members", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list section members", err)
+	}
+	return members, nil
+}
+
+func (s *sectionService) RemoveMember(
+	instanceID, sectionID, userID uuid.UUID,
+	username, ipAddress, userAgent string,
+) error {
+	if _, err := s.getSection(instanceID, sectionID); err != nil {
+		return err
+	}
+
+	removed, err := s.sectionRepo.RemoveMember(sectionID, userID)
+	if err != nil {
+		s.logger.Error("failed to remove section member", zap.Error(err))
+		return utils.ErrInternal("failed to remove section member", err)
+	}
+	if !removed {
+		return utils.ErrNotFound("student is not a member of this section")
+	}
+
+	s.audit(client.AuditActionSectionStudentRemoved, sectionID, map[string]interface{}{
+		"user_id": userID.String(),
+	}, username, ipAddress, userAgent)
+	return nil
+}
+
+func (s *sectionService) ListUserSections(userID uuid.UUID, instanceID *uuid.UUID) ([]dto.SectionResponse, error) {
+	if userID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+
+	sections, err := s.sectionRepo.ListUserSections(userID, instanceID)
+	if err != nil {
+		s.logger.Error("failed to list user sections", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list user sections", err)
+	}
+
+	counts := make(map[uuid.UUID]map[uuid.UUID]int)
+	resp := make([]dto.SectionResponse, len(sections))
+	for i := range sections {
+		section := &sections[i]
+		if _, ok := counts[section.CourseInstanceID]; !ok {
+			c, err := s.sectionRepo.CountMembers(section.CourseInstanceID)
+			if err != nil {
+				s.logger.Error("failed to count section members", zap.Error(err))
+				return nil, utils.ErrInternal("failed to count section members", err)
+			}
+			counts[section.CourseInstanceID] = c
+		}
+		resp[i] = toSectionResponse(section, counts[section.CourseInstanceID][section.ID])
+	}
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *sectionService) getInstance(instanceID uuid.UUID) (*domain.CourseInstance, error) {
+	instance, err := s.courseInstanceRepo.GetByID(instanceID)
+	if err != nil {
+		s.logger.Error("failed to load course instance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load course instance", err)
+	}
+	if instance == nil {
+		return nil, utils.ErrNotFound("course instance not found")
+	}
+	return instance, nil
+}
+
+// getSection loads a section and checks it belongs to the course instance,
+// so that a section ID cannot be used through another instance's routes.
+func (s *sectionService) getSection(instanceID, sectionID uuid.UUID) (*domain.CourseSection, error) {
+	section, err := s.sectionRepo.GetByID(sectionID)
+	if err != nil {
+		s.logger.Error("failed to load section", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load section", err)
+	}
+	if section == nil || section.CourseInstanceID != instanceID {
+		return nil, utils.ErrNotFound("section not found")
+	}
+	return section, nil
+}
+
+func (s *sectionService) checkBatch(batchID *uuid.UUID) error {
+	if batchID == nil {
+		return nil
+	}
+	batch, err := s.batchRepo.GetBatchByID(*batchID)
+	if err != nil {
+		s.logger.Error("failed to load batch", zap.Error(err))
+		return utils.ErrInternal("failed to load batch", err)
+	}
+	if batch == nil {
+		return utils.ErrNotFound("batch not found")
+	}
+	return nil
+}
+
+// checkNameFree rejects a name already used by another section of the
+// course instance, ignoring case.
+func (s *sectionService) checkNameFree(instanceID, sectionID uuid.UUID, name string) error {
+	sections, err := s.sectionRepo.ListByInstance(instanceID)
+	if err != nil {
+		s.logger.Error("failed to list sections", zap.Error(err))
+		return utils.ErrInternal("failed to list sections", err)
+	}
+	for _, section := range sections {
+		if section.ID != sectionID && strings.EqualFold(section.Name, name) {
+			return utils.ErrConflict(fmt.Sprintf("a section named %s already exists in this course instance", section.Name))
+		}
+	}
+	return nil
+}
+
+func (s *sectionService) audit(
+	action client.AuditAction,
+	entityID uuid.UUID,
+	changes map[string]interface{},
+	username, ipAddress, userAgent string,
+) {
+	if auditErr := s.auditClient.LogAction(
+		string(action),
+		"course_section",
+		entityID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+}
+
+func toSectionResponse(section *domain.CourseSection, memberCount int) dto.SectionResponse {
+	resp := dto.SectionResponse{
+		ID:               section.ID,
+		CourseInstanceID: section.CourseInstanceID,
+		Kind:             section.Kind,
+		Name:             section.Name,
+		Capacity:         section.Capacity,
+		BatchID:          section.BatchID,
+		MemberCount:      memberCount,
+		Instructors:      make([]dto.SectionInstructorResponse, len(section.Instructors)),
+		Slots:            make([]dto.SectionSlotResponse, len(section.Slots)),
+		CreatedAt:        section.CreatedAt,
+		UpdatedAt:        section.UpdatedAt,
+	}
+	for i, inst := range section.Instructors {
+		resp.Instructors[i] = dto.SectionInstructorResponse{UserID: inst.UserID, Role: inst.Role}
+	}
+	for i, slot := range section.Slots {
+		resp.Slots[i] = dto.SectionSlotResponse{
+			ID:        slot.ID,
+			DayOfWeek: slot.DayOfWeek,
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Room:      slot.Room,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+)
+
+// validateSemesterDates checks that a semester's dates are in order. The
+// semester must end after it starts, the add/drop deadline and exam period
+// must fall within it, and grades cannot be due before the exams end.
+func validateSemesterDates(s *domain.Semester) error {
+	if !s.EndDate.After(s.StartDate) {
+		return errors.New("end_date must be after start_date")
+	}
+	if !s.AddDropDeadline.IsZero() && !withinSemester(s, s.AddDropDeadline) {
+		return errors.New("add_drop_deadline must fall within the semester")
+	}
+	if s.ExamStartDate.IsZero() != s.ExamEndDate.IsZero() {
+		return errors.New("exam_start_date and exam_end_date must be set together")
+	}
+	if !s.ExamStartDate.IsZero() {
+		if s.ExamEndDate.Before(s.ExamStartDate) {
+			return errors.New("exam_end_date must not be before exam_start_date")
+		}
+		if !withinSemester(s, s.ExamStartDate) || !withinSemester(s, s.ExamEndDate) {
+			return errors.New("the exam period must fall within the semester")
+		}
+	}
+	if !s.GradeSubmissionDeadline.IsZero() {
+		if s.GradeSubmissionDeadline.Before(s.StartDate) {
+			return errors.New("grade_submission_deadline must not be before start_date")
+		}
+		if !s.ExamEndDate.IsZero() && s.GradeSubmissionDeadline.Before(s.ExamEndDate) {
+			return errors.New("grade_submission_deadline must not be before exam_end_date")
+		}
+	}
+	return nil
+}
+
+func withinSemester(s *domain.Semester, d domain.Date) bool {
+	return !d.Before(s.StartDate) && !d.After(s.EndDate)
+}
+
+// calendarTransition returns the status the scheduler should move a
+// semester to on the given day. Semesters only move forward through
+// Planned→Active→Completed; ok is false when nothing is due.
+func calendarTransition(s *domain.Semester, today domain.Date) (to string, ok bool) {
+	to = s.ScheduledStatus(today)
+	switch {
+	case s.Status == domain.SemesterStatusPlanned && to != domain.SemesterStatusPlanned:
+		return to, true
+	case s.Status == domain.SemesterStatusActive && to == domain.SemesterStatusCompleted:
+		return to, true
+	default:
+		return "", false
+	}
+}
+
+// courseInstanceTransition returns the course instance statuses that follow
+// a semester moving to the given status.
+func courseInstanceTransition(semesterStatus string) (from []string, to string) {
+	switch semesterStatus {
+	case domain.SemesterStatusActive:
+		return []string{domain.CourseInstanceStatusPlanned}, domain.CourseInstanceStatusActive
+	case domain.SemesterStatusCompleted:
+		return []string{domain.CourseInstanceStatusPlanned, domain.CourseInstanceStatusActive}, domain.CourseInstanceStatusCompleted
+	default:
+		return nil, ""
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func fallSemester() *domain.Semester {
+	return &domain.Semester{
+		TermType:  domain.TermTypeFall,
+		StartDate: domain.NewDate(2026, time.September, 1),
+		EndDate:   domain.NewDate(2026, time.December, 15),
+		Status:    domain.SemesterStatusPlanned,
+	}
+}
+
+func TestValidateSemesterDates(t *testing.T) {
+	assert.NoError(t, validateSemesterDates(fallSemester()))
+
+	s := fallSemester()
+	s.EndDate = s.StartDate
+	assert.EqualError(t, validateSemesterDates(s), "end_date must be after start_date")
+
+	s = fallSemester()
+	s.AddDropDeadline = domain.NewDate(2026, time.August, 31)
+	assert.EqualError(t, validateSemesterDates(s), "add_drop_deadline must fall within the semester")
+
+	s = fallSemester()
+	s.ExamStartDate = domain.NewDate(2026, time.December, 1)
+	assert.EqualError(t, validateSemesterDates(s), "exam_start_date and exam_end_date must be set together")
+
+	s.ExamEndDate = domain.NewDate(2026, time.December, 20)
+	assert.EqualError(t, validateSemesterDates(s), "the exam period must fall within the semester")
+
+	s.ExamEndDate = domain.NewDate(2026, time.December, 12)
+	s.GradeSubmissionDeadline = domain.NewDate(2026, time.December, 10)
+	assert.EqualError(t, validateSemesterDates(s), "grade_submission_deadline must not be before exam_end_date")
+
+	// Grades may be due after the semester ends.
+	s.GradeSubmissionDeadline = domain.NewDate(2027, time.January, 5)
+	assert.NoError(t, validateSemesterDates(s))
+}
+
+func TestSemesterOverlaps(t *testing.T) {
+	a := fallSemester()
+	b := fallSemester()
+	b.StartDate = a.EndDate
+	b.EndDate = a.EndDate.AddDays(30)
+	assert.True(t, a.Overlaps(b), "sharing the last day overlaps")
+
+	b.StartDate = a.EndDate.AddDays(1)
+	assert.False(t, a.Overlaps(b))
+	assert.False(t, b.Overlaps(a))
+}
+
+func TestCalendarTransition(t *testing.T) {
+	s := fallSemester()
+
+	_, ok := calendarTransition(s, domain.NewDate(2026, time.August, 31))
+	assert.False(t, ok, "not started yet")
+
+	to, ok := calendarTransition(s, s.StartDate)
+	assert.True(t, ok)
+	assert.Equal(t, domain.SemesterStatusActive, to)
+
+	// A semester the scheduler missed entirely goes straight to Completed.
+	to, ok = calendarTransition(s, s.EndDate.AddDays(1))
+	assert.True(t, ok)
+	assert.Equal(t, domain.SemesterStatusCompleted, to)
+
+	s.Status = domain.SemesterStatusActive
+	_, ok = calendarTransition(s, s.EndDate)
+	assert.False(t, ok, "still active on its last day")
+
+	s.Status = domain.SemesterStatusCompleted
+	_, ok = calendarTransition(s, s.StartDate)
+	assert.False(t, ok, "never moves backwards")
+
+	s.Status = domain.SemesterStatusCancelled
+	_, ok = calendarTransition(s, s.EndDate.AddDays(1))
+	assert.False(t, ok)
+}
+
+func TestAddDropOpen(t *testing.T) {
+	s := fallSemester()
+	assert.True(t, s.AddDropOpen(s.EndDate), "no deadline set")
+
+	s.AddDropDeadline = domain.NewDate(2026, time.September, 14)
+	assert.True(t, s.AddDropOpen(s.AddDropDeadline))
+	assert.False(t, s.AddDropOpen(s.AddDropDeadline.AddDays(1)))
+}
+
+func TestIsAddDropChange(t *testing.T) {
+	assert.True(t, isAddDropChange(domain.EnrollmentStatusEnrolled, domain.EnrollmentStatusDropped))
+	assert.True(t, isAddDropChange(domain.EnrollmentStatusDropped, domain.EnrollmentStatusEnrolled))
+	assert.True(t, isAddDropChange(domain.EnrollmentStatusWaitlisted, domain.EnrollmentStatusEnrolled))
+	assert.False(t, isAddDropChange(domain.EnrollmentStatusEnrolled, domain.EnrollmentStatusCompleted))
+	assert.False(t, isAddDropChange(domain.EnrollmentStatusFailed, domain.EnrollmentStatusEnrolled))
+	assert.False(t, isAddDropChange(domain.EnrollmentStatusEnrolled, ""))
+}
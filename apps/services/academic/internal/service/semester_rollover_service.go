@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Rollover instance actions.
+const (
+	RolloverActionCreate   = "create"
+	RolloverActionExisting = "existing"
+)
+
+// SemesterRolloverService copies a semester's course instances, instructor
+// assignments and assessment content into another semester.
+type SemesterRolloverService interface {
+	Rollover(ctx context.Context, req *dto.RolloverRequest, username, ipAddress, userAgent string) (*dto.RolloverResponse, error)
+}
+
+// semesterRolloverService is the concrete implementation.
+type semesterRolloverService struct {
+	semesterRepo         repository.SemesterRepository
+	batchRepo            repository.BatchRepository
+	courseInstanceRepo   repository.CourseInstanceRepository
+	courseInstructorRepo repository.CourseInstructorRepository
+	enrollmentService    EnrollmentService
+	assessmentClient     *client.AssessmentClient
+	auditClient          *client.AuditClient
+	logger               *zap.Logger
+}
+
+// NewSemesterRolloverService wires all dependencies together.
+func NewSemesterRolloverService(
+	semesterRepo repository.SemesterRepository,
+	batchRepo repository.BatchRepository,
+	courseInstanceRepo repository.CourseInstanceRepository,
+	courseInstructorRepo repository.CourseInstructorRepository,
+	enrollmentService EnrollmentService,
+	assessmentClient *client.AssessmentClient,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) SemesterRolloverService {
+	return &semesterRolloverService{
+		semesterRepo:         semesterRepo,
+		batchRepo:            batchRepo,
+		courseInstanceRepo:   courseInstanceRepo,
+		courseInstructorRepo: courseInstructorRepo,
+		enrollmentService:    enrollmentService,
+		assessmentClient:     assessmentClient,
+		auditClient:          auditClient,
+		logger:               logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Rollover
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Rollover copies the source semester's course instances into the target
+// semester. Instances that already exist in the target are reused, so a
+// rollover can be re-run after a partial failure. With DryRun nothing is
+// written and the response previews what would be copied.
+func (s *semesterRolloverService) Rollover(
+	ctx context.Context,
+	req *dto.RolloverRequest,
+	username, ipAddress, userAgent string,
+) (*dto.RolloverResponse, error) {
+	// 1. Validate semesters
+	if req.SourceSemesterID == uuid.Nil {
+		return nil, utils.ErrBadRequest("source_semester_id is required")
+	}
+	if req.TargetSemesterID == uuid.Nil {
+		return nil, utils.ErrBadRequest("target_semester_id is required")
+	}
+	if req.SourceSemesterID == req.TargetSemesterID {
+		return nil, utils.ErrBadRequest("source and target semesters must differ")
+	}
+
+	source, err := s.loadSemester(req.SourceSemesterID, "source semester not found")
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.loadSemester(req.TargetSemesterID, "target semester not found")
+	if err != nil {
+		return nil, err
+	}
+	if target.Status == domain.SemesterStatusCompleted || target.Status == domain.SemesterStatusCancelled {
+		return nil, utils.ErrBadRequest("cannot roll over into a " + target.Status + " semester")
+	}
+
+	// 2. Validate batch mappings
+	batchMap := make(map[uuid.UUID]uuid.UUID, len(req.BatchMappings))
+	for _, m := range req.BatchMappings {
+		if m.FromBatchID == uuid.Nil || m.ToBatchID == uuid.Nil {
+			return nil, utils.ErrBadRequest("batch_mappings require from_batch_id and to_batch_id")
+		}
+		batch, err := s.batchRepo.GetBatchByID(m.ToBatchID)
+		if err != nil {
+			s.logger.Error("failed to load batch", zap.Error(err))
+			return nil, utils.ErrInternal("failed to load batch", err)
+		}
+		if batch == nil {
+			return nil, utils.ErrNotFound("batch " + m.ToBatchID.String() + " not found")
+		}
+		if !batch.IsActive {
+			return nil, utils.ErrBadRequest("batch " + batch.Code + " is not active")
+		}
+		batchMap[m.FromBatchID] = m.ToBatchID
+	}
+
+	courseFilter := make(map[uuid.UUID]bool, len(req.CourseIDs))
+	for _, id := range req.CourseIDs {
+		courseFilter[id] = true
+	}
+
+	// 3. Copy course instances and instructors
+	sources, err := s.courseInstanceRepo.ListBySemester(source.ID)
+	if err != nil {
+		s.logger.Error("failed to list course instances", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list course instances", err)
+	}
+
+	resp := &dto.RolloverResponse{
+		DryRun:           req.DryRun,
+		SourceSemesterID: source.ID,
+		TargetSemesterID: target.ID,
+		OffsetDays:       source.StartDate.DaysUntil(target.StartDate),
+		Instances:        make([]dto.RolloverInstanceResponse, 0, len(sources)),
+	}
+
+	instanceStatus := domain.CourseInstanceStatusPlanned
+	if target.Status == domain.SemesterStatusActive {
+		instanceStatus = domain.CourseInstanceStatusActive
+	}
+
+	var mappings []client.CourseInstanceMapping
+	for i := range sources {
+		src := &sources[i]
+		if src.Status == domain.CourseInstanceStatusCancelled {
+			continue
+		}
+		if len(courseFilter) > 0 && !courseFilter[src.CourseID] {
+			continue
+		}
+
+		item, err := s.rollInstance(src, target, batchMap, instanceStatus, req, username, ipAddress, userAgent)
+		if err != nil {
+			return nil, err
+		}
+		if item.Action == RolloverActionCreate {
+			resp.InstancesCreated++
+		}
+		resp.InstructorsCopied += item.InstructorsCopied
+		resp.Instances = append(resp.Instances, *item)
+
+		mapping := client.CourseInstanceMapping{SourceCourseInstanceID: src.ID}
+		if item.TargetCourseInstanceID != nil {
+			mapping.TargetCourseInstanceID = *item.TargetCourseInstanceID
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	// 4. Clone assignments. The instances are already in place, so a failure
+	//    here is reported rather than undoing the rollover.
+	if req.CopyAssignments && len(mappings) > 0 {
+		s.cloneAssignments(ctx, mappings, resp, username)
+	}
+
+	if req.DryRun {
+		return resp, nil
+	}
+
+	// 5. Write a single audit record for the whole rollover
+	changes := map[string]interface{}{
+		"source_semester_id":  source.ID.String(),
+		"target_semester_id":  target.ID.String(),
+		"offset_days":         resp.OffsetDays,
+		"copy_instructors":    req.CopyInstructors,
+		"copy_assignments":    req.CopyAssignments,
+		"instances_created":   resp.InstancesCreated,
+		"instances_existing":  len(resp.Instances) - resp.InstancesCreated,
+		"instructors_copied":  resp.InstructorsCopied,
+		"assignments_cloned":  resp.AssignmentsCloned,
+		"assignments_skipped": resp.AssignmentsSkipped,
+	}
+	if resp.AssignmentError != "" {
+		changes["assignment_error"] = resp.AssignmentError
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionSemesterRolledOver),
+		"semester",
+		target.ID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	s.logger.Info("semester rolled over",
+		zap.String("source", source.Code),
+		zap.String("target", target.Code),
+		zap.Int("instances_created", resp.InstancesCreated),
+		zap.Int("instructors_copied", resp.InstructorsCopied),
+		zap.Int("assignments_cloned", resp.AssignmentsCloned),
+	)
+	return resp, nil
+}
+
+func (s *semesterRolloverService) loadSemester(id uuid.UUID, notFound string) (*domain.Semester, error) {
+	semester, err := s.semesterRepo.GetByID(id)
+	if err != nil {
+		s.logger.Error("failed to load semester", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load semester", err)
+	}
+	if semester == nil {
+		return nil, utils.ErrNotFound(notFound)
+	}
+	return semester, nil
+}
+
+// rollInstance copies one course instance, and optionally its instructors,
+// into the target semester. An instance that already exists there only
+// receives the instructors it is missing.
+func (s *semesterRolloverService) rollInstance(
+	src *domain.CourseInstance,
+	target *domain.Semester,
+	batchMap map[uuid.UUID]uuid.UUID,
+	status string,
+	req *dto.RolloverRequest,
+	username, ipAddress, userAgent string,
+) (*dto.RolloverInstanceResponse, error) {
+	targetBatchID := src.BatchID
+	if mapped, ok := batchMap[src.BatchID]; ok {
+		targetBatchID = mapped
+	}
+
+	item := &dto.RolloverInstanceResponse{
+		SourceCourseInstanceID: src.ID,
+		CourseID:               src.CourseID,
+		SourceBatchID:          src.BatchID,
+		TargetBatchID:          targetBatchID,
+		Action:                 RolloverActionCreate,
+	}
+
+	var instructors []domain.CourseInstructor
+	if req.CopyInstructors {
+		var err error
+		instructors, err = s.courseInstructorRepo.GetByCourseInstance(src.ID)
+		if err != nil {
+			s.logger.Error("failed to list course instructors", zap.Error(err))
+			return nil, utils.ErrInternal("failed to list course instructors", err)
+		}
+	}
+
+	existing, err := s.courseInstanceRepo.GetByUnique(src.CourseID, target.ID, targetBatchID)
+	if err != nil {
+		s.logger.Error("failed to check course instance uniqueness", zap.Error(err))
+		return nil, utils.ErrInternal("failed to check course instance uniqueness", err)
+	}
+
+	if existing != nil {
+		item.Action = RolloverActionExisting
+		item.TargetCourseInstanceID = &existing.ID
+		for _, ins := range instructors {
+			assigned, err := s.courseInstructorRepo.GetInstructor(existing.ID, ins.UserID)
+			if err != nil {
+				s.logger.Error("failed to check instructor assignment", zap.Error(err))
+				return nil, utils.ErrInternal("failed to check instructor assignment", err)
+			}
+			if assigned != nil {
+				continue
+			}
+			if !req.DryRun {
+				if err := s.courseInstructorRepo.AssignInstructor(&domain.CourseInstructor{
+					CourseInstanceID: existing.ID,
+					UserID:           ins.UserID,
+					Role:             ins.Role,
+				}); err != nil {
+					s.logger.Error("failed to assign instructor", zap.Error(err))
+					return nil, utils.ErrInternal("failed to assign instructor", err)
+				}
+			}
+			item.InstructorsCopied++
+		}
+		return item, nil
+	}
+
+	item.InstructorsCopied = len(instructors)
+	if req.DryRun {
+		return item, nil
+	}
+
+	instance := &domain.CourseInstance{
+		CourseID:      src.CourseID,
+		SemesterID:    target.ID,
+		BatchID:       targetBatchID,
+		Status:        status,
+		MaxEnrollment: src.MaxEnrollment,
+
+		IncludeSubBatches: src.IncludeSubBatches,
+	}
+	copies := make([]domain.CourseInstructor, 0, len(instructors))
+	for _, ins := range instructors {
+		copies = append(copies, domain.CourseInstructor{UserID: ins.UserID, Role: ins.Role})
+	}
+	if err := s.courseInstanceRepo.CreateWithInstructors(instance, copies); err != nil {
+		s.logger.Error("failed to create course instance", zap.Error(err))
+		return nil, utils.ErrInternal("failed to create course instance", err)
+	}
+	item.TargetCourseInstanceID = &instance.ID
+
+	// New instances enroll their batch like any other course instance.
+	if err := s.enrollmentService.AutoEnrollBatchMembers(instance, username, ipAddress, userAgent); err != nil {
+		s.logger.Warn("failed to auto-enroll batch members", zap.Error(err), zap.String("instance_id", instance.ID.String()))
+	}
+	return item, nil
+}
+
+// cloneAssignments asks the assessment service to copy the assignments of
+// each mapped course instance and records the outcome on resp.
+func (s *semesterRolloverService) cloneAssignments(ctx context.Context, mappings []client.CourseInstanceMapping, resp *dto.RolloverResponse, username string) {
+	result, err := s.assessmentClient.CloneAssignments(ctx, &client.CloneAssignmentsRequest{
+		Mappings:    mappings,
+		OffsetDays:  resp.OffsetDays,
+		DryRun:      resp.DryRun,
+		RequestedBy: username,
+	})
+	if err != nil {
+		s.logger.Error("failed to clone assignments", zap.Error(err))
+		resp.AssignmentError = err.Error()
+		return
+	}
+
+	resp.AssignmentsCloned = result.Cloned
+	resp.AssignmentsSkipped = result.Skipped
+	resp.Assignments = make([]dto.RolloverAssignmentResponse, 0, len(result.Assignments))
+	for _, a := range result.Assignments {
+		resp.Assignments = append(resp.Assignments, dto.RolloverAssignmentResponse{
+			SourceAssignmentID:     a.SourceAssignmentID,
+			TargetAssignmentID:     a.TargetAssignmentID,
+			SourceCourseInstanceID: a.SourceCourseInstanceID,
+			Title:                  a.Title,
+			ReleaseAt:              a.ReleaseAt,
+			DueAt:                  a.DueAt,
+			Skipped:                a.Skipped,
+			SectionsDropped:        a.SectionsDropped,
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
@@ -17,8 +20,17 @@ type SemesterService interface {
 	DeactivateSemester(id uuid.UUID, username, ipAddress, userAgent string) error
 	GetSemester(id uuid.UUID) (*domain.Semester, error)
 	ListSemesters(includeInactive bool, termType string) ([]domain.Semester, error)
+	// AdvanceCalendar moves semesters whose dates have been reached, and
+	// their course instances, through Planned→Active→Completed.
+	AdvanceCalendar(today domain.Date, username, ipAddress, userAgent string) ([]dto.SemesterTransitionResponse, error)
+	// Run advances the calendar on the given interval until ctx is done.
+	Run(ctx context.Context, interval time.Duration)
 }
 
+// calendarSchedulerUser is recorded as the actor of scheduled calendar
+// transitions.
+const calendarSchedulerUser = "system"
+
 // semesterService is the concrete implementation.
 type semesterService struct {
 	semesterRepo repository.SemesterRepository
@@ -66,6 +78,17 @@ func (s *semesterService) CreateSemester(
 	if req.EndDate == "" {
 		return nil, utils.ErrBadRequest("end_date is required")
 	}
+	dates, err := parseSemesterDates(map[string]string{
+		"start_date":                req.StartDate,
+		"end_date":                  req.EndDate,
+		"add_drop_deadline":         req.AddDropDeadline,
+		"exam_start_date":           req.ExamStartDate,
+		"exam_end_date":             req.ExamEndDate,
+		"grade_submission_deadline": req.GradeSubmissionDeadline,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// Default status to Planned when not provided
 	if req.Status == "" {
@@ -86,13 +109,23 @@ func (s *semesterService) CreateSemester(
 	}
 
 	semester := &domain.Semester{
-		Name:      req.Name,
-		Code:      req.Code,
-		TermType:  req.TermType,
-		StartDate: req.StartDate,
-		EndDate:   req.EndDate,
-		Status:    req.Status,
-		IsActive:  true,
+		Name:                    req.Name,
+		Code:                    req.Code,
+		TermType:                req.TermType,
+		StartDate:               dates["start_date"],
+		EndDate:                 dates["end_date"],
+		Status:                  req.Status,
+		IsActive:                true,
+		AddDropDeadline:         dates["add_drop_deadline"],
+		ExamStartDate:           dates["exam_start_date"],
+		ExamEndDate:             dates["exam_end_date"],
+		GradeSubmissionDeadline: dates["grade_submission_deadline"],
+	}
+	if err := validateSemesterDates(semester); err != nil {
+		return nil, utils.ErrBadRequest(err.Error())
+	}
+	if err := s.checkOverlap(semester); err != nil {
+		return nil, err
 	}
 
 	if err := s.semesterRepo.Create(semester); err != nil {
@@ -101,12 +134,16 @@ func (s *semesterService) CreateSemester(
 	}
 
 	changes := map[string]interface{}{
-		"name":       semester.Name,
-		"code":       semester.Code,
-		"term_type":  semester.TermType,
-		"start_date": semester.StartDate,
-		"end_date":   semester.EndDate,
-		"status":     semester.Status,
+		"name":                      semester.Name,
+		"code":                      semester.Code,
+		"term_type":                 semester.TermType,
+		"start_date":                semester.StartDate.String(),
+		"end_date":                  semester.EndDate.String(),
+		"status":                    semester.Status,
+		"add_drop_deadline":         semester.AddDropDeadline.String(),
+		"exam_start_date":           semester.ExamStartDate.String(),
+		"exam_end_date":             semester.ExamEndDate.String(),
+		"grade_submission_deadline": semester.GradeSubmissionDeadline.String(),
 	}
 	if auditErr := s.auditClient.LogAction(
 		string(client.AuditActionSemesterCreated),
@@ -160,13 +197,30 @@ func (s *semesterService) UpdateSemester(
 		changes["term_type"] = map[string]interface{}{"from": semester.TermType, "to": req.TermType}
 		semester.TermType = req.TermType
 	}
-	if req.StartDate != "" && req.StartDate != semester.StartDate {
-		changes["start_date"] = map[string]interface{}{"from": semester.StartDate, "to": req.StartDate}
-		semester.StartDate = req.StartDate
-	}
-	if req.EndDate != "" && req.EndDate != semester.EndDate {
-		changes["end_date"] = map[string]interface{}{"from": semester.EndDate, "to": req.EndDate}
-		semester.EndDate = req.EndDate
+
+	dates, err := parseSemesterDates(map[string]string{
+		"start_date":                req.StartDate,
+		"end_date":                  req.EndDate,
+		"add_drop_deadline":         req.AddDropDeadline,
+		"exam_start_date":           req.ExamStartDate,
+		"exam_end_date":             req.ExamEndDate,
+		"grade_submission_deadline": req.GradeSubmissionDeadline,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for field, target := range map[string]*domain.Date{
+		"start_date":                &semester.StartDate,
+		"end_date":                  &semester.EndDate,
+		"add_drop_deadline":         &semester.AddDropDeadline,
+		"exam_start_date":           &semester.ExamStartDate,
+		"exam_end_date":             &semester.ExamEndDate,
+		"grade_submission_deadline": &semester.GradeSubmissionDeadline,
+	} {
+		if d := dates[field]; !d.IsZero() && !d.Equal(*target) {
+			changes[field] = map[string]interface{}{"from": target.String(), "to": d.String()}
+			*target = d
+		}
 	}
 	if req.Status != "" && req.Status != semester.Status {
 		if !domain.IsValidSemesterStatus(req.Status) {
@@ -180,6 +234,13 @@ func (s *semesterService) UpdateSemester(
 		semester.IsActive = *req.IsActive
 	}
 
+	if err := validateSemesterDates(semester); err != nil {
+		return nil, utils.ErrBadRequest(err.Error())
+	}
+	if err := s.checkOverlap(semester); err != nil {
+		return nil, err
+	}
+
 	if err := s.semesterRepo.Update(semester); err != nil {
 		s.logger.Error("failed to update semester", zap.Error(err))
 		return nil, utils.ErrInternal("failed to update semester", err)
@@ -278,3 +339,136 @@ func (s *semesterService) ListSemesters(includeInactive bool, termType string) (
 	}
 	return semesters, nil
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Academic calendar
+// ─────────────────────────────────────────────────────────────────────────────
+
+// parseSemesterDates parses the non-empty YYYY-MM-DD values keyed by field
+// name. Empty values come back as zero dates.
+func parseSemesterDates(values map[string]string) (map[string]domain.Date, error) {
+	dates := make(map[string]domain.Date, len(values))
+	for field, value := range values {
+		if value == "" {
+			continue
+		}
+		d, err := domain.ParseDate(value)
+		if err != nil {
+			return nil, utils.ErrBadRequest("invalid " + field + ": expected YYYY-MM-DD")
+		}
+		dates[field] = d
+	}
+	return dates, nil
+}
+
+// checkOverlap rejects a semester whose dates overlap another active
+// semester of the same term type. Cancelled and inactive semesters are not
+// part of the calendar and are not checked.
+func (s *semesterService) checkOverlap(semester *domain.Semester) error {
+	if !semester.IsActive || semester.Status == domain.SemesterStatusCancelled {
+		return nil
+	}
+
+	overlapping, err := s.semesterRepo.ListOverlapping(semester.TermType, semester.StartDate, semester.EndDate, semester.ID)
+	if err != nil {
+		s.logger.Error("failed to check overlapping semesters", zap.Error(err))
+		return utils.ErrInternal("failed to check overlapping semesters", err)
+	}
+	if len(overlapping) > 0 {
+		o := overlapping[0]
+		return utils.ErrConflict("semester overlaps " + o.TermType + " semester " + o.Code +
+			" (" + o.StartDate.String() + " to " + o.EndDate.String() + ")")
+	}
+	return nil
+}
+
+func (s *semesterService) AdvanceCalendar(
+	today domain.Date,
+	username, ipAddress, userAgent string,
+) ([]dto.SemesterTransitionResponse, error) {
+	due, err := s.semesterRepo.ListDueForTransition(today)
+	if err != nil {
+		s.logger.Error("failed to list semesters due for transition", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list semesters due for transition", err)
+	}
+
+	transitions := make([]dto.SemesterTransitionResponse, 0, len(due))
+	for i := range due {
+		semester := &due[i]
+		to, ok := calendarTransition(semester, today)
+		if !ok {
+			continue
+		}
+
+		from := semester.Status
+		instancesFrom, instancesTo := courseInstanceTransition(to)
+		updated, err := s.semesterRepo.Transition(semester, to, instancesFrom, instancesTo)
+		if err != nil {
+			s.logger.Error("failed to transition semester",
+				zap.String("id", semester.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		if semester.Status != to {
+			// Status was changed by someone else in the meantime.
+			continue
+		}
+
+		transitions = append(transitions, dto.SemesterTransitionResponse{
+			SemesterID:             semester.ID,
+			Code:                   semester.Code,
+			From:                   from,
+			To:                     to,
+			CourseInstancesUpdated: updated,
+		})
+
+		changes := map[string]interface{}{
+			"status":                   map[string]interface{}{"from": from, "to": to},
+			"course_instances_updated": updated,
+		}
+		if auditErr := s.auditClient.LogAction(
+			string(client.AuditActionSemesterStatusChanged),
+			"semester",
+			semester.ID.String(),
+			0,
+			username,
+			changes,
+			nil,
+			ipAddress,
+			userAgent,
+		); auditErr != nil {
+			s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+		}
+
+		s.logger.Info("semester status advanced",
+			zap.String("id", semester.ID.String()),
+			zap.String("from", from),
+			zap.String("to", to),
+			zap.Int64("course_instances_updated", updated),
+		)
+	}
+
+	return transitions, nil
+}
+
+func (s *semesterService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.AdvanceCalendar(domain.Today(), calendarSchedulerUser, "", ""); err != nil {
+			s.logger.Warn("scheduled calendar run failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,747 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Record types of a structure import, in the order they are applied.
+const (
+	structureFaculty        = "faculty"
+	structureFacultyLeader  = "faculty_leader"
+	structureDepartment     = "department"
+	structureDegree         = "degree"
+	structureSpecialization = "specialization"
+	structureCourse         = "course"
+	structureBatch          = "batch"
+	structureBatchMember    = "batch_member"
+)
+
+var structureTypes = []string{
+	structureFaculty, structureFacultyLeader, structureDepartment, structureDegree,
+	structureSpecialization, structureCourse, structureBatch, structureBatchMember,
+}
+
+// Row actions reported by a structure import.
+const (
+	structureActionCreate  = "create"
+	structureActionExists  = "exists"
+	structureActionInvalid = "invalid"
+)
+
+// structureHeaders are the columns of the import and export format.
+var structureHeaders = []string{
+	"Type", "Code", "Name", "Parent Code", "Degree Code", "Specialization Code",
+	"Description", "Level", "Credits", "Start Year", "End Year", "User ID", "Role", "Status",
+}
+
+// degreeLevels are the accepted values of Degree.Level.
+var degreeLevels = map[string]struct{}{
+	"Undergraduate": {},
+	"Postgraduate":  {},
+	"Doctoral":      {},
+	"Diploma":       {},
+	"Certificate":   {},
+}
+
+var nonHeaderChars = regexp.MustCompile("[^a-z0-9_]+")
+
+// normalizeStructureHeader turns "Parent Code" or "parent-code" into
+// "parent_code".
+func normalizeStructureHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	h = strings.NewReplacer(" ", "_", "-", "_").Replace(h)
+	return nonHeaderChars.ReplaceAllString(h, "")
+}
+
+// parseStructureRows maps spreadsheet records onto rows by header name.
+// Unknown columns are ignored; the Type column is required.
+func parseStructureRows(headers []string, records [][]string) ([]dto.StructureRow, error) {
+	index := make(map[string]int, len(headers))
+	for i, h := range headers {
+		index[normalizeStructureHeader(h)] = i
+	}
+	if _, ok := index["type"]; !ok {
+		return nil, fmt.Errorf("the import file needs a Type column")
+	}
+
+	rows := make([]dto.StructureRow, 0, len(records))
+	for _, record := range records {
+		get := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := dto.StructureRow{
+			Type:               strings.ToLower(get("type")),
+			Code:               get("code"),
+			Name:               get("name"),
+			ParentCode:         get("parent_code"),
+			DegreeCode:         get("degree_code"),
+			SpecializationCode: get("specialization_code"),
+			Description:        get("description"),
+			Level:              get("level"),
+			Credits:            get("credits"),
+			StartYear:          get("start_year"),
+			EndYear:            get("end_year"),
+			UserID:             get("user_id"),
+			Role:               get("role"),
+			Status:             get("status"),
+		}
+		if row == (dto.StructureRow{}) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// structureRecord is the inverse of parseStructureRows, in header order.
+func structureRecord(r dto.StructureRow) []string {
+	return []string{
+		r.Type, r.Code, r.Name, r.ParentCode, r.DegreeCode, r.SpecializationCode,
+		r.Description, r.Level, r.Credits, r.StartYear, r.EndYear, r.UserID, r.Role, r.Status,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Planning
+// ─────────────────────────────────────────────────────────────────────────────
+
+// structureRef is an existing or planned record that rows can reference.
+// parentID is the owning faculty, department or degree; row is the data row
+// that creates it, or 0 for records already stored.
+type structureRef struct {
+	id       uuid.UUID
+	parentID uuid.UUID
+	active   bool
+	row      int
+}
+
+// structureIndex looks records up by code. Department, degree and
+// specialization codes are only unique under their parent, so a code can
+// resolve to several records; batch codes are unique within a degree.
+type structureIndex struct {
+	faculties       map[string]*structureRef
+	departments     map[string][]*structureRef
+	degrees         map[string][]*structureRef
+	specializations map[string][]*structureRef
+	courses         map[string]*structureRef
+	batches         map[string]*structureRef // keyed by degree ID + code
+	leaders         map[string]struct{}      // keyed by faculty ID + user ID
+	members         map[string]struct{}      // keyed by batch ID + user ID
+}
+
+func newStructureIndex(snap *repository.StructureSnapshot) *structureIndex {
+	idx := &structureIndex{
+		faculties:       make(map[string]*structureRef),
+		departments:     make(map[string][]*structureRef),
+		degrees:         make(map[string][]*structureRef),
+		specializations: make(map[string][]*structureRef),
+		courses:         make(map[string]*structureRef),
+		batches:         make(map[string]*structureRef),
+		leaders:         make(map[string]struct{}),
+		members:         make(map[string]struct{}),
+	}
+	for _, f := range snap.Faculties {
+		idx.faculties[f.Code] = &structureRef{id: f.ID, active: f.IsActive}
+		for _, l := range f.Leaders {
+			idx.leaders[pairKey(f.ID, l.UserID.String())] = struct{}{}
+		}
+	}
+	for _, d := range snap.Departments {
+		idx.departments[d.Code] = append(idx.departments[d.Code], &structureRef{id: d.ID, parentID: d.FacultyID, active: d.IsActive})
+	}
+	for _, d := range snap.Degrees {
+		idx.degrees[d.Code] = append(idx.degrees[d.Code], &structureRef{id: d.ID, parentID: d.DepartmentID, active: d.IsActive})
+	}
+	for _, s := range snap.Specializations {
+		idx.specializations[s.Code] = append(idx.specializations[s.Code], &structureRef{id: s.ID, parentID: s.DegreeID, active: s.IsActive})
+	}
+	for _, c := range snap.Courses {
+		idx.courses[c.Code] = &structureRef{id: c.ID, active: c.IsActive}
+	}
+	for _, b := range snap.Batches {
+		idx.batches[pairKey(b.DegreeID, b.Code)] = &structureRef{id: b.ID, parentID: b.DegreeID, active: b.IsActive}
+	}
+	for _, m := range snap.Members {
+		idx.members[pairKey(m.BatchID, m.UserID.String())] = struct{}{}
+	}
+	return idx
+}
+
+func pairKey(id uuid.UUID, s string) string {
+	return id.String() + "/" + s
+}
+
+// resolveCode picks the single record with the given code, failing when the
+// code is unknown or shared by records under different parents.
+func resolveCode(refs []*structureRef, what, code string) (*structureRef, error) {
+	switch len(refs) {
+	case 0:
+		return nil, fmt.Errorf("%s %q not found", what, code)
+	case 1:
+		return refs[0], nil
+	default:
+		return nil, fmt.Errorf("%s code %q is ambiguous", what, code)
+	}
+}
+
+func childOf(refs []*structureRef, parentID uuid.UUID) *structureRef {
+	for _, r := range refs {
+		if r.parentID == parentID {
+			return r
+		}
+	}
+	return nil
+}
+
+// structurePlan is the outcome of checking an import against the current
+// structure: the records to create and a result per row.
+type structurePlan struct {
+	changes repository.StructureChanges
+	results []dto.StructureRowResult
+	// newMembers are the memberships to auto-enroll once applied.
+	newMembers []domain.BatchMember
+}
+
+// valid reports whether every row can be applied.
+func (p *structurePlan) valid() bool {
+	for _, r := range p.results {
+		if r.Action == structureActionInvalid {
+			return false
+		}
+	}
+	return true
+}
+
+// planStructureImport validates the rows against the snapshot and works out
+// which records to create. Rows are checked in dependency order, so a row
+// may reference records created further down the file. Records that already
+// exist are matched by code and left unchanged.
+func planStructureImport(rows []dto.StructureRow, snap *repository.StructureSnapshot) *structurePlan {
+	p := &planner{
+		idx:  newStructureIndex(snap),
+		plan: &structurePlan{results: make([]dto.StructureRowResult, len(rows))},
+		rows: rows,
+	}
+	for i, r := range rows {
+		p.plan.results[i] = dto.StructureRowResult{RowIndex: i + 1, Data: r}
+	}
+
+	// New faculties need a leader row, so note which faculties have one.
+	p.ledFaculties = make(map[string]struct{})
+	for _, r := range rows {
+		if r.Type == structureFacultyLeader {
+			p.ledFaculties[r.ParentCode] = struct{}{}
+		}
+	}
+
+	known := make(map[string]struct{}, len(structureTypes))
+	for _, t := range structureTypes {
+		known[t] = struct{}{}
+	}
+	for i, r := range rows {
+		if _, ok := known[r.Type]; !ok {
+			p.fail(i, fmt.Sprintf("unknown type %q (must be one of %s)", r.Type, strings.Join(structureTypes, ", ")))
+		}
+	}
+
+	p.each(structureFaculty, p.faculty)
+	p.each(structureFacultyLeader, p.facultyLeader)
+	p.each(structureDepartment, p.department)
+	p.each(structureDegree, p.degree)
+	p.each(structureSpecialization, p.specialization)
+	p.each(structureCourse, p.course)
+	p.batches()
+	p.each(structureBatchMember, p.batchMember)
+
+	return p.plan
+}
+
+type planner struct {
+	idx          *structureIndex
+	plan         *structurePlan
+	rows         []dto.StructureRow
+	ledFaculties map[string]struct{}
+}
+
+func (p *planner) each(typ string, fn func(i int, r dto.StructureRow) []string) {
+	for i, r := range p.rows {
+		if r.Type != typ {
+			continue
+		}
+		if errs := fn(i, r); len(errs) > 0 {
+			p.fail(i, errs...)
+		}
+	}
+}
+
+func (p *planner) fail(i int, errs ...string) {
+	p.plan.results[i].Action = structureActionInvalid
+	p.plan.results[i].Errors = append(p.plan.results[i].Errors, errs...)
+}
+
+func (p *planner) mark(i int, action string) {
+	p.plan.results[i].Action = action
+}
+
+// duplicate describes a row that repeats a record planned by an earlier row.
+func duplicate(ref *structureRef) []string {
+	return []string{fmt.Sprintf("duplicates row %d", ref.row)}
+}
+
+func checkLength(errs []string, field, value string, min, max int) []string {
+	if value == "" {
+		return append(errs, field+" is required")
+	}
+	if len(value) < min || len(value) > max {
+		return append(errs, fmt.Sprintf("%s must be between %d and %d characters", field, min, max))
+	}
+	return errs
+}
+
+func (p *planner) faculty(i int, r dto.StructureRow) []string {
+	errs := checkLength(nil, "code", r.Code, 2, 50)
+	errs = checkLength(errs, "name", r.Name, 3, 255)
+	if len(errs) > 0 {
+		return errs
+	}
+	if ref, ok := p.idx.faculties[r.Code]; ok {
+		if ref.row != 0 {
+			return duplicate(ref)
+		}
+		p.mark(i, structureActionExists)
+		return nil
+	}
+	if _, ok := p.ledFaculties[r.Code]; !ok {
+		return []string{"a new faculty needs at least one faculty_leader row"}
+	}
+
+	f := domain.Faculty{ID: uuid.New(), Code: r.Code, Name: r.Name, Description: r.Description, IsActive: true}
+	p.idx.faculties[r.Code] = &structureRef{id: f.ID, active: true, row: i + 1}
+	p.plan.changes.Faculties = append(p.plan.changes.Faculties, f)
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+func (p *planner) facultyLeader(i int, r dto.StructureRow) []string {
+	var errs []string
+	if r.ParentCode == "" {
+		errs = append(errs, "parent code (faculty) is required")
+	}
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		errs = append(errs, "user ID must be a valid UUID")
+	}
+	errs = checkLength(errs, "role", r.Role, 3, 100)
+	if len(errs) > 0 {
+		return errs
+	}
+	faculty, ok := p.idx.faculties[r.ParentCode]
+	if !ok {
+		return []string{fmt.Sprintf("faculty %q not found", r.ParentCode)}
+	}
+
+	key := pairKey(faculty.id, userID.String())
+	if _, ok := p.idx.leaders[key]; ok {
+		p.mark(i, structureActionExists)
+		return nil
+	}
+	p.idx.leaders[key] = struct{}{}
+	p.plan.changes.Leaders = append(p.plan.changes.Leaders, domain.FacultyLeadership{
+		FacultyID: faculty.id, UserID: userID, Role: r.Role, IsActive: true,
+	})
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+func (p *planner) department(i int, r dto.StructureRow) []string {
+	errs := checkLength(nil, "code", r.Code, 2, 50)
+	errs = checkLength(errs, "name", r.Name, 3, 255)
+	if r.ParentCode == "" {
+		errs = append(errs, "parent code (faculty) is required")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	faculty, ok := p.idx.faculties[r.ParentCode]
+	if !ok {
+		return []string{fmt.Sprintf("faculty %q not found", r.ParentCode)}
+	}
+
+	if ref := childOf(p.idx.departments[r.Code], faculty.id); ref != nil {
+		if ref.row != 0 {
+			return duplicate(ref)
+		}
+		p.mark(i, structureActionExists)
+		return nil
+	}
+	if !faculty.active {
+		return []string{fmt.Sprintf("faculty %q is inactive", r.ParentCode)}
+	}
+
+	d := domain.Department{ID: uuid.New(), FacultyID: faculty.id, Code: r.Code, Name: r.Name, Description: r.Description, IsActive: true}
+	p.idx.departments[r.Code] = append(p.idx.departments[r.Code], &structureRef{id: d.ID, parentID: faculty.id, active: true, row: i + 1})
+	p.plan.changes.Departments = append(p.plan.changes.Departments, d)
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+func (p *planner) degree(i int, r dto.StructureRow) []string {
+	errs := checkLength(nil, "code", r.Code, 2, 50)
+	errs = checkLength(errs, "name", r.Name, 3, 255)
+	if r.ParentCode == "" {
+		errs = append(errs, "parent code (department) is required")
+	}
+	if _, ok := degreeLevels[r.Level]; !ok {
+		errs = append(errs, "level must be one of Undergraduate, Postgraduate, Doctoral, Diploma or Certificate")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	dept, err := resolveCode(p.idx.departments[r.ParentCode], "department", r.ParentCode)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	if ref := childOf(p.idx.degrees[r.Code], dept.id); ref != nil {
+		if ref.row != 0 {
+			return duplicate(ref)
+		}
+		p.mark(i, structureActionExists)
+		return nil
+	}
+	if !dept.active {
+		return []string{fmt.Sprintf("department %q is inactive", r.ParentCode)}
+	}
+
+	d := domain.Degree{ID: uuid.New(), DepartmentID: dept.id, Code: r.Code, Name: r.Name, Level: r.Level, IsActive: true}
+	p.idx.degrees[r.Code] = append(p.idx.degrees[r.Code], &structureRef{id: d.ID, parentID: dept.id, active: true, row: i + 1})
+	p.plan.changes.Degrees = append(p.plan.changes.Degrees, d)
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+func (p *planner) specialization(i int, r dto.StructureRow) []string {
+	errs := checkLength(nil, "code", r.Code, 1, 50)
+	errs = checkLength(errs, "name", r.Name, 3, 255)
+	if r.ParentCode == "" {
+		errs = append(errs, "parent code (degree) is required")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	degree, err := resolveCode(p.idx.degrees[r.ParentCode], "degree", r.ParentCode)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	if ref := childOf(p.idx.specializations[r.Code], degree.id); ref != nil {
+		if ref.row != 0 {
+			return duplicate(ref)
+		}
+		p.mark(i, structureActionExists)
+		return nil
+	}
+	if !degree.active {
+		return []string{fmt.Sprintf("degree %q is inactive", r.ParentCode)}
+	}
+
+	s := domain.Specialization{ID: uuid.New(), DegreeID: degree.id, Code: r.Code, Name: r.Name, IsActive: true}
+	p.idx.specializations[r.Code] = append(p.idx.specializations[r.Code], &structureRef{id: s.ID, parentID: degree.id, active: true, row: i + 1})
+	p.plan.changes.Specializations = append(p.plan.changes.Specializations, s)
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+func (p *planner) course(i int, r dto.StructureRow) []string {
+	errs := checkLength(nil, "code", r.Code, 1, 50)
+	errs = checkLength(errs, "name", r.Name, 1, 255)
+	credits := 0
+	if r.Credits != "" {
+		n, err := strconv.Atoi(r.Credits)
+		if err != nil || n < 0 {
+			errs = append(errs, "credits must be a non-negative integer")
+		}
+		credits = n
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if ref, ok := p.idx.courses[r.Code]; ok {
+		if ref.row != 0 {
+			return duplicate(ref)
+		}
+		p.mark(i, structureActionExists)
+		return nil
+	}
+
+	c := domain.Course{ID: uuid.New(), Code: r.Code, Title: r.Name, Description: r.Description, Credits: credits, IsActive: true}
+	p.idx.courses[r.Code] = &structureRef{id: c.ID, active: true, row: i + 1}
+	p.plan.changes.Courses = append(p.plan.changes.Courses, c)
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+// batches plans batch rows in rounds: a batch whose parent is itself created
+// by the import waits until the parent has been planned, so parents are
+// always inserted first whatever order the file lists them in.
+func (p *planner) batches() {
+	var pending []int
+	for i, r := range p.rows {
+		if r.Type != structureBatch {
+			continue
+		}
+		if errs := p.checkBatch(r); len(errs) > 0 {
+			p.fail(i, errs...)
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	for len(pending) > 0 {
+		var waiting []int
+		for _, i := range pending {
+			r := p.rows[i]
+			if r.ParentCode != "" && p.parentPlannedLater(r) {
+				waiting = append(waiting, i)
+				continue
+			}
+			if errs := p.batch(i, r); len(errs) > 0 {
+				p.fail(i, errs...)
+			}
+		}
+		if len(waiting) == len(pending) {
+			// No progress: the remaining parents are missing or form a cycle.
+			for _, i := range waiting {
+				p.fail(i, fmt.Sprintf("parent batch %q not found", p.rows[i].ParentCode))
+			}
+			return
+		}
+		pending = waiting
+	}
+}
+
+// checkBatch validates the columns of a batch row.
+func (p *planner) checkBatch(r dto.StructureRow) []string {
+	errs := checkLength(nil, "code", r.Code, 1, 50)
+	errs = checkLength(errs, "name", r.Name, 2, 255)
+	if r.DegreeCode == "" {
+		errs = append(errs, "degree code is required")
+	}
+	start, startErr := optionalInt(r.StartYear)
+	if startErr != nil {
+		errs = append(errs, "start year must be a whole number")
+	}
+	end, endErr := optionalInt(r.EndYear)
+	if endErr != nil {
+		errs = append(errs, "end year must be a whole number")
+	}
+	if startErr == nil && endErr == nil && start != 0 && end != 0 && end < start {
+		errs = append(errs, "end year must be greater than or equal to start year")
+	}
+	if r.ParentCode == r.Code && r.Code != "" {
+		errs = append(errs, "a batch cannot be its own parent")
+	}
+	return errs
+}
+
+// parentPlannedLater reports whether the batch's parent is another batch row
+// that has not been planned yet.
+func (p *planner) parentPlannedLater(r dto.StructureRow) bool {
+	refs := p.idx.degrees[r.DegreeCode]
+	if len(refs) != 1 {
+		return false
+	}
+	if _, ok := p.idx.batches[pairKey(refs[0].id, r.ParentCode)]; ok {
+		return false
+	}
+	for j, other := range p.rows {
+		if other.Type == structureBatch && other.Code == r.ParentCode && other.DegreeCode == r.DegreeCode &&
+			p.plan.results[j].Action == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *planner) batch(i int, r dto.StructureRow) []string {
+	degree, err := resolveCode(p.idx.degrees[r.DegreeCode], "degree", r.DegreeCode)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	if ref, ok := p.idx.batches[pairKey(degree.id, r.Code)]; ok {
+		if ref.row != 0 {
+			return duplicate(ref)
+		}
+		p.mark(i, structureActionExists)
+		return nil
+	}
+	if !degree.active {
+		return []string{fmt.Sprintf("degree %q is inactive", r.DegreeCode)}
+	}
+
+	b := domain.Batch{ID: uuid.New(), DegreeID: degree.id, Code: r.Code, Name: r.Name, IsActive: true}
+	b.StartYear, _ = optionalInt(r.StartYear)
+	b.EndYear, _ = optionalInt(r.EndYear)
+	if r.ParentCode != "" {
+		parent, ok := p.idx.batches[pairKey(degree.id, r.ParentCode)]
+		if !ok {
+			return []string{fmt.Sprintf("parent batch %q not found in degree %q", r.ParentCode, r.DegreeCode)}
+		}
+		b.ParentID = &parent.id
+	}
+	if r.SpecializationCode != "" {
+		spec := childOf(p.idx.specializations[r.SpecializationCode], degree.id)
+		if spec == nil {
+			return []string{fmt.Sprintf("specialization %q not found in degree %q", r.SpecializationCode, r.DegreeCode)}
+		}
+		b.SpecializationID = &spec.id
+	}
+
+	p.idx.batches[pairKey(degree.id, r.Code)] = &structureRef{id: b.ID, parentID: degree.id, active: true, row: i + 1}
+	p.plan.changes.Batches = append(p.plan.changes.Batches, b)
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+func (p *planner) batchMember(i int, r dto.StructureRow) []string {
+	var errs []string
+	if r.ParentCode == "" {
+		errs = append(errs, "parent code (batch) is required")
+	}
+	if r.DegreeCode == "" {
+		errs = append(errs, "degree code is required")
+	}
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		errs = append(errs, "user ID must be a valid UUID")
+	}
+	status := r.Status
+	if status == "" {
+		status = domain.BatchMemberStatusActive
+	}
+	if !domain.IsValidBatchMemberStatus(status) {
+		errs = append(errs, "status must be one of Active, Graduated, Suspended or Withdrawn")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	degree, err := resolveCode(p.idx.degrees[r.DegreeCode], "degree", r.DegreeCode)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	batch, ok := p.idx.batches[pairKey(degree.id, r.ParentCode)]
+	if !ok {
+		return []string{fmt.Sprintf("batch %q not found in degree %q", r.ParentCode, r.DegreeCode)}
+	}
+
+	key := pairKey(batch.id, userID.String())
+	if _, ok := p.idx.members[key]; ok {
+		p.mark(i, structureActionExists)
+		return nil
+	}
+	if !batch.active {
+		return []string{fmt.Sprintf("batch %q is not active", r.ParentCode)}
+	}
+
+	m := domain.BatchMember{BatchID: batch.id, UserID: userID, Status: status}
+	p.idx.members[key] = struct{}{}
+	p.plan.changes.Members = append(p.plan.changes.Members, m)
+	p.plan.newMembers = append(p.plan.newMembers, m)
+	p.mark(i, structureActionCreate)
+	return nil
+}
+
+func optionalInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Export
+// ─────────────────────────────────────────────────────────────────────────────
+
+// exportStructureRows renders the snapshot in the import format, parents
+// before children, so exporting and re-importing is a no-op.
+func exportStructureRows(snap *repository.StructureSnapshot) []dto.StructureRow {
+	faculties := make(map[uuid.UUID]string, len(snap.Faculties))
+	departments := make(map[uuid.UUID]string, len(snap.Departments))
+	degrees := make(map[uuid.UUID]string, len(snap.Degrees))
+	specializations := make(map[uuid.UUID]string, len(snap.Specializations))
+	batches := make(map[uuid.UUID]*domain.Batch, len(snap.Batches))
+	for _, f := range snap.Faculties {
+		faculties[f.ID] = f.Code
+	}
+	for _, d := range snap.Departments {
+		departments[d.ID] = d.Code
+	}
+	for _, d := range snap.Degrees {
+		degrees[d.ID] = d.Code
+	}
+	for _, s := range snap.Specializations {
+		specializations[s.ID] = s.Code
+	}
+	for i := range snap.Batches {
+		batches[snap.Batches[i].ID] = &snap.Batches[i]
+	}
+
+	var rows []dto.StructureRow
+	for _, f := range snap.Faculties {
+		rows = append(rows, dto.StructureRow{Type: structureFaculty, Code: f.Code, Name: f.Name, Description: f.Description})
+	}
+	for _, f := range snap.Faculties {
+		for _, l := range f.Leaders {
+			rows = append(rows, dto.StructureRow{Type: structureFacultyLeader, ParentCode: f.Code, UserID: l.UserID.String(), Role: l.Role})
+		}
+	}
+	for _, d := range snap.Departments {
+		rows = append(rows, dto.StructureRow{Type: structureDepartment, Code: d.Code, Name: d.Name, ParentCode: faculties[d.FacultyID], Description: d.Description})
+	}
+	for _, d := range snap.Degrees {
+		rows = append(rows, dto.StructureRow{Type: structureDegree, Code: d.Code, Name: d.Name, ParentCode: departments[d.DepartmentID], Level: d.Level})
+	}
+	for _, s := range snap.Specializations {
+		rows = append(rows, dto.StructureRow{Type: structureSpecialization, Code: s.Code, Name: s.Name, ParentCode: degrees[s.DegreeID]})
+	}
+	for _, c := range snap.Courses {
+		rows = append(rows, dto.StructureRow{Type: structureCourse, Code: c.Code, Name: c.Title, Description: c.Description, Credits: strconv.Itoa(c.Credits)})
+	}
+	for _, b := range snap.Batches {
+		row := dto.StructureRow{Type: structureBatch, Code: b.Code, Name: b.Name, DegreeCode: degrees[b.DegreeID]}
+		if b.ParentID != nil && batches[*b.ParentID] != nil {
+			row.ParentCode = batches[*b.ParentID].Code
+		}
+		if b.SpecializationID != nil {
+			row.SpecializationCode = specializations[*b.SpecializationID]
+		}
+		if b.StartYear != 0 {
+			row.StartYear = strconv.Itoa(b.StartYear)
+		}
+		if b.EndYear != 0 {
+			row.EndYear = strconv.Itoa(b.EndYear)
+		}
+		rows = append(rows, row)
+	}
+	for _, m := range snap.Members {
+		b := batches[m.BatchID]
+		if b == nil {
+			continue
+		}
+		rows = append(rows, dto.StructureRow{Type: structureBatchMember, ParentCode: b.Code, DegreeCode: degrees[b.DegreeID], UserID: m.UserID.String(), Status: m.Status})
+	}
+	return rows
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// StructureImportService imports and exports faculties, departments,
+// degrees, specializations, courses, batches and batch members as a single
+// CSV or XLSX sheet.
+type StructureImportService interface {
+	GenerateTemplate(format string) ([]byte, string, error)
+	// Preview checks a file against the current structure without writing.
+	Preview(reader io.Reader, filename string) (*dto.StructureImportResponse, error)
+	// Execute re-checks the file and, when every row is valid, creates all
+	// new records in one transaction. Otherwise nothing is written and the
+	// report has Applied set to false.
+	Execute(reader io.Reader, filename string, actorID uuid.UUID, username, ipAddress, userAgent string) (*dto.StructureImportResponse, error)
+	Export(format string) ([]byte, string, error)
+}
+
+// structureImportService is the concrete implementation.
+type structureImportService struct {
+	structureRepo     repository.StructureRepository
+	enrollmentService EnrollmentService
+	auditClient       *client.AuditClient
+	logger            *zap.Logger
+}
+
+// NewStructureImportService wires all dependencies together.
+func NewStructureImportService(
+	structureRepo repository.StructureRepository,
+	enrollmentService EnrollmentService,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) StructureImportService {
+	return &structureImportService{
+		structureRepo:     structureRepo,
+		enrollmentService: enrollmentService,
+		auditClient:       auditClient,
+		logger:            logger,
+	}
+}
+
+func (s *structureImportService) GenerateTemplate(format string) ([]byte, string, error) {
+	return writeStructureSheet(nil, format)
+}
+
+func (s *structureImportService) Preview(reader io.Reader, filename string) (*dto.StructureImportResponse, error) {
+	plan, err := s.plan(reader, filename)
+	if err != nil {
+		return nil, err
+	}
+	return toStructureImportResponse(plan, false), nil
+}
+
+func (s *structureImportService) Execute(
+	reader io.Reader,
+	filename string,
+	actorID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*dto.StructureImportResponse, error) {
+	plan, err := s.plan(reader, filename)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.valid() {
+		return toStructureImportResponse(plan, false), nil
+	}
+
+	for i := range plan.changes.Batches {
+		plan.changes.Batches[i].CreatedBy = actorID
+	}
+	if err := s.structureRepo.Apply(&plan.changes, username); err != nil {
+		s.logger.Error("failed to apply structure import", zap.Error(err))
+		return nil, utils.ErrInternal("failed to apply structure import", err)
+	}
+	resp := toStructureImportResponse(plan, true)
+
+	// New members get the batch's courses, as when added one at a time.
+	for _, m := range plan.newMembers {
+		if err := s.enrollmentService.AutoEnrollStudentInBatchCourses(m.UserID, m.BatchID, username, ipAddress, userAgent); err != nil {
+			s.logger.Warn("failed to auto-enroll imported batch member",
+				zap.String("user_id", m.UserID.String()),
+				zap.String("batch_id", m.BatchID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	changes := map[string]interface{}{
+		"filename":      filename,
+		"total_rows":    resp.TotalRows,
+		"existing_rows": resp.ExistingRows,
+	}
+	for typ, n := range resp.Created {
+		changes[typ+"_created"] = n
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionStructureImported),
+		"academic_structure",
+		"",
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	s.logger.Info("academic structure imported",
+		zap.String("filename", filename),
+		zap.Int("rows_created", resp.CreateRows),
+		zap.Int("rows_existing", resp.ExistingRows),
+	)
+	return resp, nil
+}
+
+func (s *structureImportService) Export(format string) ([]byte, string, error) {
+	snap, err := s.structureRepo.LoadSnapshot()
+	if err != nil {
+		s.logger.Error("failed to load academic structure", zap.Error(err))
+		return nil, "", utils.ErrInternal("failed to load academic structure", err)
+	}
+	return writeStructureSheet(exportStructureRows(snap), format)
+}
+
+// plan reads the file and checks it against the current structure.
+func (s *structureImportService) plan(reader io.Reader, filename string) (*structurePlan, error) {
+	headers, records, err := readStructureFile(reader, filename)
+	if err != nil {
+		return nil, utils.ErrBadRequest(err.Error())
+	}
+	rows, err := parseStructureRows(headers, records)
+	if err != nil {
+		return nil, utils.ErrBadRequest(err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, utils.ErrBadRequest("import file has no data rows")
+	}
+
+	snap, err := s.structureRepo.LoadSnapshot()
+	if err != nil {
+		s.logger.Error("failed to load academic structure", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load academic structure", err)
+	}
+	return planStructureImport(rows, snap), nil
+}
+
+func toStructureImportResponse(plan *structurePlan, applied bool) *dto.StructureImportResponse {
+	resp := &dto.StructureImportResponse{
+		Applied:   applied,
+		TotalRows: len(plan.results),
+		Created:   make(map[string]int),
+		Rows:      plan.results,
+	}
+	for _, r := range plan.results {
+		switch r.Action {
+		case structureActionCreate:
+			resp.CreateRows++
+			resp.Created[r.Data.Type]++
+		case structureActionExists:
+			resp.ExistingRows++
+		default:
+			resp.InvalidRows++
+		}
+	}
+	return resp
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// File helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// readStructureFile returns the header row and the data rows of a CSV file
+// or of the first sheet of an XLSX workbook.
+func readStructureFile(reader io.Reader, filename string) ([]string, [][]string, error) {
+	lower := strings.ToLower(filename)
+
+	var rows [][]string
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		r := csv.NewReader(reader)
+		r.FieldsPerRecord = -1
+		all, err := r.ReadAll()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		rows = all
+
+	case strings.HasSuffix(lower, ".xlsx"):
+		f, err := excelize.OpenReader(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read XLSX: %w", err)
+		}
+		defer f.Close()
+
+		all, err := f.GetRows(f.GetSheetName(0))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get rows from XLSX: %w", err)
+		}
+		rows = all
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported file format: upload a .csv or .xlsx file")
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("import file has no header row")
+	}
+	return rows[0], rows[1:], nil
+}
+
+// writeStructureSheet renders rows as CSV or, for any other format, as a
+// single-sheet XLSX workbook.
+func writeStructureSheet(rows []dto.StructureRow, format string) ([]byte, string, error) {
+	if format == "csv" {
+		var b strings.Builder
+		w := csv.NewWriter(&b)
+		if err := w.Write(structureHeaders); err != nil {
+			return nil, "", err
+		}
+		for _, r := range rows {
+			if err := w.Write(structureRecord(r)); err != nil {
+				return nil, "", err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, "", err
+		}
+		return []byte(b.String()), "text/csv", nil
+	}
+
+	const sheet = "Structure"
+	f := excelize.NewFile()
+	defer f.Close()
+	index, _ := f.NewSheet(sheet)
+	f.SetActiveSheet(index)
+	f.DeleteSheet("Sheet1")
+
+	if err := f.SetSheetRow(sheet, "A1", &structureHeaders); err != nil {
+		return nil, "", err
+	}
+	for i, r := range rows {
+		record := structureRecord(r)
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(sheet, cell, &record); err != nil {
+			return nil, "", err
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), xlsxContentType, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func existingStructure() *repository.StructureSnapshot {
+	faculty := domain.Faculty{ID: uuid.New(), Code: "FOC", Name: "Faculty of Computing", IsActive: true}
+	dept := domain.Department{ID: uuid.New(), FacultyID: faculty.ID, Code: "SE", Name: "Software Engineering", IsActive: true}
+	degree := domain.Degree{ID: uuid.New(), DepartmentID: dept.ID, Code: "BSC-SE", Name: "BSc in SE", Level: "Undergraduate", IsActive: true}
+	batch := domain.Batch{ID: uuid.New(), DegreeID: degree.ID, Code: "Y1", Name: "Year 1", IsActive: true}
+	return &repository.StructureSnapshot{
+		Faculties:   []domain.Faculty{faculty},
+		Departments: []domain.Department{dept},
+		Degrees:     []domain.Degree{degree},
+		Batches:     []domain.Batch{batch},
+	}
+}
+
+func actions(plan *structurePlan) []string {
+	out := make([]string, len(plan.results))
+	for i, r := range plan.results {
+		out[i] = r.Action
+	}
+	return out
+}
+
+func TestPlanStructureImport_CreatesInDependencyOrder(t *testing.T) {
+	leader := uuid.New().String()
+	student := uuid.New().String()
+	rows := []dto.StructureRow{
+		// Children come first in the file; the plan still resolves them.
+		{Type: structureBatchMember, ParentCode: "Y1-A", DegreeCode: "BSC-DS", UserID: student},
+		{Type: structureBatch, Code: "Y1-A", Name: "Year 1 A", DegreeCode: "BSC-DS", ParentCode: "Y1", SpecializationCode: "ML"},
+		{Type: structureBatch, Code: "Y1", Name: "Year 1", DegreeCode: "BSC-DS", StartYear: "2026", EndYear: "2030"},
+		{Type: structureSpecialization, Code: "ML", Name: "Machine Learning", ParentCode: "BSC-DS"},
+		{Type: structureDegree, Code: "BSC-DS", Name: "BSc in DS", ParentCode: "DS", Level: "Undergraduate"},
+		{Type: structureDepartment, Code: "DS", Name: "Data Science", ParentCode: "FOS"},
+		{Type: structureFacultyLeader, ParentCode: "FOS", UserID: leader, Role: "Dean"},
+		{Type: structureFaculty, Code: "FOS", Name: "Faculty of Science"},
+		{Type: structureCourse, Code: "DS101", Name: "Intro to Data", Credits: "3"},
+	}
+
+	plan := planStructureImport(rows, existingStructure())
+	require.True(t, plan.valid(), "%+v", plan.results)
+	for _, a := range actions(plan) {
+		assert.Equal(t, structureActionCreate, a)
+	}
+
+	c := plan.changes
+	require.Len(t, c.Batches, 2)
+	assert.Equal(t, "Y1", c.Batches[0].Code, "parents are inserted first")
+	assert.Equal(t, c.Batches[0].ID, *c.Batches[1].ParentID)
+	assert.Equal(t, c.Specializations[0].ID, *c.Batches[1].SpecializationID)
+	assert.Equal(t, 2026, c.Batches[0].StartYear)
+	assert.Equal(t, c.Faculties[0].ID, c.Departments[0].FacultyID)
+	assert.Equal(t, c.Departments[0].ID, c.Degrees[0].DepartmentID)
+	assert.Equal(t, c.Faculties[0].ID, c.Leaders[0].FacultyID)
+	assert.Equal(t, 3, c.Courses[0].Credits)
+	require.Len(t, plan.newMembers, 1)
+	assert.Equal(t, c.Batches[1].ID, plan.newMembers[0].BatchID)
+	assert.Equal(t, domain.BatchMemberStatusActive, plan.newMembers[0].Status)
+}
+
+func TestPlanStructureImport_ExistingRecordsAreSkipped(t *testing.T) {
+	snap := existingStructure()
+	rows := []dto.StructureRow{
+		{Type: structureFaculty, Code: "FOC", Name: "Faculty of Computing"},
+		{Type: structureDepartment, Code: "SE", Name: "Software Engineering", ParentCode: "FOC"},
+		{Type: structureBatch, Code: "Y1", Name: "Year 1", DegreeCode: "BSC-SE"},
+		{Type: structureBatch, Code: "Y1-B", Name: "Year 1 B", DegreeCode: "BSC-SE", ParentCode: "Y1"},
+	}
+
+	plan := planStructureImport(rows, snap)
+	require.True(t, plan.valid(), "%+v", plan.results)
+	assert.Equal(t, []string{structureActionExists, structureActionExists, structureActionExists, structureActionCreate}, actions(plan))
+	require.Len(t, plan.changes.Batches, 1)
+	assert.Equal(t, snap.Batches[0].ID, *plan.changes.Batches[0].ParentID)
+	assert.Empty(t, plan.changes.Faculties)
+}
+
+func TestPlanStructureImport_ReportsRowErrors(t *testing.T) {
+	snap := existingStructure()
+	snap.Degrees[0].IsActive = false
+	rows := []dto.StructureRow{
+		{Type: structureFaculty, Code: "FOS", Name: "Faculty of Science"},
+		{Type: structureDegree, Code: "BSC-X", Name: "BSc X", ParentCode: "SE", Level: "Bachelor"},
+		{Type: structureSpecialization, Code: "AI", Name: "Artificial Intelligence", ParentCode: "BSC-SE"},
+		{Type: structureCourse, Code: "CS101", Name: "Programming"},
+		{Type: structureCourse, Code: "CS101", Name: "Programming again"},
+		{Type: structureBatch, Code: "Y2", Name: "Year 2", DegreeCode: "BSC-SE", StartYear: "2027", EndYear: "2026"},
+		{Type: structureBatch, Code: "A", Name: "Cycle A", DegreeCode: "BSC-SE", ParentCode: "B"},
+		{Type: structureBatch, Code: "B", Name: "Cycle B", DegreeCode: "BSC-SE", ParentCode: "A"},
+		{Type: structureBatchMember, ParentCode: "Y1", DegreeCode: "BSC-SE", UserID: "not-a-uuid"},
+		{Type: "campus", Code: "MAIN"},
+	}
+
+	plan := planStructureImport(rows, snap)
+	assert.False(t, plan.valid())
+
+	errs := func(i int) string { return strings.Join(plan.results[i].Errors, "; ") }
+	assert.Contains(t, errs(0), "needs at least one faculty_leader row")
+	assert.Contains(t, errs(1), "level must be one of")
+	assert.Contains(t, errs(2), `degree "BSC-SE" is inactive`)
+	assert.Equal(t, structureActionCreate, plan.results[3].Action)
+	assert.Equal(t, "duplicates row 4", errs(4))
+	assert.Contains(t, errs(5), "end year must be greater than or equal to start year")
+	assert.Contains(t, errs(6), `parent batch "B" not found`)
+	assert.Contains(t, errs(7), `parent batch "A" not found`)
+	assert.Contains(t, errs(8), "user ID must be a valid UUID")
+	assert.Contains(t, errs(9), `unknown type "campus"`)
+	assert.Equal(t, 10, plan.results[9].RowIndex)
+}
+
+func TestPlanStructureImport_AmbiguousCodes(t *testing.T) {
+	snap := existingStructure()
+	other := domain.Department{ID: uuid.New(), FacultyID: uuid.New(), Code: "SE", Name: "Systems Engineering", IsActive: true}
+	snap.Departments = append(snap.Departments, other)
+
+	plan := planStructureImport([]dto.StructureRow{
+		{Type: structureDegree, Code: "MSC-SE", Name: "MSc in SE", ParentCode: "SE", Level: "Postgraduate"},
+	}, snap)
+	require.False(t, plan.valid())
+	assert.Equal(t, []string{`department code "SE" is ambiguous`}, plan.results[0].Errors)
+}
+
+func TestExportStructureRows_RoundTrips(t *testing.T) {
+	snap := existingStructure()
+	snap.Faculties[0].Leaders = []domain.FacultyLeadership{{FacultyID: snap.Faculties[0].ID, UserID: uuid.New(), Role: "Dean", IsActive: true}}
+	child := domain.Batch{ID: uuid.New(), DegreeID: snap.Degrees[0].ID, ParentID: &snap.Batches[0].ID, Code: "Y1-A", Name: "Year 1 A", IsActive: true}
+	snap.Batches = append(snap.Batches, child)
+	snap.Courses = []domain.Course{{ID: uuid.New(), Code: "SE101", Title: "Intro to SE", Credits: 4, IsActive: true}}
+	snap.Members = []domain.BatchMember{{BatchID: child.ID, UserID: uuid.New(), Status: domain.BatchMemberStatusActive}}
+
+	rows := exportStructureRows(snap)
+	require.Len(t, rows, 8)
+	assert.Equal(t, "Y1", rows[6].ParentCode)
+	assert.Equal(t, "BSC-SE", rows[7].DegreeCode)
+
+	// Re-importing an export changes nothing.
+	headers := structureHeaders
+	records := make([][]string, len(rows))
+	for i, r := range rows {
+		records[i] = structureRecord(r)
+	}
+	parsed, err := parseStructureRows(headers, records)
+	require.NoError(t, err)
+	plan := planStructureImport(parsed, snap)
+	require.True(t, plan.valid(), "%+v", plan.results)
+	for _, a := range actions(plan) {
+		assert.Equal(t, structureActionExists, a)
+	}
+}
+
+func TestParseStructureRows(t *testing.T) {
+	rows, err := parseStructureRows(
+		[]string{" TYPE ", "Code", "Parent-Code", "Notes"},
+		[][]string{{"Department", " SE ", "FOC", "ignored"}, {"", "", ""}, {"course"}},
+	)
+	require.NoError(t, err)
+	require.Len(t, rows, 2, "blank rows are skipped")
+	assert.Equal(t, dto.StructureRow{Type: "department", Code: "SE", ParentCode: "FOC"}, rows[0])
+	assert.Equal(t, "course", rows[1].Type)
+
+	_, err = parseStructureRows([]string{"Code"}, nil)
+	assert.Error(t, err)
+}
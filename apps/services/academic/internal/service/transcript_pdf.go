@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+)
+
+// A4 page geometry in PDF points.
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 50
+	pdfLineHeight   = 14
+	pdfBottomMargin = 60
+)
+
+// Columns of the transcript course table.
+var transcriptColumns = []struct {
+	title string
+	x     int
+}{
+	{"Code", pdfMargin},
+	{"Course", pdfMargin + 80},
+	{"Credits", pdfMargin + 330},
+	{"Grade", pdfMargin + 390},
+	{"Points", pdfMargin + 440},
+}
+
+// renderTranscriptPDF lays the transcript out on A4 pages using the
+// standard Helvetica fonts, so no font files need to be embedded.
+func renderTranscriptPDF(t *dto.TranscriptResponse) []byte {
+	doc := &pdfDocument{}
+	doc.newPage()
+
+	doc.text(pdfFontBold, 18, pdfMargin, "Academic Transcript")
+	doc.space(8)
+	name := t.FullName
+	if name == "" {
+		name = t.UserID.String()
+	}
+	doc.text(pdfFontRegular, 11, pdfMargin, "Student: "+name)
+	if t.StudentID != "" {
+		doc.text(pdfFontRegular, 11, pdfMargin, "Student ID: "+t.StudentID)
+	}
+	doc.text(pdfFontRegular, 11, pdfMargin, "Issued: "+t.GeneratedAt.Format("2006-01-02"))
+	doc.space(10)
+
+	if len(t.Terms) == 0 {
+		doc.text(pdfFontRegular, 11, pdfMargin, "No graded courses.")
+	}
+	for _, term := range t.Terms {
+		// Keep a term heading together with its table header and first row.
+		doc.ensureSpace(4 * pdfLineHeight)
+		doc.text(pdfFontBold, 12, pdfMargin, fmt.Sprintf("%s (%s)", term.SemesterName, term.SemesterCode))
+		doc.row(pdfFontBold, 10, columnTitles()...)
+		for _, c := range term.Courses {
+			points := "-"
+			if c.GradePoints != nil {
+				points = fmt.Sprintf("%.2f", *c.GradePoints)
+			}
+			doc.row(pdfFontRegular, 10, c.CourseCode, truncate(c.CourseTitle, 45), fmt.Sprint(c.Credits), c.FinalGrade, points)
+		}
+		doc.text(pdfFontRegular, 10, pdfMargin, fmt.Sprintf("Credits attempted: %d   Credits earned: %d   Term GPA: %.2f",
+			term.CreditsAttempted, term.CreditsEarned, term.GPA))
+		doc.space(8)
+	}
+
+	doc.ensureSpace(3 * pdfLineHeight)
+	doc.text(pdfFontBold, 11, pdfMargin, fmt.Sprintf("Total credits attempted: %d   Total credits earned: %d   Cumulative GPA: %.2f",
+		t.CreditsAttempted, t.CreditsEarned, t.CumulativeGPA))
+
+	return doc.bytes()
+}
+
+func columnTitles() []string {
+	titles := make([]string, len(transcriptColumns))
+	for i, c := range transcriptColumns {
+		titles[i] = c.title
+	}
+	return titles
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Minimal PDF writer
+// ─────────────────────────────────────────────────────────────────────────────
+
+const (
+	pdfFontRegular = "F1"
+	pdfFontBold    = "F2"
+)
+
+// pdfDocument accumulates text-only pages and serialises them as PDF 1.4.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     int
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// ensureSpace starts a new page unless height points are left on this one.
+func (d *pdfDocument) ensureSpace(height int) {
+	if d.y-height < pdfBottomMargin {
+		d.newPage()
+	}
+}
+
+func (d *pdfDocument) space(height int) {
+	d.y -= height
+}
+
+// text writes one line at x and moves down a line.
+func (d *pdfDocument) text(font string, size, x int, s string) {
+	d.ensureSpace(pdfLineHeight)
+	d.y -= size + 4
+	d.draw(font, size, x, s)
+}
+
+// row writes one table line with a cell per transcript column.
+func (d *pdfDocument) row(font string, size int, cells ...string) {
+	d.ensureSpace(pdfLineHeight)
+	d.y -= pdfLineHeight
+	for i, cell := range cells {
+		d.draw(font, size, transcriptColumns[i].x, cell)
+	}
+}
+
+func (d *pdfDocument) draw(font string, size, x int, s string) {
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, size, x, d.y, pdfEscape(s))
+}
+
+// bytes serialises the document: catalog, page tree, two fonts, then a page
+// and content stream object per page, followed by the cross-reference table.
+func (d *pdfDocument) bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, pdfFontRegular, pdfFontBold, 6+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// winAnsiExtras maps the characters WinAnsiEncoding places in 0x80-0x9F,
+// where it departs from Latin-1.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// pdfEscape escapes a string for a PDF literal shown in a WinAnsiEncoding
+// font. Characters beyond printable ASCII are written as octal escapes of
+// their WinAnsi code; those the encoding lacks are replaced with '?'.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r <= 126:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			if code, ok := winAnsiExtras[r]; ok {
+				fmt.Fprintf(&b, "\\%03o", code)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"ascii", "Data Structures", "Data Structures"},
+		{"delimiters", `Lab (part \ 2)`, `Lab \(part \\ 2\)`},
+		{"latin-1", "José Müller", `Jos\351 M\374ller`},
+		{"winansi extras", "“Intro” – €", `\223Intro\224 \226 \200`},
+		{"unsupported", "Łódź 数学", `?\363d? ??`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pdfEscape(tt.in))
+		})
+	}
+}
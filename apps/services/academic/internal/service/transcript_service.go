@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TranscriptService builds student transcripts from their graded
+// enrollments.
+type TranscriptService interface {
+	// GetTranscript returns the student's courses with a final grade, grouped
+	// by semester, with term and cumulative GPA. token is forwarded to IAM to
+	// look up the student's name and ID.
+	GetTranscript(ctx context.Context, userID uuid.UUID, token string) (*dto.TranscriptResponse, error)
+	// RenderTranscriptPDF lays the transcript out as a PDF document.
+	RenderTranscriptPDF(transcript *dto.TranscriptResponse) []byte
+}
+
+// transcriptService is the concrete implementation.
+type transcriptService struct {
+	enrollmentRepo repository.EnrollmentRepository
+	courseRepo     repository.CourseRepository
+	semesterRepo   repository.SemesterRepository
+	iamClient      *client.IAMClient
+	logger         *zap.Logger
+}
+
+// NewTranscriptService wires all dependencies together.
+func NewTranscriptService(
+	enrollmentRepo repository.EnrollmentRepository,
+	courseRepo repository.CourseRepository,
+	semesterRepo repository.SemesterRepository,
+	iamClient *client.IAMClient,
+	logger *zap.Logger,
+) TranscriptService {
+	return &transcriptService{
+		enrollmentRepo: enrollmentRepo,
+		courseRepo:     courseRepo,
+		semesterRepo:   semesterRepo,
+		iamClient:      iamClient,
+		logger:         logger,
+	}
+}
+
+func (s *transcriptService) GetTranscript(ctx context.Context, userID uuid.UUID, token string) (*dto.TranscriptResponse, error) {
+	enrollments, err := s.enrollmentRepo.GetHistoryByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to load enrollment history", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load enrollment history", err)
+	}
+
+	courses := make(map[uuid.UUID]*domain.Course)
+	semesters := make(map[uuid.UUID]*domain.Semester)
+	terms := make(map[uuid.UUID]*dto.TranscriptTermResponse)
+	for _, e := range enrollments {
+		if e.CourseInstance == nil || e.FinalGrade == "" {
+			continue
+		}
+		if e.Status != domain.EnrollmentStatusCompleted && e.Status != domain.EnrollmentStatusFailed {
+			continue
+		}
+
+		course, err := s.course(courses, e.CourseInstance.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		semester, err := s.semester(semesters, e.CourseInstance.SemesterID)
+		if err != nil {
+			return nil, err
+		}
+		if course == nil || semester == nil {
+			s.logger.Warn("skipping enrollment with missing course or semester",
+				zap.String("course_instance_id", e.CourseInstanceID.String()))
+			continue
+		}
+
+		term, ok := terms[semester.ID]
+		if !ok {
+			term = &dto.TranscriptTermResponse{
+				SemesterID:   semester.ID,
+				SemesterCode: semester.Code,
+				SemesterName: semester.Name,
+				StartDate:    semester.StartDate.String(),
+			}
+			terms[semester.ID] = term
+		}
+		term.Courses = append(term.Courses, dto.TranscriptCourseResponse{
+			CourseInstanceID: e.CourseInstanceID,
+			CourseCode:       course.Code,
+			CourseTitle:      course.Title,
+			Credits:          course.Credits,
+			Status:           e.Status,
+			FinalGrade:       e.FinalGrade,
+			GradePoints:      e.GradePoints,
+		})
+	}
+
+	transcript := &dto.TranscriptResponse{
+		UserID:      userID,
+		Terms:       make([]dto.TranscriptTermResponse, 0, len(terms)),
+		GeneratedAt: time.Now().UTC(),
+	}
+	var all []dto.TranscriptCourseResponse
+	for _, term := range terms {
+		sort.Slice(term.Courses, func(i, j int) bool { return term.Courses[i].CourseCode < term.Courses[j].CourseCode })
+		term.CreditsAttempted, term.CreditsEarned, term.GPA = summariseCredits(term.Courses)
+		all = append(all, term.Courses...)
+		transcript.Terms = append(transcript.Terms, *term)
+	}
+	sort.Slice(transcript.Terms, func(i, j int) bool { return transcript.Terms[i].StartDate < transcript.Terms[j].StartDate })
+	transcript.CreditsAttempted, transcript.CreditsEarned, transcript.CumulativeGPA = summariseCredits(all)
+
+	if info, err := s.iamClient.GetUserInfo(ctx, token, userID.String()); err != nil {
+		s.logger.Warn("failed to fetch student profile for transcript", zap.String("user_id", userID.String()), zap.Error(err))
+	} else {
+		transcript.StudentID = info.StudentID
+		transcript.FullName = info.FullName
+	}
+
+	return transcript, nil
+}
+
+func (s *transcriptService) RenderTranscriptPDF(transcript *dto.TranscriptResponse) []byte {
+	return renderTranscriptPDF(transcript)
+}
+
+func (s *transcriptService) course(cache map[uuid.UUID]*domain.Course, id uuid.UUID) (*domain.Course, error) {
+	if c, ok := cache[id]; ok {
+		return c, nil
+	}
+	c, err := s.courseRepo.GetByID(id)
+	if err != nil {
+		s.logger.Error("failed to load course", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load course", err)
+	}
+	cache[id] = c
+	return c, nil
+}
+
+func (s *transcriptService) semester(cache map[uuid.UUID]*domain.Semester, id uuid.UUID) (*domain.Semester, error) {
+	if sem, ok := cache[id]; ok {
+		return sem, nil
+	}
+	sem, err := s.semesterRepo.GetByID(id)
+	if err != nil {
+		s.logger.Error("failed to load semester", zap.Error(err))
+		return nil, utils.ErrInternal("failed to load semester", err)
+	}
+	cache[id] = sem
+	return sem, nil
+}
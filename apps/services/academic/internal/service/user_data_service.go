@@ -0,0 +1,121 @@
+package service
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/academic/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UserDataService handles requests from IAM about a user's academic data as
+// a whole, such as exporting it for a subject access request or erasing it.
+type UserDataService interface {
+	ExportUserData(userID uuid.UUID, clientID, ipAddress, userAgent string) (*repository.UserDataExport, error)
+	EraseUserData(userID uuid.UUID, retainGrades bool, clientID, ipAddress, userAgent string) (*repository.UserDataCounts, error)
+}
+
+// userDataService is the concrete implementation.
+type userDataService struct {
+	userDataRepo repository.UserDataRepository
+	auditClient  *client.AuditClient
+	logger       *zap.Logger
+}
+
+// NewUserDataService wires all dependencies together.
+func NewUserDataService(
+	userDataRepo repository.UserDataRepository,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) UserDataService {
+	return &userDataService{
+		userDataRepo: userDataRepo,
+		auditClient:  auditClient,
+		logger:       logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ExportUserData
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ExportUserData returns every academic record tied to the user.
+func (s *userDataService) ExportUserData(
+	userID uuid.UUID,
+	clientID, ipAddress, userAgent string,
+) (*repository.UserDataExport, error) {
+	if userID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+
+	export, err := s.userDataRepo.ExportUser(userID)
+	if err != nil {
+		s.logger.Error("failed to export user data", zap.Error(err))
+		return nil, utils.ErrInternal("failed to export user data", err)
+	}
+
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionUserDataExported),
+		"user",
+		userID.String(),
+		0,
+		clientID,
+		nil,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	return export, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// EraseUserData
+// ─────────────────────────────────────────────────────────────────────────────
+
+// EraseUserData removes the academic records tied to the user, keeping
+// enrollments, batch memberships and attendance when retainGrades is set.
+// Erasing a user with no records succeeds, so IAM can safely retry.
+func (s *userDataService) EraseUserData(
+	userID uuid.UUID,
+	retainGrades bool,
+	clientID, ipAddress, userAgent string,
+) (*repository.UserDataCounts, error) {
+	if userID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+
+	counts, err := s.userDataRepo.EraseUser(userID, retainGrades)
+	if err != nil {
+		s.logger.Error("failed to erase user data", zap.Error(err))
+		return nil, utils.ErrInternal("failed to erase user data", err)
+	}
+
+	changes := map[string]interface{}{
+		"batch_memberships":   counts.BatchMemberships,
+		"enrollments":         counts.Enrollments,
+		"course_instructors":  counts.CourseInstructors,
+		"faculty_leaderships": counts.FacultyLeaderships,
+		"section_memberships": counts.SectionMemberships,
+		"section_instructors": counts.SectionInstructors,
+		"attendance_records":  counts.AttendanceRecords,
+		"retain_grades":       retainGrades,
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionUserDataErased),
+		"user",
+		userID.String(),
+		0,
+		clientID,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	return counts, nil
+}
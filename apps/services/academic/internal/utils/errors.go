@@ -77,6 +77,14 @@ func ErrForbidden(message string) *AppError {
 	return NewAppError(http.StatusForbidden, message, nil)
 }
 
+func ErrUnprocessable(message string) *AppError {
+	return NewAppError(http.StatusUnprocessableEntity, message, nil)
+}
+
+func ErrTooManyRequests(message string) *AppError {
+	return NewAppError(http.StatusTooManyRequests, message, nil)
+}
+
 func IsConflict(err error) bool {
 	var appErr *AppError
 	if errors.As(err, &appErr) {
@@ -84,3 +92,11 @@ func IsConflict(err error) bool {
 	}
 	return false
 }
+
+func IsUnprocessable(err error) bool {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code == http.StatusUnprocessableEntity
+	}
+	return false
+}
@@ -20,6 +20,9 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/storage"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"go.uber.org/zap"
@@ -126,14 +129,41 @@ func run() error {
 	logger.Info("connected to rabbitmq", zap.String("url", cfg.RabbitMQ.URL))
 
 	// ── External clients ─────────────────────────────────────────────────────
-	auditClient := client.NewAuditClient(cfg.IAMServiceURL, logger)
-	academicClient := client.NewAcademicClient(cfg.AcademicSvcURL, logger)
+	serviceTokens := servicetoken.NewClient(servicetoken.Config{
+		TokenURL:     cfg.IAMServiceURL + servicetoken.TokenPath,
+		ClientID:     cfg.ServiceClient.ClientID,
+		ClientSecret: cfg.ServiceClient.ClientSecret,
+		Scopes:       []string{servicetoken.ScopeAuditWrite, servicetoken.ScopeEnrollmentRead, servicetoken.ScopeAcademicEventsRead},
+	})
+	auditClient := client.NewAuditClient(cfg.IAMServiceURL, serviceTokens, logger)
+	academicClient := client.NewAcademicClient(cfg.AcademicSvcURL, serviceTokens, logger)
+	permissionChecker := authz.NewIAMChecker(cfg.IAMServiceURL, 30*time.Second)
+	// Personal access tokens are resolved by IAM and cached for the same window
+	tokenVerifier := pat.NewIAMVerifier(cfg.IAMServiceURL, 30*time.Second)
 	judge0Client := client.NewJudge0Client(cfg.Judge0.URL, cfg.Judge0.APIKey, cfg.Judge0.Timeout, logger)
 
 	// ── Repositories ─────────────────────────────────────────────────────────
 	assignmentRepo := repository.NewAssignmentRepository(db.DB)
 	submissionRepo := repository.NewSubmissionRepository(db.DB)
 	groupRepo := repository.NewGroupRepository(db.DB)
+	regradeRepo := repository.NewRegradeRepository(db.DB)
+	courseAccessRepo := repository.NewCourseAccessRepository(db.DB)
+	analyticsRepo := repository.NewAnalyticsRepository(db.DB)
+
+	// Local read-model of academic enrollments and instructor assignments,
+	// fed by academic domain events and periodic reconciliation.
+	courseAccessService := service.NewCourseAccessService(
+		courseAccessRepo,
+		academicClient,
+		cfg.CourseAccess.CacheTTL,
+		cfg.CourseAccess.MaxStaleness,
+		logger,
+	)
+	academicEventConsumer := queue.NewAcademicEventConsumer(rmq, courseAccessService.HandleEvent, logger)
+
+	// Instructor analytics, aggregated in materialised views that are
+	// refreshed after submission events.
+	analyticsService := service.NewAnalyticsService(analyticsRepo, assignmentRepo, logger)
 
 	// ── Message queue: publisher + worker + consumer ──────────────────────────
 	submissionPublisher := queue.NewSubmissionPublisher(rmq, logger)
@@ -148,6 +178,7 @@ func run() error {
 		auditClient,
 		judge0Client,
 		evaluationService,
+		analyticsService,
 		db.DB,
 		logger,
 	)
@@ -162,7 +193,7 @@ func run() error {
 	// ── Services ─────────────────────────────────────────────────────────────
 	contentRepo := repository.NewAssignmentContentRepository(db.DB)
 
-	assignmentService := service.NewAssignmentService(assignmentRepo, contentRepo, auditClient, logger)
+	assignmentService := service.NewAssignmentService(assignmentRepo, contentRepo, academicClient, auditClient, logger)
 
 	submissionService := service.NewSubmissionService(
 		submissionRepo,
@@ -172,8 +203,9 @@ func run() error {
 		minioStorage,
 		submissionPublisher,
 		auditClient,
-		academicClient,
+		courseAccessService,
 		judge0Client,
+		analyticsService,
 		cfg.Judge0.MaxPayloadSize,
 		db.DB,
 		logger,
@@ -186,6 +218,15 @@ func run() error {
 		logger,
 	)
 
+	gradingService := service.NewGradingService(
+		submissionRepo,
+		regradeRepo,
+		groupRepo,
+		auditClient,
+		analyticsService,
+		logger,
+	)
+
 	// Code Storage (SeaweedFS + go-git) service
 	codeRepoRepo := repository.NewCodeRepository(db.DB)
 	seaweedStorage, err := storage.NewSeaweedGitStorage(
@@ -201,14 +242,23 @@ func run() error {
 	}
 	codeStorageService := service.NewCodeStorageService(codeRepoRepo, seaweedStorage, db.DB, logger)
 
+	userDataRepo := repository.NewUserDataRepository(db.DB)
+	userDataService := service.NewUserDataService(userDataRepo, minioStorage, seaweedStorage, auditClient, logger)
+	assignmentCloneService := service.NewAssignmentCloneService(assignmentRepo, contentRepo, codeRepoRepo, logger)
+	scoreService := service.NewScoreService(assignmentRepo, submissionRepo, groupRepo, logger)
+
 	// ── Handlers ─────────────────────────────────────────────────────────────
 	healthHandler := handler.NewHealthHandler()
 	assignmentHandler := handler.NewAssignmentHandler(assignmentService, logger)
 	submissionHandler := handler.NewSubmissionHandler(submissionService, logger)
 	groupHandler := handler.NewGroupHandler(groupService, logger)
-	instructorHandler := handler.NewInstructorHandler(assignmentService, submissionService, academicClient, logger)
-	studentHandler := handler.NewStudentHandler(assignmentService, submissionService, academicClient, logger)
+	instructorHandler := handler.NewInstructorHandler(assignmentService, submissionService, gradingService, analyticsService, courseAccessService, logger)
+	studentHandler := handler.NewStudentHandler(assignmentService, submissionService, gradingService, courseAccessService, logger)
 	codeHandler := handler.NewCodeHandler(codeStorageService, assignmentRepo)
+	userDataHandler := handler.NewUserDataHandler(userDataService, logger)
+	assignmentCloneHandler := handler.NewAssignmentCloneHandler(assignmentCloneService, logger)
+	scoreHandler := handler.NewScoreHandler(scoreService, logger)
+	courseAccessHandler := handler.NewCourseAccessHandler(courseAccessService, logger)
 	// ── Fiber app ────────────────────────────────────────────────────────────
 	app := fiber.New(fiber.Config{
 		AppName:      "assessment-service",
@@ -226,14 +276,20 @@ func run() error {
 
 	// ── Routes ───────────────────────────────────────────────────────────────
 	router.SetupRoutes(app, router.Config{
-		HealthHandler:     healthHandler,
-		AssignmentHandler: assignmentHandler,
-		SubmissionHandler: submissionHandler,
-		GroupHandler:      groupHandler,
-		InstructorHandler: instructorHandler,
-		StudentHandler:    studentHandler,
-		CodeHandler:       codeHandler,
-		JWTSecretKey:      []byte(cfg.JWT.SecretKey),
+		HealthHandler:          healthHandler,
+		AssignmentHandler:      assignmentHandler,
+		SubmissionHandler:      submissionHandler,
+		GroupHandler:           groupHandler,
+		InstructorHandler:      instructorHandler,
+		StudentHandler:         studentHandler,
+		CodeHandler:            codeHandler,
+		UserDataHandler:        userDataHandler,
+		AssignmentCloneHandler: assignmentCloneHandler,
+		ScoreHandler:           scoreHandler,
+		CourseAccessHandler:    courseAccessHandler,
+		JWTSecretKey:           []byte(cfg.JWT.SecretKey),
+		TokenVerifier:          tokenVerifier,
+		PermissionChecker:      permissionChecker,
 	})
 
 	// ── Graceful shutdown ────────────────────────────────────────────────────
@@ -252,6 +308,12 @@ func run() error {
 		zap.Int("workers", cfg.RabbitMQ.SubmissionWorkers),
 	)
 
+	// Keep the course access read-model current.
+	go academicEventConsumer.Start(consumerCtx)
+	go courseAccessService.Run(consumerCtx, cfg.CourseAccess.ReconcileInterval)
+
+	go analyticsService.Run(consumerCtx, cfg.Analytics.RefreshDelay)
+
 	go func() {
 		addr := fmt.Sprintf(":%s", cfg.Server.Port)
 		logger.Info("starting server", zap.String("address", addr))
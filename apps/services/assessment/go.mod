@@ -3,9 +3,14 @@ module github.com/4yrg/gradeloop-core-v2/apps/services/assessment
 go 1.25.0
 
 require (
+	github.com/4yrg/gradeloop-core-v2/packages/go/authz v0.0.0-00010101000000-000000000000
 	github.com/4yrg/gradeloop-core-v2/packages/go/env v1.0.0
+	github.com/4yrg/gradeloop-core-v2/packages/go/openapi v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/pat v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/queryspec v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken v0.0.0-00010101000000-000000000000
 	github.com/gofiber/fiber/v3 v3.0.0-rc.1
-	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/google/uuid v1.6.0
 	github.com/minio/minio-go/v7 v7.0.98
 	github.com/rabbitmq/amqp091-go v1.10.0
@@ -27,6 +32,12 @@ replace github.com/4yrg/gradeloop-core-v2/packages/go/grpc => ../../../packages/
 
 replace github.com/4yrg/gradeloop-core-v2/packages/go/env => ../../../packages/go/env
 
+replace github.com/4yrg/gradeloop-core-v2/packages/go/authz => ../../../packages/go/authz
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken => ../../../packages/go/servicetoken
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/pat => ../../../packages/go/pat
+
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/andybalholm/brotli v1.2.0 // indirect
@@ -65,3 +76,7 @@ require (
 	golang.org/x/text v0.32.0 // indirect
 	gorm.io/driver/mysql v1.5.6 // indirect
 )
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/openapi => ../../../packages/go/openapi
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/queryspec => ../../../packages/go/queryspec
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -14,7 +16,7 @@ import (
 // ─────────────────────────────────────────────────────────────────────────────
 
 // enrollmentListResponse mirrors the paginated envelope returned by the
-// Academic Service GET /api/v1/enrollments endpoint.
+// Academic Service GET /api/v1/internal/enrollments endpoint.
 type enrollmentListResponse struct {
 	Enrollments []enrollmentItem `json:"enrollments"`
 	Count       int              `json:"count"`
@@ -34,32 +36,49 @@ type enrollmentItem struct {
 
 // AcademicClient makes HTTP calls to the Academic Service for cross-service
 // validations that the Assessment Service must perform (e.g. enrollment checks).
+//
+// Calls made on behalf of a user forward that user's token; internal calls
+// use serviceHTTP, which authenticates with a service token.
 type AcademicClient struct {
-	baseURL    string
-	httpClient *http.Client
-	logger     *zap.Logger
+	baseURL     string
+	httpClient  *http.Client
+	serviceHTTP *http.Client
+	// snapshotHTTP is serviceHTTP with a longer timeout for full snapshots.
+	snapshotHTTP *http.Client
+	logger       *zap.Logger
 }
 
 // NewAcademicClient creates a new AcademicClient targeting the given base URL.
-func NewAcademicClient(baseURL string, logger *zap.Logger) *AcademicClient {
+// tokens must be granted the academic.enrollments:read scope, and
+// academic.events:read for course access snapshots.
+func NewAcademicClient(baseURL string, tokens *servicetoken.Client, logger *zap.Logger) *AcademicClient {
 	return &AcademicClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: 5 * time.Second,
 		},
+		serviceHTTP: &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: tokens.Transport(nil),
+		},
+		snapshotHTTP: &http.Client{
+			Timeout:   60 * time.Second,
+			Transport: tokens.Transport(nil),
+		},
 		logger: logger,
 	}
 }
 
-// IsEnrolled calls GET /api/v1/enrollments?user_id=<userID>&course_instance_id=<courseInstanceID>
-// and returns true when at least one active enrollment record is found.
+// IsEnrolled calls GET /api/v1/internal/enrollments?user_id=<userID>&course_instance_id=<courseInstanceID>
+// with a service token and returns true when at least one active enrollment
+// record is found.
 //
 // A network error or a non-2xx response is treated as "not enrolled" with a
 // wrapped error so that callers can distinguish a hard failure from a clean
 // "not found" result.
 func (c *AcademicClient) IsEnrolled(userID, courseInstanceID string) (bool, error) {
 	url := fmt.Sprintf(
-		"%s/api/v1/enrollments?user_id=%s&course_instance_id=%s",
+		"%s/api/v1/internal/enrollments?user_id=%s&course_instance_id=%s",
 		c.baseURL, userID, courseInstanceID,
 	)
 
@@ -73,7 +92,7 @@ func (c *AcademicClient) IsEnrolled(userID, courseInstanceID string) (bool, erro
 		return false, fmt.Errorf("building enrollment request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.serviceHTTP.Do(req)
 	if err != nil {
 		c.logger.Warn("academic client: enrollment check request failed",
 			zap.String("user_id", userID),
@@ -182,6 +201,10 @@ func (c *AcademicClient) GetStudentCourses(token string) ([]StudentCourseItem, e
 // Instructor course assignments
 // ─────────────────────────────────────────────────────────────────────────────
 
+// CourseRoleTA is the Academic Service role string for teaching assistants.
+// TAs can view and grade submissions but cannot edit assignment content.
+const CourseRoleTA = "TA"
+
 // CourseInstructorItem represents a single instructor ↔ course instance assignment.
 type CourseInstructorItem struct {
 	CourseInstanceID string `json:"course_instance_id"`
@@ -236,3 +259,117 @@ func (c *AcademicClient) GetInstructorCourses(token string) ([]CourseInstructorI
 
 	return body.Courses, nil
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Course access snapshot
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CourseAccessSnapshot mirrors the response from
+// GET /api/v1/internal/course-access: every enrollment, instructor
+// assignment and section membership. The rows of a course instance are consistent with its events
+// up to CourseInstanceSequences (0 when absent); Sequence is the highest
+// sequence committed overall.
+type CourseAccessSnapshot struct {
+	Sequence                int64               `json:"sequence"`
+	CourseInstanceSequences map[uuid.UUID]int64 `json:"course_instance_sequences"`
+	Enrollments             []struct {
+		CourseInstanceID uuid.UUID `json:"course_instance_id"`
+		UserID           uuid.UUID `json:"user_id"`
+		Status           string    `json:"status"`
+	} `json:"enrollments"`
+	Instructors []struct {
+		CourseInstanceID uuid.UUID `json:"course_instance_id"`
+		UserID           uuid.UUID `json:"user_id"`
+		Role             string    `json:"role"`
+	} `json:"instructors"`
+	SectionMembers []struct {
+		CourseInstanceID uuid.UUID `json:"course_instance_id"`
+		SectionID        uuid.UUID `json:"section_id"`
+		UserID           uuid.UUID `json:"user_id"`
+	} `json:"section_members"`
+}
+
+// GetCourseAccessSnapshot calls GET /api/v1/internal/course-access with a
+// service token. tokens must be granted the academic.events:read scope.
+func (c *AcademicClient) GetCourseAccessSnapshot() (*CourseAccessSnapshot, error) {
+	url := fmt.Sprintf("%s/api/v1/internal/course-access", c.baseURL)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building course access request: %w", err)
+	}
+
+	resp, err := c.snapshotHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("course access request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("course access snapshot returned status %d", resp.StatusCode)
+	}
+
+	var body CourseAccessSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding course access response: %w", err)
+	}
+	return &body, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Course sections
+// ─────────────────────────────────────────────────────────────────────────────
+
+// SectionItem holds the fields the assessment service needs from a course
+// section or lab group.
+type SectionItem struct {
+	ID   uuid.UUID `json:"id"`
+	Kind string    `json:"kind"`
+	Name string    `json:"name"`
+}
+
+// sectionsResponse mirrors the response from
+// GET /api/v1/internal/course-instances/:id/sections
+type sectionsResponse struct {
+	Sections []SectionItem `json:"sections"`
+	Count    int           `json:"count"`
+}
+
+// ListSections calls GET /api/v1/internal/course-instances/:id/sections with a
+// service token. When userID is non-empty only the sections that student
+// belongs to are returned.
+func (c *AcademicClient) ListSections(courseInstanceID, userID string) ([]SectionItem, error) {
+	url := fmt.Sprintf("%s/api/v1/internal/course-instances/%s/sections", c.baseURL, courseInstanceID)
+	if userID != "" {
+		url += "?user_id=" + userID
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building sections request: %w", err)
+	}
+
+	resp, err := c.serviceHTTP.Do(req)
+	if err != nil {
+		c.logger.Warn("academic client: sections request failed",
+			zap.String("course_instance_id", courseInstanceID),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("sections request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		c.logger.Warn("academic client: unexpected status on sections",
+			zap.Int("status", resp.StatusCode),
+			zap.String("course_instance_id", courseInstanceID),
+		)
+		return nil, fmt.Errorf("sections returned status %d", resp.StatusCode)
+	}
+
+	var body sectionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding sections response: %w", err)
+	}
+	return body.Sections, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"go.uber.org/zap"
 )
 
@@ -21,9 +22,18 @@ const (
 
 	// Submission audit actions
 	AuditActionSubmissionCreated AuditAction = "SUBMISSION_CREATED"
+	AuditActionSubmissionGraded  AuditAction = "SUBMISSION_GRADED"
+
+	// Regrade audit actions
+	AuditActionRegradeRequested AuditAction = "REGRADE_REQUESTED"
+	AuditActionRegradeAnswered  AuditAction = "REGRADE_ANSWERED"
 
 	// Group audit actions
 	AuditActionGroupCreated AuditAction = "GROUP_CREATED"
+
+	// User data actions
+	AuditActionUserDataErased   AuditAction = "USER_DATA_ERASED"
+	AuditActionUserDataExported AuditAction = "USER_DATA_EXPORTED"
 )
 
 // AuditLogRequest is the payload sent to the IAM Service audit-log endpoint.
@@ -48,12 +58,14 @@ type AuditClient struct {
 	logger        *zap.Logger
 }
 
-// NewAuditClient creates a new AuditClient.
-func NewAuditClient(iamServiceURL string, logger *zap.Logger) *AuditClient {
+// NewAuditClient creates a new AuditClient.  Requests are authenticated with
+// service tokens from tokens, which must be granted the iam.audit:write scope.
+func NewAuditClient(iamServiceURL string, tokens *servicetoken.Client, logger *zap.Logger) *AuditClient {
 	return &AuditClient{
 		iamServiceURL: iamServiceURL,
 		httpClient: &http.Client{
-			Timeout: 5 * time.Second,
+			Timeout:   5 * time.Second,
+			Transport: tokens.Transport(nil),
 		},
 		logger: logger,
 	}
@@ -20,6 +20,41 @@ type Config struct {
 	FrontendURL    string
 	IAMServiceURL  string
 	AcademicSvcURL string
+	ServiceClient  ServiceClientConfig
+	CourseAccess   CourseAccessConfig
+	Analytics      AnalyticsConfig
+}
+
+// CourseAccessConfig holds settings for the local read-model of Academic
+// Service enrollments and instructor assignments.
+type CourseAccessConfig struct {
+	// ReconcileInterval is how often the read-model is rebuilt from an
+	// Academic Service snapshot. Defaults to 15 minutes; zero disables it.
+	ReconcileInterval time.Duration
+
+	// MaxStaleness is how old the last reconciliation may be before per-user
+	// course lists are fetched from the Academic Service first. Defaults to
+	// 60 minutes.
+	MaxStaleness time.Duration
+
+	// CacheTTL is how long Academic Service answers are cached for lookups
+	// the read-model cannot serve. Defaults to 60 seconds.
+	CacheTTL time.Duration
+}
+
+// AnalyticsConfig holds settings for the instructor analytics views.
+type AnalyticsConfig struct {
+	// RefreshDelay is how long after a submission event the analytics views
+	// are refreshed; events within the delay share one refresh. Defaults to
+	// 10 seconds.
+	RefreshDelay time.Duration
+}
+
+// ServiceClientConfig holds the credentials this service uses to obtain
+// service tokens from IAM for service-to-service calls.
+type ServiceClientConfig struct {
+	ClientID     string
+	ClientSecret string
 }
 
 // ServerConfig holds HTTP server settings.
@@ -122,6 +157,18 @@ func Load() (*Config, error) {
 		FrontendURL:    getEnv("FRONTEND_URL", "http://localhost:3000"),
 		IAMServiceURL:  getEnv("IAM_SERVICE_URL", "http://localhost:8081"),
 		AcademicSvcURL: getEnv("ACADEMIC_SERVICE_URL", "http://localhost:8083"),
+		ServiceClient: ServiceClientConfig{
+			ClientID:     getEnv("SERVICE_CLIENT_ID", "assessment-service"),
+			ClientSecret: getEnv("SERVICE_CLIENT_SECRET", ""),
+		},
+		CourseAccess: CourseAccessConfig{
+			ReconcileInterval: time.Duration(getEnvAsInt("COURSE_ACCESS_RECONCILE_MINUTES", 15)) * time.Minute,
+			MaxStaleness:      time.Duration(getEnvAsInt("COURSE_ACCESS_MAX_STALENESS_MINUTES", 60)) * time.Minute,
+			CacheTTL:          time.Duration(getEnvAsInt("COURSE_ACCESS_CACHE_TTL_SECONDS", 60)) * time.Second,
+		},
+		Analytics: AnalyticsConfig{
+			RefreshDelay: time.Duration(getEnvAsInt("ANALYTICS_REFRESH_DELAY_SECONDS", 10)) * time.Second,
+		},
 	}, nil
 }
 
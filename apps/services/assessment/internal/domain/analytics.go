@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Submission analytics read-model
+// ─────────────────────────────────────────────────────────────────────────────
+
+// SubmissionAnalyticsProjection names the projection state row of the
+// submission analytics views. Its ReconciledAt is the time of the last
+// refresh.
+const SubmissionAnalyticsProjection = "submission_analytics"
+
+// SubmissionOwnerStats is a row of the assignment_owner_stats materialised
+// view: the submissions of one student, or of one group when IsGroup is set,
+// on an assignment.
+type SubmissionOwnerStats struct {
+	AssignmentID     uuid.UUID `gorm:"type:uuid"`
+	OwnerID          uuid.UUID `gorm:"type:uuid"`
+	IsGroup          bool
+	Attempts         int
+	FirstSubmittedAt time.Time
+	LastSubmittedAt  time.Time
+	// Score is the score of the newest graded version, nil until one is graded.
+	Score *float64
+	// AvgExecutionMs and AvgMemoryKB average the Judge0 runs of every version.
+	AvgExecutionMs *float64 `gorm:"column:avg_execution_ms"`
+	AvgMemoryKB    *float64 `gorm:"column:avg_memory_kb"`
+}
+
+// TableName overrides the GORM default table name.
+func (SubmissionOwnerStats) TableName() string {
+	return "assignment_owner_stats"
+}
+
+// TestCaseStats is a row of the assignment_test_case_stats materialised view:
+// how the latest submission versions of an assignment fared on one test case.
+type TestCaseStats struct {
+	AssignmentID uuid.UUID `gorm:"type:uuid"`
+	TestCaseID   string
+	Runs         int64
+	Passed       int64
+
+	// Joined from the assignment's test case when it still exists.
+	Description string `gorm:"->"`
+	IsHidden    bool   `gorm:"->"`
+}
+
+// TableName overrides the GORM default table name.
+func (TestCaseStats) TableName() string {
+	return "assignment_test_case_stats"
+}
+
+// SubmissionTimingBucket is a row of the assignment_submission_timing
+// materialised view: how many submissions of an assignment arrived in the
+// hour starting HoursFromDue hours after the due date. Submissions by members
+// of a targeted section are measured against that section's due date, as
+// Assignment.TargetFor picks it. Buckets before the due date are negative.
+type SubmissionTimingBucket struct {
+	AssignmentID uuid.UUID `gorm:"type:uuid"`
+	HoursFromDue int
+	Submissions  int64
+}
+
+// TableName overrides the GORM default table name.
+func (SubmissionTimingBucket) TableName() string {
+	return "assignment_submission_timing"
+}
+
+// AssignmentStats aggregates the analytics views over one assignment. Owners
+// counts the students and groups with at least one submission; the score
+// figures cover the graded ones.
+type AssignmentStats struct {
+	AssignmentID    uuid.UUID
+	Owners          int64
+	Graded          int64
+	MinScore        *float64
+	MaxScore        *float64
+	MeanScore       *float64
+	MedianScore     *float64
+	StdDevScore     *float64
+	MeanAttempts    *float64
+	AvgExecutionMs  *float64 `gorm:"column:avg_execution_ms"`
+	AvgMemoryKB     *float64 `gorm:"column:avg_memory_kb"`
+	LateSubmissions int64
+}
+
+// AssignmentAudience counts the students an assignment is set for — the
+// enrolled students, or those in its targeted sections — and how many of
+// them have no submission of their own or of a group.
+type AssignmentAudience struct {
+	AssignmentID uuid.UUID
+	Students     int64
+	NotSubmitted int64
+}
@@ -58,8 +58,71 @@ type Assignment struct {
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Sections restricts the assignment to students in these course sections
+	// or lab groups. Empty means every enrolled student.
+	Sections []AssignmentSection `gorm:"foreignKey:AssignmentID" json:"sections,omitempty"`
+}
+
+// TargetFor reports whether a student in the given sections is set the
+// assignment, and the due dates that apply to them. A section without its
+// own dates uses the assignment's; when several targeted sections match, the
+// latest due date wins so that a student is never held to the stricter one.
+func (a *Assignment) TargetFor(sectionIDs []uuid.UUID) (targeted bool, dueAt, lateDueAt *time.Time) {
+	if len(a.Sections) == 0 {
+		return true, a.DueAt, a.LateDueAt
+	}
+
+	member := make(map[uuid.UUID]bool, len(sectionIDs))
+	for _, id := range sectionIDs {
+		member[id] = true
+	}
+	for _, target := range a.Sections {
+		if !member[target.SectionID] {
+			continue
+		}
+		due, late := a.DueAt, a.LateDueAt
+		if target.DueAt != nil {
+			due = target.DueAt
+		}
+		if target.LateDueAt != nil {
+			late = target.LateDueAt
+		}
+		if !targeted || later(due, dueAt) {
+			dueAt = due
+		}
+		if !targeted || later(late, lateDueAt) {
+			lateDueAt = late
+		}
+		targeted = true
+	}
+	return targeted, dueAt, lateDueAt
+}
+
+// later reports whether a is after b, treating nil as no deadline.
+func later(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	return a.After(*b)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// AssignmentSection
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AssignmentSection targets an assignment at one course section or lab group
+// of the Academic Service, optionally with its own due dates. section_id is
+// a logical cross-service reference.
+type AssignmentSection struct {
+	AssignmentID uuid.UUID  `gorm:"type:uuid;primaryKey" json:"assignment_id"`
+	SectionID    uuid.UUID  `gorm:"type:uuid;primaryKey" json:"section_id"`
+	DueAt        *time.Time `gorm:"type:timestamp"       json:"due_at,omitempty"`
+	LateDueAt    *time.Time `gorm:"type:timestamp"       json:"late_due_at,omitempty"`
 }
 
+func (AssignmentSection) TableName() string { return "assignment_sections" }
+
 // ─────────────────────────────────────────────────────────────────────────────
 // TestCase  (lightweight — used by EvaluationService, NOT a GORM model)
 // ─────────────────────────────────────────────────────────────────────────────
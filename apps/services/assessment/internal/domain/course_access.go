@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Course access read-model
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Values mirrored from the Academic Service.
+const (
+	// EnrollmentStatusEnrolled is the only status that allows submissions.
+	EnrollmentStatusEnrolled = "Enrolled"
+	// CourseAccessProjection names the projection state row of the course
+	// access read-model.
+	CourseAccessProjection = "academic_course_access"
+)
+
+// EnrollmentProjection is the local copy of an Academic Service enrollment,
+// kept up to date from academic domain events and periodic reconciliation.
+// Sequence is the academic event sequence the row reflects; older events are
+// ignored. Removed marks an enrollment deleted in the Academic Service — the
+// row is kept so a redelivered older event cannot resurrect it.
+type EnrollmentProjection struct {
+	CourseInstanceID uuid.UUID `gorm:"type:uuid;primaryKey"        json:"course_instance_id"`
+	UserID           uuid.UUID `gorm:"type:uuid;primaryKey;index"  json:"user_id"`
+	Status           string    `gorm:"type:varchar(20);not null"   json:"status"`
+	Removed          bool      `gorm:"not null;default:false"      json:"removed"`
+	Sequence         int64     `gorm:"not null"                    json:"sequence"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TableName overrides the GORM default table name.
+func (EnrollmentProjection) TableName() string {
+	return "enrollment_projections"
+}
+
+// InstructorProjection is the local copy of an Academic Service course
+// instructor assignment. Sequence and Removed work as on EnrollmentProjection.
+type InstructorProjection struct {
+	CourseInstanceID uuid.UUID `gorm:"type:uuid;primaryKey"       json:"course_instance_id"`
+	UserID           uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	Role             string    `gorm:"type:varchar(50);not null"  json:"role"`
+	Removed          bool      `gorm:"not null;default:false"     json:"removed"`
+	Sequence         int64     `gorm:"not null"                   json:"sequence"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TableName overrides the GORM default table name.
+func (InstructorProjection) TableName() string {
+	return "instructor_projections"
+}
+
+// SectionMemberProjection is the local copy of a student's membership in an
+// Academic Service course section or lab group, used to work out who a
+// section-targeted assignment is set for. Sequence and Removed work as on
+// EnrollmentProjection.
+type SectionMemberProjection struct {
+	CourseInstanceID uuid.UUID `gorm:"type:uuid;primaryKey"       json:"course_instance_id"`
+	UserID           uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	SectionID        uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"section_id"`
+	Removed          bool      `gorm:"not null;default:false"     json:"removed"`
+	Sequence         int64     `gorm:"not null"                   json:"sequence"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TableName overrides the GORM default table name.
+func (SectionMemberProjection) TableName() string {
+	return "section_member_projections"
+}
+
+// ProjectionState records how far a read-model has caught up. LastSequence
+// is the highest event sequence applied; ReconciledAt is set by the last
+// successful full reconciliation and stays nil until the first one, before
+// which the read-model is incomplete.
+type ProjectionState struct {
+	Name         string     `gorm:"type:varchar(100);primaryKey" json:"name"`
+	LastSequence int64      `gorm:"not null;default:0"           json:"last_sequence"`
+	LastEventAt  *time.Time `gorm:""                             json:"last_event_at,omitempty"`
+	ReconciledAt *time.Time `gorm:""                             json:"reconciled_at,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the GORM default table name.
+func (ProjectionState) TableName() string {
+	return "projection_states"
+}
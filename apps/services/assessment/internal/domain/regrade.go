@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RegradeRequest
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Regrade request lifecycle states.
+const (
+	RegradeStatusOpen     = "open"
+	RegradeStatusAccepted = "accepted"
+	RegradeStatusRejected = "rejected"
+)
+
+// RegradeRequest is a student's request to have a graded submission reviewed.
+// Course staff (instructors and TAs) answer it by accepting — optionally with
+// a new score — or rejecting it.  At most one request per submission may be
+// open at a time; the constraint is enforced at the service layer.
+type RegradeRequest struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SubmissionID uuid.UUID `gorm:"type:uuid;not null;index"                       json:"submission_id"`
+	AssignmentID uuid.UUID `gorm:"type:uuid;not null;index"                       json:"assignment_id"`
+	RequestedBy  uuid.UUID `gorm:"type:uuid;not null;index"                       json:"requested_by"`
+
+	Reason string `gorm:"type:text;not null"                        json:"reason"`
+	Status string `gorm:"type:varchar(20);not null;default:'open'" json:"status"`
+
+	PreviousScore *float64   `gorm:"type:numeric(6,2)" json:"previous_score,omitempty"`
+	NewScore      *float64   `gorm:"type:numeric(6,2)" json:"new_score,omitempty"`
+	Response      string     `gorm:"type:text"         json:"response,omitempty"`
+	RespondedBy   *uuid.UUID `gorm:"type:uuid"         json:"responded_by,omitempty"`
+	RespondedAt   *time.Time `gorm:""                  json:"responded_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the GORM default table name.
+func (RegradeRequest) TableName() string {
+	return "regrade_requests"
+}
+
+// BeforeCreate generates a UUID when not already populated.
+func (r *RegradeRequest) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -95,6 +95,12 @@ type Submission struct {
 	AIConfidence            *float64   `gorm:"type:numeric(5,4)" json:"ai_confidence,omitempty"`
 	SemanticSimilarityScore *float64   `gorm:"type:numeric(6,2)" json:"semantic_similarity_score,omitempty"`
 	AnalyzedAt              *time.Time `gorm:"" json:"analyzed_at,omitempty"`
+
+	// Manual grading by course staff (instructors and TAs)
+	Score    *float64   `gorm:"type:numeric(6,2)" json:"score,omitempty"`
+	Feedback string     `gorm:"type:text"         json:"feedback,omitempty"`
+	GradedBy *uuid.UUID `gorm:"type:uuid"         json:"graded_by,omitempty"`
+	GradedAt *time.Time `gorm:""                  json:"graded_at,omitempty"`
 }
 
 // TableName overrides the GORM default table name.
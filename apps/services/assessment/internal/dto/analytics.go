@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Instructor analytics DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AssignmentSummaryResponse condenses the submissions to one assignment.
+// Submitted counts students and groups; NotSubmitted counts the students the
+// assignment is set for. Averages are null until there is something to average.
+type AssignmentSummaryResponse struct {
+	AssignmentID uuid.UUID  `json:"assignment_id"`
+	Title        string     `json:"title"`
+	DueAt        *time.Time `json:"due_at,omitempty"`
+
+	Submitted    int64 `json:"submitted"`
+	NotSubmitted int64 `json:"not_submitted"`
+	Graded       int64 `json:"graded"`
+
+	MeanScore    *float64 `json:"mean_score"`
+	MedianScore  *float64 `json:"median_score"`
+	MeanAttempts *float64 `json:"mean_attempts"`
+	// LateSubmissions counts submission versions made after the due date,
+	// taking a targeted section's own due date for its members.
+	LateSubmissions int64 `json:"late_submissions"`
+
+	AvgExecutionMs *float64 `json:"avg_execution_ms"`
+	AvgMemoryKB    *float64 `json:"avg_memory_kb"`
+}
+
+// AssignmentAnalyticsResponse is the JSON shape returned by
+// GET /instructor-assignments/:id/analytics. Figures come from materialised
+// views refreshed shortly after each submission event; RefreshedAt is the
+// time of the last refresh.
+type AssignmentAnalyticsResponse struct {
+	AssignmentSummaryResponse
+
+	// Enrolled counts the students the assignment is set for: the enrolled
+	// students, or those in its targeted sections.
+	Enrolled        int64                          `json:"enrolled"`
+	Scores          ScoreDistributionResponse      `json:"scores"`
+	TestCases       []TestCaseStatsResponse        `json:"test_cases"`
+	SubmissionTimes []SubmissionTimeBucketResponse `json:"submission_times"`
+	Attempts        []OwnerAttemptsResponse        `json:"attempts"`
+	// NotSubmittedUsers lists the students counted in NotSubmitted.
+	NotSubmittedUsers []uuid.UUID `json:"not_submitted_users"`
+
+	RefreshedAt *time.Time `json:"refreshed_at"`
+}
+
+// ScoreDistributionResponse describes the latest graded score of every
+// student or group.
+type ScoreDistributionResponse struct {
+	Min     *float64              `json:"min"`
+	Max     *float64              `json:"max"`
+	StdDev  *float64              `json:"std_dev"`
+	Buckets []ScoreBucketResponse `json:"buckets"`
+}
+
+// ScoreBucketResponse counts the scores in [From, To); the last bucket also
+// includes To.
+type ScoreBucketResponse struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int64   `json:"count"`
+}
+
+// TestCaseStatsResponse reports how the latest submissions fared on one test
+// case. Test cases removed from the assignment keep their results with an
+// empty description.
+type TestCaseStatsResponse struct {
+	TestCaseID  string  `json:"test_case_id"`
+	Description string  `json:"description"`
+	IsHidden    bool    `json:"is_hidden"`
+	Runs        int64   `json:"runs"`
+	Passed      int64   `json:"passed"`
+	Failed      int64   `json:"failed"`
+	PassRate    float64 `json:"pass_rate"`
+}
+
+// SubmissionTimeBucketResponse counts the submissions made in the hour
+// starting HoursFromDue hours after the due date that applied to the
+// submitter; negative before it.
+type SubmissionTimeBucketResponse struct {
+	HoursFromDue int   `json:"hours_from_due"`
+	Submissions  int64 `json:"submissions"`
+}
+
+// OwnerAttemptsResponse summarises the submissions of one student or group;
+// exactly one of UserID and GroupID is set.
+type OwnerAttemptsResponse struct {
+	UserID          *uuid.UUID `json:"user_id,omitempty"`
+	GroupID         *uuid.UUID `json:"group_id,omitempty"`
+	Attempts        int        `json:"attempts"`
+	LastSubmittedAt time.Time  `json:"last_submitted_at"`
+	Score           *float64   `json:"score"`
+	AvgExecutionMs  *float64   `json:"avg_execution_ms"`
+	AvgMemoryKB     *float64   `json:"avg_memory_kb"`
+}
+
+// CourseInstanceAnalyticsResponse is the JSON shape returned by
+// GET /instructor-assignments/course-instance/:id/analytics.
+type CourseInstanceAnalyticsResponse struct {
+	CourseInstanceID uuid.UUID                   `json:"course_instance_id"`
+	Enrolled         int64                       `json:"enrolled"`
+	Assignments      []AssignmentSummaryResponse `json:"assignments"`
+	RefreshedAt      *time.Time                  `json:"refreshed_at"`
+}
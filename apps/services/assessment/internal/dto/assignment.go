@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 )
 
@@ -40,6 +41,15 @@ type CreateSampleAnswerRequest struct {
 	Code       string `json:"code"`
 }
 
+// AssignmentSectionRequest targets an assignment at one course section or
+// lab group. DueAt and LateDueAt override the assignment's dates for that
+// section; nil keeps the assignment's.
+type AssignmentSectionRequest struct {
+	SectionID uuid.UUID  `json:"section_id"`
+	DueAt     *time.Time `json:"due_at"`
+	LateDueAt *time.Time `json:"late_due_at"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Request DTOs
 // ─────────────────────────────────────────────────────────────────────────────
@@ -81,6 +91,10 @@ type CreateAssignmentRequest struct {
 	// Defaults to 71 (Python 3.8.1) on the service side when omitted.
 	LanguageID int `json:"language_id,omitempty"`
 
+	// Sections restricts the assignment to these sections of the course
+	// instance. Omitted or empty sets it for every enrolled student.
+	Sections []AssignmentSectionRequest `json:"sections,omitempty"`
+
 	// ── Inline content (optional — stored in separate tables on success) ———————
 	RubricCriteria []CreateRubricCriterionRequest `json:"rubric_criteria,omitempty"`
 	TestCases      []CreateTestCaseRequest        `json:"test_cases,omitempty"`
@@ -119,6 +133,10 @@ type UpdateAssignmentRequest struct {
 
 	// Explicit pointer so callers can set is_active=false for soft delete
 	IsActive *bool `json:"is_active"`
+
+	// Sections replaces the section targets when present; an empty list
+	// sets the assignment for every enrolled student again.
+	Sections []AssignmentSectionRequest `json:"sections"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -158,16 +176,46 @@ type AssignmentResponse struct {
 	IsActive  bool      `json:"is_active"`
 	CreatedBy uuid.UUID `json:"created_by"`
 
+	// Sections lists the section targets; empty means every enrolled
+	// student. Students see the due dates of their own section instead.
+	Sections []AssignmentSectionResponse `json:"sections,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// AssignmentSectionResponse is one section target of an assignment.
+type AssignmentSectionResponse struct {
+	SectionID uuid.UUID  `json:"section_id"`
+	DueAt     *time.Time `json:"due_at,omitempty"`
+	LateDueAt *time.Time `json:"late_due_at,omitempty"`
+}
+
 // ListAssignmentsResponse wraps a slice of AssignmentResponse with a count.
 type ListAssignmentsResponse struct {
 	Assignments []AssignmentResponse `json:"assignments"`
 	Count       int                  `json:"count"`
 }
 
+// AssignmentPageResponse is one page of a course instance's assignments.
+type AssignmentPageResponse struct {
+	Assignments []AssignmentResponse `json:"assignments"`
+	queryspec.Meta
+}
+
+// ListAssignmentsSchema declares the filters, sorts and paging of
+// GET /assignments/course-instance/:courseInstanceId.
+var ListAssignmentsSchema = queryspec.Schema{
+	Fields: map[string]queryspec.Field{
+		"title":           {Kind: queryspec.String, Filter: true, Sort: true},
+		"code":            {Kind: queryspec.String, Filter: true},
+		"assessment_type": {Kind: queryspec.String, Filter: true},
+		"due_at":          {Kind: queryspec.Time, Filter: true},
+		"created_at":      {Kind: queryspec.Time, Filter: true, Sort: true},
+	},
+	Sort: "created_at",
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Content response DTOs (rubric, test cases, sample answer)
 // ─────────────────────────────────────────────────────────────────────────────
@@ -215,3 +263,50 @@ type SampleAnswerResponse struct {
 	Language     string `json:"language"`
 	Code         string `json:"code"`
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Assignment cloning (semester rollover)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CourseInstanceMapping pairs a course instance with its counterpart in the
+// next term. TargetCourseInstanceID may be uuid.Nil on a dry run, when the target
+// instance has not been created yet.
+type CourseInstanceMapping struct {
+	SourceCourseInstanceID uuid.UUID `json:"source_course_instance_id"`
+	TargetCourseInstanceID uuid.UUID `json:"target_course_instance_id"`
+}
+
+// CloneAssignmentsRequest is the payload for POST /internal/assignments/clone.
+// Release and due dates of the copies are shifted by OffsetDays.
+type CloneAssignmentsRequest struct {
+	Mappings    []CourseInstanceMapping `json:"mappings"`
+	OffsetDays  int                     `json:"offset_days"`
+	DryRun      bool                    `json:"dry_run"`
+	RequestedBy string                  `json:"requested_by"`
+}
+
+// ClonedAssignmentResponse describes one assignment copied, or to be copied
+// on a dry run. Assignments whose title already exists in the target course
+// instance are skipped so a rollover can be re-run safely. Section targets
+// name sections of the source course instance and are not copied;
+// SectionsDropped counts them, and such a copy targets every enrolled student
+// until sections are set on it.
+type ClonedAssignmentResponse struct {
+	SourceAssignmentID     uuid.UUID  `json:"source_assignment_id"`
+	TargetAssignmentID     *uuid.UUID `json:"target_assignment_id,omitempty"`
+	SourceCourseInstanceID uuid.UUID  `json:"source_course_instance_id"`
+	TargetCourseInstanceID uuid.UUID  `json:"target_course_instance_id"`
+	Title                  string     `json:"title"`
+	ReleaseAt              *time.Time `json:"release_at,omitempty"`
+	DueAt                  *time.Time `json:"due_at,omitempty"`
+	Skipped                bool       `json:"skipped,omitempty"`
+	SectionsDropped        int        `json:"sections_dropped,omitempty"`
+}
+
+// CloneAssignmentsResponse summarises a clone request.
+type CloneAssignmentsResponse struct {
+	DryRun      bool                       `json:"dry_run"`
+	Cloned      int                        `json:"cloned"`
+	Skipped     int                        `json:"skipped"`
+	Assignments []ClonedAssignmentResponse `json:"assignments"`
+}
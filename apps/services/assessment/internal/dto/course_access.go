@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Course access read-model DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CourseAccessStatusResponse is the JSON shape returned by
+// GET /course-access/status. Ages are in seconds and omitted until the
+// corresponding event or reconciliation has happened; counters are totals
+// since the process started.
+type CourseAccessStatusResponse struct {
+	// Stale is set when the last reconciliation is older than the configured
+	// maximum, in which case requests go to the Academic Service first.
+	Stale bool `json:"stale"`
+
+	LastSequence        int64      `json:"last_sequence"`
+	LastEventAt         *time.Time `json:"last_event_at,omitempty"`
+	LastEventAgeSeconds *float64   `json:"last_event_age_seconds,omitempty"`
+	// LastEventDelaySeconds is the time between the last applied event
+	// occurring in the Academic Service and it being applied here.
+	LastEventDelaySeconds *float64   `json:"last_event_delay_seconds,omitempty"`
+	ReconciledAt          *time.Time `json:"reconciled_at,omitempty"`
+	ReconcileAgeSeconds   *float64   `json:"reconcile_age_seconds,omitempty"`
+	LastReconcileError    string     `json:"last_reconcile_error,omitempty"`
+
+	Enrollments int64 `json:"enrollments"`
+	Instructors int64 `json:"instructors"`
+	CacheSize   int   `json:"cache_size"`
+
+	EventsApplied   int64 `json:"events_applied"`
+	EventsIgnored   int64 `json:"events_ignored"`
+	ProjectionHits  int64 `json:"projection_hits"`
+	CacheHits       int64 `json:"cache_hits"`
+	AcademicCalls   int64 `json:"academic_calls"`
+	AcademicErrors  int64 `json:"academic_errors"`
+	StaleFallbacks  int64 `json:"stale_fallbacks"`
+	ReconcileErrors int64 `json:"reconcile_errors"`
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Grading Request DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GradeSubmissionRequest is the payload for
+// PUT /instructor-submissions/:id/grade.
+type GradeSubmissionRequest struct {
+	Score    *float64 `json:"score"`
+	Feedback string   `json:"feedback"`
+}
+
+// CreateRegradeRequest is the payload for
+// POST /student-submissions/:id/regrade-requests.
+type CreateRegradeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RespondRegradeRequest is the payload for
+// PATCH /instructor-submissions/regrade-requests/:id.
+// NewScore is only applied when Status is "accepted".
+type RespondRegradeRequest struct {
+	Status   string   `json:"status"`
+	Response string   `json:"response"`
+	NewScore *float64 `json:"new_score,omitempty"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Grading Response DTOs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RegradeRequestResponse is the JSON shape returned for a regrade request.
+type RegradeRequestResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	SubmissionID  uuid.UUID  `json:"submission_id"`
+	AssignmentID  uuid.UUID  `json:"assignment_id"`
+	RequestedBy   uuid.UUID  `json:"requested_by"`
+	Reason        string     `json:"reason"`
+	Status        string     `json:"status"`
+	PreviousScore *float64   `json:"previous_score,omitempty"`
+	NewScore      *float64   `json:"new_score,omitempty"`
+	Response      string     `json:"response,omitempty"`
+	RespondedBy   *uuid.UUID `json:"responded_by,omitempty"`
+	RespondedAt   *time.Time `json:"responded_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ListRegradeRequestsResponse wraps a slice of RegradeRequestResponse with a count.
+type ListRegradeRequestsResponse struct {
+	RegradeRequests []RegradeRequestResponse `json:"regrade_requests"`
+	Count           int                      `json:"count"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Course instance scores (final grade computation)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// StudentScoreResponse is one student's effective score on an assignment.
+// For group assignments every group member receives the group's score.
+type StudentScoreResponse struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Score        float64   `json:"score"`
+	SubmissionID uuid.UUID `json:"submission_id"`
+}
+
+// AssignmentScoresResponse lists the effective scores recorded for one
+// assignment. Students without a graded submission are omitted.
+type AssignmentScoresResponse struct {
+	AssignmentID   uuid.UUID              `json:"assignment_id"`
+	Title          string                 `json:"title"`
+	AssessmentType string                 `json:"assessment_type"`
+	DueAt          *time.Time             `json:"due_at,omitempty"`
+	Scores         []StudentScoreResponse `json:"scores"`
+}
+
+// CourseInstanceScoresResponse is returned by
+// GET /internal/course-instances/:id/scores.
+type CourseInstanceScoresResponse struct {
+	CourseInstanceID uuid.UUID                  `json:"course_instance_id"`
+	Assignments      []AssignmentScoresResponse `json:"assignments"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 )
 
@@ -103,6 +104,12 @@ type SubmissionResponse struct {
 	AIConfidence            *float64   `json:"ai_confidence,omitempty"`
 	SemanticSimilarityScore *float64   `json:"semantic_similarity_score,omitempty"`
 	AnalyzedAt              *time.Time `json:"analyzed_at,omitempty"`
+
+	// Manual grading
+	Score    *float64   `json:"score,omitempty"`
+	Feedback string     `json:"feedback,omitempty"`
+	GradedBy *uuid.UUID `json:"graded_by,omitempty"`
+	GradedAt *time.Time `json:"graded_at,omitempty"`
 }
 
 // SubmissionCodeResponse wraps the raw source code returned by
@@ -134,6 +141,28 @@ type ListSubmissionsResponse struct {
 	Count       int                  `json:"count"`
 }
 
+// SubmissionPageResponse is one page of an assignment's submissions.
+type SubmissionPageResponse struct {
+	Submissions []SubmissionResponse `json:"submissions"`
+	queryspec.Meta
+}
+
+// ListAllSubmissionsSchema declares the filters, sorts and paging of
+// GET /instructor-submissions/assignment/:id. The default sort is
+// version-descending (latest first).
+var ListAllSubmissionsSchema = queryspec.Schema{
+	Fields: map[string]queryspec.Field{
+		"user_id":      {Kind: queryspec.UUID, Filter: true},
+		"group_id":     {Kind: queryspec.UUID, Filter: true},
+		"status":       {Kind: queryspec.String, Filter: true, Sort: true},
+		"is_latest":    {Kind: queryspec.Bool, Filter: true},
+		"language":     {Kind: queryspec.String, Filter: true},
+		"version":      {Kind: queryspec.Int, Filter: true, Sort: true},
+		"submitted_at": {Kind: queryspec.Time, Filter: true, Sort: true},
+	},
+	Sort: "-version",
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Group Request DTOs
 // ─────────────────────────────────────────────────────────────────────────────
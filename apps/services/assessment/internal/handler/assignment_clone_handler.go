@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// AssignmentCloneHandler handles internal requests to copy assignments
+// between course instances.
+type AssignmentCloneHandler struct {
+	cloneService service.AssignmentCloneService
+	logger       *zap.Logger
+}
+
+// NewAssignmentCloneHandler creates a new AssignmentCloneHandler.
+func NewAssignmentCloneHandler(cloneService service.AssignmentCloneService, logger *zap.Logger) *AssignmentCloneHandler {
+	return &AssignmentCloneHandler{
+		cloneService: cloneService,
+		logger:       logger,
+	}
+}
+
+// CloneAssignments handles POST /api/v1/internal/assignments/clone. The
+// Academic Service calls it during a semester rollover; callers need the
+// assessment.assignments:clone scope.
+func (h *AssignmentCloneHandler) CloneAssignments(c fiber.Ctx) error {
+	var req dto.CloneAssignmentsRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	resp, err := h.cloneService.CloneAssignments(&req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
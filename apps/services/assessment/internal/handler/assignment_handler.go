@@ -119,7 +119,7 @@ func (h *AssignmentHandler) UpdateAssignment(c fiber.Ctx) error {
 // ─────────────────────────────────────────────────────────────────────────────
 
 // ListAssignmentsByCourseInstance handles GET /assignments/course-instance/:courseInstanceId.
-// Returns all active assignments belonging to the given CourseInstance.
+// Returns a page of the active assignments belonging to the given CourseInstance.
 // This route must be registered BEFORE GET /assignments/:id so that Fiber does
 // not treat the literal segment "course-instance" as a UUID param value.
 func (h *AssignmentHandler) ListAssignmentsByCourseInstance(c fiber.Ctx) error {
@@ -128,18 +128,23 @@ func (h *AssignmentHandler) ListAssignmentsByCourseInstance(c fiber.Ctx) error {
 		return err
 	}
 
-	assignments, err := h.assignmentService.ListAssignmentsByCourseInstance(courseInstanceID)
+	spec, err := parseListQuery(c, dto.ListAssignmentsSchema)
 	if err != nil {
 		return err
 	}
 
-	responses := make([]dto.AssignmentResponse, len(assignments))
-	for i := range assignments {
-		responses[i] = toAssignmentResponse(&assignments[i])
+	page, err := h.assignmentService.ListAssignmentsPage(courseInstanceID, spec)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.AssignmentResponse, len(page.Items))
+	for i := range page.Items {
+		responses[i] = toAssignmentResponse(&page.Items[i])
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.ListAssignmentsResponse{
+	return c.Status(fiber.StatusOK).JSON(dto.AssignmentPageResponse{
 		Assignments: responses,
-		Count:       len(responses),
+		Meta:        page.Meta(),
 	})
 }
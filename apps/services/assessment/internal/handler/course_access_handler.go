@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/service"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// CourseAccessHandler reports on the local read-model of Academic Service
+// enrollments and instructor assignments.
+type CourseAccessHandler struct {
+	courseAccessService service.CourseAccessService
+	logger              *zap.Logger
+}
+
+// NewCourseAccessHandler creates a new CourseAccessHandler.
+func NewCourseAccessHandler(courseAccessService service.CourseAccessService, logger *zap.Logger) *CourseAccessHandler {
+	return &CourseAccessHandler{
+		courseAccessService: courseAccessService,
+		logger:              logger,
+	}
+}
+
+// GetStatus handles GET /api/v1/course-access/status. It returns the
+// read-model's staleness and how often requests were answered locally,
+// from the cache or by the Academic Service.
+func (h *CourseAccessHandler) GetStatus(c fiber.Ctx) error {
+	status, err := h.courseAccessService.Status()
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(status)
+}
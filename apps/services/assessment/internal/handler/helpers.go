@@ -4,6 +4,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
 )
@@ -29,6 +30,17 @@ func parseUUID(c fiber.Ctx, param string) (uuid.UUID, error) {
 	return id, nil
 }
 
+// parseListQuery parses the paging, filter and sort parameters of a list
+// request against the list's schema.
+// Returns a 400 Bad Request AppError describing the bad parameter.
+func parseListQuery(c fiber.Ctx, schema queryspec.Schema) (queryspec.Spec, error) {
+	spec, err := schema.Parse(c.Queries())
+	if err != nil {
+		return queryspec.Spec{}, utils.ErrBadRequest(err.Error())
+	}
+	return spec, nil
+}
+
 // requireUsername extracts the authenticated username stored in fiber.Ctx locals
 // by the JWT auth middleware.  Returns an empty string when not present.
 func requireUsername(c fiber.Ctx) string {
@@ -50,6 +62,15 @@ func requireUserID(c fiber.Ctx) uuid.UUID {
 
 // toAssignmentResponse converts a domain.Assignment to its DTO representation.
 func toAssignmentResponse(a *domain.Assignment) dto.AssignmentResponse {
+	var sections []dto.AssignmentSectionResponse
+	for _, target := range a.Sections {
+		sections = append(sections, dto.AssignmentSectionResponse{
+			SectionID: target.SectionID,
+			DueAt:     target.DueAt,
+			LateDueAt: target.LateDueAt,
+		})
+	}
+
 	return dto.AssignmentResponse{
 		ID:               a.ID,
 		CourseInstanceID: a.CourseInstanceID,
@@ -78,7 +99,39 @@ func toAssignmentResponse(a *domain.Assignment) dto.AssignmentResponse {
 		IsActive:  a.IsActive,
 		CreatedBy: a.CreatedBy,
 
+		Sections: sections,
+
 		CreatedAt: a.CreatedAt,
 		UpdatedAt: a.UpdatedAt,
 	}
 }
+
+// toRegradeRequestResponse converts a domain.RegradeRequest to its DTO representation.
+func toRegradeRequestResponse(r *domain.RegradeRequest) dto.RegradeRequestResponse {
+	return dto.RegradeRequestResponse{
+		ID:            r.ID,
+		SubmissionID:  r.SubmissionID,
+		AssignmentID:  r.AssignmentID,
+		RequestedBy:   r.RequestedBy,
+		Reason:        r.Reason,
+		Status:        r.Status,
+		PreviousScore: r.PreviousScore,
+		NewScore:      r.NewScore,
+		Response:      r.Response,
+		RespondedBy:   r.RespondedBy,
+		RespondedAt:   r.RespondedAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+// toListRegradeRequestsResponse wraps regrade requests with their count.
+func toListRegradeRequestsResponse(requests []domain.RegradeRequest) dto.ListRegradeRequestsResponse {
+	items := make([]dto.RegradeRequestResponse, len(requests))
+	for i := range requests {
+		items[i] = toRegradeRequestResponse(&requests[i])
+	}
+	return dto.ListRegradeRequestsResponse{
+		RegradeRequests: items,
+		Count:           len(items),
+	}
+}
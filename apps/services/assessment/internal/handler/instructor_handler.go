@@ -14,11 +14,16 @@ import (
 	"go.uber.org/zap"
 )
 
-// InstructorHandler handles instructor-scoped assessment requests.
+// InstructorHandler handles course-staff-scoped assessment requests.
+// Instructors and teaching assistants of a course instance may read its
+// assignments, view and grade submissions, and answer regrade requests; only
+// non-TA staff may create or edit assignments.
 type InstructorHandler struct {
 	assignmentService service.AssignmentService
 	submissionService service.SubmissionService
-	academicClient    *client.AcademicClient
+	gradingService    service.GradingService
+	analyticsService  service.AnalyticsService
+	courseAccess      service.CourseAccessService
 	logger            *zap.Logger
 }
 
@@ -26,13 +31,17 @@ type InstructorHandler struct {
 func NewInstructorHandler(
 	assignmentService service.AssignmentService,
 	submissionService service.SubmissionService,
-	academicClient *client.AcademicClient,
+	gradingService service.GradingService,
+	analyticsService service.AnalyticsService,
+	courseAccess service.CourseAccessService,
 	logger *zap.Logger,
 ) *InstructorHandler {
 	return &InstructorHandler{
 		assignmentService: assignmentService,
 		submissionService: submissionService,
-		academicClient:    academicClient,
+		gradingService:    gradingService,
+		analyticsService:  analyticsService,
+		courseAccess:      courseAccess,
 		logger:            logger,
 	}
 }
@@ -51,39 +60,59 @@ func extractToken(c fiber.Ctx) string {
 	return ""
 }
 
-// instructorCourseIDs calls the Academic Service and returns the set of
-// course_instance_id UUIDs the instructor is assigned to.
-func (h *InstructorHandler) instructorCourseIDs(c fiber.Ctx) (map[uuid.UUID]bool, error) {
+// courseRoles maps each course_instance_id the caller is assigned to onto
+// their role there ("Lead Instructor", "TA", ...), from the course access
+// read-model or, failing that, the Academic Service.
+func (h *InstructorHandler) courseRoles(c fiber.Ctx) (map[uuid.UUID]string, error) {
 	token := extractToken(c)
-	if token == "" {
+	userID := requireUserID(c)
+	if token == "" || userID == uuid.Nil {
 		return nil, utils.ErrUnauthorized("user not authenticated")
 	}
 
-	courses, err := h.academicClient.GetInstructorCourses(token)
+	courses, err := h.courseAccess.InstructorCourses(userID, token)
 	if err != nil {
 		h.logger.Error("failed to fetch instructor courses", zap.Error(err))
 		return nil, utils.ErrInternal("failed to verify instructor courses", err)
 	}
 
-	ids := make(map[uuid.UUID]bool, len(courses))
+	roles := make(map[uuid.UUID]string, len(courses))
 	for _, course := range courses {
 		id, err := uuid.Parse(course.CourseInstanceID)
 		if err == nil {
-			ids[id] = true
+			roles[id] = course.Role
 		}
 	}
 
-	return ids, nil
+	return roles, nil
+}
+
+// requireCourseAccess verifies the caller is assigned to the course instance.
+// Teaching assistants pass only when allowTA is set.
+func (h *InstructorHandler) requireCourseAccess(c fiber.Ctx, courseInstanceID uuid.UUID, allowTA bool) error {
+	roles, err := h.courseRoles(c)
+	if err != nil {
+		return err
+	}
+	role, ok := roles[courseInstanceID]
+	if !ok {
+		return utils.ErrForbidden("you are not assigned to this course instance")
+	}
+	if role == client.CourseRoleTA && !allowTA {
+		return utils.ErrForbidden("teaching assistants cannot edit assignments")
+	}
+	return nil
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // GET /api/v1/instructor-assignments/me
 // ─────────────────────────────────────────────────────────────────────────────
 
-// GetMyAssignments lists all assignments across the instructor's assigned
-// course instances. Optionally filter by ?course_instance_id=uuid.
+// GetMyAssignments lists the instructor's own assignments across their
+// assigned course instances; teaching assistants see every assignment of the
+// courses they assist. Optionally filter by ?course_instance_id=uuid.
 func (h *InstructorHandler) GetMyAssignments(c fiber.Ctx) error {
-	courseIDs, err := h.instructorCourseIDs(c)
+	courseRoles, err := h.courseRoles(c)
 	if err != nil {
 		return err
 	}
@@ -92,7 +121,7 @@ func (h *InstructorHandler) GetMyAssignments(c fiber.Ctx) error {
 
 	// Optional filter by course_instance_id query parameter
 	filterCourseID := c.Query("course_instance_id")
-	var targetCourseIDs map[uuid.UUID]bool
+	var targetCourseIDs map[uuid.UUID]string
 
 	if filterCourseID != "" {
 		filterID, err := uuid.Parse(filterCourseID)
@@ -100,18 +129,19 @@ func (h *InstructorHandler) GetMyAssignments(c fiber.Ctx) error {
 			return utils.ErrBadRequest("invalid course_instance_id format")
 		}
 		// Verify instructor is assigned to this course instance
-		if !courseIDs[filterID] {
+		role, ok := courseRoles[filterID]
+		if !ok {
 			return utils.ErrForbidden("you are not assigned to this course instance")
 		}
 		// Only query this one course instance
-		targetCourseIDs = map[uuid.UUID]bool{filterID: true}
+		targetCourseIDs = map[uuid.UUID]string{filterID: role}
 	} else {
 		// Query all assigned course instances
-		targetCourseIDs = courseIDs
+		targetCourseIDs = courseRoles
 	}
 
 	var allAssignments []dto.AssignmentResponse
-	for ciID := range targetCourseIDs {
+	for ciID, role := range targetCourseIDs {
 		assignments, err := h.assignmentService.ListAssignmentsByCourseInstance(ciID)
 		if err != nil {
 			h.logger.Warn("failed to list assignments for course instance",
@@ -121,8 +151,9 @@ func (h *InstructorHandler) GetMyAssignments(c fiber.Ctx) error {
 			continue
 		}
 		for i := range assignments {
-			// Enforce assignment ownership: only fetch assignments created by this instructor
-			if assignments[i].CreatedBy == userID {
+			// Enforce assignment ownership: only fetch assignments created by this
+			// instructor. TAs assist the whole course, so they see all of them.
+			if role == client.CourseRoleTA || assignments[i].CreatedBy == userID {
 				allAssignments = append(allAssignments, toAssignmentResponse(&assignments[i]))
 			}
 		}
@@ -147,13 +178,9 @@ func (h *InstructorHandler) CreateAssignment(c fiber.Ctx) error {
 	}
 
 	// Verify the instructor is assigned to this course instance
-	courseIDs, err := h.instructorCourseIDs(c)
-	if err != nil {
+	if err := h.requireCourseAccess(c, req.CourseInstanceID, false); err != nil {
 		return err
 	}
-	if !courseIDs[req.CourseInstanceID] {
-		return utils.ErrForbidden("you are not assigned to this course instance")
-	}
 
 	username := requireUsername(c)
 	if username == "" {
@@ -179,8 +206,9 @@ func (h *InstructorHandler) CreateAssignment(c fiber.Ctx) error {
 // GET /api/v1/instructor-submissions/assignment/:id
 // ─────────────────────────────────────────────────────────────────────────────
 
-// GetSubmissions lists all submissions for an assignment owned by one of the
-// instructor's assigned course instances.
+// GetSubmissions lists a page of the submissions for an assignment owned by
+// one of the caller's assigned course instances. Teaching assistants are
+// allowed.
 func (h *InstructorHandler) GetSubmissions(c fiber.Ctx) error {
 	assignmentID, err := parseUUID(c, "id")
 	if err != nil {
@@ -196,29 +224,30 @@ func (h *InstructorHandler) GetSubmissions(c fiber.Ctx) error {
 		return utils.ErrNotFound("assignment not found")
 	}
 
-	// Verify instructor is assigned to the assignment's course instance
-	courseIDs, err := h.instructorCourseIDs(c)
-	if err != nil {
+	// Verify the caller is course staff on the assignment's course instance
+	if err := h.requireCourseAccess(c, assignment.CourseInstanceID, true); err != nil {
 		return err
 	}
-	if !courseIDs[assignment.CourseInstanceID] {
-		return utils.ErrForbidden("you are not assigned to this course instance")
+
+	spec, err := parseListQuery(c, dto.ListAllSubmissionsSchema)
+	if err != nil {
+		return err
 	}
 
-	// List all submissions across all owners (instructor-scoped: no user/group filter).
-	submissions, err := h.submissionService.ListAllSubmissionsForAssignment(assignmentID)
+	// List submissions across all owners (instructor-scoped: no user/group scope).
+	page, err := h.submissionService.ListAllSubmissionsForAssignment(assignmentID, spec)
 	if err != nil {
 		return err
 	}
 
-	responses := make([]dto.SubmissionResponse, len(submissions))
-	for i := range submissions {
-		responses[i] = toInstructorSubmissionResponse(&submissions[i])
+	responses := make([]dto.SubmissionResponse, len(page.Items))
+	for i := range page.Items {
+		responses[i] = toInstructorSubmissionResponse(&page.Items[i])
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.ListSubmissionsResponse{
+	return c.Status(fiber.StatusOK).JSON(dto.SubmissionPageResponse{
 		Submissions: responses,
-		Count:       len(responses),
+		Meta:        page.Meta(),
 	})
 }
 
@@ -241,6 +270,12 @@ func toInstructorSubmissionResponse(s *domain.Submission) dto.SubmissionResponse
 	if s.GroupID != nil {
 		resp.GroupID = s.GroupID
 	}
+	if s.GradedAt != nil {
+		resp.Score = s.Score
+		resp.Feedback = s.Feedback
+		resp.GradedBy = s.GradedBy
+		resp.GradedAt = s.GradedAt
+	}
 	return resp
 }
 
@@ -258,13 +293,9 @@ func (h *InstructorHandler) GetAssignmentRubric(c fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	courseIDs, err := h.instructorCourseIDs(c)
-	if err != nil {
+	if err := h.requireCourseAccess(c, assignment.CourseInstanceID, true); err != nil {
 		return err
 	}
-	if !courseIDs[assignment.CourseInstanceID] {
-		return utils.ErrForbidden("you are not assigned to this course instance")
-	}
 
 	criteria, err := h.assignmentService.GetAssignmentRubric(assignmentID)
 	if err != nil {
@@ -297,7 +328,8 @@ func (h *InstructorHandler) GetAssignmentRubric(c fiber.Ctx) error {
 // ─────────────────────────────────────────────────────────────────────────────
 
 // UpdateAssignmentRubric replaces all rubric criteria for an assignment.
-// Send an empty criteria array to clear the rubric entirely.
+// Send an empty criteria array to clear the rubric entirely. Teaching
+// assistants are rejected.
 func (h *InstructorHandler) UpdateAssignmentRubric(c fiber.Ctx) error {
 	assignmentID, err := parseUUID(c, "id")
 	if err != nil {
@@ -308,13 +340,9 @@ func (h *InstructorHandler) UpdateAssignmentRubric(c fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	courseIDs, err := h.instructorCourseIDs(c)
-	if err != nil {
+	if err := h.requireCourseAccess(c, assignment.CourseInstanceID, false); err != nil {
 		return err
 	}
-	if !courseIDs[assignment.CourseInstanceID] {
-		return utils.ErrForbidden("you are not assigned to this course instance")
-	}
 
 	var req dto.UpdateRubricRequest
 	if err := c.Bind().JSON(&req); err != nil {
@@ -361,13 +389,9 @@ func (h *InstructorHandler) GetAssignmentTestCases(c fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	courseIDs, err := h.instructorCourseIDs(c)
-	if err != nil {
+	if err := h.requireCourseAccess(c, assignment.CourseInstanceID, true); err != nil {
 		return err
 	}
-	if !courseIDs[assignment.CourseInstanceID] {
-		return utils.ErrForbidden("you are not assigned to this course instance")
-	}
 
 	testCases, err := h.assignmentService.GetAssignmentTestCases(assignmentID)
 	if err != nil {
@@ -405,13 +429,9 @@ func (h *InstructorHandler) GetAssignmentSampleAnswer(c fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	courseIDs, err := h.instructorCourseIDs(c)
-	if err != nil {
+	if err := h.requireCourseAccess(c, assignment.CourseInstanceID, true); err != nil {
 		return err
 	}
-	if !courseIDs[assignment.CourseInstanceID] {
-		return utils.ErrForbidden("you are not assigned to this course instance")
-	}
 
 	answer, err := h.assignmentService.GetAssignmentSampleAnswer(assignmentID)
 	if err != nil {
@@ -429,3 +449,213 @@ func (h *InstructorHandler) GetAssignmentSampleAnswer(c fiber.Ctx) error {
 		Code:         answer.Code,
 	})
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/instructor-assignments/:id/analytics
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetAssignmentAnalytics returns aggregate submission analytics for an
+// assignment: score distribution, test case pass rates, submission times
+// around the due date, attempts per student and students yet to submit.
+func (h *InstructorHandler) GetAssignmentAnalytics(c fiber.Ctx) error {
+	assignmentID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	assignment, err := h.assignmentService.GetAssignmentByID(assignmentID)
+	if err != nil {
+		return err
+	}
+	if assignment == nil {
+		return utils.ErrNotFound("assignment not found")
+	}
+	if err := h.requireCourseAccess(c, assignment.CourseInstanceID, true); err != nil {
+		return err
+	}
+
+	analytics, err := h.analyticsService.AssignmentAnalytics(assignment)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(analytics)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/instructor-assignments/course-instance/:id/analytics
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetCourseInstanceAnalytics summarises the submissions to every active
+// assignment of a course instance.
+func (h *InstructorHandler) GetCourseInstanceAnalytics(c fiber.Ctx) error {
+	courseInstanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+	if err := h.requireCourseAccess(c, courseInstanceID, true); err != nil {
+		return err
+	}
+
+	analytics, err := h.analyticsService.CourseInstanceAnalytics(courseInstanceID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(analytics)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PUT /api/v1/instructor-submissions/:id/grade
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GradeSubmission records a score and feedback on a submission. Course staff,
+// including teaching assistants, may grade.
+func (h *InstructorHandler) GradeSubmission(c fiber.Ctx) error {
+	submissionID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.GradeSubmissionRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	submission, err := h.submissionService.GetSubmission(submissionID)
+	if err != nil {
+		return err
+	}
+	if err := h.requireAssignmentStaff(c, submission.AssignmentID); err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	graded, err := h.gradingService.GradeSubmission(
+		submissionID,
+		&req,
+		requireUserID(c),
+		username,
+		c.IP(),
+		c.Get("User-Agent"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toInstructorSubmissionResponse(graded))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PATCH /api/v1/instructor-submissions/:id/analysis
+// ─────────────────────────────────────────────────────────────────────────────
+
+// UpdateSubmissionAnalysis stores the CIPAS AI detection and semantic
+// similarity scores the instructor UI computed for a submission. Course staff,
+// including teaching assistants, may store them.
+func (h *InstructorHandler) UpdateSubmissionAnalysis(c fiber.Ctx) error {
+	submissionID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateAnalysisRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	submission, err := h.submissionService.GetSubmission(submissionID)
+	if err != nil {
+		return err
+	}
+	if err := h.requireAssignmentStaff(c, submission.AssignmentID); err != nil {
+		return err
+	}
+
+	if err := h.submissionService.UpdateAnalysis(submissionID, &req); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/instructor-submissions/assignment/:id/regrade-requests
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListRegradeRequests lists the regrade requests for an assignment.
+// Optionally filter by ?status=open|accepted|rejected.
+func (h *InstructorHandler) ListRegradeRequests(c fiber.Ctx) error {
+	assignmentID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.requireAssignmentStaff(c, assignmentID); err != nil {
+		return err
+	}
+
+	requests, err := h.gradingService.ListRegradeRequests(assignmentID, c.Query("status"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toListRegradeRequestsResponse(requests))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PATCH /api/v1/instructor-submissions/regrade-requests/:id
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RespondToRegrade accepts or rejects an open regrade request. Accepting with
+// a new_score updates the submission's grade.
+func (h *InstructorHandler) RespondToRegrade(c fiber.Ctx) error {
+	regradeID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.RespondRegradeRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	regrade, err := h.gradingService.GetRegradeRequest(regradeID)
+	if err != nil {
+		return err
+	}
+	if err := h.requireAssignmentStaff(c, regrade.AssignmentID); err != nil {
+		return err
+	}
+
+	username := requireUsername(c)
+	if username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	answered, err := h.gradingService.RespondToRegrade(
+		regradeID,
+		&req,
+		requireUserID(c),
+		username,
+		c.IP(),
+		c.Get("User-Agent"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toRegradeRequestResponse(answered))
+}
+
+// requireAssignmentStaff loads the assignment and verifies the caller is
+// course staff (instructor or TA) on its course instance.
+func (h *InstructorHandler) requireAssignmentStaff(c fiber.Ctx, assignmentID uuid.UUID) error {
+	assignment, err := h.assignmentService.GetAssignmentByID(assignmentID)
+	if err != nil {
+		return err
+	}
+	return h.requireCourseAccess(c, assignment.CourseInstanceID, true)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/service"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// ScoreHandler handles internal requests for the effective assignment scores
+// of a course instance.
+type ScoreHandler struct {
+	scoreService service.ScoreService
+	logger       *zap.Logger
+}
+
+// NewScoreHandler creates a new ScoreHandler.
+func NewScoreHandler(scoreService service.ScoreService, logger *zap.Logger) *ScoreHandler {
+	return &ScoreHandler{
+		scoreService: scoreService,
+		logger:       logger,
+	}
+}
+
+// ListCourseInstanceScores handles GET /api/v1/internal/course-instances/:id/scores.
+// The Academic Service calls it to compute final grades; callers need the
+// assessment.scores:read scope.
+func (h *ScoreHandler) ListCourseInstanceScores(c fiber.Ctx) error {
+	courseInstanceID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.scoreService.ListCourseInstanceScores(courseInstanceID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -3,7 +3,7 @@ package handler
 import (
 	"strings"
 
-	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
@@ -17,7 +17,8 @@ import (
 type StudentHandler struct {
 	assignmentService service.AssignmentService
 	submissionService service.SubmissionService
-	academicClient    *client.AcademicClient
+	gradingService    service.GradingService
+	courseAccess      service.CourseAccessService
 	logger            *zap.Logger
 }
 
@@ -25,13 +26,15 @@ type StudentHandler struct {
 func NewStudentHandler(
 	assignmentService service.AssignmentService,
 	submissionService service.SubmissionService,
-	academicClient *client.AcademicClient,
+	gradingService service.GradingService,
+	courseAccess service.CourseAccessService,
 	logger *zap.Logger,
 ) *StudentHandler {
 	return &StudentHandler{
 		assignmentService: assignmentService,
 		submissionService: submissionService,
-		academicClient:    academicClient,
+		gradingService:    gradingService,
+		courseAccess:      courseAccess,
 		logger:            logger,
 	}
 }
@@ -40,8 +43,9 @@ func NewStudentHandler(
 // Helpers
 // ─────────────────────────────────────────────────────────────────────────────
 
-// studentEnrolledCourseIDs calls the Academic Service with the student's token
-// and returns a set of course_instance_id UUIDs the student is enrolled in.
+// studentEnrolledCourseIDs returns the set of course_instance_id UUIDs the
+// student is enrolled in, from the course access read-model or, failing that,
+// the Academic Service with the student's token.
 func (h *StudentHandler) studentEnrolledCourseIDs(c fiber.Ctx) (map[uuid.UUID]bool, error) {
 	auth := c.Get("Authorization")
 	parts := strings.SplitN(auth, " ", 2)
@@ -49,11 +53,12 @@ func (h *StudentHandler) studentEnrolledCourseIDs(c fiber.Ctx) (map[uuid.UUID]bo
 	if len(parts) == 2 && parts[0] == "Bearer" {
 		token = parts[1]
 	}
-	if token == "" {
+	userID := requireUserID(c)
+	if token == "" || userID == uuid.Nil {
 		return nil, utils.ErrUnauthorized("user not authenticated")
 	}
 
-	courses, err := h.academicClient.GetStudentCourses(token)
+	courses, err := h.courseAccess.StudentCourses(userID, token)
 	if err != nil {
 		h.logger.Error("failed to fetch student enrolled courses", zap.Error(err))
 		return nil, utils.ErrInternal("failed to verify student enrollment", err)
@@ -70,12 +75,61 @@ func (h *StudentHandler) studentEnrolledCourseIDs(c fiber.Ctx) (map[uuid.UUID]bo
 	return ids, nil
 }
 
+// studentSectionIDs returns the student's sections in the course instance
+// when any of the assignments targets sections. ok is false when the lookup
+// failed; section targeting is then not applied, so that an Academic Service
+// outage shows students too much rather than hiding their work.
+func (h *StudentHandler) studentSectionIDs(userID, courseInstanceID uuid.UUID, assignments ...*domain.Assignment) (ids []uuid.UUID, ok bool) {
+	targeted := false
+	for _, a := range assignments {
+		if len(a.Sections) > 0 {
+			targeted = true
+			break
+		}
+	}
+	if !targeted {
+		return nil, true
+	}
+
+	ids, err := h.courseAccess.StudentSections(userID, courseInstanceID)
+	if err != nil {
+		h.logger.Warn("section lookup failed; showing assignments without section targeting",
+			zap.String("user_id", userID.String()),
+			zap.String("course_instance_id", courseInstanceID.String()),
+			zap.Error(err),
+		)
+		return nil, false
+	}
+	return ids, true
+}
+
+// toStudentAssignmentResponse converts an assignment for a student in the
+// given sections, with their section's due dates. It reports false when the
+// assignment is not set for those sections. The section targets themselves
+// are not shown to students.
+func toStudentAssignmentResponse(a *domain.Assignment, sectionIDs []uuid.UUID, sectionsKnown bool) (dto.AssignmentResponse, bool) {
+	resp := toAssignmentResponse(a)
+	resp.Sections = nil
+	if !sectionsKnown {
+		return resp, true
+	}
+
+	targeted, dueAt, lateDueAt := a.TargetFor(sectionIDs)
+	if !targeted {
+		return resp, false
+	}
+	resp.DueAt = dueAt
+	resp.LateDueAt = lateDueAt
+	return resp, true
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // GET /api/v1/student-assignments?course_instance_id=:id
 // ─────────────────────────────────────────────────────────────────────────────
 
-// ListMyAssignments lists all active assignments for a course instance the
-// authenticated student is enrolled in.
+// ListMyAssignments lists the active assignments for a course instance the
+// authenticated student is enrolled in, leaving out those targeted at other
+// sections.
 func (h *StudentHandler) ListMyAssignments(c fiber.Ctx) error {
 	rawCourseInstanceID := c.Query("course_instance_id")
 	if rawCourseInstanceID == "" {
@@ -101,9 +155,17 @@ func (h *StudentHandler) ListMyAssignments(c fiber.Ctx) error {
 		return err
 	}
 
-	responses := make([]dto.AssignmentResponse, len(assignments))
+	ptrs := make([]*domain.Assignment, len(assignments))
 	for i := range assignments {
-		responses[i] = toAssignmentResponse(&assignments[i])
+		ptrs[i] = &assignments[i]
+	}
+	sectionIDs, sectionsKnown := h.studentSectionIDs(requireUserID(c), courseInstanceID, ptrs...)
+
+	responses := make([]dto.AssignmentResponse, 0, len(assignments))
+	for _, a := range ptrs {
+		if resp, ok := toStudentAssignmentResponse(a, sectionIDs, sectionsKnown); ok {
+			responses = append(responses, resp)
+		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.ListAssignmentsResponse{
@@ -116,8 +178,10 @@ func (h *StudentHandler) ListMyAssignments(c fiber.Ctx) error {
 // GET /api/v1/student-assignments/:id
 // ─────────────────────────────────────────────────────────────────────────────
 
-// GetAssignment returns a single assignment by ID.
-// The authenticated student must be enrolled in the assignment's course instance.
+// GetAssignment returns a single assignment by ID, with the due dates of the
+// student's section. The authenticated student must be enrolled in the
+// assignment's course instance and, for a section-targeted assignment, be in
+// one of its sections.
 func (h *StudentHandler) GetAssignment(c fiber.Ctx) error {
 	id, err := parseUUID(c, "id")
 	if err != nil {
@@ -141,7 +205,13 @@ func (h *StudentHandler) GetAssignment(c fiber.Ctx) error {
 		return utils.ErrForbidden("you are not enrolled in this course instance")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(toAssignmentResponse(assignment))
+	sectionIDs, sectionsKnown := h.studentSectionIDs(requireUserID(c), assignment.CourseInstanceID, assignment)
+	resp, ok := toStudentAssignmentResponse(assignment, sectionIDs, sectionsKnown)
+	if !ok {
+		return utils.ErrForbidden("this assignment is not set for your section")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -258,3 +328,65 @@ func (h *StudentHandler) GetMyLatestSubmission(c fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(toSubmissionResponse(submission))
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// POST /api/v1/student-submissions/:id/regrade-requests
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RequestRegrade opens a regrade request on one of the student's graded
+// submissions. Only one request per submission may be open at a time.
+func (h *StudentHandler) RequestRegrade(c fiber.Ctx) error {
+	submissionID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateRegradeRequest
+	if err := c.Bind().JSON(&req); err != nil {
+		return utils.ErrBadRequest("invalid request body")
+	}
+
+	userID := requireUserID(c)
+	username := requireUsername(c)
+	if userID == uuid.Nil || username == "" {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	regrade, err := h.gradingService.RequestRegrade(
+		submissionID,
+		&req,
+		userID,
+		username,
+		c.IP(),
+		c.Get("User-Agent"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toRegradeRequestResponse(regrade))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GET /api/v1/student-submissions/assignment/:id/regrade-requests
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListMyRegradeRequests lists the student's regrade requests for an assignment.
+func (h *StudentHandler) ListMyRegradeRequests(c fiber.Ctx) error {
+	assignmentID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	userID := requireUserID(c)
+	if userID == uuid.Nil {
+		return utils.ErrUnauthorized("user not authenticated")
+	}
+
+	requests, err := h.gradingService.ListMyRegradeRequests(assignmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toListRegradeRequestsResponse(requests))
+}
@@ -219,31 +219,6 @@ func (h *SubmissionHandler) RunCode(c fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(result)
 }
 
-// ─────────────────────────────────────────────────────────────────────────────
-// PATCH /submissions/:id/analysis
-// ─────────────────────────────────────────────────────────────────────────────
-
-// PatchAnalysis handles PATCH /submissions/:id/analysis.
-// Stores CIPAS AI detection and semantic similarity scores for a submission.
-// This is a fire-and-forget call from the frontend after code submission.
-func (h *SubmissionHandler) PatchAnalysis(c fiber.Ctx) error {
-	id, err := parseUUID(c, "id")
-	if err != nil {
-		return err
-	}
-
-	var req dto.UpdateAnalysisRequest
-	if err := c.Bind().JSON(&req); err != nil {
-		return utils.ErrBadRequest("invalid request body")
-	}
-
-	if err := h.submissionService.UpdateAnalysis(id, &req); err != nil {
-		return err
-	}
-
-	return c.Status(fiber.StatusNoContent).Send(nil)
-}
-
 // ─────────────────────────────────────────────────────────────────────────────
 // POST /submissions/batch/code
 // ─────────────────────────────────────────────────────────────────────────────
@@ -376,5 +351,13 @@ func toSubmissionResponse(s *domain.Submission) dto.SubmissionResponse {
 		response.AnalyzedAt = s.AnalyzedAt
 	}
 
+	// Add manual grade if present
+	if s.GradedAt != nil {
+		response.Score = s.Score
+		response.Feedback = s.Feedback
+		response.GradedBy = s.GradedBy
+		response.GradedAt = s.GradedAt
+	}
+
 	return response
 }
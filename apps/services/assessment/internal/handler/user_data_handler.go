@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// UserDataHandler handles internal requests about a user's assessment data.
+type UserDataHandler struct {
+	userDataService service.UserDataService
+	logger          *zap.Logger
+}
+
+// NewUserDataHandler creates a new UserDataHandler.
+func NewUserDataHandler(userDataService service.UserDataService, logger *zap.Logger) *UserDataHandler {
+	return &UserDataHandler{
+		userDataService: userDataService,
+		logger:          logger,
+	}
+}
+
+// ExportUserData handles GET /api/v1/internal/users/:id/data. IAM calls it to
+// build a personal data export and receives a zip archive; callers need the
+// assessment.user_data:read scope.
+func (h *UserDataHandler) ExportUserData(c fiber.Ctx) error {
+	userID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	clientID, _ := c.Locals(servicetoken.LocalsClientID).(string)
+
+	archive, err := h.userDataService.ExportUserData(c.RequestCtx(), userID, clientID, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	return c.Status(fiber.StatusOK).Send(archive)
+}
+
+// EraseUserData handles DELETE /api/v1/internal/users/:id. IAM calls it
+// before purging an account and for erasure requests, passing
+// retain_grades=true when grade records must be kept. Callers need the
+// assessment.user_data:erase scope.
+func (h *UserDataHandler) EraseUserData(c fiber.Ctx) error {
+	userID, err := parseUUID(c, "id")
+	if err != nil {
+		return err
+	}
+
+	retainGrades := fiber.Query[bool](c, "retain_grades")
+	clientID, _ := c.Locals(servicetoken.LocalsClientID).(string)
+
+	erasure, err := h.userDataService.EraseUserData(c.RequestCtx(), userID, retainGrades, clientID, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user_id":       userID,
+		"retain_grades": retainGrades,
+		"erased":        erasure,
+	})
+}
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
 	"github.com/gofiber/fiber/v3"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -13,12 +16,16 @@ type Claims struct {
 	UserID   string `json:"user_id"`
 	Email    string `json:"email"`
 	UserType string `json:"user_type"` // student, instructor, admin
+	// Global roles and permissions granted by IAM.
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // AuthMiddleware validates the Bearer JWT in the Authorization header and
-// populates fiber.Ctx locals with user_id, username, user_type.
-func AuthMiddleware(secretKey []byte) fiber.Handler {
+// populates fiber.Ctx locals with user_id, username, user_type, roles and
+// permissions.
+func AuthMiddleware(secretKey []byte, tokens pat.Verifier) fiber.Handler {
 	return func(c fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -32,6 +39,27 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 
 		tokenString := parts[1]
 
+		// Personal access tokens are opaque; IAM resolves them to a user.
+		if pat.IsToken(tokenString) {
+			identity, err := tokens.Verify(c.RequestCtx(), tokenString)
+			if err != nil {
+				if errors.Is(err, pat.ErrInvalidToken) {
+					return utils.ErrUnauthorized("invalid token")
+				}
+				return utils.NewAppError(fiber.StatusServiceUnavailable, "token verification unavailable", err)
+			}
+
+			c.Locals("user_id", identity.UserID)
+			c.Locals("username", identity.Email)
+			c.Locals("user_type", identity.UserType)
+			c.Locals(authz.LocalsRoles, identity.Roles)
+			c.Locals(authz.LocalsPermissions, identity.Permissions)
+			c.Locals(pat.LocalsTokenID, identity.TokenID)
+			c.Locals(pat.LocalsTokenScopes, identity.Scopes)
+
+			return c.Next()
+		}
+
 		token, err := jwt.ParseWithClaims(
 			tokenString,
 			&Claims{},
@@ -51,9 +79,16 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 			return utils.ErrUnauthorized("invalid token claims")
 		}
 
+		// Service tokens share the signing key but carry no user.
+		if claims.UserID == "" {
+			return utils.ErrUnauthorized("invalid token claims")
+		}
+
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Email)
 		c.Locals("user_type", claims.UserType)
+		c.Locals(authz.LocalsRoles, claims.Roles)
+		c.Locals(authz.LocalsPermissions, claims.Permissions)
 
 		return c.Next()
 	}
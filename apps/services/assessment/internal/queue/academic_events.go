@@ -0,0 +1,229 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+const (
+	// AcademicEventsExchange is the durable topic exchange the Academic
+	// Service publishes its domain events to.
+	AcademicEventsExchange = "academic.events"
+
+	// AcademicEventsQueue is this service's durable queue on the academic
+	// events exchange.
+	AcademicEventsQueue = "assessment.academic-events"
+
+	// academicEventsPrefetch bounds unacknowledged academic events in flight.
+	academicEventsPrefetch = 32
+)
+
+// academicEventBindings are the routing keys the assessment service needs to
+// keep its course access read-model current.
+var academicEventBindings = []string{"enrollment.*", "instructor.*", "section_member.*"}
+
+// AcademicEvent is the message body of an Academic Service domain event.
+// Delivery is at-least-once and ordered per aggregate; Sequence orders
+// events globally.
+type AcademicEvent struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	EventType     string          `json:"event_type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+	Replayed      bool            `json:"replayed,omitempty"`
+}
+
+// AcademicEventHandler is called for every academic event dequeued. A nil
+// return ACKs the message; an error NACKs it, requeueing it once.
+type AcademicEventHandler func(ctx context.Context, event AcademicEvent) error
+
+// ─────────────────────────────────────────────────────────────────────────────
+// AcademicEventConsumer
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AcademicEventConsumer reads academic domain events from AcademicEventsQueue
+// and hands them to an AcademicEventHandler one at a time, in queue order, so
+// that per-aggregate ordering is preserved.
+//
+// An event that fails twice is dropped rather than dead-lettered: the course
+// access read-model is periodically reconciled against the Academic Service,
+// which repairs anything a dropped event would have changed.
+type AcademicEventConsumer struct {
+	rmq     *RabbitMQ
+	handler AcademicEventHandler
+	logger  *zap.Logger
+}
+
+// NewAcademicEventConsumer creates an AcademicEventConsumer.
+func NewAcademicEventConsumer(rmq *RabbitMQ, handler AcademicEventHandler, logger *zap.Logger) *AcademicEventConsumer {
+	return &AcademicEventConsumer{
+		rmq:     rmq,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start consumes academic events until ctx is cancelled, re-subscribing
+// after channel failures.
+//
+//	go consumer.Start(ctx)
+func (c *AcademicEventConsumer) Start(ctx context.Context) {
+	c.logger.Info("academic event consumer starting", zap.String("queue", AcademicEventsQueue))
+
+	for {
+		err := c.consume(ctx)
+		if err == nil || ctx.Err() != nil {
+			c.logger.Info("academic event consumer stopped")
+			return
+		}
+
+		c.logger.Warn("academic event consumer channel error; will retry",
+			zap.Error(err),
+			zap.Duration("retry_after", reconnectDelay),
+		)
+
+		select {
+		case <-ctx.Done():
+			c.logger.Info("academic event consumer stopped")
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// consume subscribes on a fresh channel and processes deliveries until ctx is
+// cancelled (nil) or the channel is lost (error).
+func (c *AcademicEventConsumer) consume(ctx context.Context) error {
+	c.rmq.mu.RLock()
+	conn := c.rmq.conn
+	c.rmq.mu.RUnlock()
+
+	if conn == nil || conn.IsClosed() {
+		return fmt.Errorf("rabbitmq connection is not available")
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("opening channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.Qos(academicEventsPrefetch, 0, false); err != nil {
+		return fmt.Errorf("setting QoS prefetch=%d: %w", academicEventsPrefetch, err)
+	}
+
+	deliveries, err := ch.Consume(
+		AcademicEventsQueue, // queue
+		"",                  // consumer tag — broker generates a unique one
+		false,               // auto-ack disabled; we ack/nack manually
+		false,               // exclusive
+		false,               // no-local (not supported by RabbitMQ)
+		false,               // no-wait
+		nil,                 // args
+	)
+	if err != nil {
+		return fmt.Errorf("registering consumer on %q: %w", AcademicEventsQueue, err)
+	}
+
+	chanClose := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case amqpErr, ok := <-chanClose:
+			if !ok || amqpErr == nil {
+				return fmt.Errorf("amqp channel closed")
+			}
+			return fmt.Errorf("amqp channel closed by broker: code=%d reason=%s",
+				amqpErr.Code, amqpErr.Reason)
+
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("deliveries channel closed")
+			}
+			c.handleDelivery(ctx, delivery)
+		}
+	}
+}
+
+// handleDelivery decodes one event and calls the handler.
+func (c *AcademicEventConsumer) handleDelivery(ctx context.Context, d amqp.Delivery) {
+	logger := c.logger.With(
+		zap.String("message_id", d.MessageId),
+		zap.String("routing_key", d.RoutingKey),
+	)
+
+	var event AcademicEvent
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		logger.Error("failed to unmarshal academic event; dropping", zap.Error(err))
+		_ = d.Nack(false, false)
+		return
+	}
+
+	if err := c.handler(ctx, event); err != nil {
+		if d.Redelivered {
+			logger.Error("academic event failed on retry; dropping until next reconciliation",
+				zap.Int64("sequence", event.Sequence),
+				zap.Error(err),
+			)
+			_ = d.Nack(false, false)
+		} else {
+			logger.Warn("academic event failed; requeueing for retry",
+				zap.Int64("sequence", event.Sequence),
+				zap.Error(err),
+			)
+			_ = d.Nack(false, true)
+		}
+		return
+	}
+
+	if err := d.Ack(false); err != nil {
+		logger.Error("failed to ack academic event", zap.Error(err))
+	}
+}
+
+// declareAcademicEventsTopology declares the academic events exchange — with
+// the same arguments the Academic Service uses — and binds this service's
+// queue to the routing keys it consumes.
+func declareAcademicEventsTopology(ch *amqp.Channel) error {
+	if err := ch.ExchangeDeclare(
+		AcademicEventsExchange, // name
+		"topic",                // kind
+		true,                   // durable
+		false,                  // auto-delete
+		false,                  // internal
+		false,                  // no-wait
+		nil,                    // args
+	); err != nil {
+		return fmt.Errorf("rabbitmq: declaring exchange %q: %w", AcademicEventsExchange, err)
+	}
+
+	if _, err := ch.QueueDeclare(
+		AcademicEventsQueue, // name
+		true,                // durable
+		false,               // auto-delete
+		false,               // exclusive
+		false,               // no-wait
+		nil,                 // args
+	); err != nil {
+		return fmt.Errorf("rabbitmq: declaring queue %q: %w", AcademicEventsQueue, err)
+	}
+
+	for _, key := range academicEventBindings {
+		if err := ch.QueueBind(AcademicEventsQueue, key, AcademicEventsExchange, false, nil); err != nil {
+			return fmt.Errorf("rabbitmq: binding queue %q to %q: %w", AcademicEventsQueue, key, err)
+		}
+	}
+	return nil
+}
@@ -277,6 +277,10 @@ func (r *RabbitMQ) declareTopology() error {
 		return fmt.Errorf("rabbitmq: binding queue %q: %w", SubmissionQueue, err)
 	}
 
+	if err := declareAcademicEventsTopology(ch); err != nil {
+		return err
+	}
+
 	r.logger.Info("rabbitmq: topology declared",
 		zap.String("exchange", SubmissionExchange),
 		zap.String("queue", SubmissionQueue),
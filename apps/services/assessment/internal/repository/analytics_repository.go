@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// analyticsViews lists the submission analytics materialised views in the
+// order they are refreshed.
+var analyticsViews = []string{
+	domain.SubmissionOwnerStats{}.TableName(),
+	domain.TestCaseStats{}.TableName(),
+	domain.SubmissionTimingBucket{}.TableName(),
+}
+
+// assignmentAudience joins assignments (as a) to the enrolled students they
+// are set for (as e): every enrolled student of the course instance when the
+// assignment targets no sections, otherwise the members of those sections.
+const assignmentAudience = `JOIN enrollment_projections e
+	ON e.course_instance_id = a.course_instance_id
+	AND e.status = '` + domain.EnrollmentStatusEnrolled + `'
+	AND NOT e.removed
+	AND (
+		NOT EXISTS (SELECT 1 FROM assignment_sections t WHERE t.assignment_id = a.id)
+		OR EXISTS (
+			SELECT 1 FROM assignment_sections t
+			JOIN section_member_projections m ON m.section_id = t.section_id AND NOT m.removed
+			WHERE t.assignment_id = a.id AND m.user_id = e.user_id
+		)
+	)`
+
+// audienceSubmitted holds when the student e has submitted assignment a,
+// either on their own or as a member of a group.
+const audienceSubmitted = `(
+	EXISTS (
+		SELECT 1 FROM assignment_owner_stats o
+		WHERE o.assignment_id = a.id AND NOT o.is_group AND o.owner_id = e.user_id
+	)
+	OR EXISTS (
+		SELECT 1 FROM assignment_owner_stats o
+		JOIN groups g ON g.id = o.owner_id
+		WHERE o.assignment_id = a.id AND o.is_group
+			AND g.members @> jsonb_build_array(e.user_id::text)
+	)
+)`
+
+// ScoreBucket counts the scores in one bucket of a score histogram. Buckets
+// are numbered from 1.
+type ScoreBucket struct {
+	Bucket int
+	Count  int64
+}
+
+// AnalyticsRepository reads the submission analytics materialised views,
+// which the migrator creates. The views lag the submissions table until the
+// next Refresh.
+type AnalyticsRepository interface {
+	// Refresh recomputes every analytics view and records the refresh on the
+	// projection state. Reads are not blocked while it runs.
+	Refresh() error
+
+	// GetState returns the projection state, whose ReconciledAt is the time
+	// of the last refresh. A zero state is returned before the first refresh.
+	GetState() (*domain.ProjectionState, error)
+
+	// SummarizeAssignments aggregates the views per assignment. Assignments
+	// without submissions have no entry.
+	SummarizeAssignments(assignmentIDs []uuid.UUID) ([]domain.AssignmentStats, error)
+
+	// ScoreHistogram counts the graded scores of an assignment in n equal
+	// buckets spanning [lo, hi]; hi must be greater than lo. Empty buckets
+	// are omitted.
+	ScoreHistogram(assignmentID uuid.UUID, lo, hi float64, n int) ([]ScoreBucket, error)
+
+	// ListOwnerStats returns the students and groups that submitted an
+	// assignment, most attempts first.
+	ListOwnerStats(assignmentID uuid.UUID) ([]domain.SubmissionOwnerStats, error)
+
+	// ListTestCaseStats returns the test case outcomes of an assignment, most
+	// failed first.
+	ListTestCaseStats(assignmentID uuid.UUID) ([]domain.TestCaseStats, error)
+
+	// ListSubmissionTiming returns the submissions of an assignment per hour
+	// relative to the due date that applied to the submitter, earliest first.
+	ListSubmissionTiming(assignmentID uuid.UUID) ([]domain.SubmissionTimingBucket, error)
+
+	// CountAudiences counts the students each assignment is set for and
+	// those of them without a submission. Assignments nobody is set for have
+	// no entry.
+	CountAudiences(assignmentIDs []uuid.UUID) ([]domain.AssignmentAudience, error)
+
+	// ListUnsubmitted returns the students the assignment is set for with no
+	// submission to it, either their own or one of a group they belong to.
+	ListUnsubmitted(assignmentID uuid.UUID) ([]uuid.UUID, error)
+
+	// CountEnrolled returns the number of students enrolled in the course
+	// instance according to the course access read-model.
+	CountEnrolled(courseInstanceID uuid.UUID) (int64, error)
+}
+
+// analyticsRepository is the concrete GORM-backed implementation.
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new analyticsRepository.
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+func (r *analyticsRepository) Refresh() error {
+	for _, view := range analyticsViews {
+		if err := r.db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + view).Error; err != nil {
+			return fmt.Errorf("refresh %s: %w", view, err)
+		}
+	}
+
+	now := time.Now().UTC()
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "reconciled_at"}, Value: now},
+			{Column: clause.Column{Name: "updated_at"}, Value: now},
+		},
+	}).Create(&domain.ProjectionState{
+		Name:         domain.SubmissionAnalyticsProjection,
+		ReconciledAt: &now,
+	}).Error
+}
+
+func (r *analyticsRepository) GetState() (*domain.ProjectionState, error) {
+	var state domain.ProjectionState
+	err := r.db.Where("name = ?", domain.SubmissionAnalyticsProjection).First(&state).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &domain.ProjectionState{Name: domain.SubmissionAnalyticsProjection}, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *analyticsRepository) SummarizeAssignments(assignmentIDs []uuid.UUID) ([]domain.AssignmentStats, error) {
+	if len(assignmentIDs) == 0 {
+		return nil, nil
+	}
+	var stats []domain.AssignmentStats
+	err := r.db.Raw(`
+		SELECT
+			o.assignment_id,
+			count(*) AS owners,
+			count(o.score) AS graded,
+			min(o.score) AS min_score,
+			max(o.score) AS max_score,
+			avg(o.score) AS mean_score,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY o.score) AS median_score,
+			stddev_pop(o.score) AS std_dev_score,
+			avg(o.attempts)::float8 AS mean_attempts,
+			avg(o.avg_execution_ms) AS avg_execution_ms,
+			avg(o.avg_memory_kb) AS avg_memory_kb,
+			(SELECT COALESCE(sum(t.submissions), 0)::bigint
+				FROM assignment_submission_timing t
+				WHERE t.assignment_id = o.assignment_id AND t.hours_from_due >= 0
+			) AS late_submissions
+		FROM assignment_owner_stats o
+		WHERE o.assignment_id IN ?
+		GROUP BY o.assignment_id
+	`, assignmentIDs).Scan(&stats).Error
+	return stats, err
+}
+
+func (r *analyticsRepository) ScoreHistogram(assignmentID uuid.UUID, lo, hi float64, n int) ([]ScoreBucket, error) {
+	var buckets []ScoreBucket
+	// width_bucket puts hi itself in bucket n+1; fold it into the last one.
+	err := r.db.Raw(`
+		SELECT LEAST(width_bucket(score, ?, ?, ?), ?) AS bucket, count(*) AS count
+		FROM assignment_owner_stats
+		WHERE assignment_id = ? AND score IS NOT NULL
+		GROUP BY 1
+		ORDER BY 1
+	`, lo, hi, n, n, assignmentID).Scan(&buckets).Error
+	return buckets, err
+}
+
+func (r *analyticsRepository) ListOwnerStats(assignmentID uuid.UUID) ([]domain.SubmissionOwnerStats, error) {
+	var stats []domain.SubmissionOwnerStats
+	err := r.db.
+		Where("assignment_id = ?", assignmentID).
+		Order("attempts DESC, owner_id").
+		Find(&stats).Error
+	return stats, err
+}
+
+func (r *analyticsRepository) ListTestCaseStats(assignmentID uuid.UUID) ([]domain.TestCaseStats, error) {
+	var stats []domain.TestCaseStats
+	err := r.db.Raw(`
+		SELECT
+			s.assignment_id, s.test_case_id, s.runs, s.passed,
+			COALESCE(tc.description, '') AS description,
+			COALESCE(tc.is_hidden, false) AS is_hidden
+		FROM assignment_test_case_stats s
+		LEFT JOIN assignment_test_cases tc
+			ON tc.assignment_id = s.assignment_id AND tc.id::text = s.test_case_id
+		WHERE s.assignment_id = ?
+		ORDER BY s.runs - s.passed DESC, tc.order_index, s.test_case_id
+	`, assignmentID).Scan(&stats).Error
+	return stats, err
+}
+
+func (r *analyticsRepository) ListSubmissionTiming(assignmentID uuid.UUID) ([]domain.SubmissionTimingBucket, error) {
+	var buckets []domain.SubmissionTimingBucket
+	err := r.db.
+		Where("assignment_id = ?", assignmentID).
+		Order("hours_from_due").
+		Find(&buckets).Error
+	return buckets, err
+}
+
+func (r *analyticsRepository) CountAudiences(assignmentIDs []uuid.UUID) ([]domain.AssignmentAudience, error) {
+	if len(assignmentIDs) == 0 {
+		return nil, nil
+	}
+	var audiences []domain.AssignmentAudience
+	err := r.db.Raw(`
+		SELECT
+			a.id AS assignment_id,
+			count(*) AS students,
+			count(*) FILTER (WHERE NOT `+audienceSubmitted+`) AS not_submitted
+		FROM assignments a
+		`+assignmentAudience+`
+		WHERE a.id IN ?
+		GROUP BY a.id
+	`, assignmentIDs).Scan(&audiences).Error
+	return audiences, err
+}
+
+func (r *analyticsRepository) ListUnsubmitted(assignmentID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Raw(`
+		SELECT e.user_id
+		FROM assignments a
+		`+assignmentAudience+`
+		WHERE a.id = ? AND NOT `+audienceSubmitted+`
+		ORDER BY e.user_id
+	`, assignmentID).Scan(&userIDs).Error
+	return userIDs, err
+}
+
+func (r *analyticsRepository) CountEnrolled(courseInstanceID uuid.UUID) (int64, error) {
+	var n int64
+	err := r.db.Model(&domain.EnrollmentProjection{}).
+		Where("course_instance_id = ? AND status = ? AND NOT removed", courseInstanceID, domain.EnrollmentStatusEnrolled).
+		Count(&n).Error
+	return n, err
+}
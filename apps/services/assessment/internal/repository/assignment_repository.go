@@ -4,8 +4,10 @@ import (
 	"errors"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // AssignmentRepository defines all data operations for assignments.
@@ -14,6 +16,11 @@ type AssignmentRepository interface {
 	GetAssignmentByID(id uuid.UUID) (*domain.Assignment, error)
 	UpdateAssignment(assignment *domain.Assignment) error
 	ListAssignmentsByCourseInstance(courseInstanceID uuid.UUID) ([]domain.Assignment, error)
+	ListAssignmentsPage(courseInstanceID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Assignment], error)
+	// ReplaceSections replaces the assignment's section targets. An empty
+	// slice targets the assignment at every enrolled student again.
+	ReplaceSections(assignmentID uuid.UUID, sections []domain.AssignmentSection) error
+	CloneAssignment(assignment *domain.Assignment, criteria []domain.AssignmentRubricCriterion, testCases []domain.AssignmentTestCase, answer *domain.AssignmentSampleAnswer, codeConfig *domain.AssignmentCodeConfig) error
 }
 
 // assignmentRepository is the concrete GORM-backed implementation.
@@ -26,7 +33,8 @@ func NewAssignmentRepository(db *gorm.DB) AssignmentRepository {
 	return &assignmentRepository{db: db}
 }
 
-// CreateAssignment inserts a new assignment record into the database.
+// CreateAssignment inserts a new assignment record, with its section
+// targets, into the database.
 func (r *assignmentRepository) CreateAssignment(assignment *domain.Assignment) error {
 	return r.db.Create(assignment).Error
 }
@@ -38,6 +46,7 @@ func (r *assignmentRepository) GetAssignmentByID(id uuid.UUID) (*domain.Assignme
 	var assignment domain.Assignment
 
 	err := r.db.
+		Preload("Sections").
 		Where("id = ? AND is_active = true", id).
 		First(&assignment).Error
 
@@ -53,8 +62,9 @@ func (r *assignmentRepository) GetAssignmentByID(id uuid.UUID) (*domain.Assignme
 
 // UpdateAssignment persists changes to an existing assignment record.
 // It uses Save so that zero-value boolean fields are written correctly.
+// Section targets are left alone; see ReplaceSections.
 func (r *assignmentRepository) UpdateAssignment(assignment *domain.Assignment) error {
-	return r.db.Save(assignment).Error
+	return r.db.Omit(clause.Associations).Save(assignment).Error
 }
 
 // ListAssignmentsByCourseInstance returns all active assignments that belong to
@@ -63,6 +73,7 @@ func (r *assignmentRepository) ListAssignmentsByCourseInstance(courseInstanceID
 	var assignments []domain.Assignment
 
 	err := r.db.
+		Preload("Sections").
 		Where("course_instance_id = ? AND is_active = true", courseInstanceID).
 		Order("created_at ASC").
 		Find(&assignments).Error
@@ -73,3 +84,78 @@ func (r *assignmentRepository) ListAssignmentsByCourseInstance(courseInstanceID
 
 	return assignments, nil
 }
+
+// ListAssignmentsPage returns a page of the assignments
+// ListAssignmentsByCourseInstance returns.
+func (r *assignmentRepository) ListAssignmentsPage(courseInstanceID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Assignment], error) {
+	query := r.db.
+		Preload("Sections").
+		Where("course_instance_id = ? AND is_active = true", courseInstanceID)
+	return queryspec.Find[domain.Assignment](query, spec)
+}
+
+// ReplaceSections deletes the existing targets and inserts the new ones in
+// one transaction.
+func (r *assignmentRepository) ReplaceSections(assignmentID uuid.UUID, sections []domain.AssignmentSection) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("assignment_id = ?", assignmentID).Delete(&domain.AssignmentSection{}).Error; err != nil {
+			return err
+		}
+		if len(sections) == 0 {
+			return nil
+		}
+		for i := range sections {
+			sections[i].AssignmentID = assignmentID
+		}
+		return tx.Create(&sections).Error
+	})
+}
+
+// CloneAssignment inserts a copied assignment together with its rubric
+// criteria, test cases, sample answer and code config in one transaction.
+// The content rows are attached to the new assignment; nil or empty content
+// is skipped. Associations loaded on assignment, such as section targets,
+// are not inserted.
+func (r *assignmentRepository) CloneAssignment(
+	assignment *domain.Assignment,
+	criteria []domain.AssignmentRubricCriterion,
+	testCases []domain.AssignmentTestCase,
+	answer *domain.AssignmentSampleAnswer,
+	codeConfig *domain.AssignmentCodeConfig,
+) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(assignment).Error; err != nil {
+			return err
+		}
+
+		if len(criteria) > 0 {
+			for i := range criteria {
+				criteria[i].AssignmentID = assignment.ID
+			}
+			if err := tx.Create(&criteria).Error; err != nil {
+				return err
+			}
+		}
+		if len(testCases) > 0 {
+			for i := range testCases {
+				testCases[i].AssignmentID = assignment.ID
+			}
+			if err := tx.Create(&testCases).Error; err != nil {
+				return err
+			}
+		}
+		if answer != nil {
+			answer.AssignmentID = assignment.ID
+			if err := tx.Create(answer).Error; err != nil {
+				return err
+			}
+		}
+		if codeConfig != nil {
+			codeConfig.AssignmentID = assignment.ID
+			if err := tx.Create(codeConfig).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
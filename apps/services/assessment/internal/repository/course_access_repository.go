@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reconcileBatchSize bounds the rows written per statement during
+// reconciliation.
+const reconcileBatchSize = 500
+
+// Key columns of the read-model tables. Enrollments and instructor
+// assignments have one row per user and course instance; a student can be in
+// several sections of one course instance.
+var (
+	accessKeyColumns        = []string{"course_instance_id", "user_id"}
+	sectionMemberKeyColumns = []string{"course_instance_id", "user_id", "section_id"}
+)
+
+// CourseAccessRepository defines all data operations for the local
+// read-model of Academic Service enrollments, instructor assignments and
+// section memberships.
+//
+// Every row carries the academic event sequence it reflects, and writes only
+// replace rows holding an older sequence. Events can therefore be applied
+// more than once and in any order relative to a reconciliation.
+type CourseAccessRepository interface {
+	// ApplyEnrollment stores p unless the stored row reflects a later event,
+	// and records the event on the projection state. It reports whether the
+	// row was written.
+	ApplyEnrollment(p *domain.EnrollmentProjection) (bool, error)
+
+	// ApplyInstructor is ApplyEnrollment for instructor assignments.
+	ApplyInstructor(p *domain.InstructorProjection) (bool, error)
+
+	// ApplySectionMember is ApplyEnrollment for section memberships.
+	ApplySectionMember(p *domain.SectionMemberProjection) (bool, error)
+
+	// GetEnrollment loads a single enrollment, including a removed one.
+	// Returns (nil, nil) when the read-model has no row for it.
+	GetEnrollment(courseInstanceID, userID uuid.UUID) (*domain.EnrollmentProjection, error)
+
+	// ListEnrollmentsByUser returns the user's enrollments that have not been
+	// removed, in any status.
+	ListEnrollmentsByUser(userID uuid.UUID) ([]domain.EnrollmentProjection, error)
+
+	// ListInstructorsByUser returns the user's current instructor assignments.
+	ListInstructorsByUser(userID uuid.UUID) ([]domain.InstructorProjection, error)
+
+	// Reconcile brings the read-model in line with a full snapshot: snapshot
+	// rows are stored and rows missing from the snapshot are marked removed,
+	// except where an event newer than the snapshot has already been applied.
+	// Snapshot rows carry the sequence of their course instance in
+	// watermarks, which also bounds the removals; instances absent from it
+	// had no events. sequence advances the projection state.
+	Reconcile(
+		enrollments []domain.EnrollmentProjection,
+		instructors []domain.InstructorProjection,
+		members []domain.SectionMemberProjection,
+		watermarks map[uuid.UUID]int64,
+		sequence int64,
+	) error
+
+	// GetState returns the projection state. A zero state is returned before
+	// the first event or reconciliation.
+	GetState() (*domain.ProjectionState, error)
+
+	// Count returns the number of current enrollments and instructor
+	// assignments in the read-model.
+	Count() (enrollments, instructors int64, err error)
+}
+
+// courseAccessRepository is the concrete GORM-backed implementation.
+type courseAccessRepository struct {
+	db *gorm.DB
+}
+
+// NewCourseAccessRepository creates a new courseAccessRepository.
+func NewCourseAccessRepository(db *gorm.DB) CourseAccessRepository {
+	return &courseAccessRepository{db: db}
+}
+
+func (r *courseAccessRepository) ApplyEnrollment(p *domain.EnrollmentProjection) (bool, error) {
+	applied := false
+	err := WithTx(r.db, func(tx *gorm.DB) error {
+		res := tx.Clauses(newerThanStored("enrollment_projections", accessKeyColumns, "<", "status")).Create(p)
+		if res.Error != nil {
+			return res.Error
+		}
+		applied = res.RowsAffected > 0
+		return recordProjectionEvent(tx, p.Sequence)
+	})
+	return applied, err
+}
+
+func (r *courseAccessRepository) ApplyInstructor(p *domain.InstructorProjection) (bool, error) {
+	applied := false
+	err := WithTx(r.db, func(tx *gorm.DB) error {
+		res := tx.Clauses(newerThanStored("instructor_projections", accessKeyColumns, "<", "role")).Create(p)
+		if res.Error != nil {
+			return res.Error
+		}
+		applied = res.RowsAffected > 0
+		return recordProjectionEvent(tx, p.Sequence)
+	})
+	return applied, err
+}
+
+func (r *courseAccessRepository) ApplySectionMember(p *domain.SectionMemberProjection) (bool, error) {
+	applied := false
+	err := WithTx(r.db, func(tx *gorm.DB) error {
+		res := tx.Clauses(newerThanStored("section_member_projections", sectionMemberKeyColumns, "<")).Create(p)
+		if res.Error != nil {
+			return res.Error
+		}
+		applied = res.RowsAffected > 0
+		return recordProjectionEvent(tx, p.Sequence)
+	})
+	return applied, err
+}
+
+func (r *courseAccessRepository) GetEnrollment(courseInstanceID, userID uuid.UUID) (*domain.EnrollmentProjection, error) {
+	var p domain.EnrollmentProjection
+	err := r.db.
+		Where("course_instance_id = ? AND user_id = ?", courseInstanceID, userID).
+		First(&p).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *courseAccessRepository) ListEnrollmentsByUser(userID uuid.UUID) ([]domain.EnrollmentProjection, error) {
+	var rows []domain.EnrollmentProjection
+	err := r.db.
+		Where("user_id = ? AND removed = ?", userID, false).
+		Order("course_instance_id").
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *courseAccessRepository) ListInstructorsByUser(userID uuid.UUID) ([]domain.InstructorProjection, error) {
+	var rows []domain.InstructorProjection
+	err := r.db.
+		Where("user_id = ? AND removed = ?", userID, false).
+		Order("course_instance_id").
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *courseAccessRepository) Reconcile(
+	enrollments []domain.EnrollmentProjection,
+	instructors []domain.InstructorProjection,
+	members []domain.SectionMemberProjection,
+	watermarks map[uuid.UUID]int64,
+	sequence int64,
+) error {
+	// The snapshot already includes every event of a course instance up to
+	// its watermark, so it also replaces rows stamped with exactly that
+	// sequence. This picks up changes the Academic Service makes without an
+	// event, such as cascading deletes. The overall sequence is no bound: an
+	// event below it may commit after the snapshot was taken.
+	return WithTx(r.db, func(tx *gorm.DB) error {
+		if len(enrollments) > 0 {
+			if err := tx.Clauses(newerThanStored("enrollment_projections", accessKeyColumns, "<=", "status")).
+				CreateInBatches(&enrollments, reconcileBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(instructors) > 0 {
+			if err := tx.Clauses(newerThanStored("instructor_projections", accessKeyColumns, "<=", "role")).
+				CreateInBatches(&instructors, reconcileBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(members) > 0 {
+			if err := tx.Clauses(newerThanStored("section_member_projections", sectionMemberKeyColumns, "<=")).
+				CreateInBatches(&members, reconcileBatchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		present := make(map[[2]uuid.UUID]bool, len(enrollments))
+		for _, e := range enrollments {
+			present[[2]uuid.UUID{e.CourseInstanceID, e.UserID}] = true
+		}
+		if err := markMissingRemoved(tx, &domain.EnrollmentProjection{}, present, watermarks); err != nil {
+			return err
+		}
+
+		present = make(map[[2]uuid.UUID]bool, len(instructors))
+		for _, i := range instructors {
+			present[[2]uuid.UUID{i.CourseInstanceID, i.UserID}] = true
+		}
+		if err := markMissingRemoved(tx, &domain.InstructorProjection{}, present, watermarks); err != nil {
+			return err
+		}
+
+		presentMembers := make(map[[3]uuid.UUID]bool, len(members))
+		for _, m := range members {
+			presentMembers[[3]uuid.UUID{m.CourseInstanceID, m.UserID, m.SectionID}] = true
+		}
+		if err := markMissingMembersRemoved(tx, presentMembers, watermarks); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "last_sequence"}, Value: gorm.Expr("GREATEST(projection_states.last_sequence, excluded.last_sequence)")},
+				{Column: clause.Column{Name: "reconciled_at"}, Value: now},
+				{Column: clause.Column{Name: "updated_at"}, Value: now},
+			},
+		}).Create(&domain.ProjectionState{
+			Name:         domain.CourseAccessProjection,
+			LastSequence: sequence,
+			ReconciledAt: &now,
+		}).Error
+	})
+}
+
+func (r *courseAccessRepository) GetState() (*domain.ProjectionState, error) {
+	var state domain.ProjectionState
+	err := r.db.Where("name = ?", domain.CourseAccessProjection).First(&state).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &domain.ProjectionState{Name: domain.CourseAccessProjection}, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *courseAccessRepository) Count() (int64, int64, error) {
+	var enrollments, instructors int64
+	if err := r.db.Model(&domain.EnrollmentProjection{}).
+		Where("removed = ?", false).
+		Count(&enrollments).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := r.db.Model(&domain.InstructorProjection{}).
+		Where("removed = ?", false).
+		Count(&instructors).Error; err != nil {
+		return 0, 0, err
+	}
+	return enrollments, instructors, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Internal helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// newerThanStored upserts on the key columns, overwriting the stored row's
+// value columns only when its sequence compares to the new one with op ("<"
+// or "<=").
+func newerThanStored(table string, keyColumns []string, op string, valueColumns ...string) clause.OnConflict {
+	columns := make([]clause.Column, len(keyColumns))
+	for i, name := range keyColumns {
+		columns[i] = clause.Column{Name: name}
+	}
+	return clause.OnConflict{
+		Columns:   columns,
+		DoUpdates: clause.AssignmentColumns(append(valueColumns, "removed", "sequence", "updated_at")),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: table + ".sequence " + op + " excluded.sequence"},
+		}},
+	}
+}
+
+// recordProjectionEvent advances the projection state past an applied event.
+func recordProjectionEvent(tx *gorm.DB, sequence int64) error {
+	now := time.Now().UTC()
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "last_sequence"}, Value: gorm.Expr("GREATEST(projection_states.last_sequence, excluded.last_sequence)")},
+			{Column: clause.Column{Name: "last_event_at"}, Value: now},
+			{Column: clause.Column{Name: "updated_at"}, Value: now},
+		},
+	}).Create(&domain.ProjectionState{
+		Name:         domain.CourseAccessProjection,
+		LastSequence: sequence,
+		LastEventAt:  &now,
+	}).Error
+}
+
+// markMissingRemoved marks current rows of model that are absent from present
+// as removed at their course instance's watermark, skipping rows written by
+// an event after it.
+func markMissingRemoved(tx *gorm.DB, model interface{}, present map[[2]uuid.UUID]bool, watermarks map[uuid.UUID]int64) error {
+	var keys []struct {
+		CourseInstanceID uuid.UUID
+		UserID           uuid.UUID
+		Sequence         int64
+	}
+	if err := tx.Model(model).
+		Select("course_instance_id", "user_id", "sequence").
+		Where("removed = ?", false).
+		Find(&keys).Error; err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		sequence := watermarks[k.CourseInstanceID]
+		if k.Sequence > sequence || present[[2]uuid.UUID{k.CourseInstanceID, k.UserID}] {
+			continue
+		}
+		if err := tx.Model(model).
+			Where("course_instance_id = ? AND user_id = ? AND sequence <= ?", k.CourseInstanceID, k.UserID, sequence).
+			Updates(map[string]interface{}{
+				"removed":    true,
+				"sequence":   sequence,
+				"updated_at": time.Now().UTC(),
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markMissingMembersRemoved is markMissingRemoved for section memberships,
+// whose rows are also keyed by section.
+func markMissingMembersRemoved(tx *gorm.DB, present map[[3]uuid.UUID]bool, watermarks map[uuid.UUID]int64) error {
+	var keys []struct {
+		CourseInstanceID uuid.UUID
+		UserID           uuid.UUID
+		SectionID        uuid.UUID
+		Sequence         int64
+	}
+	if err := tx.Model(&domain.SectionMemberProjection{}).
+		Select("course_instance_id", "user_id", "section_id", "sequence").
+		Where("removed = ?", false).
+		Find(&keys).Error; err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		sequence := watermarks[k.CourseInstanceID]
+		if k.Sequence > sequence || present[[3]uuid.UUID{k.CourseInstanceID, k.UserID, k.SectionID}] {
+			continue
+		}
+		if err := tx.Model(&domain.SectionMemberProjection{}).
+			Where("course_instance_id = ? AND user_id = ? AND section_id = ? AND sequence <= ?",
+				k.CourseInstanceID, k.UserID, k.SectionID, sequence).
+			Updates(map[string]interface{}{
+				"removed":    true,
+				"sequence":   sequence,
+				"updated_at": time.Now().UTC(),
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -34,8 +34,14 @@ func (m *Migrator) Run() error {
 		&domain.AssignmentRubricCriterion{},
 		&domain.AssignmentTestCase{},
 		&domain.AssignmentSampleAnswer{},
+		&domain.AssignmentSection{},
 		&domain.CodeRepo{},
 		&domain.CodeVersion{},
+		&domain.RegradeRequest{},
+		&domain.EnrollmentProjection{},
+		&domain.InstructorProjection{},
+		&domain.SectionMemberProjection{},
+		&domain.ProjectionState{},
 	); err != nil {
 		return fmt.Errorf("auto migrate tables: %w", err)
 	}
@@ -269,6 +275,119 @@ func (m *Migrator) Run() error {
 		m.logger.Warn("failed to add commit_sha column to submissions", zap.Error(err))
 	}
 
+	// ── Submission analytics materialised views ───────────────────────────────
+	// Refreshed by the analytics service after submission events. REFRESH
+	// MATERIALIZED VIEW CONCURRENTLY needs a unique index on plain columns
+	// covering every row, so each view gets one.
+
+	// One row per student or group that has submitted an assignment.
+	if err := m.db.Exec(`
+		CREATE MATERIALIZED VIEW IF NOT EXISTS assignment_owner_stats AS
+		SELECT
+			assignment_id,
+			COALESCE(user_id, group_id) AS owner_id,
+			bool_and(user_id IS NULL) AS is_group,
+			count(*)::int AS attempts,
+			min(submitted_at) AS first_submitted_at,
+			max(submitted_at) AS last_submitted_at,
+			(array_agg(score ORDER BY version DESC) FILTER (WHERE score IS NOT NULL))[1]::float8 AS score,
+			avg(CASE WHEN execution_time ~ '^[0-9]+(\.[0-9]+)?$'
+				THEN execution_time::numeric * 1000 END)::float8 AS avg_execution_ms,
+			avg(NULLIF(memory_used, 0))::float8 AS avg_memory_kb
+		FROM submissions
+		WHERE COALESCE(user_id, group_id) IS NOT NULL
+		GROUP BY assignment_id, COALESCE(user_id, group_id)
+	`).Error; err != nil {
+		m.logger.Warn("failed to create materialised view assignment_owner_stats", zap.Error(err))
+	}
+
+	if err := m.db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_assignment_owner_stats_owner
+		ON assignment_owner_stats(assignment_id, owner_id)
+	`).Error; err != nil {
+		m.logger.Warn("failed to create index idx_assignment_owner_stats_owner", zap.Error(err))
+	}
+
+	// Test case outcomes of the latest version of every submission.
+	if err := m.db.Exec(`
+		CREATE MATERIALIZED VIEW IF NOT EXISTS assignment_test_case_stats AS
+		SELECT
+			s.assignment_id,
+			r.value->>'test_case_id' AS test_case_id,
+			count(*) AS runs,
+			count(*) FILTER (WHERE r.value->'passed' = 'true'::jsonb) AS passed
+		FROM submissions s
+		CROSS JOIN LATERAL jsonb_array_elements(
+			CASE WHEN jsonb_typeof(s.test_case_results) = 'array'
+				THEN s.test_case_results ELSE '[]'::jsonb END
+		) AS r
+		WHERE s.is_latest AND COALESCE(r.value->>'test_case_id', '') <> ''
+		GROUP BY s.assignment_id, r.value->>'test_case_id'
+	`).Error; err != nil {
+		m.logger.Warn("failed to create materialised view assignment_test_case_stats", zap.Error(err))
+	}
+
+	if err := m.db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_assignment_test_case_stats_test_case
+		ON assignment_test_case_stats(assignment_id, test_case_id)
+	`).Error; err != nil {
+		m.logger.Warn("failed to create index idx_assignment_test_case_stats_test_case", zap.Error(err))
+	}
+
+	// The first version of assignment_submission_timing measured every
+	// submission against the assignment's due date. Drop it so the definition
+	// below, which honours section due dates, replaces it.
+	if err := m.db.Exec(`
+		DO $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1 FROM pg_matviews
+				WHERE matviewname = 'assignment_submission_timing'
+					AND definition NOT LIKE '%section_member_projections%'
+			) THEN
+				DROP MATERIALIZED VIEW assignment_submission_timing;
+			END IF;
+		END $$
+	`).Error; err != nil {
+		m.logger.Warn("failed to drop outdated materialised view assignment_submission_timing", zap.Error(err))
+	}
+
+	// Submissions per hour relative to the due date that applied to the
+	// submitter: the latest due date of the targeted sections the student, or
+	// a member of the submitting group, belongs to, falling back to the
+	// assignment's own. A targeted section without a due date of its own
+	// takes the assignment's, and no due date at all leaves the submission
+	// out. This mirrors Assignment.TargetFor.
+	if err := m.db.Exec(`
+		CREATE MATERIALIZED VIEW IF NOT EXISTS assignment_submission_timing AS
+		SELECT
+			s.assignment_id,
+			floor(extract(epoch FROM s.submitted_at - COALESCE(sd.due_at, a.due_at)) / 3600)::int AS hours_from_due,
+			count(*) AS submissions
+		FROM submissions s
+		JOIN assignments a ON a.id = s.assignment_id
+		LEFT JOIN LATERAL (
+			SELECT CASE WHEN bool_and(COALESCE(t.due_at, a.due_at) IS NOT NULL)
+				THEN max(COALESCE(t.due_at, a.due_at)) END AS due_at
+			FROM assignment_sections t
+			JOIN section_member_projections m ON m.section_id = t.section_id AND NOT m.removed
+			LEFT JOIN groups g ON g.id = s.group_id
+			WHERE t.assignment_id = s.assignment_id
+				AND (m.user_id = s.user_id OR g.members @> jsonb_build_array(m.user_id::text))
+		) sd ON true
+		WHERE COALESCE(sd.due_at, a.due_at) IS NOT NULL
+		GROUP BY 1, 2
+	`).Error; err != nil {
+		m.logger.Warn("failed to create materialised view assignment_submission_timing", zap.Error(err))
+	}
+
+	if err := m.db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_assignment_submission_timing_hour
+		ON assignment_submission_timing(assignment_id, hours_from_due)
+	`).Error; err != nil {
+		m.logger.Warn("failed to create index idx_assignment_submission_timing_hour", zap.Error(err))
+	}
+
 	m.logger.Info("migrations completed successfully")
 	return nil
 }
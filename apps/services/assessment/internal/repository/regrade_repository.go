@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RegradeRepository defines all data operations for regrade requests.
+type RegradeRepository interface {
+	// CreateRegradeRequest inserts a new regrade request.
+	CreateRegradeRequest(req *domain.RegradeRequest) error
+
+	// GetRegradeRequest loads a regrade request by its primary key.
+	// Returns (nil, nil) when not found.
+	GetRegradeRequest(id uuid.UUID) (*domain.RegradeRequest, error)
+
+	// FindOpenRegradeRequest returns the open request for a submission, if any.
+	// Returns (nil, nil) when there is none.
+	FindOpenRegradeRequest(submissionID uuid.UUID) (*domain.RegradeRequest, error)
+
+	// ListByAssignment returns the regrade requests for an assignment, newest
+	// first, optionally filtered by status.
+	ListByAssignment(assignmentID uuid.UUID, status string) ([]domain.RegradeRequest, error)
+
+	// ListByRequester returns the regrade requests raised by a user for an
+	// assignment, newest first.
+	ListByRequester(assignmentID, userID uuid.UUID) ([]domain.RegradeRequest, error)
+
+	// Respond stores the staff response on a request and, when accepted with a
+	// new score, updates the submission's grade in the same transaction. It
+	// reports false, writing nothing, when the request is no longer open.
+	Respond(req *domain.RegradeRequest) (bool, error)
+}
+
+// regradeRepository is the concrete GORM-backed implementation.
+type regradeRepository struct {
+	db *gorm.DB
+}
+
+// NewRegradeRepository creates a new regradeRepository.
+func NewRegradeRepository(db *gorm.DB) RegradeRepository {
+	return &regradeRepository{db: db}
+}
+
+func (r *regradeRepository) CreateRegradeRequest(req *domain.RegradeRequest) error {
+	return r.db.Create(req).Error
+}
+
+func (r *regradeRepository) GetRegradeRequest(id uuid.UUID) (*domain.RegradeRequest, error) {
+	var req domain.RegradeRequest
+	err := r.db.Where("id = ?", id).First(&req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *regradeRepository) FindOpenRegradeRequest(submissionID uuid.UUID) (*domain.RegradeRequest, error) {
+	var req domain.RegradeRequest
+	err := r.db.
+		Where("submission_id = ? AND status = ?", submissionID, domain.RegradeStatusOpen).
+		First(&req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *regradeRepository) ListByAssignment(assignmentID uuid.UUID, status string) ([]domain.RegradeRequest, error) {
+	var requests []domain.RegradeRequest
+	q := r.db.Where("assignment_id = ?", assignmentID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	if err := q.Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *regradeRepository) ListByRequester(assignmentID, userID uuid.UUID) ([]domain.RegradeRequest, error) {
+	var requests []domain.RegradeRequest
+	err := r.db.
+		Where("assignment_id = ? AND requested_by = ?", assignmentID, userID).
+		Order("created_at DESC").
+		Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Respond answers the request only while it is still open, so two staff
+// members responding at once cannot both change the score.
+func (r *regradeRepository) Respond(req *domain.RegradeRequest) (bool, error) {
+	responded := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.RegradeRequest{}).
+			Where("id = ? AND status = ?", req.ID, domain.RegradeStatusOpen).
+			Updates(map[string]interface{}{
+				"status":       req.Status,
+				"new_score":    req.NewScore,
+				"response":     req.Response,
+				"responded_by": req.RespondedBy,
+				"responded_at": req.RespondedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		responded = true
+
+		if req.Status != domain.RegradeStatusAccepted || req.NewScore == nil {
+			return nil
+		}
+		return tx.Model(&domain.Submission{}).
+			Where("id = ?", req.SubmissionID).
+			Updates(map[string]interface{}{
+				"score":     *req.NewScore,
+				"graded_by": req.RespondedBy,
+				"graded_at": time.Now().UTC(),
+			}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return responded, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -35,6 +36,10 @@ type SubmissionRepository interface {
 	// owner scope, ordered by version descending (newest first).
 	ListSubmissions(assignmentID uuid.UUID, userID, groupID *uuid.UUID) ([]domain.Submission, error)
 
+	// ListSubmissionsPage returns a page of every submission for the given
+	// assignment, across all owners.
+	ListSubmissionsPage(assignmentID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Submission], error)
+
 	// GetLatestSubmission returns the single submission with is_latest=true
 	// for the given assignment and owner scope.
 	GetLatestSubmission(assignmentID uuid.UUID, userID, groupID *uuid.UUID) (*domain.Submission, error)
@@ -51,6 +56,13 @@ type SubmissionRepository interface {
 	// UpdateAnalysis persists the CIPAS AI detection and semantic similarity
 	// scores for a submission.  Called via PATCH /submissions/:id/analysis.
 	UpdateAnalysis(id uuid.UUID, req *dto.UpdateAnalysisRequest) error
+
+	// UpdateGrade records a manual grade and feedback on a submission.
+	UpdateGrade(id uuid.UUID, score float64, feedback string, gradedBy uuid.UUID) error
+
+	// ListGradedSubmissions returns every graded submission for the given
+	// assignments, newest version first within each owner scope.
+	ListGradedSubmissions(assignmentIDs []uuid.UUID) ([]domain.Submission, error)
 }
 
 // submissionRepository is the concrete GORM-backed implementation.
@@ -193,6 +205,19 @@ func (r *submissionRepository) ListSubmissions(
 	return submissions, nil
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// ListSubmissionsPage
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListSubmissionsPage returns a page of every submission version for the given
+// assignment, across all owners.
+func (r *submissionRepository) ListSubmissionsPage(
+	assignmentID uuid.UUID,
+	spec queryspec.Spec,
+) (queryspec.Page[domain.Submission], error) {
+	return queryspec.Find[domain.Submission](r.db.Where("assignment_id = ?", assignmentID), spec)
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // UpdateStatus
 // ─────────────────────────────────────────────────────────────────────────────
@@ -289,3 +314,45 @@ func (r *submissionRepository) UpdateAnalysis(id uuid.UUID, req *dto.UpdateAnaly
 		Updates(updates).
 		Error
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// UpdateGrade
+// ─────────────────────────────────────────────────────────────────────────────
+
+// UpdateGrade persists a manual grade on a submission.
+func (r *submissionRepository) UpdateGrade(id uuid.UUID, score float64, feedback string, gradedBy uuid.UUID) error {
+	return r.db.
+		Model(&domain.Submission{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"score":     score,
+			"feedback":  feedback,
+			"graded_by": gradedBy,
+			"graded_at": time.Now().UTC(),
+		}).
+		Error
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ListGradedSubmissions
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListGradedSubmissions loads all submissions with a score for the given
+// assignments in a single query, ordered by version descending.
+func (r *submissionRepository) ListGradedSubmissions(assignmentIDs []uuid.UUID) ([]domain.Submission, error) {
+	if len(assignmentIDs) == 0 {
+		return []domain.Submission{}, nil
+	}
+
+	var submissions []domain.Submission
+	err := r.db.
+		Where("assignment_id IN ? AND score IS NOT NULL", assignmentIDs).
+		Order("version DESC").
+		Find(&submissions).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return submissions, nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserDataErasure reports what was removed for a user, or cleared when grade
+// records are retained. SubmissionPaths and
+// CodeAssignmentIDs identify stored code the caller still has to delete from
+// object storage.
+type UserDataErasure struct {
+	Submissions     int64 `json:"submissions"`
+	RegradeRequests int64 `json:"regrade_requests"`
+	CodeRepos       int64 `json:"code_repos"`
+	CodeVersions    int64 `json:"code_versions"`
+	GroupsLeft      int64 `json:"groups_left"`
+
+	SubmissionPaths   []string    `json:"-"`
+	CodeAssignmentIDs []uuid.UUID `json:"-"`
+}
+
+// UserDataExport holds every assessment record tied to one user. Groups are
+// the submission groups the user belongs to; group submissions are listed
+// with the user's own.
+type UserDataExport struct {
+	Submissions     []domain.Submission      `json:"submissions"`
+	Groups          []domain.SubmissionGroup `json:"groups"`
+	RegradeRequests []domain.RegradeRequest  `json:"regrade_requests"`
+	CodeRepos       []domain.CodeRepo        `json:"code_repos"`
+	CodeVersions    []domain.CodeVersion     `json:"code_versions"`
+}
+
+// UserDataRepository operates on every assessment record tied to one user.
+type UserDataRepository interface {
+	ExportUser(userID uuid.UUID) (*UserDataExport, error)
+	// EraseUser deletes the user's individual submissions, regrade requests
+	// and code repositories, and removes them from submission groups. Group
+	// submissions are kept for the remaining members.
+	//
+	// With retainGrades the rows are kept as the grade record instead: scores,
+	// grading metadata and group memberships stay, while submitted code,
+	// execution output and free-text the user wrote are cleared.
+	EraseUser(userID uuid.UUID, retainGrades bool) (*UserDataErasure, error)
+}
+
+// userDataRepository is the concrete GORM-backed implementation.
+type userDataRepository struct {
+	db *gorm.DB
+}
+
+// NewUserDataRepository creates a new userDataRepository.
+func NewUserDataRepository(db *gorm.DB) UserDataRepository {
+	return &userDataRepository{db: db}
+}
+
+func (r *userDataRepository) ExportUser(userID uuid.UUID) (*UserDataExport, error) {
+	export := &UserDataExport{}
+	if err := r.db.
+		Where("members @> ?::jsonb", memberJSON(userID)).
+		Order("created_at").
+		Find(&export.Groups).Error; err != nil {
+		return nil, err
+	}
+	groupIDs := []uuid.UUID{uuid.Nil}
+	for _, g := range export.Groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+
+	if err := r.db.
+		Where("user_id = ? OR group_id IN ?", userID, groupIDs).
+		Order("submitted_at").
+		Find(&export.Submissions).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("requested_by = ?", userID).
+		Order("created_at").
+		Find(&export.RegradeRequests).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&export.CodeRepos).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("submitted_at").
+		Find(&export.CodeVersions).Error; err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (r *userDataRepository) EraseUser(userID uuid.UUID, retainGrades bool) (*UserDataErasure, error) {
+	if retainGrades {
+		return r.pseudonymizeUser(userID)
+	}
+
+	erasure := &UserDataErasure{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var submissions []domain.Submission
+		if err := tx.Select("id", "storage_path").
+			Where("user_id = ?", userID).
+			Find(&submissions).Error; err != nil {
+			return err
+		}
+		submissionIDs := make([]uuid.UUID, 0, len(submissions))
+		for _, s := range submissions {
+			submissionIDs = append(submissionIDs, s.ID)
+			if s.StoragePath != "" {
+				erasure.SubmissionPaths = append(erasure.SubmissionPaths, s.StoragePath)
+			}
+		}
+
+		res := tx.Where("requested_by = ? OR submission_id IN ?", userID, append(submissionIDs, uuid.Nil)).
+			Delete(&domain.RegradeRequest{})
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.RegradeRequests = res.RowsAffected
+
+		res = tx.Where("user_id = ?", userID).Delete(&domain.Submission{})
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.Submissions = res.RowsAffected
+
+		if err := tx.Model(&domain.CodeRepo{}).
+			Where("user_id = ?", userID).
+			Pluck("assignment_id", &erasure.CodeAssignmentIDs).Error; err != nil {
+			return err
+		}
+
+		res = tx.Where("user_id = ?", userID).Delete(&domain.CodeVersion{})
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.CodeVersions = res.RowsAffected
+
+		res = tx.Where("user_id = ?", userID).Delete(&domain.CodeRepo{})
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.CodeRepos = res.RowsAffected
+
+		// members is a JSONB array of user-ID strings; "-" drops the element.
+		res = tx.Model(&domain.SubmissionGroup{}).
+			Where("members @> ?::jsonb", memberJSON(userID)).
+			Update("members", gorm.Expr("members - ?", userID.String()))
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.GroupsLeft = res.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
+
+// pseudonymizeUser clears what the user authored from their records while
+// keeping the rows that make up the grade record.
+func (r *userDataRepository) pseudonymizeUser(userID uuid.UUID) (*UserDataErasure, error) {
+	erasure := &UserDataErasure{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Submission{}).
+			Where("user_id = ? AND storage_path <> ''", userID).
+			Pluck("storage_path", &erasure.SubmissionPaths).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(&domain.Submission{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"storage_path":      "",
+				"execution_stdout":  "",
+				"execution_stderr":  "",
+				"compile_output":    "",
+				"test_case_results": nil,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.Submissions = res.RowsAffected
+
+		res = tx.Model(&domain.RegradeRequest{}).
+			Where("requested_by = ?", userID).
+			Update("reason", "")
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.RegradeRequests = res.RowsAffected
+
+		if err := tx.Model(&domain.CodeRepo{}).
+			Where("user_id = ?", userID).
+			Pluck("assignment_id", &erasure.CodeAssignmentIDs).Error; err != nil {
+			return err
+		}
+
+		res = tx.Model(&domain.CodeVersion{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"commit_message": "",
+				"grading_error":  "",
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.CodeVersions = res.RowsAffected
+
+		res = tx.Model(&domain.CodeRepo{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"storage_path": "",
+				"is_active":    false,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		erasure.CodeRepos = res.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
+
+// memberJSON is the JSONB containment operand matching groups that list the
+// user among their members.
+func memberJSON(userID uuid.UUID) string {
+	return `["` + userID.String() + `"]`
+}
@@ -0,0 +1,490 @@
+package router
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// OpenAPI returns the assessment service's OpenAPI document, served at
+// /openapi.json and enforced on requests by SetupRoutes. Every route
+// SetupRoutes registers must be listed in routes: TestOpenAPIMatchesRoutes
+// fails the build when the two disagree.
+func OpenAPI() *openapi.Document {
+	return openapi.Spec{
+		Info: openapi.Info{
+			Title:       "GradeLoop Assessment Service",
+			Description: "Assignments, code repositories, submissions, grading and regrade requests.",
+			Version:     "1.0.0",
+		},
+		Error:  utils.AppError{},
+		Routes: routes,
+	}.MustBuild()
+}
+
+var routes = []openapi.Route{
+
+	// service
+	{
+		Method: fiber.MethodGet, Path: "/", Name: "getServiceInfo",
+		Summary: "Service information", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"service": "", "version": "", "status": ""},
+		},
+	},
+
+	// assignments
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/assignments/", Name: "createAssignment",
+		Summary: "Create assignment", Tag: "assignments",
+		Body: dto.CreateAssignmentRequest{},
+		Responses: openapi.Responses{
+			201: dto.AssignmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/assignments/:id", Name: "getAssignment",
+		Summary: "Get assignment", Tag: "assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.AssignmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/assignments/:id", Name: "updateAssignment",
+		Summary: "Update assignment", Tag: "assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateAssignmentRequest{},
+		Responses: openapi.Responses{
+			200: dto.AssignmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/assignments/:id/latest", Name: "getLatestSubmission",
+		Summary: "Get latest submission", Tag: "assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"group_id": openapi.String, "user_id": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.SubmissionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/assignments/:id/submissions", Name: "listSubmissions",
+		Summary: "List submissions", Tag: "assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"group_id": openapi.String, "user_id": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.ListSubmissionsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/assignments/course-instance/:courseInstanceId", Name: "listAssignmentsByCourseInstance",
+		Summary: "List assignments by course instance", Tag: "assignments",
+		Params: openapi.Params{"courseInstanceId": openapi.UUID},
+		Query:  dto.ListAssignmentsSchema.Params(),
+		Responses: openapi.Responses{
+			200: dto.AssignmentPageResponse{},
+		},
+	},
+
+	// code
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/code/config/:assignmentId", Name: "getConfig",
+		Summary: "Get config", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"use_seaweedfs": false, "starter_code": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/code/config/:assignmentId", Name: "updateConfig",
+		Summary: "Update config", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Body:   openapi.Fields{"use_seaweedfs": false, "starter_code": ""},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"success": false},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/code/repos", Name: "createOrGetRepo",
+		Summary: "Create or get repo", Tag: "code",
+		Body: openapi.Fields{"assignment_id": uuid.UUID{}},
+		Responses: openapi.Responses{
+			200: dto.CodeRepoResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/code/repos/:assignmentId", Name: "getRepo",
+		Summary: "Get repo", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CodeRepoResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/code/repos/:assignmentId/commits", Name: "getCommits",
+		Summary: "Get commits", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Responses: openapi.Responses{
+			200: []dto.CodeCommitResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/code/repos/:assignmentId/files", Name: "getFiles",
+		Summary: "Get files", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Query:  openapi.Params{"path": openapi.String},
+		Responses: openapi.Responses{
+			200: []dto.CodeFileResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/code/repos/:assignmentId/files", Name: "saveFile",
+		Summary: "Save file", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Body:   dto.CodeSaveFileRequest{},
+		Responses: openapi.Responses{
+			200: dto.CodeCommitResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/code/repos/:assignmentId/files/*", Name: "getFileContent",
+		Summary: "Get file content", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"content": "", "sha": ""},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/code/repos/:assignmentId/submit", Name: "submitAssignment",
+		Summary: "Submit assignment", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Body:   openapi.Fields{"message": ""},
+		Responses: openapi.Responses{
+			200: dto.CodeVersionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/code/repos/:assignmentId/versions", Name: "getVersions",
+		Summary: "Get versions", Tag: "code",
+		Params: openapi.Params{"assignmentId": openapi.UUID},
+		Responses: openapi.Responses{
+			200: []dto.CodeVersionResponse{},
+		},
+	},
+
+	// course-access
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/course-access/status", Name: "getStatus",
+		Summary: "Get status", Tag: "course-access",
+		Responses: openapi.Responses{
+			200: dto.CourseAccessStatusResponse{},
+		},
+	},
+
+	// debug
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/debug/auth", Name: "debugAuth",
+		Summary: "Show the caller's identity", Tag: "debug",
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": "", "username": "", "user_type": "", "authenticated": false},
+		},
+	},
+
+	// groups
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/groups/", Name: "createGroup",
+		Summary: "Create group", Tag: "groups",
+		Body: dto.CreateGroupRequest{},
+		Responses: openapi.Responses{
+			201: dto.GroupResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/groups/:id", Name: "getGroup",
+		Summary: "Get group", Tag: "groups",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.GroupResponse{},
+		},
+	},
+
+	// instructor-assignments
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/instructor-assignments/", Name: "createAssignmentAsInstructor",
+		Summary: "Create assignment", Tag: "instructor-assignments",
+		Body: dto.CreateAssignmentRequest{},
+		Responses: openapi.Responses{
+			201: dto.AssignmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-assignments/:id/analytics", Name: "getAssignmentAnalytics",
+		Summary: "Get assignment analytics", Tag: "instructor-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.AssignmentAnalyticsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-assignments/:id/rubric", Name: "getAssignmentRubric",
+		Summary: "Get assignment rubric", Tag: "instructor-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.ListRubricResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/instructor-assignments/:id/rubric", Name: "updateAssignmentRubric",
+		Summary: "Update assignment rubric", Tag: "instructor-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateRubricRequest{},
+		Responses: openapi.Responses{
+			200: dto.ListRubricResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-assignments/:id/sample-answer", Name: "getAssignmentSampleAnswer",
+		Summary: "Get assignment sample answer", Tag: "instructor-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SampleAnswerResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-assignments/:id/test-cases", Name: "getAssignmentTestCases",
+		Summary: "Get assignment test cases", Tag: "instructor-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.ListTestCasesResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-assignments/course-instance/:id/analytics", Name: "getCourseInstanceAnalytics",
+		Summary: "Get course instance analytics", Tag: "instructor-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseInstanceAnalyticsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-assignments/me", Name: "getMyAssignments",
+		Summary: "Get my assignments", Tag: "instructor-assignments",
+		Query: openapi.Params{"course_instance_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.ListAssignmentsResponse{},
+		},
+	},
+
+	// instructor-submissions
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/instructor-submissions/:id/grade", Name: "gradeSubmission",
+		Summary: "Grade submission", Tag: "instructor-submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.GradeSubmissionRequest{},
+		Responses: openapi.Responses{
+			200: dto.SubmissionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/instructor-submissions/:id/analysis", Name: "updateSubmissionAnalysis",
+		Summary: "Update submission analysis", Tag: "instructor-submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateAnalysisRequest{},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-submissions/assignment/:id", Name: "getSubmissions",
+		Summary: "Get submissions", Tag: "instructor-submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  dto.ListAllSubmissionsSchema.Params(),
+		Responses: openapi.Responses{
+			200: dto.SubmissionPageResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/instructor-submissions/assignment/:id/regrade-requests", Name: "listRegradeRequests",
+		Summary: "List regrade requests", Tag: "instructor-submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"status": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.ListRegradeRequestsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/instructor-submissions/regrade-requests/:id", Name: "respondToRegrade",
+		Summary: "Respond to regrade", Tag: "instructor-submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.RespondRegradeRequest{},
+		Responses: openapi.Responses{
+			200: dto.RegradeRequestResponse{},
+		},
+	},
+
+	// internal
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/internal/assignments/clone", Name: "cloneAssignments",
+		Summary: "Clone assignments", Tag: "internal", Auth: openapi.AuthService,
+		Body: dto.CloneAssignmentsRequest{},
+		Responses: openapi.Responses{
+			200: dto.CloneAssignmentsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/course-instances/:id/scores", Name: "listCourseInstanceScores",
+		Summary: "List course instance scores", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.CourseInstanceScoresResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/internal/users/:id", Name: "eraseUserData",
+		Summary: "Erase user data", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"retain_grades": openapi.Boolean},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": uuid.UUID{}, "retain_grades": false, "erased": repository.UserDataErasure{}},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/users/:id/data", Name: "exportUserData",
+		Summary: "Export user data", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Binary{"application/zip"},
+		},
+	},
+
+	// student-assignments
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-assignments/", Name: "listMyAssignments",
+		Summary: "List my assignments", Tag: "student-assignments",
+		Query: openapi.Params{"course_instance_id": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.ListAssignmentsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-assignments/:id", Name: "getAssignmentAsStudent",
+		Summary: "Get assignment", Tag: "student-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.AssignmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-assignments/:id/sample-answer", Name: "getAssignmentSampleAnswerAsStudent",
+		Summary: "Get assignment sample answer", Tag: "student-assignments",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SampleAnswerResponse{},
+		},
+	},
+
+	// student-submissions
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/student-submissions/:id/regrade-requests", Name: "requestRegrade",
+		Summary: "Request regrade", Tag: "student-submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.CreateRegradeRequest{},
+		Responses: openapi.Responses{
+			201: dto.RegradeRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-submissions/assignment/:id/regrade-requests", Name: "listMyRegradeRequests",
+		Summary: "List my regrade requests", Tag: "student-submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.ListRegradeRequestsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-submissions/me", Name: "listMySubmissions",
+		Summary: "List my submissions", Tag: "student-submissions",
+		Query: openapi.Params{"assignment_id": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.ListSubmissionsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/student-submissions/me/latest", Name: "getMyLatestSubmission",
+		Summary: "Get my latest submission", Tag: "student-submissions",
+		Query: openapi.Params{"assignment_id": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.SubmissionResponse{},
+		},
+	},
+
+	// submissions
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/submissions/", Name: "createSubmission",
+		Summary: "Create submission", Tag: "submissions",
+		Body: dto.CreateSubmissionRequest{},
+		Responses: openapi.Responses{
+			202: dto.SubmissionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/submissions/:id", Name: "getSubmission",
+		Summary: "Get submission", Tag: "submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SubmissionResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/submissions/:id", Name: "updateSubmission",
+		Summary: "Update submission (always rejected: submissions are immutable)", Tag: "submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			405: utils.AppError{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/submissions/:id/code", Name: "getSubmissionCode",
+		Summary: "Get submission code", Tag: "submissions",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.SubmissionCodeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/submissions/batch/code", Name: "getBatchCode",
+		Summary: "Get batch code", Tag: "submissions",
+		Body: dto.BatchCodeRequest{},
+		Responses: openapi.Responses{
+			200: dto.BatchCodeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/submissions/run-code", Name: "runCode",
+		Summary: "Run code", Tag: "submissions",
+		Body: dto.RunCodeRequest{},
+		Responses: openapi.Responses{
+			200: dto.RunCodeResponse{},
+		},
+	},
+
+	// health
+	{
+		Method: fiber.MethodGet, Path: "/health", Name: "health",
+		Summary: "Health", Tag: "health", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"status": "", "service": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/openapi.json", Name: "getOpenAPI",
+		Summary: "OpenAPI document", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Any,
+		},
+	},
+}
@@ -4,6 +4,10 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/handler"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/middleware"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/gofiber/fiber/v3"
 )
 
@@ -16,7 +20,17 @@ type Config struct {
 	InstructorHandler *handler.InstructorHandler
 	StudentHandler    *handler.StudentHandler
 	CodeHandler       *handler.CodeHandler
-	JWTSecretKey      []byte
+	UserDataHandler   *handler.UserDataHandler
+	// CourseAccessHandler reports on the local enrollment read-model.
+	CourseAccessHandler *handler.CourseAccessHandler
+	// AssignmentCloneHandler and ScoreHandler serve the Academic Service.
+	AssignmentCloneHandler *handler.AssignmentCloneHandler
+	ScoreHandler           *handler.ScoreHandler
+	JWTSecretKey           []byte
+	// PermissionChecker resolves course-scoped role assignments with IAM.
+	PermissionChecker authz.Checker
+	// TokenVerifier resolves personal access tokens with IAM.
+	TokenVerifier pat.Verifier
 }
 
 // requireAdminRole is a route-level middleware that allows access only to
@@ -38,6 +52,13 @@ func requireAdminRole() fiber.Handler {
 
 // SetupRoutes registers all HTTP routes on the provided Fiber app.
 func SetupRoutes(app *fiber.App, cfg Config) {
+	// Requests are checked against the OpenAPI document (see openapi.go)
+	// after authentication: internal routes after their service token, the
+	// rest by the protected group.
+	doc := OpenAPI()
+	validate := openapi.Validate(doc)
+	app.Get("/openapi.json", openapi.Serve(doc))
+
 	// Health check — unauthenticated
 	cfg.HealthHandler.RegisterRoutes(app)
 
@@ -53,8 +74,37 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	// ── API v1 ────────────────────────────────────────────────────────────────
 	api := app.Group("/api/v1")
 
+	// GET    /api/v1/internal/users/:id/data     — export a user's data (zip)
+	// DELETE /api/v1/internal/users/:id          — erase a user's data
+	// Called by IAM with a service token holding assessment.user_data:read or
+	// assessment.user_data:erase for data requests and account purges.
+	api.Get("/internal/users/:id/data",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAssessmentUserRead),
+		validate,
+		cfg.UserDataHandler.ExportUserData)
+	api.Delete("/internal/users/:id",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAssessmentUserErase),
+		validate,
+		cfg.UserDataHandler.EraseUserData)
+
+	// POST   /api/v1/internal/assignments/clone  — copy assignments to a new term
+	// Called by the Academic Service with a service token holding
+	// assessment.assignments:clone during semester rollovers.
+	api.Post("/internal/assignments/clone",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAssignmentClone),
+		validate,
+		cfg.AssignmentCloneHandler.CloneAssignments)
+
+	// GET    /api/v1/internal/course-instances/:id/scores — effective scores
+	// Called by the Academic Service with a service token holding
+	// assessment.scores:read when computing final grades.
+	api.Get("/internal/course-instances/:id/scores",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeScoresRead),
+		validate,
+		cfg.ScoreHandler.ListCourseInstanceScores)
+
 	// All routes below require a valid JWT issued by the IAM Service.
-	protected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecretKey))
+	protected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecretKey, cfg.TokenVerifier), validate)
 
 	// Debug endpoint — useful for verifying token parsing in development.
 	protected.Get("/debug/auth", func(c fiber.Ctx) error {
@@ -114,10 +164,6 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	// segment from being swallowed as a UUID param value.
 	submissions.Get("/:id/code", cfg.SubmissionHandler.GetSubmissionCode)
 
-	// PATCH  /api/v1/submissions/:id/analysis   — store CIPAS AI + semantic scores
-	// NOTE: Must be registered BEFORE GET /:id for the same reason as /code above.
-	submissions.Patch("/:id/analysis", cfg.SubmissionHandler.PatchAnalysis)
-
 	// GET    /api/v1/submissions/:id            — get submission metadata
 	submissions.Get("/:id", cfg.SubmissionHandler.GetSubmission)
 
@@ -125,21 +171,38 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	submissions.Put("/:id", cfg.SubmissionHandler.UpdateSubmission)
 
 	// ── Instructor-scoped routes ────────────────────────────────────────────
-	// Accessible to instructor + admin.
+	// Accessible to course staff. There is no user-type gate because teaching
+	// assistants may hold any user type; each handler verifies the caller's
+	// role on the target course instance with the Academic Service, and TAs
+	// are refused on assignment edits.
 	// PathPrefix: /api/v1/instructor-assignments — routed by Traefik
 	// PathPrefix: /api/v1/instructor-submissions — routed by Traefik
-	requireInstructorOrAdmin := middleware.RequireAnyUserType("instructor", "admin")
 
-	instructorAssignments := protected.Group("/instructor-assignments", requireInstructorOrAdmin)
+	// Writes additionally require assignment:write, held globally or through a
+	// role assigned on the target course instance.
+	requireAssignmentWrite := authz.RequirePermission(authz.PermAssignmentWrite,
+		authz.ScopesFrom(authz.CourseInstanceFromBody),
+		authz.CheckWith(cfg.PermissionChecker),
+	)
+
+	instructorAssignments := protected.Group("/instructor-assignments")
 	instructorAssignments.Get("/me", cfg.InstructorHandler.GetMyAssignments)
-	instructorAssignments.Post("/", cfg.InstructorHandler.CreateAssignment)
+	instructorAssignments.Post("/", requireAssignmentWrite, cfg.InstructorHandler.CreateAssignment)
 	instructorAssignments.Get("/:id/rubric", cfg.InstructorHandler.GetAssignmentRubric)
-	instructorAssignments.Put("/:id/rubric", cfg.InstructorHandler.UpdateAssignmentRubric)
+	instructorAssignments.Put("/:id/rubric", authz.RequirePermission(authz.PermAssignmentWrite), cfg.InstructorHandler.UpdateAssignmentRubric)
 	instructorAssignments.Get("/:id/test-cases", cfg.InstructorHandler.GetAssignmentTestCases)
 	instructorAssignments.Get("/:id/sample-answer", cfg.InstructorHandler.GetAssignmentSampleAnswer)
+	instructorAssignments.Get("/:id/analytics", cfg.InstructorHandler.GetAssignmentAnalytics)
+	instructorAssignments.Get("/course-instance/:id/analytics", cfg.InstructorHandler.GetCourseInstanceAnalytics)
 
-	instructorSubmissions := protected.Group("/instructor-submissions", requireInstructorOrAdmin)
+	instructorSubmissions := protected.Group("/instructor-submissions")
+	// NOTE: /assignment/:id/regrade-requests must be registered BEFORE
+	// /assignment/:id so the literal sub-segment is not swallowed.
+	instructorSubmissions.Get("/assignment/:id/regrade-requests", cfg.InstructorHandler.ListRegradeRequests)
 	instructorSubmissions.Get("/assignment/:id", cfg.InstructorHandler.GetSubmissions)
+	instructorSubmissions.Put("/:id/grade", cfg.InstructorHandler.GradeSubmission)
+	instructorSubmissions.Patch("/:id/analysis", cfg.InstructorHandler.UpdateSubmissionAnalysis)
+	instructorSubmissions.Patch("/regrade-requests/:id", cfg.InstructorHandler.RespondToRegrade)
 
 	// ── Student-scoped routes ────────────────────────────────────────────────
 	// Accessible to student + admin.
@@ -162,6 +225,8 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	// "latest" as a sub-path of the /me route.
 	studentSubmissions.Get("/me/latest", cfg.StudentHandler.GetMyLatestSubmission)
 	studentSubmissions.Get("/me", cfg.StudentHandler.ListMySubmissions)
+	studentSubmissions.Get("/assignment/:id/regrade-requests", cfg.StudentHandler.ListMyRegradeRequests)
+	studentSubmissions.Post("/:id/regrade-requests", cfg.StudentHandler.RequestRegrade)
 
 	// ── Groups ────────────────────────────────────────────────────────────────
 	// Groups are accessible to all authenticated users — a student may create
@@ -187,4 +252,9 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	code.Get("/repos/:assignmentId/commits", cfg.CodeHandler.GetCommits)
 	code.Get("/config/:assignmentId", cfg.CodeHandler.GetConfig)
 	code.Put("/config/:assignmentId", cfg.CodeHandler.UpdateConfig)
+
+	// ── Course access read-model ─────────────────────────────────────────────
+	// GET    /api/v1/course-access/status       — staleness and usage (admin only)
+	courseAccess := protected.Group("/course-access", requireAdminRole())
+	courseAccess.Get("/status", cfg.CourseAccessHandler.GetStatus)
 }
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// scoreHistogramBuckets is the number of buckets in an assignment's score histogram.
+const scoreHistogramBuckets = 10
+
+// SubmissionObserver is told whenever a submission is created, evaluated or
+// graded.
+type SubmissionObserver interface {
+	SubmissionChanged()
+}
+
+// AnalyticsService aggregates submissions into instructor analytics. The
+// aggregation happens in materialised views, which Run refreshes after
+// submission events; course-level access is verified by the handler.
+//
+// Students without a submission are counted among those an assignment is set
+// for: the enrolled students of the course instance in the course access
+// read-model, narrowed to the members of its targeted sections when it has
+// any.
+type AnalyticsService interface {
+	SubmissionObserver
+
+	// AssignmentAnalytics returns the analytics of one assignment.
+	AssignmentAnalytics(assignment *domain.Assignment) (*dto.AssignmentAnalyticsResponse, error)
+
+	// CourseInstanceAnalytics summarises every active assignment of the
+	// course instance.
+	CourseInstanceAnalytics(courseInstanceID uuid.UUID) (*dto.CourseInstanceAnalyticsResponse, error)
+
+	// Run refreshes the views immediately and then after every submission
+	// event until ctx is done. Events arriving within delay of each other
+	// share one refresh.
+	Run(ctx context.Context, delay time.Duration)
+}
+
+type analyticsService struct {
+	repo           repository.AnalyticsRepository
+	assignmentRepo repository.AssignmentRepository
+	// changed holds a pending refresh; its buffer of one coalesces events.
+	changed chan struct{}
+	logger  *zap.Logger
+}
+
+// NewAnalyticsService wires all dependencies and returns an AnalyticsService.
+func NewAnalyticsService(
+	repo repository.AnalyticsRepository,
+	assignmentRepo repository.AssignmentRepository,
+	logger *zap.Logger,
+) AnalyticsService {
+	return &analyticsService{
+		repo:           repo,
+		assignmentRepo: assignmentRepo,
+		changed:        make(chan struct{}, 1),
+		logger:         logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Refresh
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *analyticsService) SubmissionChanged() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *analyticsService) Run(ctx context.Context, delay time.Duration) {
+	for {
+		if err := s.repo.Refresh(); err != nil {
+			s.logger.Warn("submission analytics refresh failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.changed:
+		}
+
+		// Submissions come in bursts around deadlines; wait for the rest of
+		// the burst rather than refreshing once per submission.
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Queries
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *analyticsService) AssignmentAnalytics(assignment *domain.Assignment) (*dto.AssignmentAnalyticsResponse, error) {
+	stats, err := s.repo.SummarizeAssignments([]uuid.UUID{assignment.ID})
+	if err != nil {
+		return nil, utils.ErrInternal("failed to summarise submissions", err)
+	}
+	var summary domain.AssignmentStats
+	if len(stats) > 0 {
+		summary = stats[0]
+	}
+
+	notSubmitted, err := s.repo.ListUnsubmitted(assignment.ID)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to list students without submissions", err)
+	}
+	audiences, err := s.repo.CountAudiences([]uuid.UUID{assignment.ID})
+	if err != nil {
+		return nil, utils.ErrInternal("failed to count the assignment's students", err)
+	}
+	var audience domain.AssignmentAudience
+	if len(audiences) > 0 {
+		audience = audiences[0]
+	}
+
+	resp := &dto.AssignmentAnalyticsResponse{
+		AssignmentSummaryResponse: toAssignmentSummary(assignment, summary, int64(len(notSubmitted))),
+		Enrolled:                  audience.Students,
+		Scores: dto.ScoreDistributionResponse{
+			Min:     summary.MinScore,
+			Max:     summary.MaxScore,
+			StdDev:  summary.StdDevScore,
+			Buckets: []dto.ScoreBucketResponse{},
+		},
+		TestCases:         []dto.TestCaseStatsResponse{},
+		SubmissionTimes:   []dto.SubmissionTimeBucketResponse{},
+		Attempts:          []dto.OwnerAttemptsResponse{},
+		NotSubmittedUsers: notSubmitted,
+	}
+	if resp.NotSubmittedUsers == nil {
+		resp.NotSubmittedUsers = []uuid.UUID{}
+	}
+
+	if resp.Scores.Buckets, err = s.scoreBuckets(assignment.ID, summary); err != nil {
+		return nil, err
+	}
+
+	testCases, err := s.repo.ListTestCaseStats(assignment.ID)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load test case results", err)
+	}
+	for _, tc := range testCases {
+		item := dto.TestCaseStatsResponse{
+			TestCaseID:  tc.TestCaseID,
+			Description: tc.Description,
+			IsHidden:    tc.IsHidden,
+			Runs:        tc.Runs,
+			Passed:      tc.Passed,
+			Failed:      tc.Runs - tc.Passed,
+		}
+		if tc.Runs > 0 {
+			item.PassRate = float64(tc.Passed) / float64(tc.Runs)
+		}
+		resp.TestCases = append(resp.TestCases, item)
+	}
+
+	timing, err := s.repo.ListSubmissionTiming(assignment.ID)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load submission times", err)
+	}
+	for _, b := range timing {
+		resp.SubmissionTimes = append(resp.SubmissionTimes, dto.SubmissionTimeBucketResponse{
+			HoursFromDue: b.HoursFromDue,
+			Submissions:  b.Submissions,
+		})
+	}
+
+	owners, err := s.repo.ListOwnerStats(assignment.ID)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load attempts", err)
+	}
+	for _, o := range owners {
+		item := dto.OwnerAttemptsResponse{
+			Attempts:        o.Attempts,
+			LastSubmittedAt: o.LastSubmittedAt,
+			Score:           o.Score,
+			AvgExecutionMs:  o.AvgExecutionMs,
+			AvgMemoryKB:     o.AvgMemoryKB,
+		}
+		ownerID := o.OwnerID
+		if o.IsGroup {
+			item.GroupID = &ownerID
+		} else {
+			item.UserID = &ownerID
+		}
+		resp.Attempts = append(resp.Attempts, item)
+	}
+
+	if resp.RefreshedAt, err = s.refreshedAt(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *analyticsService) CourseInstanceAnalytics(courseInstanceID uuid.UUID) (*dto.CourseInstanceAnalyticsResponse, error) {
+	assignments, err := s.assignmentRepo.ListAssignmentsByCourseInstance(courseInstanceID)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to list assignments", err)
+	}
+
+	ids := make([]uuid.UUID, len(assignments))
+	for i, a := range assignments {
+		ids[i] = a.ID
+	}
+	stats, err := s.repo.SummarizeAssignments(ids)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to summarise submissions", err)
+	}
+	byAssignment := make(map[uuid.UUID]domain.AssignmentStats, len(stats))
+	for _, st := range stats {
+		byAssignment[st.AssignmentID] = st
+	}
+
+	audiences, err := s.repo.CountAudiences(ids)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to count students without submissions", err)
+	}
+	notSubmitted := make(map[uuid.UUID]int64, len(audiences))
+	for _, a := range audiences {
+		notSubmitted[a.AssignmentID] = a.NotSubmitted
+	}
+
+	enrolled, err := s.repo.CountEnrolled(courseInstanceID)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to count enrolled students", err)
+	}
+
+	resp := &dto.CourseInstanceAnalyticsResponse{
+		CourseInstanceID: courseInstanceID,
+		Enrolled:         enrolled,
+		Assignments:      make([]dto.AssignmentSummaryResponse, 0, len(assignments)),
+	}
+	for i := range assignments {
+		a := &assignments[i]
+		resp.Assignments = append(resp.Assignments, toAssignmentSummary(a, byAssignment[a.ID], notSubmitted[a.ID]))
+	}
+
+	if resp.RefreshedAt, err = s.refreshedAt(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// scoreBuckets splits the range of the graded scores into equal buckets. A
+// single score value makes a single bucket.
+func (s *analyticsService) scoreBuckets(assignmentID uuid.UUID, stats domain.AssignmentStats) ([]dto.ScoreBucketResponse, error) {
+	if stats.Graded == 0 || stats.MinScore == nil || stats.MaxScore == nil {
+		return []dto.ScoreBucketResponse{}, nil
+	}
+	lo, hi := *stats.MinScore, *stats.MaxScore
+	if hi <= lo {
+		return []dto.ScoreBucketResponse{{From: lo, To: hi, Count: stats.Graded}}, nil
+	}
+
+	counts, err := s.repo.ScoreHistogram(assignmentID, lo, hi, scoreHistogramBuckets)
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load score distribution", err)
+	}
+	byBucket := make(map[int]int64, len(counts))
+	for _, c := range counts {
+		byBucket[c.Bucket] = c.Count
+	}
+
+	width := (hi - lo) / scoreHistogramBuckets
+	buckets := make([]dto.ScoreBucketResponse, scoreHistogramBuckets)
+	for i := range buckets {
+		buckets[i] = dto.ScoreBucketResponse{
+			From:  lo + float64(i)*width,
+			To:    lo + float64(i+1)*width,
+			Count: byBucket[i+1],
+		}
+	}
+	buckets[scoreHistogramBuckets-1].To = hi
+	return buckets, nil
+}
+
+func (s *analyticsService) refreshedAt() (*time.Time, error) {
+	state, err := s.repo.GetState()
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load analytics state", err)
+	}
+	return state.ReconciledAt, nil
+}
+
+func toAssignmentSummary(a *domain.Assignment, stats domain.AssignmentStats, notSubmitted int64) dto.AssignmentSummaryResponse {
+	return dto.AssignmentSummaryResponse{
+		AssignmentID:    a.ID,
+		Title:           a.Title,
+		DueAt:           a.DueAt,
+		Submitted:       stats.Owners,
+		NotSubmitted:    notSubmitted,
+		Graded:          stats.Graded,
+		MeanScore:       stats.MeanScore,
+		MedianScore:     stats.MedianScore,
+		MeanAttempts:    stats.MeanAttempts,
+		LateSubmissions: stats.LateSubmissions,
+		AvgExecutionMs:  stats.AvgExecutionMs,
+		AvgMemoryKB:     stats.AvgMemoryKB,
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeAnalyticsRepository serves fixed audiences and students without
+// submissions, and records the assignments each audience query covered.
+type fakeAnalyticsRepository struct {
+	repository.AnalyticsRepository
+	audiences       map[uuid.UUID]domain.AssignmentAudience
+	unsubmitted     map[uuid.UUID][]uuid.UUID
+	enrolled        int64
+	audienceQueries [][]uuid.UUID
+}
+
+func (r *fakeAnalyticsRepository) SummarizeAssignments([]uuid.UUID) ([]domain.AssignmentStats, error) {
+	return nil, nil
+}
+
+func (r *fakeAnalyticsRepository) CountAudiences(ids []uuid.UUID) ([]domain.AssignmentAudience, error) {
+	r.audienceQueries = append(r.audienceQueries, ids)
+	var audiences []domain.AssignmentAudience
+	for _, id := range ids {
+		if a, ok := r.audiences[id]; ok {
+			audiences = append(audiences, a)
+		}
+	}
+	return audiences, nil
+}
+
+func (r *fakeAnalyticsRepository) ListUnsubmitted(id uuid.UUID) ([]uuid.UUID, error) {
+	return r.unsubmitted[id], nil
+}
+
+func (r *fakeAnalyticsRepository) CountEnrolled(uuid.UUID) (int64, error) {
+	return r.enrolled, nil
+}
+
+func (r *fakeAnalyticsRepository) ListTestCaseStats(uuid.UUID) ([]domain.TestCaseStats, error) {
+	return nil, nil
+}
+
+func (r *fakeAnalyticsRepository) ListSubmissionTiming(uuid.UUID) ([]domain.SubmissionTimingBucket, error) {
+	return nil, nil
+}
+
+func (r *fakeAnalyticsRepository) ListOwnerStats(uuid.UUID) ([]domain.SubmissionOwnerStats, error) {
+	return nil, nil
+}
+
+func (r *fakeAnalyticsRepository) GetState() (*domain.ProjectionState, error) {
+	return &domain.ProjectionState{}, nil
+}
+
+func TestCourseInstanceAnalytics_CountsUnsubmittedInOneQuery(t *testing.T) {
+	course := uuid.New()
+	everyone := domain.Assignment{ID: uuid.New(), CourseInstanceID: course, Title: "Lab 1"}
+	labA := domain.Assignment{
+		ID: uuid.New(), CourseInstanceID: course, Title: "Lab 2",
+		Sections: []domain.AssignmentSection{{SectionID: uuid.New()}},
+	}
+	nobody := domain.Assignment{
+		ID: uuid.New(), CourseInstanceID: course, Title: "Lab 3",
+		Sections: []domain.AssignmentSection{{SectionID: uuid.New()}},
+	}
+
+	repo := &fakeAnalyticsRepository{
+		enrolled: 40,
+		audiences: map[uuid.UUID]domain.AssignmentAudience{
+			everyone.ID: {AssignmentID: everyone.ID, Students: 40, NotSubmitted: 12},
+			labA.ID:     {AssignmentID: labA.ID, Students: 15, NotSubmitted: 3},
+		},
+	}
+	assignments := &fakeAssignmentRepository{byInstance: map[uuid.UUID][]domain.Assignment{
+		course: {everyone, labA, nobody},
+	}}
+	s := NewAnalyticsService(repo, assignments, zap.NewNop())
+
+	resp, err := s.CourseInstanceAnalytics(course)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.audienceQueries) != 1 || len(repo.audienceQueries[0]) != 3 {
+		t.Fatalf("expected one audience query covering 3 assignments, got %v", repo.audienceQueries)
+	}
+	if resp.Enrolled != 40 {
+		t.Errorf("expected 40 enrolled, got %d", resp.Enrolled)
+	}
+	want := map[uuid.UUID]int64{everyone.ID: 12, labA.ID: 3, nobody.ID: 0}
+	for _, a := range resp.Assignments {
+		if a.NotSubmitted != want[a.AssignmentID] {
+			t.Errorf("%s: expected %d not submitted, got %d", a.Title, want[a.AssignmentID], a.NotSubmitted)
+		}
+	}
+}
+
+func TestAssignmentAnalytics_CountsTargetedStudents(t *testing.T) {
+	assignment := &domain.Assignment{
+		ID: uuid.New(), CourseInstanceID: uuid.New(), Title: "Lab 2",
+		Sections: []domain.AssignmentSection{{SectionID: uuid.New()}},
+	}
+	missing := []uuid.UUID{uuid.New(), uuid.New()}
+	repo := &fakeAnalyticsRepository{
+		enrolled: 40,
+		audiences: map[uuid.UUID]domain.AssignmentAudience{
+			assignment.ID: {AssignmentID: assignment.ID, Students: 15, NotSubmitted: 2},
+		},
+		unsubmitted: map[uuid.UUID][]uuid.UUID{assignment.ID: missing},
+	}
+	s := NewAnalyticsService(repo, &fakeAssignmentRepository{}, zap.NewNop())
+
+	resp, err := s.AssignmentAnalytics(assignment)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Enrolled != 15 {
+		t.Errorf("expected the 15 students in the targeted section, got %d", resp.Enrolled)
+	}
+	if resp.NotSubmitted != 2 || len(resp.NotSubmittedUsers) != 2 {
+		t.Errorf("expected 2 students without submissions, got %d (%v)", resp.NotSubmitted, resp.NotSubmittedUsers)
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AssignmentCloneService copies assignments between course instances when
+// the Academic Service rolls a semester over to the next term.
+type AssignmentCloneService interface {
+	CloneAssignments(req *dto.CloneAssignmentsRequest) (*dto.CloneAssignmentsResponse, error)
+}
+
+// assignmentCloneService is the concrete implementation.
+type assignmentCloneService struct {
+	assignmentRepo repository.AssignmentRepository
+	contentRepo    repository.AssignmentContentRepository
+	codeRepo       repository.CodeRepository
+	logger         *zap.Logger
+}
+
+// NewAssignmentCloneService wires all dependencies together.
+func NewAssignmentCloneService(
+	assignmentRepo repository.AssignmentRepository,
+	contentRepo repository.AssignmentContentRepository,
+	codeRepo repository.CodeRepository,
+	logger *zap.Logger,
+) AssignmentCloneService {
+	return &assignmentCloneService{
+		assignmentRepo: assignmentRepo,
+		contentRepo:    contentRepo,
+		codeRepo:       codeRepo,
+		logger:         logger,
+	}
+}
+
+// CloneAssignments copies every active assignment of each source course
+// instance, with its rubric, test cases, sample answer and code config, into
+// the mapped target instance. Release and due dates move by OffsetDays.
+// Section targets are dropped, as sections belong to the source instance.
+// Assignments whose title already exists in the target are skipped.
+func (s *assignmentCloneService) CloneAssignments(req *dto.CloneAssignmentsRequest) (*dto.CloneAssignmentsResponse, error) {
+	if len(req.Mappings) == 0 {
+		return nil, utils.ErrBadRequest("mappings is required")
+	}
+	for _, m := range req.Mappings {
+		if m.SourceCourseInstanceID == uuid.Nil {
+			return nil, utils.ErrBadRequest("source_course_instance_id is required")
+		}
+		if m.TargetCourseInstanceID == uuid.Nil && !req.DryRun {
+			return nil, utils.ErrBadRequest("target_course_instance_id is required")
+		}
+	}
+
+	resp := &dto.CloneAssignmentsResponse{
+		DryRun:      req.DryRun,
+		Assignments: make([]dto.ClonedAssignmentResponse, 0),
+	}
+
+	for _, m := range req.Mappings {
+		sources, err := s.assignmentRepo.ListAssignmentsByCourseInstance(m.SourceCourseInstanceID)
+		if err != nil {
+			s.logger.Error("failed to list source assignments", zap.Error(err))
+			return nil, utils.ErrInternal("failed to list source assignments", err)
+		}
+
+		existingTitles := make(map[string]bool)
+		if m.TargetCourseInstanceID != uuid.Nil {
+			existing, err := s.assignmentRepo.ListAssignmentsByCourseInstance(m.TargetCourseInstanceID)
+			if err != nil {
+				s.logger.Error("failed to list target assignments", zap.Error(err))
+				return nil, utils.ErrInternal("failed to list target assignments", err)
+			}
+			for _, a := range existing {
+				existingTitles[a.Title] = true
+			}
+		}
+
+		for i := range sources {
+			src := &sources[i]
+			item := dto.ClonedAssignmentResponse{
+				SourceAssignmentID:     src.ID,
+				SourceCourseInstanceID: m.SourceCourseInstanceID,
+				TargetCourseInstanceID: m.TargetCourseInstanceID,
+				Title:                  src.Title,
+				ReleaseAt:              shiftDays(src.ReleaseAt, req.OffsetDays),
+				DueAt:                  shiftDays(src.DueAt, req.OffsetDays),
+				SectionsDropped:        len(src.Sections),
+			}
+
+			if existingTitles[src.Title] {
+				item.Skipped = true
+				resp.Skipped++
+				resp.Assignments = append(resp.Assignments, item)
+				continue
+			}
+
+			if !req.DryRun {
+				clone, err := s.cloneAssignment(src, m.TargetCourseInstanceID, req.OffsetDays)
+				if err != nil {
+					s.logger.Error("failed to clone assignment",
+						zap.String("source_assignment_id", src.ID.String()),
+						zap.Error(err),
+					)
+					return nil, utils.ErrInternal("failed to clone assignment "+src.Title, err)
+				}
+				item.TargetAssignmentID = &clone.ID
+			}
+
+			resp.Cloned++
+			resp.Assignments = append(resp.Assignments, item)
+		}
+	}
+
+	if !req.DryRun {
+		s.logger.Info("assignments cloned",
+			zap.Int("cloned", resp.Cloned),
+			zap.Int("skipped", resp.Skipped),
+			zap.Int("offset_days", req.OffsetDays),
+			zap.String("requested_by", req.RequestedBy),
+		)
+	}
+	return resp, nil
+}
+
+// cloneAssignment copies src and its content into the target course instance.
+func (s *assignmentCloneService) cloneAssignment(src *domain.Assignment, targetInstanceID uuid.UUID, offsetDays int) (*domain.Assignment, error) {
+	clone := *src
+	clone.ID = uuid.Nil
+	clone.CourseInstanceID = targetInstanceID
+	clone.ReleaseAt = shiftDays(src.ReleaseAt, offsetDays)
+	clone.DueAt = shiftDays(src.DueAt, offsetDays)
+	clone.LateDueAt = shiftDays(src.LateDueAt, offsetDays)
+	clone.CreatedAt = time.Time{}
+	clone.UpdatedAt = time.Time{}
+	clone.Sections = nil
+
+	criteria, err := s.contentRepo.ListRubricCriteria(src.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range criteria {
+		criteria[i].ID = uuid.Nil
+		criteria[i].CreatedAt = time.Time{}
+		criteria[i].UpdatedAt = time.Time{}
+	}
+
+	testCases, err := s.contentRepo.ListTestCases(src.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range testCases {
+		testCases[i].ID = uuid.Nil
+		testCases[i].CreatedAt = time.Time{}
+	}
+
+	answer, err := s.contentRepo.GetSampleAnswer(src.ID)
+	if err != nil {
+		return nil, err
+	}
+	if answer != nil {
+		answer.ID = uuid.Nil
+		answer.CreatedAt = time.Time{}
+		answer.UpdatedAt = time.Time{}
+	}
+
+	codeConfig, err := s.codeRepo.GetAssignmentCodeConfig(src.ID)
+	if err != nil {
+		return nil, err
+	}
+	if codeConfig != nil {
+		codeConfig.ID = uuid.Nil
+		codeConfig.CreatedAt = time.Time{}
+		codeConfig.UpdatedAt = time.Time{}
+	}
+
+	if err := s.assignmentRepo.CloneAssignment(&clone, criteria, testCases, answer, codeConfig); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+// shiftDays returns a copy of t moved by days, or nil when t is nil.
+func shiftDays(t *time.Time, days int) *time.Time {
+	if t == nil {
+		return nil
+	}
+	shifted := t.AddDate(0, 0, days)
+	return &shifted
+}
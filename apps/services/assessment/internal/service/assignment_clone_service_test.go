@@ -0,0 +1,209 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeAssignmentRepository serves assignments per course instance, with
+// their sections loaded as the GORM repository preloads them, and records
+// the clones it is asked to insert.
+type fakeAssignmentRepository struct {
+	repository.AssignmentRepository
+	byInstance map[uuid.UUID][]domain.Assignment
+	cloned     []domain.Assignment
+	testCases  [][]domain.AssignmentTestCase
+}
+
+func (r *fakeAssignmentRepository) ListAssignmentsByCourseInstance(id uuid.UUID) ([]domain.Assignment, error) {
+	// Copy so the service cannot alias the stored slice.
+	return append([]domain.Assignment(nil), r.byInstance[id]...), nil
+}
+
+func (r *fakeAssignmentRepository) CloneAssignment(a *domain.Assignment, _ []domain.AssignmentRubricCriterion, testCases []domain.AssignmentTestCase, _ *domain.AssignmentSampleAnswer, _ *domain.AssignmentCodeConfig) error {
+	a.ID = uuid.New()
+	r.cloned = append(r.cloned, *a)
+	r.testCases = append(r.testCases, testCases)
+	return nil
+}
+
+// fakeContentRepository has no content unless testCases is set.
+type fakeContentRepository struct {
+	repository.AssignmentContentRepository
+	testCases map[uuid.UUID][]domain.AssignmentTestCase
+}
+
+func (r *fakeContentRepository) ListRubricCriteria(uuid.UUID) ([]domain.AssignmentRubricCriterion, error) {
+	return nil, nil
+}
+
+func (r *fakeContentRepository) ListTestCases(id uuid.UUID) ([]domain.AssignmentTestCase, error) {
+	return append([]domain.AssignmentTestCase(nil), r.testCases[id]...), nil
+}
+
+func (r *fakeContentRepository) GetSampleAnswer(uuid.UUID) (*domain.AssignmentSampleAnswer, error) {
+	return nil, nil
+}
+
+type fakeCodeRepository struct {
+	repository.CodeRepository
+}
+
+func (r *fakeCodeRepository) GetAssignmentCodeConfig(uuid.UUID) (*domain.AssignmentCodeConfig, error) {
+	return nil, nil
+}
+
+func TestCloneAssignments_DropsPreloadedSections(t *testing.T) {
+	source, target := uuid.New(), uuid.New()
+	src := domain.Assignment{
+		ID:               uuid.New(),
+		CourseInstanceID: source,
+		Title:            "Lab 1",
+		Sections: []domain.AssignmentSection{
+			{SectionID: uuid.New()},
+			{SectionID: uuid.New()},
+		},
+	}
+	src.Sections[0].AssignmentID = src.ID
+	src.Sections[1].AssignmentID = src.ID
+
+	assignments := &fakeAssignmentRepository{byInstance: map[uuid.UUID][]domain.Assignment{source: {src}}}
+	s := NewAssignmentCloneService(assignments, &fakeContentRepository{}, &fakeCodeRepository{}, zap.NewNop())
+
+	resp, err := s.CloneAssignments(&dto.CloneAssignmentsRequest{
+		Mappings: []dto.CourseInstanceMapping{{SourceCourseInstanceID: source, TargetCourseInstanceID: target}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(assignments.cloned) != 1 {
+		t.Fatalf("expected one clone, got %d", len(assignments.cloned))
+	}
+	if sections := assignments.cloned[0].Sections; len(sections) != 0 {
+		t.Errorf("expected the clone to have no section targets, got %+v", sections)
+	}
+	if got := resp.Assignments[0].SectionsDropped; got != 2 {
+		t.Errorf("expected 2 dropped sections reported, got %d", got)
+	}
+	if len(assignments.byInstance[source][0].Sections) != 2 {
+		t.Error("source assignment sections were modified")
+	}
+}
+
+func TestCloneAssignments_ShiftsDatesAndCopiesTestCases(t *testing.T) {
+	source, target := uuid.New(), uuid.New()
+	at := func(s string) *time.Time {
+		tm, _ := time.Parse(time.RFC3339, s)
+		return &tm
+	}
+	src := domain.Assignment{
+		ID:               uuid.New(),
+		CourseInstanceID: source,
+		Title:            "Lab 2",
+		ReleaseAt:        at("2026-02-02T09:00:00Z"),
+		DueAt:            at("2026-02-16T23:59:00Z"),
+		CreatedAt:        time.Now(),
+	}
+	existing := domain.Assignment{ID: uuid.New(), CourseInstanceID: target, Title: "Lab 1"}
+	testCases := []domain.AssignmentTestCase{
+		{ID: uuid.New(), AssignmentID: src.ID, Input: "1", ExpectedOutput: "2", OrderIndex: 0, CreatedAt: time.Now()},
+		{ID: uuid.New(), AssignmentID: src.ID, Input: "2", ExpectedOutput: "4", IsHidden: true, OrderIndex: 1, CreatedAt: time.Now()},
+	}
+
+	assignments := &fakeAssignmentRepository{byInstance: map[uuid.UUID][]domain.Assignment{
+		source: {{ID: uuid.New(), CourseInstanceID: source, Title: "Lab 1"}, src},
+		target: {existing},
+	}}
+	content := &fakeContentRepository{testCases: map[uuid.UUID][]domain.AssignmentTestCase{src.ID: testCases}}
+	s := NewAssignmentCloneService(assignments, content, &fakeCodeRepository{}, zap.NewNop())
+
+	resp, err := s.CloneAssignments(&dto.CloneAssignmentsRequest{
+		Mappings:   []dto.CourseInstanceMapping{{SourceCourseInstanceID: source, TargetCourseInstanceID: target}},
+		OffsetDays: 182,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Cloned != 1 || resp.Skipped != 1 || !resp.Assignments[0].Skipped {
+		t.Fatalf("expected Lab 1 skipped and Lab 2 cloned, got %+v", resp)
+	}
+
+	clone := assignments.cloned[0]
+	if clone.CourseInstanceID != target || clone.ID == src.ID || !clone.CreatedAt.IsZero() {
+		t.Errorf("clone not reset for the target: %+v", clone)
+	}
+	if want := at("2026-08-03T09:00:00Z"); !clone.ReleaseAt.Equal(*want) {
+		t.Errorf("expected release %v, got %v", want, clone.ReleaseAt)
+	}
+	if want := at("2026-08-17T23:59:00Z"); !clone.DueAt.Equal(*want) || !resp.Assignments[1].DueAt.Equal(*want) {
+		t.Errorf("expected due %v, got %v (reported %v)", want, clone.DueAt, resp.Assignments[1].DueAt)
+	}
+	if clone.LateDueAt != nil {
+		t.Errorf("expected no late due date, got %v", clone.LateDueAt)
+	}
+	if !src.DueAt.Equal(*at("2026-02-16T23:59:00Z")) {
+		t.Error("source due date was modified")
+	}
+
+	copied := assignments.testCases[0]
+	if len(copied) != len(testCases) {
+		t.Fatalf("expected %d test cases, got %d", len(testCases), len(copied))
+	}
+	for i, tc := range copied {
+		if tc.ID != uuid.Nil || !tc.CreatedAt.IsZero() {
+			t.Errorf("test case %d keeps its source identity: %+v", i, tc)
+		}
+		if tc.Input != testCases[i].Input || tc.ExpectedOutput != testCases[i].ExpectedOutput ||
+			tc.IsHidden != testCases[i].IsHidden || tc.OrderIndex != testCases[i].OrderIndex {
+			t.Errorf("test case %d not copied: %+v", i, tc)
+		}
+	}
+}
+
+func TestCloneAssignments_DryRunReportsWithoutCloning(t *testing.T) {
+	source := uuid.New()
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	assignments := &fakeAssignmentRepository{byInstance: map[uuid.UUID][]domain.Assignment{
+		source: {{
+			ID: uuid.New(), CourseInstanceID: source, Title: "Exam", DueAt: &due,
+			Sections: []domain.AssignmentSection{{SectionID: uuid.New()}},
+		}},
+	}}
+	s := NewAssignmentCloneService(assignments, &fakeContentRepository{}, &fakeCodeRepository{}, zap.NewNop())
+
+	resp, err := s.CloneAssignments(&dto.CloneAssignmentsRequest{
+		Mappings:   []dto.CourseInstanceMapping{{SourceCourseInstanceID: source}},
+		OffsetDays: -7,
+		DryRun:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(assignments.cloned) != 0 {
+		t.Errorf("dry run cloned %d assignments", len(assignments.cloned))
+	}
+	item := resp.Assignments[0]
+	if resp.Cloned != 1 || item.TargetAssignmentID != nil || item.SectionsDropped != 1 {
+		t.Errorf("unexpected preview: %+v", item)
+	}
+	if want := due.AddDate(0, 0, -7); !item.DueAt.Equal(want) {
+		t.Errorf("expected due %v, got %v", want, item.DueAt)
+	}
+}
+
+func TestCloneAssignments_RequiresTargetOutsideDryRun(t *testing.T) {
+	s := NewAssignmentCloneService(&fakeAssignmentRepository{}, &fakeContentRepository{}, &fakeCodeRepository{}, zap.NewNop())
+
+	_, err := s.CloneAssignments(&dto.CloneAssignmentsRequest{
+		Mappings: []dto.CourseInstanceMapping{{SourceCourseInstanceID: uuid.New()}},
+	})
+	assertAppError(t, err, http.StatusBadRequest)
+}
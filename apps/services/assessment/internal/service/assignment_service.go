@@ -1,11 +1,15 @@
 package service
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/client"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/datatypes"
@@ -72,6 +76,7 @@ type AssignmentService interface {
 	GetAssignmentByID(id uuid.UUID) (*domain.Assignment, error)
 	UpdateAssignment(id uuid.UUID, req *dto.UpdateAssignmentRequest, username, ipAddress, userAgent string) (*domain.Assignment, error)
 	ListAssignmentsByCourseInstance(courseInstanceID uuid.UUID) ([]domain.Assignment, error)
+	ListAssignmentsPage(courseInstanceID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Assignment], error)
 
 	// Content sub-resources
 	GetAssignmentRubric(assignmentID uuid.UUID) ([]domain.AssignmentRubricCriterion, error)
@@ -84,20 +89,25 @@ type AssignmentService interface {
 type assignmentService struct {
 	assignmentRepo repository.AssignmentRepository
 	contentRepo    repository.AssignmentContentRepository
+	academicClient *client.AcademicClient
 	auditClient    *client.AuditClient
 	logger         *zap.Logger
 }
 
 // NewAssignmentService wires all dependencies and returns an AssignmentService.
+// academicClient is used to check section targets against the course
+// instance's sections.
 func NewAssignmentService(
 	assignmentRepo repository.AssignmentRepository,
 	contentRepo repository.AssignmentContentRepository,
+	academicClient *client.AcademicClient,
 	auditClient *client.AuditClient,
 	logger *zap.Logger,
 ) AssignmentService {
 	return &assignmentService{
 		assignmentRepo: assignmentRepo,
 		contentRepo:    contentRepo,
+		academicClient: academicClient,
 		auditClient:    auditClient,
 		logger:         logger,
 	}
@@ -127,6 +137,12 @@ func (s *assignmentService) CreateAssignment(
 		}
 	}
 
+	// 2b. Section targets must belong to the course instance
+	sections, err := s.resolveSections(req.CourseInstanceID, req.Sections, req.DueAt, req.LateDueAt)
+	if err != nil {
+		return nil, err
+	}
+
 	// 3. Group submission validation
 	maxGroupSize := 1
 	if req.MaxGroupSize != nil {
@@ -188,6 +204,8 @@ func (s *assignmentService) CreateAssignment(
 
 		IsActive:  true,
 		CreatedBy: createdBy,
+
+		Sections: sections,
 	}
 
 	// Propagate language: top-level req.LanguageID (from the "Programming
@@ -282,6 +300,7 @@ func (s *assignmentService) CreateAssignment(
 		"enable_socratic_feedback": enableSocratic,
 		"allow_regenerate":         allowRegenerate,
 		"is_active":                true,
+		"sections":                 len(sections),
 	}
 	if auditErr := s.auditClient.LogAction(
 		string(client.AuditActionAssignmentCreated),
@@ -359,6 +378,15 @@ func (s *assignmentService) UpdateAssignment(
 		}
 	}
 
+	// 3b. Section targets, checked against the effective dates
+	var sections []domain.AssignmentSection
+	if req.Sections != nil {
+		sections, err = s.resolveSections(assignment.CourseInstanceID, req.Sections, effectiveDueAt, effectiveLateDueAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// 4. Determine effective group-submission settings
 	effectiveAllowGroup := assignment.AllowGroupSubmission
 	effectiveMaxGroupSize := assignment.MaxGroupSize
@@ -457,6 +485,15 @@ func (s *assignmentService) UpdateAssignment(
 		return nil, utils.ErrInternal("failed to update assignment", err)
 	}
 
+	if req.Sections != nil {
+		if err := s.assignmentRepo.ReplaceSections(assignment.ID, sections); err != nil {
+			s.logger.Error("failed to update assignment sections", zap.String("id", id.String()), zap.Error(err))
+			return nil, utils.ErrInternal("failed to update assignment sections", err)
+		}
+		changes["sections"] = map[string]int{"from": len(assignment.Sections), "to": len(sections)}
+		assignment.Sections = sections
+	}
+
 	// 9. Emit the appropriate audit action
 	auditAction := string(client.AuditActionAssignmentUpdated)
 	if isDeactivation {
@@ -486,6 +523,71 @@ func (s *assignmentService) UpdateAssignment(
 	return assignment, nil
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Section targets
+// ─────────────────────────────────────────────────────────────────────────────
+
+// resolveSections validates the requested section targets against the
+// course instance's sections in the Academic Service and the assignment's
+// effective due dates. It returns an empty, non-nil slice for no targets.
+func (s *assignmentService) resolveSections(
+	courseInstanceID uuid.UUID,
+	reqs []dto.AssignmentSectionRequest,
+	dueAt, lateDueAt *time.Time,
+) ([]domain.AssignmentSection, error) {
+	sections := make([]domain.AssignmentSection, 0, len(reqs))
+	if len(reqs) == 0 {
+		return sections, nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(reqs))
+	for _, r := range reqs {
+		if r.SectionID == uuid.Nil {
+			return nil, utils.ErrBadRequest("sections: section_id is required")
+		}
+		if seen[r.SectionID] {
+			return nil, utils.ErrBadRequest(fmt.Sprintf("sections: section %s is listed twice", r.SectionID))
+		}
+		seen[r.SectionID] = true
+
+		due, late := dueAt, lateDueAt
+		if r.DueAt != nil {
+			due = r.DueAt
+		}
+		if r.LateDueAt != nil {
+			late = r.LateDueAt
+		}
+		if due != nil && late != nil && late.Before(*due) {
+			return nil, utils.ErrBadRequest(fmt.Sprintf("sections: late_due_at for section %s must be on or after its due_at", r.SectionID))
+		}
+
+		sections = append(sections, domain.AssignmentSection{
+			SectionID: r.SectionID,
+			DueAt:     r.DueAt,
+			LateDueAt: r.LateDueAt,
+		})
+	}
+
+	// Unlike the enrollment gate this check fails closed: a target that
+	// cannot be verified might hide the assignment from every student.
+	known, err := s.academicClient.ListSections(courseInstanceID.String(), "")
+	if err != nil {
+		s.logger.Error("failed to list course sections", zap.String("course_instance_id", courseInstanceID.String()), zap.Error(err))
+		return nil, utils.ErrInternal("failed to verify sections with the academic service", err)
+	}
+	valid := make(map[uuid.UUID]bool, len(known))
+	for _, section := range known {
+		valid[section.ID] = true
+	}
+	for _, section := range sections {
+		if !valid[section.SectionID] {
+			return nil, utils.ErrBadRequest(fmt.Sprintf("sections: section %s does not belong to this course instance", section.SectionID))
+		}
+	}
+
+	return sections, nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // ListAssignmentsByCourseInstance
 // ─────────────────────────────────────────────────────────────────────────────
@@ -507,6 +609,23 @@ func (s *assignmentService) ListAssignmentsByCourseInstance(courseInstanceID uui
 	return assignments, nil
 }
 
+func (s *assignmentService) ListAssignmentsPage(courseInstanceID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Assignment], error) {
+	if courseInstanceID == uuid.Nil {
+		return queryspec.Page[domain.Assignment]{}, utils.ErrBadRequest("course_instance_id is required")
+	}
+
+	page, err := s.assignmentRepo.ListAssignmentsPage(courseInstanceID, spec)
+	if err != nil {
+		s.logger.Error("failed to list assignments",
+			zap.String("course_instance_id", courseInstanceID.String()),
+			zap.Error(err),
+		)
+		return queryspec.Page[domain.Assignment]{}, utils.ErrInternal("failed to list assignments", err)
+	}
+
+	return page, nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Content sub-resource getters
 // ─────────────────────────────────────────────────────────────────────────────
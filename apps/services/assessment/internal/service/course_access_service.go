@@ -0,0 +1,567 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/queue"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CourseAccessService answers enrollment and course-staff questions from a
+// local read-model of the Academic Service, so that submissions keep working
+// while the Academic Service is unavailable.
+//
+// The read-model is fed by academic domain events and periodically
+// reconciled against a full snapshot. Lookups the read-model cannot answer —
+// a missing row, or a per-user list while the read-model is stale — go to
+// the Academic Service through a short-lived TTL cache.
+type CourseAccessService interface {
+	// IsEnrolled reports whether the user is actively enrolled in the course
+	// instance. It has the same contract as client.AcademicClient.IsEnrolled.
+	IsEnrolled(userID, courseInstanceID string) (bool, error)
+
+	// StudentCourses lists the user's course enrollments. token is the
+	// user's own token, used only when the Academic Service is asked.
+	StudentCourses(userID uuid.UUID, token string) ([]client.StudentCourseItem, error)
+
+	// InstructorCourses lists the user's course staff assignments. token is
+	// used as in StudentCourses.
+	InstructorCourses(userID uuid.UUID, token string) ([]client.CourseInstructorItem, error)
+
+	// StudentSections lists the IDs of the course sections and lab groups the
+	// user belongs to in the course instance. The read-model's section
+	// memberships only feed analytics; this always asks the Academic Service
+	// through the cache.
+	StudentSections(userID, courseInstanceID uuid.UUID) ([]uuid.UUID, error)
+
+	// HandleEvent applies an academic domain event to the read-model.
+	HandleEvent(ctx context.Context, event queue.AcademicEvent) error
+
+	// Reconcile rebuilds the read-model from an Academic Service snapshot.
+	Reconcile() error
+
+	// Run reconciles immediately and then on the given interval until ctx is
+	// done.
+	Run(ctx context.Context, interval time.Duration)
+
+	// Status reports the read-model's freshness and usage counters.
+	Status() (*dto.CourseAccessStatusResponse, error)
+}
+
+// Academic event types applied to the read-model.
+const (
+	academicEventEnrollmentCreated       = "enrollment.created"
+	academicEventEnrollmentStatusChanged = "enrollment.status_changed"
+	academicEventEnrollmentDropped       = "enrollment.dropped"
+	academicEventInstructorAssigned      = "instructor.assigned"
+	academicEventInstructorRemoved       = "instructor.removed"
+	academicEventSectionMemberAdded      = "section_member.added"
+	academicEventSectionMemberRemoved    = "section_member.removed"
+)
+
+// academicEventPayload covers the payload fields of enrollment, instructor
+// and section membership events.
+type academicEventPayload struct {
+	CourseInstanceID uuid.UUID `json:"course_instance_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	SectionID        uuid.UUID `json:"section_id"`
+	Status           string    `json:"status"`
+	Role             string    `json:"role"`
+	Removed          bool      `json:"removed"`
+}
+
+// courseAccessMetrics are the usage counters reported by Status.
+type courseAccessMetrics struct {
+	eventsApplied   atomic.Int64
+	eventsIgnored   atomic.Int64
+	projectionHits  atomic.Int64
+	cacheHits       atomic.Int64
+	academicCalls   atomic.Int64
+	academicErrors  atomic.Int64
+	staleFallbacks  atomic.Int64
+	reconcileErrors atomic.Int64
+}
+
+// courseAccessService is the concrete implementation.
+type courseAccessService struct {
+	repo           repository.CourseAccessRepository
+	academicClient *client.AcademicClient
+	cache          *ttlCache
+	maxStaleness   time.Duration
+	logger         *zap.Logger
+
+	metrics courseAccessMetrics
+
+	mu                 sync.Mutex
+	lastEventDelay     *time.Duration
+	lastReconcileError string
+}
+
+// NewCourseAccessService wires all dependencies together. cacheTTL bounds how
+// long Academic Service answers are reused; maxStaleness is how old the last
+// reconciliation may be before per-user lists are fetched from the Academic
+// Service again.
+func NewCourseAccessService(
+	repo repository.CourseAccessRepository,
+	academicClient *client.AcademicClient,
+	cacheTTL time.Duration,
+	maxStaleness time.Duration,
+	logger *zap.Logger,
+) CourseAccessService {
+	return &courseAccessService{
+		repo:           repo,
+		academicClient: academicClient,
+		cache:          newTTLCache(cacheTTL),
+		maxStaleness:   maxStaleness,
+		logger:         logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Lookups
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *courseAccessService) IsEnrolled(userID, courseInstanceID string) (bool, error) {
+	uid, uidErr := uuid.Parse(userID)
+	ciid, ciErr := uuid.Parse(courseInstanceID)
+	if uidErr == nil && ciErr == nil {
+		row, err := s.repo.GetEnrollment(ciid, uid)
+		if err != nil {
+			s.logger.Warn("course access: enrollment lookup failed", zap.Error(err))
+		} else if row != nil {
+			s.metrics.projectionHits.Add(1)
+			return !row.Removed && row.Status == domain.EnrollmentStatusEnrolled, nil
+		}
+	}
+
+	// Not in the read-model yet — e.g. the enrollment predates it or its
+	// event is still in flight.
+	key := "enrolled:" + userID + ":" + courseInstanceID
+	if cached, ok := s.cache.get(key); ok {
+		s.metrics.cacheHits.Add(1)
+		return cached.(bool), nil
+	}
+
+	s.metrics.academicCalls.Add(1)
+	enrolled, err := s.academicClient.IsEnrolled(userID, courseInstanceID)
+	if err != nil {
+		s.metrics.academicErrors.Add(1)
+		return false, err
+	}
+	s.cache.set(key, enrolled)
+	return enrolled, nil
+}
+
+func (s *courseAccessService) StudentCourses(userID uuid.UUID, token string) ([]client.StudentCourseItem, error) {
+	fromProjection := func() ([]client.StudentCourseItem, error) {
+		rows, err := s.repo.ListEnrollmentsByUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]client.StudentCourseItem, len(rows))
+		for i, r := range rows {
+			items[i] = client.StudentCourseItem{
+				CourseInstanceID: r.CourseInstanceID.String(),
+				Status:           r.Status,
+			}
+		}
+		return items, nil
+	}
+	fromAcademic := func() ([]client.StudentCourseItem, error) {
+		return s.academicClient.GetStudentCourses(token)
+	}
+
+	return lookupUserList(s, "student:"+userID.String(), fromProjection, fromAcademic)
+}
+
+func (s *courseAccessService) InstructorCourses(userID uuid.UUID, token string) ([]client.CourseInstructorItem, error) {
+	fromProjection := func() ([]client.CourseInstructorItem, error) {
+		rows, err := s.repo.ListInstructorsByUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]client.CourseInstructorItem, len(rows))
+		for i, r := range rows {
+			items[i] = client.CourseInstructorItem{
+				CourseInstanceID: r.CourseInstanceID.String(),
+				UserID:           r.UserID.String(),
+				Role:             r.Role,
+			}
+		}
+		return items, nil
+	}
+	fromAcademic := func() ([]client.CourseInstructorItem, error) {
+		return s.academicClient.GetInstructorCourses(token)
+	}
+
+	return lookupUserList(s, "instructor:"+userID.String(), fromProjection, fromAcademic)
+}
+
+func (s *courseAccessService) StudentSections(userID, courseInstanceID uuid.UUID) ([]uuid.UUID, error) {
+	key := "sections:" + userID.String() + ":" + courseInstanceID.String()
+	if cached, ok := s.cache.get(key); ok {
+		s.metrics.cacheHits.Add(1)
+		return cached.([]uuid.UUID), nil
+	}
+
+	s.metrics.academicCalls.Add(1)
+	sections, err := s.academicClient.ListSections(courseInstanceID.String(), userID.String())
+	if err != nil {
+		s.metrics.academicErrors.Add(1)
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(sections))
+	for i, section := range sections {
+		ids[i] = section.ID
+	}
+	s.cache.set(key, ids)
+	return ids, nil
+}
+
+// lookupUserList serves a per-user list from the read-model while it is
+// fresh. Otherwise it tries the cache and then the Academic Service, and if
+// that fails too, falls back to the stale read-model as long as it has been
+// reconciled at least once.
+func lookupUserList[T any](
+	s *courseAccessService,
+	key string,
+	fromProjection func() ([]T, error),
+	fromAcademic func() ([]T, error),
+) ([]T, error) {
+	state, err := s.repo.GetState()
+	if err != nil {
+		s.logger.Warn("course access: loading projection state failed", zap.Error(err))
+		state = &domain.ProjectionState{}
+	}
+
+	if s.fresh(state) {
+		items, err := fromProjection()
+		if err == nil {
+			s.metrics.projectionHits.Add(1)
+			return items, nil
+		}
+		s.logger.Warn("course access: projection lookup failed", zap.Error(err))
+	}
+
+	if cached, ok := s.cache.get(key); ok {
+		s.metrics.cacheHits.Add(1)
+		return cached.([]T), nil
+	}
+
+	s.metrics.academicCalls.Add(1)
+	items, err := fromAcademic()
+	if err == nil {
+		s.cache.set(key, items)
+		return items, nil
+	}
+	s.metrics.academicErrors.Add(1)
+
+	if state.ReconciledAt != nil {
+		if stale, projErr := fromProjection(); projErr == nil {
+			s.metrics.staleFallbacks.Add(1)
+			s.logger.Warn("course access: academic service unavailable; serving stale read-model",
+				zap.Time("reconciled_at", *state.ReconciledAt),
+				zap.Error(err),
+			)
+			return stale, nil
+		}
+	}
+	return nil, err
+}
+
+// fresh reports whether the read-model has been reconciled recently enough
+// to answer per-user lists on its own.
+func (s *courseAccessService) fresh(state *domain.ProjectionState) bool {
+	return state.ReconciledAt != nil && time.Since(*state.ReconciledAt) <= s.maxStaleness
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Event handling
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *courseAccessService) HandleEvent(_ context.Context, event queue.AcademicEvent) error {
+	var payload academicEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		// A payload that does not parse never will; drop it and let the next
+		// reconciliation catch up.
+		s.logger.Error("course access: malformed academic event payload",
+			zap.Int64("sequence", event.Sequence),
+			zap.String("event_type", event.EventType),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	var (
+		applied bool
+		err     error
+	)
+	switch event.EventType {
+	case academicEventEnrollmentCreated, academicEventEnrollmentStatusChanged, academicEventEnrollmentDropped:
+		applied, err = s.repo.ApplyEnrollment(&domain.EnrollmentProjection{
+			CourseInstanceID: payload.CourseInstanceID,
+			UserID:           payload.UserID,
+			Status:           payload.Status,
+			Removed:          payload.Removed,
+			Sequence:         event.Sequence,
+		})
+		s.cache.delete("enrolled:" + payload.UserID.String() + ":" + payload.CourseInstanceID.String())
+		s.cache.delete("student:" + payload.UserID.String())
+	case academicEventInstructorAssigned, academicEventInstructorRemoved:
+		applied, err = s.repo.ApplyInstructor(&domain.InstructorProjection{
+			CourseInstanceID: payload.CourseInstanceID,
+			UserID:           payload.UserID,
+			Role:             payload.Role,
+			Removed:          event.EventType == academicEventInstructorRemoved,
+			Sequence:         event.Sequence,
+		})
+		s.cache.delete("instructor:" + payload.UserID.String())
+	case academicEventSectionMemberAdded, academicEventSectionMemberRemoved:
+		applied, err = s.repo.ApplySectionMember(&domain.SectionMemberProjection{
+			CourseInstanceID: payload.CourseInstanceID,
+			UserID:           payload.UserID,
+			SectionID:        payload.SectionID,
+			Removed:          event.EventType == academicEventSectionMemberRemoved,
+			Sequence:         event.Sequence,
+		})
+		s.cache.delete("sections:" + payload.UserID.String() + ":" + payload.CourseInstanceID.String())
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("applying %s event %d: %w", event.EventType, event.Sequence, err)
+	}
+
+	if applied {
+		s.metrics.eventsApplied.Add(1)
+	} else {
+		s.metrics.eventsIgnored.Add(1)
+	}
+	delay := time.Since(event.OccurredAt)
+	s.mu.Lock()
+	s.lastEventDelay = &delay
+	s.mu.Unlock()
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Reconciliation
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *courseAccessService) Reconcile() error {
+	err := s.reconcile()
+	s.mu.Lock()
+	if err != nil {
+		s.lastReconcileError = err.Error()
+	} else {
+		s.lastReconcileError = ""
+	}
+	s.mu.Unlock()
+	if err != nil {
+		s.metrics.reconcileErrors.Add(1)
+	}
+	return err
+}
+
+func (s *courseAccessService) reconcile() error {
+	snap, err := s.academicClient.GetCourseAccessSnapshot()
+	if err != nil {
+		return fmt.Errorf("fetching course access snapshot: %w", err)
+	}
+
+	enrollments := make([]domain.EnrollmentProjection, len(snap.Enrollments))
+	for i, e := range snap.Enrollments {
+		enrollments[i] = domain.EnrollmentProjection{
+			CourseInstanceID: e.CourseInstanceID,
+			UserID:           e.UserID,
+			Status:           e.Status,
+			Sequence:         snap.CourseInstanceSequences[e.CourseInstanceID],
+		}
+	}
+	instructors := make([]domain.InstructorProjection, len(snap.Instructors))
+	for i, ci := range snap.Instructors {
+		instructors[i] = domain.InstructorProjection{
+			CourseInstanceID: ci.CourseInstanceID,
+			UserID:           ci.UserID,
+			Role:             ci.Role,
+			Sequence:         snap.CourseInstanceSequences[ci.CourseInstanceID],
+		}
+	}
+
+	members := make([]domain.SectionMemberProjection, len(snap.SectionMembers))
+	for i, m := range snap.SectionMembers {
+		members[i] = domain.SectionMemberProjection{
+			CourseInstanceID: m.CourseInstanceID,
+			UserID:           m.UserID,
+			SectionID:        m.SectionID,
+			Sequence:         snap.CourseInstanceSequences[m.CourseInstanceID],
+		}
+	}
+
+	if err := s.repo.Reconcile(enrollments, instructors, members, snap.CourseInstanceSequences, snap.Sequence); err != nil {
+		return fmt.Errorf("storing course access snapshot: %w", err)
+	}
+	s.cache.clear()
+
+	s.logger.Info("course access read-model reconciled",
+		zap.Int64("sequence", snap.Sequence),
+		zap.Int("enrollments", len(enrollments)),
+		zap.Int("instructors", len(instructors)),
+		zap.Int("section_members", len(members)),
+	)
+	return nil
+}
+
+func (s *courseAccessService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Reconcile(); err != nil {
+			s.logger.Warn("course access reconciliation failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Status
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *courseAccessService) Status() (*dto.CourseAccessStatusResponse, error) {
+	state, err := s.repo.GetState()
+	if err != nil {
+		return nil, utils.ErrInternal("failed to load projection state", err)
+	}
+	enrollments, instructors, err := s.repo.Count()
+	if err != nil {
+		return nil, utils.ErrInternal("failed to count projection rows", err)
+	}
+
+	resp := &dto.CourseAccessStatusResponse{
+		Stale:           !s.fresh(state),
+		LastSequence:    state.LastSequence,
+		LastEventAt:     state.LastEventAt,
+		ReconciledAt:    state.ReconciledAt,
+		Enrollments:     enrollments,
+		Instructors:     instructors,
+		CacheSize:       s.cache.len(),
+		EventsApplied:   s.metrics.eventsApplied.Load(),
+		EventsIgnored:   s.metrics.eventsIgnored.Load(),
+		ProjectionHits:  s.metrics.projectionHits.Load(),
+		CacheHits:       s.metrics.cacheHits.Load(),
+		AcademicCalls:   s.metrics.academicCalls.Load(),
+		AcademicErrors:  s.metrics.academicErrors.Load(),
+		StaleFallbacks:  s.metrics.staleFallbacks.Load(),
+		ReconcileErrors: s.metrics.reconcileErrors.Load(),
+	}
+	if state.LastEventAt != nil {
+		age := time.Since(*state.LastEventAt).Seconds()
+		resp.LastEventAgeSeconds = &age
+	}
+	if state.ReconciledAt != nil {
+		age := time.Since(*state.ReconciledAt).Seconds()
+		resp.ReconcileAgeSeconds = &age
+	}
+
+	s.mu.Lock()
+	if s.lastEventDelay != nil {
+		delay := s.lastEventDelay.Seconds()
+		resp.LastEventDelaySeconds = &delay
+	}
+	resp.LastReconcileError = s.lastReconcileError
+	s.mu.Unlock()
+
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// TTL cache
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ttlCacheMaxEntries caps the cache; at the cap each new key replaces an
+// arbitrary entry.
+const ttlCacheMaxEntries = 10_000
+
+type ttlCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// ttlCache is a small concurrency-safe map whose entries expire after ttl.
+// It holds at most maxEntries entries.
+type ttlCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	entries    map[string]ttlCacheEntry
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{ttl: ttl, maxEntries: ttlCacheMaxEntries, entries: make(map[string]ttlCacheEntry)}
+}
+
+func (c *ttlCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *ttlCache) set(key string, value interface{}) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		// Map iteration order is randomized, so this evicts a random entry.
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = ttlCacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+func (c *ttlCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *ttlCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]ttlCacheEntry)
+}
+
+func (c *ttlCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
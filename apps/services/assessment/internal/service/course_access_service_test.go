@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/queue"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type (
+	accessKey [2]uuid.UUID
+	memberKey [3]uuid.UUID
+)
+
+// fakeCourseAccessRepository keeps the read-model in memory with the
+// sequencing rules of the GORM repository: a write replaces a stored row only
+// when the stored sequence is older.
+type fakeCourseAccessRepository struct {
+	repository.CourseAccessRepository
+	enrollments map[accessKey]domain.EnrollmentProjection
+	members     map[memberKey]domain.SectionMemberProjection
+	state       domain.ProjectionState
+}
+
+func newFakeCourseAccessRepository() *fakeCourseAccessRepository {
+	return &fakeCourseAccessRepository{
+		enrollments: map[accessKey]domain.EnrollmentProjection{},
+		members:     map[memberKey]domain.SectionMemberProjection{},
+	}
+}
+
+func (r *fakeCourseAccessRepository) ApplyEnrollment(p *domain.EnrollmentProjection) (bool, error) {
+	key := accessKey{p.CourseInstanceID, p.UserID}
+	stored, ok := r.enrollments[key]
+	applied := !ok || stored.Sequence < p.Sequence
+	if applied {
+		r.enrollments[key] = *p
+	}
+	r.state.LastSequence = max(r.state.LastSequence, p.Sequence)
+	return applied, nil
+}
+
+func (r *fakeCourseAccessRepository) ApplySectionMember(p *domain.SectionMemberProjection) (bool, error) {
+	key := memberKey{p.CourseInstanceID, p.UserID, p.SectionID}
+	stored, ok := r.members[key]
+	applied := !ok || stored.Sequence < p.Sequence
+	if applied {
+		r.members[key] = *p
+	}
+	r.state.LastSequence = max(r.state.LastSequence, p.Sequence)
+	return applied, nil
+}
+
+func (r *fakeCourseAccessRepository) GetEnrollment(courseInstanceID, userID uuid.UUID) (*domain.EnrollmentProjection, error) {
+	stored, ok := r.enrollments[accessKey{courseInstanceID, userID}]
+	if !ok {
+		return nil, nil
+	}
+	return &stored, nil
+}
+
+func (r *fakeCourseAccessRepository) ListEnrollmentsByUser(userID uuid.UUID) ([]domain.EnrollmentProjection, error) {
+	var rows []domain.EnrollmentProjection
+	for key, row := range r.enrollments {
+		if key[1] == userID && !row.Removed {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (r *fakeCourseAccessRepository) Reconcile(
+	enrollments []domain.EnrollmentProjection,
+	_ []domain.InstructorProjection,
+	members []domain.SectionMemberProjection,
+	watermarks map[uuid.UUID]int64,
+	sequence int64,
+) error {
+	present := map[accessKey]bool{}
+	for _, e := range enrollments {
+		key := accessKey{e.CourseInstanceID, e.UserID}
+		present[key] = true
+		if stored, ok := r.enrollments[key]; !ok || stored.Sequence <= e.Sequence {
+			r.enrollments[key] = e
+		}
+	}
+	for key, row := range r.enrollments {
+		watermark := watermarks[key[0]]
+		if !row.Removed && !present[key] && row.Sequence <= watermark {
+			row.Removed, row.Sequence = true, watermark
+			r.enrollments[key] = row
+		}
+	}
+	presentMembers := map[memberKey]bool{}
+	for _, m := range members {
+		key := memberKey{m.CourseInstanceID, m.UserID, m.SectionID}
+		presentMembers[key] = true
+		if stored, ok := r.members[key]; !ok || stored.Sequence <= m.Sequence {
+			r.members[key] = m
+		}
+	}
+	for key, row := range r.members {
+		watermark := watermarks[key[0]]
+		if !row.Removed && !presentMembers[key] && row.Sequence <= watermark {
+			row.Removed, row.Sequence = true, watermark
+			r.members[key] = row
+		}
+	}
+	now := time.Now().UTC()
+	r.state.LastSequence = max(r.state.LastSequence, sequence)
+	r.state.ReconciledAt = &now
+	return nil
+}
+
+func (r *fakeCourseAccessRepository) Count() (int64, int64, error) {
+	return int64(len(r.enrollments)), 0, nil
+}
+
+func (r *fakeCourseAccessRepository) GetState() (*domain.ProjectionState, error) {
+	state := r.state
+	return &state, nil
+}
+
+// fakeAcademic serves the Academic Service endpoints the course access
+// service calls, plus an IAM token endpoint for the service token.
+type fakeAcademic struct {
+	snapshot    map[string]interface{}
+	enrolled    bool
+	courses     []client.StudentCourseItem
+	unavailable atomic.Bool
+	calls       atomic.Int64
+}
+
+func (f *fakeAcademic) start(t *testing.T) *client.AcademicClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == servicetoken.TokenPath {
+			_ = json.NewEncoder(w).Encode(servicetoken.TokenResponse{AccessToken: "service-token", ExpiresIn: 3600})
+			return
+		}
+		f.calls.Add(1)
+		if f.unavailable.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/internal/course-access":
+			_ = json.NewEncoder(w).Encode(f.snapshot)
+		case "/api/v1/internal/enrollments":
+			count := 0
+			if f.enrolled {
+				count = 1
+			}
+			_ = json.NewEncoder(w).Encode(map[string]int{"count": count})
+		case "/api/v1/student-courses/me":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"enrollments": f.courses, "count": len(f.courses)})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tokens := servicetoken.NewClient(servicetoken.Config{
+		TokenURL:     server.URL + servicetoken.TokenPath,
+		ClientID:     "assessment",
+		ClientSecret: "secret",
+	})
+	return client.NewAcademicClient(server.URL, tokens, zap.NewNop())
+}
+
+func enrollmentEvent(t *testing.T, sequence int64, eventType string, courseInstanceID, userID uuid.UUID, status string) queue.AcademicEvent {
+	t.Helper()
+	payload, err := json.Marshal(academicEventPayload{CourseInstanceID: courseInstanceID, UserID: userID, Status: status})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return queue.AcademicEvent{Sequence: sequence, EventType: eventType, OccurredAt: time.Now(), Payload: payload}
+}
+
+func sectionMemberEvent(t *testing.T, sequence int64, eventType string, courseInstanceID, sectionID, userID uuid.UUID) queue.AcademicEvent {
+	t.Helper()
+	payload, err := json.Marshal(academicEventPayload{CourseInstanceID: courseInstanceID, SectionID: sectionID, UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return queue.AcademicEvent{Sequence: sequence, EventType: eventType, OccurredAt: time.Now(), Payload: payload}
+}
+
+func TestReconcile_KeepsEventsCommittedAfterTheSnapshot(t *testing.T) {
+	courseA, courseB := uuid.New(), uuid.New()
+	alice, bob := uuid.New(), uuid.New()
+
+	academic := &fakeAcademic{snapshot: map[string]interface{}{
+		"sequence":                  20,
+		"course_instance_sequences": map[uuid.UUID]int64{courseA: 5, courseB: 20},
+		"enrollments": []map[string]interface{}{
+			{"course_instance_id": courseA, "user_id": alice, "status": domain.EnrollmentStatusEnrolled},
+		},
+	}}
+
+	repo := newFakeCourseAccessRepository()
+	s := NewCourseAccessService(repo, academic.start(t), time.Minute, time.Hour, zap.NewNop())
+
+	// Bob's enrollment (sequence 12) was consumed before the snapshot was
+	// taken but committed after it, so the snapshot does not contain it.
+	ctx := context.Background()
+	if err := s.HandleEvent(ctx, enrollmentEvent(t, 12, academicEventEnrollmentCreated, courseA, bob, domain.EnrollmentStatusEnrolled)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Reconcile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if row := repo.enrollments[accessKey{courseA, bob}]; row.Removed {
+		t.Errorf("reconciliation removed an enrollment newer than the snapshot: %+v", row)
+	}
+	if row := repo.enrollments[accessKey{courseA, alice}]; row.Sequence != 5 {
+		t.Errorf("expected the snapshot row stamped with its course instance's sequence 5, got %d", row.Sequence)
+	}
+
+	// Alice's drop (sequence 13) also commits after the snapshot and is below
+	// its overall sequence of 20; it must still apply.
+	if err := s.HandleEvent(ctx, enrollmentEvent(t, 13, academicEventEnrollmentDropped, courseA, alice, "Dropped")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	enrolled, err := s.IsEnrolled(alice.String(), courseA.String())
+	if err != nil || enrolled {
+		t.Errorf("expected alice's drop to be applied, got enrolled=%v err=%v", enrolled, err)
+	}
+
+	// A redelivered older event is ignored.
+	if err := s.HandleEvent(ctx, enrollmentEvent(t, 4, academicEventEnrollmentCreated, courseA, alice, domain.EnrollmentStatusEnrolled)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if row := repo.enrollments[accessKey{courseA, alice}]; row.Sequence != 13 || row.Status != "Dropped" {
+		t.Errorf("expected the stale event to be ignored, got %+v", row)
+	}
+	status, _ := s.Status()
+	if status.EventsApplied != 2 || status.EventsIgnored != 1 {
+		t.Errorf("expected 2 applied and 1 ignored events, got %d and %d", status.EventsApplied, status.EventsIgnored)
+	}
+}
+
+func TestSectionMembers_FollowEventsAndSnapshots(t *testing.T) {
+	course, lab, otherLab := uuid.New(), uuid.New(), uuid.New()
+	alice, bob := uuid.New(), uuid.New()
+
+	academic := &fakeAcademic{snapshot: map[string]interface{}{
+		"sequence":                  10,
+		"course_instance_sequences": map[uuid.UUID]int64{course: 10},
+		"section_members": []map[string]interface{}{
+			{"course_instance_id": course, "section_id": lab, "user_id": alice},
+			{"course_instance_id": course, "section_id": lab, "user_id": bob},
+		},
+	}}
+	repo := newFakeCourseAccessRepository()
+	s := NewCourseAccessService(repo, academic.start(t), time.Minute, time.Hour, zap.NewNop())
+	if err := s.Reconcile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if row, ok := repo.members[memberKey{course, alice, lab}]; !ok || row.Removed || row.Sequence != 10 {
+		t.Fatalf("expected alice's membership from the snapshot, got %+v", row)
+	}
+
+	// Alice moves to the other lab.
+	ctx := context.Background()
+	for _, event := range []queue.AcademicEvent{
+		sectionMemberEvent(t, 11, academicEventSectionMemberRemoved, course, lab, alice),
+		sectionMemberEvent(t, 12, academicEventSectionMemberAdded, course, otherLab, alice),
+	} {
+		if err := s.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if row := repo.members[memberKey{course, alice, lab}]; !row.Removed {
+		t.Errorf("expected alice's old membership removed, got %+v", row)
+	}
+	if row := repo.members[memberKey{course, alice, otherLab}]; row.Removed || row.Sequence != 12 {
+		t.Errorf("expected alice's new membership, got %+v", row)
+	}
+
+	// Bob is missing from the next snapshot, so he is removed at his course
+	// instance's watermark.
+	academic.snapshot = map[string]interface{}{
+		"sequence":                  12,
+		"course_instance_sequences": map[uuid.UUID]int64{course: 12},
+		"section_members": []map[string]interface{}{
+			{"course_instance_id": course, "section_id": otherLab, "user_id": alice},
+		},
+	}
+	if err := s.Reconcile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if row := repo.members[memberKey{course, alice, otherLab}]; row.Removed {
+		t.Errorf("expected alice to stay in the other lab, got %+v", row)
+	}
+	if row := repo.members[memberKey{course, bob, lab}]; !row.Removed || row.Sequence != 12 {
+		t.Errorf("expected bob's membership removed at the watermark, got %+v", row)
+	}
+}
+
+func TestIsEnrolled_CachesAcademicAnswersForRowsNotInTheReadModel(t *testing.T) {
+	academic := &fakeAcademic{enrolled: true}
+	s := NewCourseAccessService(newFakeCourseAccessRepository(), academic.start(t), time.Minute, time.Hour, zap.NewNop())
+	userID, courseID := uuid.New().String(), uuid.New().String()
+
+	for i := 0; i < 2; i++ {
+		enrolled, err := s.IsEnrolled(userID, courseID)
+		if err != nil || !enrolled {
+			t.Fatalf("call %d: expected enrolled, got %v (%v)", i, enrolled, err)
+		}
+	}
+	if calls := academic.calls.Load(); calls != 1 {
+		t.Errorf("expected the second lookup to be served from the cache, got %d academic calls", calls)
+	}
+}
+
+func TestTTLCache_ExpiresEntries(t *testing.T) {
+	c := newTTLCache(20 * time.Millisecond)
+	c.set("key", true)
+	if v, ok := c.get("key"); !ok || v != true {
+		t.Fatalf("expected a cached value, got %v, %v", v, ok)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := c.get("key"); ok {
+		t.Error("expected the entry to have expired")
+	}
+
+	disabled := newTTLCache(0)
+	disabled.set("key", true)
+	if _, ok := disabled.get("key"); ok || disabled.len() != 0 {
+		t.Error("expected a zero TTL to disable caching")
+	}
+}
+
+func TestTTLCache_BoundsEntries(t *testing.T) {
+	c := newTTLCache(time.Minute)
+	c.maxEntries = 3
+	for i := 0; i < 10; i++ {
+		c.set(fmt.Sprintf("key-%d", i), true)
+	}
+	if c.len() != 3 {
+		t.Fatalf("expected the cache to hold 3 entries, got %d", c.len())
+	}
+	c.set("key-9", false)
+	if v, ok := c.get("key-9"); !ok || v != false || c.len() != 3 {
+		t.Errorf("expected an existing key to be overwritten in place, got %v, %v with %d entries", v, ok, c.len())
+	}
+}
+
+func TestStudentCourses_FallsBackToStaleReadModel(t *testing.T) {
+	userID, courseID := uuid.New(), uuid.New()
+	academic := &fakeAcademic{courses: []client.StudentCourseItem{{CourseInstanceID: uuid.New().String()}}}
+	academic.unavailable.Store(true)
+
+	repo := newFakeCourseAccessRepository()
+	repo.enrollments[accessKey{courseID, userID}] = domain.EnrollmentProjection{
+		CourseInstanceID: courseID, UserID: userID, Status: domain.EnrollmentStatusEnrolled, Sequence: 1,
+	}
+	s := NewCourseAccessService(repo, academic.start(t), time.Minute, time.Hour, zap.NewNop())
+
+	// Never reconciled: the read-model may be incomplete, so nothing is served.
+	if _, err := s.StudentCourses(userID, "user-token"); err == nil {
+		t.Fatal("expected an error before the first reconciliation")
+	}
+
+	reconciledAt := time.Now().Add(-2 * time.Hour)
+	repo.state.ReconciledAt = &reconciledAt
+	items, err := s.StudentCourses(userID, "user-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].CourseInstanceID != courseID.String() {
+		t.Errorf("expected the stale read-model's enrollment, got %+v", items)
+	}
+
+	// Once the Academic Service answers again, its answer wins.
+	academic.unavailable.Store(false)
+	items, err = s.StudentCourses(userID, "user-token")
+	if err != nil || len(items) != 1 || items[0].CourseInstanceID == courseID.String() {
+		t.Errorf("expected the academic service's list, got %+v (%v)", items, err)
+	}
+
+	status, _ := s.Status()
+	if !status.Stale || status.StaleFallbacks != 1 || status.AcademicErrors != 2 {
+		t.Errorf("unexpected counters: stale=%v fallbacks=%d errors=%d", status.Stale, status.StaleFallbacks, status.AcademicErrors)
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Interface
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GradingService defines the business-logic contract for manual grading and
+// regrade requests.  Course-level access (instructor or TA of the assignment's
+// course instance) is verified by the handler before these methods are called.
+type GradingService interface {
+	// GradeSubmission records a score and feedback on a submission.
+	GradeSubmission(
+		submissionID uuid.UUID,
+		req *dto.GradeSubmissionRequest,
+		graderID uuid.UUID,
+		username, ipAddress, userAgent string,
+	) (*domain.Submission, error)
+
+	// RequestRegrade opens a regrade request on a graded submission owned by
+	// the student (individually or through their group).
+	RequestRegrade(
+		submissionID uuid.UUID,
+		req *dto.CreateRegradeRequest,
+		userID uuid.UUID,
+		username, ipAddress, userAgent string,
+	) (*domain.RegradeRequest, error)
+
+	// GetRegradeRequest loads a single regrade request.
+	GetRegradeRequest(id uuid.UUID) (*domain.RegradeRequest, error)
+
+	// ListRegradeRequests returns the regrade requests for an assignment,
+	// optionally filtered by status.
+	ListRegradeRequests(assignmentID uuid.UUID, status string) ([]domain.RegradeRequest, error)
+
+	// ListMyRegradeRequests returns the caller's regrade requests for an assignment.
+	ListMyRegradeRequests(assignmentID, userID uuid.UUID) ([]domain.RegradeRequest, error)
+
+	// RespondToRegrade accepts or rejects an open regrade request.
+	RespondToRegrade(
+		id uuid.UUID,
+		req *dto.RespondRegradeRequest,
+		responderID uuid.UUID,
+		username, ipAddress, userAgent string,
+	) (*domain.RegradeRequest, error)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Implementation
+// ─────────────────────────────────────────────────────────────────────────────
+
+type gradingService struct {
+	submissionRepo repository.SubmissionRepository
+	regradeRepo    repository.RegradeRepository
+	groupRepo      repository.GroupRepository
+	auditClient    *client.AuditClient
+	observer       SubmissionObserver
+	logger         *zap.Logger
+}
+
+// NewGradingService wires all dependencies and returns a GradingService.
+func NewGradingService(
+	submissionRepo repository.SubmissionRepository,
+	regradeRepo repository.RegradeRepository,
+	groupRepo repository.GroupRepository,
+	auditClient *client.AuditClient,
+	observer SubmissionObserver,
+	logger *zap.Logger,
+) GradingService {
+	return &gradingService{
+		submissionRepo: submissionRepo,
+		regradeRepo:    regradeRepo,
+		groupRepo:      groupRepo,
+		auditClient:    auditClient,
+		observer:       observer,
+		logger:         logger,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GradeSubmission
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *gradingService) GradeSubmission(
+	submissionID uuid.UUID,
+	req *dto.GradeSubmissionRequest,
+	graderID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*domain.Submission, error) {
+	if req.Score == nil {
+		return nil, utils.ErrBadRequest("score is required")
+	}
+	if *req.Score < 0 {
+		return nil, utils.ErrBadRequest("score must not be negative")
+	}
+
+	submission, err := s.loadSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.submissionRepo.UpdateGrade(submissionID, *req.Score, req.Feedback, graderID); err != nil {
+		s.logger.Error("failed to grade submission", zap.String("id", submissionID.String()), zap.Error(err))
+		return nil, utils.ErrInternal("failed to grade submission", err)
+	}
+	s.observer.SubmissionChanged()
+
+	changes := map[string]interface{}{
+		"score":     *req.Score,
+		"graded_by": graderID.String(),
+	}
+	if submission.Score != nil {
+		changes["previous_score"] = *submission.Score
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionSubmissionGraded),
+		"submission",
+		submissionID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	return s.loadSubmission(submissionID)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RequestRegrade
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *gradingService) RequestRegrade(
+	submissionID uuid.UUID,
+	req *dto.CreateRegradeRequest,
+	userID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*domain.RegradeRequest, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, utils.ErrBadRequest("reason is required")
+	}
+
+	submission, err := s.loadSubmission(submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assertOwner(submission, userID); err != nil {
+		return nil, err
+	}
+	if submission.GradedAt == nil {
+		return nil, utils.ErrBadRequest("submission has not been graded yet")
+	}
+
+	open, err := s.regradeRepo.FindOpenRegradeRequest(submissionID)
+	if err != nil {
+		s.logger.Error("failed to check open regrade requests", zap.Error(err))
+		return nil, utils.ErrInternal("failed to check open regrade requests", err)
+	}
+	if open != nil {
+		return nil, utils.ErrConflict("a regrade request is already open for this submission")
+	}
+
+	regrade := &domain.RegradeRequest{
+		SubmissionID:  submissionID,
+		AssignmentID:  submission.AssignmentID,
+		RequestedBy:   userID,
+		Reason:        reason,
+		Status:        domain.RegradeStatusOpen,
+		PreviousScore: submission.Score,
+	}
+	if err := s.regradeRepo.CreateRegradeRequest(regrade); err != nil {
+		s.logger.Error("failed to create regrade request", zap.Error(err))
+		return nil, utils.ErrInternal("failed to create regrade request", err)
+	}
+
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionRegradeRequested),
+		"regrade_request",
+		regrade.ID.String(),
+		0,
+		username,
+		map[string]interface{}{"submission_id": submissionID.String()},
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	return regrade, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Regrade queries
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *gradingService) GetRegradeRequest(id uuid.UUID) (*domain.RegradeRequest, error) {
+	regrade, err := s.regradeRepo.GetRegradeRequest(id)
+	if err != nil {
+		s.logger.Error("failed to load regrade request", zap.String("id", id.String()), zap.Error(err))
+		return nil, utils.ErrInternal("failed to load regrade request", err)
+	}
+	if regrade == nil {
+		return nil, utils.ErrNotFound("regrade request not found")
+	}
+	return regrade, nil
+}
+
+func (s *gradingService) ListRegradeRequests(assignmentID uuid.UUID, status string) ([]domain.RegradeRequest, error) {
+	if status != "" && !isValidRegradeStatus(status) {
+		return nil, utils.ErrBadRequest("invalid status: allowed values are open, accepted, rejected")
+	}
+
+	requests, err := s.regradeRepo.ListByAssignment(assignmentID, status)
+	if err != nil {
+		s.logger.Error("failed to list regrade requests", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list regrade requests", err)
+	}
+	return requests, nil
+}
+
+func (s *gradingService) ListMyRegradeRequests(assignmentID, userID uuid.UUID) ([]domain.RegradeRequest, error) {
+	requests, err := s.regradeRepo.ListByRequester(assignmentID, userID)
+	if err != nil {
+		s.logger.Error("failed to list regrade requests", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list regrade requests", err)
+	}
+	return requests, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RespondToRegrade
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *gradingService) RespondToRegrade(
+	id uuid.UUID,
+	req *dto.RespondRegradeRequest,
+	responderID uuid.UUID,
+	username, ipAddress, userAgent string,
+) (*domain.RegradeRequest, error) {
+	if req.Status != domain.RegradeStatusAccepted && req.Status != domain.RegradeStatusRejected {
+		return nil, utils.ErrBadRequest("status must be accepted or rejected")
+	}
+	if req.NewScore != nil {
+		if req.Status != domain.RegradeStatusAccepted {
+			return nil, utils.ErrBadRequest("new_score is only allowed when accepting")
+		}
+		if *req.NewScore < 0 {
+			return nil, utils.ErrBadRequest("new_score must not be negative")
+		}
+	}
+
+	regrade, err := s.GetRegradeRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	if regrade.Status != domain.RegradeStatusOpen {
+		return nil, utils.ErrConflict("regrade request has already been answered")
+	}
+
+	now := time.Now().UTC()
+	regrade.Status = req.Status
+	regrade.Response = strings.TrimSpace(req.Response)
+	regrade.NewScore = req.NewScore
+	regrade.RespondedBy = &responderID
+	regrade.RespondedAt = &now
+
+	responded, err := s.regradeRepo.Respond(regrade)
+	if err != nil {
+		s.logger.Error("failed to respond to regrade request", zap.String("id", id.String()), zap.Error(err))
+		return nil, utils.ErrInternal("failed to respond to regrade request", err)
+	}
+	if !responded {
+		return nil, utils.ErrConflict("regrade request has already been answered")
+	}
+	if regrade.NewScore != nil {
+		s.observer.SubmissionChanged()
+	}
+
+	changes := map[string]interface{}{
+		"status":        regrade.Status,
+		"submission_id": regrade.SubmissionID.String(),
+	}
+	if regrade.NewScore != nil {
+		changes["new_score"] = *regrade.NewScore
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionRegradeAnswered),
+		"regrade_request",
+		regrade.ID.String(),
+		0,
+		username,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log", zap.Error(auditErr))
+	}
+
+	return regrade, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *gradingService) loadSubmission(id uuid.UUID) (*domain.Submission, error) {
+	submission, err := s.submissionRepo.GetSubmission(id)
+	if err != nil {
+		s.logger.Error("failed to load submission", zap.String("id", id.String()), zap.Error(err))
+		return nil, utils.ErrInternal("failed to load submission", err)
+	}
+	if submission == nil {
+		return nil, utils.ErrNotFound("submission not found")
+	}
+	return submission, nil
+}
+
+// assertOwner checks the user made the submission, directly or as a member of
+// the submitting group.
+func (s *gradingService) assertOwner(submission *domain.Submission, userID uuid.UUID) error {
+	if submission.UserID != nil && *submission.UserID == userID {
+		return nil
+	}
+	if submission.GroupID != nil {
+		group, err := s.groupRepo.GetGroup(*submission.GroupID)
+		if err != nil {
+			return utils.ErrInternal("failed to load group", err)
+		}
+		if group != nil {
+			var members []string
+			if err := json.Unmarshal(group.Members, &members); err == nil {
+				for _, m := range members {
+					if m == userID.String() {
+						return nil
+					}
+				}
+			}
+		}
+	}
+	return utils.ErrForbidden("you can only request a regrade for your own submission")
+}
+
+func isValidRegradeStatus(status string) bool {
+	switch status {
+	case domain.RegradeStatusOpen, domain.RegradeStatusAccepted, domain.RegradeStatusRejected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeSubmissionRepository holds submissions in memory. Methods the tests do
+// not reach panic through the nil embedded interface.
+type fakeSubmissionRepository struct {
+	repository.SubmissionRepository
+	submissions map[uuid.UUID]*domain.Submission
+	graded      int
+}
+
+func (r *fakeSubmissionRepository) GetSubmission(id uuid.UUID) (*domain.Submission, error) {
+	return r.submissions[id], nil
+}
+
+func (r *fakeSubmissionRepository) UpdateGrade(id uuid.UUID, score float64, feedback string, gradedBy uuid.UUID) error {
+	s := r.submissions[id]
+	s.Score = &score
+	r.graded++
+	return nil
+}
+
+// fakeRegradeRepository holds regrade requests in memory. With answered set,
+// every request is answered by someone else between read and write.
+type fakeRegradeRepository struct {
+	repository.RegradeRepository
+	requests  map[uuid.UUID]*domain.RegradeRequest
+	answered  bool
+	responded int
+}
+
+func (r *fakeRegradeRepository) GetRegradeRequest(id uuid.UUID) (*domain.RegradeRequest, error) {
+	return r.requests[id], nil
+}
+
+func (r *fakeRegradeRepository) Respond(req *domain.RegradeRequest) (bool, error) {
+	if r.answered {
+		return false, nil
+	}
+	r.requests[req.ID] = req
+	r.responded++
+	return true, nil
+}
+
+type countingObserver struct{ calls int }
+
+func (o *countingObserver) SubmissionChanged() { o.calls++ }
+
+// newTestGradingService builds a gradingService whose audit client has no
+// credentials, so audit writes fail quietly without network access.
+func newTestGradingService(subs *fakeSubmissionRepository, regrades *fakeRegradeRepository, observer SubmissionObserver) GradingService {
+	audit := client.NewAuditClient("http://audit.invalid", servicetoken.NewClient(servicetoken.Config{}), zap.NewNop())
+	return NewGradingService(subs, regrades, nil, audit, observer, zap.NewNop())
+}
+
+func assertAppError(t *testing.T, err error, code int) {
+	t.Helper()
+	var appErr *utils.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected *utils.AppError with code %d, got %v", code, err)
+	}
+	if appErr.Code != code {
+		t.Errorf("expected code %d, got %d (%s)", code, appErr.Code, appErr.Message)
+	}
+}
+
+func TestGradeSubmission(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	id := uuid.New()
+
+	tests := []struct {
+		name     string
+		score    *float64
+		wantCode int
+	}{
+		{"missing score", nil, http.StatusBadRequest},
+		{"negative score", score(-1), http.StatusBadRequest},
+		{"valid score", score(8.5), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := &fakeSubmissionRepository{submissions: map[uuid.UUID]*domain.Submission{id: {ID: id}}}
+			observer := &countingObserver{}
+			s := newTestGradingService(subs, nil, observer)
+
+			got, err := s.GradeSubmission(id, &dto.GradeSubmissionRequest{Score: tt.score}, uuid.New(), "ta@uni.example", "", "")
+			if tt.wantCode != 0 {
+				assertAppError(t, err, tt.wantCode)
+				if subs.graded != 0 || observer.calls != 0 {
+					t.Errorf("expected no grade written, got %d writes and %d observer calls", subs.graded, observer.calls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Score == nil || *got.Score != *tt.score {
+				t.Errorf("expected score %v, got %v", *tt.score, got.Score)
+			}
+			if observer.calls != 1 {
+				t.Errorf("expected one observer call, got %d", observer.calls)
+			}
+		})
+	}
+}
+
+func TestGradeSubmission_NotFound(t *testing.T) {
+	score := 5.0
+	s := newTestGradingService(&fakeSubmissionRepository{}, nil, &countingObserver{})
+
+	_, err := s.GradeSubmission(uuid.New(), &dto.GradeSubmissionRequest{Score: &score}, uuid.New(), "", "", "")
+	assertAppError(t, err, http.StatusNotFound)
+}
+
+func TestRespondToRegrade(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name          string
+		current       string
+		req           dto.RespondRegradeRequest
+		wantCode      int
+		wantObserved  int
+		wantResponded int
+		raced         bool
+	}{
+		{"invalid status", domain.RegradeStatusOpen, dto.RespondRegradeRequest{Status: "maybe"}, http.StatusBadRequest, 0, 0, false},
+		{"new score on reject", domain.RegradeStatusOpen, dto.RespondRegradeRequest{Status: domain.RegradeStatusRejected, NewScore: score(9)}, http.StatusBadRequest, 0, 0, false},
+		{"negative new score", domain.RegradeStatusOpen, dto.RespondRegradeRequest{Status: domain.RegradeStatusAccepted, NewScore: score(-2)}, http.StatusBadRequest, 0, 0, false},
+		{"already answered", domain.RegradeStatusRejected, dto.RespondRegradeRequest{Status: domain.RegradeStatusAccepted}, http.StatusConflict, 0, 0, false},
+		{"answered concurrently", domain.RegradeStatusOpen, dto.RespondRegradeRequest{Status: domain.RegradeStatusAccepted, NewScore: score(9)}, http.StatusConflict, 0, 0, true},
+		{"reject", domain.RegradeStatusOpen, dto.RespondRegradeRequest{Status: domain.RegradeStatusRejected, Response: "  score stands "}, 0, 0, 1, false},
+		{"accept with new score", domain.RegradeStatusOpen, dto.RespondRegradeRequest{Status: domain.RegradeStatusAccepted, NewScore: score(9)}, 0, 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			regrades := &fakeRegradeRepository{requests: map[uuid.UUID]*domain.RegradeRequest{
+				id: {ID: id, SubmissionID: uuid.New(), Status: tt.current},
+			}, answered: tt.raced}
+			observer := &countingObserver{}
+			s := newTestGradingService(nil, regrades, observer)
+			responder := uuid.New()
+
+			got, err := s.RespondToRegrade(id, &tt.req, responder, "lead@uni.example", "", "")
+			if tt.wantCode != 0 {
+				assertAppError(t, err, tt.wantCode)
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if regrades.responded != tt.wantResponded {
+				t.Errorf("expected %d responses written, got %d", tt.wantResponded, regrades.responded)
+			}
+			if observer.calls != tt.wantObserved {
+				t.Errorf("expected %d observer calls, got %d", tt.wantObserved, observer.calls)
+			}
+			if tt.wantCode != 0 {
+				return
+			}
+			if got.Status != tt.req.Status || got.RespondedBy == nil || *got.RespondedBy != responder || got.RespondedAt == nil {
+				t.Errorf("response not recorded: %+v", got)
+			}
+			if tt.req.Response != "" && got.Response != "score stands" {
+				t.Errorf("expected trimmed response, got %q", got.Response)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ScoreService reports the effective assignment scores of a course instance
+// so the Academic Service can compute final grades.
+type ScoreService interface {
+	ListCourseInstanceScores(courseInstanceID uuid.UUID) (*dto.CourseInstanceScoresResponse, error)
+}
+
+// scoreService is the concrete implementation.
+type scoreService struct {
+	assignmentRepo repository.AssignmentRepository
+	submissionRepo repository.SubmissionRepository
+	groupRepo      repository.GroupRepository
+	logger         *zap.Logger
+}
+
+// NewScoreService wires all dependencies together.
+func NewScoreService(
+	assignmentRepo repository.AssignmentRepository,
+	submissionRepo repository.SubmissionRepository,
+	groupRepo repository.GroupRepository,
+	logger *zap.Logger,
+) ScoreService {
+	return &scoreService{
+		assignmentRepo: assignmentRepo,
+		submissionRepo: submissionRepo,
+		groupRepo:      groupRepo,
+		logger:         logger,
+	}
+}
+
+// ListCourseInstanceScores returns the effective score of every student on
+// every active assignment of the course instance. A student's effective
+// score is the score on their newest graded submission version, which
+// already reflects any accepted regrade. Group submissions count for every
+// member of the group; an individual submission takes precedence.
+func (s *scoreService) ListCourseInstanceScores(courseInstanceID uuid.UUID) (*dto.CourseInstanceScoresResponse, error) {
+	assignments, err := s.assignmentRepo.ListAssignmentsByCourseInstance(courseInstanceID)
+	if err != nil {
+		s.logger.Error("failed to list assignments", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list assignments", err)
+	}
+
+	ids := make([]uuid.UUID, len(assignments))
+	for i, a := range assignments {
+		ids[i] = a.ID
+	}
+	submissions, err := s.submissionRepo.ListGradedSubmissions(ids)
+	if err != nil {
+		s.logger.Error("failed to list graded submissions", zap.Error(err))
+		return nil, utils.ErrInternal("failed to list graded submissions", err)
+	}
+
+	byAssignment := make(map[uuid.UUID][]domain.Submission)
+	for _, sub := range submissions {
+		byAssignment[sub.AssignmentID] = append(byAssignment[sub.AssignmentID], sub)
+	}
+
+	resp := &dto.CourseInstanceScoresResponse{
+		CourseInstanceID: courseInstanceID,
+		Assignments:      make([]dto.AssignmentScoresResponse, 0, len(assignments)),
+	}
+	for _, a := range assignments {
+		scores, err := s.effectiveScores(&a, byAssignment[a.ID])
+		if err != nil {
+			return nil, err
+		}
+		resp.Assignments = append(resp.Assignments, dto.AssignmentScoresResponse{
+			AssignmentID:   a.ID,
+			Title:          a.Title,
+			AssessmentType: a.AssessmentType,
+			DueAt:          a.DueAt,
+			Scores:         scores,
+		})
+	}
+	return resp, nil
+}
+
+// effectiveScores picks one score per student from the graded submissions of
+// a single assignment, which must be ordered newest version first.
+func (s *scoreService) effectiveScores(assignment *domain.Assignment, submissions []domain.Submission) ([]dto.StudentScoreResponse, error) {
+	individual := make(map[uuid.UUID]dto.StudentScoreResponse)
+	groupScores := make(map[uuid.UUID]domain.Submission)
+	for _, sub := range submissions {
+		switch {
+		case sub.UserID != nil:
+			if _, seen := individual[*sub.UserID]; !seen {
+				individual[*sub.UserID] = dto.StudentScoreResponse{UserID: *sub.UserID, Score: *sub.Score, SubmissionID: sub.ID}
+			}
+		case sub.GroupID != nil:
+			if _, seen := groupScores[*sub.GroupID]; !seen {
+				groupScores[*sub.GroupID] = sub
+			}
+		}
+	}
+
+	if len(groupScores) > 0 {
+		groups, err := s.groupRepo.FindByAssignment(assignment.ID)
+		if err != nil {
+			s.logger.Error("failed to list groups", zap.String("assignment_id", assignment.ID.String()), zap.Error(err))
+			return nil, utils.ErrInternal("failed to list groups", err)
+		}
+		for _, g := range groups {
+			sub, ok := groupScores[g.ID]
+			if !ok {
+				continue
+			}
+			var members []string
+			if err := json.Unmarshal(g.Members, &members); err != nil {
+				s.logger.Warn("skipping group with unreadable members", zap.String("group_id", g.ID.String()), zap.Error(err))
+				continue
+			}
+			for _, m := range members {
+				userID, err := uuid.Parse(m)
+				if err != nil {
+					continue
+				}
+				if _, seen := individual[userID]; !seen {
+					individual[userID] = dto.StudentScoreResponse{UserID: userID, Score: *sub.Score, SubmissionID: sub.ID}
+				}
+			}
+		}
+	}
+
+	scores := make([]dto.StudentScoreResponse, 0, len(individual))
+	for _, score := range individual {
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/storage"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -54,12 +55,13 @@ type SubmissionService interface {
 		userID, groupID *uuid.UUID,
 	) ([]domain.Submission, error)
 
-	// ListAllSubmissionsForAssignment returns every submission for the given
-	// assignment regardless of owner — intended for instructor/admin use where
-	// no user_id or group_id scope is required.
+	// ListAllSubmissionsForAssignment returns a page of the submissions for
+	// the given assignment regardless of owner — intended for instructor/admin
+	// use where no user_id or group_id scope is required.
 	ListAllSubmissionsForAssignment(
 		assignmentID uuid.UUID,
-	) ([]domain.Submission, error)
+		spec queryspec.Spec,
+	) (queryspec.Page[domain.Submission], error)
 
 	// GetLatestSubmission returns the submission with is_latest=true for the
 	// given assignment and owner scope.
@@ -93,8 +95,9 @@ type submissionService struct {
 	storage        *storage.MinIOStorage
 	publisher      *queue.SubmissionPublisher
 	auditClient    *client.AuditClient
-	academicClient *client.AcademicClient
+	courseAccess   CourseAccessService
 	judge0Client   *client.Judge0Client
+	observer       SubmissionObserver
 	maxPayloadSize int64
 	db             *gorm.DB
 	logger         *zap.Logger
@@ -109,8 +112,9 @@ func NewSubmissionService(
 	storage *storage.MinIOStorage,
 	publisher *queue.SubmissionPublisher,
 	auditClient *client.AuditClient,
-	academicClient *client.AcademicClient,
+	courseAccess CourseAccessService,
 	judge0Client *client.Judge0Client,
+	observer SubmissionObserver,
 	maxPayloadSize int64,
 	db *gorm.DB,
 	logger *zap.Logger,
@@ -123,8 +127,9 @@ func NewSubmissionService(
 		storage:        storage,
 		publisher:      publisher,
 		auditClient:    auditClient,
-		academicClient: academicClient,
+		courseAccess:   courseAccess,
 		judge0Client:   judge0Client,
+		observer:       observer,
 		maxPayloadSize: maxPayloadSize,
 		db:             db,
 		logger:         logger,
@@ -218,7 +223,7 @@ func (s *submissionService) CreateSubmission(
 	} else {
 		// ── Individual submission path ────────────────────────────────────────
 		// Check enrollment in the course instance that owns this assignment.
-		enrolled, err := s.academicClient.IsEnrolled(
+		enrolled, err := s.courseAccess.IsEnrolled(
 			userID.String(),
 			assignment.CourseInstanceID.String(),
 		)
@@ -235,6 +240,21 @@ func (s *submissionService) CreateSubmission(
 			return nil, utils.ErrForbidden("not enrolled in the course instance for this assignment")
 		}
 
+		// Section-targeted assignments only accept students in those
+		// sections. Like the enrollment gate, a failed lookup does not block.
+		if len(assignment.Sections) > 0 {
+			sectionIDs, err := s.courseAccess.StudentSections(userID, assignment.CourseInstanceID)
+			if err != nil {
+				s.logger.Warn("section check failed; proceeding without section gate",
+					zap.String("user_id", userID.String()),
+					zap.String("course_instance_id", assignment.CourseInstanceID.String()),
+					zap.Error(err),
+				)
+			} else if targeted, _, _ := assignment.TargetFor(sectionIDs); !targeted {
+				return nil, utils.ErrForbidden("this assignment is not set for your section")
+			}
+		}
+
 		uid := userID
 		ownerUserID = &uid
 	}
@@ -302,6 +322,7 @@ func (s *submissionService) CreateSubmission(
 		s.logger.Error("submission transaction failed", zap.Error(txErr))
 		return nil, utils.ErrInternal("failed to create submission", txErr)
 	}
+	s.observer.SubmissionChanged()
 
 	// ── 5. Publish the job to RabbitMQ ───────────────────────────────────────
 	// If publishing fails, the DB row already exists with status="queued".
@@ -523,26 +544,28 @@ func (s *submissionService) ListSubmissions(
 // ListAllSubmissionsForAssignment
 // ─────────────────────────────────────────────────────────────────────────────
 
-// ListAllSubmissionsForAssignment returns every submission version for the
-// given assignment, across all owners, ordered newest-first.  No user_id or
-// group_id filter is applied — this is the instructor/admin view.
+// ListAllSubmissionsForAssignment returns a page of the submission versions
+// for the given assignment, across all owners, filtered and sorted by spec.
+// No user_id or group_id scope is required — this is the instructor/admin
+// view.
 func (s *submissionService) ListAllSubmissionsForAssignment(
 	assignmentID uuid.UUID,
-) ([]domain.Submission, error) {
+	spec queryspec.Spec,
+) (queryspec.Page[domain.Submission], error) {
 	if assignmentID == uuid.Nil {
-		return nil, utils.ErrBadRequest("assignment_id is required")
+		return queryspec.Page[domain.Submission]{}, utils.ErrBadRequest("assignment_id is required")
 	}
 
-	submissions, err := s.submissionRepo.ListSubmissions(assignmentID, nil, nil)
+	page, err := s.submissionRepo.ListSubmissionsPage(assignmentID, spec)
 	if err != nil {
 		s.logger.Error("failed to list all submissions for assignment",
 			zap.String("assignment_id", assignmentID.String()),
 			zap.Error(err),
 		)
-		return nil, utils.ErrInternal("failed to list submissions", err)
+		return queryspec.Page[domain.Submission]{}, utils.ErrInternal("failed to list submissions", err)
 	}
 
-	return submissions, nil
+	return page, nil
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -646,7 +669,7 @@ func (s *submissionService) RunCode(
 			return nil, utils.ErrBadRequest("assignment is not active")
 		}
 
-		enrolled, enrollErr := s.academicClient.IsEnrolled(
+		enrolled, enrollErr := s.courseAccess.IsEnrolled(
 			userID.String(),
 			assignment.CourseInstanceID.String(),
 		)
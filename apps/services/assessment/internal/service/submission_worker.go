@@ -36,6 +36,7 @@ type SubmissionWorker struct {
 	auditClient    *client.AuditClient
 	judge0Client   *client.Judge0Client
 	evalService    EvaluationService
+	observer       SubmissionObserver
 	db             *gorm.DB
 	logger         *zap.Logger
 }
@@ -49,6 +50,7 @@ func NewSubmissionWorker(
 	auditClient *client.AuditClient,
 	judge0Client *client.Judge0Client,
 	evalService EvaluationService,
+	observer SubmissionObserver,
 	db *gorm.DB,
 	logger *zap.Logger,
 ) queue.JobProcessor {
@@ -59,6 +61,7 @@ func NewSubmissionWorker(
 		auditClient:    auditClient,
 		judge0Client:   judge0Client,
 		evalService:    evalService,
+		observer:       observer,
 		db:             db,
 		logger:         logger,
 	}
@@ -219,6 +222,7 @@ func (w *SubmissionWorker) Process(ctx context.Context, job queue.SubmissionJob)
 					zap.String("status", execResult.Status.Description),
 					zap.String("time", execResult.Time),
 				)
+				w.observer.SubmissionChanged()
 			}
 		}
 	}
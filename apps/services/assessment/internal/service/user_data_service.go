@@ -0,0 +1,242 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/storage"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/assessment/internal/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Interface
+// ─────────────────────────────────────────────────────────────────────────────
+
+// UserDataService handles requests from IAM about a user's assessment data
+// as a whole, such as exporting it for a subject access request or erasing it.
+type UserDataService interface {
+	// ExportUserData returns a zip archive holding the user's records as
+	// data.json together with the code behind their submissions and
+	// repositories.
+	ExportUserData(
+		ctx context.Context,
+		userID uuid.UUID,
+		clientID, ipAddress, userAgent string,
+	) ([]byte, error)
+
+	// EraseUserData removes the user's records and their stored code, or with
+	// retainGrades keeps the graded rows and clears only what the user
+	// authored. Either way the stored code is deleted. Erasing a user with no
+	// records succeeds, so IAM can safely retry.
+	EraseUserData(
+		ctx context.Context,
+		userID uuid.UUID,
+		retainGrades bool,
+		clientID, ipAddress, userAgent string,
+	) (*repository.UserDataErasure, error)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Implementation
+// ─────────────────────────────────────────────────────────────────────────────
+
+type userDataService struct {
+	userDataRepo repository.UserDataRepository
+	minio        *storage.MinIOStorage
+	codeStorage  *storage.SeaweedGitStorage
+	auditClient  *client.AuditClient
+	logger       *zap.Logger
+}
+
+// NewUserDataService wires all dependencies and returns a UserDataService.
+// Either storage may be nil when it is not configured.
+func NewUserDataService(
+	userDataRepo repository.UserDataRepository,
+	minio *storage.MinIOStorage,
+	codeStorage *storage.SeaweedGitStorage,
+	auditClient *client.AuditClient,
+	logger *zap.Logger,
+) UserDataService {
+	return &userDataService{
+		userDataRepo: userDataRepo,
+		minio:        minio,
+		codeStorage:  codeStorage,
+		auditClient:  auditClient,
+		logger:       logger,
+	}
+}
+
+func (s *userDataService) ExportUserData(
+	ctx context.Context,
+	userID uuid.UUID,
+	clientID, ipAddress, userAgent string,
+) ([]byte, error) {
+	if userID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+
+	export, err := s.userDataRepo.ExportUser(userID)
+	if err != nil {
+		s.logger.Error("failed to export user data", zap.Error(err))
+		return nil, utils.ErrInternal("failed to export user data", err)
+	}
+
+	archive, err := s.buildExportArchive(ctx, userID, export)
+	if err != nil {
+		s.logger.Error("failed to build user data archive",
+			zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, utils.ErrInternal("failed to export user data", err)
+	}
+
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionUserDataExported),
+		"user",
+		userID.String(),
+		0,
+		clientID,
+		nil,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log for user data export", zap.Error(auditErr))
+	}
+
+	return archive, nil
+}
+
+// buildExportArchive writes data.json, submissions/<submission_id>/<file> and
+// code/<assignment_id>/<path> entries. A missing object fails the export so
+// the archive is never silently incomplete.
+func (s *userDataService) buildExportArchive(
+	ctx context.Context,
+	userID uuid.UUID,
+	export *repository.UserDataExport,
+) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding data.json: %w", err)
+	}
+	if err := writeZipEntry(zw, "data.json", data); err != nil {
+		return nil, err
+	}
+
+	if s.minio != nil {
+		for _, sub := range export.Submissions {
+			if sub.StoragePath == "" {
+				continue
+			}
+			code, err := s.minio.GetSubmissionCode(ctx, sub.StoragePath)
+			if err != nil {
+				return nil, err
+			}
+			name := fmt.Sprintf("submissions/%s/%s", sub.ID, path.Base(sub.StoragePath))
+			if err := writeZipEntry(zw, name, []byte(code)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.codeStorage != nil {
+		for _, repo := range export.CodeRepos {
+			files, err := s.codeStorage.ReadRepoFiles(ctx, repo.AssignmentID.String(), userID.String())
+			if err != nil {
+				return nil, err
+			}
+			for filePath, content := range files {
+				name := fmt.Sprintf("code/%s/%s", repo.AssignmentID, filePath)
+				if err := writeZipEntry(zw, name, content); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("closing archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeZipEntry(zw *zip.Writer, name string, content []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", name, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *userDataService) EraseUserData(
+	ctx context.Context,
+	userID uuid.UUID,
+	retainGrades bool,
+	clientID, ipAddress, userAgent string,
+) (*repository.UserDataErasure, error) {
+	if userID == uuid.Nil {
+		return nil, utils.ErrBadRequest("user_id is required")
+	}
+
+	erasure, err := s.userDataRepo.EraseUser(userID, retainGrades)
+	if err != nil {
+		s.logger.Error("failed to erase user data", zap.Error(err))
+		return nil, utils.ErrInternal("failed to erase user data", err)
+	}
+
+	// The rows no longer point at the objects, so a failure here leaves orphans
+	// rather than reachable data; it is logged for cleanup instead of failing
+	// the request.
+	if s.minio != nil && len(erasure.SubmissionPaths) > 0 {
+		if err := s.minio.DeleteSubmissions(ctx, erasure.SubmissionPaths); err != nil {
+			s.logger.Warn("failed to delete submission objects",
+				zap.String("user_id", userID.String()), zap.Error(err))
+		}
+	}
+	if s.codeStorage != nil {
+		for _, assignmentID := range erasure.CodeAssignmentIDs {
+			if err := s.codeStorage.DeleteRepo(ctx, assignmentID.String(), userID.String()); err != nil {
+				s.logger.Warn("failed to delete code repository",
+					zap.String("user_id", userID.String()),
+					zap.String("assignment_id", assignmentID.String()),
+					zap.Error(err))
+			}
+		}
+	}
+
+	changes := map[string]interface{}{
+		"submissions":      erasure.Submissions,
+		"regrade_requests": erasure.RegradeRequests,
+		"code_repos":       erasure.CodeRepos,
+		"code_versions":    erasure.CodeVersions,
+		"groups_left":      erasure.GroupsLeft,
+		"retain_grades":    retainGrades,
+	}
+	if auditErr := s.auditClient.LogAction(
+		string(client.AuditActionUserDataErased),
+		"user",
+		userID.String(),
+		0,
+		clientID,
+		changes,
+		nil,
+		ipAddress,
+		userAgent,
+	); auditErr != nil {
+		s.logger.Warn("failed to write audit log for user data erasure", zap.Error(auditErr))
+	}
+
+	return erasure, nil
+}
@@ -194,6 +194,58 @@ func (s *SeaweedGitStorage) DeleteFile(ctx context.Context, assignmentID, userID
 	return nil
 }
 
+// DeleteRepo removes every object of a user's repository for an assignment.
+func (s *SeaweedGitStorage) DeleteRepo(ctx context.Context, assignmentID, userID string) error {
+	objCh := s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{
+		Prefix:    s.repoPath(assignmentID, userID) + "/",
+		Recursive: true,
+	})
+
+	for obj := range objCh {
+		if obj.Err != nil {
+			return fmt.Errorf("listing repo objects: %w", obj.Err)
+		}
+		if err := s.client.RemoveObject(ctx, s.bucketName, obj.Key, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("deleting repo object %q: %w", obj.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadRepoFiles returns the content of every working file in a user's
+// repository for an assignment, keyed by path relative to the repository.
+func (s *SeaweedGitStorage) ReadRepoFiles(ctx context.Context, assignmentID, userID string) (map[string][]byte, error) {
+	prefix := s.filesPath(assignmentID, userID) + "/"
+	objCh := s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	})
+
+	files := make(map[string][]byte)
+	for obj := range objCh {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("listing repo files: %w", obj.Err)
+		}
+		if strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+
+		reader, err := s.client.GetObject(ctx, s.bucketName, obj.Key, minio.GetObjectOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("getting file %q: %w", obj.Key, err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading file %q: %w", obj.Key, err)
+		}
+		files[strings.TrimPrefix(obj.Key, prefix)] = content
+	}
+
+	return files, nil
+}
+
 type CommitInfo struct {
 	SHA     string
 	Message string
@@ -127,3 +127,14 @@ func (s *MinIOStorage) GetSubmissionCode(ctx context.Context, storagePath string
 
 	return string(buf), nil
 }
+
+// DeleteSubmissions removes the stored code for the given storage paths.
+// Paths that no longer exist are ignored.
+func (s *MinIOStorage) DeleteSubmissions(ctx context.Context, storagePaths []string) error {
+	for _, path := range storagePaths {
+		if err := s.client.RemoveObject(ctx, s.bucketName, path, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("removing object %q from minio: %w", path, err)
+		}
+	}
+	return nil
+}
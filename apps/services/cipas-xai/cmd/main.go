@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -125,6 +126,15 @@ func errorHandler(c fiber.Ctx, err error) error {
 	// Log the error
 	fmt.Printf("Error: %v\n", err)
 
+	// Client errors, such as requests the OpenAPI validator rejects, keep
+	// their status and message
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && fiberErr.Code < fiber.StatusInternalServerError {
+		return c.Status(fiberErr.Code).JSON(fiber.Map{
+			"error": fiberErr.Message,
+		})
+	}
+
 	// Return JSON error response
 	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 		"error": "Internal server error",
@@ -4,6 +4,7 @@ go 1.25.0
 
 require (
 	github.com/4yrg/gradeloop-core-v2/packages/go/env v1.0.0
+	github.com/4yrg/gradeloop-core-v2/packages/go/openapi v0.0.0-00010101000000-000000000000
 	github.com/gofiber/fiber/v3 v3.0.0-rc.1
 	go.uber.org/zap v1.26.0
 )
@@ -39,3 +40,5 @@ require (
 	golang.org/x/sys v0.35.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 )
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/openapi => ../../../packages/go/openapi
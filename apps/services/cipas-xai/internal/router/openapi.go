@@ -0,0 +1,69 @@
+package router
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/cipas/cipas-xai/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+)
+
+// OpenAPI returns the CIPAS XAI service's OpenAPI document, served at
+// /openapi.json and enforced on requests by SetupRoutes. Every route
+// SetupRoutes registers must be listed in routes: TestOpenAPIMatchesRoutes
+// fails the build when the two disagree.
+func OpenAPI() *openapi.Document {
+	return openapi.Spec{
+		Info: openapi.Info{
+			Title:       "GradeLoop CIPAS XAI Service",
+			Description: "AI tutor chat.",
+			Version:     "1.0.0",
+		},
+		Error:  openapi.Fields{"error": ""},
+		Routes: routes,
+	}.MustBuild()
+}
+
+var routes = []openapi.Route{
+
+	// service
+	{
+		Method: fiber.MethodGet, Path: "/", Name: "getServiceInfo",
+		Summary: "Service information", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"service": "", "version": "", "status": ""},
+		},
+	},
+
+	// chat
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/chat", Name: "chat",
+		Summary: "Ask the tutor", Tag: "chat", Auth: openapi.AuthPublic,
+		Body: dto.ChatRequest{},
+		Responses: openapi.Responses{
+			200: dto.ChatResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/chat/stream", Name: "chatStream",
+		Summary: "Ask the tutor, streaming the answer as server-sent events", Tag: "chat", Auth: openapi.AuthPublic,
+		Body: dto.ChatRequest{},
+		Responses: openapi.Responses{
+			200: openapi.Binary{"text/event-stream"},
+		},
+	},
+
+	// health
+	{
+		Method: fiber.MethodGet, Path: "/health", Name: "healthCheck",
+		Summary: "Health check", Tag: "health", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"status": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/openapi.json", Name: "getOpenAPI",
+		Summary: "OpenAPI document", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Any,
+		},
+	},
+}
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+)
+
+var update = flag.Bool("update", false, "rewrite docs/openapi.json from the spec")
+
+const specFile = "../../docs/openapi.json"
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, Config{})
+	openapi.AssertRoutesDocumented(t, app, OpenAPI())
+}
+
+func TestOpenAPIDocumentIsCurrent(t *testing.T) {
+	openapi.AssertDocumentCurrent(t, OpenAPI(), specFile, *update)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/cipas/cipas-xai/internal/handler"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
 	"github.com/gofiber/fiber/v3"
 )
 
@@ -10,6 +11,12 @@ type Config struct {
 }
 
 func SetupRoutes(app *fiber.App, cfg Config) {
+	// Requests are checked against the OpenAPI document before any route
+	// handles them; see openapi.go.
+	doc := OpenAPI()
+	app.Use(openapi.Validate(doc))
+	app.Get("/openapi.json", openapi.Serve(doc))
+
 	// Register chat routes
 	cfg.ChatHandler.RegisterRoutes(app)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/infrastructure"
 	infra "github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/infrastructure/rabbitmq"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/router"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/worker"
 	"github.com/gofiber/fiber/v3"
@@ -91,26 +92,16 @@ func run() error {
 	go emailWorker.Start(ctx)
 
 	// 6. Setup Fiber App
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		ErrorHandler: router.ErrorHandler,
+	})
 
 	// Middleware
 	app.Use(logger.New())
 	app.Use(cors.New())
 
 	// Routes
-	api := app.Group("/api/v1/emails")
-
-	api.Post("/send", emailHandler.SendEmail)
-	api.Post("/templates", emailHandler.CreateTemplate)
-	api.Get("/templates/:id", emailHandler.GetTemplate)
-	api.Get("/status/:id", emailHandler.GetStatus)
-
-	app.Get("/health", func(c fiber.Ctx) error {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"status":  "ok",
-			"service": "email-service",
-		})
-	})
+	router.SetupRoutes(app, router.Config{EmailHandler: emailHandler})
 
 	log.Printf("Email Service starting on port %s", cfg.App.Port)
 	return app.Listen(":" + cfg.App.Port)
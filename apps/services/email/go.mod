@@ -4,6 +4,7 @@ go 1.25.0
 
 require (
 	github.com/4yrg/gradeloop-core-v2/packages/go/env v1.0.0
+	github.com/4yrg/gradeloop-core-v2/packages/go/openapi v0.0.0-00010101000000-000000000000
 	github.com/gofiber/fiber/v3 v3.0.0
 	github.com/google/uuid v1.6.0
 	github.com/rabbitmq/amqp091-go v1.10.0
@@ -58,3 +59,5 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	gorm.io/driver/mysql v1.5.6 // indirect
 )
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/openapi => ../../../packages/go/openapi
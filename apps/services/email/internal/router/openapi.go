@@ -0,0 +1,104 @@
+package router
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// OpenAPI returns the email service's OpenAPI document, served at
+// /openapi.json and enforced on requests by SetupRoutes. Every route
+// SetupRoutes registers must be listed in routes: TestOpenAPIMatchesRoutes
+// fails the build when the two disagree.
+func OpenAPI() *openapi.Document {
+	return openapi.Spec{
+		Info: openapi.Info{
+			Title:       "GradeLoop Email Service",
+			Description: "Event-driven email delivery from templates.",
+			Version:     "1.0.0",
+		},
+		Error:  openapi.Fields{"error": ""},
+		Routes: routes,
+	}.MustBuild()
+}
+
+var (
+	// sendEmailBody is the body of a send request. SendEmail also accepts
+	// "template", "to" and camelCase body keys, and treats unknown keys as
+	// template variables, so no field is required and others are allowed.
+	sendEmailBody = &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"template_name": openapi.String,
+			"subject":       openapi.String,
+			"body_html":     openapi.String,
+			"body_text":     openapi.String,
+			"recipients":    {Type: "array", Items: &openapi.Schema{Type: "string", Format: "email"}},
+			"variables":     {Type: "object"},
+		},
+	}
+	// createTemplateBody is the body of a create template request.
+	createTemplateBody = &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"name":      openapi.String,
+			"subject":   openapi.String,
+			"body_html": openapi.String,
+			"body_text": openapi.String,
+		},
+	}
+)
+
+var routes = []openapi.Route{
+
+	// emails
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/emails/send", Name: "sendEmail",
+		Summary: "Queue an email for sending", Tag: "emails", Auth: openapi.AuthPublic,
+		Body: sendEmailBody,
+		Responses: openapi.Responses{
+			202: openapi.Fields{"message": "", "id": uuid.UUID{}, "status": domain.StatusPending},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/emails/status/:id", Name: "getEmailStatus",
+		Summary: "Get email status", Tag: "emails", Auth: openapi.AuthPublic,
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: domain.EmailMessage{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/emails/templates", Name: "createTemplate",
+		Summary: "Create template", Tag: "emails", Auth: openapi.AuthPublic,
+		Body: createTemplateBody,
+		Responses: openapi.Responses{
+			201: domain.EmailTemplate{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/emails/templates/:id", Name: "getTemplate",
+		Summary: "Get template", Tag: "emails", Auth: openapi.AuthPublic,
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: domain.EmailTemplate{},
+		},
+	},
+
+	// health
+	{
+		Method: fiber.MethodGet, Path: "/health", Name: "healthCheck",
+		Summary: "Health check", Tag: "health", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"status": "", "service": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/openapi.json", Name: "getOpenAPI",
+		Summary: "OpenAPI document", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Any,
+		},
+	},
+}
@@ -0,0 +1,48 @@
+package router
+
+import (
+	"errors"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/email/internal/delivery/http"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+)
+
+type Config struct {
+	EmailHandler *http.Handler
+}
+
+func SetupRoutes(app *fiber.App, cfg Config) {
+	// Requests are checked against the OpenAPI document before any route
+	// handles them; see openapi.go.
+	doc := OpenAPI()
+	app.Use(openapi.Validate(doc))
+	app.Get("/openapi.json", openapi.Serve(doc))
+
+	api := app.Group("/api/v1/emails")
+
+	api.Post("/send", cfg.EmailHandler.SendEmail)
+	api.Post("/templates", cfg.EmailHandler.CreateTemplate)
+	api.Get("/templates/:id", cfg.EmailHandler.GetTemplate)
+	api.Get("/status/:id", cfg.EmailHandler.GetStatus)
+
+	app.Get("/health", func(c fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":  "ok",
+			"service": "email-service",
+		})
+	})
+}
+
+// ErrorHandler writes errors that reach the app, such as requests rejected
+// by the OpenAPI validator, in the {"error": "..."} shape the handlers use.
+func ErrorHandler(c fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	message := "Internal server error"
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		code = fiberErr.Code
+		message = fiberErr.Message
+	}
+	return c.Status(code).JSON(fiber.Map{"error": message})
+}
@@ -100,12 +100,23 @@ func run() error {
 
 	authRepo := repository.NewAuthRepository(db.DB)
 	userRepo := repository.NewUserRepository(db.DB)
+	ssoRepo := repository.NewSSORepository(db.DB)
+	rbacRepo := repository.NewRBACRepository(db.DB)
+	serviceClientRepo := repository.NewServiceClientRepository(db.DB)
+	auditLogRepo := repository.NewAuditLogRepository(db.DB)
+	accessTokenRepo := repository.NewPersonalAccessTokenRepository(db.DB)
+	bulkImportRepo := repository.NewBulkImportRepository(db.DB)
+	lifecycleRepo := repository.NewLifecycleRepository(db.DB)
+	dataRequestRepo := repository.NewDataRequestRepository(db.DB)
 
 	baseService := service.NewBaseService(db.DB)
 	defer baseService.Close()
 
 	// Initialize email client
 	emailClient := client.NewEmailClient(cfg.EmailServiceURL)
+	academicClient := client.NewAcademicClient(cfg.AcademicServiceURL, cfg.JWT.SecretKey)
+	assessmentClient := client.NewAssessmentClient(cfg.AssessmentServiceURL, cfg.JWT.SecretKey)
+	notificationClient := client.NewNotificationClient(cfg.NotificationServiceURL, cfg.JWT.SecretKey)
 
 	jwtInstance := jwt.NewJWT(
 		cfg.JWT.SecretKey,
@@ -113,9 +124,16 @@ func run() error {
 		cfg.JWT.RefreshTokenExpiry,
 	)
 
+	authorizationService := service.NewAuthorizationService(
+		rbacRepo,
+		userRepo,
+		cfg.JWT.SecretKey,
+	)
+
 	authService := service.NewAuthService(
 		db.DB,
 		authRepo,
+		authorizationService,
 		jwtInstance,
 		cfg.JWT.SecretKey,
 		cfg.JWT.RefreshTokenExpiry,
@@ -144,11 +162,63 @@ func run() error {
 	bulkImportService := service.NewBulkImportService(
 		db.DB,
 		userRepo,
+		bulkImportRepo,
 		userService,
+		academicClient,
+	)
+
+	// Process bulk user imports in the background, resuming any left
+	// unfinished by a previous run.
+	importCtx, stopImports := context.WithCancel(context.Background())
+	defer stopImports()
+	go bulkImportService.Run(importCtx)
+
+	lifecycleService := service.NewLifecycleService(
+		lifecycleRepo,
+		academicClient,
+		assessmentClient,
+		notificationClient,
+		cfg.Lifecycle,
 	)
 
+	// Evaluate account lifecycle rules on the configured interval.
+	lifecycleCtx, stopLifecycle := context.WithCancel(context.Background())
+	defer stopLifecycle()
+	go lifecycleService.Run(lifecycleCtx)
+
 	githubService := service.NewGitHubService(cfg.GitHub)
 
+	serviceClientService := service.NewServiceClientService(
+		serviceClientRepo,
+		cfg.JWT.SecretKey,
+		cfg.ServiceToken.Expiry,
+	)
+	if err := serviceClientService.RegisterConfigured(context.Background(), cfg.ServiceToken.Clients); err != nil {
+		return fmt.Errorf("registering service clients: %w", err)
+	}
+
+	auditLogService := service.NewAuditLogService(auditLogRepo)
+
+	accessTokenService := service.NewPersonalAccessTokenService(
+		accessTokenRepo,
+		userRepo,
+		authorizationService,
+		cfg.AccessToken.MaxLifetime,
+	)
+
+	ssoService, err := service.NewSSOService(
+		context.Background(),
+		db.DB,
+		ssoRepo,
+		userRepo,
+		authService,
+		cfg.SSO,
+		&http.Client{Timeout: 10 * time.Second},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing sso: %w", err)
+	}
+
 	minioStorage, err := storage.NewMinIOStorage(
 		cfg.MinIO.Endpoint,
 		cfg.MinIO.AccessKey,
@@ -162,6 +232,34 @@ func run() error {
 		return fmt.Errorf("connecting to minio: %w", err)
 	}
 
+	exportStorage, err := storage.NewExportStorage(
+		cfg.MinIO.Endpoint,
+		cfg.MinIO.AccessKey,
+		cfg.MinIO.SecretKey,
+		cfg.MinIO.ExportBucket,
+		cfg.MinIO.UseSSL,
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("connecting to minio export bucket: %w", err)
+	}
+
+	dataRequestService := service.NewDataRequestService(
+		dataRequestRepo,
+		userRepo,
+		lifecycleRepo,
+		exportStorage,
+		academicClient,
+		assessmentClient,
+		notificationClient,
+		cfg.DataRequest.ExportExpiry,
+	)
+
+	// Process personal data exports and erasures in the background.
+	dataRequestCtx, stopDataRequests := context.WithCancel(context.Background())
+	defer stopDataRequests()
+	go dataRequestService.Run(dataRequestCtx)
+
 	healthHandler := handler.NewHealthHandler()
 	authHandler := handler.NewAuthHandler(
 		authService,
@@ -174,6 +272,18 @@ func run() error {
 	)
 	userHandler := handler.NewUserHandler(userService, minioStorage)
 	bulkImportHandler := handler.NewBulkImportHandler(bulkImportService)
+	ssoHandler := handler.NewSSOHandler(
+		ssoService,
+		cfg.FrontendURL,
+		cfg.JWT.CookieSecure,
+		cfg.JWT.CookieSameSite,
+		cfg.JWT.RefreshTokenExpiry,
+	)
+	rbacHandler := handler.NewRBACHandler(authorizationService, accessTokenService)
+	accessTokenHandler := handler.NewPersonalAccessTokenHandler(accessTokenService)
+	serviceClientHandler := handler.NewServiceClientHandler(serviceClientService, auditLogService)
+	lifecycleHandler := handler.NewLifecycleHandler(lifecycleService)
+	dataRequestHandler := handler.NewDataRequestHandler(dataRequestService)
 
 	app := fiber.New(fiber.Config{
 		AppName:      "iam-service",
@@ -190,11 +300,18 @@ func run() error {
 	}))
 
 	router.SetupRoutes(app, router.Config{
-		HealthHandler:     healthHandler,
-		AuthHandler:       authHandler,
-		UserHandler:       userHandler,
-		BulkImportHandler: bulkImportHandler,
-		JWTSecretKey:      []byte(cfg.JWT.SecretKey),
+		HealthHandler:        healthHandler,
+		AuthHandler:          authHandler,
+		UserHandler:          userHandler,
+		BulkImportHandler:    bulkImportHandler,
+		SSOHandler:           ssoHandler,
+		RBACHandler:          rbacHandler,
+		ServiceClientHandler: serviceClientHandler,
+		AccessTokenHandler:   accessTokenHandler,
+		LifecycleHandler:     lifecycleHandler,
+		DataRequestHandler:   dataRequestHandler,
+		TokenVerifier:        accessTokenService,
+		JWTSecretKey:         []byte(cfg.JWT.SecretKey),
 	})
 
 	sigChan := make(chan os.Signal, 1)
@@ -3,7 +3,13 @@ module github.com/4yrg/gradeloop-core-v2/apps/services/iam
 go 1.25.0
 
 require (
+	github.com/4yrg/gradeloop-core-v2/packages/go/authz v0.0.0-00010101000000-000000000000
 	github.com/4yrg/gradeloop-core-v2/packages/go/env v1.0.0
+	github.com/4yrg/gradeloop-core-v2/packages/go/openapi v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/pat v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken v0.0.0-00010101000000-000000000000
+	github.com/beevik/etree v1.5.0
+	github.com/crewjam/saml v0.5.1
 	github.com/gofiber/fiber/v3 v3.0.0-rc.1
 	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/google/uuid v1.6.0
@@ -27,6 +33,12 @@ replace github.com/4yrg/gradeloop-core-v2/packages/go/grpc => ../../../packages/
 
 replace github.com/4yrg/gradeloop-core-v2/packages/go/env => ../../../packages/go/env
 
+replace github.com/4yrg/gradeloop-core-v2/packages/go/authz => ../../../packages/go/authz
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken => ../../../packages/go/servicetoken
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/pat => ../../../packages/go/pat
+
 replace (
 	github.com/gradeloop/packages/go/errors => ../../../packages/go/errors
 	github.com/gradeloop/packages/go/grpc => ../../../packages/go/grpc
@@ -41,15 +53,18 @@ require (
 	github.com/go-ini/ini v1.67.0 // indirect
 	github.com/gofiber/schema v1.6.0 // indirect
 	github.com/gofiber/utils/v2 v2.0.0-rc.1 // indirect
+	github.com/golang-jwt/jwt/v4 v4.5.2 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
 	github.com/jackc/pgx/v5 v5.4.3 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/joho/godotenv v1.5.1 // indirect
+	github.com/jonboulle/clockwork v0.2.2 // indirect
 	github.com/klauspost/compress v1.18.4 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.11 // indirect
 	github.com/klauspost/crc32 v1.3.0 // indirect
+	github.com/mattermost/xml-roundtrip-validator v0.1.0 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/minio/crc64nvme v1.1.1 // indirect
@@ -59,6 +74,7 @@ require (
 	github.com/richardlehane/msoleps v1.0.6 // indirect
 	github.com/rogpeppe/go-internal v1.14.1 // indirect
 	github.com/rs/xid v1.6.0 // indirect
+	github.com/russellhaering/goxmldsig v1.4.0 // indirect
 	github.com/tiendc/go-deepcopy v1.7.2 // indirect
 	github.com/tinylib/msgp v1.6.1 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
@@ -71,3 +87,5 @@ require (
 	golang.org/x/sys v0.41.0 // indirect
 	golang.org/x/text v0.34.0 // indirect
 )
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/openapi => ../../../packages/go/openapi
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+)
+
+// AcademicClient handles calls from IAM to the academic service's internal
+// endpoints.
+type AcademicClient struct {
+	baseURL    string
+	secretKey  []byte
+	httpClient *http.Client
+}
+
+// addBatchMemberRequest is the body of POST /api/v1/internal/batch-members.
+type addBatchMemberRequest struct {
+	BatchID uuid.UUID `json:"batch_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+// GraduatedUser is a user whose batches have all ended.
+type GraduatedUser struct {
+	UserID  uuid.UUID `json:"user_id"`
+	EndYear int       `json:"end_year"`
+}
+
+// graduatedMembersResponse is the body of GET /api/v1/internal/graduated-members.
+type graduatedMembersResponse struct {
+	Members []GraduatedUser `json:"members"`
+	Count   int             `json:"count"`
+}
+
+// NewAcademicClient creates a new academic service client instance
+func NewAcademicClient(baseURL, secretKey string) *AcademicClient {
+	return &AcademicClient{
+		baseURL:   baseURL,
+		secretKey: []byte(secretKey),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// AddBatchMember places a user in a batch. An existing membership counts as
+// success.
+func (c *AcademicClient) AddBatchMember(ctx context.Context, batchID, userID uuid.UUID) error {
+	return serviceRequest(ctx, c.httpClient, c.secretKey, "academic",
+		http.MethodPost, c.baseURL+"/api/v1/internal/batch-members",
+		servicetoken.ScopeBatchMemberWrite,
+		addBatchMemberRequest{BatchID: batchID, UserID: userID}, nil)
+}
+
+// ListGraduatedUsers returns users whose batches all ended in maxEndYear or
+// earlier.
+func (c *AcademicClient) ListGraduatedUsers(ctx context.Context, maxEndYear int) ([]GraduatedUser, error) {
+	var resp graduatedMembersResponse
+	url := fmt.Sprintf("%s/api/v1/internal/graduated-members?max_end_year=%d", c.baseURL, maxEndYear)
+	if err := serviceRequest(ctx, c.httpClient, c.secretKey, "academic",
+		http.MethodGet, url, servicetoken.ScopeBatchMemberRead, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Members, nil
+}
+
+// ExportUserData returns the user's batch memberships, enrollments and
+// teaching assignments as the academic service's JSON document.
+func (c *AcademicClient) ExportUserData(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	var data []byte
+	if err := serviceRequest(ctx, c.httpClient, c.secretKey, "academic",
+		http.MethodGet, c.baseURL+"/api/v1/internal/users/"+userID.String()+"/data",
+		servicetoken.ScopeAcademicUserRead, nil, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// EraseUserData removes the user's batch memberships, enrollments and
+// teaching assignments. With retainGrades the enrollments and batch
+// memberships are kept for the academic record. Erasing a user with no data
+// succeeds.
+func (c *AcademicClient) EraseUserData(ctx context.Context, userID uuid.UUID, retainGrades bool) error {
+	url := c.baseURL + "/api/v1/internal/users/" + userID.String()
+	if retainGrades {
+		url += "?retain_grades=true"
+	}
+	return serviceRequest(ctx, c.httpClient, c.secretKey, "academic",
+		http.MethodDelete, url, servicetoken.ScopeAcademicUserErase, nil, nil)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+)
+
+const testSecret = "test-secret"
+
+// requireScope fails the test unless the request carries a service token
+// signed with testSecret that grants scope.
+func requireScope(t *testing.T, r *http.Request, scope string) {
+	t.Helper()
+	claims, err := servicetoken.Verify([]byte(testSecret), strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	if err != nil {
+		t.Fatalf("invalid service token: %v", err)
+	}
+	if !claims.HasScope(scope) {
+		t.Errorf("expected scope %s, got %q", scope, claims.Scope)
+	}
+}
+
+func TestListGraduatedUsers_Success(t *testing.T) {
+	userID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/internal/graduated-members" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("max_end_year"); got != "2024" {
+			t.Errorf("expected max_end_year 2024, got %s", got)
+		}
+		requireScope(t, r, servicetoken.ScopeBatchMemberRead)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"members": []GraduatedUser{{UserID: userID, EndYear: 2023}},
+			"count":   1,
+		})
+	}))
+	defer server.Close()
+
+	users, err := NewAcademicClient(server.URL, testSecret).ListGraduatedUsers(context.Background(), 2024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 1 || users[0].UserID != userID || users[0].EndYear != 2023 {
+		t.Errorf("unexpected graduated users: %+v", users)
+	}
+}
+
+func TestEraseUserData_Success(t *testing.T) {
+	userID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/internal/users/"+userID.String() {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query, got %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewAcademicClient(server.URL, testSecret).EraseUserData(context.Background(), userID, false); err != nil {
+		t.Fatalf("academic: unexpected error: %v", err)
+	}
+	if err := NewAssessmentClient(server.URL, testSecret).EraseUserData(context.Background(), userID, false); err != nil {
+		t.Fatalf("assessment: unexpected error: %v", err)
+	}
+}
+
+func TestEraseUserData_RetainGrades(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("retain_grades"); got != "true" {
+			t.Errorf("expected retain_grades=true, got %q", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewAcademicClient(server.URL, testSecret).EraseUserData(context.Background(), uuid.New(), true); err != nil {
+		t.Fatalf("academic: unexpected error: %v", err)
+	}
+	if err := NewAssessmentClient(server.URL, testSecret).EraseUserData(context.Background(), uuid.New(), true); err != nil {
+		t.Fatalf("assessment: unexpected error: %v", err)
+	}
+}
+
+func TestExportUserData_ReturnsRawBody(t *testing.T) {
+	userID := uuid.New()
+	archive := []byte("PK\x03\x04 not json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/internal/users/"+userID.String()+"/data" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		requireScope(t, r, servicetoken.ScopeAssessmentUserRead)
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	got, err := NewAssessmentClient(server.URL, testSecret).ExportUserData(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != string(archive) {
+		t.Errorf("expected raw archive bytes, got %q", got)
+	}
+}
+
+func TestEraseUserData_ServiceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requireScope(t, r, servicetoken.ScopeAssessmentUserErase)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"message": "database unavailable"})
+	}))
+	defer server.Close()
+
+	err := NewAssessmentClient(server.URL, testSecret).EraseUserData(context.Background(), uuid.New(), false)
+	if err == nil {
+		t.Fatal("expected error for server error, got nil")
+	}
+	if err.Error() != "assessment service error: database unavailable" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+)
+
+// AssessmentClient handles calls from IAM to the assessment service's
+// internal endpoints.
+type AssessmentClient struct {
+	baseURL    string
+	secretKey  []byte
+	httpClient *http.Client
+}
+
+// NewAssessmentClient creates a new assessment service client instance
+func NewAssessmentClient(baseURL, secretKey string) *AssessmentClient {
+	return &AssessmentClient{
+		baseURL:   baseURL,
+		secretKey: []byte(secretKey),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// ExportUserData returns a zip archive of the user's submissions, submitted
+// code and code repositories.
+func (c *AssessmentClient) ExportUserData(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	var archive []byte
+	if err := serviceRequest(ctx, c.httpClient, c.secretKey, "assessment",
+		http.MethodGet, c.baseURL+"/api/v1/internal/users/"+userID.String()+"/data",
+		servicetoken.ScopeAssessmentUserRead, nil, &archive); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// EraseUserData removes the user's submissions, code repositories and group
+// memberships. With retainGrades graded rows are kept but stripped of stored
+// code and authored text. Erasing a user with no data succeeds.
+func (c *AssessmentClient) EraseUserData(ctx context.Context, userID uuid.UUID, retainGrades bool) error {
+	url := c.baseURL + "/api/v1/internal/users/" + userID.String()
+	if retainGrades {
+		url += "?retain_grades=true"
+	}
+	return serviceRequest(ctx, c.httpClient, c.secretKey, "assessment",
+		http.MethodDelete, url, servicetoken.ScopeAssessmentUserErase, nil, nil)
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+)
+
+// NotificationClient handles calls from IAM to the notification service's
+// internal endpoints.
+type NotificationClient struct {
+	baseURL    string
+	secretKey  []byte
+	httpClient *http.Client
+}
+
+// NewNotificationClient creates a new notification service client instance
+func NewNotificationClient(baseURL, secretKey string) *NotificationClient {
+	return &NotificationClient{
+		baseURL:   baseURL,
+		secretKey: []byte(secretKey),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// ExportUserData returns the user's notifications as the notification
+// service's JSON document.
+func (c *NotificationClient) ExportUserData(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	var data []byte
+	if err := serviceRequest(ctx, c.httpClient, c.secretKey, "notification",
+		http.MethodGet, c.baseURL+"/api/v1/internal/users/"+userID.String()+"/data",
+		servicetoken.ScopeNotificationUserRead, nil, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// EraseUserData deletes the user's notifications. Erasing a user with no
+// notifications succeeds.
+func (c *NotificationClient) EraseUserData(ctx context.Context, userID uuid.UUID) error {
+	return serviceRequest(ctx, c.httpClient, c.secretKey, "notification",
+		http.MethodDelete, c.baseURL+"/api/v1/internal/users/"+userID.String(),
+		servicetoken.ScopeNotificationUserErase, nil, nil)
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+)
+
+// serviceClientID identifies IAM in service tokens it mints for itself.
+const serviceClientID = "iam-service"
+
+// serviceErrorResponse is the error envelope shared by the Go services.
+type serviceErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// serviceRequest calls another service's internal API with a short-lived,
+// self-issued service token carrying scope. IAM issues the service tokens, so
+// it signs its own instead of going through the token endpoint. A non-nil in
+// is sent as the JSON body; a non-nil out receives the decoded 2xx response,
+// or the raw body when out is a *[]byte.
+func serviceRequest(ctx context.Context, httpClient *http.Client, secretKey []byte, service, method, url, scope string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("marshaling request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	token, _, err := servicetoken.Issue(secretKey, serviceClientID, []string{scope}, time.Minute)
+	if err != nil {
+		return fmt.Errorf("issuing service token: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	if in != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if out == nil {
+			return nil
+		}
+		if raw, ok := out.(*[]byte); ok {
+			*raw = respBody
+			return nil
+		}
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("decoding %s service response: %w", service, err)
+		}
+		return nil
+	}
+
+	var errResp serviceErrorResponse
+	if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Message != "" {
+		return fmt.Errorf("%s service error: %s", service, errResp.Message)
+	}
+	return fmt.Errorf("%s service returned status %d", service, resp.StatusCode)
+}
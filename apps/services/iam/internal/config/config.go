@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -10,13 +11,21 @@ import (
 
 // Config holds all configuration for the IAM service.
 type Config struct {
-	Server          ServerConfig
-	Database        DatabaseConfig
-	JWT             JWTConfig
-	MinIO           MinIOConfig
-	GitHub          GitHubConfig
-	FrontendURL     string
-	EmailServiceURL string
+	Server                 ServerConfig
+	Database               DatabaseConfig
+	JWT                    JWTConfig
+	MinIO                  MinIOConfig
+	GitHub                 GitHubConfig
+	SSO                    SSOConfig
+	ServiceToken           ServiceTokenConfig
+	AccessToken            AccessTokenConfig
+	Lifecycle              LifecycleConfig
+	DataRequest            DataRequestConfig
+	FrontendURL            string
+	EmailServiceURL        string
+	AcademicServiceURL     string
+	AssessmentServiceURL   string
+	NotificationServiceURL string
 }
 
 // ServerConfig holds server-related configuration.
@@ -37,12 +46,13 @@ type DatabaseConfig struct {
 
 // MinIOConfig holds MinIO connection configuration.
 type MinIOConfig struct {
-	Endpoint   string
-	AccessKey  string
-	SecretKey  string
-	Bucket     string
-	UseSSL     bool
-	PublicHost string // base URL used to build public object URLs
+	Endpoint     string
+	AccessKey    string
+	SecretKey    string
+	Bucket       string
+	ExportBucket string // private bucket holding personal data export archives
+	UseSSL       bool
+	PublicHost   string // base URL used to build public object URLs
 }
 
 // JWTConfig holds JWT-related configuration.
@@ -65,6 +75,79 @@ type GitHubConfig struct {
 	EncryptionKey    string
 }
 
+// SSOConfig holds the external identity providers used for single sign-on.
+type SSOConfig struct {
+	Providers []SSOProviderConfig
+	// StateExpiry bounds how long an SSO login may stay in flight, in minutes.
+	StateExpiry int64
+}
+
+// SSOProviderConfig describes one OIDC or SAML identity provider. A provider
+// is selected for a login by matching the user's email domain against Domains.
+type SSOProviderConfig struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"` // "oidc" or "saml"
+	DisplayName string   `json:"display_name"`
+	Domains     []string `json:"domains"`
+
+	// OIDC settings
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	RedirectURL  string   `json:"redirect_url"`
+	Scopes       []string `json:"scopes"`
+
+	// SAML settings
+	IDPMetadataURL string `json:"idp_metadata_url"`
+	IDPMetadataXML string `json:"idp_metadata_xml"`
+	EntityID       string `json:"entity_id"`
+	ACSURL         string `json:"acs_url"`
+	SPCertFile     string `json:"sp_cert_file"`
+	SPKeyFile      string `json:"sp_key_file"`
+
+	// Just-in-time provisioning of users unknown to IAM
+	JITProvisioning bool   `json:"jit_provisioning"`
+	DefaultUserType string `json:"default_user_type"` // "student" or "instructor"
+	UserTypeClaim   string `json:"user_type_claim"`   // claim/attribute holding the affiliation
+}
+
+// ServiceTokenConfig holds client-credentials settings for service-to-service calls.
+type ServiceTokenConfig struct {
+	Expiry int64 // in minutes
+	// Clients are registered (or re-synced) on start so deployments can provision
+	// first-party services without going through the admin API.
+	Clients []ServiceClientConfig
+}
+
+// ServiceClientConfig describes one service client registered from configuration.
+type ServiceClientConfig struct {
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes"`
+}
+
+// AccessTokenConfig holds personal access token settings.
+type AccessTokenConfig struct {
+	MaxLifetime int64 // in days
+}
+
+// LifecycleConfig holds the scheduled account lifecycle rules. A zero value
+// for a rule's threshold disables that rule.
+type LifecycleConfig struct {
+	Interval            int64  // in hours; 0 disables the scheduler
+	DryRun              bool   // scheduled runs only report what they would change
+	GraduationGraceDays int64  // days after a batch's end year before its students are deactivated
+	InactivityMonths    int64  // months without a login before an account is flagged
+	RetentionDays       int64  // days a soft-deleted user is kept before anonymisation or purge
+	RetentionMode       string // "anonymize" or "purge"
+}
+
+// DataRequestConfig holds personal data export and erasure settings.
+type DataRequestConfig struct {
+	ExportExpiry int64 // in days; export archives are deleted after this
+}
+
 // Load reads configuration from environment variables.
 func Load() (*Config, error) {
 	env.Load()
@@ -72,6 +155,16 @@ func Load() (*Config, error) {
 	dbPort := getEnv("GRA_DB_PORT", "5432")
 	dbSSLMode := getEnv("GRA_DB_SSLMODE", "disable")
 
+	ssoProviders, err := loadSSOProviders()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceClients, err := loadServiceClients()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port:          getEnv("IAM_SVC_PORT", "8081"),
@@ -93,12 +186,13 @@ func Load() (*Config, error) {
 			CookieSameSite:     getEnv("JWT_COOKIE_SAMESITE", "Lax"),
 		},
 		MinIO: MinIOConfig{
-			Endpoint:   getEnv("MINIO_ENDPOINT", "minio:9000"),
-			AccessKey:  getEnv("MINIO_ACCESS_KEY", "minioadmin"),
-			SecretKey:  getEnv("MINIO_SECRET_KEY", "minioadmin"),
-			Bucket:     getEnv("MINIO_BUCKET", "avatars"),
-			UseSSL:     getEnvAsBool("MINIO_USE_SSL", false),
-			PublicHost: getEnv("MINIO_PUBLIC_HOST", "http://localhost:9000"),
+			Endpoint:     getEnv("MINIO_ENDPOINT", "minio:9000"),
+			AccessKey:    getEnv("MINIO_ACCESS_KEY", "minioadmin"),
+			SecretKey:    getEnv("MINIO_SECRET_KEY", "minioadmin"),
+			Bucket:       getEnv("MINIO_BUCKET", "avatars"),
+			ExportBucket: getEnv("MINIO_EXPORT_BUCKET", "data-exports"),
+			UseSSL:       getEnvAsBool("MINIO_USE_SSL", false),
+			PublicHost:   getEnv("MINIO_PUBLIC_HOST", "http://localhost:9000"),
 		},
 		GitHub: GitHubConfig{
 			ClientID:         getEnv("OAUTH_GITHUB_CLIENT_ID", ""),
@@ -109,11 +203,81 @@ func Load() (*Config, error) {
 			OrgName:          getEnv("APP_GITHUB_ORG_NAME", "gradeloop-classroom"),
 			EncryptionKey:    getEnv("APP_GITHUB_TOKEN_ENCRYPTION_KEY", "32-byte-encryption-key-here!!"),
 		},
-		FrontendURL:     getEnv("FRONTEND_URL", "http://localhost:3000"),
-		EmailServiceURL: getEnv("EMAIL_SERVICE_URL", "http://localhost:8082"),
+		SSO: SSOConfig{
+			Providers:   ssoProviders,
+			StateExpiry: getEnvAsInt64("SSO_STATE_EXPIRY", 10), // 10 minutes
+		},
+		ServiceToken: ServiceTokenConfig{
+			Expiry:  getEnvAsInt64("SERVICE_TOKEN_EXPIRY", 15), // 15 minutes
+			Clients: serviceClients,
+		},
+		AccessToken: AccessTokenConfig{
+			MaxLifetime: getEnvAsInt64("PAT_MAX_LIFETIME", 365), // 365 days
+		},
+		Lifecycle: LifecycleConfig{
+			Interval:            getEnvAsInt64("LIFECYCLE_INTERVAL", 24), // 24 hours
+			DryRun:              getEnvAsBool("LIFECYCLE_DRY_RUN", true),
+			GraduationGraceDays: getEnvAsInt64("LIFECYCLE_GRADUATION_GRACE_DAYS", 90),
+			InactivityMonths:    getEnvAsInt64("LIFECYCLE_INACTIVITY_MONTHS", 12),
+			RetentionDays:       getEnvAsInt64("LIFECYCLE_RETENTION_DAYS", 365),
+			RetentionMode:       getEnv("LIFECYCLE_RETENTION_MODE", "anonymize"),
+		},
+		DataRequest: DataRequestConfig{
+			ExportExpiry: getEnvAsInt64("DATA_EXPORT_EXPIRY", 7), // 7 days
+		},
+		FrontendURL:            getEnv("FRONTEND_URL", "http://localhost:3000"),
+		EmailServiceURL:        getEnv("EMAIL_SERVICE_URL", "http://localhost:8082"),
+		AcademicServiceURL:     getEnv("ACADEMIC_SERVICE_URL", "http://localhost:8083"),
+		AssessmentServiceURL:   getEnv("ASSESSMENT_SERVICE_URL", "http://localhost:8084"),
+		NotificationServiceURL: getEnv("NOTIFICATION_SERVICE_URL", "http://localhost:8086"),
 	}, nil
 }
 
+// loadSSOProviders reads provider definitions from SSO_PROVIDERS_FILE (a JSON
+// file) or, failing that, from the SSO_PROVIDERS variable holding the JSON inline.
+func loadSSOProviders() ([]SSOProviderConfig, error) {
+	raw := []byte(os.Getenv("SSO_PROVIDERS"))
+	if path := os.Getenv("SSO_PROVIDERS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading SSO_PROVIDERS_FILE: %w", err)
+		}
+		raw = data
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var providers []SSOProviderConfig
+	if err := json.Unmarshal(raw, &providers); err != nil {
+		return nil, fmt.Errorf("parsing SSO providers: %w", err)
+	}
+	return providers, nil
+}
+
+// loadServiceClients reads client definitions from SERVICE_CLIENTS_FILE (a
+// JSON file) or, failing that, from the SERVICE_CLIENTS variable holding the
+// JSON inline.
+func loadServiceClients() ([]ServiceClientConfig, error) {
+	raw := []byte(os.Getenv("SERVICE_CLIENTS"))
+	if path := os.Getenv("SERVICE_CLIENTS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading SERVICE_CLIENTS_FILE: %w", err)
+		}
+		raw = data
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var clients []ServiceClientConfig
+	if err := json.Unmarshal(raw, &clients); err != nil {
+		return nil, fmt.Errorf("parsing service clients: %w", err)
+	}
+	return clients, nil
+}
+
 // DSN returns the database connection string.
 func (c *Config) DSN() string {
 	return fmt.Sprintf(
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bulk import job statuses
+const (
+	ImportJobPending   = "pending"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+// Bulk import row statuses
+const (
+	ImportRowPending = "pending"
+	ImportRowCreated = "created"
+	ImportRowSkipped = "skipped"
+	ImportRowFailed  = "failed"
+)
+
+// BulkImportJob is an uploaded user import processed in the background.
+// FileHash lets a re-upload of the same file attach to the job already in
+// progress instead of starting a second one.
+type BulkImportJob struct {
+	ID            uuid.UUID `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedBy     uuid.UUID `gorm:"type:uuid;not null;index" json:"created_by"`
+	Filename      string    `gorm:"size:255" json:"filename"`
+	FileHash      string    `gorm:"size:64;not null;index" json:"file_hash"`
+	Status        string    `gorm:"not null;size:20;index" json:"status"`
+	TotalRows     int       `gorm:"not null;default:0" json:"total_rows"`
+	ProcessedRows int       `gorm:"not null;default:0" json:"processed_rows"`
+	CreatedCount  int       `gorm:"not null;default:0" json:"created_count"`
+	SkippedCount  int       `gorm:"not null;default:0" json:"skipped_count"`
+	FailedCount   int       `gorm:"not null;default:0" json:"failed_count"`
+	Error         string    `gorm:"size:1000" json:"error,omitempty"`
+	// ClaimedBy is the worker running the job; it renews ClaimedAt while it
+	// works, so a job whose claim has gone stale can be taken over.
+	ClaimedBy   string     `gorm:"size:64" json:"-"`
+	ClaimedAt   *time.Time `json:"-"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (BulkImportJob) TableName() string {
+	return "bulk_import_jobs"
+}
+
+// BulkImportRow is one data row of an import job. Data holds the mapped row
+// as JSON so a resumed job does not need the original file.
+type BulkImportRow struct {
+	ID            uuid.UUID     `gorm:"type:uuid;primarykey" json:"id"`
+	JobID         uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_bulk_import_rows_job_row" json:"job_id"`
+	Job           BulkImportJob `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"-"`
+	RowIndex      int           `gorm:"not null;uniqueIndex:idx_bulk_import_rows_job_row" json:"row_index"`
+	Email         string        `gorm:"size:255" json:"email"`
+	Data          string        `gorm:"type:jsonb" json:"data"`
+	Status        string        `gorm:"not null;size:20;index" json:"status"`
+	Message       string        `gorm:"size:1000" json:"message,omitempty"`
+	UserID        *uuid.UUID    `gorm:"type:uuid" json:"user_id,omitempty"`
+	EmailSent     bool          `gorm:"not null;default:false" json:"email_sent"`
+	BatchAssigned bool          `gorm:"not null;default:false" json:"batch_assigned"`
+	ProcessedAt   *time.Time    `json:"processed_at,omitempty"`
+}
+
+func (BulkImportRow) TableName() string {
+	return "bulk_import_rows"
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Personal data request types
+const (
+	DataRequestExport  = "export"
+	DataRequestErasure = "erasure"
+)
+
+// Personal data request statuses. Self-service erasure requests wait in
+// pending_approval until an admin approves or rejects them.
+const (
+	DataRequestPendingApproval = "pending_approval"
+	DataRequestPending         = "pending"
+	DataRequestRunning         = "running"
+	DataRequestCompleted       = "completed"
+	DataRequestFailed          = "failed"
+	DataRequestRejected        = "rejected"
+	DataRequestExpired         = "expired"
+)
+
+// DataRequest is a personal data export or erasure for one user, gathered
+// from or applied to every service that holds the user's data. ObjectName
+// points at the export archive in the private export bucket until ExpiresAt.
+// Report holds the per-service results as JSON.
+type DataRequest struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primarykey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type        string     `gorm:"not null;size:20" json:"type"`
+	Status      string     `gorm:"not null;size:20;index" json:"status"`
+	RequestedBy uuid.UUID  `gorm:"type:uuid;not null" json:"requested_by"`
+	ReviewedBy  *uuid.UUID `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ObjectName  string     `gorm:"size:255" json:"-"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Error       string     `gorm:"size:1000" json:"error,omitempty"`
+	Report      string     `gorm:"type:jsonb" json:"report,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (DataRequest) TableName() string {
+	return "data_requests"
+}
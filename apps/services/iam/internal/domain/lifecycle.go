@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lifecycle run triggers
+const (
+	LifecycleTriggerSchedule = "schedule"
+	LifecycleTriggerManual   = "manual"
+)
+
+// Retention modes for soft-deleted users
+const (
+	RetentionModeAnonymize = "anonymize"
+	RetentionModePurge     = "purge"
+)
+
+// LifecycleRun is one evaluation of the account lifecycle rules. Report holds
+// the per-user findings as JSON; in a dry run nothing in it was applied.
+type LifecycleRun struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primarykey" json:"id"`
+	Trigger        string     `gorm:"not null;size:20" json:"trigger"`
+	TriggeredBy    *uuid.UUID `gorm:"type:uuid" json:"triggered_by,omitempty"`
+	DryRun         bool       `gorm:"not null" json:"dry_run"`
+	GraduatedCount int        `gorm:"not null;default:0" json:"graduated_count"`
+	InactiveCount  int        `gorm:"not null;default:0" json:"inactive_count"`
+	RetentionCount int        `gorm:"not null;default:0" json:"retention_count"`
+	ErrorCount     int        `gorm:"not null;default:0" json:"error_count"`
+	Report         string     `gorm:"type:jsonb" json:"report"`
+	StartedAt      time.Time  `gorm:"not null;index" json:"started_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+func (LifecycleRun) TableName() string {
+	return "lifecycle_runs"
+}
@@ -14,6 +14,11 @@ const (
 	UserTypeAdmin      = "admin"
 )
 
+// Deactivation reasons recorded on users switched off by lifecycle rules
+const (
+	DeactivationReasonGraduated = "graduated"
+)
+
 // Valid user types
 var ValidUserTypes = []string{
 	UserTypeStudent,
@@ -37,6 +42,11 @@ type User struct {
 	GitHubID                *string        `gorm:"size:100;index" json:"github_id,omitempty"`
 	GitHubUsername          string         `gorm:"size:255" json:"github_username,omitempty"`
 	GitHubTokenEncrypted    string         `gorm:"size:500" json:"-"`
+	LastLoginAt             *time.Time     `gorm:"index" json:"last_login_at,omitempty"`
+	InactiveFlaggedAt       *time.Time     `gorm:"index" json:"inactive_flagged_at,omitempty"`
+	DeactivatedAt           *time.Time     `json:"deactivated_at,omitempty"`
+	DeactivationReason      string         `gorm:"size:50" json:"deactivation_reason,omitempty"`
+	AnonymizedAt            *time.Time     `gorm:"index" json:"anonymized_at,omitempty"`
 	DeletedAt               gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 	CreatedAt               time.Time      `json:"created_at"`
 	UpdatedAt               time.Time      `json:"updated_at"`
@@ -79,6 +89,34 @@ type PasswordResetToken struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+// UserIdentity links a user to an account at an external identity provider.
+// Provider is the configured SSO provider ID and Subject is the provider's
+// stable identifier for the account (OIDC "sub" or SAML NameID).
+type UserIdentity struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primarykey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	User        User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	Provider    string     `gorm:"uniqueIndex:idx_user_identities_provider_subject;not null;size:100" json:"provider"`
+	Subject     string     `gorm:"uniqueIndex:idx_user_identities_provider_subject;not null;size:255" json:"subject"`
+	Email       string     `gorm:"size:255" json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// SSOLoginState tracks an SSO login between the redirect to the identity
+// provider and its callback. The state value itself is only stored hashed.
+type SSOLoginState struct {
+	ID           uuid.UUID `gorm:"type:uuid;primarykey" json:"id"`
+	StateHash    string    `gorm:"uniqueIndex;not null;size:255" json:"-"`
+	Provider     string    `gorm:"not null;size:100" json:"provider"`
+	Nonce        string    `gorm:"size:255" json:"-"`
+	CodeVerifier string    `gorm:"size:255" json:"-"`
+	RequestID    string    `gorm:"size:255" json:"-"`
+	RedirectTo   string    `gorm:"size:512" json:"redirect_to"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 // User helper methods
 
 // IsValidUserType checks if the given user type is valid
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PersonalAccessToken is a long-lived credential a user creates for scripts
+// and automation. Only the hash of the token is stored. Scopes is the
+// space-separated set of permissions the token may exercise.
+type PersonalAccessToken struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primarykey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	User        User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	Name        string     `gorm:"not null;size:100" json:"name"`
+	TokenHash   string     `gorm:"uniqueIndex;not null;size:255" json:"-"`
+	TokenPrefix string     `gorm:"size:20" json:"token_prefix"`
+	Scopes      string     `gorm:"size:1000;not null" json:"scopes"`
+	ExpiresAt   time.Time  `gorm:"not null;index" json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// ScopeList returns the token's scopes as a slice
+func (t *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// IsUsable reports whether the token is neither revoked nor expired at now.
+func (t *PersonalAccessToken) IsUsable(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/google/uuid"
+)
+
+// System role names. Every user implicitly holds the system role matching
+// their user type; further roles come from RoleAssignment rows. Advisors are
+// usually assigned on the department or faculty whose students they audit.
+const (
+	RoleAdmin             = "admin"
+	RoleInstructor        = "instructor"
+	RoleTeachingAssistant = "teaching_assistant"
+	RoleStudent           = "student"
+	RoleAdvisor           = "advisor"
+)
+
+// SystemRolePermissions is the permission set of each system role. The seeder
+// keeps the database in sync with this map on every start.
+var SystemRolePermissions = map[string][]string{
+	RoleAdmin: authz.AllPermissions,
+	RoleInstructor: {
+		authz.PermUserRead,
+		authz.PermAcademicRead,
+		authz.PermEnrollmentRead,
+		authz.PermAssignmentRead,
+		authz.PermAssignmentWrite,
+		authz.PermTestCaseWrite,
+		authz.PermSubmissionRead,
+		authz.PermSubmissionGrade,
+		authz.PermRegradeRespond,
+	},
+	RoleTeachingAssistant: {
+		authz.PermAcademicRead,
+		authz.PermEnrollmentRead,
+		authz.PermAssignmentRead,
+		authz.PermSubmissionRead,
+		authz.PermSubmissionGrade,
+		authz.PermRegradeRespond,
+	},
+	RoleStudent: {
+		authz.PermAcademicRead,
+		authz.PermAssignmentRead,
+	},
+	RoleAdvisor: {
+		authz.PermUserRead,
+		authz.PermAcademicRead,
+		authz.PermEnrollmentRead,
+		authz.PermDegreeAuditRead,
+	},
+}
+
+// Permission is a single "<resource>:<action>" capability.
+type Permission struct {
+	ID          uuid.UUID `gorm:"type:uuid;primarykey" json:"id"`
+	Name        string    `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Description string    `gorm:"size:255" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (Permission) TableName() string {
+	return "access_permissions"
+}
+
+// Role is a named set of permissions. System roles are managed by the seeder
+// and cannot be edited or deleted through the API.
+type Role struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primarykey" json:"id"`
+	Name        string       `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Description string       `gorm:"size:255" json:"description"`
+	IsSystem    bool         `gorm:"not null;default:false" json:"is_system"`
+	Permissions []Permission `gorm:"many2many:access_role_permissions" json:"permissions,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+func (Role) TableName() string {
+	return "access_roles"
+}
+
+// RoleAssignment grants a role to a user, either globally or limited to one
+// faculty, department or course instance. Global assignments use uuid.Nil as
+// ScopeID so the unique index also covers them.
+type RoleAssignment struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primarykey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_role_assignments_unique" json:"user_id"`
+	User      User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	RoleID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_role_assignments_unique" json:"role_id"`
+	Role      Role       `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE" json:"role,omitempty"`
+	ScopeType string     `gorm:"not null;size:30;uniqueIndex:idx_role_assignments_unique;index:idx_role_assignments_scope" json:"scope_type"`
+	ScopeID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_role_assignments_unique;index:idx_role_assignments_scope" json:"scope_id"`
+	GrantedBy *uuid.UUID `gorm:"type:uuid" json:"granted_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsGlobal returns true if the assignment is not limited to a scope
+func (a *RoleAssignment) IsGlobal() bool {
+	return a.ScopeType == authz.ScopeGlobal
+}
+
+// SystemRoleForUserType returns the system role implied by a user type
+func SystemRoleForUserType(userType string) string {
+	switch userType {
+	case UserTypeAdmin:
+		return RoleAdmin
+	case UserTypeInstructor:
+		return RoleInstructor
+	case UserTypeStudent:
+		return RoleStudent
+	default:
+		return ""
+	}
+}
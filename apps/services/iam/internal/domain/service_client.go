@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceClient is a machine identity allowed to obtain client-credentials
+// tokens. Scopes is the space-separated set of scopes it may request.
+type ServiceClient struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primarykey" json:"id"`
+	ClientID    string     `gorm:"uniqueIndex;not null;size:100" json:"client_id"`
+	Name        string     `gorm:"size:255" json:"name"`
+	SecretHash  string     `gorm:"not null" json:"-"`
+	Scopes      string     `gorm:"size:500;not null" json:"scopes"`
+	IsActive    bool       `gorm:"not null;default:true" json:"is_active"`
+	LastTokenAt *time.Time `json:"last_token_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (ServiceClient) TableName() string {
+	return "service_clients"
+}
+
+// ScopeList returns the client's scopes as a slice
+func (c *ServiceClient) ScopeList() []string {
+	return strings.Fields(c.Scopes)
+}
+
+// AuditLog is an audit entry reported by a GradeLoop service. ClientID records
+// the service client whose token submitted it.
+type AuditLog struct {
+	ID         uuid.UUID `gorm:"type:uuid;primarykey" json:"id"`
+	Action     string    `gorm:"not null;size:100;index" json:"action"`
+	Entity     string    `gorm:"not null;size:100;index:idx_audit_logs_entity" json:"entity"`
+	EntityID   string    `gorm:"size:100;index:idx_audit_logs_entity" json:"entity_id"`
+	Email      string    `gorm:"size:255;index" json:"email"`
+	Changes    string    `gorm:"type:jsonb" json:"changes"`
+	Metadata   string    `gorm:"type:jsonb" json:"metadata"`
+	IPAddress  string    `gorm:"size:64" json:"ip_address"`
+	UserAgent  string    `gorm:"size:500" json:"user_agent"`
+	Service    string    `gorm:"size:100;index" json:"service"`
+	ClientID   string    `gorm:"size:100" json:"client_id"`
+	OccurredAt time.Time `gorm:"not null;index" json:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -14,6 +16,8 @@ type ImportUserRow struct {
 	Faculty     string `json:"faculty"`
 	StudentID   string `json:"student_id,omitempty"`
 	Designation string `json:"designation,omitempty"`
+	// BatchID places a student in an academic batch once the account exists.
+	BatchID string `json:"batch_id,omitempty"`
 }
 
 type ImportPreviewRow struct {
@@ -21,6 +25,9 @@ type ImportPreviewRow struct {
 	Data     ImportUserRow `json:"data"`
 	Errors   []string      `json:"errors,omitempty"`
 	IsValid  bool          `json:"is_valid"`
+	// Exists marks rows whose email already has an account; importing skips
+	// them rather than failing.
+	Exists bool `json:"exists"`
 }
 
 type BulkImportPreviewResponse struct {
@@ -28,6 +35,7 @@ type BulkImportPreviewResponse struct {
 	TotalRows     int                `json:"total_rows"`
 	ValidRows     int                `json:"valid_rows"`
 	InvalidRows   int                `json:"invalid_rows"`
+	ExistingRows  int                `json:"existing_rows"`
 	ColumnMapping map[string]string  `json:"column_mapping"` // Map of normalized header to actual header
 }
 
@@ -37,17 +45,33 @@ type BulkImportExecuteRequest struct {
 }
 
 type BulkImportResultRow struct {
-	RowIndex int    `json:"row_index"`
-	Email    string `json:"email"`
-	Success  bool   `json:"success"`
-	Error    string `json:"error,omitempty"`
+	RowIndex      int        `json:"row_index"`
+	Email         string     `json:"email"`
+	Status        string     `json:"status"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	EmailSent     bool       `json:"email_sent"`
+	BatchID       string     `json:"batch_id,omitempty"`
+	BatchAssigned bool       `json:"batch_assigned"`
+	Message       string     `json:"message,omitempty"`
 }
 
-type BulkImportExecuteResponse struct {
-	TotalProcessed int                   `json:"total_processed"`
-	SuccessCount   int                   `json:"success_count"`
-	FailureCount   int                   `json:"failure_count"`
-	Results        []BulkImportResultRow `json:"results"`
+// BulkImportJobResponse reports an import job's progress. Failures holds the
+// first failed rows; the full per-row outcome is in the job report.
+type BulkImportJobResponse struct {
+	ID            uuid.UUID             `json:"id"`
+	Filename      string                `json:"filename"`
+	Status        string                `json:"status"`
+	TotalRows     int                   `json:"total_rows"`
+	ProcessedRows int                   `json:"processed_rows"`
+	CreatedCount  int                   `json:"created_count"`
+	SkippedCount  int                   `json:"skipped_count"`
+	FailedCount   int                   `json:"failed_count"`
+	Error         string                `json:"error,omitempty"`
+	Failures      []BulkImportResultRow `json:"failures,omitempty"`
+	CreatedBy     uuid.UUID             `json:"created_by"`
+	StartedAt     *time.Time            `json:"started_at,omitempty"`
+	CompletedAt   *time.Time            `json:"completed_at,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
 }
 
 type RoleInfo struct {
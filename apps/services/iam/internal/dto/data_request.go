@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateDataRequestRequest starts an export or erasure for a user on an
+// admin's behalf. Admin-created erasures skip the approval step.
+type CreateDataRequestRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Type   string    `json:"type"`
+}
+
+// RejectDataRequestRequest declines a pending erasure request.
+type RejectDataRequestRequest struct {
+	Reason string `json:"reason"`
+}
+
+// DataRequestStep is the outcome of gathering or erasing one service's data.
+type DataRequestStep struct {
+	Service string `json:"service"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// DataRequestReport lists the per-service steps of a data request.
+type DataRequestReport struct {
+	Steps []DataRequestStep `json:"steps"`
+}
+
+// DataRequestResponse describes a personal data request. A completed export
+// can be downloaded until ExpiresAt.
+type DataRequestResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	Type        string             `json:"type"`
+	Status      string             `json:"status"`
+	RequestedBy uuid.UUID          `json:"requested_by"`
+	ReviewedBy  *uuid.UUID         `json:"reviewed_by,omitempty"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	Report      *DataRequestReport `json:"report,omitempty"`
+	StartedAt   *time.Time         `json:"started_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// ExportedUser is the IAM account as written to a personal data export.
+// Credentials are never included; a linked GitHub account is only named.
+type ExportedUser struct {
+	ID             uuid.UUID  `json:"id"`
+	Email          string     `json:"email"`
+	FullName       string     `json:"full_name"`
+	AvatarURL      string     `json:"avatar_url,omitempty"`
+	Faculty        string     `json:"faculty,omitempty"`
+	Department     string     `json:"department,omitempty"`
+	UserType       string     `json:"user_type"`
+	IsActive       bool       `json:"is_active"`
+	EmailVerified  bool       `json:"email_verified"`
+	StudentID      string     `json:"student_id,omitempty"`
+	Designation    string     `json:"designation,omitempty"`
+	GitHubUsername string     `json:"github_username,omitempty"`
+	LastLoginAt    *time.Time `json:"last_login_at,omitempty"`
+	DeactivatedAt  *time.Time `json:"deactivated_at,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ExportedIdentity is a linked single sign-on account.
+type ExportedIdentity struct {
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"subject"`
+	Email       string     `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ExportedRoleAssignment is a role granted to the user.
+type ExportedRoleAssignment struct {
+	Role      string    `json:"role"`
+	ScopeType string    `json:"scope_type"`
+	ScopeID   uuid.UUID `json:"scope_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportedAccessToken describes a personal access token without its secret.
+type ExportedAccessToken struct {
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     string     `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// UserDataExport is IAM's part of a personal data export.
+type UserDataExport struct {
+	User            ExportedUser             `json:"user"`
+	Identities      []ExportedIdentity       `json:"identities"`
+	RoleAssignments []ExportedRoleAssignment `json:"role_assignments"`
+	AccessTokens    []ExportedAccessToken    `json:"access_tokens"`
+}
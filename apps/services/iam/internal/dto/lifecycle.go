@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StartLifecycleRunRequest starts a lifecycle run. Runs are dry runs unless
+// dry_run is explicitly false.
+type StartLifecycleRunRequest struct {
+	DryRun *bool `json:"dry_run"`
+}
+
+// LifecyclePolicy is the rule configuration a run was evaluated with.
+type LifecyclePolicy struct {
+	GraduationGraceDays int64  `json:"graduation_grace_days"`
+	InactivityMonths    int64  `json:"inactivity_months"`
+	RetentionDays       int64  `json:"retention_days"`
+	RetentionMode       string `json:"retention_mode"`
+}
+
+// LifecycleUserAction is one user matched by a lifecycle rule. Applied is
+// false in dry runs and when applying the action failed.
+type LifecycleUserAction struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Email    string    `json:"email,omitempty"`
+	FullName string    `json:"full_name,omitempty"`
+	Detail   string    `json:"detail"`
+	Applied  bool      `json:"applied"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// LifecycleReport lists what each rule matched in a run. Users due for
+// retention are listed by ID only so the report holds no personal data
+// after they are anonymised or purged.
+type LifecycleReport struct {
+	Policy               LifecyclePolicy       `json:"policy"`
+	GraduationCutoffYear int                   `json:"graduation_cutoff_year,omitempty"`
+	InactiveBefore       *time.Time            `json:"inactive_before,omitempty"`
+	DeletedBefore        *time.Time            `json:"deleted_before,omitempty"`
+	Graduated            []LifecycleUserAction `json:"graduated"`
+	Inactive             []LifecycleUserAction `json:"inactive"`
+	Retention            []LifecycleUserAction `json:"retention"`
+	Errors               []string              `json:"errors,omitempty"`
+}
+
+// LifecycleRunResponse summarises a lifecycle run. Report is only included
+// when a single run is fetched.
+type LifecycleRunResponse struct {
+	ID             uuid.UUID        `json:"id"`
+	Trigger        string           `json:"trigger"`
+	TriggeredBy    *uuid.UUID       `json:"triggered_by,omitempty"`
+	DryRun         bool             `json:"dry_run"`
+	GraduatedCount int              `json:"graduated_count"`
+	InactiveCount  int              `json:"inactive_count"`
+	RetentionCount int              `json:"retention_count"`
+	ErrorCount     int              `json:"error_count"`
+	StartedAt      time.Time        `json:"started_at"`
+	CompletedAt    *time.Time       `json:"completed_at,omitempty"`
+	Report         *LifecycleReport `json:"report,omitempty"`
+}
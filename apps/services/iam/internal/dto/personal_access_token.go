@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Personal Access Token DTOs
+
+type CreatePersonalAccessTokenRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes" validate:"required"`
+	// ExpiresInDays is the token's lifetime, bounded by PAT_MAX_LIFETIME.
+	ExpiresInDays int64 `json:"expires_in_days" validate:"required"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	Expired     bool       `json:"expired"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// PersonalAccessTokenSecretResponse is returned when a token is created. The
+// token is shown only once and never stored in clear.
+type PersonalAccessTokenSecretResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role & Permission DTOs
+
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsSystem    bool      `json:"is_system"`
+	Permissions []string  `json:"permissions"`
+}
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type UpdateRoleRequest struct {
+	Description *string  `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// Role Assignment DTOs
+
+type AssignRoleRequest struct {
+	Role      string `json:"role" validate:"required"`
+	ScopeType string `json:"scope_type" validate:"required,oneof=global faculty department course_instance"`
+	ScopeID   string `json:"scope_id"`
+}
+
+type RoleAssignmentResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Role      string     `json:"role"`
+	ScopeType string     `json:"scope_type"`
+	ScopeID   *uuid.UUID `json:"scope_id,omitempty"`
+	GrantedBy *uuid.UUID `json:"granted_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type EffectivePermissionsResponse struct {
+	UserID      uuid.UUID                `json:"user_id"`
+	UserType    string                   `json:"user_type"`
+	Roles       []string                 `json:"roles"`
+	Permissions []string                 `json:"permissions"`
+	Scoped      []RoleAssignmentResponse `json:"scoped_assignments"`
+}
+
+// Token validation DTOs (used by other services)
+
+type ValidateTokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ValidateTokenResponse struct {
+	Valid       bool     `json:"valid"`
+	UserID      string   `json:"user_id,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	UserType    string   `json:"user_type,omitempty"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	// Set only for personal access tokens
+	TokenID   string     `json:"token_id,omitempty"`
+	FullName  string     `json:"full_name,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
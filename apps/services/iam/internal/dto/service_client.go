@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Service Client DTOs
+
+type CreateServiceClientRequest struct {
+	ClientID string   `json:"client_id" validate:"required"`
+	Name     string   `json:"name"`
+	Scopes   []string `json:"scopes" validate:"required"`
+}
+
+type UpdateServiceClientRequest struct {
+	Name     *string  `json:"name"`
+	Scopes   []string `json:"scopes"`
+	IsActive *bool    `json:"is_active"`
+}
+
+type ServiceClientResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ClientID    string     `json:"client_id"`
+	Name        string     `json:"name"`
+	Scopes      []string   `json:"scopes"`
+	IsActive    bool       `json:"is_active"`
+	LastTokenAt *time.Time `json:"last_token_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ServiceClientSecretResponse is returned when a client is created or its
+// secret rotated. The secret is shown only once and never stored in clear.
+type ServiceClientSecretResponse struct {
+	ServiceClientResponse
+	ClientSecret string `json:"client_secret"`
+}
+
+// Audit Log DTOs
+
+// CreateAuditLogRequest is the entry services POST to /api/v1/audit-logs.
+type CreateAuditLogRequest struct {
+	Action     string                 `json:"action"`
+	Entity     string                 `json:"entity"`
+	EntityID   string                 `json:"entity_id"`
+	Email      string                 `json:"email"`
+	Changes    map[string]interface{} `json:"changes,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	IPAddress  string                 `json:"ip_address,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	Service    string                 `json:"service"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// SSO DTOs
+
+type SSOProviderResponse struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	DisplayName string   `json:"display_name"`
+	Domains     []string `json:"domains"`
+}
+
+type SSODiscoverRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type SSOLoginStartResponse struct {
+	Provider string `json:"provider"`
+	AuthURL  string `json:"auth_url"`
+}
@@ -2,10 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
 	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
 )
 
 type BulkImportHandler struct {
@@ -55,7 +58,14 @@ func (h *BulkImportHandler) PreviewImport(c fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// ExecuteImport queues the uploaded file as an import job and returns it
+// straight away; progress is polled through GetJob.
 func (h *BulkImportHandler) ExecuteImport(c fiber.Ctx) error {
+	actorID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "no file provided")
@@ -71,22 +81,89 @@ func (h *BulkImportHandler) ExecuteImport(c fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid column_mapping JSON")
 	}
 
-	// Get actor user type from context (set by AuthMiddleware)
-	actorUserType, ok := c.Locals("user_type").(string)
-	if !ok || actorUserType == "" {
-		return fiber.NewError(fiber.StatusForbidden, "Permission denied")
-	}
-
 	f, err := file.Open()
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, "failed to open file")
 	}
 	defer f.Close()
 
-	response, err := h.bulkImportService.ExecuteImport(c.RequestCtx(), f, file.Filename, mapping, actorUserType)
+	response, err := h.bulkImportService.StartImport(c.RequestCtx(), f, file.Filename, mapping, actorID)
+	if err != nil {
+		return handleBulkImportError(err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+func (h *BulkImportHandler) ListJobs(c fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.bulkImportService.ListJobs(c.RequestCtx(), limit)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return handleBulkImportError(err)
+	}
+
+	return c.JSON(fiber.Map{"jobs": response})
+}
+
+func (h *BulkImportHandler) GetJob(c fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	response, err := h.bulkImportService.GetJob(c.RequestCtx(), jobID)
+	if err != nil {
+		return handleBulkImportError(err)
 	}
 
 	return c.JSON(response)
 }
+
+// ResumeJob re-queues a failed job from its first unprocessed row.
+func (h *BulkImportHandler) ResumeJob(c fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	response, err := h.bulkImportService.ResumeJob(c.RequestCtx(), jobID)
+	if err != nil {
+		return handleBulkImportError(err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+// DownloadReport returns the per-row outcome of a job as XLSX or CSV.
+func (h *BulkImportHandler) DownloadReport(c fiber.Ctx) error {
+	jobID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid job ID")
+	}
+
+	format := c.Query("format", "xlsx")
+	content, contentType, err := h.bulkImportService.GenerateReport(c.RequestCtx(), jobID, format)
+	if err != nil {
+		return handleBulkImportError(err)
+	}
+
+	c.Set("Content-Type", contentType)
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=user_import_%s.%s", jobID, format))
+	return c.Send(content)
+}
+
+func handleBulkImportError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrImportJobNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Import job not found")
+	case errors.Is(err, service.ErrImportJobNotResumable):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrUnsupportedImportFile),
+		errors.Is(err, service.ErrEmptyImportFile),
+		errors.Is(err, service.ErrInvalidImportReportFmt):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	default:
+		return err
+	}
+}
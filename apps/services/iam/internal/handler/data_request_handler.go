@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type DataRequestHandler struct {
+	dataRequestService service.DataRequestService
+}
+
+func NewDataRequestHandler(dataRequestService service.DataRequestService) *DataRequestHandler {
+	return &DataRequestHandler{
+		dataRequestService: dataRequestService,
+	}
+}
+
+// ListMyRequests returns the current user's data requests.
+func (h *DataRequestHandler) ListMyRequests(c fiber.Ctx) error {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.dataRequestService.ListRequests(c.RequestCtx(), &userID, "", limit)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.JSON(fiber.Map{"requests": response})
+}
+
+// RequestMyExport queues an export of the current user's data.
+func (h *DataRequestHandler) RequestMyExport(c fiber.Ctx) error {
+	return h.createMyRequest(c, domain.DataRequestExport)
+}
+
+// RequestMyErasure asks for the current user's data to be erased. The request
+// waits for an admin to approve it.
+func (h *DataRequestHandler) RequestMyErasure(c fiber.Ctx) error {
+	return h.createMyRequest(c, domain.DataRequestErasure)
+}
+
+func (h *DataRequestHandler) createMyRequest(c fiber.Ctx, requestType string) error {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.dataRequestService.CreateRequest(c.RequestCtx(), userID, requestType, userID, true)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+func (h *DataRequestHandler) GetMyRequest(c fiber.Ctx) error {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	requestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request ID")
+	}
+
+	response, err := h.dataRequestService.GetRequest(c.RequestCtx(), requestID, &userID)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *DataRequestHandler) DownloadMyExport(c fiber.Ctx) error {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	return h.download(c, &userID)
+}
+
+// ListRequests returns data requests, optionally filtered by ?user_id= and
+// ?status=.
+func (h *DataRequestHandler) ListRequests(c fiber.Ctx) error {
+	var userID *uuid.UUID
+	if raw := c.Query("user_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid user ID")
+		}
+		userID = &id
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.dataRequestService.ListRequests(c.RequestCtx(), userID, c.Query("status"), limit)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.JSON(fiber.Map{"requests": response})
+}
+
+// CreateRequest starts an export or erasure for any user. Erasures created
+// by an admin are queued without a separate approval.
+func (h *DataRequestHandler) CreateRequest(c fiber.Ctx) error {
+	actorID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreateDataRequestRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if req.UserID == uuid.Nil {
+		return fiber.NewError(fiber.StatusBadRequest, "user_id is required")
+	}
+
+	response, err := h.dataRequestService.CreateRequest(c.RequestCtx(), req.UserID, req.Type, actorID, false)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+func (h *DataRequestHandler) GetRequest(c fiber.Ctx) error {
+	requestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request ID")
+	}
+
+	response, err := h.dataRequestService.GetRequest(c.RequestCtx(), requestID, nil)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *DataRequestHandler) DownloadExport(c fiber.Ctx) error {
+	return h.download(c, nil)
+}
+
+// ApproveRequest queues a self-service erasure request.
+func (h *DataRequestHandler) ApproveRequest(c fiber.Ctx) error {
+	actorID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	requestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request ID")
+	}
+
+	response, err := h.dataRequestService.ApproveRequest(c.RequestCtx(), requestID, actorID)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.JSON(response)
+}
+
+// RejectRequest declines a self-service erasure request with an optional
+// reason shown to the user.
+func (h *DataRequestHandler) RejectRequest(c fiber.Ctx) error {
+	actorID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	requestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request ID")
+	}
+
+	var req dto.RejectDataRequestRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().Body(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	response, err := h.dataRequestService.RejectRequest(c.RequestCtx(), requestID, actorID, req.Reason)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.JSON(response)
+}
+
+// RetryRequest re-queues a failed request.
+func (h *DataRequestHandler) RetryRequest(c fiber.Ctx) error {
+	requestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request ID")
+	}
+
+	response, err := h.dataRequestService.RetryRequest(c.RequestCtx(), requestID)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response)
+}
+
+func (h *DataRequestHandler) download(c fiber.Ctx, ownerID *uuid.UUID) error {
+	requestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request ID")
+	}
+
+	archive, size, err := h.dataRequestService.OpenExport(c.RequestCtx(), requestID, ownerID)
+	if err != nil {
+		return handleDataRequestError(err)
+	}
+
+	c.Set("Content-Type", "application/zip")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=gradeloop_data_%s.zip", requestID))
+	return c.SendStream(archive, int(size))
+}
+
+func handleDataRequestError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrDataRequestNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Data request not found")
+	case errors.Is(err, service.ErrUserNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "User not found")
+	case errors.Is(err, service.ErrInvalidDataRequestType):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrDataRequestWrongStatus):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrDataExportNotAvailable):
+		return fiber.NewError(fiber.StatusGone, err.Error())
+	default:
+		return err
+	}
+}
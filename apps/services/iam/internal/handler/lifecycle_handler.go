@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type LifecycleHandler struct {
+	lifecycleService service.LifecycleService
+}
+
+func NewLifecycleHandler(lifecycleService service.LifecycleService) *LifecycleHandler {
+	return &LifecycleHandler{
+		lifecycleService: lifecycleService,
+	}
+}
+
+// StartRun evaluates the lifecycle rules now. Without an explicit
+// "dry_run": false the run only reports what it would change.
+func (h *LifecycleHandler) StartRun(c fiber.Ctx) error {
+	actorID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.StartLifecycleRunRequest
+	if len(c.Body()) > 0 {
+		if err := c.Bind().Body(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+	dryRun := req.DryRun == nil || *req.DryRun
+
+	response, err := h.lifecycleService.RunRules(c.RequestCtx(), dryRun, domain.LifecycleTriggerManual, &actorID)
+	if err != nil {
+		return handleLifecycleError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+func (h *LifecycleHandler) ListRuns(c fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	response, err := h.lifecycleService.ListRuns(c.RequestCtx(), limit)
+	if err != nil {
+		return handleLifecycleError(err)
+	}
+
+	return c.JSON(fiber.Map{"runs": response})
+}
+
+func (h *LifecycleHandler) GetRun(c fiber.Ctx) error {
+	runID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid run ID")
+	}
+
+	response, err := h.lifecycleService.GetRun(c.RequestCtx(), runID)
+	if err != nil {
+		return handleLifecycleError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func handleLifecycleError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrLifecycleRunNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Lifecycle run not found")
+	case errors.Is(err, service.ErrLifecycleRunInProgress):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenHandler struct {
+	tokenService service.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenHandler(tokenService service.PersonalAccessTokenService) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{tokenService: tokenService}
+}
+
+// ListTokens returns the caller's personal access tokens.
+func (h *PersonalAccessTokenHandler) ListTokens(c fiber.Ctx) error {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.tokenService.ListTokens(c.RequestCtx(), userID)
+	if err != nil {
+		return handlePersonalAccessTokenError(err)
+	}
+
+	return c.JSON(fiber.Map{"tokens": response})
+}
+
+// CreateToken issues a personal access token for the caller. The token value
+// is only returned in this response.
+func (h *PersonalAccessTokenHandler) CreateToken(c fiber.Ctx) error {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CreatePersonalAccessTokenRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	response, err := h.tokenService.CreateToken(c.RequestCtx(), userID, &req)
+	if err != nil {
+		return handlePersonalAccessTokenError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// RevokeToken revokes one of the caller's personal access tokens.
+func (h *PersonalAccessTokenHandler) RevokeToken(c fiber.Ctx) error {
+	userID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	tokenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid token ID")
+	}
+
+	if err := h.tokenService.RevokeToken(c.RequestCtx(), userID, tokenID); err != nil {
+		return handlePersonalAccessTokenError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func handlePersonalAccessTokenError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrAccessTokenNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Personal access token not found")
+	case errors.Is(err, service.ErrInvalidAccessTokenName),
+		errors.Is(err, service.ErrInvalidAccessTokenScope),
+		errors.Is(err, service.ErrInvalidAccessTokenExpiry):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	default:
+		return err
+	}
+}
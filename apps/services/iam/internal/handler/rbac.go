@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type RBACHandler struct {
+	authorizationService service.AuthorizationService
+	tokenVerifier        pat.Verifier
+}
+
+func NewRBACHandler(authorizationService service.AuthorizationService, tokenVerifier pat.Verifier) *RBACHandler {
+	return &RBACHandler{
+		authorizationService: authorizationService,
+		tokenVerifier:        tokenVerifier,
+	}
+}
+
+// ValidateToken verifies an access token or personal access token for other
+// services. Always responds 200 for a decided token; the body's "valid" field
+// carries the result.
+func (h *RBACHandler) ValidateToken(c fiber.Ctx) error {
+	var req dto.ValidateTokenRequest
+	if err := c.Bind().Body(&req); err != nil || req.Token == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "token is required")
+	}
+
+	if !pat.IsToken(req.Token) {
+		return c.JSON(h.authorizationService.ValidateToken(req.Token))
+	}
+
+	identity, err := h.tokenVerifier.Verify(c.RequestCtx(), req.Token)
+	if err != nil {
+		if errors.Is(err, pat.ErrInvalidToken) {
+			return c.JSON(dto.ValidateTokenResponse{Valid: false, Roles: []string{}, Permissions: []string{}})
+		}
+		return err
+	}
+
+	return c.JSON(dto.ValidateTokenResponse{
+		Valid:       true,
+		UserID:      identity.UserID,
+		Email:       identity.Email,
+		UserType:    identity.UserType,
+		Roles:       identity.Roles,
+		Permissions: identity.Permissions,
+		TokenID:     identity.TokenID,
+		FullName:    identity.FullName,
+		Scopes:      identity.Scopes,
+		ExpiresAt:   identity.ExpiresAt,
+	})
+}
+
+// Authorize checks whether the caller holds a permission, globally or on one
+// of the given scopes. Used by other services for scoped checks.
+func (h *RBACHandler) Authorize(c fiber.Ctx) error {
+	userID, userType, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	var req authz.AuthorizeRequest
+	if err := c.Bind().Body(&req); err != nil || req.Permission == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "permission is required")
+	}
+
+	// A personal access token can only exercise the permissions it was
+	// created with, whatever the user holds on the scopes.
+	if tokenID, _ := c.Locals(pat.LocalsTokenID).(string); tokenID != "" {
+		scopes, _ := c.Locals(pat.LocalsTokenScopes).([]string)
+		if !authz.Contains(scopes, req.Permission) {
+			return c.JSON(authz.AuthorizeResponse{Allowed: false})
+		}
+	}
+
+	allowed, err := h.authorizationService.Authorize(c.RequestCtx(), userID, userType, req.Permission, req.Scopes)
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.JSON(authz.AuthorizeResponse{Allowed: allowed})
+}
+
+// GetMyPermissions returns the caller's roles, permissions and scoped
+// assignments.
+func (h *RBACHandler) GetMyPermissions(c fiber.Ctx) error {
+	userID, userType, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.authorizationService.EffectivePermissions(c.RequestCtx(), userID, userType)
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *RBACHandler) ListPermissions(c fiber.Ctx) error {
+	response, err := h.authorizationService.ListPermissions(c.RequestCtx())
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *RBACHandler) ListRoles(c fiber.Ctx) error {
+	response, err := h.authorizationService.ListRoles(c.RequestCtx())
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *RBACHandler) CreateRole(c fiber.Ctx) error {
+	var req dto.CreateRoleRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	response, err := h.authorizationService.CreateRole(c.RequestCtx(), &req)
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+func (h *RBACHandler) UpdateRole(c fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid role ID")
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	response, err := h.authorizationService.UpdateRole(c.RequestCtx(), id, &req)
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *RBACHandler) DeleteRole(c fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid role ID")
+	}
+
+	if err := h.authorizationService.DeleteRole(c.RequestCtx(), id); err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *RBACHandler) ListUserAssignments(c fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	response, err := h.authorizationService.ListUserAssignments(c.RequestCtx(), userID)
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *RBACHandler) AssignRole(c fiber.Ctx) error {
+	actorID, _, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.Bind().Body(&req); err != nil || req.Role == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "role and scope_type are required")
+	}
+
+	response, err := h.authorizationService.AssignRole(c.RequestCtx(), userID, &req, actorID)
+	if err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+func (h *RBACHandler) RevokeAssignment(c fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid user ID")
+	}
+	assignmentID, err := uuid.Parse(c.Params("assignmentId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid assignment ID")
+	}
+
+	if err := h.authorizationService.RevokeAssignment(c.RequestCtx(), userID, assignmentID); err != nil {
+		return handleRBACError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// currentUser reads the authenticated user's ID and type from Locals.
+func currentUser(c fiber.Ctx) (uuid.UUID, string, error) {
+	rawID, _ := c.Locals("user_id").(string)
+	userID, err := uuid.Parse(rawID)
+	if err != nil {
+		return uuid.Nil, "", fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+	}
+	userType, _ := c.Locals("user_type").(string)
+	return userID, userType, nil
+}
+
+func handleRBACError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrRoleNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Role not found")
+	case errors.Is(err, service.ErrRoleAssignmentNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Role assignment not found")
+	case errors.Is(err, service.ErrUserNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "User not found")
+	case errors.Is(err, service.ErrRoleExists):
+		return fiber.NewError(fiber.StatusConflict, "Role already exists")
+	case errors.Is(err, service.ErrRoleAssignmentExists):
+		return fiber.NewError(fiber.StatusConflict, "Role assignment already exists")
+	case errors.Is(err, service.ErrSystemRoleImmutable):
+		return fiber.NewError(fiber.StatusForbidden, "System roles cannot be modified")
+	case errors.Is(err, service.ErrUnknownPermission),
+		errors.Is(err, service.ErrInvalidScope),
+		errors.Is(err, service.ErrInvalidRoleName):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	default:
+		return err
+	}
+}
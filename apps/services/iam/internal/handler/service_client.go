@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type ServiceClientHandler struct {
+	serviceClientService service.ServiceClientService
+	auditLogService      service.AuditLogService
+}
+
+func NewServiceClientHandler(
+	serviceClientService service.ServiceClientService,
+	auditLogService service.AuditLogService,
+) *ServiceClientHandler {
+	return &ServiceClientHandler{
+		serviceClientService: serviceClientService,
+		auditLogService:      auditLogService,
+	}
+}
+
+// Token is the OAuth2 token endpoint for the client-credentials grant.
+// Clients authenticate with HTTP Basic or client_id/client_secret form fields.
+// Errors follow RFC 6749 section 5.2.
+func (h *ServiceClientHandler) Token(c fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	clientID, clientSecret, usedBasic := basicAuth(c.Get(fiber.HeaderAuthorization))
+	if !usedBasic {
+		clientID = c.FormValue("client_id")
+		clientSecret = c.FormValue("client_secret")
+	}
+	if clientID == "" || clientSecret == "" {
+		return oauthError(c, fiber.StatusBadRequest, "invalid_request", "client credentials are required")
+	}
+
+	response, err := h.serviceClientService.IssueToken(
+		c.RequestCtx(),
+		c.FormValue("grant_type"),
+		clientID,
+		clientSecret,
+		c.FormValue("scope"),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnsupportedGrantType):
+			return oauthError(c, fiber.StatusBadRequest, "unsupported_grant_type", "only client_credentials is supported")
+		case errors.Is(err, service.ErrInvalidClient):
+			if usedBasic {
+				c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="gradeloop"`)
+			}
+			return oauthError(c, fiber.StatusUnauthorized, "invalid_client", "client authentication failed")
+		case errors.Is(err, service.ErrServiceTokenNotAllowed):
+			return oauthError(c, fiber.StatusBadRequest, "invalid_scope", "requested scope is not granted to this client")
+		default:
+			return err
+		}
+	}
+
+	return c.JSON(response)
+}
+
+// CreateAuditLog stores an audit entry reported by another service. The route
+// requires a service token with the iam.audit:write scope.
+func (h *ServiceClientHandler) CreateAuditLog(c fiber.Ctx) error {
+	var req dto.CreateAuditLogRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	clientID, _ := c.Locals(servicetoken.LocalsClientID).(string)
+	if err := h.auditLogService.Record(c.RequestCtx(), &req, clientID); err != nil {
+		if errors.Is(err, service.ErrInvalidAuditLog) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+func (h *ServiceClientHandler) ListClients(c fiber.Ctx) error {
+	response, err := h.serviceClientService.ListClients(c.RequestCtx())
+	if err != nil {
+		return handleServiceClientError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *ServiceClientHandler) CreateClient(c fiber.Ctx) error {
+	var req dto.CreateServiceClientRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	response, err := h.serviceClientService.CreateClient(c.RequestCtx(), &req)
+	if err != nil {
+		return handleServiceClientError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+func (h *ServiceClientHandler) UpdateClient(c fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid service client ID")
+	}
+
+	var req dto.UpdateServiceClientRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	response, err := h.serviceClientService.UpdateClient(c.RequestCtx(), id, &req)
+	if err != nil {
+		return handleServiceClientError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *ServiceClientHandler) RotateSecret(c fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid service client ID")
+	}
+
+	response, err := h.serviceClientService.RotateSecret(c.RequestCtx(), id)
+	if err != nil {
+		return handleServiceClientError(err)
+	}
+
+	return c.JSON(response)
+}
+
+func (h *ServiceClientHandler) DeleteClient(c fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid service client ID")
+	}
+
+	if err := h.serviceClientService.DeleteClient(c.RequestCtx(), id); err != nil {
+		return handleServiceClientError(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// basicAuth decodes an HTTP Basic Authorization header.
+func basicAuth(header string) (string, string, bool) {
+	const prefix = "Basic "
+	if !strings.HasPrefix(header, prefix) {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(header[len(prefix):])
+	if err != nil {
+		return "", "", false
+	}
+	id, secret, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+func oauthError(c fiber.Ctx, status int, code, description string) error {
+	return c.Status(status).JSON(servicetoken.ErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
+
+func handleServiceClientError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrServiceClientNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Service client not found")
+	case errors.Is(err, service.ErrServiceClientExists):
+		return fiber.NewError(fiber.StatusConflict, "Service client already exists")
+	case errors.Is(err, service.ErrInvalidServiceScope),
+		errors.Is(err, service.ErrInvalidClientID):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/sso"
+	"github.com/gofiber/fiber/v3"
+)
+
+type SSOHandler struct {
+	ssoService         service.SSOService
+	frontendURL        string
+	cookieSecure       bool
+	cookieSameSite     string
+	refreshTokenExpiry time.Duration
+}
+
+func NewSSOHandler(
+	ssoService service.SSOService,
+	frontendURL string,
+	cookieSecure bool,
+	cookieSameSite string,
+	refreshTokenExpiryDays int64,
+) *SSOHandler {
+	return &SSOHandler{
+		ssoService:         ssoService,
+		frontendURL:        strings.TrimSuffix(frontendURL, "/"),
+		cookieSecure:       cookieSecure,
+		cookieSameSite:     cookieSameSite,
+		refreshTokenExpiry: time.Duration(refreshTokenExpiryDays) * 24 * time.Hour,
+	}
+}
+
+func (h *SSOHandler) ListProviders(c fiber.Ctx) error {
+	providers := h.ssoService.ListProviders()
+	return c.JSON(fiber.Map{
+		"providers": providers,
+		"count":     len(providers),
+	})
+}
+
+func (h *SSOHandler) Discover(c fiber.Ctx) error {
+	var req dto.SSODiscoverRequest
+
+	if err := c.Bind().Body(&req); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if req.Email == "" {
+		return fiber.ErrBadRequest
+	}
+
+	provider, err := h.ssoService.ProviderForEmail(req.Email)
+	if err != nil {
+		return handleSSOError(err)
+	}
+
+	return c.JSON(provider)
+}
+
+func (h *SSOHandler) BeginLogin(c fiber.Ctx) error {
+	response, err := h.ssoService.BeginLogin(c.RequestCtx(), c.Params("provider"), c.Query("redirect_to"))
+	if err != nil {
+		return handleSSOError(err)
+	}
+
+	return c.JSON(response)
+}
+
+// OIDCCallback is the provider's redirect URI. The browser lands here, so
+// failures are reported by redirecting back to the frontend login page.
+func (h *SSOHandler) OIDCCallback(c fiber.Ctx) error {
+	if idpError := c.Query("error"); idpError != "" {
+		return h.redirectWithError(c, idpError)
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		return h.redirectWithError(c, "missing_code")
+	}
+
+	response, redirectTo, err := h.ssoService.CompleteOIDCLogin(c.RequestCtx(), c.Params("provider"), c.Query("state"), code)
+	if err != nil {
+		return h.redirectWithError(c, ssoErrorCode(err))
+	}
+
+	return h.completeLogin(c, response, redirectTo)
+}
+
+// SAMLACS consumes a SAML response posted by the IdP (HTTP-POST binding).
+func (h *SSOHandler) SAMLACS(c fiber.Ctx) error {
+	samlResponse := c.FormValue("SAMLResponse")
+	if samlResponse == "" {
+		return h.redirectWithError(c, "missing_saml_response")
+	}
+
+	response, redirectTo, err := h.ssoService.CompleteSAMLLogin(c.RequestCtx(), c.Params("provider"), samlResponse, c.FormValue("RelayState"))
+	if err != nil {
+		return h.redirectWithError(c, ssoErrorCode(err))
+	}
+
+	return h.completeLogin(c, response, redirectTo)
+}
+
+func (h *SSOHandler) SAMLMetadata(c fiber.Ctx) error {
+	metadata, err := h.ssoService.SAMLMetadata(c.Params("provider"))
+	if err != nil {
+		return handleSSOError(err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/samlmetadata+xml")
+	return c.Send(metadata)
+}
+
+// completeLogin stores the refresh token in the httpOnly cookie and sends the
+// browser back to the frontend, which obtains an access token via /auth/refresh.
+func (h *SSOHandler) completeLogin(c fiber.Ctx, response *dto.LoginResponse, redirectTo string) error {
+	cookie := new(fiber.Cookie)
+	cookie.Name = "refresh_token"
+	cookie.Value = response.RefreshToken
+	cookie.Path = "/"
+	cookie.Expires = time.Now().Add(h.refreshTokenExpiry)
+	cookie.HTTPOnly = true
+	cookie.Secure = h.cookieSecure
+	cookie.SameSite = h.cookieSameSite
+
+	c.Cookie(cookie)
+
+	return c.Redirect().Status(fiber.StatusFound).To(h.frontendURL + redirectTo)
+}
+
+func (h *SSOHandler) redirectWithError(c fiber.Ctx, code string) error {
+	return c.Redirect().Status(fiber.StatusFound).To(h.frontendURL + "/login?sso_error=" + url.QueryEscape(code))
+}
+
+// ssoErrorCode maps service errors to the stable codes the frontend displays.
+func ssoErrorCode(err error) string {
+	switch {
+	case errors.Is(err, service.ErrSSOStateInvalid):
+		return "state_invalid"
+	case errors.Is(err, service.ErrSSOAccountNotProvisioned):
+		return "account_not_found"
+	case errors.Is(err, service.ErrSSOEmailDomainMismatch):
+		return "email_domain_mismatch"
+	case errors.Is(err, service.ErrSSOEmailUnverified):
+		return "email_unverified"
+	case errors.Is(err, service.ErrUserInactive):
+		return "account_inactive"
+	case errors.Is(err, sso.ErrMissingEmail):
+		return "missing_email"
+	case errors.Is(err, sso.ErrInvalidIDToken), errors.Is(err, sso.ErrInvalidSAMLResponse):
+		return "invalid_assertion"
+	default:
+		return "sso_failed"
+	}
+}
+
+func handleSSOError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrSSOProviderNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "SSO provider not found")
+	case errors.Is(err, service.ErrSSONoProviderForDomain):
+		return fiber.NewError(fiber.StatusNotFound, "No SSO provider configured for this email domain")
+	case errors.Is(err, sso.ErrDiscoveryFailed):
+		return fiber.NewError(fiber.StatusBadGateway, "Identity provider is unavailable")
+	default:
+		return err
+	}
+}
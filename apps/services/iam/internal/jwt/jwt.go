@@ -22,6 +22,10 @@ type Claims struct {
 	Email    string    `json:"email"`
 	UserType string    `json:"user_type"`
 	FullName string    `json:"full_name"`
+	// Roles and Permissions hold the user's global grants. Permissions from
+	// scoped role assignments are not embedded and are checked with IAM.
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -46,6 +50,12 @@ func NewJWT(secretKey string, accessTokenExpiryMinutes, refreshTokenExpiryDays i
 }
 
 func GenerateAccessToken(userID uuid.UUID, email, fullName, userType string, secretKey []byte, expiry time.Duration) (string, time.Time, error) {
+	return GenerateAccessTokenWithGrants(userID, email, fullName, userType, nil, nil, secretKey, expiry)
+}
+
+// GenerateAccessTokenWithGrants issues an access token carrying the user's
+// global roles and permissions.
+func GenerateAccessTokenWithGrants(userID uuid.UUID, email, fullName, userType string, roles, permissions []string, secretKey []byte, expiry time.Duration) (string, time.Time, error) {
 	if len(secretKey) == 0 {
 		return "", time.Time{}, errors.New("secret key cannot be empty")
 	}
@@ -53,10 +63,12 @@ func GenerateAccessToken(userID uuid.UUID, email, fullName, userType string, sec
 	expiresAt := time.Now().Add(expiry)
 
 	claims := Claims{
-		UserID:   userID,
-		Email:    email,
-		FullName: fullName,
-		UserType: userType,
+		UserID:      userID,
+		Email:       email,
+		FullName:    fullName,
+		UserType:    userType,
+		Roles:       roles,
+		Permissions: permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -115,6 +127,12 @@ func ValidateAccessToken(tokenString string, secretKey []byte) (*Claims, error)
 		return nil, ErrInvalidToken
 	}
 
+	// Service tokens share the signing key but carry no user; they are not
+	// access tokens.
+	if claims.UserID == uuid.Nil {
+		return nil, ErrInvalidToken
+	}
+
 	return claims, nil
 }
 
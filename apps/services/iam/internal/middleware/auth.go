@@ -1,16 +1,19 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/jwt"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
 	"github.com/gofiber/fiber/v3"
 )
 
-// AuthMiddleware creates a middleware that validates JWT access tokens
-// and stores user claims in context locals
-func AuthMiddleware(secretKey []byte) fiber.Handler {
+// AuthMiddleware creates a middleware that validates JWT access tokens or
+// personal access tokens and stores user claims in context locals
+func AuthMiddleware(secretKey []byte, tokens pat.Verifier) fiber.Handler {
 	return func(c fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -25,6 +28,27 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 
 		tokenString := parts[1]
 
+		if pat.IsToken(tokenString) {
+			identity, err := tokens.Verify(c.RequestCtx(), tokenString)
+			if err != nil {
+				if errors.Is(err, pat.ErrInvalidToken) {
+					return fiber.NewError(fiber.StatusUnauthorized, "Invalid token")
+				}
+				return fiber.NewError(fiber.StatusServiceUnavailable, "Token verification unavailable")
+			}
+
+			c.Locals("user_id", identity.UserID)
+			c.Locals("email", identity.Email)
+			c.Locals("user_type", identity.UserType)
+			c.Locals("full_name", identity.FullName)
+			c.Locals(authz.LocalsRoles, identity.Roles)
+			c.Locals(authz.LocalsPermissions, identity.Permissions)
+			c.Locals(pat.LocalsTokenID, identity.TokenID)
+			c.Locals(pat.LocalsTokenScopes, identity.Scopes)
+
+			return c.Next()
+		}
+
 		// Validate token
 		claims, err := jwt.ValidateAccessToken(tokenString, secretKey)
 		if err != nil {
@@ -39,11 +63,24 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 		c.Locals("email", claims.Email)
 		c.Locals("user_type", claims.UserType)
 		c.Locals("full_name", claims.FullName)
+		c.Locals(authz.LocalsRoles, claims.Roles)
+		c.Locals(authz.LocalsPermissions, claims.Permissions)
 
 		return c.Next()
 	}
 }
 
+// RequireSession rejects requests authenticated with a personal access token,
+// for operations that need an interactive login such as managing credentials
+func RequireSession() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if tokenID, _ := c.Locals(pat.LocalsTokenID).(string); tokenID != "" {
+			return fiber.NewError(fiber.StatusForbidden, "Not allowed with a personal access token")
+		}
+		return c.Next()
+	}
+}
+
 // RequireUserType creates a middleware that checks if the user has any of the allowed user types
 func RequireUserType(allowedUserTypes ...string) fiber.Handler {
 	return func(c fiber.Ctx) error {
@@ -0,0 +1,107 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/jwt"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/middleware"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/service"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// fakeTokenRepository holds a single personal access token.
+type fakeTokenRepository struct {
+	repository.PersonalAccessTokenRepository
+	token *domain.PersonalAccessToken
+}
+
+func (r *fakeTokenRepository) GetTokenByHash(_ context.Context, hash string) (*domain.PersonalAccessToken, error) {
+	if r.token.TokenHash == hash {
+		return r.token, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeTokenRepository) TouchLastUsed(context.Context, uuid.UUID, time.Time, time.Time) error {
+	return nil
+}
+
+// fakeUserRepository holds a single user.
+type fakeUserRepository struct {
+	repository.UserRepository
+	user *domain.User
+}
+
+func (r *fakeUserRepository) GetUserByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	if r.user.ID == id {
+		return r.user, nil
+	}
+	return nil, nil
+}
+
+// fakeAuthorization grants the same permissions to everyone.
+type fakeAuthorization struct {
+	service.AuthorizationService
+	permissions []string
+}
+
+func (a *fakeAuthorization) GlobalGrants(context.Context, uuid.UUID, string) (*service.Grants, error) {
+	return &service.Grants{Roles: []string{"admin"}, Permissions: a.permissions}, nil
+}
+
+func TestAuthMiddleware_NarrowPersonalAccessTokenFailsUserTypeGate(t *testing.T) {
+	admin := &domain.User{ID: uuid.New(), Email: "admin@uni.example", UserType: domain.UserTypeAdmin, IsActive: true}
+	permissions := []string{authz.PermUserRead, authz.PermUserDelete}
+
+	tests := []struct {
+		name   string
+		scopes string
+		want   int
+	}{
+		{"narrow token", authz.PermUserRead, http.StatusForbidden},
+		{"full token", authz.PermUserDelete + " " + authz.PermUserRead, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const secret = "glpat_test-token"
+			tokens := service.NewPersonalAccessTokenService(
+				&fakeTokenRepository{token: &domain.PersonalAccessToken{
+					ID:        uuid.New(),
+					UserID:    admin.ID,
+					TokenHash: jwt.HashToken(secret),
+					Scopes:    tt.scopes,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}},
+				&fakeUserRepository{user: admin},
+				&fakeAuthorization{permissions: permissions},
+				30,
+			)
+
+			app := fiber.New()
+			app.Delete("/users/:id",
+				middleware.AuthMiddleware([]byte("secret"), tokens),
+				middleware.RequireAdmin(),
+				func(c fiber.Ctx) error { return c.SendStatus(http.StatusOK) },
+			)
+
+			req := httptest.NewRequest(http.MethodDelete, "/users/"+uuid.NewString(), nil)
+			req.Header.Set("Authorization", "Bearer "+secret)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	CreateAuditLog(ctx context.Context, entry *domain.AuditLog) error
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (r *auditLogRepository) CreateAuditLog(ctx context.Context, entry *domain.AuditLog) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
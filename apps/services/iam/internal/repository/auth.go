@@ -21,6 +21,7 @@ type AuthRepository interface {
 	GetPasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
 	UsePasswordResetToken(ctx context.Context, tokenID uuid.UUID) error
 	GetActiveSessionsCount(ctx context.Context, userID uuid.UUID) (int64, error)
+	RecordLogin(ctx context.Context, userID uuid.UUID) error
 }
 
 type authRepository struct {
@@ -106,6 +107,17 @@ func (r *authRepository) InvalidateAllRefreshTokens(ctx context.Context, userID
 		Update("revoked_at", now).Error
 }
 
+// RecordLogin stamps the user's last login and clears any inactivity flag.
+func (r *authRepository) RecordLogin(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"last_login_at":       time.Now(),
+			"inactive_flagged_at": nil,
+		}).Error
+}
+
 func (r *authRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
 	return r.db.WithContext(ctx).Create(token).Error
 }
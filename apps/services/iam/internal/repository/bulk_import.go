@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BulkImportRepository interface {
+	// CreateJob stores the job together with its rows.
+	CreateJob(ctx context.Context, job *domain.BulkImportJob, rows []domain.BulkImportRow) error
+	GetJob(ctx context.Context, id uuid.UUID) (*domain.BulkImportJob, error)
+	ListJobs(ctx context.Context, limit int) ([]domain.BulkImportJob, error)
+	// FindActiveJobByHash returns a pending or running job the user started
+	// from a file with the given hash.
+	FindActiveJobByHash(ctx context.Context, createdBy uuid.UUID, fileHash string) (*domain.BulkImportJob, error)
+	// ListResumableJobs returns pending jobs and running ones whose claim was
+	// last renewed before staleBefore, oldest first.
+	ListResumableJobs(ctx context.Context, staleBefore time.Time) ([]domain.BulkImportJob, error)
+	// ClaimJob marks the job running for worker. It succeeds for a pending
+	// job, a job worker already holds, or one whose claim was last renewed
+	// before staleBefore; false means another worker holds it.
+	ClaimJob(ctx context.Context, id uuid.UUID, worker string, staleBefore time.Time) (bool, error)
+	ListPendingRows(ctx context.Context, jobID uuid.UUID, limit int) ([]domain.BulkImportRow, error)
+	ListRows(ctx context.Context, jobID uuid.UUID, status string) ([]domain.BulkImportRow, error)
+	// RecordRowResult saves a processed row and bumps the job's counters.
+	RecordRowResult(ctx context.Context, row *domain.BulkImportRow) error
+	UpdateJobStatus(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+}
+
+type bulkImportRepository struct {
+	db *gorm.DB
+}
+
+func NewBulkImportRepository(db *gorm.DB) BulkImportRepository {
+	return &bulkImportRepository{db: db}
+}
+
+func (r *bulkImportRepository) CreateJob(ctx context.Context, job *domain.BulkImportJob, rows []domain.BulkImportRow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, 500).Error
+	})
+}
+
+func (r *bulkImportRepository) GetJob(ctx context.Context, id uuid.UUID) (*domain.BulkImportJob, error) {
+	var job domain.BulkImportJob
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *bulkImportRepository) ListJobs(ctx context.Context, limit int) ([]domain.BulkImportJob, error) {
+	var jobs []domain.BulkImportJob
+	err := r.db.WithContext(ctx).Order("created_at DESC").Limit(limit).Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *bulkImportRepository) FindActiveJobByHash(ctx context.Context, createdBy uuid.UUID, fileHash string) (*domain.BulkImportJob, error) {
+	var job domain.BulkImportJob
+	err := r.db.WithContext(ctx).
+		Where("created_by = ? AND file_hash = ? AND status IN ?", createdBy, fileHash,
+			[]string{domain.ImportJobPending, domain.ImportJobRunning}).
+		Order("created_at DESC").
+		First(&job).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *bulkImportRepository) ListResumableJobs(ctx context.Context, staleBefore time.Time) ([]domain.BulkImportJob, error) {
+	var jobs []domain.BulkImportJob
+	err := r.db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND (claimed_at IS NULL OR claimed_at < ?))",
+			domain.ImportJobPending, domain.ImportJobRunning, staleBefore).
+		Order("created_at ASC").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *bulkImportRepository) ClaimJob(ctx context.Context, id uuid.UUID, worker string, staleBefore time.Time) (bool, error) {
+	now := time.Now()
+	res := r.db.WithContext(ctx).Model(&domain.BulkImportJob{}).
+		Where("id = ?", id).
+		Where("status = ? OR (status = ? AND (claimed_by = ? OR claimed_at IS NULL OR claimed_at < ?))",
+			domain.ImportJobPending, domain.ImportJobRunning, worker, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     domain.ImportJobRunning,
+			"claimed_by": worker,
+			"claimed_at": now,
+			"started_at": gorm.Expr("COALESCE(started_at, ?)", now),
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *bulkImportRepository) ListPendingRows(ctx context.Context, jobID uuid.UUID, limit int) ([]domain.BulkImportRow, error) {
+	var rows []domain.BulkImportRow
+	err := r.db.WithContext(ctx).
+		Where("job_id = ? AND status = ?", jobID, domain.ImportRowPending).
+		Order("row_index ASC").
+		Limit(limit).
+		Find(&rows).Error
+	return rows, err
+}
+
+// ListRows returns the job's rows in file order. An empty status returns all
+// of them.
+func (r *bulkImportRepository) ListRows(ctx context.Context, jobID uuid.UUID, status string) ([]domain.BulkImportRow, error) {
+	var rows []domain.BulkImportRow
+	query := r.db.WithContext(ctx).Where("job_id = ?", jobID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("row_index ASC").Find(&rows).Error
+	return rows, err
+}
+
+func (r *bulkImportRepository) RecordRowResult(ctx context.Context, row *domain.BulkImportRow) error {
+	counter := ""
+	switch row.Status {
+	case domain.ImportRowCreated:
+		counter = "created_count"
+	case domain.ImportRowSkipped:
+		counter = "skipped_count"
+	case domain.ImportRowFailed:
+		counter = "failed_count"
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only a still-pending row counts, so a row replayed after a crash
+		// between the two updates is not counted twice.
+		res := tx.Model(&domain.BulkImportRow{}).
+			Where("id = ? AND status = ?", row.ID, domain.ImportRowPending).
+			Updates(map[string]interface{}{
+				"status":         row.Status,
+				"message":        row.Message,
+				"user_id":        row.UserID,
+				"email_sent":     row.EmailSent,
+				"batch_assigned": row.BatchAssigned,
+				"processed_at":   row.ProcessedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 || counter == "" {
+			return nil
+		}
+		return tx.Model(&domain.BulkImportJob{}).
+			Where("id = ?", row.JobID).
+			Updates(map[string]interface{}{
+				"processed_rows": gorm.Expr("processed_rows + 1"),
+				counter:          gorm.Expr(counter + " + 1"),
+				"updated_at":     time.Now(),
+			}).Error
+	})
+}
+
+func (r *bulkImportRepository) UpdateJobStatus(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.BulkImportJob{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserData is everything IAM holds about one user, gathered for a personal
+// data export.
+type UserData struct {
+	User              domain.User
+	StudentProfile    *domain.UserProfileStudent
+	InstructorProfile *domain.UserProfileInstructor
+	Identities        []domain.UserIdentity
+	RoleAssignments   []domain.RoleAssignment
+	AccessTokens      []domain.PersonalAccessToken
+}
+
+type DataRequestRepository interface {
+	CreateRequest(ctx context.Context, req *domain.DataRequest) error
+	GetRequest(ctx context.Context, id uuid.UUID) (*domain.DataRequest, error)
+	// ListRequests returns the newest requests, optionally limited to one
+	// user and one status.
+	ListRequests(ctx context.Context, userID *uuid.UUID, status string, limit int) ([]domain.DataRequest, error)
+	// FindActiveRequest returns a request of the type for the user that is
+	// still awaiting approval, queued or running.
+	FindActiveRequest(ctx context.Context, userID uuid.UUID, requestType string) (*domain.DataRequest, error)
+	// ListQueuedRequests returns requests left pending or running, oldest first.
+	ListQueuedRequests(ctx context.Context) ([]domain.DataRequest, error)
+	// ListExportsExpiredBefore returns completed exports whose archive
+	// expired before the cutoff.
+	ListExportsExpiredBefore(ctx context.Context, cutoff time.Time) ([]domain.DataRequest, error)
+	// ListCompletedExports returns the user's exports whose archive is still
+	// stored.
+	ListCompletedExports(ctx context.Context, userID uuid.UUID) ([]domain.DataRequest, error)
+	UpdateRequest(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	// GetUserData loads the user, including a soft-deleted one, with their
+	// profiles, identities, role assignments and access tokens.
+	GetUserData(ctx context.Context, userID uuid.UUID) (*UserData, error)
+}
+
+type dataRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewDataRequestRepository(db *gorm.DB) DataRequestRepository {
+	return &dataRequestRepository{db: db}
+}
+
+func (r *dataRequestRepository) CreateRequest(ctx context.Context, req *domain.DataRequest) error {
+	return r.db.WithContext(ctx).Create(req).Error
+}
+
+func (r *dataRequestRepository) GetRequest(ctx context.Context, id uuid.UUID) (*domain.DataRequest, error) {
+	var req domain.DataRequest
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&req).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *dataRequestRepository) ListRequests(ctx context.Context, userID *uuid.UUID, status string, limit int) ([]domain.DataRequest, error) {
+	query := r.db.WithContext(ctx).Model(&domain.DataRequest{})
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var reqs []domain.DataRequest
+	err := query.Order("created_at DESC").Limit(limit).Find(&reqs).Error
+	return reqs, err
+}
+
+func (r *dataRequestRepository) FindActiveRequest(ctx context.Context, userID uuid.UUID, requestType string) (*domain.DataRequest, error) {
+	var req domain.DataRequest
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND type = ? AND status IN ?", userID, requestType,
+			[]string{domain.DataRequestPendingApproval, domain.DataRequestPending, domain.DataRequestRunning}).
+		Order("created_at DESC").
+		First(&req).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *dataRequestRepository) ListQueuedRequests(ctx context.Context) ([]domain.DataRequest, error) {
+	var reqs []domain.DataRequest
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{domain.DataRequestPending, domain.DataRequestRunning}).
+		Order("created_at ASC").
+		Find(&reqs).Error
+	return reqs, err
+}
+
+func (r *dataRequestRepository) ListExportsExpiredBefore(ctx context.Context, cutoff time.Time) ([]domain.DataRequest, error) {
+	var reqs []domain.DataRequest
+	err := r.db.WithContext(ctx).
+		Where("type = ? AND status = ? AND expires_at < ?", domain.DataRequestExport, domain.DataRequestCompleted, cutoff).
+		Find(&reqs).Error
+	return reqs, err
+}
+
+func (r *dataRequestRepository) ListCompletedExports(ctx context.Context, userID uuid.UUID) ([]domain.DataRequest, error) {
+	var reqs []domain.DataRequest
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND type = ? AND status = ?", userID, domain.DataRequestExport, domain.DataRequestCompleted).
+		Find(&reqs).Error
+	return reqs, err
+}
+
+func (r *dataRequestRepository) UpdateRequest(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&domain.DataRequest{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *dataRequestRepository) GetUserData(ctx context.Context, userID uuid.UUID) (*UserData, error) {
+	db := r.db.WithContext(ctx)
+
+	var data UserData
+	if err := db.Unscoped().Where("id = ?", userID).First(&data.User).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var student domain.UserProfileStudent
+	if err := db.Where("user_id = ?", userID).Limit(1).Find(&student).Error; err != nil {
+		return nil, err
+	}
+	if student.UserID != uuid.Nil {
+		data.StudentProfile = &student
+	}
+
+	var instructor domain.UserProfileInstructor
+	if err := db.Where("user_id = ?", userID).Limit(1).Find(&instructor).Error; err != nil {
+		return nil, err
+	}
+	if instructor.UserID != uuid.Nil {
+		data.InstructorProfile = &instructor
+	}
+
+	if err := db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Identities).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Preload("Role").Where("user_id = ?", userID).Order("created_at ASC").Find(&data.RoleAssignments).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.AccessTokens).Error; err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
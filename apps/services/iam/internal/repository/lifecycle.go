@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// lifecycleLookupChunk bounds the size of IN lists when matching users
+// reported by other services.
+const lifecycleLookupChunk = 500
+
+type LifecycleRepository interface {
+	// ListActiveStudents returns the active, non-deleted students among ids.
+	ListActiveStudents(ctx context.Context, ids []uuid.UUID) ([]domain.User, error)
+	// DeactivateUser switches the account off and revokes its sessions.
+	DeactivateUser(ctx context.Context, userID uuid.UUID, reason string, at time.Time) error
+	// ListInactiveUsers returns active users not yet flagged whose last login
+	// (or creation, if they never logged in) is before the cutoff.
+	ListInactiveUsers(ctx context.Context, before time.Time) ([]domain.User, error)
+	FlagInactive(ctx context.Context, userIDs []uuid.UUID, at time.Time) error
+	// ListExpiredDeletedUsers returns soft-deleted users deleted before the
+	// cutoff that have not been anonymised yet.
+	ListExpiredDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]domain.User, error)
+	// AnonymizeUser scrubs personal data from a soft-deleted user and removes
+	// everything that could identify or authenticate them, keeping the row
+	// so references from other services stay valid.
+	AnonymizeUser(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// PurgeUser hard-deletes a soft-deleted user; dependent rows cascade.
+	PurgeUser(ctx context.Context, userID uuid.UUID) error
+
+	CreateRun(ctx context.Context, run *domain.LifecycleRun) error
+	UpdateRun(ctx context.Context, run *domain.LifecycleRun) error
+	// GetLatestRun returns the most recently started run with the trigger.
+	GetLatestRun(ctx context.Context, trigger string) (*domain.LifecycleRun, error)
+	GetRun(ctx context.Context, id uuid.UUID) (*domain.LifecycleRun, error)
+	ListRuns(ctx context.Context, limit int) ([]domain.LifecycleRun, error)
+}
+
+type lifecycleRepository struct {
+	db *gorm.DB
+}
+
+func NewLifecycleRepository(db *gorm.DB) LifecycleRepository {
+	return &lifecycleRepository{db: db}
+}
+
+func (r *lifecycleRepository) ListActiveStudents(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	var users []domain.User
+	for start := 0; start < len(ids); start += lifecycleLookupChunk {
+		end := start + lifecycleLookupChunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var chunk []domain.User
+		if err := r.db.WithContext(ctx).
+			Where("id IN ? AND user_type = ? AND is_active = ?", ids[start:end], domain.UserTypeStudent, true).
+			Find(&chunk).Error; err != nil {
+			return nil, err
+		}
+		users = append(users, chunk...)
+	}
+	return users, nil
+}
+
+func (r *lifecycleRepository) DeactivateUser(ctx context.Context, userID uuid.UUID, reason string, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"is_active":           false,
+				"deactivated_at":      at,
+				"deactivation_reason": reason,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", at).Error
+	})
+}
+
+func (r *lifecycleRepository) ListInactiveUsers(ctx context.Context, before time.Time) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.WithContext(ctx).
+		Where("is_active = ? AND inactive_flagged_at IS NULL", true).
+		Where("COALESCE(last_login_at, created_at) < ?", before).
+		Order("created_at").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *lifecycleRepository) FlagInactive(ctx context.Context, userIDs []uuid.UUID, at time.Time) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id IN ? AND inactive_flagged_at IS NULL", userIDs).
+		Update("inactive_flagged_at", at).Error
+}
+
+func (r *lifecycleRepository) ListExpiredDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND anonymized_at IS NULL", deletedBefore).
+		Order("deleted_at").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *lifecycleRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Model(&domain.User{}).
+			Where("id = ? AND deleted_at IS NOT NULL", userID).
+			Updates(map[string]interface{}{
+				"email":                   fmt.Sprintf("deleted-%s@anonymized.invalid", userID),
+				"full_name":               "Deleted User",
+				"avatar_url":              "",
+				"faculty":                 "",
+				"department":              "",
+				"password_hash":           "",
+				"is_active":               false,
+				"git_hub_id":              nil,
+				"git_hub_username":        "",
+				"git_hub_token_encrypted": "",
+				"anonymized_at":           at,
+			}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&domain.UserProfileStudent{},
+			&domain.UserProfileInstructor{},
+			&domain.UserIdentity{},
+			&domain.RefreshToken{},
+			&domain.PasswordResetToken{},
+			&domain.PersonalAccessToken{},
+			&domain.RoleAssignment{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		return scrubImportRows(tx, userID)
+	})
+}
+
+func (r *lifecycleRepository) PurgeUser(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := scrubImportRows(tx, userID); err != nil {
+			return err
+		}
+		return tx.Unscoped().
+			Where("id = ? AND deleted_at IS NOT NULL", userID).
+			Delete(&domain.User{}).Error
+	})
+}
+
+// scrubImportRows clears the copy of a user's details kept by bulk imports.
+func scrubImportRows(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Model(&domain.BulkImportRow{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"email": "",
+			"data":  "{}",
+		}).Error
+}
+
+func (r *lifecycleRepository) CreateRun(ctx context.Context, run *domain.LifecycleRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *lifecycleRepository) UpdateRun(ctx context.Context, run *domain.LifecycleRun) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+func (r *lifecycleRepository) GetLatestRun(ctx context.Context, trigger string) (*domain.LifecycleRun, error) {
+	var run domain.LifecycleRun
+	err := r.db.WithContext(ctx).
+		Omit("report").
+		Where("trigger = ?", trigger).
+		Order("started_at DESC").
+		First(&run).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *lifecycleRepository) GetRun(ctx context.Context, id uuid.UUID) (*domain.LifecycleRun, error) {
+	var run domain.LifecycleRun
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&run).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *lifecycleRepository) ListRuns(ctx context.Context, limit int) ([]domain.LifecycleRun, error) {
+	var runs []domain.LifecycleRun
+	err := r.db.WithContext(ctx).
+		Omit("report").
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&runs).Error
+	return runs, err
+}
@@ -34,7 +34,19 @@ func (m *Migrator) Run() error {
 		&domain.UserProfileStudent{},
 		&domain.UserProfileInstructor{},
 		&domain.RefreshToken{},
+		&domain.PersonalAccessToken{},
 		&domain.PasswordResetToken{},
+		&domain.UserIdentity{},
+		&domain.SSOLoginState{},
+		&domain.Permission{},
+		&domain.Role{},
+		&domain.RoleAssignment{},
+		&domain.ServiceClient{},
+		&domain.AuditLog{},
+		&domain.BulkImportJob{},
+		&domain.BulkImportRow{},
+		&domain.LifecycleRun{},
+		&domain.DataRequest{},
 	)
 	if err != nil {
 		return fmt.Errorf("auto migration failed: %w", err)
@@ -217,6 +229,19 @@ func (m *Migrator) Rollback() error {
 	m.logger.Info("rolling back all migrations")
 
 	err := m.db.Migrator().DropTable(
+		&domain.DataRequest{},
+		&domain.LifecycleRun{},
+		&domain.BulkImportRow{},
+		&domain.BulkImportJob{},
+		&domain.RoleAssignment{},
+		&domain.ServiceClient{},
+		&domain.AuditLog{},
+		"access_role_permissions",
+		&domain.Role{},
+		&domain.Permission{},
+		&domain.SSOLoginState{},
+		&domain.UserIdentity{},
+		&domain.PersonalAccessToken{},
 		&domain.RefreshToken{},
 		&domain.PasswordResetToken{},
 		"role_permissions", // legacy many2many table name
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -23,6 +24,9 @@ func NewSeeder(db *gorm.DB, logger *zap.Logger) *Seeder {
 }
 
 func (s *Seeder) Seed() error {
+	if err := s.seedRBAC(); err != nil {
+		return fmt.Errorf("seeding roles and permissions: %w", err)
+	}
 	if err := s.seedAdmin(); err != nil {
 		return fmt.Errorf("seeding admin: %w", err)
 	}
@@ -105,3 +109,48 @@ func (s *Seeder) seedAdmin() error {
 	s.logger.Info("created admin user", zap.String("email", email))
 	return nil
 }
+
+// seedRBAC upserts the permission catalogue and the system roles, resetting
+// each system role's permissions to domain.SystemRolePermissions.
+func (s *Seeder) seedRBAC() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		permissions := make(map[string]domain.Permission, len(authz.AllPermissions))
+		for _, name := range authz.AllPermissions {
+			var perm domain.Permission
+			err := tx.Where("name = ?", name).First(&perm).Error
+			if err == gorm.ErrRecordNotFound {
+				perm = domain.Permission{ID: uuid.New(), Name: name}
+				if err := tx.Create(&perm).Error; err != nil {
+					return fmt.Errorf("creating permission %s: %w", name, err)
+				}
+			} else if err != nil {
+				return fmt.Errorf("checking permission %s: %w", name, err)
+			}
+			permissions[name] = perm
+		}
+
+		for roleName, permNames := range domain.SystemRolePermissions {
+			var role domain.Role
+			err := tx.Where("name = ?", roleName).First(&role).Error
+			if err == gorm.ErrRecordNotFound {
+				role = domain.Role{ID: uuid.New(), Name: roleName, IsSystem: true}
+				if err := tx.Create(&role).Error; err != nil {
+					return fmt.Errorf("creating role %s: %w", roleName, err)
+				}
+			} else if err != nil {
+				return fmt.Errorf("checking role %s: %w", roleName, err)
+			}
+
+			rolePerms := make([]domain.Permission, 0, len(permNames))
+			for _, name := range permNames {
+				rolePerms = append(rolePerms, permissions[name])
+			}
+			if err := tx.Model(&role).Association("Permissions").Replace(rolePerms); err != nil {
+				return fmt.Errorf("syncing permissions of role %s: %w", roleName, err)
+			}
+		}
+
+		s.logger.Info("synced roles and permissions", zap.Int("permissions", len(permissions)))
+		return nil
+	})
+}
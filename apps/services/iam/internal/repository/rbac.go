@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RBACRepository interface {
+	ListPermissions(ctx context.Context) ([]domain.Permission, error)
+	GetPermissionsByNames(ctx context.Context, names []string) ([]domain.Permission, error)
+	ListRoles(ctx context.Context) ([]domain.Role, error)
+	GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error)
+	GetRoleByName(ctx context.Context, name string) (*domain.Role, error)
+	CreateRole(ctx context.Context, role *domain.Role) error
+	UpdateRole(ctx context.Context, role *domain.Role, permissions []domain.Permission) error
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	GetRolePermissionNames(ctx context.Context, roleName string) ([]string, error)
+	ListAssignmentsByUser(ctx context.Context, userID uuid.UUID) ([]domain.RoleAssignment, error)
+	GetAssignment(ctx context.Context, id uuid.UUID) (*domain.RoleAssignment, error)
+	FindAssignment(ctx context.Context, userID, roleID uuid.UUID, scopeType string, scopeID uuid.UUID) (*domain.RoleAssignment, error)
+	CreateAssignment(ctx context.Context, assignment *domain.RoleAssignment) error
+	DeleteAssignment(ctx context.Context, id uuid.UUID) error
+}
+
+type rbacRepository struct {
+	db *gorm.DB
+}
+
+func NewRBACRepository(db *gorm.DB) RBACRepository {
+	return &rbacRepository{db: db}
+}
+
+func (r *rbacRepository) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *rbacRepository) GetPermissionsByNames(ctx context.Context, names []string) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *rbacRepository) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	var roles []domain.Role
+	err := r.db.WithContext(ctx).Preload("Permissions").Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+func (r *rbacRepository) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
+	var role domain.Role
+	err := r.db.WithContext(ctx).Preload("Permissions").Where("id = ?", id).First(&role).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *rbacRepository) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
+	var role domain.Role
+	err := r.db.WithContext(ctx).Preload("Permissions").Where("name = ?", name).First(&role).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *rbacRepository) CreateRole(ctx context.Context, role *domain.Role) error {
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+// UpdateRole saves the role's fields and replaces its permission set.
+func (r *rbacRepository) UpdateRole(ctx context.Context, role *domain.Role, permissions []domain.Permission) error {
+	return WithTxContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Model(role).Updates(map[string]interface{}{
+			"description": role.Description,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(role).Association("Permissions").Replace(permissions)
+	})
+}
+
+func (r *rbacRepository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	return WithTxContext(ctx, r.db, func(tx *gorm.DB) error {
+		role := &domain.Role{ID: id}
+		if err := tx.Model(role).Association("Permissions").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&domain.RoleAssignment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(role).Error
+	})
+}
+
+func (r *rbacRepository) GetRolePermissionNames(ctx context.Context, roleName string) ([]string, error) {
+	var names []string
+	err := r.db.WithContext(ctx).
+		Table("access_permissions").
+		Select("access_permissions.name").
+		Joins("JOIN access_role_permissions ON access_role_permissions.permission_id = access_permissions.id").
+		Joins("JOIN access_roles ON access_roles.id = access_role_permissions.role_id").
+		Where("access_roles.name = ?", roleName).
+		Pluck("access_permissions.name", &names).Error
+	return names, err
+}
+
+func (r *rbacRepository) ListAssignmentsByUser(ctx context.Context, userID uuid.UUID) ([]domain.RoleAssignment, error) {
+	var assignments []domain.RoleAssignment
+	err := r.db.WithContext(ctx).
+		Preload("Role.Permissions").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *rbacRepository) GetAssignment(ctx context.Context, id uuid.UUID) (*domain.RoleAssignment, error) {
+	var assignment domain.RoleAssignment
+	err := r.db.WithContext(ctx).Preload("Role").Where("id = ?", id).First(&assignment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *rbacRepository) FindAssignment(ctx context.Context, userID, roleID uuid.UUID, scopeType string, scopeID uuid.UUID) (*domain.RoleAssignment, error) {
+	var assignment domain.RoleAssignment
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND role_id = ? AND scope_type = ? AND scope_id = ?", userID, roleID, scopeType, scopeID).
+		First(&assignment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *rbacRepository) CreateAssignment(ctx context.Context, assignment *domain.RoleAssignment) error {
+	return r.db.WithContext(ctx).Create(assignment).Error
+}
+
+func (r *rbacRepository) DeleteAssignment(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.RoleAssignment{}).Error
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ServiceClientRepository interface {
+	ListClients(ctx context.Context) ([]domain.ServiceClient, error)
+	GetClientByID(ctx context.Context, id uuid.UUID) (*domain.ServiceClient, error)
+	GetClientByClientID(ctx context.Context, clientID string) (*domain.ServiceClient, error)
+	CreateClient(ctx context.Context, client *domain.ServiceClient) error
+	UpdateClient(ctx context.Context, client *domain.ServiceClient) error
+	DeleteClient(ctx context.Context, id uuid.UUID) error
+	TouchLastToken(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type serviceClientRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceClientRepository(db *gorm.DB) ServiceClientRepository {
+	return &serviceClientRepository{db: db}
+}
+
+func (r *serviceClientRepository) ListClients(ctx context.Context) ([]domain.ServiceClient, error) {
+	var clients []domain.ServiceClient
+	err := r.db.WithContext(ctx).Order("client_id ASC").Find(&clients).Error
+	return clients, err
+}
+
+func (r *serviceClientRepository) GetClientByID(ctx context.Context, id uuid.UUID) (*domain.ServiceClient, error) {
+	var client domain.ServiceClient
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&client).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *serviceClientRepository) GetClientByClientID(ctx context.Context, clientID string) (*domain.ServiceClient, error) {
+	var client domain.ServiceClient
+	err := r.db.WithContext(ctx).Where("client_id = ?", clientID).First(&client).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *serviceClientRepository) CreateClient(ctx context.Context, client *domain.ServiceClient) error {
+	return r.db.WithContext(ctx).Create(client).Error
+}
+
+func (r *serviceClientRepository) UpdateClient(ctx context.Context, client *domain.ServiceClient) error {
+	return r.db.WithContext(ctx).Model(client).Updates(map[string]interface{}{
+		"name":        client.Name,
+		"secret_hash": client.SecretHash,
+		"scopes":      client.Scopes,
+		"is_active":   client.IsActive,
+	}).Error
+}
+
+func (r *serviceClientRepository) DeleteClient(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.ServiceClient{}, "id = ?", id).Error
+}
+
+func (r *serviceClientRepository) TouchLastToken(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.ServiceClient{}).
+		Where("id = ?", id).
+		UpdateColumn("last_token_at", at).Error
+}
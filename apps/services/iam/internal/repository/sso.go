@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SSORepository interface {
+	CreateLoginState(ctx context.Context, state *domain.SSOLoginState) error
+	ConsumeLoginState(ctx context.Context, stateHash string) (*domain.SSOLoginState, error)
+	DeleteExpiredLoginStates(ctx context.Context) error
+	GetIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error)
+	CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error
+	TouchIdentity(ctx context.Context, identityID uuid.UUID) error
+}
+
+type ssoRepository struct {
+	db *gorm.DB
+}
+
+func NewSSORepository(db *gorm.DB) SSORepository {
+	return &ssoRepository{db: db}
+}
+
+func (r *ssoRepository) CreateLoginState(ctx context.Context, state *domain.SSOLoginState) error {
+	return r.db.WithContext(ctx).Create(state).Error
+}
+
+// ConsumeLoginState deletes the login state matching stateHash and returns it,
+// so a state can complete at most one login. Expired states are treated as
+// missing.
+func (r *ssoRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*domain.SSOLoginState, error) {
+	var states []domain.SSOLoginState
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("state_hash = ? AND expires_at > ?", stateHash, time.Now()).
+		Delete(&states).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(states) == 0 {
+		return nil, nil
+	}
+
+	return &states[0], nil
+}
+
+func (r *ssoRepository) DeleteExpiredLoginStates(ctx context.Context) error {
+	return r.db.WithContext(ctx).
+		Where("expires_at <= ?", time.Now()).
+		Delete(&domain.SSOLoginState{}).Error
+}
+
+func (r *ssoRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (r *ssoRepository) CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	return r.db.WithContext(ctx).Create(identity).Error
+}
+
+func (r *ssoRepository) TouchIdentity(ctx context.Context, identityID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserIdentity{}).
+		Where("id = ?", identityID).
+		Update("last_login_at", time.Now()).Error
+}
@@ -110,7 +110,7 @@ func (r *userRepository) RestoreUser(ctx context.Context, userID uuid.UUID) erro
 	return r.db.WithContext(ctx).
 		Model(&domain.User{}).
 		Unscoped().
-		Where("id = ?", userID).
+		Where("id = ? AND anonymized_at IS NULL", userID).
 		Update("deleted_at", nil).Error
 }
 
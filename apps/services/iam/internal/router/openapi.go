@@ -0,0 +1,610 @@
+package router
+
+import (
+	"reflect"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/gofiber/fiber/v3"
+	"gorm.io/gorm"
+)
+
+// OpenAPI returns the IAM service's OpenAPI document, served at /openapi.json
+// and enforced on requests by SetupRoutes. Every route SetupRoutes registers
+// must be listed in routes: TestOpenAPIMatchesRoutes fails the build when the
+// two disagree.
+func OpenAPI() *openapi.Document {
+	return openapi.Spec{
+		Info: openapi.Info{
+			Title:       "GradeLoop IAM Service",
+			Description: "Users, authentication, SSO, roles, service clients and personal data requests.",
+			Version:     "1.0.0",
+		},
+		Types: map[reflect.Type]*openapi.Schema{
+			reflect.TypeOf(gorm.DeletedAt{}): {Type: []string{"string", "null"}, Format: "date-time"},
+		},
+		Error:  utils.AppError{},
+		Routes: routes,
+	}.MustBuild()
+}
+
+var (
+	// sheetFormat is the format of user import sheets.
+	sheetFormat = &openapi.Schema{Type: "string", Enum: []any{"csv", "xlsx"}}
+	// sheet is a user import sheet in either format.
+	sheet = openapi.Binary{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}
+)
+
+var routes = []openapi.Route{
+
+	// service
+	{
+		Method: fiber.MethodGet, Path: "/", Name: "getServiceInfo",
+		Summary: "Service information", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"service": "", "version": "", "status": ""},
+		},
+	},
+
+	// admin
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/admin/data-requests/", Name: "listRequests",
+		Summary: "List requests", Tag: "admin",
+		Query: openapi.Params{"limit": openapi.Integer, "status": openapi.String, "user_id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"requests": []dto.DataRequestResponse{}},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/admin/data-requests/", Name: "createRequest",
+		Summary: "Create request", Tag: "admin",
+		Body: dto.CreateDataRequestRequest{},
+		Responses: openapi.Responses{
+			202: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/admin/data-requests/:id", Name: "getRequest",
+		Summary: "Get request", Tag: "admin",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/admin/data-requests/:id/approve", Name: "approveRequest",
+		Summary: "Approve request", Tag: "admin",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/admin/data-requests/:id/download", Name: "downloadExport",
+		Summary: "Download export", Tag: "admin",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Binary{"application/zip"},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/admin/data-requests/:id/reject", Name: "rejectRequest",
+		Summary: "Reject request", Tag: "admin",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.RejectDataRequestRequest{},
+		Responses: openapi.Responses{
+			200: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/admin/data-requests/:id/retry", Name: "retryRequest",
+		Summary: "Retry request", Tag: "admin",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			202: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/admin/lifecycle/runs", Name: "listRuns",
+		Summary: "List runs", Tag: "admin",
+		Query: openapi.Params{"limit": openapi.Integer},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"runs": []dto.LifecycleRunResponse{}},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/admin/lifecycle/runs", Name: "startRun",
+		Summary: "Start run", Tag: "admin",
+		Body: dto.StartLifecycleRunRequest{},
+		Responses: openapi.Responses{
+			201: dto.LifecycleRunResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/admin/lifecycle/runs/:id", Name: "getRun",
+		Summary: "Get run", Tag: "admin",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.LifecycleRunResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/admin/users/:id/revoke-sessions", Name: "revokeUserSessions",
+		Summary: "Revoke user sessions", Tag: "admin",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.RevokeUserSessionsResponse{},
+		},
+	},
+
+	// audit-logs
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/audit-logs", Name: "createAuditLog",
+		Summary: "Create audit log", Tag: "audit-logs", Auth: openapi.AuthService,
+		Body: dto.CreateAuditLogRequest{},
+		Responses: openapi.Responses{
+			201: nil,
+		},
+	},
+
+	// auth
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/authorize", Name: "authorize",
+		Summary: "Authorize", Tag: "auth",
+		Body: authz.AuthorizeRequest{},
+		Responses: openapi.Responses{
+			200: authz.AuthorizeResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/change-password", Name: "changePassword",
+		Summary: "Change password", Tag: "auth",
+		Body: dto.ChangePasswordRequest{},
+		Responses: openapi.Responses{
+			200: dto.ChangePasswordResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/forgot-password", Name: "forgotPassword",
+		Summary: "Forgot password", Tag: "auth", Auth: openapi.AuthPublic,
+		Body: dto.ForgotPasswordRequest{},
+		Responses: openapi.Responses{
+			200: dto.ForgotPasswordResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/login", Name: "login",
+		Summary: "Login", Tag: "auth", Auth: openapi.AuthPublic,
+		Body: dto.LoginRequest{},
+		Responses: openapi.Responses{
+			200: dto.LoginResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/logout", Name: "logout",
+		Summary: "Logout", Tag: "auth", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"message": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/permissions", Name: "getMyPermissions",
+		Summary: "Get my permissions", Tag: "auth",
+		Responses: openapi.Responses{
+			200: dto.EffectivePermissionsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/profile", Name: "getProfile",
+		Summary: "Get profile", Tag: "auth",
+		Responses: openapi.Responses{
+			200: dto.UserResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/auth/profile/avatar", Name: "updateAvatar",
+		Summary: "Update avatar", Tag: "auth",
+		Body: openapi.Upload{"avatar"},
+		Responses: openapi.Responses{
+			200: dto.UpdateAvatarResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/profile/data-requests", Name: "listMyRequests",
+		Summary: "List my requests", Tag: "auth",
+		Query: openapi.Params{"limit": openapi.Integer},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"requests": []dto.DataRequestResponse{}},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/profile/data-requests/:id", Name: "getMyRequest",
+		Summary: "Get my request", Tag: "auth",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/profile/data-requests/:id/download", Name: "downloadMyExport",
+		Summary: "Download my export", Tag: "auth",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Binary{"application/zip"},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/profile/data-requests/erasure", Name: "requestMyErasure",
+		Summary: "Request my erasure", Tag: "auth",
+		Responses: openapi.Responses{
+			202: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/profile/data-requests/export", Name: "requestMyExport",
+		Summary: "Request my export", Tag: "auth",
+		Responses: openapi.Responses{
+			202: dto.DataRequestResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/profile/tokens", Name: "listTokens",
+		Summary: "List tokens", Tag: "auth",
+		Responses: openapi.Responses{
+			200: openapi.Fields{"tokens": []dto.PersonalAccessTokenResponse{}},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/profile/tokens", Name: "createToken",
+		Summary: "Create token", Tag: "auth",
+		Body: dto.CreatePersonalAccessTokenRequest{},
+		Responses: openapi.Responses{
+			201: dto.PersonalAccessTokenSecretResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/auth/profile/tokens/:id", Name: "revokeToken",
+		Summary: "Revoke token", Tag: "auth",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/refresh", Name: "refreshToken",
+		Summary: "Refresh token", Tag: "auth", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: dto.RefreshTokenResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/reset-password", Name: "resetPassword",
+		Summary: "Reset password", Tag: "auth", Auth: openapi.AuthPublic,
+		Body: dto.ResetPasswordRequest{},
+		Responses: openapi.Responses{
+			200: dto.ResetPasswordResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/sso/:provider/acs", Name: "samlACS",
+		Summary: "SAML assertion consumer service", Tag: "auth", Auth: openapi.AuthPublic,
+		Body: openapi.Form{"RelayState": openapi.String, "SAMLResponse": openapi.String},
+		Responses: openapi.Responses{
+			302: nil,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/sso/:provider/callback", Name: "oidcCallback",
+		Summary: "OIDC callback", Tag: "auth", Auth: openapi.AuthPublic,
+		Query: openapi.Params{"code": openapi.String, "error": openapi.String, "state": openapi.String},
+		Responses: openapi.Responses{
+			302: nil,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/sso/:provider/login", Name: "beginSSOLogin",
+		Summary: "Begin SSO login", Tag: "auth", Auth: openapi.AuthPublic,
+		Query: openapi.Params{"redirect_to": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.SSOLoginStartResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/sso/:provider/metadata", Name: "samlMetadata",
+		Summary: "SAML metadata", Tag: "auth", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Binary{"application/samlmetadata+xml"},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/sso/discover", Name: "discoverSSOProvider",
+		Summary: "Discover SSO provider", Tag: "auth", Auth: openapi.AuthPublic,
+		Body: dto.SSODiscoverRequest{},
+		Responses: openapi.Responses{
+			200: dto.SSOProviderResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/auth/sso/providers", Name: "listSSOProviders",
+		Summary: "List SSO providers", Tag: "auth", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.ListOf("providers", []dto.SSOProviderResponse{}),
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/auth/validate", Name: "validateToken",
+		Summary: "Validate token", Tag: "auth", Auth: openapi.AuthPublic,
+		Body: dto.ValidateTokenRequest{},
+		Responses: openapi.Responses{
+			200: dto.ValidateTokenResponse{},
+		},
+	},
+
+	// oauth
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/oauth/token", Name: "issueServiceToken",
+		Summary: "Issue a service token (client credentials grant)", Tag: "oauth", Auth: openapi.AuthPublic,
+		Body: openapi.Form{"client_id": openapi.String, "client_secret": openapi.String, "grant_type": openapi.String, "scope": openapi.String},
+		Responses: openapi.Responses{
+			200: servicetoken.TokenResponse{},
+			400: servicetoken.ErrorResponse{},
+			401: servicetoken.ErrorResponse{},
+		},
+	},
+
+	// permissions
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/permissions/", Name: "listPermissions",
+		Summary: "List permissions", Tag: "permissions",
+		Responses: openapi.Responses{
+			200: []dto.PermissionResponse{},
+		},
+	},
+
+	// roles
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/roles/", Name: "listRoles",
+		Summary: "List roles", Tag: "roles",
+		Responses: openapi.Responses{
+			200: []dto.RoleResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/roles/", Name: "createRole",
+		Summary: "Create role", Tag: "roles",
+		Body: dto.CreateRoleRequest{},
+		Responses: openapi.Responses{
+			201: dto.RoleResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/roles/:id", Name: "updateRole",
+		Summary: "Update role", Tag: "roles",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateRoleRequest{},
+		Responses: openapi.Responses{
+			200: dto.RoleResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/roles/:id", Name: "deleteRole",
+		Summary: "Delete role", Tag: "roles",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+
+	// service-clients
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/service-clients/", Name: "listClients",
+		Summary: "List clients", Tag: "service-clients",
+		Responses: openapi.Responses{
+			200: []dto.ServiceClientResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/service-clients/", Name: "createClient",
+		Summary: "Create client", Tag: "service-clients",
+		Body: dto.CreateServiceClientRequest{},
+		Responses: openapi.Responses{
+			201: dto.ServiceClientSecretResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/service-clients/:id", Name: "updateClient",
+		Summary: "Update client", Tag: "service-clients",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.UpdateServiceClientRequest{},
+		Responses: openapi.Responses{
+			200: dto.ServiceClientResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/service-clients/:id", Name: "deleteClient",
+		Summary: "Delete client", Tag: "service-clients",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/service-clients/:id/rotate-secret", Name: "rotateSecret",
+		Summary: "Rotate secret", Tag: "service-clients",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.ServiceClientSecretResponse{},
+		},
+	},
+
+	// users
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/", Name: "getUsers",
+		Summary: "Get users", Tag: "users",
+		Query: openapi.Params{"limit": openapi.Integer, "page": openapi.Integer, "search": openapi.String, "user_type": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.GetUsersResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/users/", Name: "createUser",
+		Summary: "Create user", Tag: "users",
+		Body: dto.CreateUserRequest{},
+		Responses: openapi.Responses{
+			201: dto.CreateUserResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/:id", Name: "getUserByID",
+		Summary: "Get user by ID", Tag: "users",
+		Responses: openapi.Responses{
+			200: dto.UserResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPut, Path: "/api/v1/users/:id", Name: "updateUser",
+		Summary: "Update user", Tag: "users",
+		Body: dto.UpdateUserRequest{},
+		Responses: openapi.Responses{
+			200: dto.UpdateUserResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/users/:id", Name: "deleteUser",
+		Summary: "Delete user", Tag: "users",
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/users/:id/restore", Name: "restoreUser",
+		Summary: "Restore user", Tag: "users",
+		Responses: openapi.Responses{
+			200: nil,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/:id/role-assignments", Name: "listUserAssignments",
+		Summary: "List user assignments", Tag: "users",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: []dto.RoleAssignmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/users/:id/role-assignments", Name: "assignRole",
+		Summary: "Assign role", Tag: "users",
+		Params: openapi.Params{"id": openapi.UUID},
+		Body:   dto.AssignRoleRequest{},
+		Responses: openapi.Responses{
+			201: dto.RoleAssignmentResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/users/:id/role-assignments/:assignmentId", Name: "revokeAssignment",
+		Summary: "Revoke assignment", Tag: "users",
+		Params: openapi.Params{"assignmentId": openapi.UUID, "id": openapi.UUID},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/users/bulk", Name: "getUsersByIDs",
+		Summary: "Get users by i ds", Tag: "users",
+		Body: dto.GetUsersByIDsRequest{},
+		Responses: openapi.Responses{
+			200: dto.GetUsersResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/users/import/execute", Name: "executeUserImport",
+		Summary: "Execute user import", Tag: "users",
+		Body: openapi.Upload{"file"},
+		Responses: openapi.Responses{
+			202: dto.BulkImportJobResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/import/jobs", Name: "listImportJobs",
+		Summary: "List import jobs", Tag: "users",
+		Query: openapi.Params{"limit": openapi.Integer},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"jobs": []dto.BulkImportJobResponse{}},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/import/jobs/:id", Name: "getImportJob",
+		Summary: "Get import job", Tag: "users",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: dto.BulkImportJobResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/import/jobs/:id/report", Name: "downloadImportReport",
+		Summary: "Download import report", Tag: "users",
+		Params: openapi.Params{"id": openapi.UUID},
+		Query:  openapi.Params{"format": sheetFormat},
+		Responses: openapi.Responses{
+			200: sheet,
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/users/import/jobs/:id/resume", Name: "resumeImportJob",
+		Summary: "Resume import job", Tag: "users",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			202: dto.BulkImportJobResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/api/v1/users/import/preview", Name: "previewUserImport",
+		Summary: "Preview user import", Tag: "users",
+		Body: openapi.Upload{"file"},
+		Responses: openapi.Responses{
+			200: dto.BulkImportPreviewResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/import/template", Name: "downloadImportTemplate",
+		Summary: "Download import template", Tag: "users",
+		Query: openapi.Params{"format": sheetFormat},
+		Responses: openapi.Responses{
+			200: sheet,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/me", Name: "getMyUser",
+		Summary: "Get my user profile", Tag: "users",
+		Responses: openapi.Responses{
+			200: dto.UserResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/users/students", Name: "getStudents",
+		Summary: "Get students", Tag: "users",
+		Query: openapi.Params{"limit": openapi.Integer, "page": openapi.Integer, "search": openapi.String},
+		Responses: openapi.Responses{
+			200: dto.GetUsersResponse{},
+		},
+	},
+
+	// health
+	{
+		Method: fiber.MethodGet, Path: "/health", Name: "healthCheck",
+		Summary: "Health check", Tag: "health", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"status": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/openapi.json", Name: "getOpenAPI",
+		Summary: "OpenAPI document", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Any,
+		},
+	},
+}
@@ -0,0 +1,57 @@
+package router
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+)
+
+var update = flag.Bool("update", false, "rewrite docs/openapi.json from the spec")
+
+const specFile = "../../docs/openapi.json"
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, Config{})
+	openapi.AssertRoutesDocumented(t, app, OpenAPI())
+}
+
+func TestOpenAPIDocumentIsCurrent(t *testing.T) {
+	openapi.AssertDocumentCurrent(t, OpenAPI(), specFile, *update)
+}
+
+func TestValidationFollowsAuthentication(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, Config{})
+
+	tests := []struct {
+		name string
+		req  func() *http.Request
+		want int
+	}{
+		{"protected route without credentials", func() *http.Request {
+			return httptest.NewRequest(fiber.MethodGet, "/api/v1/admin/data-requests/not-a-uuid", nil)
+		}, fiber.StatusUnauthorized},
+		{"public route", func() *http.Request {
+			req := httptest.NewRequest(fiber.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email": 1}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return req
+		}, fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(tt.req())
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -3,18 +3,38 @@ package router
 import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/handler"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/middleware"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/gofiber/fiber/v3"
 )
 
 type Config struct {
-	HealthHandler     *handler.HealthHandler
-	AuthHandler       *handler.AuthHandler
-	UserHandler       *handler.UserHandler
-	BulkImportHandler *handler.BulkImportHandler
-	JWTSecretKey      []byte
+	HealthHandler        *handler.HealthHandler
+	AuthHandler          *handler.AuthHandler
+	UserHandler          *handler.UserHandler
+	BulkImportHandler    *handler.BulkImportHandler
+	SSOHandler           *handler.SSOHandler
+	RBACHandler          *handler.RBACHandler
+	ServiceClientHandler *handler.ServiceClientHandler
+	AccessTokenHandler   *handler.PersonalAccessTokenHandler
+	LifecycleHandler     *handler.LifecycleHandler
+	DataRequestHandler   *handler.DataRequestHandler
+	// TokenVerifier resolves personal access tokens presented as bearer tokens.
+	TokenVerifier pat.Verifier
+	JWTSecretKey  []byte
 }
 
 func SetupRoutes(app *fiber.App, cfg Config) {
+	// Requests are checked against the OpenAPI document once they are
+	// authenticated, so that callers without credentials learn nothing of
+	// the request schemas; see openapi.go. Public routes are checked on
+	// their own, as their groups share prefixes with protected ones.
+	doc := OpenAPI()
+	validate := openapi.Validate(doc)
+	app.Get("/openapi.json", openapi.Serve(doc))
+
 	cfg.HealthHandler.RegisterRoutes(app)
 
 	// API v1 group
@@ -22,20 +42,54 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 
 	// Public auth routes
 	auth := api.Group("/auth")
-	auth.Post("/login", cfg.AuthHandler.Login)
-	auth.Post("/refresh", cfg.AuthHandler.RefreshToken)
-	auth.Post("/logout", cfg.AuthHandler.Logout)
-	auth.Post("/forgot-password", cfg.AuthHandler.ForgotPassword)
-	auth.Post("/reset-password", cfg.AuthHandler.ResetPassword)
+	auth.Post("/login", validate, cfg.AuthHandler.Login)
+	auth.Post("/refresh", validate, cfg.AuthHandler.RefreshToken)
+	auth.Post("/logout", validate, cfg.AuthHandler.Logout)
+	auth.Post("/forgot-password", validate, cfg.AuthHandler.ForgotPassword)
+	auth.Post("/reset-password", validate, cfg.AuthHandler.ResetPassword)
+	auth.Post("/validate", validate, cfg.RBACHandler.ValidateToken)
+
+	// Single sign-on routes (OIDC and SAML)
+	auth.Get("/sso/providers", validate, cfg.SSOHandler.ListProviders)
+	auth.Post("/sso/discover", validate, cfg.SSOHandler.Discover)
+	auth.Get("/sso/:provider/login", validate, cfg.SSOHandler.BeginLogin)
+	auth.Get("/sso/:provider/callback", validate, cfg.SSOHandler.OIDCCallback)
+	auth.Post("/sso/:provider/acs", validate, cfg.SSOHandler.SAMLACS)
+	auth.Get("/sso/:provider/metadata", validate, cfg.SSOHandler.SAMLMetadata)
+
+	// OAuth2 client-credentials token endpoint for service clients
+	api.Post("/oauth/token", validate, cfg.ServiceClientHandler.Token)
+
+	// Audit entries reported by other services (service token required)
+	api.Post("/audit-logs",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeAuditWrite),
+		validate,
+		cfg.ServiceClientHandler.CreateAuditLog)
+
+	authMiddleware := middleware.AuthMiddleware(cfg.JWTSecretKey, cfg.TokenVerifier)
 
 	// Protected auth routes (require authentication)
-	authProtected := api.Group("/auth", middleware.AuthMiddleware(cfg.JWTSecretKey))
-	authProtected.Post("/change-password", cfg.AuthHandler.ChangePassword)
+	authProtected := api.Group("/auth", authMiddleware, validate)
+	authProtected.Post("/change-password", middleware.RequireSession(), cfg.AuthHandler.ChangePassword)
 	authProtected.Get("/profile", cfg.UserHandler.GetProfile)
 	authProtected.Patch("/profile/avatar", cfg.UserHandler.UpdateAvatar)
 
+	// Personal access tokens can only be managed from an interactive session
+	authProtected.Get("/profile/tokens", middleware.RequireSession(), cfg.AccessTokenHandler.ListTokens)
+	authProtected.Post("/profile/tokens", middleware.RequireSession(), cfg.AccessTokenHandler.CreateToken)
+	authProtected.Delete("/profile/tokens/:id", middleware.RequireSession(), cfg.AccessTokenHandler.RevokeToken)
+
+	// Personal data export and erasure requests
+	authProtected.Get("/profile/data-requests", middleware.RequireSession(), cfg.DataRequestHandler.ListMyRequests)
+	authProtected.Post("/profile/data-requests/export", middleware.RequireSession(), cfg.DataRequestHandler.RequestMyExport)
+	authProtected.Post("/profile/data-requests/erasure", middleware.RequireSession(), cfg.DataRequestHandler.RequestMyErasure)
+	authProtected.Get("/profile/data-requests/:id", middleware.RequireSession(), cfg.DataRequestHandler.GetMyRequest)
+	authProtected.Get("/profile/data-requests/:id/download", middleware.RequireSession(), cfg.DataRequestHandler.DownloadMyExport)
+	authProtected.Post("/authorize", cfg.RBACHandler.Authorize)
+	authProtected.Get("/permissions", cfg.RBACHandler.GetMyPermissions)
+
 	// User routes with authentication middleware (admin-only operations)
-	users := api.Group("/users", middleware.AuthMiddleware(cfg.JWTSecretKey))
+	users := api.Group("/users", authMiddleware, validate)
 	// Static sub-paths must be registered BEFORE /:id to prevent Fiber matching
 	// them as UUID parameters.
 	users.Get("/students", middleware.RequireInstructor(), cfg.UserHandler.GetStudents)
@@ -52,9 +106,54 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 	users.Get("/import/template", middleware.RequireAdmin(), cfg.BulkImportHandler.DownloadTemplate)
 	users.Post("/import/preview", middleware.RequireAdmin(), cfg.BulkImportHandler.PreviewImport)
 	users.Post("/import/execute", middleware.RequireAdmin(), cfg.BulkImportHandler.ExecuteImport)
+	users.Get("/import/jobs", middleware.RequireAdmin(), cfg.BulkImportHandler.ListJobs)
+	users.Get("/import/jobs/:id", middleware.RequireAdmin(), cfg.BulkImportHandler.GetJob)
+	users.Get("/import/jobs/:id/report", middleware.RequireAdmin(), cfg.BulkImportHandler.DownloadReport)
+	users.Post("/import/jobs/:id/resume", middleware.RequireAdmin(), cfg.BulkImportHandler.ResumeJob)
+
+	// Role assignment routes
+	requireRoleManage := authz.RequirePermission(authz.PermRoleManage)
+	users.Get("/:id/role-assignments", requireRoleManage, cfg.RBACHandler.ListUserAssignments)
+	users.Post("/:id/role-assignments", requireRoleManage, cfg.RBACHandler.AssignRole)
+	users.Delete("/:id/role-assignments/:assignmentId", requireRoleManage, cfg.RBACHandler.RevokeAssignment)
+
+	// Role & permission management routes
+	roles := api.Group("/roles", authMiddleware, validate, requireRoleManage)
+	roles.Get("/", cfg.RBACHandler.ListRoles)
+	roles.Post("/", cfg.RBACHandler.CreateRole)
+	roles.Put("/:id", cfg.RBACHandler.UpdateRole)
+	roles.Delete("/:id", cfg.RBACHandler.DeleteRole)
+
+	permissions := api.Group("/permissions", authMiddleware, validate, requireRoleManage)
+	permissions.Get("/", cfg.RBACHandler.ListPermissions)
+
+	// Service client management routes
+	serviceClients := api.Group("/service-clients", authMiddleware, validate,
+		authz.RequirePermission(authz.PermServiceClientManage))
+	serviceClients.Get("/", cfg.ServiceClientHandler.ListClients)
+	serviceClients.Post("/", cfg.ServiceClientHandler.CreateClient)
+	serviceClients.Put("/:id", cfg.ServiceClientHandler.UpdateClient)
+	serviceClients.Post("/:id/rotate-secret", cfg.ServiceClientHandler.RotateSecret)
+	serviceClients.Delete("/:id", cfg.ServiceClientHandler.DeleteClient)
+
+	// Account lifecycle runs (graduation, inactivity, retention)
+	lifecycle := api.Group("/admin/lifecycle", authMiddleware, validate, middleware.RequireAdmin())
+	lifecycle.Post("/runs", cfg.LifecycleHandler.StartRun)
+	lifecycle.Get("/runs", cfg.LifecycleHandler.ListRuns)
+	lifecycle.Get("/runs/:id", cfg.LifecycleHandler.GetRun)
+
+	// Personal data export and erasure requests for any user
+	dataRequests := api.Group("/admin/data-requests", authMiddleware, validate, middleware.RequireAdmin())
+	dataRequests.Get("/", cfg.DataRequestHandler.ListRequests)
+	dataRequests.Post("/", cfg.DataRequestHandler.CreateRequest)
+	dataRequests.Get("/:id", cfg.DataRequestHandler.GetRequest)
+	dataRequests.Get("/:id/download", cfg.DataRequestHandler.DownloadExport)
+	dataRequests.Post("/:id/approve", cfg.DataRequestHandler.ApproveRequest)
+	dataRequests.Post("/:id/reject", cfg.DataRequestHandler.RejectRequest)
+	dataRequests.Post("/:id/retry", cfg.DataRequestHandler.RetryRequest)
 
 	// Admin routes with authentication middleware
-	adminProtected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecretKey))
+	adminProtected := api.Group("", authMiddleware, validate)
 	cfg.AuthHandler.RegisterAdminRoutes(adminProtected)
 
 	app.Get("/", func(c fiber.Ctx) error {
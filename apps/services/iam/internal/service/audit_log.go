@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidAuditLog = errors.New("action and entity are required")
+
+type AuditLogService interface {
+	// Record stores an audit entry reported by the service client clientID.
+	Record(ctx context.Context, req *dto.CreateAuditLogRequest, clientID string) error
+}
+
+type auditLogService struct {
+	auditRepo repository.AuditLogRepository
+}
+
+func NewAuditLogService(auditRepo repository.AuditLogRepository) AuditLogService {
+	return &auditLogService{auditRepo: auditRepo}
+}
+
+func (s *auditLogService) Record(ctx context.Context, req *dto.CreateAuditLogRequest, clientID string) error {
+	if strings.TrimSpace(req.Action) == "" || strings.TrimSpace(req.Entity) == "" {
+		return ErrInvalidAuditLog
+	}
+
+	changes, err := json.Marshal(req.Changes)
+	if err != nil {
+		return fmt.Errorf("encoding changes: %w", err)
+	}
+	metadata, err := json.Marshal(req.Metadata)
+	if err != nil {
+		return fmt.Errorf("encoding metadata: %w", err)
+	}
+
+	occurredAt := req.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	return s.auditRepo.CreateAuditLog(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		Action:     req.Action,
+		Entity:     req.Entity,
+		EntityID:   req.EntityID,
+		Email:      req.Email,
+		Changes:    string(changes),
+		Metadata:   string(metadata),
+		IPAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+		Service:    req.Service,
+		ClientID:   clientID,
+		OccurredAt: occurredAt,
+	})
+}
@@ -37,6 +37,7 @@ type AuthService interface {
 type authService struct {
 	db                 *gorm.DB
 	authRepo           repository.AuthRepository
+	authorization      AuthorizationService
 	jwt                *jwt.JWT
 	secretKey          []byte
 	refreshTokenExpiry time.Duration
@@ -45,6 +46,7 @@ type authService struct {
 func NewAuthService(
 	db *gorm.DB,
 	authRepo repository.AuthRepository,
+	authorization AuthorizationService,
 	jwtConfig *jwt.JWT,
 	secretKey string,
 	refreshTokenExpiryDays int64,
@@ -52,6 +54,7 @@ func NewAuthService(
 	return &authService{
 		db:                 db,
 		authRepo:           authRepo,
+		authorization:      authorization,
 		jwt:                jwtConfig,
 		secretKey:          []byte(secretKey),
 		refreshTokenExpiry: time.Duration(refreshTokenExpiryDays) * 24 * time.Hour,
@@ -86,14 +89,7 @@ func (s *authService) Login(ctx context.Context, email, password string) (*dto.L
 	}
 
 	// Generate token pair
-	accessToken, _, err := jwt.GenerateAccessToken(
-		user.ID,
-		user.Email,
-		user.FullName,
-		user.UserType,
-		s.secretKey,
-		15*time.Minute,
-	)
+	accessToken, _, err := s.issueAccessToken(ctx, user.ID, user.Email, user.FullName, user.UserType)
 	if err != nil {
 		return nil, fmt.Errorf("generating access token: %w", err)
 	}
@@ -124,6 +120,10 @@ func (s *authService) Login(ctx context.Context, email, password string) (*dto.L
 		return nil, fmt.Errorf("storing refresh token: %w", err)
 	}
 
+	if err := s.authRepo.RecordLogin(ctx, user.ID); err != nil {
+		fmt.Printf("warning: failed to record login: %v\n", err)
+	}
+
 	return &dto.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -136,14 +136,7 @@ func (s *authService) LoginWithUser(ctx context.Context, user *domain.User) (*dt
 		return nil, ErrUserInactive
 	}
 
-	accessToken, _, err := jwt.GenerateAccessToken(
-		user.ID,
-		user.Email,
-		user.FullName,
-		user.UserType,
-		s.secretKey,
-		15*time.Minute,
-	)
+	accessToken, _, err := s.issueAccessToken(ctx, user.ID, user.Email, user.FullName, user.UserType)
 	if err != nil {
 		return nil, fmt.Errorf("generating access token: %w", err)
 	}
@@ -170,6 +163,10 @@ func (s *authService) LoginWithUser(ctx context.Context, user *domain.User) (*dt
 		return nil, fmt.Errorf("storing refresh token: %w", err)
 	}
 
+	if err := s.authRepo.RecordLogin(ctx, user.ID); err != nil {
+		fmt.Printf("warning: failed to record login: %v\n", err)
+	}
+
 	return &dto.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -211,14 +208,7 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	}
 
 	// Generate new access token
-	accessToken, expiresAt, err := jwt.GenerateAccessToken(
-		user.ID,
-		user.Email,
-		user.FullName,
-		user.UserType,
-		s.secretKey,
-		15*time.Minute,
-	)
+	accessToken, expiresAt, err := s.issueAccessToken(ctx, user.ID, user.Email, user.FullName, user.UserType)
 	if err != nil {
 		return nil, fmt.Errorf("generating access token: %w", err)
 	}
@@ -249,6 +239,26 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	}, nil
 }
 
+// issueAccessToken signs a short-lived access token embedding the user's
+// current global roles and permissions.
+func (s *authService) issueAccessToken(ctx context.Context, userID uuid.UUID, email, fullName, userType string) (string, time.Time, error) {
+	grants, err := s.authorization.GlobalGrants(ctx, userID, userType)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("resolving grants: %w", err)
+	}
+
+	return jwt.GenerateAccessTokenWithGrants(
+		userID,
+		email,
+		fullName,
+		userType,
+		grants.Roles,
+		grants.Permissions,
+		s.secretKey,
+		15*time.Minute,
+	)
+}
+
 func (s *authService) Logout(ctx context.Context, refreshToken string) error {
 	tokenHash := jwt.HashToken(refreshToken)
 
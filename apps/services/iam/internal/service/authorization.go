@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/jwt"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/authz"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrRoleExists             = errors.New("role already exists")
+	ErrSystemRoleImmutable    = errors.New("system roles cannot be modified")
+	ErrUnknownPermission      = errors.New("unknown permission")
+	ErrInvalidScope           = errors.New("invalid scope")
+	ErrInvalidRoleName        = errors.New("role name is required")
+	ErrRoleAssignmentNotFound = errors.New("role assignment not found")
+	ErrRoleAssignmentExists   = errors.New("role assignment already exists")
+)
+
+// Grants is the set of roles and permissions a user holds globally.
+type Grants struct {
+	Roles       []string
+	Permissions []string
+}
+
+type AuthorizationService interface {
+	GlobalGrants(ctx context.Context, userID uuid.UUID, userType string) (*Grants, error)
+	Authorize(ctx context.Context, userID uuid.UUID, userType, permission string, scopes []authz.Scope) (bool, error)
+	EffectivePermissions(ctx context.Context, userID uuid.UUID, userType string) (*dto.EffectivePermissionsResponse, error)
+	ValidateToken(token string) *dto.ValidateTokenResponse
+
+	ListPermissions(ctx context.Context) ([]dto.PermissionResponse, error)
+	ListRoles(ctx context.Context) ([]dto.RoleResponse, error)
+	CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*dto.RoleResponse, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, req *dto.UpdateRoleRequest) (*dto.RoleResponse, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+
+	ListUserAssignments(ctx context.Context, userID uuid.UUID) ([]dto.RoleAssignmentResponse, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, req *dto.AssignRoleRequest, grantedBy uuid.UUID) (*dto.RoleAssignmentResponse, error)
+	RevokeAssignment(ctx context.Context, userID, assignmentID uuid.UUID) error
+}
+
+type authorizationService struct {
+	rbacRepo  repository.RBACRepository
+	userRepo  repository.UserRepository
+	secretKey []byte
+}
+
+func NewAuthorizationService(
+	rbacRepo repository.RBACRepository,
+	userRepo repository.UserRepository,
+	secretKey string,
+) AuthorizationService {
+	return &authorizationService{
+		rbacRepo:  rbacRepo,
+		userRepo:  userRepo,
+		secretKey: []byte(secretKey),
+	}
+}
+
+// GlobalGrants returns the system role implied by the user type together with
+// every globally assigned role, and the union of their permissions.
+func (s *authorizationService) GlobalGrants(ctx context.Context, userID uuid.UUID, userType string) (*Grants, error) {
+	roles := map[string]struct{}{}
+	permissions := map[string]struct{}{}
+
+	if systemRole := domain.SystemRoleForUserType(userType); systemRole != "" {
+		names, err := s.rbacRepo.GetRolePermissionNames(ctx, systemRole)
+		if err != nil {
+			return nil, fmt.Errorf("fetching system role permissions: %w", err)
+		}
+		roles[systemRole] = struct{}{}
+		for _, name := range names {
+			permissions[name] = struct{}{}
+		}
+	}
+
+	assignments, err := s.rbacRepo.ListAssignmentsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching role assignments: %w", err)
+	}
+	for _, assignment := range assignments {
+		if !assignment.IsGlobal() {
+			continue
+		}
+		roles[assignment.Role.Name] = struct{}{}
+		for _, permission := range assignment.Role.Permissions {
+			permissions[permission.Name] = struct{}{}
+		}
+	}
+
+	return &Grants{Roles: sortedKeys(roles), Permissions: sortedKeys(permissions)}, nil
+}
+
+// Authorize reports whether the user holds permission globally or through a
+// role assigned on one of the given scopes.
+func (s *authorizationService) Authorize(ctx context.Context, userID uuid.UUID, userType, permission string, scopes []authz.Scope) (bool, error) {
+	grants, err := s.GlobalGrants(ctx, userID, userType)
+	if err != nil {
+		return false, err
+	}
+	if authz.Contains(grants.Permissions, permission) {
+		return true, nil
+	}
+	if len(scopes) == 0 {
+		return false, nil
+	}
+
+	assignments, err := s.rbacRepo.ListAssignmentsByUser(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("fetching role assignments: %w", err)
+	}
+	for _, assignment := range assignments {
+		if assignment.IsGlobal() || !matchesScope(&assignment, scopes) {
+			continue
+		}
+		for _, p := range assignment.Role.Permissions {
+			if p.Name == permission {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (s *authorizationService) EffectivePermissions(ctx context.Context, userID uuid.UUID, userType string) (*dto.EffectivePermissionsResponse, error) {
+	grants, err := s.GlobalGrants(ctx, userID, userType)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.rbacRepo.ListAssignmentsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching role assignments: %w", err)
+	}
+
+	scoped := []dto.RoleAssignmentResponse{}
+	for i := range assignments {
+		if !assignments[i].IsGlobal() {
+			scoped = append(scoped, toRoleAssignmentResponse(&assignments[i]))
+		}
+	}
+
+	return &dto.EffectivePermissionsResponse{
+		UserID:      userID,
+		UserType:    userType,
+		Roles:       grants.Roles,
+		Permissions: grants.Permissions,
+		Scoped:      scoped,
+	}, nil
+}
+
+// ValidateToken verifies an access token on behalf of another service and
+// returns the identity and global grants it carries.
+func (s *authorizationService) ValidateToken(token string) *dto.ValidateTokenResponse {
+	claims, err := jwt.ValidateAccessToken(token, s.secretKey)
+	if err != nil {
+		return &dto.ValidateTokenResponse{Valid: false, Roles: []string{}, Permissions: []string{}}
+	}
+
+	resp := &dto.ValidateTokenResponse{
+		Valid:       true,
+		UserID:      claims.UserID.String(),
+		Email:       claims.Email,
+		UserType:    claims.UserType,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+	}
+	if resp.Roles == nil {
+		resp.Roles = []string{}
+	}
+	if resp.Permissions == nil {
+		resp.Permissions = []string{}
+	}
+	return resp
+}
+
+func (s *authorizationService) ListPermissions(ctx context.Context) ([]dto.PermissionResponse, error) {
+	permissions, err := s.rbacRepo.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing permissions: %w", err)
+	}
+
+	resp := make([]dto.PermissionResponse, len(permissions))
+	for i, p := range permissions {
+		resp[i] = dto.PermissionResponse{Name: p.Name, Description: p.Description}
+	}
+	return resp, nil
+}
+
+func (s *authorizationService) ListRoles(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := s.rbacRepo.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing roles: %w", err)
+	}
+
+	resp := make([]dto.RoleResponse, len(roles))
+	for i := range roles {
+		resp[i] = toRoleResponse(&roles[i])
+	}
+	return resp, nil
+}
+
+func (s *authorizationService) CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	name := strings.TrimSpace(strings.ToLower(req.Name))
+	if name == "" {
+		return nil, ErrInvalidRoleName
+	}
+
+	existing, err := s.rbacRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("checking role: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrRoleExists
+	}
+
+	permissions, err := s.resolvePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &domain.Role{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: req.Description,
+		Permissions: permissions,
+	}
+	if err := s.rbacRepo.CreateRole(ctx, role); err != nil {
+		return nil, fmt.Errorf("creating role: %w", err)
+	}
+
+	resp := toRoleResponse(role)
+	return &resp, nil
+}
+
+func (s *authorizationService) UpdateRole(ctx context.Context, id uuid.UUID, req *dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	role, err := s.rbacRepo.GetRoleByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fetching role: %w", err)
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	if role.IsSystem {
+		return nil, ErrSystemRoleImmutable
+	}
+
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+	permissions := role.Permissions
+	if req.Permissions != nil {
+		permissions, err = s.resolvePermissions(ctx, req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.rbacRepo.UpdateRole(ctx, role, permissions); err != nil {
+		return nil, fmt.Errorf("updating role: %w", err)
+	}
+	role.Permissions = permissions
+
+	resp := toRoleResponse(role)
+	return &resp, nil
+}
+
+func (s *authorizationService) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	role, err := s.rbacRepo.GetRoleByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("fetching role: %w", err)
+	}
+	if role == nil {
+		return ErrRoleNotFound
+	}
+	if role.IsSystem {
+		return ErrSystemRoleImmutable
+	}
+
+	if err := s.rbacRepo.DeleteRole(ctx, id); err != nil {
+		return fmt.Errorf("deleting role: %w", err)
+	}
+	return nil
+}
+
+func (s *authorizationService) ListUserAssignments(ctx context.Context, userID uuid.UUID) ([]dto.RoleAssignmentResponse, error) {
+	assignments, err := s.rbacRepo.ListAssignmentsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching role assignments: %w", err)
+	}
+
+	resp := make([]dto.RoleAssignmentResponse, len(assignments))
+	for i := range assignments {
+		resp[i] = toRoleAssignmentResponse(&assignments[i])
+	}
+	return resp, nil
+}
+
+func (s *authorizationService) AssignRole(ctx context.Context, userID uuid.UUID, req *dto.AssignRoleRequest, grantedBy uuid.UUID) (*dto.RoleAssignmentResponse, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	role, err := s.rbacRepo.GetRoleByName(ctx, req.Role)
+	if err != nil {
+		return nil, fmt.Errorf("fetching role: %w", err)
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	scopeID, err := parseScope(req.ScopeType, req.ScopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.rbacRepo.FindAssignment(ctx, userID, role.ID, req.ScopeType, scopeID)
+	if err != nil {
+		return nil, fmt.Errorf("checking role assignment: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrRoleAssignmentExists
+	}
+
+	assignment := &domain.RoleAssignment{
+		ID:        uuid.New(),
+		UserID:    userID,
+		RoleID:    role.ID,
+		ScopeType: req.ScopeType,
+		ScopeID:   scopeID,
+		GrantedBy: &grantedBy,
+	}
+	if err := s.rbacRepo.CreateAssignment(ctx, assignment); err != nil {
+		return nil, fmt.Errorf("creating role assignment: %w", err)
+	}
+	assignment.Role = *role
+
+	resp := toRoleAssignmentResponse(assignment)
+	return &resp, nil
+}
+
+func (s *authorizationService) RevokeAssignment(ctx context.Context, userID, assignmentID uuid.UUID) error {
+	assignment, err := s.rbacRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return fmt.Errorf("fetching role assignment: %w", err)
+	}
+	if assignment == nil || assignment.UserID != userID {
+		return ErrRoleAssignmentNotFound
+	}
+
+	if err := s.rbacRepo.DeleteAssignment(ctx, assignmentID); err != nil {
+		return fmt.Errorf("deleting role assignment: %w", err)
+	}
+	return nil
+}
+
+// resolvePermissions loads the named permissions, rejecting unknown names.
+func (s *authorizationService) resolvePermissions(ctx context.Context, names []string) ([]domain.Permission, error) {
+	permissions, err := s.rbacRepo.GetPermissionsByNames(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("fetching permissions: %w", err)
+	}
+
+	found := make(map[string]struct{}, len(permissions))
+	for _, p := range permissions {
+		found[p.Name] = struct{}{}
+	}
+	for _, name := range names {
+		if _, ok := found[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, name)
+		}
+	}
+	return permissions, nil
+}
+
+// parseScope validates a scope and returns its ID, uuid.Nil for global.
+func parseScope(scopeType, scopeID string) (uuid.UUID, error) {
+	if !authz.IsValidScopeType(scopeType) {
+		return uuid.Nil, fmt.Errorf("%w: unknown scope type %q", ErrInvalidScope, scopeType)
+	}
+	if scopeType == authz.ScopeGlobal {
+		if scopeID != "" {
+			return uuid.Nil, fmt.Errorf("%w: global assignments take no scope_id", ErrInvalidScope)
+		}
+		return uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(scopeID)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("%w: scope_id must be a UUID", ErrInvalidScope)
+	}
+	return id, nil
+}
+
+func matchesScope(assignment *domain.RoleAssignment, scopes []authz.Scope) bool {
+	for _, scope := range scopes {
+		if scope.Type == assignment.ScopeType && scope.ID == assignment.ScopeID.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func toRoleResponse(role *domain.Role) dto.RoleResponse {
+	permissions := make([]string, len(role.Permissions))
+	for i, p := range role.Permissions {
+		permissions[i] = p.Name
+	}
+	sort.Strings(permissions)
+
+	return dto.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: permissions,
+	}
+}
+
+func toRoleAssignmentResponse(assignment *domain.RoleAssignment) dto.RoleAssignmentResponse {
+	resp := dto.RoleAssignmentResponse{
+		ID:        assignment.ID,
+		UserID:    assignment.UserID,
+		Role:      assignment.Role.Name,
+		ScopeType: assignment.ScopeType,
+		GrantedBy: assignment.GrantedBy,
+		CreatedAt: assignment.CreatedAt,
+	}
+	if !assignment.IsGlobal() {
+		scopeID := assignment.ScopeID
+		resp.ScopeID = &scopeID
+	}
+	return resp
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,313 +1,669 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/mail"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/client"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/google/uuid"
 	"github.com/xuri/excelize/v2"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrImportJobNotFound      = errors.New("import job not found")
+	ErrImportJobNotResumable  = errors.New("only failed import jobs can be resumed")
+	ErrUnsupportedImportFile  = errors.New("unsupported file format")
+	ErrEmptyImportFile        = errors.New("import file has no data rows")
+	ErrInvalidImportReportFmt = errors.New("report format must be csv or xlsx")
+)
+
+const (
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	// importRowBatchSize is how many pending rows the worker loads at a time.
+	importRowBatchSize = 100
+	// importPollInterval is how often the worker looks for jobs it was not
+	// woken for, such as ones resumed by another replica.
+	importPollInterval = 30 * time.Second
+	// importClaimTTL is how long a running job's claim lasts without being
+	// renewed before another replica may take the job over. The worker renews
+	// it every importClaimRenewInterval.
+	importClaimTTL           = 5 * time.Minute
+	importClaimRenewInterval = time.Minute
+	// maxJobFailures caps the failed rows returned with a job.
+	maxJobFailures = 100
+)
+
 type BulkImportService interface {
 	GenerateTemplate(format string) ([]byte, string, error)
 	PreviewImport(ctx context.Context, reader io.Reader, filename string) (*dto.BulkImportPreviewResponse, error)
-	ExecuteImport(ctx context.Context, reader io.Reader, filename string, mapping map[string]string, actorUserType string) (*dto.BulkImportExecuteResponse, error)
+	// StartImport stores the file's rows as a job and queues it. Uploading a
+	// file whose job is still pending or running returns that job.
+	StartImport(ctx context.Context, reader io.Reader, filename string, mapping map[string]string, actorID uuid.UUID) (*dto.BulkImportJobResponse, error)
+	GetJob(ctx context.Context, id uuid.UUID) (*dto.BulkImportJobResponse, error)
+	ListJobs(ctx context.Context, limit int) ([]dto.BulkImportJobResponse, error)
+	ResumeJob(ctx context.Context, id uuid.UUID) (*dto.BulkImportJobResponse, error)
+	GenerateReport(ctx context.Context, id uuid.UUID, format string) ([]byte, string, error)
+	// Run processes queued jobs until ctx is done. Jobs left unfinished by a
+	// previous run are picked up first, once their claim has gone stale.
+	Run(ctx context.Context)
 }
 
 type bulkImportService struct {
-	db          *gorm.DB
-	userRepo    repository.UserRepository
-	userService UserService
+	db             *gorm.DB
+	userRepo       repository.UserRepository
+	importRepo     repository.BulkImportRepository
+	userService    UserService
+	academicClient *client.AcademicClient
+	wake           chan struct{}
+	// workerID identifies this replica's claims on import jobs.
+	workerID string
 }
 
-func NewBulkImportService(db *gorm.DB, userRepo repository.UserRepository, userService UserService) BulkImportService {
+func NewBulkImportService(
+	db *gorm.DB,
+	userRepo repository.UserRepository,
+	importRepo repository.BulkImportRepository,
+	userService UserService,
+	academicClient *client.AcademicClient,
+) BulkImportService {
 	return &bulkImportService{
-		db:          db,
-		userRepo:    userRepo,
-		userService: userService,
+		db:             db,
+		userRepo:       userRepo,
+		importRepo:     importRepo,
+		userService:    userService,
+		academicClient: academicClient,
+		wake:           make(chan struct{}, 1),
+		workerID:       uuid.NewString(),
 	}
 }
 
 func (s *bulkImportService) GenerateTemplate(format string) ([]byte, string, error) {
-	headers := []string{"Full Name", "Email", "User Type", "Department", "Faculty", "Student ID", "Designation"}
+	headers := []string{"Full Name", "Email", "User Type", "Department", "Faculty", "Student ID", "Designation", "Batch ID"}
+	return writeSheet("Template", headers, nil, format)
+}
 
-	if format == "csv" {
-		var b strings.Builder
-		w := csv.NewWriter(&b)
-		if err := w.Write(headers); err != nil {
-			return nil, "", err
+func (s *bulkImportService) PreviewImport(ctx context.Context, reader io.Reader, filename string) (*dto.BulkImportPreviewResponse, error) {
+	const maxPreviewRows = 100
+
+	headers, rows, err := readImportRows(reader, filename)
+	if err != nil {
+		return nil, err
+	}
+	if headers == nil {
+		return &dto.BulkImportPreviewResponse{}, nil
+	}
+
+	mapping := s.autoMapColumns(headers)
+	previewRows := make([]dto.ImportPreviewRow, 0, min(len(rows), maxPreviewRows))
+	validCount, existingCount := 0, 0
+
+	for i, row := range rows {
+		userRow := s.mapRowToUser(row, headers, mapping)
+		validationErrors := s.validateRow(userRow)
+		isValid := len(validationErrors) == 0
+		if isValid {
+			validCount++
+		}
+
+		exists := false
+		if isValid {
+			existing, _ := s.userRepo.GetUserByEmail(ctx, userRow.Email)
+			exists = existing != nil
+			if exists {
+				existingCount++
+			}
+		}
+
+		if i < maxPreviewRows {
+			previewRows = append(previewRows, dto.ImportPreviewRow{
+				RowIndex: i + 1,
+				Data:     userRow,
+				Errors:   validationErrors,
+				IsValid:  isValid,
+				Exists:   exists,
+			})
 		}
-		w.Flush()
-		return []byte(b.String()), "text/csv", nil
 	}
 
-	f := excelize.NewFile()
-	sheet := "Template"
-	index, _ := f.NewSheet(sheet)
-	f.SetActiveSheet(index)
-	f.DeleteSheet("Sheet1")
+	return &dto.BulkImportPreviewResponse{
+		Rows:          previewRows,
+		TotalRows:     len(rows),
+		ValidRows:     validCount,
+		InvalidRows:   len(rows) - validCount,
+		ExistingRows:  existingCount,
+		ColumnMapping: mapping,
+	}, nil
+}
 
-	for i, h := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		f.SetCellValue(sheet, cell, h)
+func (s *bulkImportService) StartImport(ctx context.Context, reader io.Reader, filename string, mapping map[string]string, actorID uuid.UUID) (*dto.BulkImportJobResponse, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading import file: %w", err)
 	}
+	sum := sha256.Sum256(content)
+	fileHash := hex.EncodeToString(sum[:])
 
-	buf, err := f.WriteToBuffer()
+	active, err := s.importRepo.FindActiveJobByHash(ctx, actorID, fileHash)
 	if err != nil {
-		return nil, "", err
+		return nil, fmt.Errorf("checking running imports: %w", err)
+	}
+	if active != nil {
+		return toBulkImportJobResponse(active, nil), nil
 	}
-	return buf.Bytes(), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
-}
 
-func (s *bulkImportService) PreviewImport(ctx context.Context, reader io.Reader, filename string) (*dto.BulkImportPreviewResponse, error) {
-	var headers []string
-	var previewRows []dto.ImportPreviewRow
-	totalRows := 0
-	validCount := 0
-	const maxPreviewRows = 100
+	headers, rows, err := readImportRows(bytes.NewReader(content), filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyImportFile
+	}
 
-	if strings.HasSuffix(strings.ToLower(filename), ".csv") {
-		r := csv.NewReader(reader)
-		// Read headers
-		var err error
-		headers, err = r.Read()
+	job := &domain.BulkImportJob{
+		ID:        uuid.New(),
+		CreatedBy: actorID,
+		Filename:  filename,
+		FileHash:  fileHash,
+		Status:    domain.ImportJobPending,
+		TotalRows: len(rows),
+	}
+
+	jobRows := make([]domain.BulkImportRow, 0, len(rows))
+	for i, row := range rows {
+		userRow := s.mapRowToUser(row, headers, mapping)
+		data, err := json.Marshal(userRow)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read CSV headers: %w", err)
+			return nil, fmt.Errorf("encoding row %d: %w", i+1, err)
 		}
+		jobRows = append(jobRows, domain.BulkImportRow{
+			ID:       uuid.New(),
+			JobID:    job.ID,
+			RowIndex: i + 1,
+			Email:    userRow.Email,
+			Data:     string(data),
+			Status:   domain.ImportRowPending,
+		})
+	}
 
-		mapping := s.autoMapColumns(headers)
+	if err := s.importRepo.CreateJob(ctx, job, jobRows); err != nil {
+		return nil, fmt.Errorf("storing import job: %w", err)
+	}
+	s.notify()
 
-		for {
-			row, err := r.Read()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return nil, fmt.Errorf("failed to read CSV row: %w", err)
-			}
-			totalRows++
+	return toBulkImportJobResponse(job, nil), nil
+}
 
-			userRow := s.mapRowToUser(row, headers, mapping)
-			validationErrors := s.validateRow(ctx, userRow)
-			isValid := len(validationErrors) == 0
-			if isValid {
-				validCount++
-			}
+func (s *bulkImportService) GetJob(ctx context.Context, id uuid.UUID) (*dto.BulkImportJobResponse, error) {
+	job, err := s.importRepo.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrImportJobNotFound
+	}
 
-			if totalRows <= maxPreviewRows {
-				previewRows = append(previewRows, dto.ImportPreviewRow{
-					RowIndex: totalRows,
-					Data:     userRow,
-					Errors:   validationErrors,
-					IsValid:  isValid,
-				})
-			}
-		}
+	failed, err := s.importRepo.ListRows(ctx, id, domain.ImportRowFailed)
+	if err != nil {
+		return nil, err
+	}
+	if len(failed) > maxJobFailures {
+		failed = failed[:maxJobFailures]
+	}
+	return toBulkImportJobResponse(job, failed), nil
+}
 
-		return &dto.BulkImportPreviewResponse{
-			Rows:          previewRows,
-			TotalRows:     totalRows,
-			ValidRows:     validCount,
-			InvalidRows:   totalRows - validCount,
-			ColumnMapping: mapping,
-		}, nil
+func (s *bulkImportService) ListJobs(ctx context.Context, limit int) ([]dto.BulkImportJobResponse, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	jobs, err := s.importRepo.ListJobs(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
 
-	} else if strings.HasSuffix(strings.ToLower(filename), ".xlsx") {
-		f, err := excelize.OpenReader(reader)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read XLSX: %w", err)
-		}
-		defer f.Close()
+	response := make([]dto.BulkImportJobResponse, 0, len(jobs))
+	for i := range jobs {
+		response = append(response, *toBulkImportJobResponse(&jobs[i], nil))
+	}
+	return response, nil
+}
 
-		sheet := f.GetSheetName(0)
-		rows, err := f.Rows(sheet)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get rows from XLSX: %w", err)
+// ResumeJob re-queues a failed job. Rows already processed keep their
+// outcome; only the pending ones are run again.
+func (s *bulkImportService) ResumeJob(ctx context.Context, id uuid.UUID) (*dto.BulkImportJobResponse, error) {
+	job, err := s.importRepo.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrImportJobNotFound
+	}
+	if job.Status != domain.ImportJobFailed {
+		return nil, ErrImportJobNotResumable
+	}
+
+	if err := s.importRepo.UpdateJobStatus(ctx, id, map[string]interface{}{
+		"status": domain.ImportJobPending,
+		"error":  "",
+	}); err != nil {
+		return nil, err
+	}
+	s.notify()
+
+	job.Status = domain.ImportJobPending
+	job.Error = ""
+	return toBulkImportJobResponse(job, nil), nil
+}
+
+// GenerateReport renders every row of the job with its outcome.
+func (s *bulkImportService) GenerateReport(ctx context.Context, id uuid.UUID, format string) ([]byte, string, error) {
+	if format != "csv" && format != "xlsx" {
+		return nil, "", ErrInvalidImportReportFmt
+	}
+
+	job, err := s.importRepo.GetJob(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if job == nil {
+		return nil, "", ErrImportJobNotFound
+	}
+
+	rows, err := s.importRepo.ListRows(ctx, id, "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	headers := []string{"Row", "Email", "Full Name", "User Type", "Status", "User ID", "Setup Email Sent", "Batch ID", "Batch Assigned", "Message"}
+	records := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		var data dto.ImportUserRow
+		_ = json.Unmarshal([]byte(row.Data), &data)
+
+		userID := ""
+		if row.UserID != nil {
+			userID = row.UserID.String()
 		}
-		defer rows.Close()
+		records = append(records, []string{
+			strconv.Itoa(row.RowIndex),
+			row.Email,
+			data.FullName,
+			data.UserType,
+			row.Status,
+			userID,
+			strconv.FormatBool(row.EmailSent),
+			data.BatchID,
+			strconv.FormatBool(row.BatchAssigned),
+			row.Message,
+		})
+	}
 
-		if !rows.Next() {
-			return &dto.BulkImportPreviewResponse{}, nil
+	return writeSheet("Report", headers, records, format)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Worker
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (s *bulkImportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(importPollInterval)
+	defer ticker.Stop()
+
+	s.notify()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
 		}
 
-		headers, err = rows.Columns()
+		jobs, err := s.importRepo.ListResumableJobs(ctx, time.Now().Add(-importClaimTTL))
 		if err != nil {
-			return nil, fmt.Errorf("failed to get XLSX headers: %w", err)
+			fmt.Printf("warning: failed to list import jobs: %v\n", err)
+			continue
+		}
+		for i := range jobs {
+			if ctx.Err() != nil {
+				return
+			}
+			s.runJob(ctx, &jobs[i])
 		}
+	}
+}
 
-		mapping := s.autoMapColumns(headers)
+// notify wakes the worker without blocking if it is already due to run.
+func (s *bulkImportService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
 
-		for rows.Next() {
-			row, err := rows.Columns()
-			if err != nil {
-				return nil, fmt.Errorf("failed to get XLSX row: %w", err)
-			}
-			totalRows++
+// runJob processes the job's pending rows. Another replica may list the same
+// job, so it first claims the job and stops as soon as the claim is lost.
+func (s *bulkImportService) runJob(ctx context.Context, job *domain.BulkImportJob) {
+	if !s.claimJob(ctx, job.ID) {
+		return
+	}
+	claimedAt := time.Now()
 
-			userRow := s.mapRowToUser(row, headers, mapping)
-			validationErrors := s.validateRow(ctx, userRow)
-			isValid := len(validationErrors) == 0
-			if isValid {
-				validCount++
+	for {
+		rows, err := s.importRepo.ListPendingRows(ctx, job.ID, importRowBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.failJob(job.ID, err)
 			}
+			return
+		}
+		if len(rows) == 0 {
+			break
+		}
 
-			if totalRows <= maxPreviewRows {
-				previewRows = append(previewRows, dto.ImportPreviewRow{
-					RowIndex: totalRows,
-					Data:     userRow,
-					Errors:   validationErrors,
-					IsValid:  isValid,
-				})
+		for i := range rows {
+			// Leave the job running so the next start resumes it.
+			if ctx.Err() != nil {
+				return
+			}
+			if time.Since(claimedAt) >= importClaimRenewInterval {
+				if !s.claimJob(ctx, job.ID) {
+					return
+				}
+				claimedAt = time.Now()
+			}
+			row := &rows[i]
+			s.processRow(ctx, row)
+			if err := s.importRepo.RecordRowResult(ctx, row); err != nil {
+				if ctx.Err() == nil {
+					s.failJob(job.ID, err)
+				}
+				return
 			}
 		}
+	}
+
+	if err := s.importRepo.UpdateJobStatus(ctx, job.ID, map[string]interface{}{
+		"status":       domain.ImportJobCompleted,
+		"completed_at": time.Now(),
+	}); err != nil {
+		fmt.Printf("warning: failed to complete import job %s: %v\n", job.ID, err)
+	}
+}
 
-		return &dto.BulkImportPreviewResponse{
-			Rows:          previewRows,
-			TotalRows:     totalRows,
-			ValidRows:     validCount,
-			InvalidRows:   totalRows - validCount,
-			ColumnMapping: mapping,
-		}, nil
+// claimJob claims or renews the job for this worker. It reports false when
+// another worker holds the job or the claim could not be saved.
+func (s *bulkImportService) claimJob(ctx context.Context, jobID uuid.UUID) bool {
+	claimed, err := s.importRepo.ClaimJob(ctx, jobID, s.workerID, time.Now().Add(-importClaimTTL))
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Printf("warning: failed to claim import job %s: %v\n", jobID, err)
+		}
+		return false
 	}
+	return claimed
+}
 
-	return nil, fmt.Errorf("unsupported file format")
+func (s *bulkImportService) failJob(jobID uuid.UUID, cause error) {
+	fmt.Printf("warning: import job %s failed: %v\n", jobID, cause)
+	err := s.importRepo.UpdateJobStatus(context.Background(), jobID, map[string]interface{}{
+		"status": domain.ImportJobFailed,
+		"error":  truncate(cause.Error(), 1000),
+	})
+	if err != nil {
+		fmt.Printf("warning: failed to mark import job %s as failed: %v\n", jobID, err)
+	}
 }
 
-func (s *bulkImportService) ExecuteImport(ctx context.Context, reader io.Reader, filename string, mapping map[string]string, actorUserType string) (*dto.BulkImportExecuteResponse, error) {
-	results := make([]dto.BulkImportResultRow, 0)
-	successCount := 0
-	totalProcessed := 0
+// processRow fills in the row's outcome. An email that already has an
+// account is skipped rather than failed, which makes re-running a file safe;
+// its batch assignment is still applied.
+func (s *bulkImportService) processRow(ctx context.Context, row *domain.BulkImportRow) {
+	now := time.Now()
+	row.ProcessedAt = &now
+
+	var data dto.ImportUserRow
+	if err := json.Unmarshal([]byte(row.Data), &data); err != nil {
+		row.Status = domain.ImportRowFailed
+		row.Message = "invalid row data"
+		return
+	}
 
-	processRow := func(rowIndex int, row []string, headers []string) {
-		totalProcessed++
-		userRow := s.mapRowToUser(row, headers, mapping)
+	if validationErrors := s.validateRow(data); len(validationErrors) > 0 {
+		row.Status = domain.ImportRowFailed
+		row.Message = strings.Join(validationErrors, "; ")
+		return
+	}
 
-		// Final validation before creation
-		validationErrors := s.validateRow(ctx, userRow)
-		if len(validationErrors) > 0 {
-			results = append(results, dto.BulkImportResultRow{
-				RowIndex: rowIndex,
-				Email:    userRow.Email,
-				Success:  false,
-				Error:    strings.Join(validationErrors, "; "),
-			})
-			return
-		}
+	var messages []string
 
-		// Validate user type
-		if !domain.IsValidUserType(userRow.UserType) {
-			results = append(results, dto.BulkImportResultRow{
-				RowIndex: rowIndex,
-				Email:    userRow.Email,
-				Success:  false,
-				Error:    fmt.Sprintf("invalid user type '%s'", userRow.UserType),
-			})
+	existing, err := s.userRepo.GetUserByEmail(ctx, data.Email)
+	if err != nil {
+		row.Status = domain.ImportRowFailed
+		row.Message = fmt.Sprintf("checking email: %v", err)
+		return
+	}
+
+	var user *domain.User
+	if existing != nil {
+		user = existing
+		row.Status = domain.ImportRowSkipped
+		messages = append(messages, "Email already exists")
+	} else {
+		created, resetLink, _, err := s.userService.ProvisionUser(ctx, &dto.CreateUserRequest{
+			FullName:    data.FullName,
+			Email:       data.Email,
+			UserType:    strings.ToLower(data.UserType),
+			Department:  data.Department,
+			Faculty:     data.Faculty,
+			StudentID:   data.StudentID,
+			Designation: data.Designation,
+		})
+		if err != nil {
+			row.Status = domain.ImportRowFailed
+			row.Message = truncate(err.Error(), 1000)
 			return
 		}
+		user = created
+		row.Status = domain.ImportRowCreated
 
-		createReq := &dto.CreateUserRequest{
-			FullName:    userRow.FullName,
-			Email:       userRow.Email,
-			UserType:    userRow.UserType,
-			Department:  userRow.Department,
-			Faculty:     userRow.Faculty,
-			StudentID:   userRow.StudentID,
-			Designation: userRow.Designation,
+		if err := s.userService.SendSetupEmail(ctx, user, resetLink); err != nil {
+			messages = append(messages, fmt.Sprintf("setup email not sent: %v", err))
+		} else {
+			row.EmailSent = true
 		}
+	}
+	row.UserID = &user.ID
 
-		_, err := s.userService.CreateUser(ctx, createReq, actorUserType)
-		if err != nil {
-			results = append(results, dto.BulkImportResultRow{
-				RowIndex: rowIndex,
-				Email:    userRow.Email,
-				Success:  false,
-				Error:    err.Error(),
-			})
-			return
+	if data.BatchID != "" {
+		if err := s.assignBatch(ctx, user, data.BatchID); err != nil {
+			messages = append(messages, fmt.Sprintf("batch not assigned: %v", err))
+		} else {
+			row.BatchAssigned = true
 		}
+	}
 
-		results = append(results, dto.BulkImportResultRow{
-			RowIndex: rowIndex,
-			Email:    userRow.Email,
-			Success:  true,
-		})
-		successCount++
+	row.Message = truncate(strings.Join(messages, "; "), 1000)
+}
+
+func (s *bulkImportService) assignBatch(ctx context.Context, user *domain.User, rawBatchID string) error {
+	if user.UserType != domain.UserTypeStudent {
+		return errors.New("only students can be placed in a batch")
+	}
+	if s.academicClient == nil {
+		return errors.New("academic service not configured")
 	}
+	batchID, err := uuid.Parse(rawBatchID)
+	if err != nil {
+		return errors.New("invalid batch ID")
+	}
+	return s.academicClient.AddBatchMember(ctx, batchID, user.ID)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// File helpers
+// ─────────────────────────────────────────────────────────────────────────────
 
-	if strings.HasSuffix(strings.ToLower(filename), ".csv") {
+// readImportRows returns the header row and the data rows of a CSV or XLSX
+// file. Headers are nil for an empty spreadsheet.
+func readImportRows(reader io.Reader, filename string) ([]string, [][]string, error) {
+	lower := strings.ToLower(filename)
+
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
 		r := csv.NewReader(reader)
+		r.FieldsPerRecord = -1
 		headers, err := r.Read()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read CSV headers: %w", err)
+			return nil, nil, fmt.Errorf("failed to read CSV headers: %w", err)
 		}
 
-		rowIndex := 1
+		var rows [][]string
 		for {
 			row, err := r.Read()
 			if err == io.EOF {
 				break
 			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to read CSV row at index %d: %w", rowIndex, err)
+				return nil, nil, fmt.Errorf("failed to read CSV row at index %d: %w", len(rows)+1, err)
 			}
-			rowIndex++
-			processRow(rowIndex-1, row, headers)
+			rows = append(rows, row)
 		}
-	} else if strings.HasSuffix(strings.ToLower(filename), ".xlsx") {
+		return headers, rows, nil
+
+	case strings.HasSuffix(lower, ".xlsx"):
 		f, err := excelize.OpenReader(reader)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read XLSX: %w", err)
+			return nil, nil, fmt.Errorf("failed to read XLSX: %w", err)
 		}
 		defer f.Close()
 
-		sheet := f.GetSheetName(0)
-		rows, err := f.Rows(sheet)
+		sheetRows, err := f.Rows(f.GetSheetName(0))
 		if err != nil {
-			return nil, fmt.Errorf("failed to get rows from XLSX: %w", err)
+			return nil, nil, fmt.Errorf("failed to get rows from XLSX: %w", err)
 		}
-		defer rows.Close()
+		defer sheetRows.Close()
 
-		if !rows.Next() {
-			return nil, fmt.Errorf("empty XLSX file")
+		if !sheetRows.Next() {
+			return nil, nil, nil
 		}
-
-		headers, err := rows.Columns()
+		headers, err := sheetRows.Columns()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get XLSX headers: %w", err)
+			return nil, nil, fmt.Errorf("failed to get XLSX headers: %w", err)
 		}
 
-		rowIndex := 1
-		for rows.Next() {
-			row, err := rows.Columns()
+		var rows [][]string
+		for sheetRows.Next() {
+			row, err := sheetRows.Columns()
 			if err != nil {
-				return nil, fmt.Errorf("failed to get XLSX row at index %d: %w", rowIndex, err)
+				return nil, nil, fmt.Errorf("failed to get XLSX row at index %d: %w", len(rows)+1, err)
 			}
-			rowIndex++
-			processRow(rowIndex-1, row, headers)
+			rows = append(rows, row)
 		}
-	} else {
-		return nil, fmt.Errorf("unsupported file format")
+		return headers, rows, nil
 	}
 
-	return &dto.BulkImportExecuteResponse{
-		TotalProcessed: totalProcessed,
-		SuccessCount:   successCount,
-		FailureCount:   totalProcessed - successCount,
-		Results:        results,
-	}, nil
+	return nil, nil, ErrUnsupportedImportFile
+}
+
+// writeSheet renders headers and records as CSV or, for any other format,
+// as a single-sheet XLSX workbook.
+func writeSheet(sheet string, headers []string, records [][]string, format string) ([]byte, string, error) {
+	if format == "csv" {
+		var b strings.Builder
+		w := csv.NewWriter(&b)
+		if err := w.Write(headers); err != nil {
+			return nil, "", err
+		}
+		if err := w.WriteAll(records); err != nil {
+			return nil, "", err
+		}
+		return []byte(b.String()), "text/csv", nil
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	index, _ := f.NewSheet(sheet)
+	f.SetActiveSheet(index)
+	f.DeleteSheet("Sheet1")
+
+	for i, h := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheet, cell, h)
+	}
+	for r, record := range records {
+		for i, v := range record {
+			cell, _ := excelize.CoordinatesToCellName(i+1, r+2)
+			f.SetCellValue(sheet, cell, v)
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), xlsxContentType, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+func toBulkImportJobResponse(job *domain.BulkImportJob, failed []domain.BulkImportRow) *dto.BulkImportJobResponse {
+	response := &dto.BulkImportJobResponse{
+		ID:            job.ID,
+		Filename:      job.Filename,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		SkippedCount:  job.SkippedCount,
+		FailedCount:   job.FailedCount,
+		Error:         job.Error,
+		CreatedBy:     job.CreatedBy,
+		StartedAt:     job.StartedAt,
+		CompletedAt:   job.CompletedAt,
+		CreatedAt:     job.CreatedAt,
+	}
+	for _, row := range failed {
+		var data dto.ImportUserRow
+		_ = json.Unmarshal([]byte(row.Data), &data)
+		response.Failures = append(response.Failures, dto.BulkImportResultRow{
+			RowIndex:      row.RowIndex,
+			Email:         row.Email,
+			Status:        row.Status,
+			UserID:        row.UserID,
+			EmailSent:     row.EmailSent,
+			BatchID:       data.BatchID,
+			BatchAssigned: row.BatchAssigned,
+			Message:       row.Message,
+		})
+	}
+	return response
 }
 
 func (s *bulkImportService) autoMapColumns(headers []string) map[string]string {
 	mapping := make(map[string]string)
-	systemFields := []string{"full_name", "email", "username", "role", "user_type", "department", "faculty", "student_id", "designation"}
+	systemFields := []string{"full_name", "email", "username", "role", "user_type", "department", "faculty", "student_id", "designation", "batch_id"}
 
 	for _, h := range headers {
 		normalized := s.normalizeHeader(h)
@@ -335,6 +691,7 @@ func (s *bulkImportService) isAlias(normalized, field string) bool {
 		"email":      {"email_address", "emailaddr"},
 		"user_type":  {"type", "usertype"},
 		"student_id": {"student_no", "id_number", "reg_no"},
+		"batch_id":   {"batch", "batchid"},
 	}
 
 	if list, ok := aliases[field]; ok {
@@ -372,6 +729,7 @@ func (s *bulkImportService) mapRowToUser(row []string, headers []string, mapping
 	user.Faculty = getValue("faculty")
 	user.StudentID = getValue("student_id")
 	user.Designation = getValue("designation")
+	user.BatchID = getValue("batch_id")
 
 	if user.Username == "" {
 		user.Username = user.Email
@@ -380,7 +738,9 @@ func (s *bulkImportService) mapRowToUser(row []string, headers []string, mapping
 	return user
 }
 
-func (s *bulkImportService) validateRow(ctx context.Context, row dto.ImportUserRow) []string {
+// validateRow checks a row's fields. Whether the email is already taken is
+// not an error here: existing accounts are skipped on import.
+func (s *bulkImportService) validateRow(row dto.ImportUserRow) []string {
 	errors := make([]string, 0)
 
 	if row.FullName == "" {
@@ -391,18 +751,12 @@ func (s *bulkImportService) validateRow(ctx context.Context, row dto.ImportUserR
 		errors = append(errors, "Email is required")
 	} else if _, err := mail.ParseAddress(row.Email); err != nil {
 		errors = append(errors, "Invalid email format")
-	} else {
-		// Check if email exists
-		existing, _ := s.userRepo.GetUserByEmail(ctx, row.Email)
-		if existing != nil {
-			errors = append(errors, "Email already exists")
-		}
 	}
 
+	lType := strings.ToLower(row.UserType)
 	if row.UserType == "" {
 		errors = append(errors, "User Type is required")
 	} else {
-		lType := strings.ToLower(row.UserType)
 		if lType != "student" && lType != "instructor" && lType != "admin" {
 			errors = append(errors, "Invalid User Type (must be student, instructor, or admin)")
 		}
@@ -414,5 +768,13 @@ func (s *bulkImportService) validateRow(ctx context.Context, row dto.ImportUserR
 		}
 	}
 
+	if row.BatchID != "" {
+		if _, err := uuid.Parse(row.BatchID); err != nil {
+			errors = append(errors, "Invalid Batch ID")
+		} else if lType != "student" {
+			errors = append(errors, "Batch ID is only allowed for students")
+		}
+	}
+
 	return errors
 }
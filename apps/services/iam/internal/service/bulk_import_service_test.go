@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/google/uuid"
+)
+
+// fakeImportRepository keeps import jobs and their rows in memory.
+type fakeImportRepository struct {
+	repository.BulkImportRepository
+	jobs    map[uuid.UUID]*domain.BulkImportJob
+	rows    []domain.BulkImportRow
+	created int
+	// failRecordAfter makes RecordRowResult fail once that many rows are saved.
+	failRecordAfter int
+}
+
+func newFakeImportRepository() *fakeImportRepository {
+	return &fakeImportRepository{jobs: map[uuid.UUID]*domain.BulkImportJob{}, failRecordAfter: -1}
+}
+
+func (r *fakeImportRepository) CreateJob(_ context.Context, job *domain.BulkImportJob, rows []domain.BulkImportRow) error {
+	r.jobs[job.ID] = job
+	r.rows = append(r.rows, rows...)
+	r.created++
+	return nil
+}
+
+func (r *fakeImportRepository) GetJob(_ context.Context, id uuid.UUID) (*domain.BulkImportJob, error) {
+	return r.jobs[id], nil
+}
+
+func (r *fakeImportRepository) FindActiveJobByHash(_ context.Context, createdBy uuid.UUID, fileHash string) (*domain.BulkImportJob, error) {
+	for _, j := range r.jobs {
+		active := j.Status == domain.ImportJobPending || j.Status == domain.ImportJobRunning
+		if active && j.CreatedBy == createdBy && j.FileHash == fileHash {
+			return j, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeImportRepository) ListPendingRows(_ context.Context, jobID uuid.UUID, limit int) ([]domain.BulkImportRow, error) {
+	var pending []domain.BulkImportRow
+	for _, row := range r.rows {
+		if row.JobID == jobID && row.Status == domain.ImportRowPending && len(pending) < limit {
+			pending = append(pending, row)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeImportRepository) ListRows(_ context.Context, jobID uuid.UUID, status string) ([]domain.BulkImportRow, error) {
+	var rows []domain.BulkImportRow
+	for _, row := range r.rows {
+		if row.JobID == jobID && (status == "" || row.Status == status) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (r *fakeImportRepository) RecordRowResult(_ context.Context, row *domain.BulkImportRow) error {
+	if r.failRecordAfter == 0 {
+		return errors.New("connection reset")
+	}
+	r.failRecordAfter--
+	for i := range r.rows {
+		if r.rows[i].ID == row.ID {
+			r.rows[i] = *row
+		}
+	}
+	r.jobs[row.JobID].ProcessedRows++
+	return nil
+}
+
+func (r *fakeImportRepository) ClaimJob(_ context.Context, id uuid.UUID, worker string, staleBefore time.Time) (bool, error) {
+	job := r.jobs[id]
+	free := job.Status == domain.ImportJobPending ||
+		(job.Status == domain.ImportJobRunning &&
+			(job.ClaimedBy == worker || job.ClaimedAt == nil || job.ClaimedAt.Before(staleBefore)))
+	if !free {
+		return false, nil
+	}
+	now := time.Now()
+	job.Status = domain.ImportJobRunning
+	job.ClaimedBy = worker
+	job.ClaimedAt = &now
+	return true, nil
+}
+
+func (r *fakeImportRepository) UpdateJobStatus(_ context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	job := r.jobs[id]
+	if status, ok := updates["status"].(string); ok {
+		job.Status = status
+	}
+	if msg, ok := updates["error"].(string); ok {
+		job.Error = msg
+	}
+	return nil
+}
+
+// fakeProvisioner records the accounts created for import rows and adds them
+// to users, so a replayed row finds its account.
+type fakeProvisioner struct {
+	UserService
+	users       *fakeUserRepository
+	provisioned []string
+	emailErr    error
+}
+
+func (p *fakeProvisioner) ProvisionUser(_ context.Context, req *dto.CreateUserRequest) (*domain.User, string, time.Time, error) {
+	p.provisioned = append(p.provisioned, req.Email)
+	user := &domain.User{ID: uuid.New(), Email: req.Email, UserType: req.UserType}
+	p.users.users = append(p.users.users, user)
+	return user, "https://reset.example", time.Time{}, nil
+}
+
+func (p *fakeProvisioner) SendSetupEmail(context.Context, *domain.User, string) error {
+	return p.emailErr
+}
+
+const importCSV = `Full Name,Email,User Type,Student ID,Designation
+Ada Lovelace,ada@uni.example,student,S-1,
+Existing Person,taken@uni.example,instructor,,Lecturer
+,broken,student,,
+`
+
+// importMapping maps the columns of importCSV.
+func importMapping(s *bulkImportService) map[string]string {
+	headers, _, _ := readImportRows(strings.NewReader(importCSV), "users.csv")
+	return s.autoMapColumns(headers)
+}
+
+func newTestBulkImportService(imports *fakeImportRepository, users *fakeUserRepository, provisioner *fakeProvisioner) *bulkImportService {
+	provisioner.users = users
+	return NewBulkImportService(nil, users, imports, provisioner, nil).(*bulkImportService)
+}
+
+func TestStartImport_ReusesActiveJobForSameFile(t *testing.T) {
+	imports := newFakeImportRepository()
+	s := newTestBulkImportService(imports, &fakeUserRepository{}, &fakeProvisioner{})
+	actor := uuid.New()
+
+	first, err := s.StartImport(context.Background(), strings.NewReader(importCSV), "users.csv", nil, actor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.TotalRows != 3 || first.Status != domain.ImportJobPending {
+		t.Fatalf("unexpected job: %+v", first)
+	}
+
+	again, err := s.StartImport(context.Background(), strings.NewReader(importCSV), "users.csv", nil, actor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.ID != first.ID || imports.created != 1 {
+		t.Errorf("expected the running job %s to be returned, got %s after %d jobs", first.ID, again.ID, imports.created)
+	}
+
+	_, err = s.StartImport(context.Background(), strings.NewReader("Full Name,Email\n"), "empty.csv", nil, actor)
+	if !errors.Is(err, ErrEmptyImportFile) {
+		t.Errorf("expected ErrEmptyImportFile, got %v", err)
+	}
+}
+
+func TestRunJob_RecordsRowOutcomes(t *testing.T) {
+	imports := newFakeImportRepository()
+	users := &fakeUserRepository{users: []*domain.User{{ID: uuid.New(), Email: "taken@uni.example"}}}
+	provisioner := &fakeProvisioner{emailErr: errors.New("smtp down")}
+	s := newTestBulkImportService(imports, users, provisioner)
+
+	started, err := s.StartImport(context.Background(), strings.NewReader(importCSV), "users.csv", importMapping(s), uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := imports.jobs[started.ID]
+	s.runJob(context.Background(), job)
+
+	if job.Status != domain.ImportJobCompleted {
+		t.Fatalf("expected job to complete, got %q (%s)", job.Status, job.Error)
+	}
+	if len(provisioner.provisioned) != 1 || provisioner.provisioned[0] != "ada@uni.example" {
+		t.Errorf("expected only ada to be provisioned, got %v", provisioner.provisioned)
+	}
+
+	rows, _ := imports.ListRows(context.Background(), job.ID, "")
+	want := []string{domain.ImportRowCreated, domain.ImportRowSkipped, domain.ImportRowFailed}
+	for i, row := range rows {
+		if row.Status != want[i] {
+			t.Errorf("row %d: expected %q, got %q (%s)", row.RowIndex, want[i], row.Status, row.Message)
+		}
+		if row.ProcessedAt == nil {
+			t.Errorf("row %d: processed_at not set", row.RowIndex)
+		}
+	}
+	if rows[0].EmailSent || !strings.Contains(rows[0].Message, "setup email not sent") {
+		t.Errorf("expected the setup email failure on row 1, got %+v", rows[0])
+	}
+	if rows[1].UserID == nil || *rows[1].UserID != users.users[0].ID {
+		t.Errorf("expected the skipped row to reference the existing user, got %v", rows[1].UserID)
+	}
+}
+
+func TestRunJob_FailureLeavesRemainingRowsResumable(t *testing.T) {
+	imports := newFakeImportRepository()
+	imports.failRecordAfter = 1
+	provisioner := &fakeProvisioner{}
+	s := newTestBulkImportService(imports, &fakeUserRepository{}, provisioner)
+
+	started, err := s.StartImport(context.Background(), strings.NewReader(importCSV), "users.csv", importMapping(s), uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := imports.jobs[started.ID]
+	s.runJob(context.Background(), job)
+
+	if job.Status != domain.ImportJobFailed || job.Error == "" {
+		t.Fatalf("expected a failed job with an error, got %q", job.Status)
+	}
+	pending, _ := imports.ListRows(context.Background(), job.ID, domain.ImportRowPending)
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 rows left pending, got %d", len(pending))
+	}
+
+	resumed, err := s.ResumeJob(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resumed.Status != domain.ImportJobPending || job.Error != "" {
+		t.Errorf("expected a pending job with the error cleared, got %q / %q", resumed.Status, job.Error)
+	}
+
+	imports.failRecordAfter = -1
+	s.runJob(context.Background(), job)
+	if job.Status != domain.ImportJobCompleted || job.ProcessedRows != 3 {
+		t.Errorf("expected all 3 rows processed once, got %q with %d", job.Status, job.ProcessedRows)
+	}
+	if len(provisioner.provisioned) != 2 {
+		t.Errorf("expected each valid row provisioned once, got %v", provisioner.provisioned)
+	}
+	// The row whose result was lost is replayed and finds its own account.
+	replayed, _ := imports.ListRows(context.Background(), job.ID, domain.ImportRowSkipped)
+	if len(replayed) != 1 || replayed[0].Email != "taken@uni.example" {
+		t.Errorf("expected the replayed row to be skipped, got %+v", replayed)
+	}
+
+	if _, err := s.ResumeJob(context.Background(), job.ID); !errors.Is(err, ErrImportJobNotResumable) {
+		t.Errorf("expected ErrImportJobNotResumable for a completed job, got %v", err)
+	}
+}
+
+func TestRunJob_SkipsJobClaimedByAnotherWorker(t *testing.T) {
+	imports := newFakeImportRepository()
+	provisioner := &fakeProvisioner{}
+	s := newTestBulkImportService(imports, &fakeUserRepository{}, provisioner)
+
+	started, err := s.StartImport(context.Background(), strings.NewReader(importCSV), "users.csv", importMapping(s), uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := imports.jobs[started.ID]
+	claimedAt := time.Now()
+	job.Status = domain.ImportJobRunning
+	job.ClaimedBy = "other-replica"
+	job.ClaimedAt = &claimedAt
+
+	s.runJob(context.Background(), job)
+	if len(provisioner.provisioned) != 0 || job.ClaimedBy != "other-replica" {
+		t.Fatalf("expected the job to be left to its worker, got %v provisioned by %q", provisioner.provisioned, job.ClaimedBy)
+	}
+
+	stale := claimedAt.Add(-2 * importClaimTTL)
+	job.ClaimedAt = &stale
+	s.runJob(context.Background(), job)
+	if job.Status != domain.ImportJobCompleted || job.ClaimedBy != s.workerID {
+		t.Errorf("expected the stale job to be taken over and completed, got %q by %q", job.Status, job.ClaimedBy)
+	}
+}
@@ -0,0 +1,601 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDataRequestNotFound    = errors.New("data request not found")
+	ErrInvalidDataRequestType = errors.New("type must be export or erasure")
+	ErrDataRequestWrongStatus = errors.New("data request is not in a state that allows this action")
+	ErrDataExportNotAvailable = errors.New("export archive is not available")
+)
+
+const (
+	// dataRequestPollInterval is how often the worker looks for requests it
+	// was not woken for, and removes expired export archives.
+	dataRequestPollInterval = time.Minute
+
+	dataStepDone   = "done"
+	dataStepFailed = "failed"
+)
+
+type DataRequestService interface {
+	// CreateRequest records an export or erasure for userID. Erasures asked
+	// for by the user themselves wait for admin approval; everything else is
+	// queued straight away. An export or erasure already in progress for the
+	// user is returned instead of starting a second one.
+	CreateRequest(ctx context.Context, userID uuid.UUID, requestType string, requestedBy uuid.UUID, selfService bool) (*dto.DataRequestResponse, error)
+	// GetRequest returns a request. With ownerID set, requests of other users
+	// are reported as not found.
+	GetRequest(ctx context.Context, id uuid.UUID, ownerID *uuid.UUID) (*dto.DataRequestResponse, error)
+	ListRequests(ctx context.Context, userID *uuid.UUID, status string, limit int) ([]dto.DataRequestResponse, error)
+	ApproveRequest(ctx context.Context, id, reviewerID uuid.UUID) (*dto.DataRequestResponse, error)
+	RejectRequest(ctx context.Context, id, reviewerID uuid.UUID, reason string) (*dto.DataRequestResponse, error)
+	// RetryRequest queues a failed request again. Every step is idempotent,
+	// so an erasure resumes safely after a partial failure.
+	RetryRequest(ctx context.Context, id uuid.UUID) (*dto.DataRequestResponse, error)
+	// OpenExport opens a completed export's archive. With ownerID set,
+	// requests of other users are reported as not found.
+	OpenExport(ctx context.Context, id uuid.UUID, ownerID *uuid.UUID) (io.ReadCloser, int64, error)
+	// Run processes queued requests until ctx is done. Requests left running
+	// by a previous run are picked up again.
+	Run(ctx context.Context)
+}
+
+type dataRequestService struct {
+	requestRepo        repository.DataRequestRepository
+	userRepo           repository.UserRepository
+	lifecycleRepo      repository.LifecycleRepository
+	exportStorage      *storage.ExportStorage
+	academicClient     *client.AcademicClient
+	assessmentClient   *client.AssessmentClient
+	notificationClient *client.NotificationClient
+	exportExpiry       time.Duration
+	wake               chan struct{}
+}
+
+func NewDataRequestService(
+	requestRepo repository.DataRequestRepository,
+	userRepo repository.UserRepository,
+	lifecycleRepo repository.LifecycleRepository,
+	exportStorage *storage.ExportStorage,
+	academicClient *client.AcademicClient,
+	assessmentClient *client.AssessmentClient,
+	notificationClient *client.NotificationClient,
+	exportExpiryDays int64,
+) DataRequestService {
+	return &dataRequestService{
+		requestRepo:        requestRepo,
+		userRepo:           userRepo,
+		lifecycleRepo:      lifecycleRepo,
+		exportStorage:      exportStorage,
+		academicClient:     academicClient,
+		assessmentClient:   assessmentClient,
+		notificationClient: notificationClient,
+		exportExpiry:       time.Duration(exportExpiryDays) * 24 * time.Hour,
+		wake:               make(chan struct{}, 1),
+	}
+}
+
+func (s *dataRequestService) CreateRequest(ctx context.Context, userID uuid.UUID, requestType string, requestedBy uuid.UUID, selfService bool) (*dto.DataRequestResponse, error) {
+	if requestType != domain.DataRequestExport && requestType != domain.DataRequestErasure {
+		return nil, ErrInvalidDataRequestType
+	}
+
+	// Admins may export or erase the data of an already deleted account.
+	data, err := s.requestRepo.GetUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil || data.User.AnonymizedAt != nil {
+		return nil, ErrUserNotFound
+	}
+
+	existing, err := s.requestRepo.FindActiveRequest(ctx, userID, requestType)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return toDataRequestResponse(existing)
+	}
+
+	status := domain.DataRequestPending
+	if requestType == domain.DataRequestErasure && selfService {
+		status = domain.DataRequestPendingApproval
+	}
+
+	req := &domain.DataRequest{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Type:        requestType,
+		Status:      status,
+		RequestedBy: requestedBy,
+	}
+	if err := s.requestRepo.CreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if status == domain.DataRequestPending {
+		s.notify()
+	}
+	return toDataRequestResponse(req)
+}
+
+func (s *dataRequestService) GetRequest(ctx context.Context, id uuid.UUID, ownerID *uuid.UUID) (*dto.DataRequestResponse, error) {
+	req, err := s.getRequest(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return toDataRequestResponse(req)
+}
+
+func (s *dataRequestService) getRequest(ctx context.Context, id uuid.UUID, ownerID *uuid.UUID) (*domain.DataRequest, error) {
+	req, err := s.requestRepo.GetRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || (ownerID != nil && req.UserID != *ownerID) {
+		return nil, ErrDataRequestNotFound
+	}
+	return req, nil
+}
+
+func (s *dataRequestService) ListRequests(ctx context.Context, userID *uuid.UUID, status string, limit int) ([]dto.DataRequestResponse, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	reqs, err := s.requestRepo.ListRequests(ctx, userID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.DataRequestResponse, 0, len(reqs))
+	for i := range reqs {
+		r, err := toDataRequestResponse(&reqs[i])
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, *r)
+	}
+	return resp, nil
+}
+
+func (s *dataRequestService) ApproveRequest(ctx context.Context, id, reviewerID uuid.UUID) (*dto.DataRequestResponse, error) {
+	return s.transition(ctx, id, domain.DataRequestPendingApproval, map[string]interface{}{
+		"status":      domain.DataRequestPending,
+		"reviewed_by": reviewerID,
+	})
+}
+
+func (s *dataRequestService) RejectRequest(ctx context.Context, id, reviewerID uuid.UUID, reason string) (*dto.DataRequestResponse, error) {
+	return s.transition(ctx, id, domain.DataRequestPendingApproval, map[string]interface{}{
+		"status":       domain.DataRequestRejected,
+		"reviewed_by":  reviewerID,
+		"error":        truncate(reason, 1000),
+		"completed_at": time.Now(),
+	})
+}
+
+func (s *dataRequestService) RetryRequest(ctx context.Context, id uuid.UUID) (*dto.DataRequestResponse, error) {
+	return s.transition(ctx, id, domain.DataRequestFailed, map[string]interface{}{
+		"status":       domain.DataRequestPending,
+		"error":        "",
+		"completed_at": nil,
+	})
+}
+
+// transition moves a request in status from to the given updates and wakes
+// the worker if the request was queued.
+func (s *dataRequestService) transition(ctx context.Context, id uuid.UUID, from string, updates map[string]interface{}) (*dto.DataRequestResponse, error) {
+	req, err := s.getRequest(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != from {
+		return nil, ErrDataRequestWrongStatus
+	}
+
+	if err := s.requestRepo.UpdateRequest(ctx, id, updates); err != nil {
+		return nil, err
+	}
+	if updates["status"] == domain.DataRequestPending {
+		s.notify()
+	}
+
+	return s.GetRequest(ctx, id, nil)
+}
+
+func (s *dataRequestService) OpenExport(ctx context.Context, id uuid.UUID, ownerID *uuid.UUID) (io.ReadCloser, int64, error) {
+	req, err := s.getRequest(ctx, id, ownerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if req.Type != domain.DataRequestExport || req.Status != domain.DataRequestCompleted ||
+		req.ObjectName == "" || (req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now())) {
+		return nil, 0, ErrDataExportNotAvailable
+	}
+	return s.exportStorage.GetExport(ctx, req.ObjectName)
+}
+
+func (s *dataRequestService) Run(ctx context.Context) {
+	ticker := time.NewTicker(dataRequestPollInterval)
+	defer ticker.Stop()
+
+	s.notify()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+			s.expireExports(ctx)
+		}
+
+		reqs, err := s.requestRepo.ListQueuedRequests(ctx)
+		if err != nil {
+			fmt.Printf("warning: failed to list data requests: %v\n", err)
+			continue
+		}
+		for i := range reqs {
+			if ctx.Err() != nil {
+				return
+			}
+			s.process(ctx, &reqs[i])
+		}
+	}
+}
+
+// notify wakes the worker without blocking if it is already due to run.
+func (s *dataRequestService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *dataRequestService) process(ctx context.Context, req *domain.DataRequest) {
+	startedAt := time.Now()
+	if err := s.requestRepo.UpdateRequest(ctx, req.ID, map[string]interface{}{
+		"status":     domain.DataRequestRunning,
+		"started_at": startedAt,
+	}); err != nil {
+		fmt.Printf("warning: failed to start data request %s: %v\n", req.ID, err)
+		return
+	}
+
+	report := &dto.DataRequestReport{Steps: []dto.DataRequestStep{}}
+	updates := map[string]interface{}{}
+	var runErr error
+	if req.Type == domain.DataRequestExport {
+		runErr = s.runExport(ctx, req, report, updates)
+	} else {
+		runErr = s.runErasure(ctx, req, report)
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		fmt.Printf("warning: failed to encode data request report %s: %v\n", req.ID, err)
+		reportJSON = []byte("{}")
+	}
+	updates["report"] = string(reportJSON)
+	updates["completed_at"] = time.Now()
+	if runErr != nil {
+		updates["status"] = domain.DataRequestFailed
+		updates["error"] = truncate(runErr.Error(), 1000)
+	} else {
+		updates["status"] = domain.DataRequestCompleted
+		updates["error"] = ""
+	}
+
+	// The work has already been done; record the outcome even if the worker
+	// is shutting down.
+	if err := s.requestRepo.UpdateRequest(context.WithoutCancel(ctx), req.ID, updates); err != nil {
+		fmt.Printf("warning: failed to save data request %s: %v\n", req.ID, err)
+	}
+}
+
+// runExport gathers the user's data from every service into one zip archive
+// and stores it in the export bucket. Any service failing fails the export so
+// a user never receives a silently incomplete archive.
+func (s *dataRequestService) runExport(ctx context.Context, req *domain.DataRequest, report *dto.DataRequestReport, updates map[string]interface{}) error {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	steps := []struct {
+		service string
+		run     func() error
+	}{
+		{"iam", func() error {
+			data, err := s.requestRepo.GetUserData(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+			if data == nil {
+				return ErrUserNotFound
+			}
+			return writeJSONEntry(zw, "iam/user.json", toUserDataExport(data))
+		}},
+		{"academic", func() error {
+			data, err := s.academicClient.ExportUserData(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+			return writeZipFile(zw, "academic/data.json", data)
+		}},
+		{"assessment", func() error {
+			archive, err := s.assessmentClient.ExportUserData(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+			return copyZipEntries(zw, "assessment/", archive)
+		}},
+		{"notification", func() error {
+			data, err := s.notificationClient.ExportUserData(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+			return writeZipFile(zw, "notification/notifications.json", data)
+		}},
+	}
+
+	var failed error
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			report.Steps = append(report.Steps, dto.DataRequestStep{Service: step.service, Status: dataStepFailed, Error: err.Error()})
+			if failed == nil {
+				failed = fmt.Errorf("exporting %s data: %w", step.service, err)
+			}
+			continue
+		}
+		report.Steps = append(report.Steps, dto.DataRequestStep{Service: step.service, Status: dataStepDone})
+	}
+	if failed != nil {
+		return failed
+	}
+
+	generatedAt := time.Now()
+	if err := writeJSONEntry(zw, "manifest.json", map[string]interface{}{
+		"request_id":   req.ID,
+		"user_id":      req.UserID,
+		"generated_at": generatedAt,
+		"services":     []string{"iam", "academic", "assessment", "notification"},
+	}); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("finishing export archive: %w", err)
+	}
+
+	objectName := fmt.Sprintf("exports/%s/%s.zip", req.UserID, req.ID)
+	if err := s.exportStorage.PutExport(ctx, objectName, buf.Bytes()); err != nil {
+		return err
+	}
+
+	expiresAt := generatedAt.Add(s.exportExpiry)
+	updates["object_name"] = objectName
+	updates["expires_at"] = expiresAt
+	return nil
+}
+
+// runErasure removes the user's data from every service. Grades are part of
+// the academic record, so enrollments and graded submissions are kept but
+// point at an anonymised account. Steps run in order and stop at the first
+// failure; each is idempotent, so retrying picks up where it left off.
+func (s *dataRequestService) runErasure(ctx context.Context, req *domain.DataRequest, report *dto.DataRequestReport) error {
+	steps := []struct {
+		service string
+		run     func() error
+	}{
+		{"exports", func() error { return s.deleteExports(ctx, req.UserID) }},
+		{"notification", func() error { return s.notificationClient.EraseUserData(ctx, req.UserID) }},
+		{"academic", func() error { return s.academicClient.EraseUserData(ctx, req.UserID, true) }},
+		{"assessment", func() error { return s.assessmentClient.EraseUserData(ctx, req.UserID, true) }},
+		{"iam", func() error {
+			// Soft deletion revokes the user's sessions; anonymisation then
+			// removes everything that identifies them.
+			if err := s.userRepo.SoftDeleteUser(ctx, req.UserID); err != nil {
+				return err
+			}
+			return s.lifecycleRepo.AnonymizeUser(ctx, req.UserID, time.Now())
+		}},
+	}
+
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			report.Steps = append(report.Steps, dto.DataRequestStep{Service: step.service, Status: dataStepFailed, Error: err.Error()})
+			return fmt.Errorf("erasing %s data: %w", step.service, err)
+		}
+		report.Steps = append(report.Steps, dto.DataRequestStep{Service: step.service, Status: dataStepDone})
+	}
+	return nil
+}
+
+// deleteExports removes the user's stored export archives, which hold the
+// very data being erased.
+func (s *dataRequestService) deleteExports(ctx context.Context, userID uuid.UUID) error {
+	exports, err := s.requestRepo.ListCompletedExports(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for i := range exports {
+		if err := s.expireExport(ctx, &exports[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expireExports deletes archives whose download window has passed.
+func (s *dataRequestService) expireExports(ctx context.Context) {
+	exports, err := s.requestRepo.ListExportsExpiredBefore(ctx, time.Now())
+	if err != nil {
+		fmt.Printf("warning: failed to list expired data exports: %v\n", err)
+		return
+	}
+	for i := range exports {
+		if err := s.expireExport(ctx, &exports[i]); err != nil {
+			fmt.Printf("warning: failed to expire data export %s: %v\n", exports[i].ID, err)
+		}
+	}
+}
+
+func (s *dataRequestService) expireExport(ctx context.Context, req *domain.DataRequest) error {
+	if req.ObjectName != "" {
+		if err := s.exportStorage.DeleteExport(ctx, req.ObjectName); err != nil {
+			return err
+		}
+	}
+	return s.requestRepo.UpdateRequest(ctx, req.ID, map[string]interface{}{
+		"status":      domain.DataRequestExpired,
+		"object_name": "",
+	})
+}
+
+func writeJSONEntry(zw *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
+	}
+	return writeZipFile(zw, name, data)
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("adding %s to archive: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// copyZipEntries copies every file of a service's export archive into zw
+// under prefix.
+func copyZipEntries(zw *zip.Writer, prefix string, archive []byte) error {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return fmt.Errorf("reading archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", f.Name, err)
+		}
+		if err := writeZipFile(zw, prefix+f.Name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toUserDataExport(data *repository.UserData) dto.UserDataExport {
+	u := data.User
+	export := dto.UserDataExport{
+		User: dto.ExportedUser{
+			ID:             u.ID,
+			Email:          u.Email,
+			FullName:       u.FullName,
+			AvatarURL:      u.AvatarURL,
+			Faculty:        u.Faculty,
+			Department:     u.Department,
+			UserType:       u.UserType,
+			IsActive:       u.IsActive,
+			EmailVerified:  u.EmailVerified,
+			GitHubUsername: u.GitHubUsername,
+			LastLoginAt:    u.LastLoginAt,
+			DeactivatedAt:  u.DeactivatedAt,
+			CreatedAt:      u.CreatedAt,
+		},
+		Identities:      make([]dto.ExportedIdentity, 0, len(data.Identities)),
+		RoleAssignments: make([]dto.ExportedRoleAssignment, 0, len(data.RoleAssignments)),
+		AccessTokens:    make([]dto.ExportedAccessToken, 0, len(data.AccessTokens)),
+	}
+	if u.DeletedAt.Valid {
+		export.User.DeletedAt = &u.DeletedAt.Time
+	}
+	if data.StudentProfile != nil {
+		export.User.StudentID = data.StudentProfile.StudentID
+	}
+	if data.InstructorProfile != nil {
+		export.User.Designation = data.InstructorProfile.Designation
+	}
+
+	for _, i := range data.Identities {
+		export.Identities = append(export.Identities, dto.ExportedIdentity{
+			Provider:    i.Provider,
+			Subject:     i.Subject,
+			Email:       i.Email,
+			LastLoginAt: i.LastLoginAt,
+			CreatedAt:   i.CreatedAt,
+		})
+	}
+	for _, a := range data.RoleAssignments {
+		export.RoleAssignments = append(export.RoleAssignments, dto.ExportedRoleAssignment{
+			Role:      a.Role.Name,
+			ScopeType: a.ScopeType,
+			ScopeID:   a.ScopeID,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	for _, t := range data.AccessTokens {
+		export.AccessTokens = append(export.AccessTokens, dto.ExportedAccessToken{
+			Name:       t.Name,
+			Prefix:     t.TokenPrefix,
+			Scopes:     t.Scopes,
+			ExpiresAt:  t.ExpiresAt,
+			LastUsedAt: t.LastUsedAt,
+			RevokedAt:  t.RevokedAt,
+			CreatedAt:  t.CreatedAt,
+		})
+	}
+	return export
+}
+
+func toDataRequestResponse(req *domain.DataRequest) (*dto.DataRequestResponse, error) {
+	resp := &dto.DataRequestResponse{
+		ID:          req.ID,
+		UserID:      req.UserID,
+		Type:        req.Type,
+		Status:      req.Status,
+		RequestedBy: req.RequestedBy,
+		ReviewedBy:  req.ReviewedBy,
+		ExpiresAt:   req.ExpiresAt,
+		Error:       req.Error,
+		StartedAt:   req.StartedAt,
+		CompletedAt: req.CompletedAt,
+		CreatedAt:   req.CreatedAt,
+	}
+	if req.Report != "" {
+		var report dto.DataRequestReport
+		if err := json.Unmarshal([]byte(req.Report), &report); err != nil {
+			return nil, fmt.Errorf("decoding data request report: %w", err)
+		}
+		resp.Report = &report
+	}
+	return resp, nil
+}
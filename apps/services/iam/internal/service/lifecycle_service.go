@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLifecycleRunInProgress = errors.New("a lifecycle run is already in progress")
+	ErrLifecycleRunNotFound   = errors.New("lifecycle run not found")
+)
+
+// lifecycleStartupDelay keeps the first scheduled run off the service's
+// start-up path.
+const lifecycleStartupDelay = time.Minute
+
+type LifecycleService interface {
+	// RunRules evaluates every lifecycle rule once. A dry run only reports the
+	// users each rule matches; otherwise the actions are applied as well.
+	RunRules(ctx context.Context, dryRun bool, trigger string, triggeredBy *uuid.UUID) (*dto.LifecycleRunResponse, error)
+	GetRun(ctx context.Context, id uuid.UUID) (*dto.LifecycleRunResponse, error)
+	ListRuns(ctx context.Context, limit int) ([]dto.LifecycleRunResponse, error)
+	// Run evaluates the rules on the configured interval until ctx is done.
+	Run(ctx context.Context)
+}
+
+type lifecycleService struct {
+	lifecycleRepo      repository.LifecycleRepository
+	academicClient     *client.AcademicClient
+	assessmentClient   *client.AssessmentClient
+	notificationClient *client.NotificationClient
+	cfg                config.LifecycleConfig
+	running            sync.Mutex
+}
+
+func NewLifecycleService(
+	lifecycleRepo repository.LifecycleRepository,
+	academicClient *client.AcademicClient,
+	assessmentClient *client.AssessmentClient,
+	notificationClient *client.NotificationClient,
+	cfg config.LifecycleConfig,
+) LifecycleService {
+	return &lifecycleService{
+		lifecycleRepo:      lifecycleRepo,
+		academicClient:     academicClient,
+		assessmentClient:   assessmentClient,
+		notificationClient: notificationClient,
+		cfg:                cfg,
+	}
+}
+
+func (s *lifecycleService) RunRules(ctx context.Context, dryRun bool, trigger string, triggeredBy *uuid.UUID) (*dto.LifecycleRunResponse, error) {
+	if !s.running.TryLock() {
+		return nil, ErrLifecycleRunInProgress
+	}
+	defer s.running.Unlock()
+
+	run := &domain.LifecycleRun{
+		ID:          uuid.New(),
+		Trigger:     trigger,
+		TriggeredBy: triggeredBy,
+		DryRun:      dryRun,
+		Report:      "{}",
+		StartedAt:   time.Now(),
+	}
+	if err := s.lifecycleRepo.CreateRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("creating lifecycle run: %w", err)
+	}
+
+	report := &dto.LifecycleReport{
+		Policy: dto.LifecyclePolicy{
+			GraduationGraceDays: s.cfg.GraduationGraceDays,
+			InactivityMonths:    s.cfg.InactivityMonths,
+			RetentionDays:       s.cfg.RetentionDays,
+			RetentionMode:       s.cfg.RetentionMode,
+		},
+		Graduated: []dto.LifecycleUserAction{},
+		Inactive:  []dto.LifecycleUserAction{},
+		Retention: []dto.LifecycleUserAction{},
+	}
+
+	s.applyGraduation(ctx, run.StartedAt, dryRun, report)
+	s.applyInactivity(ctx, run.StartedAt, dryRun, report)
+	s.applyRetention(ctx, run.StartedAt, dryRun, report)
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("encoding lifecycle report: %w", err)
+	}
+
+	completedAt := time.Now()
+	run.Report = string(reportJSON)
+	run.GraduatedCount = len(report.Graduated)
+	run.InactiveCount = len(report.Inactive)
+	run.RetentionCount = len(report.Retention)
+	run.ErrorCount = countLifecycleErrors(report)
+	run.CompletedAt = &completedAt
+
+	// The rules have already run; record the outcome even if the caller has
+	// gone away.
+	if err := s.lifecycleRepo.UpdateRun(context.WithoutCancel(ctx), run); err != nil {
+		return nil, fmt.Errorf("saving lifecycle run: %w", err)
+	}
+
+	resp := toLifecycleRunResponse(run)
+	resp.Report = report
+	return resp, nil
+}
+
+// applyGraduation deactivates students whose batches all ended more than the
+// grace period ago. A batch is taken to end with its end year, so students of
+// a batch ending in year Y are due once Y+1 plus the grace period has passed.
+func (s *lifecycleService) applyGraduation(ctx context.Context, now time.Time, dryRun bool, report *dto.LifecycleReport) {
+	if s.cfg.GraduationGraceDays <= 0 {
+		return
+	}
+
+	cutoffYear := now.AddDate(0, 0, -int(s.cfg.GraduationGraceDays)).Year() - 1
+	report.GraduationCutoffYear = cutoffYear
+
+	graduates, err := s.academicClient.ListGraduatedUsers(ctx, cutoffYear)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("listing graduated students: %v", err))
+		return
+	}
+	if len(graduates) == 0 {
+		return
+	}
+
+	endYears := make(map[uuid.UUID]int, len(graduates))
+	ids := make([]uuid.UUID, 0, len(graduates))
+	for _, g := range graduates {
+		endYears[g.UserID] = g.EndYear
+		ids = append(ids, g.UserID)
+	}
+
+	users, err := s.lifecycleRepo.ListActiveStudents(ctx, ids)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("matching graduated students: %v", err))
+		return
+	}
+
+	for i := range users {
+		action := dto.LifecycleUserAction{
+			UserID:   users[i].ID,
+			Email:    users[i].Email,
+			FullName: users[i].FullName,
+			Detail:   fmt.Sprintf("batch ended %d", endYears[users[i].ID]),
+		}
+		if !dryRun {
+			if err := s.lifecycleRepo.DeactivateUser(ctx, users[i].ID, domain.DeactivationReasonGraduated, now); err != nil {
+				action.Error = err.Error()
+			} else {
+				action.Applied = true
+			}
+		}
+		report.Graduated = append(report.Graduated, action)
+	}
+}
+
+// applyInactivity flags active accounts that have not logged in for the
+// configured number of months. Flagged accounts stay usable; the flag is
+// cleared by their next login.
+func (s *lifecycleService) applyInactivity(ctx context.Context, now time.Time, dryRun bool, report *dto.LifecycleReport) {
+	if s.cfg.InactivityMonths <= 0 {
+		return
+	}
+
+	before := now.AddDate(0, -int(s.cfg.InactivityMonths), 0)
+	report.InactiveBefore = &before
+
+	users, err := s.lifecycleRepo.ListInactiveUsers(ctx, before)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("listing inactive users: %v", err))
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(users))
+	for i := range users {
+		ids = append(ids, users[i].ID)
+	}
+
+	var flagErr error
+	if !dryRun {
+		flagErr = s.lifecycleRepo.FlagInactive(ctx, ids, now)
+	}
+
+	for i := range users {
+		detail := "never logged in"
+		if users[i].LastLoginAt != nil {
+			detail = "last login " + users[i].LastLoginAt.Format(time.RFC3339)
+		}
+		action := dto.LifecycleUserAction{
+			UserID:   users[i].ID,
+			Email:    users[i].Email,
+			FullName: users[i].FullName,
+			Detail:   detail,
+		}
+		if !dryRun {
+			if flagErr != nil {
+				action.Error = flagErr.Error()
+			} else {
+				action.Applied = true
+			}
+		}
+		report.Inactive = append(report.Inactive, action)
+	}
+}
+
+// applyRetention anonymises or purges users soft-deleted longer than the
+// retention window. Both first erase the user's data in the academic,
+// assessment and notification services, anonymising keeping graded records as
+// an erasure request does; a user whose erasure fails anywhere is left for the
+// next run.
+func (s *lifecycleService) applyRetention(ctx context.Context, now time.Time, dryRun bool, report *dto.LifecycleReport) {
+	if s.cfg.RetentionDays <= 0 {
+		return
+	}
+
+	mode := s.cfg.RetentionMode
+	if mode != domain.RetentionModeAnonymize && mode != domain.RetentionModePurge {
+		report.Errors = append(report.Errors, fmt.Sprintf("unknown retention mode %q", mode))
+		return
+	}
+
+	before := now.AddDate(0, 0, -int(s.cfg.RetentionDays))
+	report.DeletedBefore = &before
+
+	users, err := s.lifecycleRepo.ListExpiredDeletedUsers(ctx, before)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("listing expired deleted users: %v", err))
+		return
+	}
+
+	for i := range users {
+		action := dto.LifecycleUserAction{
+			UserID: users[i].ID,
+			Detail: fmt.Sprintf("%s, deleted %s", mode, users[i].DeletedAt.Time.Format(time.RFC3339)),
+		}
+		if !dryRun {
+			if err := s.retainUser(ctx, users[i].ID, mode, now); err != nil {
+				action.Error = err.Error()
+			} else {
+				action.Applied = true
+			}
+		}
+		report.Retention = append(report.Retention, action)
+	}
+}
+
+func (s *lifecycleService) retainUser(ctx context.Context, userID uuid.UUID, mode string, now time.Time) error {
+	anonymize := mode == domain.RetentionModeAnonymize
+
+	if err := s.academicClient.EraseUserData(ctx, userID, anonymize); err != nil {
+		return fmt.Errorf("erasing academic data: %w", err)
+	}
+	if err := s.assessmentClient.EraseUserData(ctx, userID, anonymize); err != nil {
+		return fmt.Errorf("erasing assessment data: %w", err)
+	}
+	if err := s.notificationClient.EraseUserData(ctx, userID); err != nil {
+		return fmt.Errorf("erasing notifications: %w", err)
+	}
+
+	if anonymize {
+		return s.lifecycleRepo.AnonymizeUser(ctx, userID, now)
+	}
+	return s.lifecycleRepo.PurgeUser(ctx, userID)
+}
+
+func (s *lifecycleService) GetRun(ctx context.Context, id uuid.UUID) (*dto.LifecycleRunResponse, error) {
+	run, err := s.lifecycleRepo.GetRun(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrLifecycleRunNotFound
+	}
+
+	resp := toLifecycleRunResponse(run)
+	var report dto.LifecycleReport
+	if err := json.Unmarshal([]byte(run.Report), &report); err != nil {
+		return nil, fmt.Errorf("decoding lifecycle report: %w", err)
+	}
+	resp.Report = &report
+	return resp, nil
+}
+
+func (s *lifecycleService) ListRuns(ctx context.Context, limit int) ([]dto.LifecycleRunResponse, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	runs, err := s.lifecycleRepo.ListRuns(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.LifecycleRunResponse, 0, len(runs))
+	for i := range runs {
+		responses = append(responses, *toLifecycleRunResponse(&runs[i]))
+	}
+	return responses, nil
+}
+
+func (s *lifecycleService) Run(ctx context.Context) {
+	interval := time.Duration(s.cfg.Interval) * time.Hour
+	if interval <= 0 {
+		return
+	}
+
+	for {
+		// Schedule from the last scheduled run so restarts do not reset the
+		// clock or run the rules twice in one interval.
+		wait := lifecycleStartupDelay
+		last, err := s.lifecycleRepo.GetLatestRun(ctx, domain.LifecycleTriggerSchedule)
+		if err != nil {
+			fmt.Printf("warning: failed to load last lifecycle run: %v\n", err)
+		} else if last != nil {
+			if due := time.Until(last.StartedAt.Add(interval)); due > wait {
+				wait = due
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if _, err := s.RunRules(ctx, s.cfg.DryRun, domain.LifecycleTriggerSchedule, nil); err != nil {
+			fmt.Printf("warning: scheduled lifecycle run failed: %v\n", err)
+		}
+	}
+}
+
+func countLifecycleErrors(report *dto.LifecycleReport) int {
+	count := len(report.Errors)
+	for _, actions := range [][]dto.LifecycleUserAction{report.Graduated, report.Inactive, report.Retention} {
+		for i := range actions {
+			if actions[i].Error != "" {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func toLifecycleRunResponse(run *domain.LifecycleRun) *dto.LifecycleRunResponse {
+	return &dto.LifecycleRunResponse{
+		ID:             run.ID,
+		Trigger:        run.Trigger,
+		TriggeredBy:    run.TriggeredBy,
+		DryRun:         run.DryRun,
+		GraduatedCount: run.GraduatedCount,
+		InactiveCount:  run.InactiveCount,
+		RetentionCount: run.RetentionCount,
+		ErrorCount:     run.ErrorCount,
+		StartedAt:      run.StartedAt,
+		CompletedAt:    run.CompletedAt,
+	}
+}
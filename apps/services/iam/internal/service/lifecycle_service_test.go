@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/client"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeLifecycleRepository returns fixed expired users and records what was
+// done to them.
+type fakeLifecycleRepository struct {
+	repository.LifecycleRepository
+	expired    []domain.User
+	anonymized []uuid.UUID
+	purged     []uuid.UUID
+}
+
+func (r *fakeLifecycleRepository) ListExpiredDeletedUsers(context.Context, time.Time) ([]domain.User, error) {
+	return r.expired, nil
+}
+
+func (r *fakeLifecycleRepository) AnonymizeUser(_ context.Context, userID uuid.UUID, _ time.Time) error {
+	r.anonymized = append(r.anonymized, userID)
+	return nil
+}
+
+func (r *fakeLifecycleRepository) PurgeUser(_ context.Context, userID uuid.UUID) error {
+	r.purged = append(r.purged, userID)
+	return nil
+}
+
+func TestApplyRetention_ErasesDownstreamDataInEveryMode(t *testing.T) {
+	tests := []struct {
+		mode          string
+		wantQuery     string
+		wantAnonymize bool
+	}{
+		{domain.RetentionModeAnonymize, "retain_grades=true", true},
+		{domain.RetentionModePurge, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			var mu sync.Mutex
+			var erasures []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("expected DELETE, got %s", r.Method)
+				}
+				mu.Lock()
+				erasures = append(erasures, r.URL.RawQuery)
+				mu.Unlock()
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			user := domain.User{ID: uuid.New(), DeletedAt: gorm.DeletedAt{Time: time.Now().AddDate(-2, 0, 0), Valid: true}}
+			repo := &fakeLifecycleRepository{expired: []domain.User{user}}
+			s := &lifecycleService{
+				lifecycleRepo:      repo,
+				academicClient:     client.NewAcademicClient(server.URL, "secret"),
+				assessmentClient:   client.NewAssessmentClient(server.URL, "secret"),
+				notificationClient: client.NewNotificationClient(server.URL, "secret"),
+				cfg:                config.LifecycleConfig{RetentionDays: 365, RetentionMode: tt.mode},
+			}
+
+			report := &dto.LifecycleReport{}
+			s.applyRetention(context.Background(), time.Now(), false, report)
+
+			if len(report.Retention) != 1 || !report.Retention[0].Applied {
+				t.Fatalf("expected the user to be retained, got %+v", report.Retention)
+			}
+			// Academic, assessment and notification data are all erased.
+			if len(erasures) != 3 {
+				t.Fatalf("expected 3 downstream erasures, got %d", len(erasures))
+			}
+			for i, query := range erasures[:2] {
+				if query != tt.wantQuery {
+					t.Errorf("erasure %d: expected query %q, got %q", i, tt.wantQuery, query)
+				}
+			}
+			if anonymized := len(repo.anonymized) == 1; anonymized != tt.wantAnonymize {
+				t.Errorf("expected anonymised=%v, got %v", tt.wantAnonymize, repo.anonymized)
+			}
+			if purged := len(repo.purged) == 1; purged == tt.wantAnonymize {
+				t.Errorf("expected purged=%v, got %v", !tt.wantAnonymize, repo.purged)
+			}
+		})
+	}
+}
+
+func TestApplyRetention_KeepsUserWhenErasureFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	user := domain.User{ID: uuid.New(), DeletedAt: gorm.DeletedAt{Time: time.Now().AddDate(-2, 0, 0), Valid: true}}
+	repo := &fakeLifecycleRepository{expired: []domain.User{user}}
+	s := &lifecycleService{
+		lifecycleRepo:      repo,
+		academicClient:     client.NewAcademicClient(server.URL, "secret"),
+		assessmentClient:   client.NewAssessmentClient(server.URL, "secret"),
+		notificationClient: client.NewNotificationClient(server.URL, "secret"),
+		cfg:                config.LifecycleConfig{RetentionDays: 365, RetentionMode: domain.RetentionModeAnonymize},
+	}
+
+	report := &dto.LifecycleReport{}
+	s.applyRetention(context.Background(), time.Now(), false, report)
+
+	if len(report.Retention) != 1 || report.Retention[0].Applied || report.Retention[0].Error == "" {
+		t.Fatalf("expected an unapplied action with an error, got %+v", report.Retention)
+	}
+	if len(repo.anonymized) != 0 {
+		t.Errorf("expected the account to be left for the next run, got %v", repo.anonymized)
+	}
+}
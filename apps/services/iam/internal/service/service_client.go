@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidClient          = errors.New("invalid client credentials")
+	ErrInvalidServiceScope    = errors.New("invalid service scope")
+	ErrInvalidClientID        = errors.New("client_id is required")
+	ErrServiceClientNotFound  = errors.New("service client not found")
+	ErrServiceClientExists    = errors.New("service client already exists")
+	ErrUnsupportedGrantType   = errors.New("unsupported grant type")
+	ErrServiceTokenNotAllowed = errors.New("scope not granted to client")
+)
+
+// GrantTypeClientCredentials is the only OAuth2 grant the token endpoint serves.
+const GrantTypeClientCredentials = "client_credentials"
+
+type ServiceClientService interface {
+	IssueToken(ctx context.Context, grantType, clientID, clientSecret, scope string) (*servicetoken.TokenResponse, error)
+
+	ListClients(ctx context.Context) ([]dto.ServiceClientResponse, error)
+	CreateClient(ctx context.Context, req *dto.CreateServiceClientRequest) (*dto.ServiceClientSecretResponse, error)
+	UpdateClient(ctx context.Context, id uuid.UUID, req *dto.UpdateServiceClientRequest) (*dto.ServiceClientResponse, error)
+	RotateSecret(ctx context.Context, id uuid.UUID) (*dto.ServiceClientSecretResponse, error)
+	DeleteClient(ctx context.Context, id uuid.UUID) error
+
+	// RegisterConfigured creates or re-syncs the clients declared in configuration.
+	RegisterConfigured(ctx context.Context, clients []config.ServiceClientConfig) error
+}
+
+type serviceClientService struct {
+	clientRepo repository.ServiceClientRepository
+	secretKey  []byte
+	tokenTTL   time.Duration
+}
+
+func NewServiceClientService(
+	clientRepo repository.ServiceClientRepository,
+	secretKey string,
+	tokenExpiryMinutes int64,
+) ServiceClientService {
+	return &serviceClientService{
+		clientRepo: clientRepo,
+		secretKey:  []byte(secretKey),
+		tokenTTL:   time.Duration(tokenExpiryMinutes) * time.Minute,
+	}
+}
+
+// IssueToken implements the OAuth2 client-credentials grant. An empty scope
+// requests every scope the client is registered for; otherwise each requested
+// scope must be registered.
+func (s *serviceClientService) IssueToken(ctx context.Context, grantType, clientID, clientSecret, scope string) (*servicetoken.TokenResponse, error) {
+	if grantType != GrantTypeClientCredentials {
+		return nil, ErrUnsupportedGrantType
+	}
+
+	client, err := s.clientRepo.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("loading service client: %w", err)
+	}
+	if client == nil || !client.IsActive {
+		return nil, ErrInvalidClient
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(clientSecret)); err != nil {
+		return nil, ErrInvalidClient
+	}
+
+	granted := client.ScopeList()
+	if requested := strings.Fields(scope); len(requested) > 0 {
+		for _, sc := range requested {
+			if !containsString(granted, sc) {
+				return nil, ErrServiceTokenNotAllowed
+			}
+		}
+		granted = requested
+	}
+
+	token, _, err := servicetoken.Issue(s.secretKey, client.ClientID, granted, s.tokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clientRepo.TouchLastToken(ctx, client.ID, time.Now()); err != nil {
+		fmt.Printf("warning: failed to record token issue for %s: %v\n", client.ClientID, err)
+	}
+
+	return &servicetoken.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.tokenTTL / time.Second),
+		Scope:       strings.Join(granted, " "),
+	}, nil
+}
+
+func (s *serviceClientService) ListClients(ctx context.Context) ([]dto.ServiceClientResponse, error) {
+	clients, err := s.clientRepo.ListClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ServiceClientResponse, 0, len(clients))
+	for i := range clients {
+		response = append(response, toServiceClientResponse(&clients[i]))
+	}
+	return response, nil
+}
+
+func (s *serviceClientService) CreateClient(ctx context.Context, req *dto.CreateServiceClientRequest) (*dto.ServiceClientSecretResponse, error) {
+	clientID := strings.TrimSpace(req.ClientID)
+	if clientID == "" {
+		return nil, ErrInvalidClientID
+	}
+	scopes, err := normalizeServiceScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.clientRepo.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrServiceClientExists
+	}
+
+	secret, hash, err := generateClientSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &domain.ServiceClient{
+		ID:         uuid.New(),
+		ClientID:   clientID,
+		Name:       req.Name,
+		SecretHash: hash,
+		Scopes:     strings.Join(scopes, " "),
+		IsActive:   true,
+	}
+	if err := s.clientRepo.CreateClient(ctx, client); err != nil {
+		return nil, err
+	}
+
+	return &dto.ServiceClientSecretResponse{
+		ServiceClientResponse: toServiceClientResponse(client),
+		ClientSecret:          secret,
+	}, nil
+}
+
+func (s *serviceClientService) UpdateClient(ctx context.Context, id uuid.UUID, req *dto.UpdateServiceClientRequest) (*dto.ServiceClientResponse, error) {
+	client, err := s.getClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		client.Name = *req.Name
+	}
+	if req.Scopes != nil {
+		scopes, err := normalizeServiceScopes(req.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		client.Scopes = strings.Join(scopes, " ")
+	}
+	if req.IsActive != nil {
+		client.IsActive = *req.IsActive
+	}
+
+	if err := s.clientRepo.UpdateClient(ctx, client); err != nil {
+		return nil, err
+	}
+
+	response := toServiceClientResponse(client)
+	return &response, nil
+}
+
+func (s *serviceClientService) RotateSecret(ctx context.Context, id uuid.UUID) (*dto.ServiceClientSecretResponse, error) {
+	client, err := s.getClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, hash, err := generateClientSecret()
+	if err != nil {
+		return nil, err
+	}
+	client.SecretHash = hash
+
+	if err := s.clientRepo.UpdateClient(ctx, client); err != nil {
+		return nil, err
+	}
+
+	return &dto.ServiceClientSecretResponse{
+		ServiceClientResponse: toServiceClientResponse(client),
+		ClientSecret:          secret,
+	}, nil
+}
+
+func (s *serviceClientService) DeleteClient(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getClient(ctx, id); err != nil {
+		return err
+	}
+	return s.clientRepo.DeleteClient(ctx, id)
+}
+
+func (s *serviceClientService) RegisterConfigured(ctx context.Context, clients []config.ServiceClientConfig) error {
+	for _, cfg := range clients {
+		if cfg.ClientID == "" || cfg.ClientSecret == "" {
+			return fmt.Errorf("service client %q: client_id and client_secret are required", cfg.ClientID)
+		}
+		scopes, err := normalizeServiceScopes(cfg.Scopes)
+		if err != nil {
+			return fmt.Errorf("service client %q: %w", cfg.ClientID, err)
+		}
+
+		client, err := s.clientRepo.GetClientByClientID(ctx, cfg.ClientID)
+		if err != nil {
+			return err
+		}
+
+		if client == nil {
+			hash, err := bcrypt.GenerateFromPassword([]byte(cfg.ClientSecret), bcrypt.DefaultCost)
+			if err != nil {
+				return fmt.Errorf("hashing client secret: %w", err)
+			}
+			client = &domain.ServiceClient{
+				ID:         uuid.New(),
+				ClientID:   cfg.ClientID,
+				Name:       cfg.Name,
+				SecretHash: string(hash),
+				Scopes:     strings.Join(scopes, " "),
+				IsActive:   true,
+			}
+			if err := s.clientRepo.CreateClient(ctx, client); err != nil {
+				return fmt.Errorf("creating service client %q: %w", cfg.ClientID, err)
+			}
+			continue
+		}
+
+		// Configuration is the source of truth for these clients: keep the
+		// scopes and secret in step with it.
+		client.Name = cfg.Name
+		client.Scopes = strings.Join(scopes, " ")
+		if bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(cfg.ClientSecret)) != nil {
+			hash, err := bcrypt.GenerateFromPassword([]byte(cfg.ClientSecret), bcrypt.DefaultCost)
+			if err != nil {
+				return fmt.Errorf("hashing client secret: %w", err)
+			}
+			client.SecretHash = string(hash)
+		}
+		if err := s.clientRepo.UpdateClient(ctx, client); err != nil {
+			return fmt.Errorf("updating service client %q: %w", cfg.ClientID, err)
+		}
+	}
+	return nil
+}
+
+func (s *serviceClientService) getClient(ctx context.Context, id uuid.UUID) (*domain.ServiceClient, error) {
+	client, err := s.clientRepo.GetClientByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrServiceClientNotFound
+	}
+	return client, nil
+}
+
+// normalizeServiceScopes validates scopes against the catalogue and returns
+// them de-duplicated and sorted.
+func normalizeServiceScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, ErrInvalidServiceScope
+	}
+	seen := make(map[string]bool, len(scopes))
+	out := make([]string, 0, len(scopes))
+	for _, sc := range scopes {
+		if !servicetoken.IsValidScope(sc) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidServiceScope, sc)
+		}
+		if !seen[sc] {
+			seen[sc] = true
+			out = append(out, sc)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// generateClientSecret returns a random secret and its bcrypt hash.
+func generateClientSecret() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generating client secret: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", fmt.Errorf("hashing client secret: %w", err)
+	}
+	return secret, string(hash), nil
+}
+
+func toServiceClientResponse(c *domain.ServiceClient) dto.ServiceClientResponse {
+	return dto.ServiceClientResponse{
+		ID:          c.ID,
+		ClientID:    c.ClientID,
+		Name:        c.Name,
+		Scopes:      c.ScopeList(),
+		IsActive:    c.IsActive,
+		LastTokenAt: c.LastTokenAt,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
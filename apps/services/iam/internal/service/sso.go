@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/jwt"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/sso"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSSOProviderNotFound      = errors.New("sso provider not found")
+	ErrSSONoProviderForDomain   = errors.New("no sso provider configured for this email domain")
+	ErrSSOStateInvalid          = errors.New("sso login state is invalid or expired")
+	ErrSSOEmailDomainMismatch   = errors.New("identity email domain is not served by this provider")
+	ErrSSOEmailUnverified       = errors.New("identity provider has not verified the email")
+	ErrSSOAccountNotProvisioned = errors.New("no account exists for this identity")
+)
+
+// Affiliation values that provision an instructor rather than a student when
+// found in the provider's UserTypeClaim.
+var instructorAffiliations = map[string]bool{
+	"instructor": true,
+	"faculty":    true,
+	"staff":      true,
+	"teacher":    true,
+	"employee":   true,
+	"lecturer":   true,
+}
+
+type SSOService interface {
+	ListProviders() []dto.SSOProviderResponse
+	ProviderForEmail(email string) (*dto.SSOProviderResponse, error)
+	BeginLogin(ctx context.Context, providerID, redirectTo string) (*dto.SSOLoginStartResponse, error)
+	CompleteOIDCLogin(ctx context.Context, providerID, state, code string) (*dto.LoginResponse, string, error)
+	CompleteSAMLLogin(ctx context.Context, providerID, samlResponse, relayState string) (*dto.LoginResponse, string, error)
+	SAMLMetadata(providerID string) ([]byte, error)
+}
+
+type ssoService struct {
+	db            *gorm.DB
+	ssoRepo       repository.SSORepository
+	userRepo      repository.UserRepository
+	authService   AuthService
+	providers     map[string]config.SSOProviderConfig
+	oidcProviders map[string]*sso.OIDCProvider
+	samlProviders map[string]*sso.SAMLProvider
+	stateExpiry   time.Duration
+}
+
+func NewSSOService(
+	ctx context.Context,
+	db *gorm.DB,
+	ssoRepo repository.SSORepository,
+	userRepo repository.UserRepository,
+	authService AuthService,
+	cfg config.SSOConfig,
+	httpClient *http.Client,
+) (SSOService, error) {
+	s := &ssoService{
+		db:            db,
+		ssoRepo:       ssoRepo,
+		userRepo:      userRepo,
+		authService:   authService,
+		providers:     make(map[string]config.SSOProviderConfig),
+		oidcProviders: make(map[string]*sso.OIDCProvider),
+		samlProviders: make(map[string]*sso.SAMLProvider),
+		stateExpiry:   time.Duration(cfg.StateExpiry) * time.Minute,
+	}
+
+	for _, p := range cfg.Providers {
+		if p.ID == "" {
+			return nil, fmt.Errorf("sso provider without id")
+		}
+		if _, exists := s.providers[p.ID]; exists {
+			return nil, fmt.Errorf("duplicate sso provider id %q", p.ID)
+		}
+		if p.DefaultUserType != "" && p.DefaultUserType != domain.UserTypeStudent && p.DefaultUserType != domain.UserTypeInstructor {
+			return nil, fmt.Errorf("sso provider %s: default_user_type must be student or instructor", p.ID)
+		}
+
+		switch p.Type {
+		case sso.ProviderTypeOIDC:
+			s.oidcProviders[p.ID] = sso.NewOIDCProvider(p, httpClient)
+		case sso.ProviderTypeSAML:
+			samlProvider, err := sso.NewSAMLProvider(ctx, p, httpClient)
+			if err != nil {
+				return nil, err
+			}
+			s.samlProviders[p.ID] = samlProvider
+		default:
+			return nil, fmt.Errorf("sso provider %s: unsupported type %q", p.ID, p.Type)
+		}
+		s.providers[p.ID] = p
+	}
+
+	return s, nil
+}
+
+func (s *ssoService) ListProviders() []dto.SSOProviderResponse {
+	providers := make([]dto.SSOProviderResponse, 0, len(s.providers))
+	for _, p := range s.providers {
+		providers = append(providers, toSSOProviderResponse(p))
+	}
+	return providers
+}
+
+func (s *ssoService) ProviderForEmail(email string) (*dto.SSOProviderResponse, error) {
+	emailDomain := sso.EmailDomain(email)
+	if emailDomain == "" {
+		return nil, ErrSSONoProviderForDomain
+	}
+
+	for _, p := range s.providers {
+		if servesDomain(p, emailDomain) {
+			resp := toSSOProviderResponse(p)
+			return &resp, nil
+		}
+	}
+
+	return nil, ErrSSONoProviderForDomain
+}
+
+func (s *ssoService) BeginLogin(ctx context.Context, providerID, redirectTo string) (*dto.SSOLoginStartResponse, error) {
+	p, ok := s.providers[providerID]
+	if !ok {
+		return nil, ErrSSOProviderNotFound
+	}
+
+	state, err := sso.RandomToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating state: %w", err)
+	}
+
+	loginState := &domain.SSOLoginState{
+		ID:         uuid.New(),
+		StateHash:  jwt.HashToken(state),
+		Provider:   providerID,
+		RedirectTo: safeRedirectPath(redirectTo),
+		ExpiresAt:  time.Now().Add(s.stateExpiry),
+	}
+
+	var authURL string
+	switch p.Type {
+	case sso.ProviderTypeOIDC:
+		nonce, err := sso.RandomToken()
+		if err != nil {
+			return nil, fmt.Errorf("generating nonce: %w", err)
+		}
+		verifier, err := sso.RandomToken()
+		if err != nil {
+			return nil, fmt.Errorf("generating code verifier: %w", err)
+		}
+		loginState.Nonce = nonce
+		loginState.CodeVerifier = verifier
+
+		authURL, err = s.oidcProviders[providerID].AuthURL(ctx, state, nonce, sso.CodeChallengeS256(verifier))
+		if err != nil {
+			return nil, err
+		}
+	case sso.ProviderTypeSAML:
+		var requestID string
+		authURL, requestID, err = s.samlProviders[providerID].AuthURL(state)
+		if err != nil {
+			return nil, err
+		}
+		loginState.RequestID = requestID
+	}
+
+	if err := s.ssoRepo.DeleteExpiredLoginStates(ctx); err != nil {
+		fmt.Printf("warning: failed to delete expired sso login states: %v\n", err)
+	}
+
+	if err := s.ssoRepo.CreateLoginState(ctx, loginState); err != nil {
+		return nil, fmt.Errorf("storing sso login state: %w", err)
+	}
+
+	return &dto.SSOLoginStartResponse{
+		Provider: providerID,
+		AuthURL:  authURL,
+	}, nil
+}
+
+func (s *ssoService) CompleteOIDCLogin(ctx context.Context, providerID, state, code string) (*dto.LoginResponse, string, error) {
+	oidcProvider, ok := s.oidcProviders[providerID]
+	if !ok {
+		return nil, "", ErrSSOProviderNotFound
+	}
+
+	loginState, err := s.consumeState(ctx, providerID, state)
+	if err != nil {
+		return nil, "", err
+	}
+
+	identity, err := oidcProvider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, "", err
+	}
+
+	response, err := s.login(ctx, s.providers[providerID], identity)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response, loginState.RedirectTo, nil
+}
+
+func (s *ssoService) CompleteSAMLLogin(ctx context.Context, providerID, samlResponse, relayState string) (*dto.LoginResponse, string, error) {
+	samlProvider, ok := s.samlProviders[providerID]
+	if !ok {
+		return nil, "", ErrSSOProviderNotFound
+	}
+
+	// IdP-initiated logins carry no RelayState we issued and are rejected.
+	loginState, err := s.consumeState(ctx, providerID, relayState)
+	if err != nil {
+		return nil, "", err
+	}
+
+	identity, err := samlProvider.ParseResponse(samlResponse, []string{loginState.RequestID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	response, err := s.login(ctx, s.providers[providerID], identity)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response, loginState.RedirectTo, nil
+}
+
+func (s *ssoService) SAMLMetadata(providerID string) ([]byte, error) {
+	samlProvider, ok := s.samlProviders[providerID]
+	if !ok {
+		return nil, ErrSSOProviderNotFound
+	}
+	return samlProvider.Metadata()
+}
+
+func (s *ssoService) consumeState(ctx context.Context, providerID, state string) (*domain.SSOLoginState, error) {
+	if state == "" {
+		return nil, ErrSSOStateInvalid
+	}
+
+	loginState, err := s.ssoRepo.ConsumeLoginState(ctx, jwt.HashToken(state))
+	if err != nil {
+		return nil, fmt.Errorf("fetching sso login state: %w", err)
+	}
+	if loginState == nil || loginState.Provider != providerID {
+		return nil, ErrSSOStateInvalid
+	}
+
+	return loginState, nil
+}
+
+// login maps the external identity to a user and issues a session for it.
+func (s *ssoService) login(ctx context.Context, p config.SSOProviderConfig, identity *sso.ExternalIdentity) (*dto.LoginResponse, error) {
+	user, identityID, err := s.resolveUser(ctx, p, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ssoRepo.TouchIdentity(ctx, identityID); err != nil {
+		fmt.Printf("warning: failed to update sso identity last login: %v\n", err)
+	}
+
+	return s.authService.LoginWithUser(ctx, user)
+}
+
+// resolveUser finds the user for an external identity: first through an
+// existing identity link, then by email (linking the identity), and finally
+// by provisioning a new user when the provider allows it.
+func (s *ssoService) resolveUser(ctx context.Context, p config.SSOProviderConfig, identity *sso.ExternalIdentity) (*domain.User, uuid.UUID, error) {
+	// 1. Previously linked identity
+	link, err := s.ssoRepo.GetIdentity(ctx, p.ID, identity.Subject)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("fetching sso identity: %w", err)
+	}
+	if link != nil {
+		user, err := s.userRepo.GetUserByID(ctx, link.UserID)
+		if err != nil {
+			return nil, uuid.Nil, fmt.Errorf("fetching user: %w", err)
+		}
+		if user == nil {
+			return nil, uuid.Nil, ErrUserNotFound
+		}
+		return user, link.ID, nil
+	}
+
+	// 2. Existing user with the same email. Only addresses in the provider's
+	//    domains are trusted, so another IdP cannot claim a local account.
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+	if email == "" {
+		return nil, uuid.Nil, sso.ErrMissingEmail
+	}
+	if !servesDomain(p, sso.EmailDomain(email)) {
+		return nil, uuid.Nil, ErrSSOEmailDomainMismatch
+	}
+
+	newLink := &domain.UserIdentity{
+		ID:       uuid.New(),
+		Provider: p.ID,
+		Subject:  identity.Subject,
+		Email:    email,
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("fetching user: %w", err)
+	}
+	if user != nil {
+		// Linking hands the local account to the external identity, so the
+		// provider must vouch for the address; a missing claim does not.
+		if !identity.EmailVerified {
+			return nil, uuid.Nil, ErrSSOEmailUnverified
+		}
+		newLink.UserID = user.ID
+		if err := s.ssoRepo.CreateIdentity(ctx, newLink); err != nil {
+			return nil, uuid.Nil, fmt.Errorf("linking sso identity: %w", err)
+		}
+		return user, newLink.ID, nil
+	}
+
+	// 3. Just-in-time provisioning
+	if !p.JITProvisioning {
+		return nil, uuid.Nil, ErrSSOAccountNotProvisioned
+	}
+
+	user, err = s.provisionUser(ctx, p, identity, email, newLink)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	return user, newLink.ID, nil
+}
+
+func (s *ssoService) provisionUser(
+	ctx context.Context,
+	p config.SSOProviderConfig,
+	identity *sso.ExternalIdentity,
+	email string,
+	link *domain.UserIdentity,
+) (*domain.User, error) {
+	now := time.Now()
+	fullName := identity.FullName
+	if fullName == "" {
+		fullName = email[:strings.LastIndex(email, "@")]
+	}
+
+	user := &domain.User{
+		ID:            uuid.New(),
+		Email:         email,
+		FullName:      fullName,
+		UserType:      jitUserType(p, identity),
+		IsActive:      true,
+		EmailVerified: identity.EmailVerified,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	link.UserID = user.ID
+
+	err := repository.WithTxContext(ctx, s.db, func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return fmt.Errorf("creating user: %w", err)
+		}
+
+		switch user.UserType {
+		case domain.UserTypeStudent:
+			studentID := firstNonEmpty(identity.Attribute("student_id"), identity.Attribute("studentId"), identity.Attribute("employeeNumber"))
+			if studentID == "" {
+				studentID = strings.ToUpper(email[:strings.LastIndex(email, "@")])
+			}
+			if err := tx.Create(&domain.UserProfileStudent{UserID: user.ID, StudentID: studentID}).Error; err != nil {
+				return fmt.Errorf("creating student profile: %w", err)
+			}
+		case domain.UserTypeInstructor:
+			designation := firstNonEmpty(identity.Attribute("title"), identity.Attribute("designation"), "Instructor")
+			if err := tx.Create(&domain.UserProfileInstructor{UserID: user.ID, Designation: designation}).Error; err != nil {
+				return fmt.Errorf("creating instructor profile: %w", err)
+			}
+		}
+
+		if err := tx.Create(link).Error; err != nil {
+			return fmt.Errorf("linking sso identity: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// jitUserType picks the user type for a provisioned user. Admins are never
+// provisioned; the affiliation claim can only choose student or instructor.
+func jitUserType(p config.SSOProviderConfig, identity *sso.ExternalIdentity) string {
+	if p.UserTypeClaim != "" {
+		for _, value := range identity.Attributes[p.UserTypeClaim] {
+			affiliation := strings.ToLower(value)
+			// eduPersonScopedAffiliation values look like "faculty@example.edu"
+			if at := strings.Index(affiliation, "@"); at >= 0 {
+				affiliation = affiliation[:at]
+			}
+			if instructorAffiliations[affiliation] {
+				return domain.UserTypeInstructor
+			}
+			if affiliation == domain.UserTypeStudent {
+				return domain.UserTypeStudent
+			}
+		}
+	}
+
+	if p.DefaultUserType == domain.UserTypeInstructor {
+		return domain.UserTypeInstructor
+	}
+	return domain.UserTypeStudent
+}
+
+func servesDomain(p config.SSOProviderConfig, emailDomain string) bool {
+	for _, d := range p.Domains {
+		if strings.EqualFold(d, emailDomain) {
+			return true
+		}
+	}
+	return false
+}
+
+// safeRedirectPath only allows same-origin relative paths so the login flow
+// cannot be abused as an open redirect.
+func safeRedirectPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return "/"
+	}
+	return path
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func toSSOProviderResponse(p config.SSOProviderConfig) dto.SSOProviderResponse {
+	name := p.DisplayName
+	if name == "" {
+		name = p.ID
+	}
+	return dto.SSOProviderResponse{
+		ID:          p.ID,
+		Type:        p.Type,
+		DisplayName: name,
+		Domains:     p.Domains,
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/repository"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/sso"
+	"github.com/google/uuid"
+)
+
+// fakeSSORepository keeps identity links in memory. Methods the tests do not
+// reach panic through the nil embedded interface.
+type fakeSSORepository struct {
+	repository.SSORepository
+	links []*domain.UserIdentity
+}
+
+func (r *fakeSSORepository) GetIdentity(_ context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	for _, l := range r.links {
+		if l.Provider == provider && l.Subject == subject {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSSORepository) CreateIdentity(_ context.Context, identity *domain.UserIdentity) error {
+	r.links = append(r.links, identity)
+	return nil
+}
+
+// fakeUserRepository looks users up by email.
+type fakeUserRepository struct {
+	repository.UserRepository
+	users []*domain.User
+}
+
+func (r *fakeUserRepository) GetUserByEmail(_ context.Context, email string) (*domain.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestResolveUser_LinksExistingAccountOnlyWithVerifiedEmail(t *testing.T) {
+	provider := config.SSOProviderConfig{ID: "uni", Domains: []string{"uni.example"}}
+	existing := &domain.User{ID: uuid.New(), Email: "jane@uni.example"}
+
+	tests := []struct {
+		name     string
+		verified bool
+		wantErr  error
+	}{
+		{"unverified", false, ErrSSOEmailUnverified},
+		{"verified", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ssoRepo := &fakeSSORepository{}
+			s := &ssoService{
+				ssoRepo:  ssoRepo,
+				userRepo: &fakeUserRepository{users: []*domain.User{existing}},
+			}
+
+			user, _, err := s.resolveUser(context.Background(), provider, &sso.ExternalIdentity{
+				Provider:      "uni",
+				Subject:       "u-123",
+				Email:         "Jane@uni.example",
+				EmailVerified: tt.verified,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				if len(ssoRepo.links) != 0 {
+					t.Errorf("expected no identity link, got %d", len(ssoRepo.links))
+				}
+				return
+			}
+			if user == nil || user.ID != existing.ID {
+				t.Fatalf("expected the existing user, got %+v", user)
+			}
+			if len(ssoRepo.links) != 1 || ssoRepo.links[0].UserID != existing.ID {
+				t.Errorf("expected one link to the existing user, got %+v", ssoRepo.links)
+			}
+		})
+	}
+}
@@ -22,6 +22,10 @@ var (
 
 type UserService interface {
 	CreateUser(ctx context.Context, req *dto.CreateUserRequest, actorUserType string) (*dto.CreateUserResponse, error)
+	// ProvisionUser creates an inactive account with its profile and a
+	// password setup link, without sending any email.
+	ProvisionUser(ctx context.Context, req *dto.CreateUserRequest) (*domain.User, string, time.Time, error)
+	SendSetupEmail(ctx context.Context, user *domain.User, resetLink string) error
 	GetUsers(ctx context.Context, page, limit int, userType string, search string) (*dto.GetUsersResponse, error)
 	UpdateUser(ctx context.Context, id string, req *dto.UpdateUserRequest) (*dto.UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, id string) error
@@ -70,18 +74,43 @@ func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest
 		return nil, ErrUnauthorized
 	}
 
+	user, resetLink, expiresAt, err := s.ProvisionUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send setup email
+	if s.emailClient != nil {
+		if err := s.SendSetupEmail(ctx, user, resetLink); err != nil {
+			// Log the error but don't fail the user creation
+			fmt.Printf("Warning: Failed to send setup email to %s: %v\n", user.Email, err)
+		}
+	}
+
+	return &dto.CreateUserResponse{
+		ID:        user.ID,
+		FullName:  user.FullName,
+		Email:     user.Email,
+		UserType:  user.UserType,
+		IsActive:  user.IsActive,
+		ResetLink: resetLink,
+		Message:   fmt.Sprintf("User created successfully. A setup email has been sent to %s. The link expires at %s", user.Email, expiresAt.Format(time.RFC3339)),
+	}, nil
+}
+
+func (s *userService) ProvisionUser(ctx context.Context, req *dto.CreateUserRequest) (*domain.User, string, time.Time, error) {
 	// Validate user type
 	if !domain.IsValidUserType(req.UserType) {
-		return nil, ErrInvalidUserType
+		return nil, "", time.Time{}, ErrInvalidUserType
 	}
 
 	// Check if email already exists
 	existingUser, err := s.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("checking email: %w", err)
+		return nil, "", time.Time{}, fmt.Errorf("checking email: %w", err)
 	}
 	if existingUser != nil {
-		return nil, ErrEmailTaken
+		return nil, "", time.Time{}, ErrEmailTaken
 	}
 
 	// User initial password will be set via password reset link flow
@@ -108,14 +137,14 @@ func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest
 
 	if err := tx.Create(user).Error; err != nil {
 		tx.Rollback()
-		return nil, fmt.Errorf("creating user: %w", err)
+		return nil, "", time.Time{}, fmt.Errorf("creating user: %w", err)
 	}
 
 	// Create profile based on type
 	if req.UserType == "student" {
 		if req.StudentID == "" {
 			tx.Rollback()
-			return nil, errors.New("student_id is required for student type")
+			return nil, "", time.Time{}, errors.New("student_id is required for student type")
 		}
 		profile := &domain.UserProfileStudent{
 			UserID:    user.ID,
@@ -123,12 +152,12 @@ func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest
 		}
 		if err := tx.Create(profile).Error; err != nil {
 			tx.Rollback()
-			return nil, fmt.Errorf("creating student profile: %w", err)
+			return nil, "", time.Time{}, fmt.Errorf("creating student profile: %w", err)
 		}
 	} else if req.UserType == "instructor" {
 		if req.Designation == "" {
 			tx.Rollback()
-			return nil, errors.New("designation is required for instructor type")
+			return nil, "", time.Time{}, errors.New("designation is required for instructor type")
 		}
 		profile := &domain.UserProfileInstructor{
 			UserID:      user.ID,
@@ -136,18 +165,18 @@ func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest
 		}
 		if err := tx.Create(profile).Error; err != nil {
 			tx.Rollback()
-			return nil, fmt.Errorf("creating employee profile: %w", err)
+			return nil, "", time.Time{}, fmt.Errorf("creating employee profile: %w", err)
 		}
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		return nil, fmt.Errorf("committing transaction: %w", err)
+		return nil, "", time.Time{}, fmt.Errorf("committing transaction: %w", err)
 	}
 
 	// Generate reset token
 	resetToken, err := GenerateResetToken()
 	if err != nil {
-		return nil, fmt.Errorf("generating password reset token: %w", err)
+		return nil, "", time.Time{}, fmt.Errorf("generating password reset token: %w", err)
 	}
 
 	// Hash the token for DB
@@ -163,29 +192,21 @@ func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest
 	}
 
 	if err := s.authRepo.CreatePasswordResetToken(ctx, resetTokenEntity); err != nil {
-		return nil, fmt.Errorf("storing password reset token: %w", err)
+		return nil, "", time.Time{}, fmt.Errorf("storing password reset token: %w", err)
 	}
 
 	// Create password reset link
 	resetLink := fmt.Sprintf("%s/reset-password?token=%s", s.frontendURL, resetToken)
 
-	// Send setup email
-	if s.emailClient != nil {
-		if err := s.emailClient.SendPasswordResetEmail(ctx, user.Email, user.FullName, resetLink); err != nil {
-			// Log the error but don't fail the user creation
-			fmt.Printf("Warning: Failed to send setup email to %s: %v\n", user.Email, err)
-		}
-	}
+	return user, resetLink, expiresAt, nil
+}
 
-	return &dto.CreateUserResponse{
-		ID:        user.ID,
-		FullName:  user.FullName,
-		Email:     user.Email,
-		UserType:  user.UserType,
-		IsActive:  user.IsActive,
-		ResetLink: resetLink,
-		Message:   fmt.Sprintf("User created successfully. A setup email has been sent to %s. The link expires at %s", user.Email, expiresAt.Format(time.RFC3339)),
-	}, nil
+// SendSetupEmail emails the user their password setup link.
+func (s *userService) SendSetupEmail(ctx context.Context, user *domain.User, resetLink string) error {
+	if s.emailClient == nil {
+		return errors.New("email client not configured")
+	}
+	return s.emailClient.SendPasswordResetEmail(ctx, user.Email, user.FullName, resetLink)
 }
 
 func (s *userService) GetUsers(ctx context.Context, page, limit int, userType string, search string) (*dto.GetUsersResponse, error) {
@@ -248,8 +269,15 @@ func (s *userService) UpdateUser(ctx context.Context, id string, req *dto.Update
 		user.UserType = *req.UserType
 	}
 
-	if req.IsActive != nil {
+	if req.IsActive != nil && *req.IsActive != user.IsActive {
 		user.IsActive = *req.IsActive
+		if user.IsActive {
+			user.DeactivatedAt = nil
+			user.DeactivationReason = ""
+		} else {
+			now := time.Now()
+			user.DeactivatedAt = &now
+		}
 	}
 
 	if err := s.userRepo.UpdateUser(ctx, user); err != nil {
@@ -412,6 +440,11 @@ func buildUserResponse(user *domain.User, profile *domain.ProfileData) dto.UserR
 		CreatedAt:  user.CreatedAt.Format(time.RFC3339),
 	}
 
+	if user.LastLoginAt != nil {
+		lastLoginAt := user.LastLoginAt.Format(time.RFC3339)
+		response.LastLoginAt = &lastLoginAt
+	}
+
 	if profile != nil {
 		response.StudentID = profile.StudentID
 		response.Designation = profile.Designation
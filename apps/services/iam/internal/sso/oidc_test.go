@@ -0,0 +1,378 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockOIDCProvider is a minimal OpenID provider: discovery, JWKS, and a token
+// endpoint that enforces PKCE for codes registered through authorize.
+type mockOIDCProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+
+	mu    sync.Mutex
+	codes map[string]mockAuthorization
+}
+
+type mockAuthorization struct {
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	m := &mockOIDCProvider{t: t, key: key, kid: "key-1", codes: map[string]mockAuthorization{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"use": "sig",
+				"kid": m.kid,
+				"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parsing token form: %v", err)
+		}
+
+		m.mu.Lock()
+		auth, ok := m.codes[r.PostForm.Get("code")]
+		delete(m.codes, r.PostForm.Get("code"))
+		m.mu.Unlock()
+
+		if !ok || CodeChallengeS256(r.PostForm.Get("code_verifier")) != auth.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		idToken := m.sign(auth.claims)
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "opaque",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// authorize simulates the user approving the login and returns the code the
+// provider would send to the redirect URI.
+func (m *mockOIDCProvider) authorize(authURL string, claims jwt.MapClaims) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("parsing auth url: %v", err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		m.t.Fatalf("expected S256 code challenge, got %q", q.Get("code_challenge_method"))
+	}
+
+	full := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   q.Get("client_id"),
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": q.Get("nonce"),
+	}
+	for k, v := range claims {
+		full[k] = v
+	}
+
+	code := "code-" + q.Get("state")
+	m.mu.Lock()
+	m.codes[code] = mockAuthorization{challenge: q.Get("code_challenge"), claims: full}
+	m.mu.Unlock()
+	return code
+}
+
+func (m *mockOIDCProvider) sign(claims jwt.MapClaims) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = m.kid
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		m.t.Fatalf("signing id token: %v", err)
+	}
+	return signed
+}
+
+func (m *mockOIDCProvider) providerConfig() config.SSOProviderConfig {
+	return config.SSOProviderConfig{
+		ID:          "uni",
+		Type:        ProviderTypeOIDC,
+		Domains:     []string{"uni.example"},
+		Issuer:      m.server.URL,
+		ClientID:    "gradeloop",
+		RedirectURL: "http://localhost:8081/api/v1/auth/sso/uni/callback",
+	}
+}
+
+func TestOIDCProvider_LoginFlow(t *testing.T) {
+	idp := newMockOIDCProvider(t)
+	provider := NewOIDCProvider(idp.providerConfig(), nil)
+	ctx := context.Background()
+
+	verifier, _ := RandomToken()
+	authURL, err := provider.AuthURL(ctx, "state-1", "nonce-1", CodeChallengeS256(verifier))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	code := idp.authorize(authURL, jwt.MapClaims{
+		"sub":            "u-123",
+		"email":          "jane@uni.example",
+		"email_verified": true,
+		"name":           "Jane Doe",
+		"affiliation":    []string{"student"},
+	})
+
+	identity, err := provider.Exchange(ctx, code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if identity.Subject != "u-123" {
+		t.Errorf("expected subject u-123, got %s", identity.Subject)
+	}
+	if identity.Email != "jane@uni.example" || !identity.EmailVerified {
+		t.Errorf("unexpected email %q (verified=%v)", identity.Email, identity.EmailVerified)
+	}
+	if identity.FullName != "Jane Doe" {
+		t.Errorf("expected name Jane Doe, got %s", identity.FullName)
+	}
+	if identity.Attribute("affiliation") != "student" {
+		t.Errorf("expected affiliation claim to be kept, got %v", identity.Attributes["affiliation"])
+	}
+}
+
+func TestOIDCProvider_RejectsWrongCodeVerifier(t *testing.T) {
+	idp := newMockOIDCProvider(t)
+	provider := NewOIDCProvider(idp.providerConfig(), nil)
+	ctx := context.Background()
+
+	verifier, _ := RandomToken()
+	authURL, err := provider.AuthURL(ctx, "state-1", "nonce-1", CodeChallengeS256(verifier))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code := idp.authorize(authURL, jwt.MapClaims{"sub": "u-123"})
+
+	_, err = provider.Exchange(ctx, code, "not-the-verifier", "nonce-1")
+	if !errors.Is(err, ErrTokenExchange) {
+		t.Fatalf("expected ErrTokenExchange, got %v", err)
+	}
+}
+
+func TestOIDCProvider_VerifyIDToken(t *testing.T) {
+	idp := newMockOIDCProvider(t)
+	provider := NewOIDCProvider(idp.providerConfig(), nil)
+	ctx := context.Background()
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   idp.server.URL,
+			"aud":   "gradeloop",
+			"sub":   "u-123",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(5 * time.Minute).Unix(),
+			"nonce": "nonce-1",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		nonce  string
+	}{
+		{"nonce mismatch", func(c jwt.MapClaims) {}, "other-nonce"},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "someone-else" }, "nonce-1"},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }, "nonce-1"},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, "nonce-1"},
+		{"missing subject", func(c jwt.MapClaims) { delete(c, "sub") }, "nonce-1"},
+		{"foreign azp", func(c jwt.MapClaims) {
+			c["aud"] = []string{"gradeloop", "other"}
+			c["azp"] = "other"
+		}, "nonce-1"},
+	}
+
+	if _, err := provider.VerifyIDToken(ctx, idp.sign(valid()), "nonce-1"); err != nil {
+		t.Fatalf("expected valid token to verify, got %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.mutate(claims)
+
+			_, err := provider.VerifyIDToken(ctx, idp.sign(claims), tt.nonce)
+			if !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("expected ErrInvalidIDToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOIDCProvider_EmailVerifiedClaim(t *testing.T) {
+	idp := newMockOIDCProvider(t)
+	provider := NewOIDCProvider(idp.providerConfig(), nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		claim any
+		want  bool
+	}{
+		{"missing", nil, false},
+		{"true", true, true},
+		{"false", false, false},
+		{"string true", "true", true},
+		{"string false", "false", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{
+				"iss":   idp.server.URL,
+				"aud":   "gradeloop",
+				"sub":   "u-123",
+				"email": "jane@uni.example",
+				"iat":   time.Now().Unix(),
+				"exp":   time.Now().Add(5 * time.Minute).Unix(),
+				"nonce": "nonce-1",
+			}
+			if tt.claim != nil {
+				claims["email_verified"] = tt.claim
+			}
+
+			identity, err := provider.VerifyIDToken(ctx, idp.sign(claims), "nonce-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity.EmailVerified != tt.want {
+				t.Errorf("expected EmailVerified=%v, got %v", tt.want, identity.EmailVerified)
+			}
+		})
+	}
+}
+
+func TestOIDCProvider_RejectsUntrustedSignature(t *testing.T) {
+	idp := newMockOIDCProvider(t)
+	provider := NewOIDCProvider(idp.providerConfig(), nil)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   idp.server.URL,
+		"aud":   "gradeloop",
+		"sub":   "u-123",
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": "nonce-1",
+	})
+	token.Header["kid"] = "key-1"
+	forged, _ := token.SignedString(otherKey)
+
+	_, err = provider.VerifyIDToken(context.Background(), forged, "nonce-1")
+	if !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("expected ErrInvalidIDToken, got %v", err)
+	}
+}
+
+func TestOIDCProvider_RefetchesKeysOnRotation(t *testing.T) {
+	idp := newMockOIDCProvider(t)
+	provider := NewOIDCProvider(idp.providerConfig(), nil)
+	ctx := context.Background()
+
+	claims := jwt.MapClaims{
+		"iss":   idp.server.URL,
+		"aud":   "gradeloop",
+		"sub":   "u-123",
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": "nonce-1",
+	}
+	if _, err := provider.VerifyIDToken(ctx, idp.sign(claims), "nonce-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Rotate the provider's signing key.
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	idp.mu.Lock()
+	idp.key = newKey
+	idp.kid = "key-2"
+	idp.mu.Unlock()
+
+	if _, err := provider.VerifyIDToken(ctx, idp.sign(claims), "nonce-1"); err != nil {
+		t.Fatalf("expected rotated key to be fetched, got %v", err)
+	}
+}
+
+func TestOIDCProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	idp := newMockOIDCProvider(t)
+	cfg := idp.providerConfig()
+
+	// A discovery document that advertises an issuer other than the one
+	// configured must not be trusted.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 "https://elsewhere.example",
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	}))
+	defer proxy.Close()
+	cfg.Issuer = proxy.URL
+
+	provider := NewOIDCProvider(cfg, nil)
+	_, err := provider.AuthURL(context.Background(), "s", "n", "c")
+	if !errors.Is(err, ErrDiscoveryFailed) {
+		t.Fatalf("expected ErrDiscoveryFailed, got %v", err)
+	}
+}
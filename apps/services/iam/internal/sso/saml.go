@@ -0,0 +1,193 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+)
+
+// Well-known SAML attribute names used to populate ExternalIdentity.
+var (
+	samlEmailAttributes = []string{
+		"email", "mail", "emailAddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+	}
+	samlNameAttributes = []string{
+		"displayName", "cn", "name",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+		"urn:oid:2.5.4.3",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name",
+	}
+)
+
+// SAMLProvider is a SAML 2.0 service provider bound to one identity provider.
+// Requests use the HTTP-Redirect binding and responses are expected on the
+// HTTP-POST binding at the configured ACS URL.
+type SAMLProvider struct {
+	cfg config.SSOProviderConfig
+	sp  *saml.ServiceProvider
+}
+
+// NewSAMLProvider loads the SP key pair (optional) and the IdP metadata, either
+// inline from the config or fetched from IDPMetadataURL.
+func NewSAMLProvider(ctx context.Context, cfg config.SSOProviderConfig, httpClient *http.Client) (*SAMLProvider, error) {
+	acsURL, err := url.Parse(cfg.ACSURL)
+	if err != nil || cfg.ACSURL == "" {
+		return nil, fmt.Errorf("saml provider %s: invalid acs_url", cfg.ID)
+	}
+
+	entityID := cfg.EntityID
+	if entityID == "" {
+		entityID = strings.TrimSuffix(cfg.ACSURL, "/acs") + "/metadata"
+	}
+	metadataURL, err := url.Parse(entityID)
+	if err != nil {
+		return nil, fmt.Errorf("saml provider %s: invalid entity_id: %w", cfg.ID, err)
+	}
+
+	var idpMetadata *saml.EntityDescriptor
+	switch {
+	case cfg.IDPMetadataXML != "":
+		idpMetadata, err = samlsp.ParseMetadata([]byte(cfg.IDPMetadataXML))
+	case cfg.IDPMetadataURL != "":
+		var idpURL *url.URL
+		idpURL, err = url.Parse(cfg.IDPMetadataURL)
+		if err == nil {
+			if httpClient == nil {
+				httpClient = http.DefaultClient
+			}
+			idpMetadata, err = samlsp.FetchMetadata(ctx, httpClient, *idpURL)
+		}
+	default:
+		err = fmt.Errorf("idp_metadata_xml or idp_metadata_url is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("saml provider %s: loading idp metadata: %w", cfg.ID, err)
+	}
+
+	sp := &saml.ServiceProvider{
+		EntityID:          entityID,
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		IDPMetadata:       idpMetadata,
+		AuthnNameIDFormat: saml.PersistentNameIDFormat,
+		HTTPClient:        httpClient,
+	}
+
+	if cfg.SPCertFile != "" && cfg.SPKeyFile != "" {
+		keyPair, err := tls.LoadX509KeyPair(cfg.SPCertFile, cfg.SPKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("saml provider %s: loading sp key pair: %w", cfg.ID, err)
+		}
+		cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("saml provider %s: parsing sp certificate: %w", cfg.ID, err)
+		}
+		signer, ok := keyPair.PrivateKey.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("saml provider %s: sp key cannot sign", cfg.ID)
+		}
+		sp.Key = signer
+		sp.Certificate = cert
+	}
+
+	return &SAMLProvider{cfg: cfg, sp: sp}, nil
+}
+
+// Metadata returns the SP metadata document to register with the IdP.
+func (p *SAMLProvider) Metadata() ([]byte, error) {
+	data, err := xml.MarshalIndent(p.sp.Metadata(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// AuthURL builds the HTTP-Redirect binding URL for a new AuthnRequest and
+// returns it together with the request ID the response must answer.
+func (p *SAMLProvider) AuthURL(relayState string) (string, string, error) {
+	req, err := p.sp.MakeAuthenticationRequest(
+		p.sp.GetSSOBindingLocation(saml.HTTPRedirectBinding),
+		saml.HTTPRedirectBinding,
+		saml.HTTPPostBinding,
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("creating authn request: %w", err)
+	}
+
+	redirectURL, err := req.Redirect(relayState, p.sp)
+	if err != nil {
+		return "", "", fmt.Errorf("encoding authn request: %w", err)
+	}
+
+	return redirectURL.String(), req.ID, nil
+}
+
+// ParseResponse validates a base64 encoded SAMLResponse posted to the ACS,
+// including its signature, audience, validity window and InResponseTo.
+func (p *SAMLProvider) ParseResponse(samlResponse string, requestIDs []string) (*ExternalIdentity, error) {
+	decoded, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSAMLResponse, err)
+	}
+
+	assertion, err := p.sp.ParseXMLResponse(decoded, requestIDs, p.sp.AcsURL)
+	if err != nil {
+		if invalid, ok := err.(*saml.InvalidResponseError); ok {
+			err = invalid.PrivateErr
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSAMLResponse, err)
+	}
+
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return nil, fmt.Errorf("%w: assertion has no subject", ErrInvalidSAMLResponse)
+	}
+
+	identity := &ExternalIdentity{
+		Provider:   p.cfg.ID,
+		Subject:    assertion.Subject.NameID.Value,
+		Attributes: map[string][]string{},
+	}
+
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			for _, value := range attr.Values {
+				identity.Attributes[attr.Name] = append(identity.Attributes[attr.Name], value.Value)
+				if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+					identity.Attributes[attr.FriendlyName] = append(identity.Attributes[attr.FriendlyName], value.Value)
+				}
+			}
+		}
+	}
+
+	identity.Email = firstAttribute(identity, samlEmailAttributes)
+	if identity.Email == "" && assertion.Subject.NameID.Format == string(saml.EmailAddressNameIDFormat) {
+		identity.Email = assertion.Subject.NameID.Value
+	}
+	// A signed assertion from a configured IdP vouches for the address.
+	identity.EmailVerified = identity.Email != ""
+	identity.FullName = firstAttribute(identity, samlNameAttributes)
+
+	return identity, nil
+}
+
+func firstAttribute(identity *ExternalIdentity, names []string) string {
+	for _, name := range names {
+		if v := identity.Attribute(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,221 @@
+package sso
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/apps/services/iam/internal/config"
+	"github.com/beevik/etree"
+	"github.com/crewjam/saml"
+)
+
+// mockSAMLIdP wraps a crewjam IdentityProvider that trusts a single SP.
+type mockSAMLIdP struct {
+	t   *testing.T
+	idp *saml.IdentityProvider
+	sp  *saml.EntityDescriptor
+}
+
+func (m *mockSAMLIdP) GetServiceProvider(r *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	return m.sp, nil
+}
+
+func newMockSAMLIdP(t *testing.T) *mockSAMLIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mock-idp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	m := &mockSAMLIdP{t: t}
+	m.idp = &saml.IdentityProvider{
+		Key:                     key,
+		Certificate:             cert,
+		MetadataURL:             url.URL{Scheme: "https", Host: "idp.example", Path: "/metadata"},
+		SSOURL:                  url.URL{Scheme: "https", Host: "idp.example", Path: "/sso"},
+		ServiceProviderProvider: m,
+	}
+	return m
+}
+
+func (m *mockSAMLIdP) metadataXML() string {
+	data, err := xml.Marshal(m.idp.Metadata())
+	if err != nil {
+		m.t.Fatalf("marshalling idp metadata: %v", err)
+	}
+	return string(data)
+}
+
+// respond plays the IdP side of an HTTP-Redirect AuthnRequest and returns the
+// base64 SAMLResponse it would POST back to the ACS.
+func (m *mockSAMLIdP) respond(authURL string, session *saml.Session) string {
+	httpReq, err := http.NewRequest(http.MethodGet, authURL, nil)
+	if err != nil {
+		m.t.Fatalf("building request: %v", err)
+	}
+
+	req, err := saml.NewIdpAuthnRequest(m.idp, httpReq)
+	if err != nil {
+		m.t.Fatalf("reading authn request: %v", err)
+	}
+	if err := req.Validate(); err != nil {
+		m.t.Fatalf("validating authn request: %v", err)
+	}
+	if err := (saml.DefaultAssertionMaker{}).MakeAssertion(req, session); err != nil {
+		m.t.Fatalf("making assertion: %v", err)
+	}
+	if err := req.MakeResponse(); err != nil {
+		m.t.Fatalf("making response: %v", err)
+	}
+
+	doc := etree.NewDocument()
+	doc.SetRoot(req.ResponseEl)
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		m.t.Fatalf("serialising response: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func newTestSAMLProvider(t *testing.T, idp *mockSAMLIdP) *SAMLProvider {
+	t.Helper()
+
+	provider, err := NewSAMLProvider(context.Background(), config.SSOProviderConfig{
+		ID:             "staff",
+		Type:           ProviderTypeSAML,
+		Domains:        []string{"uni.example"},
+		IDPMetadataXML: idp.metadataXML(),
+		EntityID:       "https://iam.example/api/v1/auth/sso/staff/metadata",
+		ACSURL:         "https://iam.example/api/v1/auth/sso/staff/acs",
+	}, nil)
+	if err != nil {
+		t.Fatalf("creating saml provider: %v", err)
+	}
+	idp.sp = provider.sp.Metadata()
+	return provider
+}
+
+func TestSAMLProvider_LoginFlow(t *testing.T) {
+	idp := newMockSAMLIdP(t)
+	provider := newTestSAMLProvider(t, idp)
+
+	authURL, requestID, err := provider.AuthURL("relay-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(authURL, "https://idp.example/sso?") {
+		t.Errorf("expected redirect to idp sso url, got %s", authURL)
+	}
+
+	samlResponse := idp.respond(authURL, &saml.Session{
+		ID:             "session-1",
+		CreateTime:     time.Now(),
+		ExpireTime:     time.Now().Add(time.Hour),
+		Index:          "1",
+		NameID:         "staff-42",
+		UserEmail:      "prof@uni.example",
+		UserCommonName: "Prof Smith",
+		Groups:         []string{"faculty"},
+	})
+
+	identity, err := provider.ParseResponse(samlResponse, []string{requestID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if identity.Provider != "staff" || identity.Subject != "staff-42" {
+		t.Errorf("unexpected identity %s/%s", identity.Provider, identity.Subject)
+	}
+	if identity.Email != "prof@uni.example" {
+		t.Errorf("expected email prof@uni.example, got %s", identity.Email)
+	}
+	if identity.FullName != "Prof Smith" {
+		t.Errorf("expected name Prof Smith, got %s", identity.FullName)
+	}
+	if identity.Attribute("eduPersonAffiliation") != "faculty" {
+		t.Errorf("expected affiliation faculty, got %v", identity.Attributes["eduPersonAffiliation"])
+	}
+}
+
+func TestSAMLProvider_RejectsUnknownRequestID(t *testing.T) {
+	idp := newMockSAMLIdP(t)
+	provider := newTestSAMLProvider(t, idp)
+
+	authURL, _, err := provider.AuthURL("relay-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	samlResponse := idp.respond(authURL, &saml.Session{NameID: "staff-42", UserEmail: "prof@uni.example"})
+
+	_, err = provider.ParseResponse(samlResponse, []string{"id-some-other-request"})
+	if !errors.Is(err, ErrInvalidSAMLResponse) {
+		t.Fatalf("expected ErrInvalidSAMLResponse, got %v", err)
+	}
+}
+
+func TestSAMLProvider_RejectsUntrustedIdP(t *testing.T) {
+	trusted := newMockSAMLIdP(t)
+	provider := newTestSAMLProvider(t, trusted)
+
+	// A second IdP with its own key answers the request.
+	rogue := newMockSAMLIdP(t)
+	rogue.sp = trusted.sp
+
+	authURL, requestID, err := provider.AuthURL("relay-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	samlResponse := rogue.respond(authURL, &saml.Session{NameID: "staff-42", UserEmail: "prof@uni.example"})
+
+	_, err = provider.ParseResponse(samlResponse, []string{requestID})
+	if !errors.Is(err, ErrInvalidSAMLResponse) {
+		t.Fatalf("expected ErrInvalidSAMLResponse, got %v", err)
+	}
+}
+
+func TestSAMLProvider_Metadata(t *testing.T) {
+	idp := newMockSAMLIdP(t)
+	provider := newTestSAMLProvider(t, idp)
+
+	metadata, err := provider.Metadata()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`entityID="https://iam.example/api/v1/auth/sso/staff/metadata"`,
+		`Location="https://iam.example/api/v1/auth/sso/staff/acs"`,
+	} {
+		if !strings.Contains(string(metadata), want) {
+			t.Errorf("expected metadata to contain %s", want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.uber.org/zap"
+)
+
+// ExportStorage keeps personal data export archives in a private MinIO
+// bucket. Unlike the avatar bucket it has no public policy; archives are only
+// served through the authenticated download endpoints.
+type ExportStorage struct {
+	client     *minio.Client
+	bucketName string
+	logger     *zap.Logger
+}
+
+// NewExportStorage creates an ExportStorage instance, connects to MinIO, and
+// ensures the export bucket exists before returning.
+func NewExportStorage(
+	endpoint, accessKey, secretKey, bucketName string,
+	useSSL bool,
+	logger *zap.Logger,
+) (*ExportStorage, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating minio client: %w", err)
+	}
+
+	s := &ExportStorage{
+		client:     client,
+		bucketName: bucketName,
+		logger:     logger,
+	}
+
+	ctx := context.Background()
+	exists, err := client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return nil, fmt.Errorf("checking bucket %q existence: %w", bucketName, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("creating bucket %q: %w", bucketName, err)
+		}
+		logger.Info("minio bucket created", zap.String("bucket", bucketName))
+	}
+
+	return s, nil
+}
+
+// PutExport stores an export archive under objectName.
+func (s *ExportStorage) PutExport(ctx context.Context, objectName string, data []byte) error {
+	_, err := s.client.PutObject(
+		ctx,
+		s.bucketName,
+		objectName,
+		bytes.NewReader(data),
+		int64(len(data)),
+		minio.PutObjectOptions{ContentType: "application/zip"},
+	)
+	if err != nil {
+		return fmt.Errorf("uploading export to minio: %w", err)
+	}
+	return nil
+}
+
+// GetExport opens an export archive for reading and returns its size. The
+// caller must close the reader.
+func (s *ExportStorage) GetExport(ctx context.Context, objectName string) (io.ReadCloser, int64, error) {
+	obj, err := s.client.GetObject(ctx, s.bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("opening export: %w", err)
+	}
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, 0, fmt.Errorf("reading export: %w", err)
+	}
+	return obj, info.Size, nil
+}
+
+// DeleteExport removes an export archive. Deleting a missing archive succeeds.
+func (s *ExportStorage) DeleteExport(ctx context.Context, objectName string) error {
+	if err := s.client.RemoveObject(ctx, s.bucketName, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("deleting export: %w", err)
+	}
+	return nil
+}
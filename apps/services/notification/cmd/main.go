@@ -20,6 +20,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/service"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/sse"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"go.uber.org/zap"
@@ -135,6 +136,7 @@ func run() error {
 		NotificationHandler: notificationHandler,
 		SSEHandler:          sseHandler,
 		JWTSecretKey:        []byte(cfg.JWT.SecretKey),
+		TokenVerifier:       pat.NewIAMVerifier(cfg.IAMServiceURL, 30*time.Second),
 	})
 
 	sigChan := make(chan os.Signal, 1)
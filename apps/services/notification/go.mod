@@ -4,8 +4,11 @@ go 1.25.0
 
 require (
 	github.com/4yrg/gradeloop-core-v2/packages/go/notifier v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/openapi v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/pat v0.0.0-00010101000000-000000000000
+	github.com/4yrg/gradeloop-core-v2/packages/go/queryspec v0.0.0-00010101000000-000000000000
 	github.com/gofiber/fiber/v3 v3.0.0-rc.1
-	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 	github.com/rabbitmq/amqp091-go v1.10.0
@@ -18,6 +21,7 @@ require (
 
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken v0.0.0-00010101000000-000000000000
 	github.com/andybalholm/brotli v1.2.0 // indirect
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
@@ -47,3 +51,11 @@ require (
 )
 
 replace github.com/4yrg/gradeloop-core-v2/packages/go/notifier => ../../../packages/go/notifier
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/pat => ../../../packages/go/pat
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken => ../../../packages/go/servicetoken
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/openapi => ../../../packages/go/openapi
+
+replace github.com/4yrg/gradeloop-core-v2/packages/go/queryspec => ../../../packages/go/queryspec
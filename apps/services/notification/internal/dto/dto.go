@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
+)
 
 type CreateNotificationRequest struct {
 	UserIDs []string       `json:"user_ids" validate:"required,min=1"`
@@ -28,9 +32,20 @@ type UnreadCountResponse struct {
 
 type ListNotificationsResponse struct {
 	Notifications []NotificationResponse `json:"notifications"`
-	Total         int64                  `json:"total"`
-	Page          int                    `json:"page"`
-	PerPage       int                    `json:"per_page"`
+	queryspec.Meta
+}
+
+// ListNotificationsSchema declares the filters, sorts and paging of
+// GET /notifications, newest first by default.
+var ListNotificationsSchema = queryspec.Schema{
+	Fields: map[string]queryspec.Field{
+		"type":       {Kind: queryspec.String, Filter: true},
+		"read":       {Kind: queryspec.Bool, Filter: true},
+		"created_at": {Kind: queryspec.Time, Filter: true, Sort: true},
+	},
+	Sort:         "-created_at",
+	DefaultLimit: 20,
+	MaxLimit:     100,
 }
 
 type MarkReadRequest struct {
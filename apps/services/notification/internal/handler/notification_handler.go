@@ -2,7 +2,6 @@ package handler
 
 import (
 	"encoding/json"
-	"strconv"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/domain"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/dto"
@@ -28,37 +27,25 @@ func (h *NotificationHandler) List(c fiber.Ctx) error {
 		return err
 	}
 
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	perPage, _ := strconv.Atoi(c.Query("per_page", "20"))
-	if page < 1 {
-		page = 1
-	}
-	if perPage < 1 || perPage > 100 {
-		perPage = 20
-	}
-
-	var readFilter *bool
-	if v := c.Query("read"); v != "" {
-		b := v == "true"
-		readFilter = &b
+	spec, err := dto.ListNotificationsSchema.Parse(c.Queries())
+	if err != nil {
+		return utils.ErrBadRequest(err.Error())
 	}
 
-	notifications, total, err := h.service.ListByUserID(c, userID, readFilter, page, perPage)
+	page, err := h.service.ListByUserID(c, userID, spec)
 	if err != nil {
 		h.logger.Error("listing notifications", zap.Error(err))
 		return utils.ErrInternal("failed to list notifications", err)
 	}
 
-	items := make([]dto.NotificationResponse, len(notifications))
-	for i, n := range notifications {
+	items := make([]dto.NotificationResponse, len(page.Items))
+	for i, n := range page.Items {
 		items[i] = toNotificationResponse(n)
 	}
 
 	return c.JSON(dto.ListNotificationsResponse{
 		Notifications: items,
-		Total:         total,
-		Page:          page,
-		PerPage:       perPage,
+		Meta:          page.Meta(),
 	})
 }
 
@@ -129,6 +116,52 @@ func (h *NotificationHandler) Delete(c fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// ExportUserData handles GET /internal/users/:id/data. IAM calls it to build
+// a personal data export; callers need the notification.user_data:read scope.
+func (h *NotificationHandler) ExportUserData(c fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrBadRequest("invalid user id")
+	}
+
+	notifications, err := h.service.ListAllByUserID(c, userID)
+	if err != nil {
+		h.logger.Error("exporting notifications", zap.Error(err))
+		return utils.ErrInternal("failed to export notifications", err)
+	}
+
+	items := make([]dto.NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		items[i] = toNotificationResponse(n)
+	}
+
+	return c.JSON(fiber.Map{
+		"user_id":       userID,
+		"notifications": items,
+	})
+}
+
+// EraseUserData handles DELETE /internal/users/:id. IAM calls it for erasure
+// requests and account purges; callers need the notification.user_data:erase
+// scope.
+func (h *NotificationHandler) EraseUserData(c fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ErrBadRequest("invalid user id")
+	}
+
+	deleted, err := h.service.EraseUserData(c, userID)
+	if err != nil {
+		h.logger.Error("erasing notifications", zap.Error(err))
+		return utils.ErrInternal("failed to erase notifications", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"user_id": userID,
+		"erased":  fiber.Map{"notifications": deleted},
+	})
+}
+
 func requireUserID(c fiber.Ctx) (uuid.UUID, error) {
 	userIDStr, ok := c.Locals("user_id").(string)
 	if !ok || userIDStr == "" {
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
 	"github.com/gofiber/fiber/v3"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -15,7 +17,7 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func AuthMiddleware(secretKey []byte) fiber.Handler {
+func AuthMiddleware(secretKey []byte, tokens pat.Verifier) fiber.Handler {
 	return func(c fiber.Ctx) error {
 		tokenString := ""
 
@@ -35,6 +37,25 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 			return utils.ErrUnauthorized("missing authorization header or token")
 		}
 
+		// Personal access tokens are opaque; IAM resolves them to a user.
+		if pat.IsToken(tokenString) {
+			identity, err := tokens.Verify(c.RequestCtx(), tokenString)
+			if err != nil {
+				if errors.Is(err, pat.ErrInvalidToken) {
+					return utils.ErrUnauthorized("invalid token")
+				}
+				return utils.NewAppError(fiber.StatusServiceUnavailable, "token verification unavailable", err)
+			}
+
+			c.Locals("user_id", identity.UserID)
+			c.Locals("username", identity.Email)
+			c.Locals("user_type", identity.UserType)
+			c.Locals(pat.LocalsTokenID, identity.TokenID)
+			c.Locals(pat.LocalsTokenScopes, identity.Scopes)
+
+			return c.Next()
+		}
+
 		token, err := jwt.ParseWithClaims(
 			tokenString,
 			&Claims{},
@@ -54,6 +75,11 @@ func AuthMiddleware(secretKey []byte) fiber.Handler {
 			return utils.ErrUnauthorized("invalid token claims")
 		}
 
+		// Service tokens share the signing key but carry no user.
+		if claims.UserID == "" {
+			return utils.ErrUnauthorized("invalid token claims")
+		}
+
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Email)
 		c.Locals("user_type", claims.UserType)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/domain"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -33,25 +34,8 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 	return &n, nil
 }
 
-func (r *NotificationRepository) ListByUserID(ctx context.Context, userID uuid.UUID, read *bool, page, perPage int) ([]domain.Notification, int64, error) {
-	var notifications []domain.Notification
-	var total int64
-
-	query := r.DB.WithContext(ctx).Where("user_id = ?", userID)
-	if read != nil {
-		query = query.Where("read = ?", *read)
-	}
-
-	if err := query.Model(&domain.Notification{}).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * perPage
-	if err := query.Order("created_at DESC").Offset(offset).Limit(perPage).Find(&notifications).Error; err != nil {
-		return nil, 0, err
-	}
-
-	return notifications, total, nil
+func (r *NotificationRepository) ListByUserID(ctx context.Context, userID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Notification], error) {
+	return queryspec.Find[domain.Notification](r.DB.WithContext(ctx).Where("user_id = ?", userID), spec)
 }
 
 func (r *NotificationRepository) CountUnreadByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
@@ -79,3 +63,20 @@ func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userID uuid.
 func (r *NotificationRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
 	return r.DB.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&domain.Notification{}).Error
 }
+
+// ListAllByUserID returns every notification of a user, oldest first.
+func (r *NotificationRepository) ListAllByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	err := r.DB.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// DeleteAllByUserID removes every notification of a user and returns how
+// many were deleted.
+func (r *NotificationRepository) DeleteAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	res := r.DB.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.Notification{})
+	return res.RowsAffected, res.Error
+}
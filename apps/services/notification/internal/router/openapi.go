@@ -0,0 +1,127 @@
+package router
+
+import (
+	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/dto"
+	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/utils"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// OpenAPI returns the notification service's OpenAPI document, served at
+// /openapi.json and enforced on requests by SetupRoutes. Every route
+// SetupRoutes registers must be listed in routes: TestOpenAPIMatchesRoutes
+// fails the build when the two disagree.
+func OpenAPI() *openapi.Document {
+	return openapi.Spec{
+		Info: openapi.Info{
+			Title:       "GradeLoop Notification Service",
+			Description: "In-app notifications and their live stream.",
+			Version:     "1.0.0",
+		},
+		Error:  utils.AppError{},
+		Routes: routes,
+	}.MustBuild()
+}
+
+var routes = []openapi.Route{
+
+	// service
+	{
+		Method: fiber.MethodGet, Path: "/", Name: "getServiceInfo",
+		Summary: "Service information", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"service": "", "version": "", "status": ""},
+		},
+	},
+
+	// debug
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/debug/auth", Name: "debugAuth",
+		Summary: "Show the caller's identity", Tag: "debug",
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": "", "username": "", "user_type": "", "authenticated": false},
+		},
+	},
+
+	// internal
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/internal/users/:id", Name: "eraseUserData",
+		Summary: "Erase user data", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": uuid.UUID{}, "erased": openapi.Fields{"notifications": 0}},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/internal/users/:id/data", Name: "exportUserData",
+		Summary: "Export user data", Tag: "internal", Auth: openapi.AuthService,
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			200: openapi.Fields{"user_id": uuid.UUID{}, "notifications": []dto.NotificationResponse{}},
+		},
+	},
+
+	// notifications
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/notifications/", Name: "listNotifications",
+		Summary: "List notifications", Tag: "notifications",
+		Query: dto.ListNotificationsSchema.Params(),
+		Responses: openapi.Responses{
+			200: dto.ListNotificationsResponse{},
+		},
+	},
+	{
+		Method: fiber.MethodDelete, Path: "/api/v1/notifications/:id", Name: "deleteNotification",
+		Summary: "Delete notification", Tag: "notifications",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/notifications/:id/read", Name: "markAsRead",
+		Summary: "Mark as read", Tag: "notifications",
+		Params: openapi.Params{"id": openapi.UUID},
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodPatch, Path: "/api/v1/notifications/read-all", Name: "markAllAsRead",
+		Summary: "Mark all as read", Tag: "notifications",
+		Responses: openapi.Responses{
+			204: nil,
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/notifications/stream", Name: "streamNotifications",
+		Summary: "Stream notifications as server-sent events", Tag: "notifications",
+		Responses: openapi.Responses{
+			200: openapi.Binary{"text/event-stream"},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/api/v1/notifications/unread-count", Name: "getUnreadCount",
+		Summary: "Get unread count", Tag: "notifications",
+		Responses: openapi.Responses{
+			200: dto.UnreadCountResponse{},
+		},
+	},
+
+	// health
+	{
+		Method: fiber.MethodGet, Path: "/health", Name: "health",
+		Summary: "Health", Tag: "health", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Fields{"status": "", "service": ""},
+		},
+	},
+	{
+		Method: fiber.MethodGet, Path: "/openapi.json", Name: "getOpenAPI",
+		Summary: "OpenAPI document", Tag: "service", Auth: openapi.AuthPublic,
+		Responses: openapi.Responses{
+			200: openapi.Any,
+		},
+	},
+}
@@ -3,6 +3,9 @@ package router
 import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/handler"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/middleware"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/pat"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/servicetoken"
 	"github.com/gofiber/fiber/v3"
 )
 
@@ -11,9 +14,17 @@ type Config struct {
 	NotificationHandler *handler.NotificationHandler
 	SSEHandler          *handler.SSEHandler
 	JWTSecretKey        []byte
+	// TokenVerifier resolves personal access tokens with IAM.
+	TokenVerifier pat.Verifier
 }
 
 func SetupRoutes(app *fiber.App, cfg Config) {
+	// Requests are checked against the OpenAPI document after their
+	// credentials; see openapi.go.
+	doc := OpenAPI()
+	validate := openapi.Validate(doc)
+	app.Get("/openapi.json", openapi.Serve(doc))
+
 	cfg.HealthHandler.RegisterRoutes(app)
 
 	app.Get("/", func(c fiber.Ctx) error {
@@ -26,7 +37,20 @@ func SetupRoutes(app *fiber.App, cfg Config) {
 
 	api := app.Group("/api/v1")
 
-	protected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecretKey))
+	// Internal routes for IAM, authenticated by service token alone.
+	// NOTE: Must be registered BEFORE the protected group below, whose user
+	// AuthMiddleware is mounted on the whole /api/v1 prefix.
+	internal := api.Group("/internal")
+	internal.Get("/users/:id/data",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeNotificationUserRead),
+		validate,
+		cfg.NotificationHandler.ExportUserData)
+	internal.Delete("/users/:id",
+		servicetoken.RequireScopes(cfg.JWTSecretKey, servicetoken.ScopeNotificationUserErase),
+		validate,
+		cfg.NotificationHandler.EraseUserData)
+
+	protected := api.Group("", middleware.AuthMiddleware(cfg.JWTSecretKey, cfg.TokenVerifier), validate)
 
 	protected.Get("/debug/auth", func(c fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -9,6 +9,7 @@ import (
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/repository"
 	"github.com/4yrg/gradeloop-core-v2/apps/services/notification/internal/sse"
 	notifier "github.com/4yrg/gradeloop-core-v2/packages/go/notifier"
+	"github.com/4yrg/gradeloop-core-v2/packages/go/queryspec"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/datatypes"
@@ -107,8 +108,8 @@ func (s *NotificationService) ProcessIncoming(ctx context.Context, n notifier.No
 	return nil
 }
 
-func (s *NotificationService) ListByUserID(ctx context.Context, userID uuid.UUID, read *bool, page, perPage int) ([]domain.Notification, int64, error) {
-	return s.repo.ListByUserID(ctx, userID, read, page, perPage)
+func (s *NotificationService) ListByUserID(ctx context.Context, userID uuid.UUID, spec queryspec.Spec) (queryspec.Page[domain.Notification], error) {
+	return s.repo.ListByUserID(ctx, userID, spec)
 }
 
 func (s *NotificationService) CountUnreadByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
@@ -127,6 +128,25 @@ func (s *NotificationService) Delete(ctx context.Context, id, userID uuid.UUID)
 	return s.repo.Delete(ctx, id, userID)
 }
 
+// ListAllByUserID returns every notification of a user for a personal data
+// export.
+func (s *NotificationService) ListAllByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Notification, error) {
+	return s.repo.ListAllByUserID(ctx, userID)
+}
+
+// EraseUserData deletes every notification of a user.
+func (s *NotificationService) EraseUserData(ctx context.Context, userID uuid.UUID) (int64, error) {
+	deleted, err := s.repo.DeleteAllByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	s.logger.Info("erased user notifications",
+		zap.String("user_id", userID.String()),
+		zap.Int64("deleted", deleted),
+	)
+	return deleted, nil
+}
+
 func toResponse(n domain.Notification) map[string]any {
 	var data map[string]any
 	if len(n.Data) > 0 {
@@ -50,9 +50,7 @@ export default function BehaviorAnalyticsPage({ params }: PageProps) {
         async function load() {
             try {
                 // 1. Resolve user_id from submission
-                const submissions = await instructorAssessmentsApi.listSubmissions(assignmentId);
-                const found = submissions.find((s: SubmissionResponse) => s.id === submissionId) ?? null;
-                if (!found) throw new Error("Submission not found.");
+                const found = await instructorAssessmentsApi.getSubmission(submissionId);
                 if (!cancelled) setSubmission(found);
 
                 const userId = found.user_id;
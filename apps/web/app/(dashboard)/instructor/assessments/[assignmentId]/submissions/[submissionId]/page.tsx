@@ -100,22 +100,7 @@ export default function SubmissionReviewPage({ params }: PageProps) {
                     fetchStudent(sub.user_id);
                 }
             })
-            .catch(() => {
-                // Fallback: search through the list
-                instructorAssessmentsApi
-                    .listSubmissions(assignmentId)
-                    .then((resp: SubmissionResponse[]) => {
-                        const found = resp.find((s: SubmissionResponse) => s.id === submissionId);
-                        if (found) {
-                            setSubmission(found);
-                            if (found.user_id) {
-                                fetchArchive(found.user_id);
-                                fetchStudent(found.user_id);
-                            }
-                        }
-                    })
-                    .catch(() => {/* non-critical */});
-            });
+            .catch(() => {/* non-critical */});
     }, [assignmentId, submissionId]);
 
     // Poll for grade with exponential back-off
@@ -44,9 +44,7 @@ export default function SessionPlaybackPage({ params }: PageProps) {
         async function load() {
             try {
                 // Step 1: Resolve submission → user_id
-                const submissions = await instructorAssessmentsApi.listSubmissions(assignmentId);
-                const found = submissions.find((s: SubmissionResponse) => s.id === submissionId) ?? null;
-                if (!found) throw new Error("Submission not found.");
+                const found = await instructorAssessmentsApi.getSubmission(submissionId);
                 if (!cancelled) setSubmission(found);
 
                 const userId = found.user_id;
@@ -5,7 +5,7 @@ import { useParams } from "next/navigation";
 import { githubApi, type GitHubVersion } from "@/lib/api/github";
 import { assessmentsApi, instructorAssessmentsApi } from "@/lib/api/assessments";
 import { usersApi } from "@/lib/api/users";
-import type { AssignmentResponse } from "@/types/assessments.types";
+import type { AssignmentResponse, SubmissionResponse } from "@/types/assessments.types";
 import type { UserListItem } from "@/types/auth.types";
 import { GitBranch, GitCommit, Clock, CheckCircle, XCircle, Loader2, ArrowLeft, ExternalLink, User } from "lucide-react";
 import { Button } from "@/components/ui/button";
@@ -28,11 +28,38 @@ export default function GitHubVersionsPage() {
     const assignmentId = params.assignmentId as string;
 
     const [assignment, setAssignment] = React.useState<AssignmentResponse | null>(null);
-    const [studentVersions, setStudentVersions] = React.useState<StudentVersion[]>([]);
     const [isLoading, setIsLoading] = React.useState(true);
     const [error, setError] = React.useState<string | null>(null);
     const [selectedStudent, setSelectedStudent] = React.useState<string | null>(null);
 
+    // Submissions are read a page at a time; nextCursor is null once the last
+    // page has been loaded.
+    const [submissions, setSubmissions] = React.useState<SubmissionResponse[]>([]);
+    const [studentNames, setStudentNames] = React.useState<Map<string, string>>(new Map());
+    const [nextCursor, setNextCursor] = React.useState<string | null>(null);
+    const [isLoadingMore, setIsLoadingMore] = React.useState(false);
+
+    // Fetch one page of submissions and the names of students not seen yet.
+    const fetchPage = async (cursor: string | null, known: Map<string, string>) => {
+        const page = await instructorAssessmentsApi.listSubmissions(assignmentId, cursor);
+
+        const userIds = [...new Set(page.items.map(s => s.user_id).filter(Boolean) as string[])]
+            .filter(uid => !known.has(uid));
+        const names = new Map(known);
+
+        await Promise.all(
+            userIds.map(async (uid) => {
+                try {
+                    const user: UserListItem = await usersApi.get(uid);
+                    names.set(uid, user.full_name);
+                } catch {
+                    // ignore
+                }
+            })
+        );
+        return { page, names };
+    };
+
     const loadData = async () => {
         try {
             setIsLoading(true);
@@ -46,58 +73,10 @@ export default function GitHubVersionsPage() {
                 return;
             }
 
-            const subs = await instructorAssessmentsApi.listSubmissions(assignmentId);
-            
-            const userIds = [...new Set(subs.map(s => s.user_id).filter(Boolean) as string[])];
-            const userMap = new Map<string, UserListItem>();
-            
-            await Promise.all(
-                userIds.map(async (uid) => {
-                    try {
-                        const user = await usersApi.get(uid);
-                        userMap.set(uid, user);
-                    } catch {
-                        // ignore
-                    }
-                })
-            );
-
-            const groupedByUser: Map<string, GitHubVersion[]> = new Map();
-            
-            for (const sub of subs) {
-                if (sub.user_id && sub.commit_sha) {
-                    if (!groupedByUser.has(sub.user_id)) {
-                        groupedByUser.set(sub.user_id, []);
-                    }
-                    groupedByUser.get(sub.user_id)?.push({
-                        id: sub.id,
-                        github_repo_id: "",
-                        assignment_id: sub.assignment_id,
-                        user_id: sub.user_id,
-                        version: sub.version,
-                        commit_sha: sub.commit_sha,
-                        commit_message: "",
-                        tag_name: "",
-                        grade: undefined,
-                        graded_at: undefined,
-                        grading_status: sub.status,
-                        grading_error: undefined,
-                        submitted_at: sub.submitted_at,
-                    });
-                }
-            }
-
-            const studentVersionsData: StudentVersion[] = [];
-            groupedByUser.forEach((versions, userId) => {
-                const user = userMap.get(userId);
-                studentVersionsData.push({
-                    userId,
-                    studentName: user?.full_name || `Student ${userId.slice(0, 8)}`,
-                    versions: versions.sort((a, b) => b.version - a.version),
-                });
-            });
-
-            setStudentVersions(studentVersionsData);
+            const { page, names } = await fetchPage(null, new Map());
+            setSubmissions(page.items);
+            setStudentNames(names);
+            setNextCursor(page.nextCursor);
         } catch (err) {
             console.error("Failed to load data:", err);
             setError("Failed to load submission data");
@@ -106,10 +85,62 @@ export default function GitHubVersionsPage() {
         }
     };
 
+    const loadMore = async () => {
+        if (!nextCursor || isLoadingMore) return;
+        setIsLoadingMore(true);
+        try {
+            const { page, names } = await fetchPage(nextCursor, studentNames);
+            setSubmissions(prev => [...prev, ...page.items]);
+            setStudentNames(names);
+            setNextCursor(page.nextCursor);
+        } catch (err) {
+            console.error("Failed to load more versions:", err);
+        } finally {
+            setIsLoadingMore(false);
+        }
+    };
+
     React.useEffect(() => {
         loadData();
     }, [assignmentId]);
 
+    const studentVersions = React.useMemo(() => {
+        const groupedByUser: Map<string, GitHubVersion[]> = new Map();
+
+        for (const sub of submissions) {
+            if (sub.user_id && sub.commit_sha) {
+                if (!groupedByUser.has(sub.user_id)) {
+                    groupedByUser.set(sub.user_id, []);
+                }
+                groupedByUser.get(sub.user_id)?.push({
+                    id: sub.id,
+                    github_repo_id: "",
+                    assignment_id: sub.assignment_id,
+                    user_id: sub.user_id,
+                    version: sub.version,
+                    commit_sha: sub.commit_sha,
+                    commit_message: "",
+                    tag_name: "",
+                    grade: undefined,
+                    graded_at: undefined,
+                    grading_status: sub.status,
+                    grading_error: undefined,
+                    submitted_at: sub.submitted_at,
+                });
+            }
+        }
+
+        const studentVersionsData: StudentVersion[] = [];
+        groupedByUser.forEach((versions, userId) => {
+            studentVersionsData.push({
+                userId,
+                studentName: studentNames.get(userId) || `Student ${userId.slice(0, 8)}`,
+                versions: versions.sort((a, b) => b.version - a.version),
+            });
+        });
+        return studentVersionsData;
+    }, [submissions, studentNames]);
+
     const getGradingStatusBadge = (status: string) => {
         switch (status?.toLowerCase()) {
             case "accepted":
@@ -315,6 +346,16 @@ export default function GitHubVersionsPage() {
                     ))}
                 </TabsContent>
             </Tabs>
+
+            {/* Further versions are fetched on demand */}
+            {nextCursor && (
+                <div className="flex justify-center mt-6">
+                    <Button variant="outline" onClick={loadMore} disabled={isLoadingMore}>
+                        {isLoadingMore && <Loader2 className="h-4 w-4 mr-2 animate-spin" />}
+                        Load more versions
+                    </Button>
+                </div>
+            )}
         </div>
     );
 }
\ No newline at end of file
@@ -124,8 +124,8 @@ export default function SimilarityOverviewPage() {
       try {
         setIsAnalyzingSubmissions(true);
 
-        // Fetch all submissions for this assignment
-        const submissions = await instructorAssessmentsApi.listSubmissions(assignmentId);
+        // Fetch every student's latest submission for this assignment
+        const submissions = await instructorAssessmentsApi.listLatestSubmissions(assignmentId);
 
         // Filter out submissions that have already been analyzed
         const unanalyzedSubmissions = submissions.filter(
@@ -241,8 +241,8 @@ export default function SimilarityOverviewPage() {
       setIsRunning(true);
       setError(null);
 
-      // Fetch all submissions for this assignment
-      const submissions = await instructorAssessmentsApi.listSubmissions(assignmentId);
+      // Fetch every student's latest submission for this assignment
+      const submissions = await instructorAssessmentsApi.listLatestSubmissions(assignmentId);
 
       if (submissions.length < 2) {
         setError("At least 2 submissions are required for similarity analysis");
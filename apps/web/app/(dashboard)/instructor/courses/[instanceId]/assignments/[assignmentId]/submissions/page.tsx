@@ -56,6 +56,46 @@ export default function AssignmentSubmissionsPage({
     const [isAnalyzingSheet, setIsAnalyzingSheet] = React.useState(false);
     const [sheetArchive, setSheetArchive] = React.useState<ArchiveLookupResult | null>(null);
 
+    // Submissions are read a page at a time; nextCursor is null once the last
+    // page has been loaded.
+    const [total, setTotal] = React.useState(0);
+    const [nextCursor, setNextCursor] = React.useState<string | null>(null);
+    const [isLoadingMore, setIsLoadingMore] = React.useState(false);
+
+    // Fetch one page and attach the students' names.
+    const fetchPage = React.useCallback(
+        async (cursor: string | null, offset: number) => {
+            const page = await instructorAssessmentsApi.listSubmissions(assignmentId, cursor);
+
+            // Batch-fetch user profiles for all unique user IDs in parallel
+            const uniqueUserIds = [
+                ...new Set(page.items.map((s) => s.user_id).filter(Boolean) as string[]),
+            ];
+            const userMap = new Map<string, UserListItem>();
+            await Promise.allSettled(
+                uniqueUserIds.map(async (uid) => {
+                    try {
+                        const user = await usersApi.get(uid);
+                        userMap.set(uid, user);
+                    } catch {
+                        // user not found — fall back to placeholder below
+                    }
+                })
+            );
+
+            const enriched: SubmissionWithMeta[] = page.items.map((s, i) => {
+                const user = s.user_id ? userMap.get(s.user_id) : undefined;
+                return {
+                    ...s,
+                    studentName: user?.full_name ?? `Student ${offset + i + 1}`,
+                    studentId: user?.student_id,
+                };
+            });
+            return { ...page, items: enriched };
+        },
+        [assignmentId]
+    );
+
     React.useEffect(() => {
         let mounted = true;
 
@@ -63,34 +103,12 @@ export default function AssignmentSubmissionsPage({
             try {
                 setIsLoading(true);
                 setError(null);
-                const subs = await instructorAssessmentsApi.listSubmissions(assignmentId);
-
-                // Batch-fetch user profiles for all unique user IDs in parallel
-                const uniqueUserIds = [
-                    ...new Set(subs.map((s) => s.user_id).filter(Boolean) as string[]),
-                ];
-                const userMap = new Map<string, UserListItem>();
-                await Promise.allSettled(
-                    uniqueUserIds.map(async (uid) => {
-                        try {
-                            const user = await usersApi.get(uid);
-                            userMap.set(uid, user);
-                        } catch {
-                            // user not found — fall back to placeholder below
-                        }
-                    })
-                );
-
-                const enriched: SubmissionWithMeta[] = subs.map((s, i) => {
-                    const user = s.user_id ? userMap.get(s.user_id) : undefined;
-                    return {
-                        ...s,
-                        studentName: user?.full_name ?? `Student ${i + 1}`,
-                        studentId: user?.student_id,
-                    };
-                });
-
-                if (mounted) setSubmissions(enriched);
+                const page = await fetchPage(null, 0);
+                if (mounted) {
+                    setSubmissions(page.items);
+                    setTotal(page.total);
+                    setNextCursor(page.nextCursor);
+                }
             } catch (err) {
                 console.error(err);
                 if (mounted) setError("Failed to load submissions. Please try again.");
@@ -103,7 +121,23 @@ export default function AssignmentSubmissionsPage({
         return () => {
             mounted = false;
         };
-    }, [assignmentId]);
+    }, [fetchPage]);
+
+    const loadMore = async () => {
+        if (!nextCursor || isLoadingMore) return;
+        setIsLoadingMore(true);
+        try {
+            const page = await fetchPage(nextCursor, submissions.length);
+            setSubmissions((prev) => [...prev, ...page.items]);
+            setTotal(page.total);
+            setNextCursor(page.nextCursor);
+        } catch (err) {
+            console.error(err);
+            toast.error("Failed to load more submissions.");
+        } finally {
+            setIsLoadingMore(false);
+        }
+    };
 
     // Load actual submission code when the grading sheet opens
     React.useEffect(() => {
@@ -410,6 +444,16 @@ export default function AssignmentSubmissionsPage({
                 />
             )}
 
+            {/* Further pages are fetched on demand */}
+            {!isLoading && !error && nextCursor && (
+                <div className="flex justify-center">
+                    <Button variant="outline" onClick={loadMore} disabled={isLoadingMore}>
+                        {isLoadingMore && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                        Load more ({submissions.length} of {total})
+                    </Button>
+                </div>
+            )}
+
             {/* Grading Review Sheet */}
             <SideSheetForm
                 open={selectedSubmission !== null}
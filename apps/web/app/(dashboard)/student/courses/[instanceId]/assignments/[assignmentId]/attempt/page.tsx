@@ -19,7 +19,6 @@ import { studentAssessmentsApi, acafsApi } from "@/lib/api/assessments";
 import {
     detectAICode,
     getSemanticSimilarity,
-    saveSubmissionAnalysis,
 } from "@/lib/api/cipas-client";
 import type { AssignmentResponse, SubmissionGrade } from "@/types/assessments.types";
 import { handleApiError } from "@/lib/api/axios";
@@ -230,7 +229,9 @@ export default function StudentAttemptPage() {
     // Clear topbar title when leaving this page
     React.useEffect(() => () => { setPageTitle(null); }, [setPageTitle]);
 
-    const runCIPASAnalysis = React.useCallback(async (code: string, submissionId: string) => {
+    // The scores are only shown to the student; course staff store their own
+    // analysis from the instructor views.
+    const runCIPASAnalysis = React.useCallback(async (code: string) => {
         try {
             setIsAnalyzing(true);
             const ai = await detectAICode(code);
@@ -238,13 +239,6 @@ export default function StudentAttemptPage() {
                 aiLikelihood: ai.ai_likelihood,
                 humanLikelihood: ai.human_likelihood,
             });
-            await saveSubmissionAnalysis(submissionId, {
-                ai_likelihood: ai.ai_likelihood,
-                human_likelihood: ai.human_likelihood,
-                is_ai_generated: ai.is_ai_generated,
-                ai_confidence: ai.confidence,
-                semantic_similarity_score: null,
-            });
         } catch (e) {
             console.error("CIPAS analysis failed:", e);
         } finally {
@@ -267,7 +261,7 @@ export default function StudentAttemptPage() {
             setSubmittedVersion(submission.version);
             setGradedSubmissionId(submission.id);
             toast.success(`Submitted successfully! Version ${submission.version}`);
-            runCIPASAnalysis(code, submission.id);
+            runCIPASAnalysis(code);
         } catch (err) {
             const msg = handleApiError(err);
             setError(msg);
@@ -32,7 +32,7 @@ import { usersApi, handleApiError } from '@/lib/api/users';
 import { toast } from '@/lib/hooks/use-toast';
 import type {
     BulkImportPreviewResponse,
-    BulkImportExecuteResponse,
+    BulkImportJob,
 } from '@/types/admin.types';
 
 interface Props {
@@ -43,22 +43,55 @@ interface Props {
 
 type Step = 'upload' | 'preview' | 'result';
 
+const JOB_POLL_INTERVAL_MS = 2000;
+
+function isJobActive(job: BulkImportJob) {
+    return job.status === 'pending' || job.status === 'running';
+}
+
 export function BulkImportDialog({ open, onOpenChange, onSuccess }: Props) {
     const [step, setStep] = React.useState<Step>('upload');
     const [file, setFile] = React.useState<File | null>(null);
     const [loading, setLoading] = React.useState(false);
     const [preview, setPreview] = React.useState<BulkImportPreviewResponse | null>(null);
-    const [result, setResult] = React.useState<BulkImportExecuteResponse | null>(null);
+    const [job, setJob] = React.useState<BulkImportJob | null>(null);
 
     React.useEffect(() => {
         if (open) {
             setStep('upload');
             setFile(null);
             setPreview(null);
-            setResult(null);
+            setJob(null);
         }
     }, [open]);
 
+    // Poll the import job until the background worker finishes it.
+    const jobId = job?.id;
+    const jobActive = job ? isJobActive(job) : false;
+    React.useEffect(() => {
+        if (!open || !jobId || !jobActive) return;
+
+        const timer = window.setInterval(async () => {
+            try {
+                const next = await usersApi.getImportJob(jobId);
+                setJob(next);
+                if (next.status === 'completed') {
+                    toast.success(
+                        'Import completed',
+                        `${next.created_count} created, ${next.skipped_count} skipped, ${next.failed_count} failed.`,
+                    );
+                    onSuccess();
+                } else if (next.status === 'failed') {
+                    toast.error('Import stopped', next.error ?? 'The import job failed.');
+                }
+            } catch (err) {
+                toast.error('Failed to fetch import progress', handleApiError(err));
+            }
+        }, JOB_POLL_INTERVAL_MS);
+
+        return () => window.clearInterval(timer);
+    }, [open, jobId, jobActive, onSuccess]);
+
     const handleFileChange = async (e: React.ChangeEvent<HTMLInputElement>) => {
         const selectedFile = e.target.files?.[0];
         if (!selectedFile) return;
@@ -83,10 +116,8 @@ export function BulkImportDialog({ open, onOpenChange, onSuccess }: Props) {
         setLoading(true);
         try {
             const resp = await usersApi.importExecute(file, preview.column_mapping);
-            setResult(resp);
+            setJob(resp);
             setStep('result');
-            toast.success('Import completed', `Successfully imported ${resp.success_count} users.`);
-            onSuccess();
         } catch (err) {
             toast.error('Failed to execute import', handleApiError(err));
         } finally {
@@ -94,10 +125,30 @@ export function BulkImportDialog({ open, onOpenChange, onSuccess }: Props) {
         }
     };
 
+    const handleResume = async () => {
+        if (!job) return;
+
+        setLoading(true);
+        try {
+            setJob(await usersApi.resumeImportJob(job.id));
+        } catch (err) {
+            toast.error('Failed to resume import', handleApiError(err));
+        } finally {
+            setLoading(false);
+        }
+    };
+
     const downloadTemplate = (format: 'csv' | 'xlsx') => {
         usersApi.importTemplate(format);
     };
 
+    const downloadReport = (format: 'csv' | 'xlsx') => {
+        if (!job) return;
+        usersApi.importReport(job.id, format).catch((err) =>
+            toast.error('Failed to download report', handleApiError(err)),
+        );
+    };
+
     return (
         <Dialog open={open} onOpenChange={onOpenChange}>
             <DialogContent className="max-w-4xl max-h-[90vh] flex flex-col p-6 overflow-hidden">
@@ -194,6 +245,10 @@ export function BulkImportDialog({ open, onOpenChange, onSuccess }: Props) {
                                         <span className="text-xs text-zinc-500 uppercase font-semibold">Invalid</span>
                                         <span className="text-lg font-bold text-red-600">{preview.invalid_rows}</span>
                                     </div>
+                                    <div className="flex flex-col">
+                                        <span className="text-xs text-zinc-500 uppercase font-semibold">Existing</span>
+                                        <span className="text-lg font-bold text-zinc-500">{preview.existing_rows}</span>
+                                    </div>
                                 </div>
                                 <div className="flex gap-3">
                                     <Button variant="outline" onClick={() => setStep('upload')}>
@@ -221,7 +276,7 @@ export function BulkImportDialog({ open, onOpenChange, onSuccess }: Props) {
                                             <TableHead className="w-12">#</TableHead>
                                             <TableHead className="min-w-[150px]">Full Name</TableHead>
                                             <TableHead className="min-w-[150px]">Email</TableHead>
-                                            <TableHead>Role</TableHead>
+                                            <TableHead>Batch</TableHead>
                                             <TableHead>Type</TableHead>
                                             <TableHead>Status</TableHead>
                                         </TableRow>
@@ -232,10 +287,14 @@ export function BulkImportDialog({ open, onOpenChange, onSuccess }: Props) {
                                                 <TableCell className="text-zinc-500 text-xs">{row.row_index}</TableCell>
                                                 <TableCell className="font-medium text-sm">{row.data.full_name}</TableCell>
                                                 <TableCell className="text-sm">{row.data.email}</TableCell>
-                                                <TableCell className="text-sm">{row.data.role}</TableCell>
+                                                <TableCell className="text-xs font-mono text-zinc-500">{row.data.batch_id}</TableCell>
                                                 <TableCell className="text-sm capitalize">{row.data.user_type}</TableCell>
                                                 <TableCell>
-                                                    {row.is_valid ? (
+                                                    {row.is_valid && row.exists ? (
+                                                        <Badge variant="secondary" className="gap-1">
+                                                            Exists
+                                                        </Badge>
+                                                    ) : row.is_valid ? (
                                                         <Badge variant="success" className="gap-1">
                                                             <Check className="h-3 w-3" />
                                                             Ready
@@ -262,51 +321,99 @@ export function BulkImportDialog({ open, onOpenChange, onSuccess }: Props) {
                         </div>
                     )}
 
-                    {step === 'result' && result && (
+                    {step === 'result' && job && (
                         <div className="p-12 flex flex-col items-center justify-center text-center space-y-6">
-                            <div className="h-20 w-20 rounded-full bg-green-100 dark:bg-green-900/30 flex items-center justify-center text-green-600 dark:text-green-400">
-                                <CheckCircle2 className="h-10 w-10" />
+                            <div className="h-20 w-20 rounded-full bg-zinc-100 dark:bg-zinc-800 flex items-center justify-center">
+                                {isJobActive(job) ? (
+                                    <Loader2 className="h-10 w-10 text-zinc-500 animate-spin" />
+                                ) : job.status === 'failed' ? (
+                                    <AlertCircle className="h-10 w-10 text-red-600 dark:text-red-400" />
+                                ) : (
+                                    <CheckCircle2 className="h-10 w-10 text-green-600 dark:text-green-400" />
+                                )}
                             </div>
                             <div className="space-y-2">
                                 <h3 className="text-2xl font-bold text-zinc-900 dark:text-zinc-50">
-                                    Import Completed Successfully
+                                    {isJobActive(job)
+                                        ? 'Importing Users'
+                                        : job.status === 'failed'
+                                          ? 'Import Stopped'
+                                          : 'Import Completed'}
                                 </h3>
                                 <p className="text-zinc-500 max-w-md mx-auto">
-                                    We&apos;ve finished processing your file. Below is a summary of the operation.
+                                    {isJobActive(job)
+                                        ? `Processed ${job.processed_rows} of ${job.total_rows} rows. You can close this dialog; the import keeps running.`
+                                        : job.status === 'failed'
+                                          ? job.error ?? 'The import stopped before every row was processed.'
+                                          : 'Existing users were skipped. Download the report for the outcome of every row.'}
                                 </p>
                             </div>
 
-                            <div className="grid grid-cols-2 gap-8 w-full max-w-sm border border-zinc-100 dark:border-zinc-800 rounded-xl p-6 bg-zinc-50/50 dark:bg-zinc-900/50">
+                            <div className="grid grid-cols-3 gap-8 w-full max-w-md border border-zinc-100 dark:border-zinc-800 rounded-xl p-6 bg-zinc-50/50 dark:bg-zinc-900/50">
                                 <div className="flex flex-col">
-                                    <span className="text-sm text-zinc-500 mb-1">Success</span>
+                                    <span className="text-sm text-zinc-500 mb-1">Created</span>
                                     <span className="text-3xl font-bold text-zinc-900 dark:text-zinc-50">
-                                        {result.success_count}
+                                        {job.created_count}
+                                    </span>
+                                </div>
+                                <div className="flex flex-col">
+                                    <span className="text-sm text-zinc-500 mb-1">Skipped</span>
+                                    <span className="text-3xl font-bold text-zinc-500">
+                                        {job.skipped_count}
                                     </span>
                                 </div>
                                 <div className="flex flex-col">
                                     <span className="text-sm text-zinc-500 mb-1">Failed</span>
                                     <span className="text-3xl font-bold text-red-600">
-                                        {result.failure_count}
+                                        {job.failed_count}
                                     </span>
                                 </div>
                             </div>
 
-                            {result.failure_count > 0 && (
+                            {job.failures && job.failures.length > 0 && (
                                 <div className="w-full max-w-md bg-red-50 dark:bg-red-900/10 border border-red-100 dark:border-red-900/30 rounded-lg p-4 text-left">
                                     <div className="flex items-center gap-2 text-red-700 dark:text-red-400 font-medium mb-2">
                                         <AlertCircle className="h-4 w-4" />
                                         Failed Rows
                                     </div>
                                     <ul className="text-xs text-red-600 dark:text-red-400 space-y-1.5 list-disc list-inside">
-                                        {result.results.filter(r => !r.success).map((r, i) => (
-                                            <li key={i}>
-                                                Row {r.row_index} ({r.email}): {r.error}
+                                        {job.failures.map((r) => (
+                                            <li key={r.row_index}>
+                                                Row {r.row_index} ({r.email}): {r.message}
                                             </li>
                                         ))}
                                     </ul>
                                 </div>
                             )}
 
+                            {!isJobActive(job) && (
+                                <div className="flex gap-3">
+                                    <Button
+                                        variant="outline"
+                                        size="sm"
+                                        className="gap-2"
+                                        onClick={() => downloadReport('xlsx')}
+                                    >
+                                        <Download className="h-4 w-4" />
+                                        Report (.xlsx)
+                                    </Button>
+                                    <Button
+                                        variant="outline"
+                                        size="sm"
+                                        className="gap-2"
+                                        onClick={() => downloadReport('csv')}
+                                    >
+                                        <Download className="h-4 w-4" />
+                                        Report (.csv)
+                                    </Button>
+                                    {job.status === 'failed' && (
+                                        <Button size="sm" onClick={handleResume} disabled={loading}>
+                                            Resume Import
+                                        </Button>
+                                    )}
+                                </div>
+                            )}
+
                             <Button className="w-full max-w-sm" onClick={() => onOpenChange(false)}>
                                 Go Back to Users
                             </Button>
@@ -47,19 +47,34 @@ export function DiffSheet({
       setError(null);
 
       try {
-        const rawSubs: SubmissionResponse[] =
-          await instructorAssessmentsApi.listSubmissions(assignmentId);
-
         // Only fetch code for members of this cluster (by student_id)
         // member_ids in CollusionGroup are student_id values
-        const memberSet = new Set(cluster!.member_ids);
-        const relevant = rawSubs.filter(
-          (s) => s.user_id && memberSet.has(s.user_id)
+        const memberIds = cluster!.member_ids;
+        const pages = await Promise.allSettled(
+          memberIds.map((userId) =>
+            instructorAssessmentsApi.listSubmissions(
+              assignmentId,
+              null,
+              { user_id: userId, is_latest: true },
+              1,
+            ),
+          ),
+        );
+        const relevant = pages.flatMap((r) =>
+          r.status === "fulfilled" ? r.value.items : [],
         );
 
-        // Fall back to all latest submissions if member filtering yields nothing
-        // (can happen when member_ids are submission_ids instead of user_ids)
-        const targets = relevant.length > 0 ? relevant : rawSubs.filter((s) => s.is_latest);
+        // Fall back to looking the members up as submissions if member
+        // filtering yields nothing (can happen when member_ids are
+        // submission_ids instead of user_ids)
+        const targets: SubmissionResponse[] =
+          relevant.length > 0
+            ? relevant
+            : (
+                await Promise.allSettled(
+                  memberIds.map((id) => instructorAssessmentsApi.getSubmission(id)),
+                )
+              ).flatMap((r) => (r.status === "fulfilled" ? [r.value] : []));
 
         const withCode = await Promise.all(
           targets.map(async (sub) => {
@@ -3,6 +3,9 @@
  *
  * All list endpoints return a wrapped object, e.g.
  *   GET /departments → { departments: [...], count: N }
+ * Paginated lists (courses, batches, enrollments) add total and next_cursor;
+ * the helpers below read every page, as these lists stay small: catalogue
+ * entries for pickers and the enrollments of a single course instance.
  * Single-entity endpoints (GET/:id, POST, PUT) return the entity directly.
  *
  * Gateway coverage (Traefik):
@@ -18,7 +21,7 @@
  *   ✅ /api/v1/course-instructors
  *   ✅ /api/v1/enrollments
  */
-import { axiosInstance } from "./axios";
+import { axiosInstance, getAllPages } from "./axios";
 import type {
   Faculty,
   Department,
@@ -306,10 +309,7 @@ export const coursesApi = {
   list: async (includeInactive = false): Promise<Course[]> => {
     const params: Record<string, unknown> = {};
     if (includeInactive) params.include_inactive = true;
-    const { data } = await axiosInstance.get("/courses", { params });
-    if (Array.isArray(data)) return data as Course[];
-    if (Array.isArray(data?.courses)) return data.courses as Course[];
-    return [];
+    return getAllPages<Course>("/courses", "courses", params);
   },
 
   get: async (id: string): Promise<Course> => {
@@ -438,10 +438,7 @@ export const batchesApi = {
   list: async (includeInactive = false): Promise<Batch[]> => {
     const params: Record<string, unknown> = {};
     if (includeInactive) params.include_inactive = true;
-    const { data } = await axiosInstance.get("/batches", { params });
-    if (Array.isArray(data)) return data as Batch[];
-    if (Array.isArray(data?.batches)) return data.batches as Batch[];
-    return [];
+    return getAllPages<Batch>("/batches", "batches", params);
   },
 
   get: async (id: string): Promise<Batch> => {
@@ -582,13 +579,10 @@ export const courseInstancesApi = {
   },
 
   getEnrollments: async (instanceId: string): Promise<Enrollment[]> => {
-    const { data } = await axiosInstance.get(
+    return getAllPages<Enrollment>(
       `/course-instances/${instanceId}/enrollments`,
+      "enrollments",
     );
-    if (Array.isArray(data)) return data as Enrollment[];
-    if (Array.isArray(data?.enrollments))
-      return data.enrollments as Enrollment[];
-    return [];
   },
 
   delete: async (instanceId: string): Promise<void> => {
@@ -626,13 +620,10 @@ export const courseInstructorsApi = {
 
 export const enrollmentsApi = {
   list: async (instanceId: string): Promise<Enrollment[]> => {
-    const { data } = await axiosInstance.get(
+    return getAllPages<Enrollment>(
       `/course-instances/${instanceId}/enrollments`,
+      "enrollments",
     );
-    if (Array.isArray(data)) return data as Enrollment[];
-    if (Array.isArray(data?.enrollments))
-      return data.enrollments as Enrollment[];
-    return [];
   },
 
   enroll: async (req: EnrollStudentRequest): Promise<Enrollment> => {
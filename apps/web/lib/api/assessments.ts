@@ -1,4 +1,4 @@
-import { axiosInstance } from './axios';
+import { axiosInstance, getAllPages, getPage, type Page } from './axios';
 import type {
     AssignmentResponse,
     ListAssignmentsResponse,
@@ -6,6 +6,7 @@ import type {
     SampleAnswerDto,
     SubmissionResponse,
     ListSubmissionsResponse,
+    SubmissionFilters,
     SubmissionCodeResponse,
     CreateSubmissionRequest,
     UpdateSubmissionAnalysisRequest,
@@ -44,9 +45,43 @@ export const instructorAssessmentsApi = {
         return data;
     },
 
-    listSubmissions: async (assignmentId: string): Promise<SubmissionResponse[]> => {
-        const { data } = await axiosInstance.get<ListSubmissionsResponse>(`/instructor-submissions/assignment/${assignmentId}`);
-        return data.submissions || [];
+    /**
+     * One page of an assignment's submissions, latest version first. Pass the
+     * previous page's nextCursor to read the next one.
+     * Backend: GET /instructor-submissions/assignment/:id?cursor=&limit=
+     */
+    listSubmissions: async (
+        assignmentId: string,
+        cursor?: string | null,
+        filters: SubmissionFilters = {},
+        limit = 50,
+    ): Promise<Page<SubmissionResponse>> => {
+        return getPage<SubmissionResponse>(
+            `/instructor-submissions/assignment/${assignmentId}`,
+            'submissions',
+            { ...filters, limit },
+            cursor,
+        );
+    },
+
+    /**
+     * The latest submission of every student or group, for analyses that
+     * compare the whole class. One per submitter, so bounded by the class size.
+     */
+    listLatestSubmissions: async (assignmentId: string): Promise<SubmissionResponse[]> => {
+        return getAllPages<SubmissionResponse>(
+            `/instructor-submissions/assignment/${assignmentId}`,
+            'submissions',
+            { is_latest: true },
+        );
+    },
+
+    /**
+     * Persist CIPAS analysis scores on a submission. Course staff only.
+     * Backend: PATCH /instructor-submissions/:id/analysis
+     */
+    updateSubmissionAnalysis: async (id: string, req: UpdateSubmissionAnalysisRequest): Promise<void> => {
+        await axiosInstance.patch(`/instructor-submissions/${id}/analysis`, req);
     },
 
     /**
@@ -117,14 +152,6 @@ export const assessmentsApi = {
         return data;
     },
 
-    /**
-     * Persist CIPAS analysis scores on a submission (fire-and-forget).
-     * Backend: PATCH /submissions/:id/analysis
-     */
-    updateSubmissionAnalysis: async (id: string, req: UpdateSubmissionAnalysisRequest): Promise<void> => {
-        await axiosInstance.patch(`/submissions/${id}/analysis`, req);
-    },
-
     createGroup: async (req: CreateGroupRequest): Promise<GroupResponse> => {
         const { data } = await axiosInstance.post<GroupResponse>('/groups', req);
         return data;
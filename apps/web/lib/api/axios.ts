@@ -160,3 +160,49 @@ export const handleApiError = (error: unknown): string => {
 
   return "An unexpected error occurred";
 };
+
+// One page of a cursor-paginated list: its items, the total across all pages
+// and the cursor of the next page, null on the last.
+export interface Page<T> {
+  items: T[];
+  total: number;
+  nextCursor: string | null;
+}
+
+// Helper to read one page of a cursor-paginated list endpoint, whose
+// responses hold the items under `key`, the total under `total` and the next
+// page's cursor in `next_cursor`. Lists that grow with use, such as
+// submissions, are read this way, a page at a time as the user asks for more.
+export const getPage = async <T>(
+  url: string,
+  key: string,
+  params: Record<string, unknown> = {},
+  cursor?: string | null,
+): Promise<Page<T>> => {
+  const { data } = await axiosInstance.get(url, {
+    params: { ...params, ...(cursor ? { cursor } : {}) },
+  });
+  return {
+    items: (data?.[key] ?? []) as T[],
+    total: data?.total ?? 0,
+    nextCursor: data?.next_cursor ?? null,
+  };
+};
+
+// Helper to read every page of a cursor-paginated list endpoint. Only for
+// small, bounded lookups such as the options of a dropdown or one row per
+// student of a course; anything that grows with use goes through getPage.
+export const getAllPages = async <T>(
+  url: string,
+  key: string,
+  params: Record<string, unknown> = {},
+): Promise<T[]> => {
+  const items: T[] = [];
+  let cursor: string | null = null;
+  do {
+    const page: Page<T> = await getPage<T>(url, key, { ...params, limit: 200 }, cursor);
+    items.push(...page.items);
+    cursor = page.nextCursor;
+  } while (cursor);
+  return items;
+};
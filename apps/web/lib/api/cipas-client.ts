@@ -16,7 +16,7 @@ import type {
   BatchCodeRequest,
   BatchCodeResponse,
 } from "@/types/assessments.types";
-import { assessmentsApi } from "@/lib/api/assessments";
+import { instructorAssessmentsApi } from "@/lib/api/assessments";
 
 // Gateway URL builder (similar to keystroke.ts pattern)
 const GATEWAY_URL = (() => {
@@ -304,7 +304,8 @@ export async function getSemanticSimilarity(
 
 /**
  * Save submission analysis results (AI detection, semantic similarity) for an assignment submission.
- * Persists to the submissions table via PATCH /api/v1/submissions/:id/analysis.
+ * Persists to the submissions table via PATCH /api/v1/instructor-submissions/:id/analysis,
+ * which only course staff may call.
  * This is a best-effort operation - failures are logged but not thrown.
  */
 export async function saveSubmissionAnalysis(
@@ -326,7 +327,7 @@ export async function saveSubmissionAnalysis(
       semantic_similarity_score: analysis.semantic_similarity_score,
     };
 
-    await assessmentsApi.updateSubmissionAnalysis(submissionId, request);
+    await instructorAssessmentsApi.updateSubmissionAnalysis(submissionId, request);
   } catch (error) {
     console.error(
       `Failed to save analysis for submission ${submissionId}:`,
@@ -5,8 +5,9 @@ import type {
 } from "@/types/notification.types";
 
 export const notificationsApi = {
-  list: async (page = 1, perPage = 20, read?: boolean) => {
-    const params: Record<string, unknown> = { page, per_page: perPage };
+  list: async (cursor?: string, limit = 20, read?: boolean) => {
+    const params: Record<string, unknown> = { limit };
+    if (cursor) params.cursor = cursor;
     if (read !== undefined) params.read = String(read);
     const { data } = await axiosInstance.get<ListNotificationsResponse>(
       "/notifications",
@@ -8,7 +8,7 @@ import type {
   CreateUserResponse,
   UpdateUserResponse,
   BulkImportPreviewResponse,
-  BulkImportExecuteResponse,
+  BulkImportJob,
 } from "@/types/admin.types";
 
 export type PaginatedUsers = PaginatedResponse<UserListItem>;
@@ -170,15 +170,15 @@ export const usersApi = {
     return data;
   },
 
-  /** POST /users/import/execute */
+  /** POST /users/import/execute — queues the import and returns the job */
   importExecute: async (
     file: File,
     mapping: Record<string, string>,
-  ): Promise<BulkImportExecuteResponse> => {
+  ): Promise<BulkImportJob> => {
     const formData = new FormData();
     formData.append("file", file);
     formData.append("column_mapping", JSON.stringify(mapping));
-    const { data } = await axiosInstance.post<BulkImportExecuteResponse>(
+    const { data } = await axiosInstance.post<BulkImportJob>(
       "/users/import/execute",
       formData,
       {
@@ -188,6 +188,40 @@ export const usersApi = {
     return data;
   },
 
+  /** GET /users/import/jobs/:id */
+  getImportJob: async (id: string): Promise<BulkImportJob> => {
+    const { data } = await axiosInstance.get<BulkImportJob>(
+      `/users/import/jobs/${id}`,
+    );
+    return data;
+  },
+
+  /** POST /users/import/jobs/:id/resume */
+  resumeImportJob: async (id: string): Promise<BulkImportJob> => {
+    const { data } = await axiosInstance.post<BulkImportJob>(
+      `/users/import/jobs/${id}/resume`,
+    );
+    return data;
+  },
+
+  /** GET /users/import/jobs/:id/report */
+  importReport: async (id: string, format: "csv" | "xlsx"): Promise<void> => {
+    const { data } = await axiosInstance.get(
+      `/users/import/jobs/${id}/report`,
+      {
+        params: { format },
+        responseType: "blob",
+      },
+    );
+    const url = window.URL.createObjectURL(new Blob([data]));
+    const link = document.createElement("a");
+    link.href = url;
+    link.setAttribute("download", `user_import_${id}.${format}`);
+    document.body.appendChild(link);
+    link.click();
+    link.remove();
+  },
+
   /** GET /admin/users/:id/activity — Fetch user activity/audit logs */
   getActivity: async (
     userId: string,
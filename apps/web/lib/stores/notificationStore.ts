@@ -13,7 +13,7 @@ interface NotificationState {
   connected: boolean;
   isLoading: boolean;
 
-  fetchNotifications: (page?: number) => Promise<void>;
+  fetchNotifications: () => Promise<void>;
   fetchUnreadCount: () => Promise<void>;
   markAsRead: (id: string) => Promise<void>;
   markAllAsRead: () => Promise<void>;
@@ -31,7 +31,7 @@ export const useNotificationStore = create<NotificationState>()((set) => ({
   connected: false,
   isLoading: false,
 
-  fetchNotifications: async (page = 1) => {
+  fetchNotifications: async () => {
     // Don't try to fetch if not authenticated
     const isAuthenticated = useAuthStore.getState().isAuthenticated;
     if (!isAuthenticated) {
@@ -43,7 +43,7 @@ export const useNotificationStore = create<NotificationState>()((set) => ({
     try {
       const { data } = await axiosInstance.get<ListNotificationsResponse>(
         "/notifications",
-        { params: { page, per_page: 20 } },
+        { params: { limit: 20 } },
       );
       set({ notifications: data.notifications, isLoading: false });
     } catch {
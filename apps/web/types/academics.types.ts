@@ -39,7 +39,11 @@ export const ENROLLMENT_STATUSES = [
   "Completed",
   "Failed",
 ] as const;
-export type EnrollmentStatus = (typeof ENROLLMENT_STATUSES)[number];
+// "Waitlisted" is assigned by the server when a course instance is full and
+// cannot be chosen manually, so it is not in ENROLLMENT_STATUSES.
+export type EnrollmentStatus =
+  | (typeof ENROLLMENT_STATUSES)[number]
+  | "Waitlisted";
 
 export const BATCH_MEMBER_STATUSES = [
   "Active",
@@ -189,6 +193,10 @@ export interface Semester {
   end_date: string;
   status: SemesterStatus;
   is_active: boolean;
+  add_drop_deadline?: string;
+  exam_start_date?: string;
+  exam_end_date?: string;
+  grade_submission_deadline?: string;
   created_at: string;
   updated_at: string;
 }
@@ -223,12 +231,25 @@ export interface Enrollment {
   email: string;
   status: EnrollmentStatus;
   final_grade?: string;
+  waitlist_position?: number;
   enrolled_at: string;
 }
 
+/**
+ * "prerequisite" must be completed first; a "corequisite" may also be taken
+ * in the same semester.
+ */
+export type PrerequisiteKind = "prerequisite" | "corequisite";
+
+/**
+ * Rules sharing a non-zero group are alternatives; all other rules must be met.
+ */
 export interface CoursePrerequisite {
   course_id: string;
   prerequisite_course_id: string;
+  kind: PrerequisiteKind;
+  group: number;
+  min_grade?: string;
   prerequisite_course?: Course;
 }
 
@@ -339,6 +360,10 @@ export interface CreateSemesterRequest {
   start_date: string;
   end_date: string;
   status: SemesterStatus;
+  add_drop_deadline?: string;
+  exam_start_date?: string;
+  exam_end_date?: string;
+  grade_submission_deadline?: string;
 }
 
 export interface UpdateSemesterRequest {
@@ -348,6 +373,10 @@ export interface UpdateSemesterRequest {
   end_date?: string;
   status?: SemesterStatus;
   is_active?: boolean;
+  add_drop_deadline?: string;
+  exam_start_date?: string;
+  exam_end_date?: string;
+  grade_submission_deadline?: string;
 }
 
 export interface CreateCourseInstanceRequest {
@@ -374,6 +403,8 @@ export interface EnrollStudentRequest {
   user_id: string;
   status: EnrollmentStatus;
   allow_individual?: boolean; // Skip batch membership check for individual enrollments
+  override_prerequisites?: boolean;
+  override_reason?: string; // Required with override_prerequisites
 }
 
 export interface UpdateEnrollmentRequest {
@@ -383,6 +414,9 @@ export interface UpdateEnrollmentRequest {
 
 export interface AddPrerequisiteRequest {
   prerequisite_course_id: string;
+  kind?: PrerequisiteKind;
+  group?: number;
+  min_grade?: string;
 }
 
 // ─── API list-response wrappers ───────────────────────────────────────────────
@@ -412,11 +446,280 @@ export interface SemesterListResponse {
   count: number;
 }
 
+export interface RolloverBatchMapping {
+  from_batch_id: string;
+  to_batch_id: string;
+}
+
+export interface RolloverRequest {
+  source_semester_id: string;
+  target_semester_id: string;
+  course_ids?: string[];
+  batch_mappings?: RolloverBatchMapping[];
+  copy_instructors: boolean;
+  copy_assignments: boolean;
+  dry_run: boolean;
+}
+
+export interface RolloverInstance {
+  source_course_instance_id: string;
+  target_course_instance_id?: string;
+  course_id: string;
+  source_batch_id: string;
+  target_batch_id: string;
+  action: "create" | "existing";
+  instructors_copied: number;
+}
+
+export interface RolloverAssignment {
+  source_assignment_id: string;
+  target_assignment_id?: string;
+  source_course_instance_id: string;
+  title: string;
+  release_at?: string;
+  due_at?: string;
+  skipped?: boolean;
+  /** Section targets of the source not carried over to the copy. */
+  sections_dropped?: number;
+}
+
+export interface RolloverResponse {
+  dry_run: boolean;
+  source_semester_id: string;
+  target_semester_id: string;
+  offset_days: number;
+  instances_created: number;
+  instructors_copied: number;
+  assignments_cloned: number;
+  assignments_skipped: number;
+  instances: RolloverInstance[];
+  assignments?: RolloverAssignment[];
+  assignment_error?: string;
+}
+
+// ─── Grading schemes, final grades and transcripts ───────────────────────────
+
+/** Name matches the assessment type of assignments, e.g. "lab" or "exam". */
+export interface GradingCategory {
+  name: string;
+  weight: number;
+}
+
+export interface GradeBand {
+  letter: string;
+  min_percent: number;
+  grade_points: number;
+}
+
+/** Category weights add up to 100; one band must start at 0. */
+export interface SetGradingSchemeRequest {
+  categories: GradingCategory[];
+  bands: GradeBand[];
+}
+
+export interface GradingScheme extends SetGradingSchemeRequest {
+  course_instance_id: string;
+  locked_at?: string;
+  locked_by?: string;
+  updated_at: string;
+}
+
+export interface CategoryScore {
+  name: string;
+  weight: number;
+  average: number;
+  assignments: number;
+  missing: number;
+}
+
+export interface FinalGrade {
+  user_id: string;
+  status: EnrollmentStatus;
+  final_score?: number;
+  final_grade?: string;
+  grade_points?: number;
+  grade_overridden: boolean;
+  categories?: CategoryScore[]; // Only returned by a compute run
+}
+
+export interface FinalGradesResponse {
+  course_instance_id: string;
+  locked: boolean;
+  locked_at?: string;
+  grades: FinalGrade[];
+  count: number;
+}
+
+export interface OverrideFinalGradeRequest {
+  final_grade: string;
+  reason: string;
+}
+
+export interface TranscriptCourse {
+  course_instance_id: string;
+  course_code: string;
+  course_title: string;
+  credits: number;
+  status: EnrollmentStatus;
+  final_grade: string;
+  grade_points?: number;
+}
+
+export interface TranscriptTerm {
+  semester_id: string;
+  semester_code: string;
+  semester_name: string;
+  start_date: string;
+  courses: TranscriptCourse[];
+  credits_attempted: number;
+  credits_earned: number;
+  gpa: number;
+}
+
+export interface Transcript {
+  user_id: string;
+  student_id?: string;
+  full_name?: string;
+  terms: TranscriptTerm[];
+  credits_attempted: number;
+  credits_earned: number;
+  cumulative_gpa: number;
+  generated_at: string;
+}
+
+// ─── Degree requirements and audits ──────────────────────────────────────────
+
+export type DegreeRequirementKind =
+  | "required_courses"
+  | "elective_pool"
+  | "total_credits";
+
+/** course_ids is empty for total_credits rules. */
+export interface DegreeRequirementRequest {
+  specialization_id?: string;
+  kind: DegreeRequirementKind;
+  name: string;
+  min_credits: number;
+  min_grade?: string;
+  course_ids: string[];
+}
+
+export interface RequirementCourse {
+  course_id: string;
+  code: string;
+  title: string;
+  credits: number;
+}
+
+export interface DegreeRequirement {
+  id: string;
+  degree_id: string;
+  specialization_id?: string;
+  kind: DegreeRequirementKind;
+  name: string;
+  min_credits: number;
+  min_grade?: string;
+  courses: RequirementCourse[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface DegreeRequirementListResponse {
+  requirements: DegreeRequirement[];
+  count: number;
+}
+
+export type AuditCourseStatus = "completed" | "in_progress" | "missing";
+
+/** "pending" means met once the in-progress courses are completed. */
+export type RequirementAuditStatus = "satisfied" | "pending" | "missing";
+
+export interface AuditCourse {
+  course_id: string;
+  course_code: string;
+  credits: number;
+  status: AuditCourseStatus;
+  final_grade?: string;
+}
+
+export interface RequirementAudit {
+  requirement_id: string;
+  kind: DegreeRequirementKind;
+  name: string;
+  status: RequirementAuditStatus;
+  min_credits?: number;
+  credits_completed: number;
+  credits_in_progress: number;
+  courses?: AuditCourse[];
+}
+
+export interface DegreeAudit {
+  user_id: string;
+  degree_id: string;
+  degree_code: string;
+  degree_name: string;
+  specialization_id?: string;
+  specialization_name?: string;
+  status: RequirementAuditStatus;
+  credits_completed: number;
+  credits_in_progress: number;
+  requirements: RequirementAudit[];
+  generated_at: string;
+}
+
 export interface BatchListResponse {
   batches: Batch[];
   count: number;
 }
 
+// ─── Academic structure import/export ────────────────────────────────────────
+
+export type StructureRowType =
+  | "faculty"
+  | "faculty_leader"
+  | "department"
+  | "degree"
+  | "specialization"
+  | "course"
+  | "batch"
+  | "batch_member";
+
+export type StructureRowAction = "create" | "exists" | "invalid";
+
+export interface StructureRow {
+  type: StructureRowType;
+  code?: string;
+  name?: string;
+  parent_code?: string;
+  degree_code?: string;
+  specialization_code?: string;
+  description?: string;
+  level?: string;
+  credits?: string;
+  start_year?: string;
+  end_year?: string;
+  user_id?: string;
+  role?: string;
+  status?: string;
+}
+
+export interface StructureRowResult {
+  row_index: number;
+  data: StructureRow;
+  action: StructureRowAction;
+  errors?: string[];
+}
+
+export interface StructureImportResponse {
+  applied: boolean;
+  total_rows: number;
+  create_rows: number;
+  existing_rows: number;
+  invalid_rows: number;
+  created: Partial<Record<StructureRowType, number>>;
+  rows: StructureRowResult[];
+}
+
 // ─── Form validation helpers ──────────────────────────────────────────────────
 
 export interface AcademicFormErrors {
@@ -446,6 +749,7 @@ export interface StudentCourseEnrollment {
   batch_name?: string;
   status: EnrollmentStatus;
   final_grade?: string;
+  waitlist_position?: number;
   enrolled_at: string;
 }
 
@@ -472,8 +776,12 @@ export interface EnrolledBatchStats {
  */
 export interface EnrollBatchResult {
   enrolled: number;
+  waitlisted: number;
   skipped: number;
+  ineligible: number;
   total: number;
   skipped_users: string[];
+  waitlisted_users: string[];
+  ineligible_users: string[]; // Prerequisites not met
 }
 
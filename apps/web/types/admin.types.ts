@@ -87,6 +87,7 @@ export interface BulkImportUserRow {
   faculty: string;
   student_id?: string;
   designation?: string;
+  batch_id?: string;
 }
 
 export interface BulkImportPreviewRow {
@@ -94,6 +95,7 @@ export interface BulkImportPreviewRow {
   data: BulkImportUserRow;
   errors?: string[];
   is_valid: boolean;
+  exists: boolean;
 }
 
 export interface BulkImportPreviewResponse {
@@ -101,6 +103,7 @@ export interface BulkImportPreviewResponse {
   total_rows: number;
   valid_rows: number;
   invalid_rows: number;
+  existing_rows: number;
   column_mapping: Record<string, string>;
 }
 
@@ -109,16 +112,34 @@ export interface BulkImportExecuteRequest {
   column_mapping: Record<string, string>;
 }
 
+export type BulkImportRowStatus = "pending" | "created" | "skipped" | "failed";
+
 export interface BulkImportResultRow {
   row_index: number;
   email: string;
-  success: boolean;
-  error?: string;
+  status: BulkImportRowStatus;
+  user_id?: string;
+  email_sent: boolean;
+  batch_id?: string;
+  batch_assigned: boolean;
+  message?: string;
 }
 
-export interface BulkImportExecuteResponse {
-  total_processed: number;
-  success_count: number;
-  failure_count: number;
-  results: BulkImportResultRow[];
+export type BulkImportJobStatus = "pending" | "running" | "completed" | "failed";
+
+export interface BulkImportJob {
+  id: string;
+  filename: string;
+  status: BulkImportJobStatus;
+  total_rows: number;
+  processed_rows: number;
+  created_count: number;
+  skipped_count: number;
+  failed_count: number;
+  error?: string;
+  failures?: BulkImportResultRow[];
+  created_by: string;
+  started_at?: string;
+  completed_at?: string;
+  created_at: string;
 }
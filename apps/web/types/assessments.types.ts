@@ -165,6 +165,14 @@ export interface ListSubmissionsResponse {
     count: number;
 }
 
+/** Filters of GET /instructor-submissions/assignment/:id. */
+export interface SubmissionFilters {
+    user_id?: string;
+    group_id?: string;
+    status?: string;
+    is_latest?: boolean;
+}
+
 // ─── ACAFS grading types ─────────────────────────────────────────────────────
 
 /** Score and justification for a single rubric criterion. */
@@ -28,9 +28,9 @@ export interface UnreadCountResponse {
 
 export interface ListNotificationsResponse {
   notifications: Notification[];
+  count: number;
   total: number;
-  page: number;
-  per_page: number;
+  next_cursor: string | null;
 }
 
 export type SSEEvent =
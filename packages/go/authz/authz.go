@@ -0,0 +1,89 @@
+// Package authz holds the permission catalogue shared by GradeLoop services
+// and the Fiber middleware used to enforce it.
+//
+// IAM owns roles and role assignments. A user's global permissions (from their
+// user type and any unscoped assignments) travel in the access token's
+// "permissions" claim. Assignments scoped to a faculty, department or course
+// instance are resolved by asking IAM through a Checker.
+package authz
+
+// Permission names, in "<resource>:<action>" form.
+const (
+	PermUserRead   = "user:read"
+	PermUserWrite  = "user:write"
+	PermUserDelete = "user:delete"
+	PermRoleManage = "role:manage"
+
+	PermServiceClientManage = "service_client:manage"
+
+	PermAcademicRead        = "academic:read"
+	PermAcademicWrite       = "academic:write"
+	PermCourseInstanceWrite = "course_instance:write"
+	PermEnrollmentRead      = "enrollment:read"
+	PermEnrollmentWrite     = "enrollment:write"
+	PermDegreeAuditRead     = "degree_audit:read"
+
+	PermAssignmentRead  = "assignment:read"
+	PermAssignmentWrite = "assignment:write"
+	PermTestCaseWrite   = "test_case:write"
+	PermSubmissionRead  = "submission:read"
+	PermSubmissionGrade = "submission:grade"
+	PermRegradeRespond  = "regrade:respond"
+
+	PermAuditRead = "audit:read"
+)
+
+// AllPermissions lists every permission in the catalogue.
+var AllPermissions = []string{
+	PermUserRead, PermUserWrite, PermUserDelete, PermRoleManage,
+	PermServiceClientManage,
+	PermAcademicRead, PermAcademicWrite, PermCourseInstanceWrite,
+	PermEnrollmentRead, PermEnrollmentWrite, PermDegreeAuditRead,
+	PermAssignmentRead, PermAssignmentWrite, PermTestCaseWrite,
+	PermSubmissionRead, PermSubmissionGrade, PermRegradeRespond,
+	PermAuditRead,
+}
+
+// Scope types a role assignment can be limited to. ScopeGlobal assignments
+// apply everywhere and are carried in the token.
+const (
+	ScopeGlobal         = "global"
+	ScopeFaculty        = "faculty"
+	ScopeDepartment     = "department"
+	ScopeCourseInstance = "course_instance"
+)
+
+// IsValidScopeType reports whether t is a known scope type.
+func IsValidScopeType(t string) bool {
+	switch t {
+	case ScopeGlobal, ScopeFaculty, ScopeDepartment, ScopeCourseInstance:
+		return true
+	}
+	return false
+}
+
+// Scope identifies a resource a scoped role assignment can cover.
+type Scope struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Fiber Locals keys written by the services' AuthMiddleware.
+const (
+	LocalsPermissions = "permissions"
+	LocalsRoles       = "roles"
+	LocalsUserType    = "user_type"
+)
+
+// UserTypeAdmin is the user type that holds every permission.
+const UserTypeAdmin = "admin"
+
+// Contains reports whether permissions grants permission.
+func Contains(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthorizeRequest is the body of IAM's POST /api/v1/auth/authorize.
+type AuthorizeRequest struct {
+	Permission string  `json:"permission"`
+	Scopes     []Scope `json:"scopes"`
+}
+
+// AuthorizeResponse is IAM's answer to an AuthorizeRequest.
+type AuthorizeResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+// IAMChecker asks IAM whether the bearer of a token holds a permission in any
+// of the given scopes. Answers are cached briefly per token so a burst of
+// requests from one user costs a single round-trip.
+type IAMChecker struct {
+	baseURL    string
+	httpClient *http.Client
+	ttl        time.Duration
+
+	mu    sync.Mutex
+	cache map[string]checkerEntry
+}
+
+type checkerEntry struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+// NewIAMChecker creates a Checker backed by the IAM service at baseURL.
+func NewIAMChecker(baseURL string, ttl time.Duration) *IAMChecker {
+	return &IAMChecker{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		ttl:        ttl,
+		cache:      make(map[string]checkerEntry),
+	}
+}
+
+// Check implements Checker.
+func (c *IAMChecker) Check(ctx context.Context, token, permission string, scopes []Scope) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	key := cacheKey(token, permission, scopes)
+	now := time.Now()
+
+	c.mu.Lock()
+	if entry, ok := c.cache[key]; ok && now.Before(entry.expiresAt) {
+		c.mu.Unlock()
+		return entry.allowed, nil
+	}
+	c.mu.Unlock()
+
+	body, err := json.Marshal(AuthorizeRequest{Permission: permission, Scopes: scopes})
+	if err != nil {
+		return false, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/auth/authorize", bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// An invalid or expired token is a denial, not a failure of the check.
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("iam returned status %d", resp.StatusCode)
+	}
+
+	var result AuthorizeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("decoding response: %w", err)
+	}
+
+	c.mu.Lock()
+	c.evictExpired(now)
+	c.cache[key] = checkerEntry{allowed: result.Allowed, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+
+	return result.Allowed, nil
+}
+
+// evictExpired must be called with c.mu held.
+func (c *IAMChecker) evictExpired(now time.Time) {
+	for key, entry := range c.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(c.cache, key)
+		}
+	}
+}
+
+func cacheKey(token, permission string, scopes []Scope) string {
+	parts := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		parts = append(parts, s.Type+"="+s.ID)
+	}
+	sort.Strings(parts)
+
+	sum := sha256.Sum256([]byte(token + "|" + permission + "|" + strings.Join(parts, ",")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,129 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Checker decides permissions that are not carried in the access token,
+// i.e. those granted by role assignments limited to a scope.
+type Checker interface {
+	Check(ctx context.Context, token, permission string, scopes []Scope) (bool, error)
+}
+
+// ScopeResolver derives the scopes a request touches, for example the faculty
+// and department owning the course instance in the URL.
+type ScopeResolver func(c fiber.Ctx) ([]Scope, error)
+
+type requirement struct {
+	scopeParams map[string]string // route param name -> scope type
+	resolvers   []ScopeResolver
+	checker     Checker
+}
+
+// Option configures RequirePermission.
+type Option func(*requirement)
+
+// InScope lets a role assignment of scopeType on the resource identified by
+// route parameter param satisfy the requirement.
+func InScope(scopeType, param string) Option {
+	return func(r *requirement) {
+		r.scopeParams[param] = scopeType
+	}
+}
+
+// ScopesFrom adds scopes computed from the request.
+func ScopesFrom(resolver ScopeResolver) Option {
+	return func(r *requirement) {
+		r.resolvers = append(r.resolvers, resolver)
+	}
+}
+
+// CourseInstanceFromBody is a ScopeResolver that scopes a request to the
+// course_instance_id in its JSON body. A body without one adds no scope.
+func CourseInstanceFromBody(c fiber.Ctx) ([]Scope, error) {
+	var body struct {
+		CourseInstanceID string `json:"course_instance_id"`
+	}
+	if err := json.Unmarshal(c.Body(), &body); err != nil || body.CourseInstanceID == "" {
+		return nil, nil
+	}
+	return []Scope{{Type: ScopeCourseInstance, ID: body.CourseInstanceID}}, nil
+}
+
+// CheckWith sets the Checker used for scoped permissions. Without one only
+// the permissions in the token are considered.
+func CheckWith(checker Checker) Option {
+	return func(r *requirement) {
+		r.checker = checker
+	}
+}
+
+// RequirePermission allows the request when the caller holds permission,
+// either globally (from the token) or through a scoped role assignment on one
+// of the request's scopes. It must run after the service's AuthMiddleware.
+func RequirePermission(permission string, opts ...Option) fiber.Handler {
+	req := &requirement{scopeParams: map[string]string{}}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	return func(c fiber.Ctx) error {
+		if HasPermission(c, permission) {
+			return c.Next()
+		}
+
+		if req.checker != nil {
+			scopes, err := req.scopes(c)
+			if err != nil {
+				return err
+			}
+			if len(scopes) > 0 {
+				token := strings.TrimSpace(strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "))
+				allowed, err := req.checker.Check(c.RequestCtx(), token, permission, scopes)
+				if err != nil {
+					return fiber.NewError(fiber.StatusServiceUnavailable, "permission check unavailable")
+				}
+				if allowed {
+					return c.Next()
+				}
+			}
+		}
+
+		return fiber.NewError(fiber.StatusForbidden, "missing permission: "+permission)
+	}
+}
+
+// HasPermission reports whether the token presented with the request grants
+// permission globally.
+//
+// Access tokens issued before the permissions claim existed carry none. Until
+// they expire, an admin's such token keeps the access its user type gave it.
+func HasPermission(c fiber.Ctx, permission string) bool {
+	permissions, _ := c.Locals(LocalsPermissions).([]string)
+	if permissions == nil {
+		userType, _ := c.Locals(LocalsUserType).(string)
+		return userType == UserTypeAdmin
+	}
+	return Contains(permissions, permission)
+}
+
+func (r *requirement) scopes(c fiber.Ctx) ([]Scope, error) {
+	var scopes []Scope
+	for param, scopeType := range r.scopeParams {
+		if id := c.Params(param); id != "" {
+			scopes = append(scopes, Scope{Type: scopeType, ID: id})
+		}
+	}
+	for _, resolve := range r.resolvers {
+		resolved, err := resolve(c)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, resolved...)
+	}
+	return scopes, nil
+}
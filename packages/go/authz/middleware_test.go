@@ -0,0 +1,192 @@
+package authz
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// newTestApp mounts RequirePermission behind a fake auth middleware that
+// copies the X-Test-Permissions header into Locals like a real one would.
+func newTestApp(handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if perms := c.Get("X-Test-Permissions"); perms != "" {
+			c.Locals(LocalsPermissions, []string{perms})
+		}
+		if userType := c.Get("X-Test-User-Type"); userType != "" {
+			c.Locals(LocalsUserType, userType)
+		}
+		return c.Next()
+	})
+	app.Get("/courses/:id", handler, func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func doRequest(t *testing.T, app *fiber.App, permissions string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/courses/ci-1", nil)
+	req.Header.Set("Authorization", "Bearer user-token")
+	if permissions != "" {
+		req.Header.Set("X-Test-Permissions", permissions)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestRequirePermission_TokenPermission(t *testing.T) {
+	app := newTestApp(RequirePermission(PermAssignmentWrite))
+
+	if status := doRequest(t, app, PermAssignmentWrite); status != fiber.StatusOK {
+		t.Errorf("expected 200 with permission, got %d", status)
+	}
+	if status := doRequest(t, app, PermAssignmentRead); status != fiber.StatusForbidden {
+		t.Errorf("expected 403 without permission, got %d", status)
+	}
+}
+
+func TestRequirePermission_TokenWithoutPermissionsClaim(t *testing.T) {
+	app := newTestApp(RequirePermission(PermEnrollmentWrite))
+
+	tests := []struct {
+		name        string
+		userType    string
+		permissions string
+		want        int
+	}{
+		{"legacy admin token", UserTypeAdmin, "", fiber.StatusOK},
+		{"legacy instructor token", "instructor", "", fiber.StatusForbidden},
+		{"admin token with claim", UserTypeAdmin, PermAcademicRead, fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/courses/ci-1", nil)
+			req.Header.Set("Authorization", "Bearer user-token")
+			req.Header.Set("X-Test-User-Type", tt.userType)
+			if tt.permissions != "" {
+				req.Header.Set("X-Test-Permissions", tt.permissions)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRequirePermission_ScopedCheck(t *testing.T) {
+	var calls int32
+	iam := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		if r.URL.Path != "/api/v1/auth/authorize" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			t.Errorf("expected user token to be forwarded, got %q", r.Header.Get("Authorization"))
+		}
+
+		var req AuthorizeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		allowed := req.Permission == PermSubmissionGrade &&
+			len(req.Scopes) == 1 &&
+			req.Scopes[0] == Scope{Type: ScopeCourseInstance, ID: "ci-1"}
+
+		json.NewEncoder(w).Encode(AuthorizeResponse{Allowed: allowed})
+	}))
+	defer iam.Close()
+
+	checker := NewIAMChecker(iam.URL, time.Minute)
+
+	grade := newTestApp(RequirePermission(PermSubmissionGrade,
+		InScope(ScopeCourseInstance, "id"),
+		CheckWith(checker),
+	))
+	if status := doRequest(t, grade, ""); status != fiber.StatusOK {
+		t.Errorf("expected scoped grant to allow, got %d", status)
+	}
+	// Second request is answered from the cache.
+	if status := doRequest(t, grade, ""); status != fiber.StatusOK {
+		t.Errorf("expected cached grant to allow, got %d", status)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call to IAM, got %d", got)
+	}
+
+	write := newTestApp(RequirePermission(PermAssignmentWrite,
+		InScope(ScopeCourseInstance, "id"),
+		CheckWith(checker),
+	))
+	if status := doRequest(t, write, ""); status != fiber.StatusForbidden {
+		t.Errorf("expected scoped denial, got %d", status)
+	}
+}
+
+func TestRequirePermission_CheckerUnavailable(t *testing.T) {
+	iam := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer iam.Close()
+
+	app := newTestApp(RequirePermission(PermSubmissionGrade,
+		InScope(ScopeCourseInstance, "id"),
+		CheckWith(NewIAMChecker(iam.URL, time.Minute)),
+	))
+
+	if status := doRequest(t, app, ""); status != fiber.StatusServiceUnavailable {
+		t.Errorf("expected 503 when IAM fails, got %d", status)
+	}
+	// A token permission never needs IAM.
+	if status := doRequest(t, app, PermSubmissionGrade); status != fiber.StatusOK {
+		t.Errorf("expected 200 with token permission, got %d", status)
+	}
+}
+
+func TestCourseInstanceFromBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Scope
+	}{
+		{"course instance", `{"course_instance_id":"ci-1","title":"Lab"}`, []Scope{{Type: ScopeCourseInstance, ID: "ci-1"}}},
+		{"missing field", `{"title":"Lab"}`, nil},
+		{"not json", `title=Lab`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Scope
+			app := fiber.New()
+			app.Post("/", func(c fiber.Ctx) error {
+				scopes, err := CourseInstanceFromBody(c)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				got = scopes
+				return c.SendStatus(fiber.StatusOK)
+			})
+			if _, err := app.Test(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if len(got) != len(tt.want) || (len(got) == 1 && got[0] != tt.want[0]) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
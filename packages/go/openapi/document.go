@@ -0,0 +1,143 @@
+// Package openapi builds the OpenAPI 3.1 contract of a GradeLoop service
+// from a table of its routes, serves it, validates requests against it and
+// checks it against the routes the service actually registers.
+//
+// A service lists every route it serves as a Route, naming the Go types of
+// its query string, request body and responses; Build reflects those types
+// into JSON Schemas, so the document follows the DTOs it is written against.
+// Validate enforces the document on incoming requests, and CheckRoutes
+// reports where it and a Fiber app disagree, which each service asserts in
+// a test so the build fails on drift.
+package openapi
+
+import "encoding/json"
+
+// Version is the OpenAPI version of every document built by this package.
+const Version = "3.1.0"
+
+// Document is an OpenAPI 3.1 document. Only the parts GradeLoop services use
+// are modelled.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a base URL the API is served from.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of one path, keyed by lower-case HTTP
+// method.
+type PathItem map[string]*Operation
+
+// Operation describes one method on one path.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes the accepted request payloads by media type.
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes one response status.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType pairs a media type with the schema of its payload.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the named schemas and security schemes operations refer
+// to.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes how callers authenticate.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// Schema is the subset of JSON Schema 2020-12 used to describe GradeLoop
+// payloads. Type is a string, or a list including "null" for values that may
+// be null.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// Common schemas, for Route.Params and Spec.Types.
+var (
+	// UUID is a string holding a UUID.
+	UUID = &Schema{Type: "string", Format: "uuid"}
+	// Date is a string holding a "YYYY-MM-DD" calendar date.
+	Date = &Schema{Type: "string", Format: "date"}
+	// DateTime is a string holding an RFC 3339 timestamp.
+	DateTime = &Schema{Type: "string", Format: "date-time"}
+	// String is any string.
+	String = &Schema{Type: "string"}
+	// Integer is any integer.
+	Integer = &Schema{Type: "integer"}
+	// Boolean is true or false.
+	Boolean = &Schema{Type: "boolean"}
+	// Any accepts every JSON value.
+	Any = &Schema{}
+)
+
+// JSON returns the document as indented JSON.
+func (d *Document) JSON() ([]byte, error) {
+	out, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// CheckRoutes compares a document with the routes of a Fiber app, as
+// returned by app.GetRoutes(true), and describes every route one has and the
+// other lacks. HEAD routes are ignored, since Fiber adds one for every GET.
+func CheckRoutes(doc *Document, routes []fiber.Route) []string {
+	registered := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		if r.Method == fiber.MethodHead {
+			continue
+		}
+		registered[r.Method+" "+PathTemplate(r.Path)] = true
+	}
+
+	documented := make(map[string]bool, len(registered))
+	for template, item := range doc.Paths {
+		for method := range *item {
+			documented[strings.ToUpper(method)+" "+template] = true
+		}
+	}
+
+	var problems []string
+	for route := range registered {
+		if !documented[route] {
+			problems = append(problems, route+" is registered but not documented")
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			problems = append(problems, route+" is documented but not registered")
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestCheckRoutes(t *testing.T) {
+	doc := testSpec().MustBuild()
+	ok := func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error { return c.Next() })
+	app.Get("/health", ok)
+	api := app.Group("/api/v1")
+	things := api.Group("/things")
+	things.Post("/", ok)
+	things.Get("/search", ok)
+	things.Get("/:id", ok)
+	things.Delete("/:id", ok)
+	things.Post("/import", ok)
+	api.Get("/internal/things/export", ok)
+	app.Post("/oauth/token", ok)
+	if problems := CheckRoutes(doc, app.GetRoutes(true)); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+
+	things.Put("/:thingID", ok)
+	app.Get("/extra", ok)
+	want := []string{
+		"GET /extra is registered but not documented",
+		"PUT /api/v1/things/{thingID} is registered but not documented",
+	}
+	if got := CheckRoutes(doc, app.GetRoutes(true)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	partial := fiber.New()
+	partial.Get("/health", ok)
+	if got := CheckRoutes(doc, partial.GetRoutes(true)); len(got) != 7 {
+		t.Errorf("expected the seven unregistered routes, got %v", got)
+	}
+}
+
+// recorder is a testing.TB that records failures instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestAssertRoutesDocumented(t *testing.T) {
+	app := fiber.New()
+	app.Get("/extra", func(c fiber.Ctx) error { return nil })
+
+	r := &recorder{TB: t}
+	AssertRoutesDocumented(r, app, testSpec().MustBuild())
+	if len(r.errors) != 1 {
+		t.Fatalf("expected one failure, got %v", r.errors)
+	}
+}
+
+func TestAssertDocumentCurrent(t *testing.T) {
+	doc := testSpec().MustBuild()
+	path := filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := &recorder{TB: t}
+	AssertDocumentCurrent(stale, doc, path, false)
+	if len(stale.errors) != 1 {
+		t.Fatalf("expected the stale file reported, got %v", stale.errors)
+	}
+
+	updated := &recorder{TB: t}
+	AssertDocumentCurrent(updated, doc, path, true)
+	AssertDocumentCurrent(updated, doc, path, false)
+	if len(updated.errors) != 0 {
+		t.Errorf("expected the rewritten file to be current, got %v", updated.errors)
+	}
+}
+
+func TestServe(t *testing.T) {
+	app := fiber.New()
+	app.Get("/openapi.json", Serve(testSpec().MustBuild()))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/openapi.json", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	var doc map[string]any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc["openapi"] != "3.1.0" {
+		t.Errorf("expected OpenAPI 3.1.0, got %v", doc["openapi"])
+	}
+}
@@ -0,0 +1,396 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemas reflects Go types into JSON Schemas, collecting named struct types
+// as components.
+type schemas struct {
+	types      map[reflect.Type]*Schema
+	components map[string]*Schema
+	owners     map[string]reflect.Type
+}
+
+func newSchemas(types map[reflect.Type]*Schema) *schemas {
+	return &schemas{
+		types:      types,
+		components: make(map[string]*Schema),
+		owners:     make(map[string]reflect.Type),
+	}
+}
+
+// of returns the schema of a value given in a Route: a *Schema as is, Fields
+// as an object, and any other value as the schema of its Go type.
+func (g *schemas) of(v any) (*Schema, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case *Schema:
+		return v, nil
+	case Fields:
+		return g.fields(v)
+	case reflect.Type:
+		return g.typ(v)
+	default:
+		return g.typ(reflect.TypeOf(v))
+	}
+}
+
+func (g *schemas) fields(f Fields) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema, len(f))}
+	for name, v := range f {
+		prop, err := g.of(v)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		s.Properties[name] = prop
+		s.Required = append(s.Required, name)
+	}
+	sort.Strings(s.Required)
+	return s, nil
+}
+
+func (g *schemas) typ(t reflect.Type) (*Schema, error) {
+	if s, ok := g.types[t]; ok {
+		return s, nil
+	}
+
+	switch {
+	case t == timeType:
+		return DateTime, nil
+	case t == rawMessageType:
+		return Any, nil
+	case t.PkgPath() == "github.com/google/uuid" && t.Name() == "UUID":
+		return UUID, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem, err := g.typ(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(elem), nil
+	case reflect.Interface:
+		return Any, nil
+	}
+
+	// Types that marshal themselves are only known to be strings when they
+	// marshal as text.
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return String, nil
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return Any, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := g.typ(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind() == reflect.Slice {
+			// encoding/json writes a nil slice as null.
+			return &Schema{Type: []string{"array", "null"}, Items: items}, nil
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String && !t.Key().Implements(textMarshalerType) {
+			return nil, fmt.Errorf("map key type %s is not a string", t.Key())
+		}
+		values, err := g.typ(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return g.structType(t)
+	}
+	return nil, fmt.Errorf("type %s cannot be described in JSON", t)
+}
+
+// structType returns a reference to the component of a named struct, or the
+// inline schema of an anonymous one.
+func (g *schemas) structType(t reflect.Type) (*Schema, error) {
+	if t.Name() == "" {
+		return g.object(t)
+	}
+
+	name := componentName(t)
+	if owner, ok := g.owners[name]; ok {
+		if owner != t {
+			return nil, fmt.Errorf("types %s and %s share the component name %q", owner, t, name)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}, nil
+	}
+
+	// Claim the name before describing the fields, so recursive types refer
+	// back to it.
+	g.owners[name] = t
+	s, err := g.object(t)
+	if err != nil {
+		delete(g.owners, name)
+		return nil, err
+	}
+	g.components[name] = s
+	return &Schema{Ref: "#/components/schemas/" + name}, nil
+}
+
+// componentName names a struct after its package and type, as in
+// "dto.CourseResponse".
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	if pkg := path.Base(t.PkgPath()); pkg != "." && pkg != "/" {
+		return pkg + "." + name
+	}
+	return name
+}
+
+func (g *schemas) object(t reflect.Type) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	if err := g.addFields(s, t); err != nil {
+		return nil, err
+	}
+	sort.Strings(s.Required)
+	return s, nil
+}
+
+// addFields adds the JSON fields of struct t to s, following the rules of
+// encoding/json: unexported and "-" fields are skipped and the fields of
+// untagged embedded structs are promoted.
+func (g *schemas) addFields(s *Schema, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := g.addFields(s, ft); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := g.typ(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+		if strings.Contains(opts, "string") {
+			prop = String
+		}
+		required, prop := constrain(prop, f.Tag.Get("validate"))
+		if required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+	return nil
+}
+
+// constrain applies the rules of a validate tag to a field's schema and
+// reports whether the field is required. Rules after "dive" apply to the
+// items of a list.
+func constrain(s *Schema, tag string) (bool, *Schema) {
+	if tag == "" {
+		return false, s
+	}
+	rules := strings.Split(tag, ",")
+	dive := len(rules)
+	for i, rule := range rules {
+		if rule == "dive" {
+			dive = i
+			break
+		}
+	}
+
+	required, optional := false, false
+	for _, rule := range rules[:dive] {
+		switch rule {
+		case "required":
+			required = true
+		case "omitempty":
+			optional = true
+		}
+	}
+
+	s = applyRules(s, rules[:dive], optional)
+	if dive < len(rules) && s.Items != nil {
+		items := *s.Items
+		s.Items = applyRules(&items, rules[dive+1:], slices.Contains(rules[dive+1:], "omitempty"))
+	}
+	return required, s
+}
+
+// applyRules returns a copy of s narrowed by the rules. A validator skips
+// the rules of an empty omitempty field, so for those the empty string stays
+// allowed.
+func applyRules(s *Schema, rules []string, optional bool) *Schema {
+	if s.Ref != "" {
+		return s
+	}
+	out := *s
+	kind := baseType(s)
+	for _, rule := range rules {
+		key, arg, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			if kind == "string" && out.MinLength == nil {
+				out.MinLength = intPtr(1)
+			}
+		case "email":
+			out.Format = "email"
+		case "uuid", "uuid4":
+			out.Format = "uuid"
+		case "url":
+			out.Format = "uri"
+		case "oneof":
+			out.Enum = nil
+			for _, v := range strings.Fields(arg) {
+				out.Enum = append(out.Enum, enumValue(kind, v))
+			}
+			if optional && kind == "string" {
+				out.Enum = append(out.Enum, "")
+			}
+		case "min", "max", "len":
+			n, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				continue
+			}
+			bound(&out, kind, key, n, !optional || kind == "integer" || kind == "number")
+		}
+	}
+	return &out
+}
+
+func bound(s *Schema, kind, rule string, n float64, apply bool) {
+	if !apply {
+		return
+	}
+	lower := rule == "min" || rule == "len"
+	upper := rule == "max" || rule == "len"
+	switch kind {
+	case "string":
+		if lower {
+			s.MinLength = intPtr(int(n))
+		}
+		if upper {
+			s.MaxLength = intPtr(int(n))
+		}
+	case "array":
+		if lower {
+			s.MinItems = intPtr(int(n))
+		}
+		if upper {
+			s.MaxItems = intPtr(int(n))
+		}
+	case "integer", "number":
+		if lower {
+			s.Minimum = &n
+		}
+		if upper {
+			s.Maximum = &n
+		}
+	}
+}
+
+func enumValue(kind, v string) any {
+	switch kind {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+// nullable returns a schema that also accepts null.
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" || s.Type == nil {
+		if s.Type == nil && s.Ref == "" {
+			return s
+		}
+		return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+	}
+	out := *s
+	switch t := s.Type.(type) {
+	case string:
+		out.Type = []string{t, "null"}
+	case []string:
+		for _, name := range t {
+			if name == "null" {
+				return s
+			}
+		}
+		out.Type = append(append([]string(nil), t...), "null")
+	}
+	return &out
+}
+
+// baseType returns the non-null type of a schema, or "" if it has none.
+func baseType(s *Schema) string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []string:
+		for _, name := range t {
+			if name != "null" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func intPtr(n int) *int { return &n }
@@ -0,0 +1,18 @@
+package openapi
+
+import (
+	"github.com/gofiber/fiber/v3"
+)
+
+// Serve returns a handler that writes the document as JSON. The document is
+// encoded once, so it must not change after Serve is called.
+func Serve(doc *Document) fiber.Handler {
+	body, err := doc.JSON()
+	if err != nil {
+		panic("openapi: encoding document: " + err.Error())
+	}
+	return func(c fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(body)
+	}
+}
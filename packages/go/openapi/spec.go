@@ -0,0 +1,344 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Auth is how the caller of a route authenticates.
+type Auth int
+
+const (
+	// AuthUser routes take a user's access token or personal access token
+	// as a bearer token.
+	AuthUser Auth = iota
+	// AuthPublic routes take no credentials.
+	AuthPublic
+	// AuthService routes take a service token minted for another GradeLoop
+	// service.
+	AuthService
+)
+
+// Params maps parameter names to their schemas.
+type Params map[string]*Schema
+
+// Responses maps status codes to response bodies. A nil body is a response
+// without content.
+type Responses map[int]any
+
+// Fields describes a JSON object, such as a fiber.Map, by the values of its
+// fields. Every field is required.
+type Fields map[string]any
+
+// ListOf describes the {key: [...], "count": n} envelope list endpoints
+// return, where items is the list.
+func ListOf(key string, items any) Fields {
+	return Fields{key: items, "count": 0}
+}
+
+// PageOf describes the envelope of a paginated list, which adds the total
+// across pages and the cursor of the next page, null on the last, to ListOf.
+func PageOf(key string, items any) Fields {
+	return Fields{key: items, "count": 0, "total": 0, "next_cursor": (*string)(nil)}
+}
+
+// Binary is a response body, or a request body, that is a file of one of the
+// given media types.
+type Binary []string
+
+// Content is a response body offered in several media types, such as a
+// report as JSON or PDF, mapping each media type to its body. A nil body is
+// a file.
+type Content map[string]any
+
+// Form is an application/x-www-form-urlencoded request body, mapping its
+// fields to their schemas.
+type Form Params
+
+// Upload is a multipart/form-data request body carrying files in the named
+// form fields.
+type Upload []string
+
+// Route describes one route a service registers.
+type Route struct {
+	// Method is the HTTP method, such as fiber.MethodGet.
+	Method string
+	// Path is the route's full path in Fiber syntax, such as
+	// "/api/v1/courses/:id".
+	Path string
+	// Name is the operation ID. It must be unique within the spec.
+	Name    string
+	Summary string
+	Tag     string
+	Auth    Auth
+	// Params gives the schemas of path parameters; unlisted ones are strings.
+	Params Params
+	// Query lists the optional query parameters the route reads.
+	Query Params
+	// Body is the request body: a value of the Go type it is decoded into,
+	// Fields, Form, Upload, Binary or a *Schema.
+	Body any
+	// Responses are the success responses and those errors the handler
+	// writes itself, each a value as for Body, Binary or Content; Spec.Error
+	// describes the rest.
+	Responses Responses
+}
+
+// Spec is the table of routes a service documents.
+type Spec struct {
+	Info    Info
+	Servers []Server
+	// Types overrides the schemas reflected for Go types, such as those with
+	// custom JSON encodings.
+	Types map[reflect.Type]*Schema
+	// Error is the body of error responses.
+	Error  any
+	Routes []Route
+}
+
+var fiberParam = regexp.MustCompile(`:([A-Za-z0-9_]+)\??|\*|\+`)
+
+// PathTemplate converts a Fiber route path to an OpenAPI path template, as
+// in "/courses/:id/" to "/courses/{id}". Wildcards become a parameter named
+// "path".
+func PathTemplate(fiberPath string) string {
+	p := fiberParam.ReplaceAllStringFunc(fiberPath, func(m string) string {
+		if m == "*" || m == "+" {
+			return "{path}"
+		}
+		return "{" + strings.TrimSuffix(m[1:], "?") + "}"
+	})
+	if len(p) > 1 {
+		p = strings.TrimSuffix(p, "/")
+	}
+	return p
+}
+
+// templateParams returns the names of the parameters in a path template.
+func templateParams(template string) []string {
+	var names []string
+	for _, seg := range strings.Split(template, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			names = append(names, seg[1:len(seg)-1])
+		}
+	}
+	return names
+}
+
+// Build returns the OpenAPI document of the spec.
+func (s Spec) Build() (*Document, error) {
+	g := newSchemas(s.Types)
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    s.Info,
+		Servers: s.Servers,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "A user's access token or personal access token.",
+				},
+				"serviceToken": {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "A service token minted for another GradeLoop service.",
+				},
+			},
+		},
+	}
+
+	errSchema, err := g.of(s.Error)
+	if err != nil {
+		return nil, fmt.Errorf("error body: %w", err)
+	}
+
+	names := make(map[string]bool, len(s.Routes))
+	for _, r := range s.Routes {
+		op, err := s.operation(g, r, errSchema)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", r.Method, r.Path, err)
+		}
+		if names[op.OperationID] {
+			return nil, fmt.Errorf("%s %s: duplicate operation name %q", r.Method, r.Path, op.OperationID)
+		}
+		names[op.OperationID] = true
+
+		template := PathTemplate(r.Path)
+		item := doc.Paths[template]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[template] = item
+		}
+		method := strings.ToLower(r.Method)
+		if (*item)[method] != nil {
+			return nil, fmt.Errorf("%s %s: route listed twice", r.Method, r.Path)
+		}
+		(*item)[method] = op
+	}
+
+	doc.Components.Schemas = g.components
+	return doc, nil
+}
+
+// MustBuild is like Build but panics if the spec is invalid.
+func (s Spec) MustBuild() *Document {
+	doc, err := s.Build()
+	if err != nil {
+		panic("openapi: " + err.Error())
+	}
+	return doc
+}
+
+func (s Spec) operation(g *schemas, r Route, errSchema *Schema) (*Operation, error) {
+	if r.Name == "" {
+		return nil, fmt.Errorf("route has no name")
+	}
+	op := &Operation{
+		OperationID: r.Name,
+		Summary:     r.Summary,
+		Responses:   make(map[string]*Response),
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	switch r.Auth {
+	case AuthUser:
+		op.Security = []map[string][]string{{"bearerAuth": {}}}
+	case AuthService:
+		op.Security = []map[string][]string{{"serviceToken": {}}}
+	}
+
+	inPath := templateParams(PathTemplate(r.Path))
+	declared := make(map[string]bool, len(inPath))
+	for _, name := range inPath {
+		declared[name] = true
+		schema := r.Params[name]
+		if schema == nil {
+			schema = String
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	for name := range r.Params {
+		if !declared[name] {
+			return nil, fmt.Errorf("path parameter %q is not in the path", name)
+		}
+	}
+
+	query := make([]string, 0, len(r.Query))
+	for name := range r.Query {
+		query = append(query, name)
+	}
+	sort.Strings(query)
+	for _, name := range query {
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "query", Schema: r.Query[name]})
+	}
+
+	if r.Body != nil {
+		body, err := requestBody(g, r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+		op.RequestBody = body
+	}
+
+	for status, body := range r.Responses {
+		resp, err := response(g, status, body)
+		if err != nil {
+			return nil, fmt.Errorf("%d response: %w", status, err)
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+	}
+	if errSchema != nil {
+		op.Responses["default"] = &Response{
+			Description: "Error",
+			Content:     map[string]*MediaType{"application/json": {Schema: errSchema}},
+		}
+	}
+	return op, nil
+}
+
+func requestBody(g *schemas, body any) (*RequestBody, error) {
+	switch b := body.(type) {
+	case Binary:
+		return &RequestBody{Required: true, Content: binaryContent(b)}, nil
+	case Form:
+		form := &Schema{Type: "object", Properties: make(map[string]*Schema, len(b))}
+		for field, schema := range b {
+			form.Properties[field] = schema
+		}
+		return &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/x-www-form-urlencoded": {Schema: form}},
+		}, nil
+	case Upload:
+		form := &Schema{Type: "object", Properties: make(map[string]*Schema, len(b))}
+		for _, field := range b {
+			form.Properties[field] = &Schema{Type: "string", Format: "binary"}
+			form.Required = append(form.Required, field)
+		}
+		return &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"multipart/form-data": {Schema: form}},
+		}, nil
+	}
+	schema, err := g.of(body)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]*MediaType{"application/json": {Schema: schema}},
+	}, nil
+}
+
+func response(g *schemas, status int, body any) (*Response, error) {
+	desc := http.StatusText(status)
+	if desc == "" {
+		desc = "Status " + strconv.Itoa(status)
+	}
+	resp := &Response{Description: desc}
+	switch b := body.(type) {
+	case nil:
+		return resp, nil
+	case Binary:
+		resp.Content = binaryContent(b)
+		return resp, nil
+	case Content:
+		resp.Content = make(map[string]*MediaType, len(b))
+		for mediaType, body := range b {
+			if body == nil {
+				resp.Content[mediaType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+				continue
+			}
+			schema, err := g.of(body)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", mediaType, err)
+			}
+			resp.Content[mediaType] = &MediaType{Schema: schema}
+		}
+		return resp, nil
+	}
+	schema, err := g.of(body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Content = map[string]*MediaType{"application/json": {Schema: schema}}
+	return resp, nil
+}
+
+func binaryContent(types Binary) map[string]*MediaType {
+	content := make(map[string]*MediaType, len(types))
+	for _, t := range types {
+		content[t] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	return content
+}
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type testCommon struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testCreateRequest struct {
+	Name    string       `json:"name" validate:"required,min=2,max=50"`
+	Email   string       `json:"email" validate:"required,email"`
+	Role    string       `json:"role" validate:"omitempty,oneof=student instructor"`
+	Credits int          `json:"credits" validate:"min=1,max=10"`
+	Tags    []string     `json:"tags" validate:"dive,min=1"`
+	Address *testAddress `json:"address,omitempty"`
+	Secret  string       `json:"-"`
+	hidden  string
+}
+
+type testResponse struct {
+	testCommon
+	Name     string          `json:"name"`
+	Children []testResponse  `json:"children"`
+	Extra    json.RawMessage `json:"extra"`
+	Day      testDate        `json:"day"`
+}
+
+type testDate struct{ time.Time }
+
+func (d testDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(time.DateOnly))
+}
+
+func testSpec() Spec {
+	return Spec{
+		Info:  Info{Title: "Test API", Version: "1.0.0"},
+		Types: map[reflect.Type]*Schema{reflect.TypeOf(testDate{}): Date},
+		Error: Fields{"code": 0, "message": ""},
+		Routes: []Route{
+			{Method: "GET", Path: "/health", Name: "health", Auth: AuthPublic,
+				Responses: Responses{200: Fields{"status": ""}}},
+			{Method: "POST", Path: "/api/v1/things/", Name: "createThing", Tag: "things",
+				Body:      testCreateRequest{},
+				Responses: Responses{201: testResponse{}}},
+			{Method: "GET", Path: "/api/v1/things/:id", Name: "getThing", Tag: "things",
+				Params:    Params{"id": UUID},
+				Query:     Params{"limit": Integer, "active": Boolean},
+				Responses: Responses{200: testResponse{}}},
+			{Method: "GET", Path: "/api/v1/things/search", Name: "searchThings", Tag: "things",
+				Responses: Responses{200: PageOf("things", []testResponse{})}},
+			{Method: "DELETE", Path: "/api/v1/things/:id", Name: "deleteThing", Tag: "things",
+				Params:    Params{"id": UUID},
+				Responses: Responses{204: nil}},
+			{Method: "GET", Path: "/api/v1/internal/things/export", Name: "exportThings", Auth: AuthService,
+				Responses: Responses{200: Content{"application/json": ListOf("things", []testResponse{}), "text/csv": nil}}},
+			{Method: "POST", Path: "/api/v1/things/import", Name: "importThings", Tag: "things",
+				Body:      Upload{"file"},
+				Responses: Responses{200: Fields{"imported": 0}}},
+			{Method: "POST", Path: "/oauth/token", Name: "token", Auth: AuthPublic,
+				Body:      Form{"grant_type": String, "scope": String},
+				Responses: Responses{200: Fields{"access_token": ""}, 302: nil}},
+		},
+	}
+}
+
+func TestBuildReflectsTypes(t *testing.T) {
+	doc, err := testSpec().Build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("expected OpenAPI 3.1.0, got %s", doc.OpenAPI)
+	}
+
+	req := doc.Components.Schemas["openapi.testCreateRequest"]
+	if req == nil {
+		t.Fatalf("request component missing; have %v", keys(doc.Components.Schemas))
+	}
+	if !reflect.DeepEqual(req.Required, []string{"email", "name"}) {
+		t.Errorf("unexpected required fields %v", req.Required)
+	}
+	if _, ok := req.Properties["Secret"]; ok {
+		t.Error(`json:"-" field should be skipped`)
+	}
+	if _, ok := req.Properties["hidden"]; ok {
+		t.Error("unexported field should be skipped")
+	}
+	name := req.Properties["name"]
+	if *name.MinLength != 2 || *name.MaxLength != 50 {
+		t.Errorf("expected name length 2..50, got %v..%v", *name.MinLength, *name.MaxLength)
+	}
+	if req.Properties["email"].Format != "email" {
+		t.Error("expected email format")
+	}
+	if role := req.Properties["role"]; len(role.Enum) != 3 || role.MinLength != nil {
+		t.Errorf("expected an optional role enum, got %+v", role)
+	}
+	if c := req.Properties["credits"]; *c.Minimum != 1 || *c.Maximum != 10 {
+		t.Errorf("expected credits 1..10, got %+v", c)
+	}
+	if tags := req.Properties["tags"]; tags.Items.MinLength == nil || *tags.Items.MinLength != 1 {
+		t.Errorf("expected dive rules on the items, got %+v", tags.Items)
+	}
+	if addr := req.Properties["address"]; len(addr.AnyOf) != 2 || addr.AnyOf[0].Ref != "#/components/schemas/openapi.testAddress" {
+		t.Errorf("expected a nullable reference, got %+v", addr)
+	}
+
+	resp := doc.Components.Schemas["openapi.testResponse"]
+	if resp.Properties["id"] == nil || resp.Properties["created_at"].Format != "date-time" {
+		t.Errorf("expected embedded fields to be promoted, got %v", keys(resp.Properties))
+	}
+	if resp.Properties["children"].Items.Ref != "#/components/schemas/openapi.testResponse" {
+		t.Error("expected a recursive reference")
+	}
+	if resp.Properties["day"] != Date {
+		t.Error("expected the type override to apply")
+	}
+	if resp.Properties["extra"] != Any {
+		t.Error("expected raw JSON to accept anything")
+	}
+
+	get := (*doc.Paths["/api/v1/things/{id}"])["get"]
+	if len(get.Parameters) != 3 || get.Parameters[0].In != "path" || get.Parameters[1].Name != "active" {
+		t.Errorf("unexpected parameters %+v", get.Parameters)
+	}
+	if get.Security[0]["bearerAuth"] == nil {
+		t.Error("expected bearer auth by default")
+	}
+	if get.Responses["default"] == nil {
+		t.Error("expected the error response")
+	}
+	if (*doc.Paths["/health"])["get"].Security != nil {
+		t.Error("public route should have no security")
+	}
+	if (*doc.Paths["/api/v1/things"])["post"] == nil {
+		t.Error("expected the trailing slash to be dropped")
+	}
+	export := (*doc.Paths["/api/v1/internal/things/export"])["get"].Responses["200"].Content
+	if export["text/csv"].Schema.Format != "binary" || export["application/json"].Schema.Type != "object" {
+		t.Errorf("unexpected export content %+v", export)
+	}
+	token := (*doc.Paths["/oauth/token"])["post"]
+	if form := token.RequestBody.Content["application/x-www-form-urlencoded"]; form == nil || len(form.Schema.Properties) != 2 {
+		t.Errorf("unexpected form body %+v", token.RequestBody)
+	}
+	if token.Responses["302"].Content != nil {
+		t.Error("expected a redirect without content")
+	}
+	list := (*doc.Paths["/api/v1/things/search"])["get"].Responses["200"].Content["application/json"].Schema
+	if !reflect.DeepEqual(list.Required, []string{"count", "next_cursor", "things", "total"}) {
+		t.Errorf("unexpected list envelope %+v", list)
+	}
+	if next := list.Properties["next_cursor"]; !reflect.DeepEqual(next.Type, []string{"string", "null"}) {
+		t.Errorf("expected a nullable next_cursor, got %+v", next)
+	}
+}
+
+func TestBuildRejectsInvalidSpecs(t *testing.T) {
+	cases := map[string]Route{
+		"unnamed":        {Method: "GET", Path: "/a"},
+		"unknown param":  {Method: "GET", Path: "/a", Name: "a", Params: Params{"id": UUID}},
+		"duplicate name": {Method: "GET", Path: "/b", Name: "health"},
+		"duplicate path": {Method: "GET", Path: "/health/", Name: "health2"},
+		"bad type":       {Method: "GET", Path: "/c", Name: "c", Responses: Responses{200: make(chan int)}},
+	}
+	for name, route := range cases {
+		spec := Spec{Routes: []Route{{Method: "GET", Path: "/health", Name: "health"}, route}}
+		if _, err := spec.Build(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/":                         "/",
+		"/api/v1/courses/":          "/api/v1/courses",
+		"/courses/:id":              "/courses/{id}",
+		"/members/:batchID/:userID": "/members/{batchID}/{userID}",
+		"/files/:name?":             "/files/{name}",
+		"/docs/*":                   "/docs/{path}",
+	}
+	for in, want := range cases {
+		if got := PathTemplate(in); got != want {
+			t.Errorf("PathTemplate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// AssertRoutesDocumented fails the test when the routes registered on app
+// and the operations of doc disagree, listing every difference found by
+// CheckRoutes.
+func AssertRoutesDocumented(t testing.TB, app *fiber.App, doc *Document) {
+	t.Helper()
+	if problems := CheckRoutes(doc, app.GetRoutes(true)); len(problems) != 0 {
+		t.Errorf("routes and OpenAPI spec disagree; update routes in openapi.go:\n%s", strings.Join(problems, "\n"))
+	}
+}
+
+// AssertDocumentCurrent fails the test when the file at path is not the
+// JSON encoding of doc. With update set the file is rewritten first, which
+// is how services regenerate their docs/openapi.json.
+func AssertDocumentCurrent(t testing.TB, doc *Document, path string, update bool) {
+	t.Helper()
+	got, err := doc.JSON()
+	if err != nil {
+		t.Fatalf("encode spec: %v", err)
+	}
+
+	if update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s is stale; run go test ./internal/router -update", path)
+	}
+}
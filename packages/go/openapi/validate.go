@@ -0,0 +1,424 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Validate returns middleware that rejects requests the document does not
+// allow with 400 Bad Request: path and query parameters of the wrong type or
+// format, and JSON bodies that do not match their schema. Requests to paths
+// the document does not describe, and empty bodies, are passed through for
+// the routes and handlers to deal with.
+func Validate(doc *Document) fiber.Handler {
+	v := newValidator(doc)
+	return func(c fiber.Ctx) error {
+		method := c.Method()
+		if method == fiber.MethodHead {
+			method = fiber.MethodGet
+		}
+		op, params := v.match(method, c.Path())
+		if op == nil {
+			return c.Next()
+		}
+		if err := v.request(c, op, params); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+type validator struct {
+	doc *Document
+	// routes are the documented operations by method, most specific path
+	// first.
+	routes map[string][]templateRoute
+}
+
+type templateRoute struct {
+	segments []string
+	op       *Operation
+}
+
+func newValidator(doc *Document) *validator {
+	v := &validator{doc: doc, routes: make(map[string][]templateRoute)}
+	for template, item := range doc.Paths {
+		segments := splitPath(template)
+		for method, op := range *item {
+			method = strings.ToUpper(method)
+			v.routes[method] = append(v.routes[method], templateRoute{segments: segments, op: op})
+		}
+	}
+	for _, routes := range v.routes {
+		sort.Slice(routes, func(i, j int) bool {
+			return moreSpecific(routes[i].segments, routes[j].segments)
+		})
+	}
+	return v
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{")
+}
+
+// moreSpecific orders templates so that, as in routers that register static
+// paths first, a literal segment wins over a parameter.
+func moreSpecific(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if pa, pb := isParam(a[i]), isParam(b[i]); pa != pb {
+			return !pa
+		}
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) > len(b)
+}
+
+// match finds the operation for a request path and the values of its path
+// parameters.
+func (v *validator) match(method, path string) (*Operation, map[string]string) {
+	segments := splitPath(path)
+	for _, r := range v.routes[method] {
+		if params, ok := matchSegments(r.segments, segments); ok {
+			return r.op, params
+		}
+	}
+	return nil, nil
+}
+
+func matchSegments(template, segments []string) (map[string]string, bool) {
+	params := make(map[string]string)
+	for i, t := range template {
+		if t == "{path}" && i == len(template)-1 {
+			params["path"] = strings.Join(segments[min(i, len(segments)):], "/")
+			return params, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		if isParam(t) {
+			value, err := url.PathUnescape(segments[i])
+			if err != nil {
+				return nil, false
+			}
+			params[t[1:len(t)-1]] = value
+		} else if t != segments[i] {
+			return nil, false
+		}
+	}
+	return params, len(template) == len(segments)
+}
+
+func (v *validator) request(c fiber.Ctx, op *Operation, params map[string]string) error {
+	for _, p := range op.Parameters {
+		var raw string
+		switch p.In {
+		case "path":
+			raw = params[p.Name]
+		case "query":
+			raw = c.Query(p.Name)
+			if raw == "" {
+				continue
+			}
+		default:
+			continue
+		}
+		if err := v.param(raw, p.Schema); err != nil {
+			return fmt.Errorf("invalid %s parameter %s: %w", p.In, p.Name, err)
+		}
+	}
+
+	if op.RequestBody == nil || len(bytes.TrimSpace(c.Body())) == 0 {
+		return nil
+	}
+	media := op.RequestBody.Content[fiber.MIMEApplicationJSON]
+	if media == nil || !isJSON(c.Get(fiber.HeaderContentType)) {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(c.Body()))
+	dec.UseNumber()
+	var body any
+	if err := dec.Decode(&body); err != nil {
+		return fmt.Errorf("invalid request body: malformed JSON")
+	}
+	if err := v.value(body, media.Schema, "body"); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// isJSON reports whether a request's content type allows a JSON body. A
+// missing content type does, since handlers decode JSON regardless.
+func isJSON(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	return mediaType == "" || mediaType == fiber.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+// param checks a path or query parameter, converting it to the type of its
+// schema first.
+func (v *validator) param(raw string, s *Schema) error {
+	s = v.resolve(s)
+	var value any = raw
+	switch baseType(s) {
+	case "integer", "number":
+		value = json.Number(raw)
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return fmt.Errorf("must be a number")
+		}
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+		value = b
+	}
+	return v.value(value, s, "")
+}
+
+func (v *validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = v.doc.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	if s == nil {
+		return Any
+	}
+	return s
+}
+
+// value checks a decoded JSON value against a schema. at names the value in
+// errors.
+func (v *validator) value(value any, s *Schema, at string) error {
+	s = v.resolve(s)
+
+	if len(s.AnyOf) > 0 {
+		var first error
+		for _, alt := range s.AnyOf {
+			err := v.value(value, alt, at)
+			if err == nil {
+				return nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+
+	kind := jsonType(value)
+	if types := schemaTypes(s); len(types) > 0 && !typeAllowed(types, kind) {
+		return fail(at, "must be %s", strings.Join(types, " or "))
+	}
+	if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+		allowed := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			allowed[i] = fmt.Sprint(e)
+		}
+		return fail(at, "must be one of %s", strings.Join(allowed, ", "))
+	}
+
+	switch value := value.(type) {
+	case string:
+		return v.str(value, s, at)
+	case json.Number:
+		n, _ := value.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			return fail(at, "must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return fail(at, "must be at most %v", *s.Maximum)
+		}
+	case []any:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			return fail(at, "must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(value) > *s.MaxItems {
+			return fail(at, "must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range value {
+				if err := v.value(item, s.Items, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]any:
+		return v.object(value, s, at)
+	}
+	return nil
+}
+
+func (v *validator) object(value map[string]any, s *Schema, at string) error {
+	// A required field may not be null either, matching a validator's
+	// "required" rule.
+	for _, name := range s.Required {
+		if value[name] == nil {
+			return fail(field(at, name), "is required")
+		}
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, ok := s.Properties[name]
+		if !ok {
+			prop = s.AdditionalProperties
+		}
+		if prop == nil {
+			continue
+		}
+		if err := v.value(value[name], prop, field(at, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func (v *validator) str(value string, s *Schema, at string) error {
+	length := utf8.RuneCountInString(value)
+	if s.MinLength != nil && length < *s.MinLength {
+		if *s.MinLength == 1 {
+			return fail(at, "must not be empty")
+		}
+		return fail(at, "must be at least %d characters", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return fail(at, "must be at most %d characters", *s.MaxLength)
+	}
+
+	var ok bool
+	switch s.Format {
+	case "uuid":
+		ok = uuidPattern.MatchString(value)
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		ok = err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		ok = err == nil
+	case "email":
+		addr, err := mail.ParseAddress(value)
+		ok = err == nil && addr.Address == value
+	case "uri":
+		u, err := url.Parse(value)
+		ok = err == nil && u.Scheme != "" && u.Host != ""
+	case "byte":
+		_, err := base64.StdEncoding.DecodeString(value)
+		ok = err == nil
+	default:
+		return nil
+	}
+	if !ok {
+		return fail(at, "must be a valid %s", s.Format)
+	}
+	return nil
+}
+
+func fail(at, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if at == "" {
+		return fmt.Errorf("%s", msg)
+	}
+	return fmt.Errorf("%s %s", at, msg)
+}
+
+func field(at, name string) string {
+	if at == "body" {
+		return name
+	}
+	return at + "." + name
+}
+
+func jsonType(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if n, err := value.Float64(); err == nil && n == math.Trunc(n) && !math.IsInf(n, 0) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return ""
+}
+
+func schemaTypes(s *Schema) []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+func typeAllowed(types []string, kind string) bool {
+	for _, t := range types {
+		if t == kind || (t == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func inEnum(value any, enum []any) bool {
+	for _, e := range enum {
+		switch value := value.(type) {
+		case json.Number:
+			n, _ := value.Float64()
+			var want float64
+			switch e := e.(type) {
+			case int64:
+				want = float64(e)
+			case float64:
+				want = e
+			case int:
+				want = float64(e)
+			default:
+				continue
+			}
+			if n == want {
+				return true
+			}
+		default:
+			if value == e {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func newValidatedApp(t *testing.T) *fiber.App {
+	t.Helper()
+	doc, err := testSpec().Build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	app := fiber.New()
+	app.Use(Validate(doc))
+	ok := func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Post("/api/v1/things", ok)
+	app.Get("/api/v1/things/search", ok)
+	app.Get("/api/v1/things/:id", ok)
+	app.Post("/api/v1/things/import", ok)
+	app.Get("/undocumented/:id", ok)
+	return app
+}
+
+func doValidated(t *testing.T, app *fiber.App, method, target, body string) (int, string) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	out, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(out)
+}
+
+func TestValidateParameters(t *testing.T) {
+	app := newValidatedApp(t)
+	id := "7d1f8f36-4a53-4a5e-9a43-3b9a1c2f0e11"
+
+	cases := []struct {
+		target string
+		status int
+		reason string
+	}{
+		{"/api/v1/things/" + id, fiber.StatusOK, ""},
+		{"/api/v1/things/not-a-uuid", fiber.StatusBadRequest, "invalid path parameter id"},
+		{"/api/v1/things/search", fiber.StatusOK, ""},
+		{"/api/v1/things/" + id + "?limit=10&active=true", fiber.StatusOK, ""},
+		{"/api/v1/things/" + id + "?limit=ten", fiber.StatusBadRequest, "invalid query parameter limit"},
+		{"/api/v1/things/" + id + "?active=maybe", fiber.StatusBadRequest, "invalid query parameter active"},
+		{"/undocumented/anything", fiber.StatusOK, ""},
+	}
+	for _, tc := range cases {
+		status, body := doValidated(t, app, http.MethodGet, tc.target, "")
+		if status != tc.status || !strings.Contains(body, tc.reason) {
+			t.Errorf("GET %s: got %d %q, want %d containing %q", tc.target, status, body, tc.status, tc.reason)
+		}
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	app := newValidatedApp(t)
+
+	cases := []struct {
+		body   string
+		status int
+		reason string
+	}{
+		{`{"name":"Ada","email":"ada@example.com","credits":3}`, fiber.StatusOK, ""},
+		{`{"name":"Ada","email":"ada@example.com","role":"","credits":3,"address":null}`, fiber.StatusOK, ""},
+		{`{"email":"ada@example.com"}`, fiber.StatusBadRequest, "name is required"},
+		{`{"name":null,"email":"ada@example.com"}`, fiber.StatusBadRequest, "name is required"},
+		{`{"name":"A","email":"ada@example.com"}`, fiber.StatusBadRequest, "name must be at least 2 characters"},
+		{`{"name":"Ada","email":"not an email"}`, fiber.StatusBadRequest, "email must be a valid email"},
+		{`{"name":"Ada","email":"ada@example.com","role":"admin"}`, fiber.StatusBadRequest, "role must be one of student, instructor, "},
+		{`{"name":"Ada","email":"ada@example.com","credits":2.5}`, fiber.StatusBadRequest, "credits must be integer"},
+		{`{"name":"Ada","email":"ada@example.com","credits":11}`, fiber.StatusBadRequest, "credits must be at most 10"},
+		{`{"name":"Ada","email":"ada@example.com","tags":["a",""]}`, fiber.StatusBadRequest, "tags[1] must not be empty"},
+		{`{"name":"Ada","email":"ada@example.com","address":{}}`, fiber.StatusBadRequest, "address.city is required"},
+		{`{"name":"Ada","email":"ada@example.com","address":"Colombo"}`, fiber.StatusBadRequest, "address must be object"},
+		{`{"name":`, fiber.StatusBadRequest, "malformed JSON"},
+		{`[1, 2]`, fiber.StatusBadRequest, "body must be object"},
+	}
+	for _, tc := range cases {
+		status, body := doValidated(t, app, http.MethodPost, "/api/v1/things", tc.body)
+		if status != tc.status || !strings.Contains(body, tc.reason) {
+			t.Errorf("POST %s: got %d %q, want %d containing %q", tc.body, status, body, tc.status, tc.reason)
+		}
+	}
+
+	// Empty bodies and non-JSON uploads are left to the handlers.
+	if status, _ := doValidated(t, app, http.MethodPost, "/api/v1/things", ""); status != fiber.StatusOK {
+		t.Errorf("expected an empty body to pass through, got %d", status)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/things/import", strings.NewReader("--x--"))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected an upload to pass through, got %d", resp.StatusCode)
+	}
+}
+
+func TestValidatePrefersStaticSegments(t *testing.T) {
+	v := newValidator(testSpec().MustBuild())
+	op, _ := v.match(fiber.MethodGet, "/api/v1/things/search")
+	if op == nil || op.OperationID != "searchThings" {
+		t.Errorf("expected searchThings, got %+v", op)
+	}
+	op, params := v.match(fiber.MethodGet, "/api/v1/things/abc/")
+	if op == nil || op.OperationID != "getThing" || params["id"] != "abc" {
+		t.Errorf("expected getThing with id abc, got %+v %v", op, params)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/4yrg/gradeloop-core-v2/packages/go/pat
+
+go 1.25.0
@@ -0,0 +1,142 @@
+package pat
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValidatePath is IAM's token validation endpoint.
+const ValidatePath = "/api/v1/auth/validate"
+
+// verifierCacheMaxEntries bounds the answer cache. Rejections are cached too,
+// so without a bound anyone could grow it by sending made-up tokens.
+const verifierCacheMaxEntries = 10000
+
+// validateRequest and validateResponse mirror the body and answer of IAM's
+// POST /api/v1/auth/validate.
+type validateRequest struct {
+	Token string `json:"token"`
+}
+
+type validateResponse struct {
+	Valid bool `json:"valid"`
+	Identity
+}
+
+// IAMVerifier verifies personal access tokens with IAM. Answers are cached
+// for ttl, so a revoked token stops working within ttl everywhere. Once the
+// cache is full, each new answer replaces an arbitrary cached one.
+type IAMVerifier struct {
+	baseURL    string
+	httpClient *http.Client
+	ttl        time.Duration
+	maxEntries int
+
+	mu    sync.Mutex
+	cache map[string]verifierEntry
+}
+
+type verifierEntry struct {
+	identity  *Identity // nil when IAM rejected the token
+	expiresAt time.Time
+}
+
+// NewIAMVerifier creates a Verifier backed by the IAM service at baseURL.
+func NewIAMVerifier(baseURL string, ttl time.Duration) *IAMVerifier {
+	return &IAMVerifier{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		ttl:        ttl,
+		maxEntries: verifierCacheMaxEntries,
+		cache:      make(map[string]verifierEntry),
+	}
+}
+
+// Verify implements Verifier.
+func (v *IAMVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	if !IsToken(token) {
+		return nil, ErrInvalidToken
+	}
+
+	key := tokenKey(token)
+	now := time.Now()
+
+	v.mu.Lock()
+	if entry, ok := v.cache[key]; ok && now.Before(entry.expiresAt) {
+		v.mu.Unlock()
+		if entry.identity == nil {
+			return nil, ErrInvalidToken
+		}
+		return entry.identity, nil
+	}
+	v.mu.Unlock()
+
+	body, err := json.Marshal(validateRequest{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.baseURL+ValidatePath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("iam returned status %d", resp.StatusCode)
+	}
+
+	var result validateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	entry := verifierEntry{expiresAt: now.Add(v.ttl)}
+	if result.Valid && result.TokenID != "" {
+		identity := result.Identity
+		entry.identity = &identity
+		if identity.ExpiresAt != nil && identity.ExpiresAt.Before(entry.expiresAt) {
+			entry.expiresAt = *identity.ExpiresAt
+		}
+	}
+
+	v.mu.Lock()
+	if _, ok := v.cache[key]; !ok && len(v.cache) >= v.maxEntries {
+		v.evictOne()
+	}
+	v.cache[key] = entry
+	v.mu.Unlock()
+
+	if entry.identity == nil {
+		return nil, ErrInvalidToken
+	}
+	return entry.identity, nil
+}
+
+// evictOne drops an arbitrary entry; map iteration order is randomized, so
+// the victim is effectively random. It must be called with v.mu held.
+func (v *IAMVerifier) evictOne() {
+	for key := range v.cache {
+		delete(v.cache, key)
+		return
+	}
+}
+
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
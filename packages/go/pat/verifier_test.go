@@ -0,0 +1,151 @@
+package pat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newValidateServer fakes IAM's validate endpoint. Only token is valid; calls
+// counts every request.
+func newValidateServer(t *testing.T, token string, calls *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if r.URL.Path != ValidatePath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req validateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.Token != token {
+			_ = json.NewEncoder(w).Encode(validateResponse{Valid: false})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(validateResponse{
+			Valid: true,
+			Identity: Identity{
+				TokenID:     "tok-1",
+				UserID:      "user-1",
+				Email:       "a@example.com",
+				UserType:    "instructor",
+				Permissions: []string{"assignment:read"},
+				Scopes:      []string{"assignment:read"},
+			},
+		})
+	}))
+}
+
+func TestIAMVerifier_VerifiesAndCaches(t *testing.T) {
+	token, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	var calls int32
+	srv := newValidateServer(t, token, &calls)
+	defer srv.Close()
+
+	v := NewIAMVerifier(srv.URL, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		identity, err := v.Verify(context.Background(), token)
+		if err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+		if identity.UserID != "user-1" || identity.TokenID != "tok-1" {
+			t.Fatalf("unexpected identity %+v", identity)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call to IAM, got %d", calls)
+	}
+}
+
+func TestIAMVerifier_RejectsUnknownToken(t *testing.T) {
+	var calls int32
+	srv := newValidateServer(t, Prefix+"known", &calls)
+	defer srv.Close()
+
+	v := NewIAMVerifier(srv.URL, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := v.Verify(context.Background(), Prefix+"unknown"); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the rejection to be cached, got %d calls", calls)
+	}
+}
+
+func TestIAMVerifier_BoundsCache(t *testing.T) {
+	var calls int32
+	srv := newValidateServer(t, Prefix+"known", &calls)
+	defer srv.Close()
+
+	v := NewIAMVerifier(srv.URL, time.Minute)
+	v.maxEntries = 3
+
+	for i := 0; i < 10; i++ {
+		if _, err := v.Verify(context.Background(), fmt.Sprintf("%sunknown%d", Prefix, i)); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("expected ErrInvalidToken, got %v", err)
+		}
+	}
+	if len(v.cache) != 3 {
+		t.Fatalf("expected the cache to hold 3 entries, got %d", len(v.cache))
+	}
+}
+
+func TestIAMVerifier_SkipsNonTokens(t *testing.T) {
+	var calls int32
+	srv := newValidateServer(t, Prefix+"known", &calls)
+	defer srv.Close()
+
+	v := NewIAMVerifier(srv.URL, time.Minute)
+
+	if _, err := v.Verify(context.Background(), "eyJhbGciOiJIUzI1NiJ9.e30.sig"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no call to IAM, got %d", calls)
+	}
+}
+
+func TestIAMVerifier_ReportsUnavailableIAM(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	v := NewIAMVerifier(srv.URL, time.Minute)
+
+	_, err := v.Verify(context.Background(), Prefix+"any")
+	if err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected a transport error, got %v", err)
+	}
+}
+
+func TestNarrow(t *testing.T) {
+	got := Narrow([]string{"a:read", "a:write", "b:read"}, []string{"a:read", "b:read", "c:read"})
+	if len(got) != 2 || got[0] != "a:read" || got[1] != "b:read" {
+		t.Fatalf("unexpected permissions %v", got)
+	}
+}
+
+func TestCovers(t *testing.T) {
+	if !Covers([]string{"a:read", "b:read"}, []string{"a:read", "b:read", "c:read"}) {
+		t.Error("expected scopes listing every permission to cover them")
+	}
+	if Covers([]string{"a:read", "a:write"}, []string{"a:read"}) {
+		t.Error("expected a narrower scope list not to cover the permissions")
+	}
+}
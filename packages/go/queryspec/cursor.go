@@ -0,0 +1,93 @@
+package queryspec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errBadCursor = errors.New("cursor is invalid or was made for another sort")
+
+// cursor is the decoded form of a page cursor: the sort it was made for and
+// the sort values of the row it continues after.
+type cursor struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+}
+
+func encodeCursor(sort string, values []any) (string, error) {
+	encoded := make([]any, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case time.Time:
+			encoded[i] = v.UTC().Format(time.RFC3339Nano)
+		case uuid.UUID:
+			encoded[i] = v.String()
+		default:
+			encoded[i] = v
+		}
+	}
+	data, err := json.Marshal(cursor{Sort: sort, Values: encoded})
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor returns the values of a cursor, typed by the orders they
+// belong to.
+func decodeCursor(s, sort string, orders []Order) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errBadCursor
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var c cursor
+	if err := dec.Decode(&c); err != nil || c.Sort != sort || len(c.Values) != len(orders) {
+		return nil, errBadCursor
+	}
+
+	values := make([]any, len(orders))
+	for i, o := range orders {
+		v, err := cursorValue(o.kind, c.Values[i])
+		if err != nil {
+			return nil, errBadCursor
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func cursorValue(kind Kind, v any) (any, error) {
+	switch kind {
+	case Int:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, errBadCursor
+		}
+		return n.Int64()
+	case Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errBadCursor
+		}
+		return b, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, errBadCursor
+	}
+	switch kind {
+	case UUID:
+		return uuid.Parse(s)
+	case Time:
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	return s, nil
+}
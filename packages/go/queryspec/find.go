@@ -0,0 +1,149 @@
+package queryspec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Page is one page of a list.
+type Page[T any] struct {
+	Items []T
+	// Total is the number of rows matching the filters across all pages.
+	Total int64
+	// NextCursor continues the list after this page; empty on the last page.
+	NextCursor string
+}
+
+// Meta is the pagination part of a list response. Services embed it in their
+// list envelopes next to the items, so that every list reports the same
+// count, total and next_cursor fields.
+type Meta struct {
+	// Count is the number of items in this page.
+	Count int   `json:"count"`
+	Total int64 `json:"total"`
+	// NextCursor is null on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
+
+// Meta returns the pagination part of the page's response.
+func (p Page[T]) Meta() Meta {
+	m := Meta{Count: len(p.Items), Total: p.Total}
+	if p.NextCursor != "" {
+		next := p.NextCursor
+		m.NextCursor = &next
+	}
+	return m
+}
+
+// Find returns the page of the rows of T that db selects and spec filters,
+// sorts and pages. db carries the conditions every request shares, such as
+// the parent the list belongs to, and must not be ordered or limited. Find
+// leaves db itself unchanged.
+func Find[T any](db *gorm.DB, spec Spec) (Page[T], error) {
+	q := db.Session(&gorm.Session{}).Model(new(T))
+	for _, f := range spec.Filters {
+		q = q.Where(f.expression())
+	}
+	q = q.Session(&gorm.Session{})
+
+	var page Page[T]
+	if err := q.Count(&page.Total).Error; err != nil {
+		return Page[T]{}, err
+	}
+
+	if spec.After != nil {
+		q = q.Where(after(spec.Sort, spec.After))
+	}
+	for _, o := range spec.Sort {
+		q = q.Order(clause.OrderByColumn{Column: clause.Column{Name: o.Column}, Desc: o.Desc})
+	}
+	// One row past the page tells whether another page follows.
+	if err := q.Limit(spec.Limit + 1).Find(&page.Items).Error; err != nil {
+		return Page[T]{}, err
+	}
+	if len(page.Items) <= spec.Limit {
+		return page, nil
+	}
+
+	page.Items = page.Items[:spec.Limit]
+	values, err := sortValues(db, spec.Sort, &page.Items[spec.Limit-1])
+	if err != nil {
+		return Page[T]{}, err
+	}
+	if page.NextCursor, err = encodeCursor(spec.sort, values); err != nil {
+		return Page[T]{}, err
+	}
+	return page, nil
+}
+
+func (f Filter) expression() clause.Expression {
+	column := clause.Column{Name: f.Column}
+	switch f.Op {
+	case Ne:
+		return clause.Neq{Column: column, Value: f.Value}
+	case Gt:
+		return clause.Gt{Column: column, Value: f.Value}
+	case Gte:
+		return clause.Gte{Column: column, Value: f.Value}
+	case Lt:
+		return clause.Lt{Column: column, Value: f.Value}
+	case Lte:
+		return clause.Lte{Column: column, Value: f.Value}
+	case In:
+		return clause.IN{Column: column, Values: f.Value.([]any)}
+	case Contains:
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(f.Value.(string))) + "%"
+		return clause.Expr{SQL: `LOWER(?) LIKE ? ESCAPE '\'`, Vars: []any{column, pattern}}
+	}
+	return clause.Eq{Column: column, Value: f.Value}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// after matches the rows that sort after the row with the given sort values:
+// those past it in the first column, or tied there and past it in the next,
+// and so on.
+func after(orders []Order, values []any) clause.Expression {
+	var or []clause.Expression
+	for i, o := range orders {
+		and := make([]clause.Expression, 0, i+1)
+		for j := range i {
+			and = append(and, clause.Eq{Column: clause.Column{Name: orders[j].Column}, Value: values[j]})
+		}
+		column := clause.Column{Name: o.Column}
+		if o.Desc {
+			and = append(and, clause.Lt{Column: column, Value: values[i]})
+		} else {
+			and = append(and, clause.Gt{Column: column, Value: values[i]})
+		}
+		or = append(or, clause.And(and...))
+	}
+	// A lone OR condition would be joined to the query's other conditions
+	// with OR rather than AND.
+	if len(or) == 1 {
+		return or[0]
+	}
+	return clause.Or(or...)
+}
+
+// sortValues reads the sort columns of row.
+func sortValues[T any](db *gorm.DB, orders []Order, row *T) ([]any, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(row); err != nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(row).Elem()
+	values := make([]any, len(orders))
+	for i, o := range orders {
+		field := stmt.Schema.LookUpField(o.Column)
+		if field == nil {
+			return nil, fmt.Errorf("queryspec: %T has no column %q", *row, o.Column)
+		}
+		values[i], _ = field.ValueOf(db.Statement.Context, rv)
+	}
+	return values, nil
+}
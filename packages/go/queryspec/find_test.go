@@ -0,0 +1,164 @@
+package queryspec
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type testCourse struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OwnerID   uuid.UUID
+	Title     string
+	Credits   int
+	IsActive  bool
+	CreatedAt time.Time
+}
+
+func setupCourses(t *testing.T) (*gorm.DB, uuid.UUID) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := db.AutoMigrate(&testCourse{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	owner := uuid.New()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 7 {
+		// Courses 3 and 4 share a creation time, so the key breaks the tie.
+		hour := i
+		if i == 4 {
+			hour = 3
+		}
+		course := testCourse{
+			ID:        uuid.New(),
+			OwnerID:   owner,
+			Title:     fmt.Sprintf("Course %d", i),
+			Credits:   i % 3,
+			IsActive:  i != 5,
+			CreatedAt: base.Add(time.Duration(hour) * time.Hour),
+		}
+		if err := db.Create(&course).Error; err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	other := testCourse{ID: uuid.New(), OwnerID: uuid.New(), Title: "Elsewhere", CreatedAt: base}
+	if err := db.Create(&other).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	return db, owner
+}
+
+// collect pages through the list, returning the titles in order.
+func collect(t *testing.T, db *gorm.DB, params map[string]string) ([]string, int64) {
+	t.Helper()
+	var (
+		titles []string
+		total  int64
+	)
+	for range 10 {
+		spec, err := testSchema.Parse(params)
+		if err != nil {
+			t.Fatalf("parse %v: %v", params, err)
+		}
+		page, err := Find[testCourse](db, spec)
+		if err != nil {
+			t.Fatalf("find: %v", err)
+		}
+		if len(page.Items) > spec.Limit {
+			t.Fatalf("page of %d exceeds limit %d", len(page.Items), spec.Limit)
+		}
+		for _, c := range page.Items {
+			titles = append(titles, c.Title)
+		}
+		total = page.Total
+		if page.NextCursor == "" {
+			return titles, total
+		}
+		next := map[string]string{"cursor": page.NextCursor}
+		for k, v := range params {
+			if k != "cursor" {
+				next[k] = v
+			}
+		}
+		params = next
+	}
+	t.Fatal("pagination did not end")
+	return nil, 0
+}
+
+func TestFindPagesThroughEveryRow(t *testing.T) {
+	db, owner := setupCourses(t)
+	scoped := db.Where("owner_id = ?", owner)
+
+	titles, total := collect(t, scoped, map[string]string{"sort": "title"})
+	want := []string{"Course 0", "Course 1", "Course 2", "Course 3", "Course 4", "Course 5", "Course 6"}
+	if fmt.Sprint(titles) != fmt.Sprint(want) || total != 7 {
+		t.Errorf("got %v (total %d), want %v (total 7)", titles, total, want)
+	}
+
+	titles, _ = collect(t, scoped, map[string]string{"sort": "-credits,title"})
+	want = []string{"Course 2", "Course 5", "Course 1", "Course 4", "Course 0", "Course 3", "Course 6"}
+	if fmt.Sprint(titles) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", titles, want)
+	}
+
+	// Rows tied on the sort column still each appear exactly once.
+	titles, _ = collect(t, scoped, nil)
+	if len(titles) != 7 {
+		t.Errorf("expected 7 courses in the default sort, got %v", titles)
+	}
+	seen := make(map[string]bool)
+	for _, title := range titles {
+		if seen[title] {
+			t.Errorf("%s listed twice in %v", title, titles)
+		}
+		seen[title] = true
+	}
+}
+
+func TestFindFilters(t *testing.T) {
+	db, owner := setupCourses(t)
+	scoped := db.Where("owner_id = ?", owner)
+
+	cases := []struct {
+		params map[string]string
+		want   []string
+	}{
+		{map[string]string{"active": "false"}, []string{"Course 5"}},
+		{map[string]string{"credits[in]": "0,2", "active": "true"}, []string{"Course 0", "Course 2", "Course 3", "Course 6"}},
+		{map[string]string{"credits[gt]": "1"}, []string{"Course 2", "Course 5"}},
+		{map[string]string{"title[contains]": "SE 4"}, []string{"Course 4"}},
+		{map[string]string{"title[contains]": "%"}, nil},
+		{map[string]string{"created_at[gte]": "2025-01-01T05:00:00Z"}, []string{"Course 5", "Course 6"}},
+	}
+	for _, tc := range cases {
+		params := map[string]string{"sort": "title"}
+		for k, v := range tc.params {
+			params[k] = v
+		}
+		titles, total := collect(t, scoped, params)
+		if fmt.Sprint(titles) != fmt.Sprint(tc.want) || total != int64(len(tc.want)) {
+			t.Errorf("%v: got %v (total %d), want %v", tc.params, titles, total, tc.want)
+		}
+	}
+}
+
+func TestPageMeta(t *testing.T) {
+	last := Page[int]{Items: []int{1, 2}, Total: 2}.Meta()
+	if last.Count != 2 || last.Total != 2 || last.NextCursor != nil {
+		t.Errorf("unexpected last page meta %+v", last)
+	}
+	more := Page[int]{Items: []int{1}, Total: 2, NextCursor: "abc"}.Meta()
+	if more.NextCursor == nil || *more.NextCursor != "abc" {
+		t.Errorf("unexpected meta %+v", more)
+	}
+}
@@ -0,0 +1,48 @@
+package queryspec
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/4yrg/gradeloop-core-v2/packages/go/openapi"
+)
+
+// Params returns the OpenAPI query parameters of a list with the schema,
+// with filter fields documented by their equality form. The request
+// validator checks those; the operator forms are checked by Parse.
+func (s Schema) Params() openapi.Params {
+	lower, upper := 1.0, float64(s.maxLimit())
+	var sortable []string
+	params := openapi.Params{
+		"limit":  {Type: "integer", Minimum: &lower, Maximum: &upper},
+		"cursor": {Type: "string", Description: "The next_cursor of the previous page."},
+	}
+	for name, f := range s.Fields {
+		if f.Sort {
+			sortable = append(sortable, name)
+		}
+		if f.Filter {
+			params[name] = kindSchema(f.Kind)
+		}
+	}
+	sort.Strings(sortable)
+	params["sort"] = &openapi.Schema{
+		Type:        "string",
+		Description: "Comma-separated fields to sort by, each prefixed with - for descending: " + strings.Join(sortable, ", ") + ".",
+	}
+	return params
+}
+
+func kindSchema(kind Kind) *openapi.Schema {
+	switch kind {
+	case Int:
+		return openapi.Integer
+	case Bool:
+		return openapi.Boolean
+	case UUID:
+		return openapi.UUID
+	case Time:
+		return &openapi.Schema{AnyOf: []*openapi.Schema{openapi.DateTime, openapi.Date}}
+	}
+	return openapi.String
+}
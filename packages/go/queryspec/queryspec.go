@@ -0,0 +1,336 @@
+// Package queryspec gives list endpoints cursor pagination, field filters and
+// sorting. A service declares a Schema of the fields a list exposes, parses
+// each request's query string into a Spec, and runs it against a GORM query
+// with Find, which returns one page of rows, the total matching the filters
+// and the cursor of the next page.
+//
+// The query string of a list endpoint takes
+//
+//	limit=50                 page size, up to the schema's MaxLimit
+//	cursor=<next_cursor>     continue after the previous page
+//	sort=-created_at,title   sortable fields, "-" for descending
+//	status=active            a filter field equal to a value
+//	credits[gte]=3           a filter field compared with an operator
+//
+// The operators are eq, ne, gt, gte, lt, lte, in (a comma-separated list) and
+// contains (a case-insensitive substring of a string field). Query keys that
+// are not schema fields are left to the handler.
+package queryspec
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind is the type of a field's values.
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Bool
+	UUID
+	// Time values are RFC 3339 timestamps or YYYY-MM-DD dates.
+	Time
+)
+
+// Field is a field of a list that requests can filter or sort by.
+type Field struct {
+	// Column is the database column; the field's name if empty.
+	Column string
+	Kind   Kind
+	// Filter allows filtering by the field.
+	Filter bool
+	// Sort allows sorting by the field. The column must not be nullable.
+	Sort bool
+}
+
+// Schema declares the fields of a list and its defaults.
+type Schema struct {
+	Fields map[string]Field
+	// Sort is the default sort, as in "-created_at".
+	Sort string
+	// Key is the unique column that orders rows the sort leaves tied; "id"
+	// if empty.
+	Key string
+	// DefaultLimit and MaxLimit bound the page size; 50 and 200 if zero.
+	DefaultLimit int
+	MaxLimit     int
+}
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// Op is a filter operator.
+type Op string
+
+const (
+	Eq       Op = "eq"
+	Ne       Op = "ne"
+	Gt       Op = "gt"
+	Gte      Op = "gte"
+	Lt       Op = "lt"
+	Lte      Op = "lte"
+	In       Op = "in"
+	Contains Op = "contains"
+)
+
+// Filter restricts a list to rows whose column compares with a value. The
+// value of an In filter is a []any.
+type Filter struct {
+	Column string
+	Op     Op
+	Value  any
+}
+
+// Order sorts a list by a column.
+type Order struct {
+	Column string
+	Desc   bool
+	kind   Kind
+}
+
+// Spec is a parsed list request.
+type Spec struct {
+	Limit   int
+	Filters []Filter
+	// Sort ends with the schema's key column, so that it orders every row.
+	Sort []Order
+	// After holds the sort values of the last row of the previous page, or
+	// nil for the first page.
+	After []any
+
+	// sort is the request's sort, which a cursor must have been made for.
+	sort string
+}
+
+// Parse parses the query parameters of a list request. Its errors describe
+// the bad parameter and are meant for the client.
+func (s Schema) Parse(params map[string]string) (Spec, error) {
+	spec := Spec{Limit: s.limit(0)}
+
+	if v, ok := params["limit"]; ok && v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return Spec{}, fmt.Errorf("limit must be a positive integer")
+		}
+		spec.Limit = s.limit(n)
+	}
+
+	sort := params["sort"]
+	if sort == "" {
+		sort = s.Sort
+	}
+	order, canonical, err := s.order(sort)
+	if err != nil {
+		return Spec{}, err
+	}
+	spec.Sort, spec.sort = order, canonical
+
+	for key, value := range params {
+		switch key {
+		case "limit", "sort", "cursor":
+			continue
+		}
+		filter, ok, err := s.filter(key, value)
+		if err != nil {
+			return Spec{}, err
+		}
+		if ok {
+			spec.Filters = append(spec.Filters, filter)
+		}
+	}
+	// Map iteration order is random; keep the generated SQL stable.
+	sortFilters(spec.Filters)
+
+	if cursor := params["cursor"]; cursor != "" {
+		after, err := decodeCursor(cursor, spec.sort, spec.Sort)
+		if err != nil {
+			return Spec{}, err
+		}
+		spec.After = after
+	}
+	return spec, nil
+}
+
+// All returns the spec of an unfiltered first page in the default sort.
+func (s Schema) All() Spec {
+	spec, err := s.Parse(nil)
+	if err != nil {
+		panic("queryspec: invalid default sort: " + err.Error())
+	}
+	return spec
+}
+
+func (s Schema) limit(n int) int {
+	if n == 0 {
+		n = s.DefaultLimit
+		if n == 0 {
+			n = defaultLimit
+		}
+	}
+	return min(n, s.maxLimit())
+}
+
+func (s Schema) maxLimit() int {
+	if s.MaxLimit == 0 {
+		return maxLimit
+	}
+	return s.MaxLimit
+}
+
+func (s Schema) key() string {
+	if s.Key == "" {
+		return "id"
+	}
+	return s.Key
+}
+
+func (s Schema) column(name string, f Field) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// order parses a sort parameter, returning the orders with the key column
+// appended and the sort in canonical form.
+func (s Schema) order(sort string) ([]Order, string, error) {
+	var (
+		orders []Order
+		names  []string
+		seen   = make(map[string]bool)
+	)
+	for _, name := range strings.Split(sort, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		f, ok := s.Fields[name]
+		if !ok || !f.Sort {
+			return nil, "", fmt.Errorf("cannot sort by %q", name)
+		}
+		if seen[name] {
+			return nil, "", fmt.Errorf("sort lists %q twice", name)
+		}
+		seen[name] = true
+		orders = append(orders, Order{Column: s.column(name, f), Desc: desc, kind: f.Kind})
+		if desc {
+			name = "-" + name
+		}
+		names = append(names, name)
+	}
+
+	key := s.key()
+	for _, o := range orders {
+		if o.Column == key {
+			return orders, strings.Join(names, ","), nil
+		}
+	}
+	desc := len(orders) > 0 && orders[len(orders)-1].Desc
+	orders = append(orders, Order{Column: key, Desc: desc, kind: s.keyKind()})
+	return orders, strings.Join(names, ","), nil
+}
+
+func (s Schema) keyKind() Kind {
+	key := s.key()
+	for name, f := range s.Fields {
+		if s.column(name, f) == key {
+			return f.Kind
+		}
+	}
+	return UUID
+}
+
+// filter parses one query parameter, reporting false for keys that are not
+// filters of the schema.
+func (s Schema) filter(key, value string) (Filter, bool, error) {
+	name, op := key, Eq
+	if i := strings.IndexByte(key, '['); i > 0 && strings.HasSuffix(key, "]") {
+		name, op = key[:i], Op(key[i+1:len(key)-1])
+	}
+	f, ok := s.Fields[name]
+	if !ok || !f.Filter {
+		if name != key {
+			return Filter{}, false, fmt.Errorf("cannot filter by %q", name)
+		}
+		return Filter{}, false, nil
+	}
+
+	filter := Filter{Column: s.column(name, f), Op: op}
+	switch op {
+	case Eq, Ne, Gt, Gte, Lt, Lte:
+		v, err := parseValue(f.Kind, value)
+		if err != nil {
+			return Filter{}, false, fmt.Errorf("%s: %w", key, err)
+		}
+		filter.Value = v
+	case In:
+		var values []any
+		for _, part := range strings.Split(value, ",") {
+			v, err := parseValue(f.Kind, strings.TrimSpace(part))
+			if err != nil {
+				return Filter{}, false, fmt.Errorf("%s: %w", key, err)
+			}
+			values = append(values, v)
+		}
+		filter.Value = values
+	case Contains:
+		if f.Kind != String {
+			return Filter{}, false, fmt.Errorf("%s: contains only applies to text fields", key)
+		}
+		filter.Value = value
+	default:
+		return Filter{}, false, fmt.Errorf("%s: unknown operator %q", key, op)
+	}
+	return filter, true, nil
+}
+
+func parseValue(kind Kind, v string) (any, error) {
+	switch kind {
+	case Int:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", v)
+		}
+		return n, nil
+	case Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", v)
+		}
+		return b, nil
+	case UUID:
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a UUID", v)
+		}
+		return id, nil
+	case Time:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date or RFC 3339 time", v)
+		}
+		return t, nil
+	}
+	return v, nil
+}
+
+func sortFilters(filters []Filter) {
+	slices.SortFunc(filters, func(a, b Filter) int {
+		return cmp.Or(strings.Compare(a.Column, b.Column), strings.Compare(string(a.Op), string(b.Op)))
+	})
+}
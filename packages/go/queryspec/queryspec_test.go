@@ -0,0 +1,114 @@
+package queryspec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testSchema = Schema{
+	Fields: map[string]Field{
+		"id":         {Kind: UUID},
+		"title":      {Kind: String, Filter: true, Sort: true},
+		"credits":    {Kind: Int, Filter: true, Sort: true},
+		"active":     {Column: "is_active", Kind: Bool, Filter: true},
+		"created_at": {Kind: Time, Filter: true, Sort: true},
+	},
+	Sort:         "-created_at",
+	DefaultLimit: 2,
+	MaxLimit:     3,
+}
+
+func TestParseDefaults(t *testing.T) {
+	spec, err := testSchema.Parse(map[string]string{"include_inactive": "true"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if spec.Limit != 2 || len(spec.Filters) != 0 || spec.After != nil {
+		t.Errorf("unexpected spec %+v", spec)
+	}
+	want := []Order{{Column: "created_at", Desc: true, kind: Time}, {Column: "id", Desc: true, kind: UUID}}
+	if !reflect.DeepEqual(spec.Sort, want) {
+		t.Errorf("got sort %+v, want %+v", spec.Sort, want)
+	}
+}
+
+func TestParseFiltersAndSort(t *testing.T) {
+	id := uuid.New()
+	spec, err := testSchema.Parse(map[string]string{
+		"limit":            "10",
+		"sort":             "title,-credits",
+		"active":           "false",
+		"credits[in]":      "3, 4",
+		"title[contains]":  "Intro",
+		"created_at[gte]":  "2025-01-31",
+		"id":               id.String(),
+		"include_inactive": "true",
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if spec.Limit != 3 {
+		t.Errorf("expected the limit capped at 3, got %d", spec.Limit)
+	}
+	want := []Filter{
+		{Column: "created_at", Op: Gte, Value: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{Column: "credits", Op: In, Value: []any{int64(3), int64(4)}},
+		{Column: "is_active", Op: Eq, Value: false},
+		{Column: "title", Op: Contains, Value: "Intro"},
+	}
+	if !reflect.DeepEqual(spec.Filters, want) {
+		t.Errorf("got filters %+v, want %+v", spec.Filters, want)
+	}
+	if spec.sort != "title,-credits" || len(spec.Sort) != 3 || spec.Sort[2].Column != "id" || !spec.Sort[2].Desc {
+		t.Errorf("unexpected sort %q %+v", spec.sort, spec.Sort)
+	}
+}
+
+func TestParseRejectsBadParameters(t *testing.T) {
+	cases := map[string]map[string]string{
+		"cannot sort by \"active\"":   {"sort": "active"},
+		"cannot sort by \"missing\"":  {"sort": "missing"},
+		"lists \"title\" twice":       {"sort": "title,-title"},
+		"limit must be":               {"limit": "0"},
+		"cannot filter by \"id\"":     {"id[eq]": uuid.NewString()},
+		"is not an integer":           {"credits": "three"},
+		"is not true or false":        {"active": "yes"},
+		"unknown operator \"like\"":   {"title[like]": "x"},
+		"only applies to text fields": {"credits[contains]": "3"},
+		"is not a date":               {"created_at[lt]": "yesterday"},
+		"cursor is invalid":           {"cursor": "not-a-cursor"},
+	}
+	for want, params := range cases {
+		_, err := testSchema.Parse(params)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: got %v, want an error containing %q", params, err, want)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	spec := testSchema.All()
+	created := time.Date(2025, 3, 1, 9, 30, 0, 123, time.UTC)
+	id := uuid.New()
+	cursor, err := encodeCursor(spec.sort, []any{created, id})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	next, err := testSchema.Parse(map[string]string{"cursor": cursor})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !reflect.DeepEqual(next.After, []any{created, id}) {
+		t.Errorf("got %v, want %v", next.After, []any{created, id})
+	}
+
+	// A cursor only continues the sort it was made for.
+	if _, err := testSchema.Parse(map[string]string{"cursor": cursor, "sort": "title"}); err == nil {
+		t.Error("expected a cursor for another sort to be rejected")
+	}
+}
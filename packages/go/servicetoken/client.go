@@ -0,0 +1,183 @@
+package servicetoken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenPath is IAM's OAuth2 token endpoint, relative to its base URL.
+const TokenPath = "/api/v1/oauth/token"
+
+// refreshSkew renews a cached token this long before it expires so a request
+// never leaves with a token that lapses in flight.
+const refreshSkew = 30 * time.Second
+
+// TokenResponse is the OAuth2 token endpoint's success body.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// ErrorResponse is the OAuth2 token endpoint's error body.
+type ErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// ErrNotConfigured is returned when the client has no credentials.
+var ErrNotConfigured = errors.New("service client credentials not configured")
+
+// Config identifies a service client to IAM.
+type Config struct {
+	// TokenURL is the full URL of IAM's token endpoint.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	// Scopes to request; empty requests every scope the client is registered for.
+	Scopes []string
+}
+
+// Client fetches service tokens with the client-credentials grant and caches
+// them until shortly before they expire. It is safe for concurrent use.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewClient creates a Client for cfg.
+func NewClient(cfg Config) *Client {
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Token returns a valid access token, fetching a new one when the cached token
+// is missing or about to expire.
+func (c *Client) Token(ctx context.Context) (string, error) {
+	if c.cfg.ClientID == "" || c.cfg.ClientSecret == "" {
+		return "", ErrNotConfigured
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Add(refreshSkew).Before(c.expiresAt) {
+		return c.token, nil
+	}
+
+	resp, err := c.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = resp.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	return c.token, nil
+}
+
+// Invalidate drops the cached token, e.g. after a receiver rejected it.
+func (c *Client) Invalidate() {
+	c.mu.Lock()
+	c.token = ""
+	c.expiresAt = time.Time{}
+	c.mu.Unlock()
+}
+
+func (c *Client) fetch(ctx context.Context) (*TokenResponse, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.cfg.ClientID, c.cfg.ClientSecret)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting service token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body ErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, body.Error)
+	}
+
+	var body TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return nil, errors.New("token endpoint returned no access token")
+	}
+	return &body, nil
+}
+
+// Transport returns an http.RoundTripper that authenticates every request with
+// a service token. When the receiver answers 401 the cached token is dropped
+// and the request is retried once with a fresh one.
+func (c *Client) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{client: c, base: base}
+}
+
+type transport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	resp.Body.Close()
+	t.client.Invalidate()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return t.send(retry)
+}
+
+func (t *transport) send(req *http.Request) (*http.Response, error) {
+	token, err := t.client.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	// RoundTrippers must not modify the caller's request.
+	out := req.Clone(req.Context())
+	out.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(out)
+}
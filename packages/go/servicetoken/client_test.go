@@ -0,0 +1,145 @@
+package servicetoken
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("test-secret")
+
+// newTokenServer fakes IAM's token endpoint, issuing real tokens for the
+// "svc"/"pw" client and counting how many were handed out.
+func newTokenServer(t *testing.T, ttl time.Duration, issued *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "svc" || secret != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "invalid_client"})
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(issued, 1)
+		token, _, err := Issue(testSecret, id, []string{ScopeAuditWrite}, ttl)
+		if err != nil {
+			t.Fatalf("issuing token: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(TokenResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   int64(ttl / time.Second),
+			Scope:       ScopeAuditWrite,
+		})
+	}))
+}
+
+func TestClient_CachesToken(t *testing.T) {
+	var issued int32
+	srv := newTokenServer(t, time.Hour, &issued)
+	defer srv.Close()
+
+	client := NewClient(Config{TokenURL: srv.URL, ClientID: "svc", ClientSecret: "pw"})
+
+	first, err := client.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	second, err := client.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+
+	if first != second {
+		t.Error("expected the cached token to be reused")
+	}
+	if issued != 1 {
+		t.Errorf("expected 1 token request, got %d", issued)
+	}
+
+	claims, err := Verify(testSecret, first)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Subject != "svc" || !claims.HasScope(ScopeAuditWrite) {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestClient_RefreshesNearExpiry(t *testing.T) {
+	var issued int32
+	// Shorter than refreshSkew, so every call must fetch a new token.
+	srv := newTokenServer(t, 10*time.Second, &issued)
+	defer srv.Close()
+
+	client := NewClient(Config{TokenURL: srv.URL, ClientID: "svc", ClientSecret: "pw"})
+	for i := 0; i < 2; i++ {
+		if _, err := client.Token(context.Background()); err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+	}
+
+	if issued != 2 {
+		t.Errorf("expected 2 token requests, got %d", issued)
+	}
+}
+
+func TestClient_RejectedCredentials(t *testing.T) {
+	var issued int32
+	srv := newTokenServer(t, time.Hour, &issued)
+	defer srv.Close()
+
+	client := NewClient(Config{TokenURL: srv.URL, ClientID: "svc", ClientSecret: "wrong"})
+	if _, err := client.Token(context.Background()); err == nil {
+		t.Error("expected an error for bad credentials")
+	}
+
+	unconfigured := NewClient(Config{TokenURL: srv.URL})
+	if _, err := unconfigured.Token(context.Background()); err != ErrNotConfigured {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
+
+func TestTransport_RetriesOnceAfterUnauthorized(t *testing.T) {
+	var issued int32
+	srv := newTokenServer(t, time.Hour, &issued)
+	defer srv.Close()
+
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Reject the first call as if the receiver had rotated its view of the token.
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if _, err := Verify(testSecret, r.Header.Get("Authorization")[len("Bearer "):]); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	client := NewClient(Config{TokenURL: srv.URL, ClientID: "svc", ClientSecret: "pw"})
+	httpClient := &http.Client{Transport: client.Transport(nil)}
+
+	resp, err := httpClient.Get(api.URL)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 after retry, got %d", resp.StatusCode)
+	}
+	if calls != 2 || issued != 2 {
+		t.Errorf("expected 2 calls and 2 tokens, got %d calls and %d tokens", calls, issued)
+	}
+}
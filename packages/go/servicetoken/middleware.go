@@ -0,0 +1,33 @@
+package servicetoken
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RequireScopes admits requests bearing a valid service token that grants
+// every one of scopes. User access tokens are rejected. The verified client
+// ID is stored in Locals under LocalsClientID.
+func RequireScopes(secret []byte, scopes ...string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		if !strings.HasPrefix(header, "Bearer ") {
+			return fiber.NewError(fiber.StatusUnauthorized, "missing service token")
+		}
+
+		claims, err := Verify(secret, strings.TrimSpace(strings.TrimPrefix(header, "Bearer ")))
+		if err != nil {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid service token")
+		}
+
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				return fiber.NewError(fiber.StatusForbidden, "service token lacks scope "+scope)
+			}
+		}
+
+		c.Locals(LocalsClientID, claims.Subject)
+		return c.Next()
+	}
+}
@@ -0,0 +1,79 @@
+package servicetoken
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newScopedApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/internal", RequireScopes(testSecret, ScopeEnrollmentRead), func(c fiber.Ctx) error {
+		return c.SendString(c.Locals(LocalsClientID).(string))
+	})
+	return app
+}
+
+func doScopedRequest(t *testing.T, app *fiber.App, token string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/internal", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestRequireScopes(t *testing.T) {
+	app := newScopedApp()
+
+	granted, _, _ := Issue(testSecret, "assessment-service", []string{ScopeEnrollmentRead, ScopeAuditWrite}, time.Minute)
+	if status := doScopedRequest(t, app, granted); status != fiber.StatusOK {
+		t.Errorf("expected 200 with the scope, got %d", status)
+	}
+
+	lacking, _, _ := Issue(testSecret, "cipas-service", []string{ScopeScoresRead}, time.Minute)
+	if status := doScopedRequest(t, app, lacking); status != fiber.StatusForbidden {
+		t.Errorf("expected 403 without the scope, got %d", status)
+	}
+
+	expired, _, _ := Issue(testSecret, "assessment-service", []string{ScopeEnrollmentRead}, -time.Minute)
+	if status := doScopedRequest(t, app, expired); status != fiber.StatusUnauthorized {
+		t.Errorf("expected 401 for an expired token, got %d", status)
+	}
+
+	forged, _, _ := Issue([]byte("other-secret"), "assessment-service", []string{ScopeEnrollmentRead}, time.Minute)
+	if status := doScopedRequest(t, app, forged); status != fiber.StatusUnauthorized {
+		t.Errorf("expected 401 for a token signed with another key, got %d", status)
+	}
+
+	if status := doScopedRequest(t, app, ""); status != fiber.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", status)
+	}
+}
+
+func TestRequireScopes_RejectsUserTokens(t *testing.T) {
+	app := newScopedApp()
+
+	// A user access token signed with the same secret but without token_use.
+	userToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "u-1",
+		"sub":     "u-1",
+		"scope":   ScopeEnrollmentRead,
+		"exp":     time.Now().Add(time.Minute).Unix(),
+	}).SignedString(testSecret)
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+
+	if status := doScopedRequest(t, app, userToken); status != fiber.StatusUnauthorized {
+		t.Errorf("expected 401 for a user token, got %d", status)
+	}
+}